@@ -27,6 +27,8 @@ func New(opts *config.Options) (api.AriesController, error) {
 		return nil, err
 	}
 
+	log.SetRedactionPolicy(config.RedactionPolicy(opts))
+
 	if !opts.UseLocalAgent {
 		return rest.NewAries(opts)
 	}
@@ -12,4 +12,13 @@ import "github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wra
 type JSONLDContextController interface {
 	// AddContext adds JSON-LD contexts to the underlying storage.
 	AddContext(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// ListContexts lists the JSON-LD contexts of the underlying storage.
+	ListContexts(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RefreshContext loads a JSON-LD context from its remote URL again.
+	RefreshContext(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RemoveContext removes a JSON-LD context from the underlying storage.
+	RemoveContext(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
@@ -16,6 +16,9 @@ type VerifiableController interface {
 	// ValidateCredential validates the verifiable credential.
 	ValidateCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
 
+	// VerifyCredential verifies the verifiable credential against the verification policy.
+	VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
+
 	// SaveCredential saves the verifiable credential to the store.
 	SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope
 
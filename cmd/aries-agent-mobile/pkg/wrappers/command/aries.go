@@ -71,7 +71,7 @@ func NewAries(opts *config.Options) (*Aries, error) {
 	notifications := make(chan notifier.NotificationPayload)
 
 	commandHandlers, err := controller.GetCommandHandlers(context,
-		controller.WithNotifier(notifier.NewNotifier(notifications)),
+		controller.WithNotifier(notifier.NewNotifier(notifications, config.RedactionPolicy(opts))),
 		controller.WithAutoAccept(opts.AutoAccept),
 	)
 	if err != nil {
@@ -150,7 +150,7 @@ func (de *DIDExchange) CreateConnection(request *models.RequestEnvelope) *models
 
 // RemoveConnection removes given connection record.
 func (de *DIDExchange) RemoveConnection(request *models.RequestEnvelope) *models.ResponseEnvelope {
-	args := cmddidexch.ConnectionIDArg{}
+	args := cmddidexch.RemoveConnectionRequest{}
 
 	if err := json.Unmarshal(request.Payload, &args); err != nil {
 		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
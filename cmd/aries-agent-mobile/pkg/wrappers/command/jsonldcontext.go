@@ -34,3 +34,45 @@ func (c *JSONLDContext) AddContext(request *models.RequestEnvelope) *models.Resp
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// ListContexts lists the JSON-LD contexts of the underlying storage.
+func (c *JSONLDContext) ListContexts(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(c.handlers[context.ListContextsCommandMethod], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RefreshContext loads a JSON-LD context from its remote URL again.
+func (c *JSONLDContext) RefreshContext(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := context.RefreshRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(c.handlers[context.RefreshContextCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RemoveContext removes a JSON-LD context from the underlying storage.
+func (c *JSONLDContext) RemoveContext(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := context.RemoveRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(c.handlers[context.RemoveContextCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
@@ -35,6 +35,22 @@ func (v *Verifiable) ValidateCredential(request *models.RequestEnvelope) *models
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// VerifyCredential verifies the verifiable credential against the verification policy.
+func (v *Verifiable) VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdverifiable.VerifyCredentialRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(v.handlers[cmdverifiable.VerifyCredentialCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (v *Verifiable) SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	args := cmdverifiable.CredentialExt{}
@@ -123,6 +123,27 @@ func TestVerifiable_ValidateCredential(t *testing.T) {
 	})
 }
 
+func TestVerifiable_VerifyCredential(t *testing.T) {
+	t.Run("test it verifies a credential", func(t *testing.T) {
+		v := getVerifiableController(t)
+
+		mockResponse := `{"report":{"verified":true,"checks":[{"rule":"proof","passed":true}]}}`
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		v.handlers[cmdverifiable.VerifyCredentialCommandMethod] = fakeHandler.exec
+
+		payload := fmt.Sprintf(`{"verifiableCredential": %s, "policy": {"checkValidityPeriod": true}}`,
+			strconv.Quote(mockVC))
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := v.VerifyCredential(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
 func TestVerifiable_SaveCredential(t *testing.T) {
 	t.Run("test it saves a credential", func(t *testing.T) {
 		v := getVerifiableController(t)
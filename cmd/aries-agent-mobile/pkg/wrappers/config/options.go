@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package config
 
-import "github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
+import (
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/api"
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
+)
 
 // Options represents configurations for Aries.
 type Options struct {
@@ -19,12 +22,15 @@ type Options struct {
 	LogLevel             string
 	Logger               api.LoggerProvider
 	Storage              api.Provider
+	// DisableRedaction disables masking the sensitive members of the messages in logs and notifications.
+	DisableRedaction bool
 
 	// expected to be ignored by gomobile
 	// not intended to be used by golang code
 	HTTPResolvers     []string
 	OutboundTransport []string
 	WebsocketURL      string
+	RedactionPaths    []string
 }
 
 // New returns an instance of Options which can be used to configure an aries controller instance.
@@ -41,3 +47,18 @@ func (o *Options) AddHTTPResolver(resolverURL string) {
 func (o *Options) AddOutboundTransport(transportType string) {
 	o.OutboundTransport = append(o.OutboundTransport, transportType)
 }
+
+// AddRedactionPath appends a JSON path of the members masked in logs and notifications, in addition to
+// redact.DefaultPaths, e.g. credentialSubject.* or $.proof.jws.
+func (o *Options) AddRedactionPath(path string) {
+	o.RedactionPaths = append(o.RedactionPaths, path)
+}
+
+// RedactionPolicy returns the redaction policy configured by o, or nil if redaction is disabled.
+func RedactionPolicy(o *Options) *redact.Policy {
+	if o.DisableRedaction {
+		return nil
+	}
+
+	return redact.DefaultPolicy(redact.WithPaths(o.RedactionPaths...))
+}
@@ -10,6 +10,7 @@ package notifier
 import (
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 )
 
@@ -22,16 +23,18 @@ type NotificationPayload struct {
 // Notifier is implementation for mobile.
 type Notifier struct {
 	connection chan<- NotificationPayload
+	policy     *redact.Policy
 }
 
-// NewNotifier return notifier instance (for mobile).
-func NewNotifier(connection chan<- NotificationPayload) *Notifier {
-	return &Notifier{connection: connection}
+// NewNotifier return notifier instance (for mobile). The messages are redacted with policy, a nil policy disables
+// redaction.
+func NewNotifier(connection chan<- NotificationPayload, policy *redact.Policy) *Notifier {
+	return &Notifier{connection: connection, policy: policy}
 }
 
-// Notify sends the given message to the subscribers.
+// Notify sends the given message, redacted with the redaction policy, to the subscribers.
 func (n *Notifier) Notify(topic string, message []byte) error {
-	msg, err := webnotifier.PrepareTopicMessage(topic, message)
+	msg, err := webnotifier.PrepareTopicMessage(topic, n.policy.JSON(message))
 	if err != nil {
 		return fmt.Errorf("prepare topic message: %w", err)
 	}
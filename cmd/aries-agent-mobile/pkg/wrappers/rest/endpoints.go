@@ -112,6 +112,10 @@ func getVerifiableEndpoints() map[string]*endpoint {
 			Path:   opverifiable.ValidateCredentialPath,
 			Method: http.MethodPost,
 		},
+		cmdverifiable.VerifyCredentialCommandMethod: {
+			Path:   opverifiable.VerifyCredentialPath,
+			Method: http.MethodPost,
+		},
 		cmdverifiable.SaveCredentialCommandMethod: {
 			Path:   opverifiable.SaveCredentialPath,
 			Method: http.MethodPost,
@@ -445,5 +449,17 @@ func getJSONLDContextEndpoints() map[string]*endpoint {
 			Path:   opjsonldcontext.AddContextPath,
 			Method: http.MethodPost,
 		},
+		cmdjsonldcontext.ListContextsCommandMethod: {
+			Path:   opjsonldcontext.ListContextsPath,
+			Method: http.MethodGet,
+		},
+		cmdjsonldcontext.RefreshContextCommandMethod: {
+			Path:   opjsonldcontext.RefreshContextPath,
+			Method: http.MethodPost,
+		},
+		cmdjsonldcontext.RemoveContextCommandMethod: {
+			Path:   opjsonldcontext.RemoveContextPath,
+			Method: http.MethodPost,
+		},
 	}
 }
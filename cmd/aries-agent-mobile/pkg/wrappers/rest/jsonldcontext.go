@@ -25,6 +25,21 @@ func (c *JSONLDContext) AddContext(request *models.RequestEnvelope) *models.Resp
 	return c.createRespEnvelope(request, context.AddContextCommandMethod)
 }
 
+// ListContexts lists the JSON-LD contexts of the underlying storage.
+func (c *JSONLDContext) ListContexts(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return c.createRespEnvelope(request, context.ListContextsCommandMethod)
+}
+
+// RefreshContext loads a JSON-LD context from its remote URL again.
+func (c *JSONLDContext) RefreshContext(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return c.createRespEnvelope(request, context.RefreshContextCommandMethod)
+}
+
+// RemoveContext removes a JSON-LD context from the underlying storage.
+func (c *JSONLDContext) RemoveContext(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return c.createRespEnvelope(request, context.RemoveContextCommandMethod)
+}
+
 func (c *JSONLDContext) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        c.URL,
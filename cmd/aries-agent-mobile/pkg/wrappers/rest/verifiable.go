@@ -25,6 +25,11 @@ func (vr *Verifiable) ValidateCredential(request *models.RequestEnvelope) *model
 	return vr.createRespEnvelope(request, cmdverifiable.ValidateCredentialCommandMethod)
 }
 
+// VerifyCredential verifies the verifiable credential against the verification policy.
+func (vr *Verifiable) VerifyCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return vr.createRespEnvelope(request, cmdverifiable.VerifyCredentialCommandMethod)
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (vr *Verifiable) SaveCredential(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	return vr.createRespEnvelope(request, cmdverifiable.SaveCredentialCommandMethod)
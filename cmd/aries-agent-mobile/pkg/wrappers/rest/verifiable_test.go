@@ -134,6 +134,27 @@ func TestVerifiable_ValidateCredential(t *testing.T) {
 	})
 }
 
+func TestVerifiable_VerifyCredential(t *testing.T) {
+	t.Run("test it performs a verify credential request", func(t *testing.T) {
+		v := getVerifiableController(t)
+
+		mockResponse := `{"report":{"verified":true,"checks":[{"rule":"proof","passed":true}]}}`
+		v.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opverifiable.VerifyCredentialPath,
+		}
+
+		reqData := fmt.Sprintf(`{"verifiableCredential": %s, "policy": {"checkValidityPeriod": true}}`,
+			strconv.Quote(mockVC))
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := v.VerifyCredential(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
 func TestVerifiable_SaveCredential(t *testing.T) {
 	t.Run("test it performs a save credential request", func(t *testing.T) {
 		v := getVerifiableController(t)
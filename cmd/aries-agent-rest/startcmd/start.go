@@ -6,13 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	"context"
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -23,16 +28,20 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/guard"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/shared"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/ws"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
-	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
-	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -162,22 +171,125 @@ const (
 		"RFC0593-compliant attachment formats. Default is false." +
 		" Alternatively, this can be set with the following environment variable: " + agentAutoExecuteRFC0593EnvKey
 
+	// did:web document directory flag.
+	agentDIDWebDirFlagName  = "did-web-dir"
+	agentDIDWebDirEnvKey    = "ARIESD_DID_WEB_DIR"
+	agentDIDWebDirFlagUsage = "Directory to write did:web documents created by the agent to, one subdirectory per host." +
+		" When set, the documents are also hosted on the api host under /.well-known/did.json and /<path>/did.json." +
+		" Alternatively, this can be set with the following environment variable: " + agentDIDWebDirEnvKey
+
+	// OTLP exporter flag.
+	agentOTLPEndpointFlagName  = "otlp-endpoint"
+	agentOTLPEndpointEnvKey    = "ARIESD_OTLP_ENDPOINT"
+	agentOTLPEndpointFlagUsage = "OTLP/HTTP endpoint of the OpenTelemetry collector to export the DIDComm traces to" +
+		" (for example http://localhost:4318). Traces are not recorded if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentOTLPEndpointEnvKey
+
+	// redaction flags.
+	agentRedactionPathsFlagName  = "redaction-paths"
+	agentRedactionPathsEnvKey    = "ARIESD_REDACTION_PATHS"
+	agentRedactionPathsFlagUsage = "JSON paths of the message members to mask in logs and notifications," +
+		" in addition to credentialSubject.*, proof.jws, proof.proofValue and the private JWK members" +
+		" (for example $.attributes.ssn). A path matches at any depth unless it starts with $." +
+		" This flag can be repeated, allowing to configure multiple paths." +
+		" Alternatively, this can be set with the following environment variable: " + agentRedactionPathsEnvKey
+
+	agentRedactionDisabledFlagName  = "redaction-disabled"
+	agentRedactionDisabledEnvKey    = "ARIESD_REDACTION_DISABLED"
+	agentRedactionDisabledFlagUsage = "Disables masking sensitive data in logs and notifications." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentRedactionDisabledEnvKey
+
+	// inbound guard flags.
+	agentInboundMaxEnvelopeSizeFlagName  = "inbound-max-envelope-size"
+	agentInboundMaxEnvelopeSizeEnvKey    = "ARIESD_INBOUND_MAX_ENVELOPE_SIZE"
+	agentInboundMaxEnvelopeSizeFlagUsage = "Maximum size in bytes of the envelopes accepted by the inbound transports." +
+		" Unlimited if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentInboundMaxEnvelopeSizeEnvKey
+
+	agentInboundIPRateLimitFlagName  = "inbound-ip-rate-limit"
+	agentInboundIPRateLimitEnvKey    = "ARIESD_INBOUND_IP_RATE_LIMIT"
+	agentInboundIPRateLimitFlagUsage = "Rate limit of the messages received from each IP address by the inbound" +
+		" transports, as RATE[:BURST] where RATE is the number of messages per second and BURST the number of" +
+		" messages accepted at once (for example 10:20). Unlimited if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentInboundIPRateLimitEnvKey
+
+	agentInboundRecipientRateLimitFlagName  = "inbound-recipient-rate-limit"
+	agentInboundRecipientRateLimitEnvKey    = "ARIESD_INBOUND_RECIPIENT_RATE_LIMIT"
+	agentInboundRecipientRateLimitFlagUsage = "Rate limit of the messages received for each recipient key by the" +
+		" inbound transports, as RATE[:BURST]. Unlimited if not set." +
+		" Alternatively, this can be set with the following environment variable: " +
+		agentInboundRecipientRateLimitEnvKey
+
+	agentInboundMaxUnpacksFlagName  = "inbound-max-concurrent-unpacks"
+	agentInboundMaxUnpacksEnvKey    = "ARIESD_INBOUND_MAX_CONCURRENT_UNPACKS"
+	agentInboundMaxUnpacksFlagUsage = "Maximum number of envelopes unpacked concurrently by the inbound transports," +
+		" the envelopes received meanwhile being rejected. Unlimited if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentInboundMaxUnpacksEnvKey
+
+	agentInboundRejectUnknownFlagName  = "inbound-reject-unknown-recipients"
+	agentInboundRejectUnknownEnvKey    = "ARIESD_INBOUND_REJECT_UNKNOWN_RECIPIENTS"
+	agentInboundRejectUnknownFlagUsage = "Rejects the envelopes whose recipient keys are not owned by the agent" +
+		" before unpacking them. Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentInboundRejectUnknownEnvKey
+
+	// shutdown timeout flag.
+	agentShutdownTimeoutFlagName  = "shutdown-timeout"
+	agentShutdownTimeoutEnvKey    = "ARIESD_SHUTDOWN_TIMEOUT"
+	agentShutdownTimeoutFlagUsage = "Time to wait on SIGTERM or SIGINT for the in-flight requests and messages to" +
+		" complete before the agent exits (for example 30s). Defaults to 10s if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentShutdownTimeoutEnvKey
+
+	// multi-tenant flags.
+	agentMultiTenantFlagName  = "multi-tenant"
+	agentMultiTenantEnvKey    = "ARIESD_MULTI_TENANT"
+	agentMultiTenantFlagUsage = "Hosts several tenant agents sharing the inbound transports and the database, each" +
+		" tenant having its own store namespaces, KMS keystore, secret lock and webhooks. The tenants are managed" +
+		" with the admin API under " + adminTenantsPath + ", protected by the api token which is then required." +
+		" The other REST calls are routed to a tenant by its API key, in the " + tenantAPIKeyHeader + " header, or by" +
+		" the " + tenantIDClaim + " claim of a JWT bearer token signed with the tenant JWT secret." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentMultiTenantEnvKey
+
+	agentTenantJWTSecretFlagName  = "tenant-jwt-secret"
+	agentTenantJWTSecretEnvKey    = "ARIESD_TENANT_JWT_SECRET" // nolint:gosec
+	agentTenantJWTSecretFlagUsage = "Secret of the HS256 JWT bearer tokens routing the REST calls to a tenant in" +
+		" multi-tenant mode. The tenants are routed by API key only if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentTenantJWTSecretEnvKey
+
+	agentTenantLockPassphraseFlagName  = "tenant-lock-passphrase"
+	agentTenantLockPassphraseEnvKey    = "ARIESD_TENANT_LOCK_PASSPHRASE" // nolint:gosec
+	agentTenantLockPassphraseFlagUsage = "Passphrase protecting the master keys of the secret locks of the tenants in" +
+		" multi-tenant mode, required in this mode." +
+		" Alternatively, this can be set with the following environment variable: " + agentTenantLockPassphraseEnvKey
+
+	metricsPath        = "/metrics"
+	tracingServiceName = "aries-agent-rest"
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
+	defaultShutdownTimeout = 10 * time.Second
+
 	databaseTypeMemOption     = "mem"
 	databaseTypeLevelDBOption = "leveldb"
 )
 
 var (
 	errMissingHost = errors.New("host not provided")
+	errAdminToken  = errors.New("api token is required in multi-tenant mode to protect the admin API")
 	logger         = log.New("aries-framework/agent-rest")
+
+	// shutdownSignals are the signals on which the agent is shut down gracefully.
+	shutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT} // nolint:gochecknoglobals
 )
 
 type agentParameters struct {
 	server                                         server
 	host, defaultLabel, transportReturnRoute       string
 	tlsCertFile, tlsKeyFile                        string
+	didWebDir                                      string
+	otlpEndpoint                                   string
 	token                                          string
 	webhookURLs, httpResolvers, outboundTransports []string
 	inboundHostInternals, inboundHostExternals     []string
@@ -185,6 +297,11 @@ type agentParameters struct {
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
 	autoExecuteRFC0593                             bool
+	redactionPolicy                                *redact.Policy
+	inboundGuard                                   *guard.Guard
+	shutdownTimeout                                time.Duration
+	multiTenant                                    bool
+	tenantJWTSecret, tenantLockPassphrase          string
 }
 
 type dbParam struct {
@@ -207,16 +324,52 @@ type server interface {
 	ListenAndServe(host string, router http.Handler, certFile, keyFile string) error
 }
 
+// gracefulServer is implemented by the servers which can be shut down without interrupting the active requests,
+// ListenAndServe then returning nil.
+type gracefulServer interface {
+	Shutdown(ctx context.Context) error
+}
+
 // HTTPServer represents an actual server implementation.
-type HTTPServer struct{}
+type HTTPServer struct {
+	mu  sync.Mutex
+	srv *http.Server
+}
 
 // ListenAndServe starts the server using the standard Go HTTP server implementation.
 func (s *HTTPServer) ListenAndServe(host string, router http.Handler, certFile, keyFile string) error {
+	srv := &http.Server{Addr: host, Handler: router}
+
+	s.mu.Lock()
+	s.srv = srv
+	s.mu.Unlock()
+
+	var err error
+
 	if certFile != "" && keyFile != "" {
-		return http.ListenAndServeTLS(host, certFile, keyFile, router)
+		err = srv.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops the server, waiting for the active requests to complete or for ctx to be done.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	srv := s.srv
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
 	}
 
-	return http.ListenAndServe(host, router)
+	return srv.Shutdown(ctx)
 }
 
 // Cmd returns the Cobra start command.
@@ -281,7 +434,25 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
-			webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey, autoAccept)
+			multiTenant, err := getMultiTenant(cmd)
+			if err != nil {
+				return err
+			}
+
+			// the tenants have their own webhooks
+			webhookURLs, err := getUserSetVars(cmd, agentWebhookFlagName, agentWebhookEnvKey,
+				autoAccept || multiTenant)
+			if err != nil {
+				return err
+			}
+
+			tenantJWTSecret, err := getUserSetVar(cmd, agentTenantJWTSecretFlagName, agentTenantJWTSecretEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			tenantLockPassphrase, err := getUserSetVar(cmd, agentTenantLockPassphraseFlagName,
+				agentTenantLockPassphraseEnvKey, true)
 			if err != nil {
 				return err
 			}
@@ -318,6 +489,31 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				return err
 			}
 
+			didWebDir, err := getUserSetVar(cmd, agentDIDWebDirFlagName, agentDIDWebDirEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			otlpEndpoint, err := getUserSetVar(cmd, agentOTLPEndpointFlagName, agentOTLPEndpointEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			redactionPolicy, err := getRedactionPolicy(cmd)
+			if err != nil {
+				return err
+			}
+
+			inboundGuard, err := getInboundGuard(cmd)
+			if err != nil {
+				return err
+			}
+
+			shutdownTimeout, err := getShutdownTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
 			parameters := &agentParameters{
 				server:               server,
 				host:                 host,
@@ -334,6 +530,14 @@ func createStartCMD(server server) *cobra.Command { //nolint: funlen,gocyclo,goc
 				tlsCertFile:          tlsCertFile,
 				tlsKeyFile:           tlsKeyFile,
 				autoExecuteRFC0593:   autoExecuteRFC0593,
+				didWebDir:            didWebDir,
+				otlpEndpoint:         otlpEndpoint,
+				redactionPolicy:      redactionPolicy,
+				inboundGuard:         inboundGuard,
+				shutdownTimeout:      shutdownTimeout,
+				multiTenant:          multiTenant,
+				tenantJWTSecret:      tenantJWTSecret,
+				tenantLockPassphrase: tenantLockPassphrase,
 			}
 
 			return startAgent(parameters)
@@ -388,6 +592,20 @@ func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(v)
 }
 
+func getMultiTenant(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentMultiTenantFlagName, agentMultiTenantEnvKey, true)
+	if err != nil || v == "" {
+		return false, err
+	}
+
+	multiTenant, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", agentMultiTenantFlagName, err)
+	}
+
+	return multiTenant, nil
+}
+
 func getAutoExecuteRFC0593(cmd *cobra.Command) (bool, error) {
 	autoExecuteRFC0593Str, err := getUserSetVar(cmd, agentAutoExecuteRFC0593FlagName,
 		agentAutoExecuteRFC0593EnvKey, true)
@@ -402,6 +620,143 @@ func getAutoExecuteRFC0593(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(autoExecuteRFC0593Str)
 }
 
+func getRedactionPolicy(cmd *cobra.Command) (*redact.Policy, error) {
+	disabled, err := getUserSetVar(cmd, agentRedactionDisabledFlagName, agentRedactionDisabledEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if disabled != "" {
+		isDisabled, errParse := strconv.ParseBool(disabled)
+		if errParse != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", agentRedactionDisabledFlagName, errParse)
+		}
+
+		if isDisabled {
+			return redact.NewPolicy(), nil
+		}
+	}
+
+	paths, err := getUserSetVars(cmd, agentRedactionPathsFlagName, agentRedactionPathsEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return redact.DefaultPolicy(redact.WithPaths(paths...)), nil
+}
+
+// getInboundGuard returns the guard of the inbound transports, nil if no limit is set.
+func getInboundGuard(cmd *cobra.Command) (*guard.Guard, error) { //nolint:gocyclo
+	var opts []guard.Opt
+
+	maxSize, err := getUserSetVar(cmd, agentInboundMaxEnvelopeSizeFlagName, agentInboundMaxEnvelopeSizeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize != "" {
+		size, errParse := strconv.ParseInt(maxSize, 10, 64)
+		if errParse != nil || size < 0 {
+			return nil, fmt.Errorf("invalid %s [%s]", agentInboundMaxEnvelopeSizeFlagName, maxSize)
+		}
+
+		opts = append(opts, guard.WithMaxEnvelopeSize(size))
+	}
+
+	ipRate, ipBurst, err := getRateLimit(cmd, agentInboundIPRateLimitFlagName, agentInboundIPRateLimitEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ipRate > 0 {
+		opts = append(opts, guard.WithSourceRateLimit(ipRate, ipBurst))
+	}
+
+	recipientRate, recipientBurst, err := getRateLimit(cmd, agentInboundRecipientRateLimitFlagName,
+		agentInboundRecipientRateLimitEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipientRate > 0 {
+		opts = append(opts, guard.WithRecipientRateLimit(recipientRate, recipientBurst))
+	}
+
+	maxUnpacks, err := getUserSetVar(cmd, agentInboundMaxUnpacksFlagName, agentInboundMaxUnpacksEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxUnpacks != "" {
+		n, errParse := strconv.Atoi(maxUnpacks)
+		if errParse != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s [%s]", agentInboundMaxUnpacksFlagName, maxUnpacks)
+		}
+
+		opts = append(opts, guard.WithMaxConcurrentUnpacks(n))
+	}
+
+	rejectUnknown, err := getUserSetVar(cmd, agentInboundRejectUnknownFlagName, agentInboundRejectUnknownEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if rejectUnknown != "" {
+		reject, errParse := strconv.ParseBool(rejectUnknown)
+		if errParse != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", agentInboundRejectUnknownFlagName, errParse)
+		}
+
+		opts = append(opts, guard.WithRecipientCheck(reject))
+	}
+
+	if len(opts) == 0 {
+		return nil, nil
+	}
+
+	return guard.New(opts...), nil
+}
+
+func getShutdownTimeout(cmd *cobra.Command) (time.Duration, error) {
+	value, err := getUserSetVar(cmd, agentShutdownTimeoutFlagName, agentShutdownTimeoutEnvKey, true)
+	if err != nil || value == "" {
+		return defaultShutdownTimeout, err
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s [%s]", agentShutdownTimeoutFlagName, value)
+	}
+
+	return timeout, nil
+}
+
+// getRateLimit parses a RATE[:BURST] rate limit, the burst defaulting to the rate rounded up.
+func getRateLimit(cmd *cobra.Command, flagName, envKey string) (float64, int, error) {
+	value, err := getUserSetVar(cmd, flagName, envKey, true)
+	if err != nil || value == "" {
+		return 0, 0, err
+	}
+
+	parts := strings.SplitN(value, ":", 2) //nolint:gomnd
+
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate < 0 {
+		return 0, 0, fmt.Errorf("invalid %s [%s]: use RATE[:BURST]", flagName, value)
+	}
+
+	burst := int(math.Ceil(rate))
+
+	if len(parts) > 1 {
+		burst, err = strconv.Atoi(parts[1])
+		if err != nil || burst < 1 {
+			return 0, 0, fmt.Errorf("invalid %s [%s]: use RATE[:BURST]", flagName, value)
+		}
+	}
+
+	return rate, burst, nil
+}
+
 func createFlags(startCmd *cobra.Command) {
 	// agent host flag
 	startCmd.Flags().StringP(agentHostFlagName, agentHostFlagShorthand, "", agentHostFlagUsage)
@@ -459,6 +814,31 @@ func createFlags(startCmd *cobra.Command) {
 
 	// db timeout
 	startCmd.Flags().StringP(databaseTimeoutFlagName, "", "", databaseTimeoutFlagUsage)
+
+	// did:web document directory
+	startCmd.Flags().StringP(agentDIDWebDirFlagName, "", "", agentDIDWebDirFlagUsage)
+
+	// OTLP exporter endpoint
+	startCmd.Flags().StringP(agentOTLPEndpointFlagName, "", "", agentOTLPEndpointFlagUsage)
+
+	// redaction
+	startCmd.Flags().StringSliceP(agentRedactionPathsFlagName, "", []string{}, agentRedactionPathsFlagUsage)
+	startCmd.Flags().StringP(agentRedactionDisabledFlagName, "", "", agentRedactionDisabledFlagUsage)
+
+	// inbound guard
+	startCmd.Flags().StringP(agentInboundMaxEnvelopeSizeFlagName, "", "", agentInboundMaxEnvelopeSizeFlagUsage)
+	startCmd.Flags().StringP(agentInboundIPRateLimitFlagName, "", "", agentInboundIPRateLimitFlagUsage)
+	startCmd.Flags().StringP(agentInboundRecipientRateLimitFlagName, "", "", agentInboundRecipientRateLimitFlagUsage)
+	startCmd.Flags().StringP(agentInboundMaxUnpacksFlagName, "", "", agentInboundMaxUnpacksFlagUsage)
+	startCmd.Flags().StringP(agentInboundRejectUnknownFlagName, "", "", agentInboundRejectUnknownFlagUsage)
+
+	// shutdown timeout flag
+	startCmd.Flags().StringP(agentShutdownTimeoutFlagName, "", "", agentShutdownTimeoutFlagUsage)
+
+	// multi-tenant flags
+	startCmd.Flags().StringP(agentMultiTenantFlagName, "", "", agentMultiTenantFlagUsage)
+	startCmd.Flags().StringP(agentTenantJWTSecretFlagName, "", "", agentTenantJWTSecretFlagUsage)
+	startCmd.Flags().StringP(agentTenantLockPassphraseFlagName, "", "", agentTenantLockPassphraseFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -561,7 +941,21 @@ func getOutboundTransportOpts(outboundTransports []string) ([]aries.Option, erro
 }
 
 func getInboundTransportOpts(inboundHostInternals, inboundHostExternals []string, certFile,
-	keyFile string) ([]aries.Option, error) {
+	keyFile string, inboundGuard *guard.Guard) ([]aries.Option, error) {
+	inbounds, err := getInboundTransports(inboundHostInternals, inboundHostExternals, certFile, keyFile, inboundGuard)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inbounds) == 0 {
+		return nil, nil
+	}
+
+	return []aries.Option{aries.WithInboundTransport(inbounds...)}, nil
+}
+
+func getInboundTransports(inboundHostInternals, inboundHostExternals []string, certFile,
+	keyFile string, inboundGuard *guard.Guard) ([]transport.InboundTransport, error) {
 	internalHost, err := getInboundSchemeToURLMap(inboundHostInternals)
 	if err != nil {
 		return nil, fmt.Errorf("inbound internal host : %w", err)
@@ -572,20 +966,39 @@ func getInboundTransportOpts(inboundHostInternals, inboundHostExternals []string
 		return nil, fmt.Errorf("inbound external host : %w", err)
 	}
 
-	var opts []aries.Option
+	var (
+		inbounds []transport.InboundTransport
+		httpOpts []arieshttp.InboundHTTPOpt
+		wsOpts   []ws.Opt
+	)
+
+	if inboundGuard != nil {
+		httpOpts = append(httpOpts, arieshttp.WithInboundGuard(inboundGuard))
+		wsOpts = append(wsOpts, ws.WithGuard(inboundGuard))
+	}
 
 	for scheme, host := range internalHost {
+		var inbound transport.InboundTransport
+
 		switch scheme {
 		case httpProtocol:
-			opts = append(opts, defaults.WithInboundHTTPAddr(host, externalHost[scheme], certFile, keyFile))
+			inbound, err = arieshttp.NewInbound(host, externalHost[scheme], certFile, keyFile, httpOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("http inbound transport initialization failed : %w", err)
+			}
 		case websocketProtocol:
-			opts = append(opts, defaults.WithInboundWSAddr(host, externalHost[scheme], certFile, keyFile))
+			inbound, err = ws.NewInbound(host, externalHost[scheme], certFile, keyFile, wsOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("ws inbound transport initialization failed : %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("inbound transport [%s] not supported", scheme)
 		}
+
+		inbounds = append(inbounds, inbound)
 	}
 
-	return opts, nil
+	return inbounds, nil
 }
 
 func getInboundSchemeToURLMap(schemeHostStr []string) (map[string]string, error) {
@@ -651,32 +1064,37 @@ func startAgent(parameters *agentParameters) error {
 		return errMissingHost
 	}
 
-	// set message handler
-	parameters.msgHandler = msghandler.NewRegistrar()
+	if parameters.otlpEndpoint != "" {
+		exporter := tracing.NewOTLPExporter(parameters.otlpEndpoint, tracing.WithServiceName(tracingServiceName))
+		defer exporter.Shutdown()
 
-	ctx, err := createAriesAgent(parameters)
-	if err != nil {
-		return err
+		tracing.SetExporter(exporter)
+		defer tracing.SetExporter(nil)
 	}
 
-	// get all HTTP REST API handlers available for controller API
-	handlers, err := controller.GetRESTHandlers(ctx, controller.WithWebhookURLs(parameters.webhookURLs...),
-		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
-		controller.WithMessageHandler(parameters.msgHandler),
-		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593))
-	if err != nil {
-		return fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
-			parameters.host, err)
+	// the policy masks nothing if redaction is disabled
+	var controllerOpts []controller.Opt
+
+	if parameters.redactionPolicy != nil {
+		log.SetRedactionPolicy(parameters.redactionPolicy)
+		controllerOpts = append(controllerOpts, controller.WithRedactionPolicy(parameters.redactionPolicy))
 	}
 
 	router := mux.NewRouter()
 
-	if parameters.token != "" {
-		router.Use(authorizationMiddleware(parameters.token))
+	var (
+		closeAgents func()
+		err         error
+	)
+
+	if parameters.multiTenant {
+		closeAgents, err = startTenantAgents(parameters, controllerOpts, router)
+	} else {
+		closeAgents, err = startSingleAgent(parameters, controllerOpts, router)
 	}
 
-	for _, handler := range handlers {
-		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	if err != nil {
+		return err
 	}
 
 	logger.Infof("Starting aries agent rest on host [%s]", parameters.host)
@@ -684,11 +1102,25 @@ func startAgent(parameters *agentParameters) error {
 	handler := cors.New(
 		cors.Options{
 			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodHead},
-			AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization"},
+			AllowedHeaders: []string{
+				"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization", tenantAPIKeyHeader,
+			},
 		},
 	).Handler(router)
 
+	if parameters.didWebDir != "" {
+		handler = didWebHandler(parameters.didWebDir, handler)
+	}
+
+	if srv, ok := parameters.server.(gracefulServer); ok {
+		stop := shutdownOnSignal(srv, parameters.shutdownTimeout)
+		defer stop()
+	}
+
 	err = parameters.server.ListenAndServe(parameters.host, handler, parameters.tlsCertFile, parameters.tlsKeyFile)
+
+	closeAgents()
+
 	if err != nil {
 		return fmt.Errorf("failed to start aries agent rest on port [%s], cause:  %w", parameters.host, err)
 	}
@@ -696,28 +1128,185 @@ func startAgent(parameters *agentParameters) error {
 	return nil
 }
 
-func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
-	var opts []aries.Option
+// startSingleAgent starts the agent and registers its REST API with router, returning the function closing it.
+func startSingleAgent(parameters *agentParameters, controllerOpts []controller.Opt,
+	router *mux.Router) (func(), error) {
+	// set message handler
+	parameters.msgHandler = msghandler.NewRegistrar()
 
-	storePro, err := createStoreProviders(parameters)
+	framework, err := createAriesAgent(parameters)
 	if err != nil {
 		return nil, err
 	}
 
-	opts = append(opts, aries.WithStoreProvider(storePro))
+	ctx, err := framework.Context()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get aries context : %w",
+			parameters.host, err)
+	}
 
-	if parameters.transportReturnRoute != "" {
-		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
+	// get all HTTP REST API handlers available for controller API
+	handlers, err := controller.GetRESTHandlers(ctx, append(controllerOpts,
+		controller.WithWebhookURLs(parameters.webhookURLs...),
+		controller.WithDefaultLabel(parameters.defaultLabel), controller.WithAutoAccept(parameters.autoAccept),
+		controller.WithMessageHandler(parameters.msgHandler),
+		controller.WithAutoExecuteRFC0593(parameters.autoExecuteRFC0593))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get rest service api :  %w",
+			parameters.host, err)
+	}
+
+	if parameters.token != "" {
+		router.Use(authorizationMiddleware(parameters.token))
+	}
+
+	for _, handler := range handlers {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	router.Handle(metricsPath, metrics.Handler()).Methods(http.MethodGet)
+
+	return func() {
+		// the framework drains the in-flight messages before closing the stores
+		if closeErr := framework.Close(); closeErr != nil {
+			logger.Warnf("failed to close aries agent: %s", closeErr)
+		}
+	}, nil
+}
+
+// startTenantAgents starts the shared inbound transports and the tenant agents, and registers the admin API and the
+// routing of the REST calls to the tenants with router. It returns the function closing them.
+func startTenantAgents(parameters *agentParameters, controllerOpts []controller.Opt,
+	router *mux.Router) (func(), error) {
+	if parameters.token == "" {
+		return nil, errAdminToken
+	}
+
+	if parameters.tenantLockPassphrase == "" {
+		return nil, errTenantLockPassphrase
+	}
+
+	storeProvider, err := createStoreProviders(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	inbounds, err := getInboundTransports(parameters.inboundHostInternals, parameters.inboundHostExternals,
+		parameters.tlsCertFile, parameters.tlsKeyFile, parameters.inboundGuard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to inbound tranpsort opt : %w",
+			parameters.host, err)
+	}
+
+	inboundRouter, err := shared.NewRouter(storeProvider, inbounds...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to create inbound router : %w",
+			parameters.host, err)
+	}
+
+	if err = inboundRouter.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to start inbound : %w",
+			parameters.host, err)
+	}
+
+	tenants, err := newTenantManager(parameters, controllerOpts, storeProvider, inboundRouter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to start tenants : %w",
+			parameters.host, err)
+	}
+
+	adminAuth := authorizationMiddleware(parameters.token)
+
+	admin := router.NewRoute().Subrouter()
+	admin.Use(adminAuth)
+	tenants.registerAdminHandlers(admin)
+	admin.Handle(metricsPath, metrics.Handler()).Methods(http.MethodGet)
+
+	router.PathPrefix("/").Handler(tenants)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), parameters.shutdownTimeout)
+		defer cancel()
+
+		if shutdownErr := inboundRouter.Shutdown(ctx); shutdownErr != nil {
+			logger.Warnf("failed to shut down the shared inbound transports: %s", shutdownErr)
+		}
+
+		// each tenant framework drains its in-flight messages before closing its stores
+		tenants.close()
+
+		if closeErr := storeProvider.Close(); closeErr != nil {
+			logger.Warnf("failed to close store provider: %s", closeErr)
+		}
+	}, nil
+}
+
+// shutdownOnSignal shuts the server down on SIGTERM or SIGINT, ListenAndServe then returning once the active
+// requests are completed. The signals are handled until the returned stop function is called.
+func shutdownOnSignal(srv gracefulServer, timeout time.Duration) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, shutdownSignals...)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Infof("Shutting down aries agent rest on %s", sig)
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Warnf("failed to shut down aries agent rest gracefully: %s", err)
+			}
+		case <-stopped:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(stopped)
+	}
+}
+
+// didWebHandler serves the hosted did:web documents publicly, bypassing the authorization of the REST API.
+func didWebHandler(documentDir string, next http.Handler) http.Handler {
+	docHandler := web.NewDocumentHandler(documentDir)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsDocumentPath(r.URL.Path) {
+			docHandler.ServeHTTP(w, r)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func createAriesAgent(parameters *agentParameters) (*aries.Aries, error) {
+	storePro, err := createStoreProviders(parameters)
+	if err != nil {
+		return nil, err
 	}
 
 	inboundTransportOpt, err := getInboundTransportOpts(parameters.inboundHostInternals,
-		parameters.inboundHostExternals, parameters.tlsCertFile, parameters.tlsKeyFile)
+		parameters.inboundHostExternals, parameters.tlsCertFile, parameters.tlsKeyFile, parameters.inboundGuard)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to inbound tranpsort opt : %w",
 			parameters.host, err)
 	}
 
-	opts = append(opts, inboundTransportOpt...)
+	return newAriesAgent(parameters, append([]aries.Option{aries.WithStoreProvider(storePro)}, inboundTransportOpt...)...)
+}
+
+// newAriesAgent creates a framework with opts, in addition to the options set by the parameters common to all the
+// agents of the process.
+func newAriesAgent(parameters *agentParameters, opts ...aries.Option) (*aries.Aries, error) {
+	if parameters.transportReturnRoute != "" {
+		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
+	}
 
 	resolverOpts, err := getResolverOpts(parameters.httpResolvers)
 	if err != nil {
@@ -727,6 +1316,10 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 
 	opts = append(opts, resolverOpts...)
 
+	if parameters.didWebDir != "" {
+		opts = append(opts, aries.WithVDR(web.New(web.WithDocumentDir(parameters.didWebDir))))
+	}
+
 	outboundTransportOpts, err := getOutboundTransportOpts(parameters.outboundTransports)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to outbound transport opts : %w",
@@ -736,19 +1329,17 @@ func createAriesAgent(parameters *agentParameters) (*context.Provider, error) {
 	opts = append(opts, outboundTransportOpts...)
 	opts = append(opts, aries.WithMessageServiceProvider(parameters.msgHandler))
 
-	framework, err := aries.New(opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
-			parameters.host, err)
+	if parameters.shutdownTimeout > 0 {
+		opts = append(opts, aries.WithShutdownTimeout(parameters.shutdownTimeout))
 	}
 
-	ctx, err := framework.Context()
+	framework, err := aries.New(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to get aries context : %w",
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
 			parameters.host, err)
 	}
 
-	return ctx, nil
+	return framework, nil
 }
 
 func createStoreProviders(parameters *agentParameters) (storage.Provider, error) {
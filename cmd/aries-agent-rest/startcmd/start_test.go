@@ -13,8 +13,11 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
 
@@ -82,6 +86,18 @@ func TestStartCmdContents(t *testing.T) {
 	checkFlagPropertiesCorrect(t, startCmd, agentInboundHostFlagName,
 		agentInboundHostFlagShorthand, agentInboundHostFlagUsage, "[]")
 	checkFlagPropertiesCorrect(t, startCmd, databaseTypeFlagName, databaseTypeFlagShorthand, databaseTypeFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentOTLPEndpointFlagName, "", agentOTLPEndpointFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentRedactionPathsFlagName, "", agentRedactionPathsFlagUsage, "[]")
+	checkFlagPropertiesCorrect(t, startCmd, agentRedactionDisabledFlagName, "", agentRedactionDisabledFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentInboundMaxEnvelopeSizeFlagName, "",
+		agentInboundMaxEnvelopeSizeFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentInboundIPRateLimitFlagName, "", agentInboundIPRateLimitFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentInboundRecipientRateLimitFlagName, "",
+		agentInboundRecipientRateLimitFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentInboundMaxUnpacksFlagName, "", agentInboundMaxUnpacksFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentInboundRejectUnknownFlagName, "",
+		agentInboundRejectUnknownFlagUsage, "")
+	checkFlagPropertiesCorrect(t, startCmd, agentShutdownTimeoutFlagName, "", agentShutdownTimeoutFlagUsage, "")
 }
 
 func checkFlagPropertiesCorrect(t *testing.T, cmd *cobra.Command, flagName,
@@ -204,6 +220,14 @@ func validateRequests(t *testing.T, testHostURL, authorizationHdr, testInboundHo
 			expectResponseData: true,
 		},
 
+		// metrics test
+		{
+			name:               "metrics",
+			r:                  newreq("GET", fmt.Sprintf("http://%s/metrics", testHostURL), nil, ""),
+			expectedStatus:     http.StatusOK,
+			expectResponseData: false,
+		},
+
 		// DIDComm inbound API test
 		{
 			name: "200: testing didcomm inbound",
@@ -453,6 +477,194 @@ func TestStartCmdWithoutWebhookURLAndAutoAccept(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStartCmdWithOTLPEndpoint(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentWebhookFlagName,
+		"",
+		"--" + agentOTLPEndpointFlagName,
+		"http://localhost:4318",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.NoError(t, err)
+}
+
+func TestStartCmdWithRedaction(t *testing.T) {
+	defer log.SetRedactionPolicy(redact.DefaultPolicy())
+
+	baseArgs := func() []string {
+		return []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"",
+		}
+	}
+
+	t.Run("additional paths", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentRedactionPathsFlagName, "$.attributes.ssn",
+			"--"+agentRedactionPathsFlagName, "email"))
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(), "--"+agentRedactionDisabledFlagName, "true"))
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("invalid disabled value", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(), "--"+agentRedactionDisabledFlagName, "maybe"))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse "+agentRedactionDisabledFlagName)
+	})
+}
+
+func TestStartCmdWithInboundGuard(t *testing.T) {
+	baseArgs := func() []string {
+		return []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + agentInboundHostFlagName,
+			websocketProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentWebhookFlagName,
+			"",
+		}
+	}
+
+	t.Run("valid limits", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(append(baseArgs(),
+			"--"+agentInboundMaxEnvelopeSizeFlagName, "65536",
+			"--"+agentInboundIPRateLimitFlagName, "10:20",
+			"--"+agentInboundRecipientRateLimitFlagName, "2.5",
+			"--"+agentInboundMaxUnpacksFlagName, "8",
+			"--"+agentInboundRejectUnknownFlagName, "true"))
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	invalid := map[string][]string{
+		"invalid " + agentInboundMaxEnvelopeSizeFlagName:       {agentInboundMaxEnvelopeSizeFlagName, "-1"},
+		"invalid " + agentInboundIPRateLimitFlagName:           {agentInboundIPRateLimitFlagName, "fast"},
+		"invalid " + agentInboundRecipientRateLimitFlagName:    {agentInboundRecipientRateLimitFlagName, "1:0"},
+		"invalid " + agentInboundMaxUnpacksFlagName:            {agentInboundMaxUnpacksFlagName, "many"},
+		"failed to parse " + agentInboundRejectUnknownFlagName: {agentInboundRejectUnknownFlagName, "maybe"},
+	}
+
+	for expected, flag := range invalid {
+		expected, flag := expected, flag
+
+		t.Run(expected, func(t *testing.T) {
+			startCmd, err := Cmd(&mockServer{})
+			require.NoError(t, err)
+
+			startCmd.SetArgs(append(baseArgs(), "--"+flag[0], flag[1]))
+
+			err = startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expected)
+		})
+	}
+}
+
+func TestStartCmdWithShutdownTimeout(t *testing.T) {
+	t.Run("shut down on signal", func(t *testing.T) {
+		// the agents started by the other tests keep handling the default signals
+		defaultSignals := shutdownSignals
+		shutdownSignals = []os.Signal{syscall.SIGUSR1}
+
+		defer func() { shutdownSignals = defaultSignals }()
+
+		testHostURL := randomURL()
+		testInboundHostURL := randomURL()
+
+		startCmd, err := Cmd(&HTTPServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, testHostURL,
+			"--" + agentInboundHostFlagName, httpProtocol + "@" + testInboundHostURL,
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentWebhookFlagName, "",
+			"--" + agentShutdownTimeoutFlagName, "5s",
+		})
+
+		stopped := make(chan error)
+
+		go func() {
+			stopped <- startCmd.Execute()
+		}()
+
+		waitForServerToStart(t, testHostURL, testInboundHostURL)
+
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+		select {
+		case err = <-stopped:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "agent not shut down on signal")
+		}
+
+		// the inbound transport is closed with the framework
+		_, err = net.Dial("tcp", testInboundHostURL)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid shutdown timeout", func(t *testing.T) {
+		for _, value := range []string{"soon", "-1s", "0"} {
+			startCmd, err := Cmd(&mockServer{})
+			require.NoError(t, err)
+
+			startCmd.SetArgs([]string{
+				"--" + agentHostFlagName, randomURL(),
+				"--" + agentInboundHostFlagName, httpProtocol + "@" + randomURL(),
+				"--" + databaseTypeFlagName, databaseTypeMemOption,
+				"--" + agentWebhookFlagName, "",
+				"--" + agentShutdownTimeoutFlagName, value,
+			})
+
+			err = startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid "+agentShutdownTimeoutFlagName)
+		}
+	})
+}
+
 func TestStartCmdValidArgs(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -734,6 +946,28 @@ func TestStartAriesWithAuthorization(t *testing.T) {
 	})
 }
 
+func TestDIDWebHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "didweb")
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, os.RemoveAll(dir)) }()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "example.com", ".well-known"), 0o755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "example.com", ".well-known", "did.json"),
+		[]byte(`{"id":"did:web:example.com"}`), 0o600))
+
+	handler := didWebHandler(dir, authorizationMiddleware("ABCD")(http.NotFoundHandler()))
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/.well-known/did.json", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), "did:web:example.com")
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/connections", nil))
+	require.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
 func TestStoreProvider(t *testing.T) {
 	t.Run("test invalid database type", func(t *testing.T) {
 		_, err := createAriesAgent(&agentParameters{dbParam: &dbParam{dbType: "data1"}})
@@ -0,0 +1,613 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/shared"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/prefix"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	tenantStoreName    = "tenants"
+	tenantTag          = "tenant"
+	tenantStorePrefix  = "tenant_%s_"
+	tenantAPIKeyHeader = "X-API-Key"
+	tenantIDClaim      = "tenant_id"
+	tenantKeySize      = 32
+	// tenantMasterKeyURI is the master key URI of the KMS of the tenants, the default one of the framework.
+	tenantMasterKeyURI = "local-lock://default/master/key/"
+
+	adminTenantsPath = "/admin/tenants"
+	adminTenantPath  = adminTenantsPath + "/{id}"
+)
+
+var (
+	errTenantNotFound       = errors.New("tenant not found")
+	errTenantTokenValid     = errors.New("invalid tenant token")
+	errTenantLockPassphrase = errors.New("tenant lock passphrase is required in multi-tenant mode to protect the" +
+		" master keys of the tenants")
+)
+
+// tenantRecord is the persisted configuration of a tenant.
+type tenantRecord struct {
+	ID          string    `json:"id"`
+	Label       string    `json:"label,omitempty"`
+	WebhookURLs []string  `json:"webhook_urls,omitempty"`
+	CreatedTime time.Time `json:"created_time"`
+	// APIKeyHash is the SHA-256 of the API key of the tenant, the key being returned once on creation.
+	APIKeyHash string `json:"api_key_hash"`
+	// MasterKey is the master key of the secret lock of the tenant, protected by the tenant lock passphrase.
+	MasterKey string `json:"master_key"`
+}
+
+// tenantRequest creates a tenant.
+type tenantRequest struct {
+	Label       string   `json:"label,omitempty"`
+	WebhookURLs []string `json:"webhook_urls,omitempty"`
+}
+
+// tenantResponse describes a tenant, the API key being set on creation only.
+type tenantResponse struct {
+	ID          string    `json:"id"`
+	Label       string    `json:"label,omitempty"`
+	WebhookURLs []string  `json:"webhook_urls,omitempty"`
+	CreatedTime time.Time `json:"created_time"`
+	APIKey      string    `json:"api_key,omitempty"`
+}
+
+type tenantsResponse struct {
+	Tenants []*tenantResponse `json:"tenants"`
+}
+
+// tenant is a running tenant agent.
+type tenant struct {
+	record        *tenantRecord
+	framework     *aries.Aries
+	handler       http.Handler
+	storeProvider *prefix.ProviderPrefixWrapper
+}
+
+// tenantManager hosts the tenant agents of a multi-tenant agent. The tenants share the inbound transports and the
+// database of the process, each tenant having its own store namespaces, KMS keystore, secret lock and webhooks.
+type tenantManager struct {
+	params         *agentParameters
+	controllerOpts []controller.Opt
+	router         *shared.Router
+	storeProvider  storage.Provider
+	store          storage.Store
+	masterLock     secretlock.Service
+	jwtSecret      []byte
+	tenants        map[string]*tenant
+	apiKeys        map[string]*tenant
+	lock           sync.RWMutex
+}
+
+// newTenantManager starts the tenants persisted in storeProvider.
+func newTenantManager(params *agentParameters, controllerOpts []controller.Opt, storeProvider storage.Provider,
+	router *shared.Router) (*tenantManager, error) {
+	store, err := storeProvider.OpenStore(tenantStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open tenant store : %w", err)
+	}
+
+	err = storeProvider.SetStoreConfig(tenantStoreName, storage.StoreConfiguration{TagNames: []string{tenantTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set tenant store config : %w", err)
+	}
+
+	m := &tenantManager{
+		params:         params,
+		controllerOpts: controllerOpts,
+		router:         router,
+		storeProvider:  storeProvider,
+		store:          store,
+		jwtSecret:      []byte(params.tenantJWTSecret),
+		tenants:        make(map[string]*tenant),
+		apiKeys:        make(map[string]*tenant),
+	}
+
+	if params.tenantLockPassphrase == "" {
+		return nil, errTenantLockPassphrase
+	}
+
+	m.masterLock, err = hkdf.NewMasterLock(params.tenantLockPassphrase, sha256.New, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create tenant master lock : %w", err)
+	}
+
+	records, err := m.records()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if _, err = m.start(record); err != nil {
+			m.close()
+
+			return nil, fmt.Errorf("start tenant %s : %w", record.ID, err)
+		}
+	}
+
+	logger.Infof("started %d tenants", len(records))
+
+	return m, nil
+}
+
+// create creates and starts a tenant, returning its API key.
+func (m *tenantManager) create(req *tenantRequest) (*tenant, string, error) {
+	apiKey, err := randomKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	masterKey, err := randomKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	record := &tenantRecord{
+		ID:          uuid.New().String(),
+		Label:       req.Label,
+		WebhookURLs: req.WebhookURLs,
+		CreatedTime: time.Now().UTC(),
+		APIKeyHash:  hashAPIKey(apiKey),
+	}
+
+	record.MasterKey, err = m.protectMasterKey(masterKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	t, err := m.start(record)
+	if err != nil {
+		return nil, "", err
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal tenant : %w", err)
+	}
+
+	err = m.store.Put(record.ID, recordBytes, storage.Tag{Name: tenantTag})
+	if err != nil {
+		m.stop(t)
+
+		return nil, "", fmt.Errorf("save tenant : %w", err)
+	}
+
+	logger.Infof("created tenant %s", record.ID)
+
+	return t, apiKey, nil
+}
+
+// delete stops a tenant and deletes it along with its data.
+func (m *tenantManager) delete(id string) error {
+	m.lock.RLock()
+	t, ok := m.tenants[id]
+	m.lock.RUnlock()
+
+	if !ok {
+		return errTenantNotFound
+	}
+
+	if err := m.store.Delete(id); err != nil {
+		return fmt.Errorf("delete tenant : %w", err)
+	}
+
+	m.stop(t)
+
+	if err := t.storeProvider.Purge(); err != nil {
+		return fmt.Errorf("purge tenant data : %w", err)
+	}
+
+	if err := m.router.RemoveAgent(id); err != nil {
+		return fmt.Errorf("remove tenant keys : %w", err)
+	}
+
+	logger.Infof("deleted tenant %s", id)
+
+	return nil
+}
+
+// get returns the tenant id.
+func (m *tenantManager) get(id string) (*tenant, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	t, ok := m.tenants[id]
+	if !ok {
+		return nil, errTenantNotFound
+	}
+
+	return t, nil
+}
+
+// list returns the tenants ordered by creation time.
+func (m *tenantManager) list() []*tenant {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tenants := make([]*tenant, 0, len(m.tenants))
+
+	for _, t := range m.tenants {
+		tenants = append(tenants, t)
+	}
+
+	sort.Slice(tenants, func(i, j int) bool {
+		return tenants[i].record.CreatedTime.Before(tenants[j].record.CreatedTime)
+	})
+
+	return tenants
+}
+
+// close stops all the tenants, the framework of each tenant draining its in-flight messages.
+func (m *tenantManager) close() {
+	for _, t := range m.list() {
+		m.stop(t)
+	}
+}
+
+// start creates the agent of record and starts routing to it.
+func (m *tenantManager) start(record *tenantRecord) (*tenant, error) {
+	storeProvider, err := prefix.NewPrefixProviderWrapper(m.storeProvider, fmt.Sprintf(tenantStorePrefix, record.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	secretLock, err := m.secretLock(record.MasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("create secret lock : %w", err)
+	}
+
+	// each tenant has its own message handlers, the parameters being otherwise shared
+	params := *m.params
+	params.msgHandler = msghandler.NewRegistrar()
+
+	// the keys of the tenant are indexed to route the inbound envelopes to the tenant
+	kmsCreator := m.router.KMS(record.ID, func(p kms.Provider) (kms.KeyManager, error) {
+		return localkms.New(tenantMasterKeyURI, p)
+	})
+
+	framework, err := newAriesAgent(&params,
+		aries.WithStoreProvider(storeProvider),
+		aries.WithSecretLock(secretLock),
+		aries.WithKMS(kmsCreator),
+		aries.WithInboundTransport(m.router.Inbounds()...))
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := m.tenantHandler(framework, &params, record)
+	if err != nil {
+		if closeErr := framework.Close(); closeErr != nil {
+			logger.Warnf("failed to close tenant %s: %s", record.ID, closeErr)
+		}
+
+		return nil, err
+	}
+
+	t := &tenant{record: record, framework: framework, handler: handler, storeProvider: storeProvider}
+
+	m.lock.Lock()
+	m.tenants[record.ID] = t
+	m.apiKeys[record.APIKeyHash] = t
+	m.lock.Unlock()
+
+	return t, nil
+}
+
+func (m *tenantManager) stop(t *tenant) {
+	m.lock.Lock()
+	delete(m.tenants, t.record.ID)
+	delete(m.apiKeys, t.record.APIKeyHash)
+	m.lock.Unlock()
+
+	if err := t.framework.Close(); err != nil {
+		logger.Warnf("failed to close tenant %s: %s", t.record.ID, err)
+	}
+}
+
+// tenantHandler returns the REST API of a tenant, notifying the webhooks of the tenant.
+func (m *tenantManager) tenantHandler(framework *aries.Aries, params *agentParameters,
+	record *tenantRecord) (http.Handler, error) {
+	ctx, err := framework.Context()
+	if err != nil {
+		return nil, fmt.Errorf("get tenant context : %w", err)
+	}
+
+	label := record.Label
+	if label == "" {
+		label = params.defaultLabel
+	}
+
+	handlers, err := controller.GetRESTHandlers(ctx, append(m.controllerOpts,
+		controller.WithWebhookURLs(record.WebhookURLs...),
+		controller.WithDefaultLabel(label), controller.WithAutoAccept(params.autoAccept),
+		controller.WithMessageHandler(params.msgHandler),
+		controller.WithAutoExecuteRFC0593(params.autoExecuteRFC0593))...)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant rest handlers : %w", err)
+	}
+
+	router := mux.NewRouter()
+
+	for _, handler := range handlers {
+		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+	}
+
+	return router, nil
+}
+
+func (m *tenantManager) records() ([]*tenantRecord, error) {
+	iter, err := m.store.Query(tenantTag)
+	if err != nil {
+		return nil, fmt.Errorf("query tenants : %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*tenantRecord
+
+	more, err := iter.Next()
+	for ; err == nil && more; more, err = iter.Next() {
+		var value []byte
+
+		if value, err = iter.Value(); err != nil {
+			break
+		}
+
+		record := &tenantRecord{}
+		if err = json.Unmarshal(value, record); err != nil {
+			break
+		}
+
+		records = append(records, record)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read tenants : %w", err)
+	}
+
+	return records, nil
+}
+
+// protectMasterKey encrypts masterKey with the tenant master lock.
+func (m *tenantManager) protectMasterKey(masterKey string) (string, error) {
+	key, err := base64.URLEncoding.DecodeString(masterKey)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := m.masterLock.Encrypt("", &secretlock.EncryptRequest{Plaintext: string(key)})
+	if err != nil {
+		return "", fmt.Errorf("protect master key : %w", err)
+	}
+
+	return resp.Ciphertext, nil
+}
+
+func (m *tenantManager) secretLock(masterKey string) (secretlock.Service, error) {
+	return locallock.NewService(strings.NewReader(masterKey), m.masterLock)
+}
+
+// tenantOf returns the tenant a REST request is made for, selected by its API key or by the tenant ID claim of a
+// JWT signed with the tenant JWT secret.
+func (m *tenantManager) tenantOf(r *http.Request) (*tenant, error) {
+	if apiKey := r.Header.Get(tenantAPIKeyHeader); apiKey != "" {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+
+		t, ok := m.apiKeys[hashAPIKey(apiKey)]
+		if !ok {
+			return nil, errTenantNotFound
+		}
+
+		return t, nil
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if len(m.jwtSecret) == 0 || token == "" {
+		return nil, errTenantNotFound
+	}
+
+	id, err := m.tenantClaim(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.get(id)
+}
+
+// tenantClaim returns the tenant ID claim of an HS256 JWT signed with the tenant JWT secret.
+func (m *tenantManager) tenantClaim(token string) (string, error) {
+	verifier := jose.SignatureVerifierFunc(func(headers jose.Headers, _, signingInput, signature []byte) error {
+		if alg, _ := headers.Algorithm(); alg != "HS256" {
+			return fmt.Errorf("unsupported algorithm %s", alg)
+		}
+
+		mac := hmac.New(sha256.New, m.jwtSecret)
+		mac.Write(signingInput) // nolint:errcheck,gosec
+
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	})
+
+	parsed, err := jwt.Parse(token, jwt.WithSignatureVerifier(verifier))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errTenantTokenValid, err)
+	}
+
+	claims := &struct {
+		jwt.Claims
+		TenantID string `json:"tenant_id"`
+	}{}
+
+	if err = parsed.DecodeClaims(claims); err != nil {
+		return "", fmt.Errorf("%w: %s", errTenantTokenValid, err)
+	}
+
+	if claims.Expiry != nil && claims.Expiry.Time().Before(time.Now()) {
+		return "", fmt.Errorf("%w: token expired", errTenantTokenValid)
+	}
+
+	if claims.TenantID == "" {
+		return "", fmt.Errorf("%w: no %s claim", errTenantTokenValid, tenantIDClaim)
+	}
+
+	return claims.TenantID, nil
+}
+
+// ServeHTTP serves the REST API of the tenant a request is made for.
+func (m *tenantManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t, err := m.tenantOf(r)
+	if err != nil {
+		logger.Debugf("refused tenant request: %s", err)
+
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Unauthorised.\n")) // nolint:gosec,errcheck
+
+		return
+	}
+
+	t.handler.ServeHTTP(w, r)
+}
+
+// registerAdminHandlers registers the tenant admin API with router.
+func (m *tenantManager) registerAdminHandlers(router *mux.Router) {
+	router.HandleFunc(adminTenantsPath, m.createTenant).Methods(http.MethodPost)
+	router.HandleFunc(adminTenantsPath, m.listTenants).Methods(http.MethodGet)
+	router.HandleFunc(adminTenantPath, m.getTenant).Methods(http.MethodGet)
+	router.HandleFunc(adminTenantPath, m.deleteTenant).Methods(http.MethodDelete)
+}
+
+func (m *tenantManager) createTenant(rw http.ResponseWriter, req *http.Request) {
+	request := &tenantRequest{}
+
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, command.UnknownStatus, err)
+
+		return
+	}
+
+	t, apiKey, err := m.create(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, command.UnknownStatus, err)
+
+		return
+	}
+
+	resp := t.response()
+	resp.APIKey = apiKey
+
+	sendJSON(rw, http.StatusCreated, resp)
+}
+
+func (m *tenantManager) listTenants(rw http.ResponseWriter, _ *http.Request) {
+	resp := &tenantsResponse{Tenants: []*tenantResponse{}}
+
+	for _, t := range m.list() {
+		resp.Tenants = append(resp.Tenants, t.response())
+	}
+
+	sendJSON(rw, http.StatusOK, resp)
+}
+
+func (m *tenantManager) getTenant(rw http.ResponseWriter, req *http.Request) {
+	t, err := m.get(mux.Vars(req)["id"])
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, command.UnknownStatus, err)
+
+		return
+	}
+
+	sendJSON(rw, http.StatusOK, t.response())
+}
+
+func (m *tenantManager) deleteTenant(rw http.ResponseWriter, req *http.Request) {
+	err := m.delete(mux.Vars(req)["id"])
+	if errors.Is(err, errTenantNotFound) {
+		rest.SendHTTPStatusError(rw, http.StatusNotFound, command.UnknownStatus, err)
+
+		return
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, command.UnknownStatus, err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (t *tenant) response() *tenantResponse {
+	return &tenantResponse{
+		ID:          t.record.ID,
+		Label:       t.record.Label,
+		WebhookURLs: t.record.WebhookURLs,
+		CreatedTime: t.record.CreatedTime,
+	}
+}
+
+func sendJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		logger.Errorf("Unable to send response, %s", err)
+	}
+}
+
+// randomKey returns a random base64 URL encoded key.
+func randomKey() (string, error) {
+	key := make([]byte, tenantKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generate key : %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(key), nil
+}
+
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(sum[:])
+}
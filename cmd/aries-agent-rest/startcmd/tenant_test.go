@@ -0,0 +1,509 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/shared"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/prefix"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	adminToken = "admin-token"
+	jwtSecret  = "jwt-secret"
+)
+
+func TestTenants(t *testing.T) {
+	t.Run("routes the REST calls to the tenants by API key", func(t *testing.T) {
+		server, _ := newTenantServer(t, newTenantParams(t))
+
+		alice := createTenant(t, server.URL, `{"label":"alice","webhook_urls":["http://localhost:9999"]}`)
+		require.NotEmpty(t, alice.ID)
+		require.NotEmpty(t, alice.APIKey)
+		require.Equal(t, "alice", alice.Label)
+		require.Equal(t, []string{"http://localhost:9999"}, alice.WebhookURLs)
+
+		status, _ := request(t, http.MethodGet, server.URL+"/connections", nil,
+			map[string]string{tenantAPIKeyHeader: alice.APIKey})
+		require.Equal(t, http.StatusOK, status)
+
+		status, _ = request(t, http.MethodGet, server.URL+"/connections", nil,
+			map[string]string{tenantAPIKeyHeader: "unknown"})
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		status, _ = request(t, http.MethodGet, server.URL+"/connections", nil, nil)
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("routes the REST calls to the tenants by JWT", func(t *testing.T) {
+		server, _ := newTenantServer(t, newTenantParams(t))
+
+		alice := createTenant(t, server.URL, `{"label":"alice"}`)
+
+		for _, test := range []struct {
+			name   string
+			token  string
+			status int
+		}{
+			{"valid", signJWT(t, jwtSecret, map[string]interface{}{"tenant_id": alice.ID}), http.StatusOK},
+			{"other secret", signJWT(t, "other", map[string]interface{}{"tenant_id": alice.ID}), http.StatusUnauthorized},
+			{"unknown tenant", signJWT(t, jwtSecret, map[string]interface{}{"tenant_id": "x"}), http.StatusUnauthorized},
+			{"no tenant", signJWT(t, jwtSecret, map[string]interface{}{"sub": alice.ID}), http.StatusUnauthorized},
+			{"expired", signJWT(t, jwtSecret, map[string]interface{}{
+				"tenant_id": alice.ID, "exp": time.Now().Add(-time.Minute).Unix(),
+			}), http.StatusUnauthorized},
+			{"not a JWT", "token", http.StatusUnauthorized},
+		} {
+			status, _ := request(t, http.MethodGet, server.URL+"/connections", nil,
+				map[string]string{"Authorization": "Bearer " + test.token})
+			require.Equal(t, test.status, status, test.name)
+		}
+	})
+
+	t.Run("isolates the stores and keys of the tenants", func(t *testing.T) {
+		_, tenants := newTenantServer(t, newTenantParams(t))
+
+		alice, _, err := tenants.create(&tenantRequest{Label: "alice"})
+		require.NoError(t, err)
+
+		bob, _, err := tenants.create(&tenantRequest{Label: "bob"})
+		require.NoError(t, err)
+
+		aliceCtx, err := alice.framework.Context()
+		require.NoError(t, err)
+
+		bobCtx, err := bob.framework.Context()
+		require.NoError(t, err)
+
+		kid, _, err := aliceCtx.KMS().Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = aliceCtx.KMS().Get(kid)
+		require.NoError(t, err)
+
+		_, err = bobCtx.KMS().Get(kid)
+		require.Error(t, err)
+	})
+
+	t.Run("restores the tenants", func(t *testing.T) {
+		params := newTenantParams(t)
+		params.tenantLockPassphrase = "passphrase"
+
+		// the mem stores drop their data when the tenant agents close them
+		storeProvider := leveldb.NewProvider(t.TempDir())
+
+		tenants, err := newTenantManager(params, nil, storeProvider, newInboundRouter(t, storeProvider))
+		require.NoError(t, err)
+
+		alice, apiKey, err := tenants.create(&tenantRequest{Label: "alice"})
+		require.NoError(t, err)
+
+		ctx, err := alice.framework.Context()
+		require.NoError(t, err)
+
+		kid, _, err := ctx.KMS().Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		tenants.close()
+
+		restored, err := newTenantManager(params, nil, storeProvider, newInboundRouter(t, storeProvider))
+		require.NoError(t, err)
+
+		defer restored.close()
+
+		req := httptest.NewRequest(http.MethodGet, "/connections", nil)
+		req.Header.Set(tenantAPIKeyHeader, apiKey)
+
+		tenant, err := restored.tenantOf(req)
+		require.NoError(t, err)
+		require.Equal(t, alice.record.ID, tenant.record.ID)
+
+		ctx, err = tenant.framework.Context()
+		require.NoError(t, err)
+
+		_, err = ctx.KMS().Get(kid)
+		require.NoError(t, err)
+
+		// the master keys can't be read with another passphrase
+		params.tenantLockPassphrase = "other"
+
+		restored.close()
+
+		_, err = newTenantManager(params, nil, storeProvider, newInboundRouter(t, storeProvider))
+		require.Error(t, err)
+	})
+
+	t.Run("manages the tenants with the admin API", func(t *testing.T) {
+		server, _ := newTenantServer(t, newTenantParams(t))
+
+		alice := createTenant(t, server.URL, `{"label":"alice"}`)
+		bob := createTenant(t, server.URL, `{"label":"bob"}`)
+
+		admin := map[string]string{"Authorization": "Bearer " + adminToken}
+
+		status, body := request(t, http.MethodGet, server.URL+adminTenantsPath, nil, admin)
+		require.Equal(t, http.StatusOK, status)
+
+		list := &tenantsResponse{}
+		require.NoError(t, json.Unmarshal(body, list))
+		require.Len(t, list.Tenants, 2)
+		require.Equal(t, alice.ID, list.Tenants[0].ID)
+		require.Equal(t, bob.ID, list.Tenants[1].ID)
+		require.Empty(t, list.Tenants[0].APIKey)
+
+		status, body = request(t, http.MethodGet, server.URL+adminTenantsPath+"/"+bob.ID, nil, admin)
+		require.Equal(t, http.StatusOK, status)
+		require.Contains(t, string(body), `"label":"bob"`)
+
+		status, _ = request(t, http.MethodDelete, server.URL+adminTenantsPath+"/"+bob.ID, nil, admin)
+		require.Equal(t, http.StatusNoContent, status)
+
+		status, _ = request(t, http.MethodGet, server.URL+"/connections", nil,
+			map[string]string{tenantAPIKeyHeader: bob.APIKey})
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		status, _ = request(t, http.MethodGet, server.URL+adminTenantsPath+"/"+bob.ID, nil, admin)
+		require.Equal(t, http.StatusNotFound, status)
+
+		status, _ = request(t, http.MethodDelete, server.URL+adminTenantsPath+"/"+bob.ID, nil, admin)
+		require.Equal(t, http.StatusNotFound, status)
+
+		status, _ = request(t, http.MethodPost, server.URL+adminTenantsPath, strings.NewReader("{"), admin)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("protects the admin API with the api token", func(t *testing.T) {
+		server, _ := newTenantServer(t, newTenantParams(t))
+
+		alice := createTenant(t, server.URL, `{"label":"alice"}`)
+
+		for _, headers := range []map[string]string{
+			nil,
+			{"Authorization": "Bearer other"},
+			{tenantAPIKeyHeader: alice.APIKey},
+		} {
+			status, _ := request(t, http.MethodGet, server.URL+adminTenantsPath, nil, headers)
+			require.Equal(t, http.StatusUnauthorized, status)
+
+			status, _ = request(t, http.MethodGet, server.URL+metricsPath, nil, headers)
+			require.Equal(t, http.StatusUnauthorized, status)
+		}
+
+		status, _ := request(t, http.MethodGet, server.URL+metricsPath, nil,
+			map[string]string{"Authorization": "Bearer " + adminToken})
+		require.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("requires the api token", func(t *testing.T) {
+		params := newTenantParams(t)
+		params.token = ""
+
+		_, err := startTenantAgents(params, nil, mux.NewRouter())
+		require.Equal(t, errAdminToken, err)
+	})
+
+	t.Run("requires the tenant lock passphrase", func(t *testing.T) {
+		params := newTenantParams(t)
+		params.tenantLockPassphrase = ""
+
+		_, err := startTenantAgents(params, nil, mux.NewRouter())
+		require.Equal(t, errTenantLockPassphrase, err)
+
+		storeProvider := mem.NewProvider()
+
+		_, err = newTenantManager(params, nil, storeProvider, newInboundRouter(t, storeProvider))
+		require.Equal(t, errTenantLockPassphrase, err)
+	})
+
+	t.Run("purges the data of the deleted tenants", func(t *testing.T) {
+		params := newTenantParams(t)
+
+		// the mem stores drop their data when the tenant agents close them
+		storeProvider := leveldb.NewProvider(t.TempDir())
+
+		tenants, err := newTenantManager(params, nil, storeProvider, newInboundRouter(t, storeProvider))
+		require.NoError(t, err)
+
+		defer tenants.close()
+
+		alice, _, err := tenants.create(&tenantRequest{Label: "alice"})
+		require.NoError(t, err)
+
+		bob, _, err := tenants.create(&tenantRequest{Label: "bob"})
+		require.NoError(t, err)
+
+		tenantStores := func(id string) []storage.Store {
+			t.Helper()
+
+			var stores []storage.Store
+
+			for _, name := range []string{localkms.Namespace, connection.Namespace} {
+				store, openErr := storeProvider.OpenStore(fmt.Sprintf(tenantStorePrefix, id) + name)
+				require.NoError(t, openErr)
+
+				stores = append(stores, store)
+			}
+
+			return stores
+		}
+
+		var kids []string
+
+		for _, tenant := range []*tenant{alice, bob} {
+			ctx, ctxErr := tenant.framework.Context()
+			require.NoError(t, ctxErr)
+
+			kid, _, createErr := ctx.KMS().Create(kms.ED25519Type)
+			require.NoError(t, createErr)
+
+			kids = append(kids, kid)
+
+			recorder, recErr := connection.NewRecorder(ctx)
+			require.NoError(t, recErr)
+
+			require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+				ConnectionID: "conn", State: connection.StateNameCompleted, MyDID: "did:example:a", TheirDID: "b",
+			}))
+		}
+
+		require.NoError(t, tenants.delete(alice.record.ID))
+
+		stores := tenantStores(alice.record.ID)
+
+		_, err = stores[0].Get(prefix.StorageKIDPrefix + kids[0])
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		_, err = stores[1].Get("conn_conn")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		// the data of the other tenants is left untouched
+		stores = tenantStores(bob.record.ID)
+
+		_, err = stores[0].Get(prefix.StorageKIDPrefix + kids[1])
+		require.NoError(t, err)
+
+		keys, err := storeProvider.OpenStore("sharedinboundkeys")
+		require.NoError(t, err)
+
+		_, err = keys.Get(kids[0])
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		_, err = keys.Get(kids[1])
+		require.NoError(t, err)
+	})
+
+	t.Run("connects tenants through the shared inbound transport", func(t *testing.T) {
+		inboundHost := randomURL()
+
+		params := newTenantParams(t)
+		params.inboundHostInternals = []string{httpProtocol + "@" + inboundHost}
+		params.inboundHostExternals = []string{httpProtocol + "@http://" + inboundHost}
+		params.outboundTransports = []string{httpProtocol}
+		params.autoAccept = true
+
+		server, _ := newTenantServer(t, params)
+
+		alice := createTenant(t, server.URL, `{"label":"alice"}`)
+		bob := createTenant(t, server.URL, `{"label":"bob"}`)
+
+		status, body := request(t, http.MethodPost, server.URL+"/connections/create-invitation", nil,
+			map[string]string{tenantAPIKeyHeader: alice.APIKey})
+		require.Equal(t, http.StatusOK, status)
+
+		invitation := &struct {
+			Invitation json.RawMessage `json:"invitation"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, invitation))
+		require.Contains(t, string(invitation.Invitation), inboundHost)
+
+		status, _ = request(t, http.MethodPost, server.URL+"/connections/receive-invitation",
+			bytes.NewReader(invitation.Invitation), map[string]string{tenantAPIKeyHeader: bob.APIKey})
+		require.Equal(t, http.StatusOK, status)
+
+		for _, apiKey := range []string{alice.APIKey, bob.APIKey} {
+			require.Eventually(t, func() bool {
+				_, body := request(t, http.MethodGet, server.URL+"/connections?state=completed", nil,
+					map[string]string{tenantAPIKeyHeader: apiKey})
+
+				return strings.Contains(string(body), `"State":"completed"`)
+			}, 10*time.Second, 100*time.Millisecond)
+		}
+	})
+}
+
+func TestStartCmdWithMultiTenant(t *testing.T) {
+	t.Run("start in multi-tenant mode", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentTokenFlagName, adminToken,
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentMultiTenantFlagName, "true",
+			"--" + agentTenantJWTSecretFlagName, jwtSecret,
+			"--" + agentTenantLockPassphraseFlagName, "passphrase",
+		})
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("multi-tenant mode requires the api token", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentMultiTenantFlagName, "true",
+		})
+
+		require.Equal(t, errAdminToken, startCmd.Execute())
+	})
+
+	t.Run("invalid multi-tenant value", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentMultiTenantFlagName, "maybe",
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse "+agentMultiTenantFlagName)
+	})
+}
+
+func newInboundRouter(t *testing.T, storeProvider storage.Provider) *shared.Router {
+	t.Helper()
+
+	router, err := shared.NewRouter(storeProvider)
+	require.NoError(t, err)
+
+	return router
+}
+
+func newTenantParams(t *testing.T) *agentParameters {
+	t.Helper()
+
+	return &agentParameters{
+		host:                 "localhost:0",
+		token:                adminToken,
+		tenantJWTSecret:      jwtSecret,
+		tenantLockPassphrase: "passphrase",
+		dbParam:              &dbParam{dbType: databaseTypeMemOption},
+		shutdownTimeout:      time.Second,
+	}
+}
+
+// newTenantServer serves the multi-tenant REST API of params.
+func newTenantServer(t *testing.T, params *agentParameters) (*httptest.Server, *tenantManager) {
+	t.Helper()
+
+	router := mux.NewRouter()
+
+	closeAgents, err := startTenantAgents(params, nil, router)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router)
+
+	t.Cleanup(func() {
+		server.Close()
+		closeAgents()
+	})
+
+	var tenants *tenantManager
+
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if m, ok := route.GetHandler().(*tenantManager); ok {
+			tenants = m
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, tenants)
+
+	return server, tenants
+}
+
+func createTenant(t *testing.T, serverURL, req string) *tenantResponse {
+	t.Helper()
+
+	status, body := request(t, http.MethodPost, serverURL+adminTenantsPath, strings.NewReader(req),
+		map[string]string{"Authorization": "Bearer " + adminToken})
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	resp := &tenantResponse{}
+	require.NoError(t, json.Unmarshal(body, resp))
+
+	return resp
+}
+
+func request(t *testing.T, method, url string, body io.Reader, headers map[string]string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, body) // nolint:noctx
+	require.NoError(t, err)
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, respBody
+}
+
+func signJWT(t *testing.T, secret string, claims map[string]interface{}) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput)) // nolint:errcheck,gosec
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
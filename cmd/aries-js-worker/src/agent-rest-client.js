@@ -136,6 +136,10 @@ const pkgs = {
             path: "/verifiable/credential/validate",
             method: "POST"
         },
+        VerifyCredential: {
+            path: "/verifiable/credential/verify",
+            method: "POST"
+        },
         SaveCredential: {
             path: "/verifiable/credential",
             method: "POST"
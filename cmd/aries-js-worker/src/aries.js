@@ -937,6 +937,16 @@ const Aries = function (opts) {
                 return invoke(aw, pending, this.pkgname, "ValidateCredential", req, "timeout while validating verifiable credential")
             },
 
+            /**
+             * Verifies a verifiable credential against a verification policy.
+             *
+             * @param req - json document with the verifiable credential and the policy
+             * @returns {Promise<Object>} - verification report
+             */
+            verifyCredential: async function (req) {
+                return invoke(aw, pending, this.pkgname, "VerifyCredential", req, "timeout while verifying verifiable credential")
+            },
+
             /**
              * Saves a verifiable credential.
              *
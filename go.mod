@@ -37,6 +37,7 @@ require (
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
 	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v3 v3.0.1
 	nhooyr.io/websocket v1.8.3
 )
 
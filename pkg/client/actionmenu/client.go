@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	service.Event
+	PublishMenu(connectionID string, menu *actionmenu.Menu) (*actionmenu.Menu, error)
+	MyMenu(connectionID string) (*actionmenu.Menu, error)
+	TheirMenu(connectionID string) (*actionmenu.Menu, error)
+	RequestMenu(connectionID string) error
+	Perform(connectionID, name string, params map[string]string) error
+	Actions() ([]actionmenu.Action, error)
+	ActionContinue(piID string, opt actionmenu.Opt) error
+	ActionStop(piID string, cause error) error
+}
+
+// Client enables access to the action menu api.
+type Client struct {
+	service.Event
+	actionMenuSvc protocolService
+}
+
+// New returns new instance of the action menu client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(actionmenu.ActionMenu)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action menu service: %w", err)
+	}
+
+	actionMenuSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to action menu service failed")
+	}
+
+	return &Client{Event: actionMenuSvc, actionMenuSvc: actionMenuSvc}, nil
+}
+
+// PublishMenu makes the menu the current menu offered over the connection and sends it to the other agent.
+func (c *Client) PublishMenu(connectionID string, menu *actionmenu.Menu) (*actionmenu.Menu, error) {
+	published, err := c.actionMenuSvc.PublishMenu(connectionID, menu)
+	if err != nil {
+		return nil, fmt.Errorf("action menu client - publish menu: %w", err)
+	}
+
+	return published, nil
+}
+
+// Menu returns the current menu offered over the connection.
+func (c *Client) Menu(connectionID string) (*actionmenu.Menu, error) {
+	menu, err := c.actionMenuSvc.MyMenu(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("action menu client - menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// TheirMenu returns the last menu received over the connection.
+func (c *Client) TheirMenu(connectionID string) (*actionmenu.Menu, error) {
+	menu, err := c.actionMenuSvc.TheirMenu(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("action menu client - their menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+// RequestMenu requests the agent at the other end of the connection to send its current menu, the menu being
+// available with TheirMenu once received.
+func (c *Client) RequestMenu(connectionID string) error {
+	if err := c.actionMenuSvc.RequestMenu(connectionID); err != nil {
+		return fmt.Errorf("action menu client - request menu: %w", err)
+	}
+
+	return nil
+}
+
+// Perform requests the agent at the other end of the connection to perform an option of its menu, the params being
+// the values of the option form.
+func (c *Client) Perform(connectionID, name string, params map[string]string) error {
+	if err := c.actionMenuSvc.Perform(connectionID, name, params); err != nil {
+		return fmt.Errorf("action menu client - perform: %w", err)
+	}
+
+	return nil
+}
+
+// Actions returns the perform requests waiting to be accepted or declined.
+func (c *Client) Actions() ([]actionmenu.Action, error) {
+	actions, err := c.actionMenuSvc.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("action menu client - actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// AcceptPerform accepts the perform request with the piID, the menu being published as the new current menu of the
+// connection when given.
+func (c *Client) AcceptPerform(piID string, menu *actionmenu.Menu) error {
+	var opt actionmenu.Opt
+
+	if menu != nil {
+		opt = actionmenu.WithMenu(menu)
+	}
+
+	if err := c.actionMenuSvc.ActionContinue(piID, opt); err != nil {
+		return fmt.Errorf("action menu client - accept perform: %w", err)
+	}
+
+	return nil
+}
+
+// DeclinePerform declines the perform request with the piID. When a reason is given, the current menu is sent back
+// with it as error message.
+func (c *Client) DeclinePerform(piID, reason string) error {
+	var cause error
+
+	if reason != "" {
+		cause = errors.New(reason)
+	}
+
+	if err := c.actionMenuSvc.ActionStop(piID, cause); err != nil {
+		return fmt.Errorf("action menu client - decline perform: %w", err)
+	}
+
+	return nil
+}
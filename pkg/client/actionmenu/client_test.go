@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mockactionmenu "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/actionmenu"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockactionmenu.MockActionMenuSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("cast error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.EqualError(t, err, "cast service to action menu service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		menu := &actionmenu.Menu{Title: "menu", Options: []actionmenu.MenuOption{{Name: "option"}}}
+
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockactionmenu.MockActionMenuSvc{
+			MyMenuValue:    menu,
+			TheirMenuValue: menu,
+			ActionsValue:   []actionmenu.Action{{PIID: "piid"}},
+			PerformFunc: func(connectionID, name string, params map[string]string) error {
+				require.Equal(t, "conn", connectionID)
+				require.Equal(t, "option", name)
+				require.Equal(t, "value", params["param"])
+
+				return nil
+			},
+		}})
+		require.NoError(t, err)
+
+		published, err := client.PublishMenu("conn", menu)
+		require.NoError(t, err)
+		require.Equal(t, menu, published)
+
+		result, err := client.Menu("conn")
+		require.NoError(t, err)
+		require.Equal(t, menu, result)
+
+		result, err = client.TheirMenu("conn")
+		require.NoError(t, err)
+		require.Equal(t, menu, result)
+
+		require.NoError(t, client.RequestMenu("conn"))
+		require.NoError(t, client.Perform("conn", "option", map[string]string{"param": "value"}))
+
+		actions, err := client.Actions()
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+
+		require.NoError(t, client.AcceptPerform("piid", menu))
+		require.NoError(t, client.AcceptPerform("piid", nil))
+		require.NoError(t, client.DeclinePerform("piid", "reason"))
+		require.NoError(t, client.DeclinePerform("piid", ""))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockactionmenu.MockActionMenuSvc{
+			PublishMenuErr: errors.New("publish error"),
+			MyMenuErr:      actionmenu.ErrMenuNotFound,
+			TheirMenuErr:   actionmenu.ErrMenuNotFound,
+			RequestMenuErr: errors.New("request error"),
+			PerformFunc: func(string, string, map[string]string) error {
+				return errors.New("perform error")
+			},
+			ActionsErr:        errors.New("actions error"),
+			ActionContinueErr: errors.New("continue error"),
+			ActionStopErr:     errors.New("stop error"),
+		}})
+		require.NoError(t, err)
+
+		_, err = client.PublishMenu("conn", &actionmenu.Menu{})
+		require.EqualError(t, err, "action menu client - publish menu: publish error")
+
+		_, err = client.Menu("conn")
+		require.ErrorIs(t, err, actionmenu.ErrMenuNotFound)
+
+		_, err = client.TheirMenu("conn")
+		require.ErrorIs(t, err, actionmenu.ErrMenuNotFound)
+
+		require.EqualError(t, client.RequestMenu("conn"), "action menu client - request menu: request error")
+		require.EqualError(t, client.Perform("conn", "option", nil), "action menu client - perform: perform error")
+
+		_, err = client.Actions()
+		require.EqualError(t, err, "action menu client - actions: actions error")
+
+		require.EqualError(t, client.AcceptPerform("piid", nil),
+			"action menu client - accept perform: continue error")
+		require.EqualError(t, client.DeclinePerform("piid", ""),
+			"action menu client - decline perform: stop error")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -38,6 +40,7 @@ var ErrConnectionNotFound = errors.New("connection not found")
 type options struct {
 	routerConnections  []string
 	routerConnectionID string
+	removeStoredData   bool
 }
 
 func applyOptions(args ...Opt) *options {
@@ -75,6 +78,25 @@ func WithRouterConnections(conns ...string) Opt {
 	}
 }
 
+// RemoveOpt represents option for the RemoveConnection function.
+type RemoveOpt Opt
+
+// WithStoredData removes, along with the connection record, the event data and the namespaced thread ID mapping kept
+// by the DID exchange service for the connection, and the credentials and presentations stored for it. The DID key
+// mappings of the DID connection store, possibly used by other connections, and the state of the other protocols run
+// over the connection (e.g. issue-credential, present-proof or introduce) are kept.
+func WithStoredData() RemoveOpt {
+	return func(opts *options) {
+		opts.removeStoredData = true
+	}
+}
+
+// verifiableStoreProvider is implemented by the providers giving access to the stored credentials, which WithStoredData
+// removes along with their connection.
+type verifiableStoreProvider interface {
+	VerifiableStore() verifiable.Store
+}
+
 // provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context().
 type provider interface {
 	Service(id string) (interface{}, error)
@@ -94,6 +116,7 @@ type Client struct {
 	kms              kms.KeyManager
 	serviceEndpoint  string
 	connectionStore  *connection.Recorder
+	verifiableStore  verifiable.Store
 	keyType          kms.KeyType
 	keyAgreementType kms.KeyType
 }
@@ -155,6 +178,12 @@ func New(ctx provider) (*Client, error) {
 		keyAgreementType = kms.X25519ECDHKWType
 	}
 
+	var verifiableStore verifiable.Store
+
+	if p, ok := ctx.(verifiableStoreProvider); ok {
+		verifiableStore = p.VerifiableStore()
+	}
+
 	return &Client{
 		Event:            didexchangeSvc,
 		didexchangeSvc:   didexchangeSvc,
@@ -162,6 +191,7 @@ func New(ctx provider) (*Client, error) {
 		kms:              ctx.KMS(),
 		serviceEndpoint:  ctx.ServiceEndpoint(),
 		connectionStore:  connectionStore,
+		verifiableStore:  verifiableStore,
 		keyType:          keyType,
 		keyAgreementType: keyAgreementType,
 	}, nil
@@ -306,7 +336,7 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) { //nolint: gocyclo
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
 	//  also results needs to be paged.
-	records, err := c.connectionStore.QueryConnectionRecords()
+	records, err := c.queryConnectionRecords(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed query connections: %w", err)
 	}
@@ -334,12 +364,72 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 			continue
 		}
 
+		if !matchesLifecycle(request, record) {
+			continue
+		}
+
 		result = append(result, &Connection{Record: record})
 	}
 
 	return result, nil
 }
 
+// queryConnectionRecords queries the connection records by one of the metadata or tags criteria, if any, through the
+// storage tags.
+func (c *Client) queryConnectionRecords(request *QueryConnectionsParams) ([]*connection.Record, error) {
+	if len(request.Metadata) > 0 {
+		keys := make([]string, 0, len(request.Metadata))
+
+		for key := range request.Metadata {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		return c.connectionStore.QueryConnectionRecordsByMetadata(keys[0], request.Metadata[keys[0]])
+	}
+
+	if len(request.Tags) > 0 {
+		return c.connectionStore.QueryConnectionRecordsByTag(request.Tags[0])
+	}
+
+	return c.connectionStore.QueryConnectionRecords()
+}
+
+// matchesLifecycle checks whether the connection record has the metadata, tags and status of the request.
+func matchesLifecycle(request *QueryConnectionsParams, record *connection.Record) bool {
+	for key, value := range request.Metadata {
+		if record.Metadata[key] != value {
+			return false
+		}
+	}
+
+	for _, tag := range request.Tags {
+		if !hasTag(record, tag) {
+			return false
+		}
+	}
+
+	switch request.Status {
+	case "":
+		return true
+	case connection.StatusActive:
+		return record.IsActive()
+	default:
+		return request.Status == record.Status
+	}
+}
+
+func hasTag(record *connection.Record, tag string) bool {
+	for _, t := range record.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetConnection fetches single connection record for given id.
 func (c *Client) GetConnection(connectionID string) (*Connection, error) {
 	conn, err := c.connectionStore.GetConnectionRecord(connectionID)
@@ -404,12 +494,128 @@ func (c *Client) CreateConnection(myDID string, theirDID *did.Doc, options ...Co
 }
 
 // RemoveConnection removes connection record for given id.
-func (c *Client) RemoveConnection(connectionID string) error {
-	err := c.connectionStore.RemoveConnection(connectionID)
+// With the WithStoredData option, the DID exchange event data and thread ID mapping and the stored credentials and
+// presentations of the connection are removed too.
+func (c *Client) RemoveConnection(connectionID string, args ...RemoveOpt) error {
+	opts := &options{}
+
+	for i := range args {
+		args[i](opts)
+	}
+
+	var (
+		record *connection.Record
+		err    error
+	)
+
+	if opts.removeStoredData {
+		record, err = c.connectionStore.GetConnectionRecord(connectionID)
+		if err != nil {
+			return fmt.Errorf("cannot remove connection from the store: err=%w", err)
+		}
+	}
+
+	err = c.connectionStore.RemoveConnection(connectionID)
 	if err != nil {
 		return fmt.Errorf("cannot remove connection from the store: err=%w", err)
 	}
 
+	if !opts.removeStoredData {
+		return nil
+	}
+
+	if err = c.connectionStore.RemoveExchangeData(record); err != nil {
+		return fmt.Errorf("cannot remove connection exchange data: err=%w", err)
+	}
+
+	if err = c.removeStoredCredentials(record); err != nil {
+		return fmt.Errorf("cannot remove connection credentials: err=%w", err)
+	}
+
+	return nil
+}
+
+// removeStoredCredentials removes the credentials and presentations exchanged over the connection.
+func (c *Client) removeStoredCredentials(record *connection.Record) error {
+	if c.verifiableStore == nil || record.MyDID == "" || record.TheirDID == "" {
+		return nil
+	}
+
+	credentials, err := c.verifiableStore.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("get credentials: %w", err)
+	}
+
+	for _, credential := range credentials {
+		if credential.MyDID == record.MyDID && credential.TheirDID == record.TheirDID {
+			if err = c.verifiableStore.RemoveCredentialByName(credential.Name); err != nil {
+				return fmt.Errorf("remove credential %s: %w", credential.Name, err)
+			}
+		}
+	}
+
+	presentations, err := c.verifiableStore.GetPresentations()
+	if err != nil {
+		return fmt.Errorf("get presentations: %w", err)
+	}
+
+	for _, presentation := range presentations {
+		if presentation.MyDID == record.MyDID && presentation.TheirDID == record.TheirDID {
+			if err = c.verifiableStore.RemovePresentationByName(presentation.Name); err != nil {
+				return fmt.Errorf("remove presentation %s: %w", presentation.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateConnectionMetadata merges metadata into the metadata of the connection, the keys with an empty value being
+// removed. The connections can then be queried by their metadata.
+func (c *Client) UpdateConnectionMetadata(connectionID string, metadata map[string]string) error {
+	return c.updateConnection(connectionID, func() error {
+		return c.connectionStore.UpdateMetadata(connectionID, metadata)
+	})
+}
+
+// SetConnectionTags replaces the tags of the connection. The connections can then be queried by their tags.
+func (c *Client) SetConnectionTags(connectionID string, tags ...string) error {
+	return c.updateConnection(connectionID, func() error {
+		return c.connectionStore.SetTags(connectionID, tags...)
+	})
+}
+
+// ActivateConnection sets the lifecycle status of the connection back to active.
+func (c *Client) ActivateConnection(connectionID string) error {
+	return c.updateConnection(connectionID, func() error {
+		return c.connectionStore.SetStatus(connectionID, connection.StatusActive)
+	})
+}
+
+// MarkConnectionInactive sets the lifecycle status of the connection to inactive.
+func (c *Client) MarkConnectionInactive(connectionID string) error {
+	return c.updateConnection(connectionID, func() error {
+		return c.connectionStore.SetStatus(connectionID, connection.StatusInactive)
+	})
+}
+
+// ArchiveConnection sets the lifecycle status of the connection to archived.
+func (c *Client) ArchiveConnection(connectionID string) error {
+	return c.updateConnection(connectionID, func() error {
+		return c.connectionStore.SetStatus(connectionID, connection.StatusArchived)
+	})
+}
+
+func (c *Client) updateConnection(connectionID string, update func() error) error {
+	err := update()
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return ErrConnectionNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot update connection: %w", err)
+	}
+
 	return nil
 }
 
@@ -38,6 +38,7 @@ import (
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -1457,3 +1458,191 @@ func unsignedDocAttach(t *testing.T, doc *did.Doc) *decorator.Attachment {
 
 	return att
 }
+
+func TestClient_ConnectionLifecycle(t *testing.T) {
+	newClient := func(t *testing.T, vcStore verifiable.Store) *Client {
+		t.Helper()
+
+		svc, err := didexchange.New(&mockprotocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		c, err := New(&vcStoreProvider{
+			Provider: &mockprovider.Provider{
+				ProtocolStateStorageProviderValue: mem.NewProvider(),
+				StorageProviderValue:              mem.NewProvider(),
+				ServiceMap: map[string]interface{}{
+					didexchange.DIDExchange: svc,
+					mediator.Coordination:   &mockroute.MockMediatorSvc{},
+				},
+			},
+			store: vcStore,
+		})
+		require.NoError(t, err)
+
+		return c
+	}
+
+	saveConnection := func(t *testing.T, c *Client, connID string) {
+		t.Helper()
+
+		record := &connection.Record{
+			ConnectionID: connID,
+			ThreadID:     "thid-" + connID,
+			State:        connection.StateNameCompleted,
+			Namespace:    connection.MyNSPrefix,
+			MyDID:        "did:example:me",
+			TheirDID:     "did:example:" + connID,
+		}
+
+		require.NoError(t, c.connectionStore.SaveConnectionRecordWithMappings(record))
+	}
+
+	t.Run("query by metadata, tags and status", func(t *testing.T) {
+		c := newClient(t, nil)
+
+		saveConnection(t, c, "alice")
+		saveConnection(t, c, "bob")
+		saveConnection(t, c, "carol")
+
+		require.NoError(t, c.UpdateConnectionMetadata("alice", map[string]string{"crm_id": "crm:1", "region": "eu"}))
+		require.NoError(t, c.UpdateConnectionMetadata("bob", map[string]string{"crm_id": "crm:2", "region": "eu"}))
+		require.NoError(t, c.SetConnectionTags("alice", "vip", "partner"))
+		require.NoError(t, c.SetConnectionTags("carol", "partner"))
+		require.NoError(t, c.MarkConnectionInactive("bob"))
+		require.NoError(t, c.ArchiveConnection("carol"))
+
+		connectionIDs := func(params *QueryConnectionsParams) []string {
+			results, err := c.QueryConnections(params)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, result := range results {
+				ids = append(ids, result.ConnectionID)
+			}
+
+			return ids
+		}
+
+		require.Equal(t, []string{"alice"}, connectionIDs(&QueryConnectionsParams{
+			Metadata: map[string]string{"crm_id": "crm:1"},
+		}))
+		require.ElementsMatch(t, []string{"alice", "bob"}, connectionIDs(&QueryConnectionsParams{
+			Metadata: map[string]string{"region": "eu"},
+		}))
+		require.Equal(t, []string{"bob"}, connectionIDs(&QueryConnectionsParams{
+			Metadata: map[string]string{"region": "eu", "crm_id": "crm:2"},
+		}))
+		require.ElementsMatch(t, []string{"alice", "carol"}, connectionIDs(&QueryConnectionsParams{
+			Tags: []string{"partner"},
+		}))
+		require.Equal(t, []string{"alice"}, connectionIDs(&QueryConnectionsParams{
+			Tags: []string{"partner", "vip"},
+		}))
+		require.Equal(t, []string{"alice"}, connectionIDs(&QueryConnectionsParams{Status: connection.StatusActive}))
+		require.Equal(t, []string{"bob"}, connectionIDs(&QueryConnectionsParams{Status: connection.StatusInactive}))
+		require.Equal(t, []string{"carol"}, connectionIDs(&QueryConnectionsParams{
+			Tags:   []string{"partner"},
+			Status: connection.StatusArchived,
+		}))
+
+		require.NoError(t, c.ActivateConnection("carol"))
+		require.ElementsMatch(t, []string{"alice", "carol"}, connectionIDs(&QueryConnectionsParams{
+			Status: connection.StatusActive,
+		}))
+	})
+
+	t.Run("update unknown connection", func(t *testing.T) {
+		c := newClient(t, nil)
+
+		require.ErrorIs(t, c.UpdateConnectionMetadata("unknown", map[string]string{"key": "value"}),
+			ErrConnectionNotFound)
+		require.ErrorIs(t, c.SetConnectionTags("unknown", "tag"), ErrConnectionNotFound)
+		require.ErrorIs(t, c.MarkConnectionInactive("unknown"), ErrConnectionNotFound)
+
+		saveConnection(t, c, "alice")
+
+		err := c.SetConnectionTags("alice", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "tag can't be empty")
+	})
+
+	t.Run("removal with stored data", func(t *testing.T) {
+		vcStore := &credentialStore{
+			credentials: []*verifiable.Record{
+				{Name: "alice-vc", MyDID: "did:example:me", TheirDID: "did:example:alice"},
+				{Name: "bob-vc", MyDID: "did:example:me", TheirDID: "did:example:bob"},
+			},
+			presentations: []*verifiable.Record{
+				{Name: "alice-vp", MyDID: "did:example:me", TheirDID: "did:example:alice"},
+			},
+		}
+
+		c := newClient(t, vcStore)
+
+		saveConnection(t, c, "alice")
+		require.NoError(t, c.connectionStore.SaveEvent("alice", []byte("event")))
+
+		require.NoError(t, c.RemoveConnection("alice", WithStoredData()))
+
+		_, err := c.GetConnection("alice")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		_, err = c.connectionStore.GetEvent("alice")
+		require.ErrorIs(t, err, spi.ErrDataNotFound)
+
+		require.Equal(t, []string{"alice-vc", "alice-vp"}, vcStore.removed)
+
+		err = c.RemoveConnection("alice", WithStoredData())
+		require.ErrorIs(t, err, spi.ErrDataNotFound)
+	})
+
+	t.Run("removal with stored data - credential store error", func(t *testing.T) {
+		c := newClient(t, &credentialStore{err: errors.New("store error")})
+
+		saveConnection(t, c, "alice")
+
+		err := c.RemoveConnection("alice", WithStoredData())
+		require.EqualError(t, err, "cannot remove connection credentials: err=get credentials: store error")
+	})
+}
+
+type vcStoreProvider struct {
+	*mockprovider.Provider
+	store verifiable.Store
+}
+
+func (p *vcStoreProvider) VerifiableStore() verifiable.Store {
+	return p.store
+}
+
+type credentialStore struct {
+	verifiable.Store
+	credentials   []*verifiable.Record
+	presentations []*verifiable.Record
+	removed       []string
+	err           error
+}
+
+func (s *credentialStore) GetCredentials() ([]*verifiable.Record, error) {
+	return s.credentials, s.err
+}
+
+func (s *credentialStore) GetPresentations() ([]*verifiable.Record, error) {
+	return s.presentations, s.err
+}
+
+func (s *credentialStore) RemoveCredentialByName(name string) error {
+	s.removed = append(s.removed, name)
+
+	return nil
+}
+
+func (s *credentialStore) RemovePresentationByName(name string) error {
+	s.removed = append(s.removed, name)
+
+	return nil
+}
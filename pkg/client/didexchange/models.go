@@ -43,6 +43,15 @@ type QueryConnectionsParams struct {
 
 	// TheirRole is other party's role
 	TheirRole string `json:"their_role,omitempty"`
+
+	// Metadata the connections have, all the given keys having to match
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Tags the connections have, all the given tags having to match
+	Tags []string `json:"tags,omitempty"`
+
+	// Status is the lifecycle status of the connections (active, inactive or archived)
+	Status string `json:"status,omitempty"`
 }
 
 // Connection model
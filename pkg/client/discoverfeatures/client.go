@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Disclose(matches ...string) []discoverfeatures.Disclosure
+	Query(connectionID string, matches []string, opts ...discoverfeatures.QueryOpt) ([]discoverfeatures.Disclosure,
+		error)
+}
+
+// Client enables access to the discover features api.
+type Client struct {
+	discoverSvc protocolService
+}
+
+// New returns new instance of the discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discover features service: %w", err)
+	}
+
+	discoverSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{discoverSvc: discoverSvc}, nil
+}
+
+// Query asks the agent at the other end of the connection which of the protocols matching the given matches it
+// supports, a `*` in a match standing for any sequence of characters. Discover features 2.0 is used unless
+// discoverfeatures.WithSpecV1 is given.
+func (c *Client) Query(connectionID string, matches []string,
+	opts ...discoverfeatures.QueryOpt) ([]discoverfeatures.Disclosure, error) {
+	disclosures, err := c.discoverSvc.Query(connectionID, matches, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return disclosures, nil
+}
+
+// Features returns the protocols supported by this agent matching any of the given matches.
+func (c *Client) Features(matches ...string) []discoverfeatures.Disclosure {
+	return c.discoverSvc.Disclose(matches...)
+}
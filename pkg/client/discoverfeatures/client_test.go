@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscover "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("cast error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.EqualError(t, err, "cast service to discover features service failed")
+	})
+}
+
+func TestClient(t *testing.T) {
+	disclosures := []discoverfeatures.Disclosure{
+		{FeatureType: discoverfeatures.FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+	}
+
+	t.Run("query", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{Disclosures: disclosures}})
+		require.NoError(t, err)
+
+		result, err := client.Query("conn", []string{"*"}, discoverfeatures.WithSpecV1())
+		require.NoError(t, err)
+		require.Equal(t, disclosures, result)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{QueryErr: discoverfeatures.ErrNoDisclosure}})
+		require.NoError(t, err)
+
+		_, err = client.Query("conn", []string{"*"})
+		require.ErrorIs(t, err, discoverfeatures.ErrNoDisclosure)
+		require.Contains(t, err.Error(), "discover features client - query")
+	})
+
+	t.Run("features", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{Disclosures: disclosures}})
+		require.NoError(t, err)
+		require.Equal(t, disclosures, client.Features("*"))
+	})
+}
@@ -8,15 +8,21 @@ package issuecredential
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 var (
-	errEmptyOffer    = errors.New("received an empty offer")
-	errEmptyProposal = errors.New("received an empty proposal")
-	errEmptyRequest  = errors.New("received an empty request")
+	errEmptyOffer       = errors.New("received an empty offer")
+	errEmptyProposal    = errors.New("received an empty proposal")
+	errEmptyRequest     = errors.New("received an empty request")
+	errNoConnectionless = errors.New("the provider does not support connectionless exchanges")
 )
 
 type (
@@ -45,6 +51,12 @@ type Provider interface {
 	Service(id string) (interface{}, error)
 }
 
+// connectionlessProvider is implemented by the providers supporting connectionless exchanges, like aries.Context().
+type connectionlessProvider interface {
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
+}
+
 // ProtocolService defines the issuecredential service.
 type ProtocolService interface {
 	service.DIDComm
@@ -56,7 +68,8 @@ type ProtocolService interface {
 // Client enable access to issuecredential API.
 type Client struct {
 	service.Event
-	service ProtocolService
+	service        ProtocolService
+	connectionless connectionlessProvider
 }
 
 // New return new instance of the issuecredential client.
@@ -71,10 +84,16 @@ func New(ctx Provider) (*Client, error) {
 		return nil, errors.New("cast service to issuecredential service failed")
 	}
 
-	return &Client{
+	client := &Client{
 		Event:   svc,
 		service: svc,
-	}, nil
+	}
+
+	if p, ok := ctx.(connectionlessProvider); ok {
+		client.connectionless = p
+	}
+
+	return client, nil
 }
 
 // Actions returns unfinished actions for the async usage.
@@ -103,6 +122,42 @@ func (c *Client) SendOffer(offer *OfferCredential, myDID, theirDID string) (stri
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
 }
 
+// CreateConnectionlessOffer is used by the Issuer to offer a credential without a connection, from a kiosk for
+// instance. It returns the threadID of the new instance of the protocol and the offer to deliver out-of-band to the
+// Holder, in a `d_m` URL or an out-of-band invitation attachment.
+// The offer carries a ~service decorator with a new key of the Issuer, the Holder replies to it.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0056-service-decorator
+func (c *Client) CreateConnectionlessOffer(offer *OfferCredential) (string, service.DIDCommMsgMap, error) {
+	if offer == nil {
+		return "", nil, errEmptyOffer
+	}
+
+	if c.connectionless == nil {
+		return "", nil, errNoConnectionless
+	}
+
+	offer.Type = issuecredential.OfferCredentialMsgType
+
+	svc, err := decorator.NewService(c.connectionless.KMS(), c.connectionless.ServiceEndpoint())
+	if err != nil {
+		return "", nil, fmt.Errorf("create service decorator: %w", err)
+	}
+
+	msg := service.NewDIDCommMsgMap(offer)
+	msg["@id"] = uuid.New().String()
+	msg["~service"] = svc
+
+	piID, err := c.service.HandleOutbound(msg, "", "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the message starts the thread, the recipient replies in it
+	msg["~thread"] = &decorator.Thread{ID: piID}
+
+	return piID, msg, nil
+}
+
 // SendProposal is used by the Holder to send a proposal.
 func (c *Client) SendProposal(proposal *ProposeCredential, myDID, theirDID string) (string, error) {
 	if proposal == nil {
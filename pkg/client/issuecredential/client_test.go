@@ -14,8 +14,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 const (
@@ -83,6 +87,94 @@ func TestClient_SendOffer(t *testing.T) {
 	})
 }
 
+func TestClient_CreateConnectionlessOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const endpoint = "http://issuer.example.com"
+
+	pubKey := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), "", "").
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, issuecredential.OfferCredentialMsgType, msg.Type())
+
+				return msg.ThreadID()
+			})
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue:         svc,
+			KMSValue:             &mockkms.KeyManager{CrAndExportPubKeyValue: pubKey},
+			ServiceEndpointValue: endpoint,
+		})
+		require.NoError(t, err)
+
+		piid, msg, err := client.CreateConnectionlessOffer(&OfferCredential{Comment: "kiosk"})
+		require.NoError(t, err)
+		require.Equal(t, msg.ID(), piid)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, piid, thID)
+
+		didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+		offer := &struct {
+			Comment string             `json:"comment"`
+			Service *decorator.Service `json:"~service"`
+		}{}
+		require.NoError(t, msg.Decode(offer))
+		require.Equal(t, "kiosk", offer.Comment)
+		require.Equal(t, &decorator.Service{RecipientKeys: []string{didKey}, ServiceEndpoint: endpoint}, offer.Service)
+	})
+
+	t.Run("Empty offer", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: mocks.NewMockProtocolService(ctrl)})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessOffer(nil)
+		require.EqualError(t, err, errEmptyOffer.Error())
+	})
+
+	t.Run("Provider without connectionless support", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessOffer(&OfferCredential{})
+		require.EqualError(t, err, errNoConnectionless.Error())
+	})
+
+	t.Run("Key creation error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: mocks.NewMockProtocolService(ctrl),
+			KMSValue:     &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessOffer(&OfferCredential{})
+		require.EqualError(t, err, "create service decorator: create recipient key: test error")
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), "", "").Return("", errors.New("test error"))
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: svc,
+			KMSValue:     &mockkms.KeyManager{CrAndExportPubKeyValue: pubKey},
+		})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessOffer(&OfferCredential{})
+		require.EqualError(t, err, "test error")
+	})
+}
+
 func TestClient_SendProposal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -70,6 +70,9 @@ SPDX-License-Identifier: Apache-2.0
 // The protocol can be initiated by the Issuer or by the Holder.
 // Issuer initiates the protocol.
 //  client.SendOffer(&OfferCredential{}, myDID, theirDID)
+// Issuer initiates the protocol without a connection, from a kiosk for instance. The offer is delivered
+// out-of-band, in a `d_m` URL for instance, and the Holder replies to its ~service decorator.
+//  piid, offer, err := client.CreateConnectionlessOffer(&OfferCredential{})
 // Holder initiates the protocol. There are two options of how to initiate the protocol.
 //
 // 1. The Holder can begin with a proposal.
@@ -57,7 +57,42 @@ func (c *Client) Add(ctx context.Context, docs ...jsonld.ContextDocument) error
 		return fmt.Errorf("marshal AddRequest: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+rest.AddContextPath, bytes.NewReader(b))
+	return c.send(ctx, http.MethodPost, rest.AddContextPath, b, nil)
+}
+
+// List lists the JSON-LD context documents of the underlying storage.
+func (c *Client) List(ctx context.Context) ([]jsonld.StoredContext, error) {
+	var resp cmd.ListResponse
+
+	if err := c.send(ctx, http.MethodGet, rest.ListContextsPath, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Contexts, nil
+}
+
+// Refresh loads the JSON-LD context document of contextURL from its remote URL again.
+func (c *Client) Refresh(ctx context.Context, contextURL string) error {
+	b, err := json.Marshal(cmd.RefreshRequest{URL: contextURL})
+	if err != nil {
+		return fmt.Errorf("marshal RefreshRequest: %w", err)
+	}
+
+	return c.send(ctx, http.MethodPost, rest.RefreshContextPath, b, nil)
+}
+
+// Remove removes the JSON-LD context document of contextURL from the underlying storage.
+func (c *Client) Remove(ctx context.Context, contextURL string) error {
+	b, err := json.Marshal(cmd.RemoveRequest{URL: contextURL})
+	if err != nil {
+		return fmt.Errorf("marshal RemoveRequest: %w", err)
+	}
+
+	return c.send(ctx, http.MethodPost, rest.RemoveContextPath, b, nil)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("new http request: %w", err)
 	}
@@ -78,6 +113,14 @@ func (c *Client) Add(ctx context.Context, docs ...jsonld.ContextDocument) error
 		return getResponseError(res.Body)
 	}
 
+	if result == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
 	return nil
 }
 
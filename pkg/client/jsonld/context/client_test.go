@@ -80,6 +80,82 @@ func TestClient_Add(t *testing.T) {
 	})
 }
 
+func TestClient_List(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, http.MethodGet, req.Method)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewReader(
+						[]byte(`{"contexts":[{"url":"https://example.com/context.jsonld"}]}`))),
+				}, nil
+			},
+		}
+
+		client := jsonldcontext.NewClient("", jsonldcontext.WithHTTPClient(httpClient))
+
+		contexts, err := client.List(context.Background())
+		require.NoError(t, err)
+		require.Len(t, contexts, 1)
+		require.Equal(t, "https://example.com/context.jsonld", contexts[0].URL)
+	})
+
+	t.Run("Fail to decode response", func(t *testing.T) {
+		httpClient := &mockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte("invalid"))),
+				}, nil
+			},
+		}
+
+		client := jsonldcontext.NewClient("", jsonldcontext.WithHTTPClient(httpClient))
+
+		_, err := client.List(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode response")
+	})
+}
+
+func TestClient_Refresh(t *testing.T) {
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/context/refresh", req.URL.Path)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		},
+	}
+
+	client := jsonldcontext.NewClient("", jsonldcontext.WithHTTPClient(httpClient))
+
+	err := client.Refresh(context.Background(), "https://example.com/context.jsonld")
+	require.NoError(t, err)
+}
+
+func TestClient_Remove(t *testing.T) {
+	httpClient := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/context/remove", req.URL.Path)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		},
+	}
+
+	client := jsonldcontext.NewClient("", jsonldcontext.WithHTTPClient(httpClient))
+
+	err := client.Remove(context.Background(), "https://example.com/context.jsonld")
+	require.NoError(t, err)
+}
+
 type mockHTTPClient struct {
 	DoFunc func(req *http.Request) (*http.Response, error)
 }
@@ -13,18 +13,27 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
+var errNoConnectionEventSource = errors.New("no outbound transport keeps a pool of connections")
+
 // provider contains dependencies for the route protocol and is typically created by using aries.Context().
 type provider interface {
 	Service(id string) (interface{}, error)
 }
 
+// outboundTransportsProvider is implemented by the framework context, aries.Context().
+type outboundTransportsProvider interface {
+	OutboundTransports() []transport.OutboundTransport
+}
+
 // Client enable access to route api.
 type Client struct {
 	service.Event
-	routeSvc protocolService
-	options  []mediator.ClientOption
+	routeSvc     protocolService
+	options      []mediator.ClientOption
+	eventSources []transport.ConnectionEventSource
 }
 
 // protocolService defines DID Exchange service.
@@ -43,6 +52,12 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// RemoveKey removes the agent recKey from the router.
+	RemoveKey(connID, recKey string) error
+
+	// KeylistQuery returns the keys of the agent registered with the router.
+	KeylistQuery(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -64,11 +79,21 @@ func New(ctx provider, options ...mediator.ClientOption) (*Client, error) {
 		return nil, errors.New("cast service to route service failed")
 	}
 
-	return &Client{
+	c := &Client{
 		Event:    routeSvc,
 		routeSvc: routeSvc,
 		options:  options,
-	}, nil
+	}
+
+	if p, ok := ctx.(outboundTransportsProvider); ok {
+		for _, t := range p.OutboundTransports() {
+			if src, ok := t.(transport.ConnectionEventSource); ok {
+				c.eventSources = append(c.eventSources, src)
+			}
+		}
+	}
+
+	return c, nil
 }
 
 // Register the agent with the router(passed in connectionID). This function asks router's
@@ -109,3 +134,56 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// RemoveKey removes the recipient key from the router, which then stops forwarding the messages sent to it.
+func (c *Client) RemoveKey(connID, recKey string) error {
+	if err := c.routeSvc.RemoveKey(connID, recKey); err != nil {
+		return fmt.Errorf("router remove key : %w", err)
+	}
+
+	return nil
+}
+
+// GetKeys returns the recipient keys of the agent registered with the router. The page of the keys is selected by
+// paginate, all the keys being returned when it is nil.
+func (c *Client) GetKeys(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+	keylist, err := c.routeSvc.KeylistQuery(connID, paginate)
+	if err != nil {
+		return nil, fmt.Errorf("router keylist query : %w", err)
+	}
+
+	return keylist, nil
+}
+
+// RegisterConnectionEvent registers ch to receive the events of the connections pooled by the outbound transports,
+// like the websocket connections to the router. A router is unaware of a reconnected connection until a message
+// asking for the return route is sent on it, so on transport.ConnectionOpened events whose Attempt is not 0 the
+// agent should send one, like a message pickup status request, to receive the messages queued meanwhile.
+func (c *Client) RegisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if len(c.eventSources) == 0 {
+		return errNoConnectionEventSource
+	}
+
+	for _, src := range c.eventSources {
+		if err := src.RegisterConnectionEvent(ch); err != nil {
+			return fmt.Errorf("register connection event : %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UnregisterConnectionEvent unregisters ch.
+func (c *Client) UnregisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if len(c.eventSources) == 0 {
+		return errNoConnectionEventSource
+	}
+
+	for _, src := range c.eventSources {
+		if err := src.UnregisterConnectionEvent(ch); err != nil {
+			return fmt.Errorf("unregister connection event : %w", err)
+		}
+	}
+
+	return nil
+}
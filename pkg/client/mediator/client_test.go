@@ -16,6 +16,8 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 )
@@ -170,3 +172,128 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_RemoveKey(t *testing.T) {
+	t.Run("test remove key - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, c.RemoveKey("conn", "key"))
+	})
+
+	t.Run("test remove key - error", func(t *testing.T) {
+		expected := errors.New("remove error")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				RemoveKeyErr: expected,
+			},
+		})
+		require.NoError(t, err)
+
+		err = c.RemoveKey("conn", "key")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "router remove key")
+	})
+}
+
+func TestClient_GetKeys(t *testing.T) {
+	t.Run("test get keys - success", func(t *testing.T) {
+		keylist := &mediator.Keylist{Keys: []mediator.KeylistKey{{RecipientKey: "key"}}}
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				Keylist: keylist,
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := c.GetKeys("conn", &mediator.Paginate{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, keylist, result)
+	})
+
+	t.Run("test get keys - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryErr: errors.New("query error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.GetKeys("conn", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "router keylist query")
+	})
+}
+
+func TestClient_RegisterConnectionEvent(t *testing.T) {
+	t.Run("registers with the transports keeping a pool", func(t *testing.T) {
+		src := &mockEventSource{}
+
+		c, err := New(&mockprovider.Provider{
+			ServiceValue:            &mockroute.MockMediatorSvc{},
+			OutboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{}, src},
+		})
+		require.NoError(t, err)
+
+		events := make(chan transport.ConnectionEvent)
+
+		require.NoError(t, c.RegisterConnectionEvent(events))
+		require.Len(t, src.registered, 1)
+
+		require.NoError(t, c.UnregisterConnectionEvent(events))
+		require.Empty(t, src.registered)
+	})
+
+	t.Run("wraps transport error", func(t *testing.T) {
+		expected := errors.New("test")
+
+		c, err := New(&mockprovider.Provider{
+			ServiceValue:            &mockroute.MockMediatorSvc{},
+			OutboundTransportsValue: []transport.OutboundTransport{&mockEventSource{err: expected}},
+		})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, c.RegisterConnectionEvent(make(chan transport.ConnectionEvent)), expected)
+		require.ErrorIs(t, c.UnregisterConnectionEvent(make(chan transport.ConnectionEvent)), expected)
+	})
+
+	t.Run("no transport keeping a pool", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue:            &mockroute.MockMediatorSvc{},
+			OutboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{}},
+		})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, c.RegisterConnectionEvent(make(chan transport.ConnectionEvent)), errNoConnectionEventSource)
+		require.ErrorIs(t, c.UnregisterConnectionEvent(make(chan transport.ConnectionEvent)), errNoConnectionEventSource)
+	})
+}
+
+type mockEventSource struct {
+	mockdidcomm.MockOutboundTransport
+	registered []chan<- transport.ConnectionEvent
+	err        error
+}
+
+func (m *mockEventSource) RegisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.registered = append(m.registered, ch)
+
+	return nil
+}
+
+func (m *mockEventSource) UnregisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.registered = nil
+
+	return nil
+}
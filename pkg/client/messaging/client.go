@@ -9,6 +9,7 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -16,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
@@ -33,6 +35,10 @@ const (
 
 var logger = log.New("aries-framework/client/messaging")
 
+// ErrBasicMessageUnavailable is returned by the basic message operations when the basic message protocol service
+// isn't registered in the framework.
+var ErrBasicMessageUnavailable = errors.New("basic message service not available")
+
 // provider contains dependencies for the message client and is typically created by using aries.Context().
 type provider interface {
 	VDRegistry() vdr.Registry
@@ -42,6 +48,18 @@ type provider interface {
 	KMS() kms.KeyManager
 }
 
+// serviceProvider is implemented by the providers supplying the protocol services, the basic message operations
+// being available through them.
+type serviceProvider interface {
+	Service(id string) (interface{}, error)
+}
+
+type basicMessageService interface {
+	Send(connectionID, content string, opts ...basicmessage.SendOpt) (*basicmessage.Record, error)
+	History(connectionID string, opts ...basicmessage.HistoryOpt) (*basicmessage.History, error)
+	MarkRead(connectionID string, msgIDs ...string) (int, error)
+}
+
 // MessageHandler maintains registered message services
 // and it allows dynamic registration of message services.
 type MessageHandler interface {
@@ -218,6 +236,74 @@ func (c *Client) Reply(ctx context.Context, msg json.RawMessage, msgID string, s
 	return c.sendAndWaitForReply(ctx, action, "", waitForResponse)
 }
 
+// SendBasicMessage sends the content to the agent at the other end of the connection with the basic message
+// protocol, the sent message being kept in the connection history.
+func (c *Client) SendBasicMessage(connectionID, content string,
+	opts ...basicmessage.SendOpt) (*basicmessage.Record, error) {
+	svc, err := c.basicMessageService()
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := svc.Send(connectionID, content, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("send basic message: %w", err)
+	}
+
+	return record, nil
+}
+
+// BasicMessageHistory returns the basic messages sent and received over the connection.
+func (c *Client) BasicMessageHistory(connectionID string,
+	opts ...basicmessage.HistoryOpt) (*basicmessage.History, error) {
+	svc, err := c.basicMessageService()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := svc.History(connectionID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("basic message history: %w", err)
+	}
+
+	return history, nil
+}
+
+// MarkBasicMessagesRead marks the given received basic messages of the connection as read, all its unread messages
+// being marked when no message ID is given. It returns the number of messages newly marked as read.
+func (c *Client) MarkBasicMessagesRead(connectionID string, msgIDs ...string) (int, error) {
+	svc, err := c.basicMessageService()
+	if err != nil {
+		return 0, err
+	}
+
+	marked, err := svc.MarkRead(connectionID, msgIDs...)
+	if err != nil {
+		return 0, fmt.Errorf("mark basic messages read: %w", err)
+	}
+
+	return marked, nil
+}
+
+func (c *Client) basicMessageService() (basicMessageService, error) {
+	prov, ok := c.ctx.(serviceProvider)
+	if !ok {
+		return nil, ErrBasicMessageUnavailable
+	}
+
+	svc, err := prov.Service(basicmessage.BasicMessage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBasicMessageUnavailable, err)
+	}
+
+	basicMsgSvc, ok := svc.(basicMessageService)
+	if !ok {
+		return nil, ErrBasicMessageUnavailable
+	}
+
+	return basicMsgSvc, nil
+}
+
 func (c *Client) sendToConnection(msg service.DIDCommMsgMap, connectionID string) (messageDispatcher, error) {
 	conn, err := c.connectionLookup.GetConnectionRecord(connectionID)
 	if err != nil {
@@ -306,7 +392,7 @@ func (c *Client) sendAndWaitForReply(ctx context.Context, action messageDispatch
 		defer func() {
 			e := c.msgRegistrar.Unregister(topic)
 			if e != nil {
-				logger.Warnf("Failed to unregister wait for reply notifier: %w", e)
+				logger.Warnf("Failed to unregister wait for reply notifier: %s", e)
 			}
 		}()
 	}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -19,10 +20,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockbasicmessage "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mocksvc "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -626,6 +629,80 @@ func TestCommand_Reply(t *testing.T) {
 	})
 }
 
+func TestCommand_BasicMessage(t *testing.T) {
+	newClient := func(t *testing.T, svc interface{}) *Client {
+		t.Helper()
+
+		client, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{basicmessage.BasicMessage: svc},
+		}, msghandler.NewMockMsgServiceProvider(), &mockNotifier{})
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("send, history and mark read", func(t *testing.T) {
+		client := newClient(t, &mockbasicmessage.MockBasicMessageSvc{
+			HistoryFunc: func(connectionID string, opts ...basicmessage.HistoryOpt) (*basicmessage.History, error) {
+				require.Len(t, opts, 1)
+
+				return &basicmessage.History{
+					Messages: []*basicmessage.Record{{ID: "msg-1", ConnectionID: connectionID}},
+					Total:    1,
+				}, nil
+			},
+		})
+
+		record, err := client.SendBasicMessage("conn", "hello", basicmessage.WithSpecV2())
+		require.NoError(t, err)
+		require.Equal(t, "hello", record.Content)
+
+		history, err := client.BasicMessageHistory("conn", basicmessage.WithNewestFirst())
+		require.NoError(t, err)
+		require.Equal(t, 1, history.Total)
+		require.Equal(t, "conn", history.Messages[0].ConnectionID)
+
+		marked, err := client.MarkBasicMessagesRead("conn", "msg-1", "msg-2")
+		require.NoError(t, err)
+		require.Equal(t, 2, marked)
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		client := newClient(t, &mockbasicmessage.MockBasicMessageSvc{
+			SendErr:     errors.New("send error"),
+			HistoryErr:  errors.New("history error"),
+			MarkReadErr: errors.New("mark error"),
+		})
+
+		_, err := client.SendBasicMessage("conn", "hello")
+		require.EqualError(t, err, "send basic message: send error")
+
+		_, err = client.BasicMessageHistory("conn")
+		require.EqualError(t, err, "basic message history: history error")
+
+		_, err = client.MarkBasicMessagesRead("conn")
+		require.EqualError(t, err, "mark basic messages read: mark error")
+	})
+
+	t.Run("service not available", func(t *testing.T) {
+		client := newClient(t, nil)
+
+		_, err := client.SendBasicMessage("conn", "hello")
+		require.ErrorIs(t, err, ErrBasicMessageUnavailable)
+
+		client, err = New(&protocol.MockProvider{ServiceErr: errors.New("lookup error")},
+			msghandler.NewMockMsgServiceProvider(), &mockNotifier{})
+		require.NoError(t, err)
+
+		_, err = client.BasicMessageHistory("conn")
+		require.ErrorIs(t, err, ErrBasicMessageUnavailable)
+		require.Contains(t, err.Error(), "lookup error")
+
+		_, err = client.MarkBasicMessagesRead("conn")
+		require.ErrorIs(t, err, ErrBasicMessageUnavailable)
+	})
+}
+
 // mockNotifier is mock implementation of Notifier.
 type mockNotifier struct {
 	NotifyFunc func(topic string, message []byte) error
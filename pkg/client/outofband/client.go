@@ -25,6 +25,8 @@ import (
 type (
 	// Invitation is this protocol's `invitation` message.
 	Invitation outofband.Invitation
+	// InvitationV2 is the out-of-band 2.0 `invitation` message.
+	InvitationV2 outofband.InvitationV2
 	// Action contains helpful information about action.
 	Action outofband.Action
 )
@@ -32,6 +34,8 @@ type (
 const (
 	// InvitationMsgType is the '@type' for the invitation message.
 	InvitationMsgType = outofband.InvitationMsgType
+	// InvitationMsgTypeV2 is the '@type' for the out-of-band 2.0 invitation message.
+	InvitationMsgTypeV2 = outofband.InvitationMsgTypeV2
 	// HandshakeReuseMsgType is the '@type' for the handshake reuse message.
 	HandshakeReuseMsgType = outofband.HandshakeReuseMsgType
 	// HandshakeReuseAcceptedMsgType is the '@type' for the handshake reuse accepted message.
@@ -100,11 +104,13 @@ type MessageOption func(*message)
 
 type message struct {
 	Label              string
+	From               string
 	Goal               string
 	GoalCode           string
 	RouterConnections  []string
 	Service            []interface{}
 	HandshakeProtocols []string
+	NoHandshake        bool
 	Attachments        []*decorator.Attachment
 	Accept             []string
 	ReuseAnyConnection bool
@@ -123,6 +129,7 @@ func (m *message) RouterConnection() string {
 type OobService interface {
 	service.Event
 	AcceptInvitation(*outofband.Invitation, outofband.Options) (string, error)
+	AcceptInvitationV2(*outofband.InvitationV2, outofband.Options) error
 	SaveInvitation(*outofband.Invitation) error
 	Actions() ([]outofband.Action, error)
 	ActionContinue(string, outofband.Options) error
@@ -165,8 +172,8 @@ func New(p Provider) (*Client, error) {
 
 // CreateInvitation creates and saves an out-of-band invitation.
 // Services are required in the RFC, but optional in this implementation. If not provided, a default will be assigned.
-// TODO HandShakeProtocols are optional in the RFC and as arguments to this function.
-//  However, if not provided, a default will be assigned for you.
+// HandShakeProtocols are optional in the RFC and as arguments to this function. If not provided, the did-exchange
+// protocol is assigned, unless WithoutHandshake is given to create a connectionless invitation.
 func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption) (*Invitation, error) {
 	msg := &message{}
 
@@ -200,7 +207,11 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 		}
 	}
 
-	if len(inv.Protocols) == 0 {
+	if msg.NoHandshake {
+		if len(inv.Protocols) > 0 || len(inv.Requests) == 0 {
+			return nil, errors.New("a connectionless invitation requires attachments and no handshake protocols")
+		}
+	} else if len(inv.Protocols) == 0 {
 		// TODO should be injected into client
 		//  https://github.com/hyperledger/aries-framework-go/issues/1691
 		inv.Protocols = []string{didexchange.PIURI}
@@ -216,6 +227,51 @@ func (c *Client) CreateInvitation(services []interface{}, opts ...MessageOption)
 	return inv, nil
 }
 
+// CreateInvitationV2 creates an out-of-band 2.0 invitation, sent from the DID given with WithFrom.
+// The out-of-band 2.0 invitations have no handshake: the receiver handles their attachments right away and messages
+// the sender's DID to proceed.
+func (c *Client) CreateInvitationV2(opts ...MessageOption) (*InvitationV2, error) {
+	msg := &message{}
+
+	for _, opt := range opts {
+		opt(msg)
+	}
+
+	if msg.From == "" {
+		return nil, errors.New("an out-of-band 2.0 invitation requires the DID of the sender")
+	}
+
+	if _, err := did.Parse(msg.From); err != nil {
+		return nil, fmt.Errorf("invalid DID [%s]: %w", msg.From, err)
+	}
+
+	inv := &InvitationV2{
+		ID:    uuid.New().String(),
+		Type:  InvitationMsgTypeV2,
+		Label: msg.Label,
+		From:  msg.From,
+		Body: &outofband.InvitationBody{
+			Goal:     msg.Goal,
+			GoalCode: msg.GoalCode,
+			Accept:   msg.Accept,
+		},
+	}
+
+	for _, a := range msg.Attachments {
+		inv.Attachments = append(inv.Attachments, &decorator.AttachmentV2{
+			ID:          a.ID,
+			Description: a.Description,
+			FileName:    a.FileName,
+			MediaType:   a.MimeType,
+			LastModTime: a.LastModTime,
+			ByteCount:   a.ByteCount,
+			Data:        a.Data,
+		})
+	}
+
+	return inv, nil
+}
+
 // Actions returns unfinished actions for the async usage.
 func (c *Client) Actions() ([]Action, error) {
 	actions, err := c.oobService.Actions()
@@ -278,6 +334,33 @@ func (c *Client) AcceptInvitation(i *Invitation, myLabel string, opts ...Message
 	return connID, err
 }
 
+// AcceptInvitationV2 accepts an out-of-band 2.0 invitation from another agent. The attachments of the invitation
+// are handled right away, without handshake.
+func (c *Client) AcceptInvitationV2(i *InvitationV2, opts ...MessageOption) error {
+	msg := &message{}
+
+	for _, opt := range opts {
+		opt(msg)
+	}
+
+	cast := outofband.InvitationV2(*i)
+
+	err := c.oobService.AcceptInvitationV2(
+		&cast,
+		&EventOptions{
+			Label:       msg.Label,
+			ReuseAny:    msg.ReuseAnyConnection,
+			ReuseDID:    msg.ReuseConnection,
+			Connections: msg.RouterConnections,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("out-of-band service failed to accept invitation : %w", err)
+	}
+
+	return nil
+}
+
 // WithLabel allows you to specify the label on the message.
 func WithLabel(l string) MessageOption {
 	return func(m *message) {
@@ -312,6 +395,21 @@ func WithHandshakeProtocols(proto ...string) MessageOption {
 	}
 }
 
+// WithoutHandshake creates a connectionless Invitation, without handshake protocols. The receiver handles the
+// attachments of the Invitation right away, so the Invitation must have some.
+func WithoutHandshake() MessageOption {
+	return func(m *message) {
+		m.NoHandshake = true
+	}
+}
+
+// WithFrom allows you to specify the DID of the sender of an out-of-band 2.0 Invitation.
+func WithFrom(from string) MessageOption {
+	return func(m *message) {
+		m.From = from
+	}
+}
+
 // WithAttachments allows you to include attachments in the Invitation.
 func WithAttachments(a ...*decorator.Attachment) MessageOption {
 	return func(m *message) {
@@ -191,6 +191,57 @@ func TestCreateInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Contains(t, inv.Requests, expected)
 	})
+	t.Run("WithoutHandshake", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		inv, err := c.CreateInvitation(nil, WithoutHandshake(), WithAttachments(dummyAttachment(t)))
+		require.NoError(t, err)
+		require.Empty(t, inv.Protocols)
+		require.Len(t, inv.Requests, 1)
+	})
+	t.Run("WithoutHandshake requires attachments and no handshake protocols", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.CreateInvitation(nil, WithoutHandshake())
+		require.EqualError(t, err, "a connectionless invitation requires attachments and no handshake protocols")
+		_, err = c.CreateInvitation(nil, WithoutHandshake(), WithAttachments(dummyAttachment(t)),
+			WithHandshakeProtocols(didexchange.PIURI))
+		require.Error(t, err)
+	})
+}
+
+func TestCreateInvitationV2(t *testing.T) {
+	t.Run("creates an out-of-band 2.0 invitation", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		attachment := dummyAttachment(t)
+		inv, err := c.CreateInvitationV2(
+			WithFrom("did:example:alice"),
+			WithLabel("alice"),
+			WithGoal("issue a VC", "issue-vc"),
+			WithAccept(MediaTypeProfileDIDCommV2),
+			WithAttachments(attachment),
+		)
+		require.NoError(t, err)
+		require.NotEmpty(t, inv.ID)
+		require.Equal(t, "https://didcomm.org/out-of-band/2.0/invitation", inv.Type)
+		require.Equal(t, "did:example:alice", inv.From)
+		require.Equal(t, "alice", inv.Label)
+		require.Equal(t, "issue-vc", inv.Body.GoalCode)
+		require.Equal(t, []string{MediaTypeProfileDIDCommV2}, inv.Body.Accept)
+		require.Len(t, inv.Attachments, 1)
+		require.Equal(t, attachment.MimeType, inv.Attachments[0].MediaType)
+		require.Equal(t, attachment.Data, inv.Attachments[0].Data)
+	})
+	t.Run("requires the DID of the sender", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.CreateInvitationV2()
+		require.EqualError(t, err, "an out-of-band 2.0 invitation requires the DID of the sender")
+		_, err = c.CreateInvitationV2(WithFrom("alice"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid DID [alice]")
+	})
 }
 
 func TestClient_ActionContinue(t *testing.T) {
@@ -306,6 +357,41 @@ func TestAcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestAcceptInvitationV2(t *testing.T) {
+	t.Run("accepts the invitation", func(t *testing.T) {
+		expected := &InvitationV2{ID: uuid.New().String(), Type: InvitationMsgTypeV2}
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptInvV2Func: func(i *outofband.InvitationV2, o outofband.Options) error {
+					require.Equal(t, expected.ID, i.ID)
+					require.Equal(t, "bob", o.MyLabel())
+
+					return nil
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		require.NoError(t, c.AcceptInvitationV2(expected, WithLabel("bob")))
+	})
+	t.Run("wraps error from outofband service", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptInvV2Func: func(*outofband.InvitationV2, outofband.Options) error {
+					return expected
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		err = c.AcceptInvitationV2(&InvitationV2{})
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
 func dummyAttachment(t *testing.T) *decorator.Attachment {
 	t.Helper()
 
@@ -360,6 +446,7 @@ func withTestProvider() *mockprovider.Provider {
 type stubOOBService struct {
 	service.Event
 	acceptInvFunc      func(*outofband.Invitation, outofband.Options) (string, error)
+	acceptInvV2Func    func(*outofband.InvitationV2, outofband.Options) error
 	saveInvFunc        func(*outofband.Invitation) error
 	actionsFunc        func() ([]outofband.Action, error)
 	actionContinueFunc func(string, outofband.Options) error
@@ -374,6 +461,14 @@ func (s *stubOOBService) AcceptInvitation(i *outofband.Invitation, o outofband.O
 	return "", nil
 }
 
+func (s *stubOOBService) AcceptInvitationV2(i *outofband.InvitationV2, o outofband.Options) error {
+	if s.acceptInvV2Func != nil {
+		return s.acceptInvV2Func(i, o)
+	}
+
+	return nil
+}
+
 func (s *stubOOBService) SaveInvitation(i *outofband.Invitation) error {
 	if s.saveInvFunc != nil {
 		return s.saveInvFunc(i)
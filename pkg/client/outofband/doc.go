@@ -23,6 +23,13 @@ SPDX-License-Identifier: Apache-2.0
 // client.AcceptInvitation() respectively. These return the ID of the newly-created connection
 // record.
 //
+// Out-of-band 2.0 invitations are created with client.CreateInvitationV2() and accepted with
+// client.AcceptInvitationV2(). They have no handshake: their attachments are handled right away, like
+// the ones of the connectionless invitations created with the WithoutHandshake() option.
+//
+// Invitations are shared as URLs with EncodeInvitationURL() and read back with DecodeInvitationURL(),
+// or with ResolveInvitationURL() for the shortened URLs.
+//
 // If you're expecting to receive out-of-band invitations or requests via a DIDComm channel then
 // you should register to the action event stream and the state event stream:
 //
@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+)
+
+const (
+	// InvitationURLParam is the query parameter carrying the out-of-band invitations in invitation URLs.
+	// https://github.com/hyperledger/aries-rfcs/tree/main/features/0434-outofband#standard-invitation-encoding
+	InvitationURLParam = "oob"
+	// InvitationV2URLParam is the query parameter carrying the out-of-band 2.0 invitations in invitation URLs.
+	// https://identity.foundation/didcomm-messaging/spec/#standard-message-encoding
+	InvitationV2URLParam = "_oob"
+	// ConnectionInvitationURLParam is the query parameter carrying the connection invitations in invitation URLs.
+	// https://github.com/hyperledger/aries-rfcs/tree/main/features/0160-connection-protocol#standard-invitation-encoding
+	ConnectionInvitationURLParam = "c_i"
+	// MessageURLParam is the query parameter carrying the connectionless messages in URLs.
+	// https://github.com/hyperledger/aries-rfcs/tree/main/features/0056-service-decorator
+	MessageURLParam = "d_m"
+)
+
+// maxShortenedURLResponseSize bounds the JSON messages read from the URL shorteners.
+const maxShortenedURLResponseSize = 1 << 20
+
+var logger = log.New("aries-framework/client/outofband")
+
+// ErrNoMessageInURL is returned when decoding a URL carrying no message in any of the supported query parameters,
+// a shortened URL for instance.
+var ErrNoMessageInURL = errors.New("no message in the URL query")
+
+// urlParams are the query parameters carrying messages, in decoding order.
+var urlParams = []string{ //nolint:gochecknoglobals
+	InvitationURLParam, InvitationV2URLParam, ConnectionInvitationURLParam, MessageURLParam,
+}
+
+// EncodeInvitationURL encodes an out-of-band invitation in the `oob` query parameter of baseURL.
+func EncodeInvitationURL(baseURL string, inv *Invitation) (string, error) {
+	return EncodeMessageURL(baseURL, InvitationURLParam, inv)
+}
+
+// EncodeInvitationV2URL encodes an out-of-band 2.0 invitation in the `_oob` query parameter of baseURL.
+func EncodeInvitationV2URL(baseURL string, inv *InvitationV2) (string, error) {
+	return EncodeMessageURL(baseURL, InvitationV2URLParam, inv)
+}
+
+// EncodeMessageURL encodes msg as base64url JSON in the param query parameter of baseURL, keeping the other query
+// parameters of baseURL.
+func EncodeMessageURL(baseURL, param string, msg interface{}) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse base URL: %w", err)
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("marshal message: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(param, base64.URLEncoding.EncodeToString(raw))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// DecodeInvitationURL decodes the out-of-band invitation of an invitation URL.
+func DecodeInvitationURL(invitationURL string) (*Invitation, error) {
+	msg, err := DecodeMessageURL(invitationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitation(msg)
+}
+
+// DecodeInvitationV2URL decodes the out-of-band 2.0 invitation of an invitation URL.
+func DecodeInvitationV2URL(invitationURL string) (*InvitationV2, error) {
+	msg, err := DecodeMessageURL(invitationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitationV2(msg)
+}
+
+// DecodeMessageURL decodes the message carried by the `oob`, `_oob`, `c_i` or `d_m` query parameter of a URL. The
+// type of the message tells what to do with it, a connection invitation being accepted with the didexchange client
+// for instance. ErrNoMessageInURL is returned if the URL has none of those parameters.
+func DecodeMessageURL(rawURL string) (service.DIDCommMsgMap, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+
+	query := u.Query()
+
+	for _, param := range urlParams {
+		encoded := query.Get(param)
+		if encoded == "" {
+			continue
+		}
+
+		raw, err := decodeBase64(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s query parameter: %w", param, err)
+		}
+
+		msg, err := service.ParseDIDCommMsgMap(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s query parameter: %w", param, err)
+		}
+
+		return msg, nil
+	}
+
+	return nil, ErrNoMessageInURL
+}
+
+// ResolveURL returns the message of an invitation URL, resolving it with client, http.DefaultClient if nil, if it is
+// a shortened URL.
+//
+// A shortened URL is resolved by fetching it: the URL shortener either redirects to the long URL carrying the message
+// or responds with the JSON message itself.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0434-outofband#url-shortening
+func ResolveURL(client *http.Client, rawURL string) (service.DIDCommMsgMap, error) {
+	msg, err := DecodeMessageURL(rawURL)
+	if !errors.Is(err, ErrNoMessageInURL) {
+		return msg, err
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// don't follow the redirections, their location is the long URL carrying the message
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := noRedirect.Get(rawURL) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("resolve shortened URL: %w", err)
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Warnf("failed to close shortened URL response body: %s", errClose)
+		}
+	}()
+
+	switch {
+	case resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode < http.StatusBadRequest:
+		location, err := resp.Location()
+		if err != nil {
+			return nil, fmt.Errorf("resolve shortened URL: %w", err)
+		}
+
+		return DecodeMessageURL(location.String())
+	case resp.StatusCode == http.StatusOK && isJSON(resp.Header.Get("Content-Type")):
+		raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxShortenedURLResponseSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read shortened URL response: %w", err)
+		}
+
+		if len(raw) > maxShortenedURLResponseSize {
+			return nil, errors.New("resolve shortened URL: response too large")
+		}
+
+		return service.ParseDIDCommMsgMap(raw)
+	default:
+		return nil, fmt.Errorf("resolve shortened URL: unexpected response with status %d and content type '%s'",
+			resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+// ResolveInvitationURL returns the out-of-band invitation of an invitation URL, resolving it with client if it is a
+// shortened URL.
+func ResolveInvitationURL(client *http.Client, invitationURL string) (*Invitation, error) {
+	msg, err := ResolveURL(client, invitationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitation(msg)
+}
+
+func toInvitation(msg service.DIDCommMsgMap) (*Invitation, error) {
+	if msg.Type() != InvitationMsgType && msg.Type() != outofband.OldInvitationMsgType {
+		return nil, fmt.Errorf("not an out-of-band invitation: %s", msg.Type())
+	}
+
+	inv := &Invitation{}
+
+	err := msg.Decode(inv)
+	if err != nil {
+		return nil, fmt.Errorf("decode out-of-band invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+func toInvitationV2(msg service.DIDCommMsgMap) (*InvitationV2, error) {
+	if msg.Type() != InvitationMsgTypeV2 {
+		return nil, fmt.Errorf("not an out-of-band 2.0 invitation: %s", msg.Type())
+	}
+
+	inv := &InvitationV2{}
+
+	err := msg.Decode(inv)
+	if err != nil {
+		return nil, fmt.Errorf("decode out-of-band 2.0 invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// decodeBase64 decodes the query parameters encoded with or without padding, with the URL or standard alphabet, as
+// the encoders differ between agents.
+func decodeBase64(encoded string) ([]byte, error) {
+	// the '+' of the standard alphabet are decoded as spaces from the queries which didn't escape them
+	encoded = strings.ReplaceAll(strings.TrimRight(encoded, "="), " ", "+")
+
+	if strings.ContainsAny(encoded, "+/") {
+		return base64.RawStdEncoding.DecodeString(encoded)
+	}
+
+	return base64.RawURLEncoding.DecodeString(encoded)
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
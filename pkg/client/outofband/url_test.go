@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+)
+
+func TestInvitationURL(t *testing.T) {
+	t.Run("encodes and decodes out-of-band invitations", func(t *testing.T) {
+		expected := newTestInvitation()
+
+		invitationURL, err := EncodeInvitationURL("https://example.com/path?lang=en", expected)
+		require.NoError(t, err)
+
+		u, err := url.Parse(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, "en", u.Query().Get("lang"))
+		require.NotEmpty(t, u.Query().Get(InvitationURLParam))
+
+		inv, err := DecodeInvitationURL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+
+		_, err = DecodeInvitationV2URL(invitationURL)
+		require.EqualError(t, err, "not an out-of-band 2.0 invitation: "+InvitationMsgType)
+	})
+
+	t.Run("encodes and decodes out-of-band 2.0 invitations", func(t *testing.T) {
+		expected := &InvitationV2{
+			ID:   uuid.New().String(),
+			Type: InvitationMsgTypeV2,
+			From: "did:example:alice",
+			Body: &outofband.InvitationBody{GoalCode: "issue-vc", Accept: []string{MediaTypeProfileDIDCommV2}},
+		}
+
+		invitationURL, err := EncodeInvitationV2URL("https://example.com", expected)
+		require.NoError(t, err)
+		require.Contains(t, invitationURL, InvitationV2URLParam+"=")
+
+		inv, err := DecodeInvitationV2URL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+
+		_, err = DecodeInvitationURL(invitationURL)
+		require.EqualError(t, err, "not an out-of-band invitation: "+InvitationMsgTypeV2)
+	})
+
+	t.Run("decodes the out-of-band 2.0 invitation example of the DIDComm messaging spec", func(t *testing.T) {
+		// https://identity.foundation/didcomm-messaging/spec/#standard-message-encoding
+		const invitationURL = "http://example.com/path?_oob=eyJ0eXBlIjoiaHR0cHM6Ly9kaWRjb21tLm9yZy9vdXQtb2YtYmFuZC8yLjAv" +
+			"aW52aXRhdGlvbiIsImlkIjoiNjkyMTJhM2EtZDA2OC00ZjlkLWEyZGQtNDc0MWJjYTg5YWYzIiwiZnJvbSI6ImRpZDpleGFtcGxlOmFsaWNl" +
+			"IiwiYm9keSI6eyJnb2FsX2NvZGUiOiIiLCJnb2FsIjoiIn19"
+
+		inv, err := DecodeInvitationV2URL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, &InvitationV2{
+			ID:   "69212a3a-d068-4f9d-a2dd-4741bca89af3",
+			Type: InvitationMsgTypeV2,
+			From: "did:example:alice",
+			Body: &outofband.InvitationBody{},
+		}, inv)
+
+		raw, err := json.Marshal(inv)
+		require.NoError(t, err)
+		require.Contains(t, string(raw), `"id":"69212a3a-d068-4f9d-a2dd-4741bca89af3"`)
+		require.Contains(t, string(raw), `"type":"`+InvitationMsgTypeV2+`"`)
+		require.NotContains(t, string(raw), `"@type"`)
+	})
+
+	t.Run("decodes the connection invitations and connectionless messages", func(t *testing.T) {
+		for _, param := range []string{ConnectionInvitationURLParam, MessageURLParam} {
+			messageURL, err := EncodeMessageURL("https://example.com", param, &didexchange.Invitation{
+				ID:   "invitation-id",
+				Type: didexchange.InvitationMsgType,
+			})
+			require.NoError(t, err)
+
+			msg, err := DecodeMessageURL(messageURL)
+			require.NoError(t, err)
+			require.Equal(t, didexchange.InvitationMsgType, msg.Type())
+			require.Equal(t, "invitation-id", msg.ID())
+		}
+	})
+
+	t.Run("decodes the parameters encoded with the other base64 variants", func(t *testing.T) {
+		raw, err := json.Marshal(newTestInvitation())
+		require.NoError(t, err)
+
+		for _, encoded := range []string{
+			base64.RawURLEncoding.EncodeToString(raw),
+			base64.StdEncoding.EncodeToString(raw),
+			url.QueryEscape(base64.StdEncoding.EncodeToString(raw)),
+		} {
+			inv, err := DecodeInvitationURL("https://example.com?oob=" + encoded)
+			require.NoError(t, err)
+			require.Equal(t, "alice", inv.Label)
+		}
+	})
+
+	t.Run("fails to decode invalid URLs", func(t *testing.T) {
+		_, err := DecodeMessageURL("https://example.com?lang=en")
+		require.True(t, errors.Is(err, ErrNoMessageInURL))
+
+		_, err = DecodeMessageURL("://example.com")
+		require.Error(t, err)
+
+		_, err = DecodeMessageURL("https://example.com?oob=!!!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode oob query parameter")
+
+		_, err = DecodeMessageURL("https://example.com?oob=" + base64.URLEncoding.EncodeToString([]byte("not json")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse oob query parameter")
+
+		_, err = EncodeMessageURL("://example.com", InvitationURLParam, newTestInvitation())
+		require.Error(t, err)
+
+		_, err = EncodeMessageURL("https://example.com", InvitationURLParam, make(chan int))
+		require.Error(t, err)
+	})
+}
+
+func TestResolveURL(t *testing.T) {
+	expected := newTestInvitation()
+
+	longURL, err := EncodeInvitationURL("https://example.com", expected)
+	require.NoError(t, err)
+
+	// stands in for a URL shortener
+	shortener := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/redirect":
+			http.Redirect(rw, req, longURL, http.StatusFound)
+		case "/json":
+			rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+			require.NoError(t, json.NewEncoder(rw).Encode(expected))
+		case "/large":
+			rw.Header().Set("Content-Type", "application/json")
+			_, err := rw.Write([]byte(`{"label":"` + strings.Repeat("a", maxShortenedURLResponseSize) + `"}`))
+			require.NoError(t, err)
+		case "/no-location":
+			rw.WriteHeader(http.StatusFound)
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	defer shortener.Close()
+
+	t.Run("resolves the shortened URLs redirecting to the long URL", func(t *testing.T) {
+		inv, err := ResolveInvitationURL(shortener.Client(), shortener.URL+"/redirect")
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+	})
+
+	t.Run("resolves the shortened URLs responding with the invitation", func(t *testing.T) {
+		inv, err := ResolveInvitationURL(shortener.Client(), shortener.URL+"/json")
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+	})
+
+	t.Run("resolves the shortened URLs with the default client", func(t *testing.T) {
+		inv, err := ResolveInvitationURL(nil, shortener.URL+"/redirect")
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+	})
+
+	t.Run("decodes the long URLs without fetching them", func(t *testing.T) {
+		inv, err := ResolveInvitationURL(shortener.Client(), longURL)
+		require.NoError(t, err)
+		require.Equal(t, expected, inv)
+	})
+
+	t.Run("fails to resolve shortened URLs", func(t *testing.T) {
+		_, err := ResolveURL(shortener.Client(), shortener.URL+"/unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected response with status 404")
+
+		_, err = ResolveURL(shortener.Client(), shortener.URL+"/no-location")
+		require.Error(t, err)
+
+		_, err = ResolveURL(shortener.Client(), shortener.URL+"/large")
+		require.EqualError(t, err, "resolve shortened URL: response too large")
+
+		_, err = ResolveURL(shortener.Client(), "http://127.0.0.1:0/unreachable")
+		require.Error(t, err)
+
+		_, err = ResolveInvitationURL(shortener.Client(), "://example.com")
+		require.Error(t, err)
+	})
+}
+
+func newTestInvitation() *Invitation {
+	return &Invitation{
+		ID:        uuid.New().String(),
+		Type:      InvitationMsgType,
+		Label:     "alice",
+		Services:  []interface{}{"did:example:alice"},
+		Protocols: []string{didexchange.PIURI},
+	}
+}
@@ -8,10 +8,15 @@ package presentproof
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 type (
@@ -30,6 +35,7 @@ type (
 var (
 	errEmptyRequestPresentation = errors.New("request presentation message is empty")
 	errEmptyProposePresentation = errors.New("propose presentation message is empty")
+	errNoConnectionless         = errors.New("the provider does not support connectionless exchanges")
 )
 
 // Provider contains dependencies for the protocol and is typically created by using aries.Context().
@@ -37,6 +43,12 @@ type Provider interface {
 	Service(id string) (interface{}, error)
 }
 
+// connectionlessProvider is implemented by the providers supporting connectionless exchanges, like aries.Context().
+type connectionlessProvider interface {
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
+}
+
 // ProtocolService defines the presentproof service.
 type ProtocolService interface {
 	service.DIDComm
@@ -49,7 +61,8 @@ type ProtocolService interface {
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0037-present-proof
 type Client struct {
 	service.Event
-	service ProtocolService
+	service        ProtocolService
+	connectionless connectionlessProvider
 }
 
 // New returns new instance of the presentproof client.
@@ -64,10 +77,16 @@ func New(ctx Provider) (*Client, error) {
 		return nil, errors.New("cast service to presentproof service failed")
 	}
 
-	return &Client{
+	client := &Client{
 		Event:   svc,
 		service: svc,
-	}, nil
+	}
+
+	if p, ok := ctx.(connectionlessProvider); ok {
+		client.connectionless = p
+	}
+
+	return client, nil
 }
 
 // Actions returns pending actions that have yet to be executed or cancelled.
@@ -97,6 +116,43 @@ func (c *Client) SendRequestPresentation(msg *RequestPresentation, myDID, theirD
 	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
 }
 
+// CreateConnectionlessRequestPresentation is used by the Verifier to request a presentation without a connection,
+// from a kiosk for instance. It returns the threadID of the new instance of the protocol and the request presentation
+// to deliver out-of-band to the Prover, in a `d_m` URL or an out-of-band invitation attachment.
+// The request carries a ~service decorator with a new key of the Verifier, the Prover replies to it.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0056-service-decorator
+func (c *Client) CreateConnectionlessRequestPresentation(
+	msg *RequestPresentation) (string, service.DIDCommMsgMap, error) {
+	if msg == nil {
+		return "", nil, errEmptyRequestPresentation
+	}
+
+	if c.connectionless == nil {
+		return "", nil, errNoConnectionless
+	}
+
+	msg.Type = presentproof.RequestPresentationMsgType
+
+	svc, err := decorator.NewService(c.connectionless.KMS(), c.connectionless.ServiceEndpoint())
+	if err != nil {
+		return "", nil, fmt.Errorf("create service decorator: %w", err)
+	}
+
+	msgMap := service.NewDIDCommMsgMap(msg)
+	msgMap["@id"] = uuid.New().String()
+	msgMap["~service"] = svc
+
+	piID, err := c.service.HandleInbound(msgMap, service.EmptyDIDCommContext())
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the message starts the thread, the recipient replies in it
+	msgMap["~thread"] = &decorator.Thread{ID: piID}
+
+	return piID, msgMap, nil
+}
+
 type addProof func(presentation *verifiable.Presentation) error
 
 // AcceptRequestPresentation is used by the Prover is to accept a presentation request.
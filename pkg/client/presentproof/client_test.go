@@ -15,8 +15,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 const (
@@ -82,6 +86,94 @@ func TestClient_SendRequestPresentation(t *testing.T) {
 	})
 }
 
+func TestClient_CreateConnectionlessRequestPresentation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const endpoint = "http://verifier.example.com"
+
+	pubKey := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.EmptyDIDCommContext()).
+			DoAndReturn(func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+				require.Equal(t, presentproof.RequestPresentationMsgType, msg.Type())
+
+				return msg.ThreadID()
+			})
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue:         svc,
+			KMSValue:             &mockkms.KeyManager{CrAndExportPubKeyValue: pubKey},
+			ServiceEndpointValue: endpoint,
+		})
+		require.NoError(t, err)
+
+		piID, msg, err := client.CreateConnectionlessRequestPresentation(&RequestPresentation{Comment: "kiosk"})
+		require.NoError(t, err)
+		require.Equal(t, msg.ID(), piID)
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, piID, thID)
+
+		didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+		req := &struct {
+			Comment string             `json:"comment"`
+			Service *decorator.Service `json:"~service"`
+		}{}
+		require.NoError(t, msg.Decode(req))
+		require.Equal(t, "kiosk", req.Comment)
+		require.Equal(t, &decorator.Service{RecipientKeys: []string{didKey}, ServiceEndpoint: endpoint}, req.Service)
+	})
+
+	t.Run("Empty Request Presentation", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: mocks.NewMockProtocolService(ctrl)})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessRequestPresentation(nil)
+		require.EqualError(t, err, errEmptyRequestPresentation.Error())
+	})
+
+	t.Run("Provider without connectionless support", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.EqualError(t, err, errNoConnectionless.Error())
+	})
+
+	t.Run("Key creation error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: mocks.NewMockProtocolService(ctrl),
+			KMSValue:     &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test error")},
+		})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.EqualError(t, err, "create service decorator: create recipient key: test error")
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return("", errors.New("test error"))
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: svc,
+			KMSValue:     &mockkms.KeyManager{CrAndExportPubKeyValue: pubKey},
+		})
+		require.NoError(t, err)
+
+		_, _, err = client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
+		require.EqualError(t, err, "test error")
+	})
+}
+
 func TestClient_SendProposePresentation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
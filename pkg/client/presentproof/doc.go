@@ -66,5 +66,8 @@ SPDX-License-Identifier: Apache-2.0
 //  client.SendProposePresentation(&ProposePresentation{}, myDID, theirDID)
 // Verifier initiates the protocol.
 //  client.SendRequestPresentation(&RequestPresentation{}, myDID, theirDID)
+// Verifier initiates the protocol without a connection, from a kiosk for instance. The request is delivered
+// out-of-band, in a `d_m` URL for instance, and the Prover replies to its ~service decorator.
+//  piid, request, err := client.CreateConnectionlessRequestPresentation(&RequestPresentation{})
 //
 package presentproof
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Ping(connectionID string, opts ...trustping.PingOpt) (*trustping.Result, error)
+}
+
+// Client enables access to the trust ping api.
+type Client struct {
+	trustpingSvc protocolService
+}
+
+// New returns new instance of the trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trust ping service: %w", err)
+	}
+
+	trustpingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{trustpingSvc: trustpingSvc}, nil
+}
+
+// Ping checks that the agent at the other end of the connection is reachable. By default the response is requested
+// and its latency measured, trustping.ErrNoResponse being returned when it doesn't come in time.
+func (c *Client) Ping(connectionID string, opts ...trustping.PingOpt) (*trustping.Result, error) {
+	result, err := c.trustpingSvc.Ping(connectionID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("cast error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.EqualError(t, err, "cast service to trust ping service failed")
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(string, ...trustping.PingOpt) (*trustping.Result, error) {
+				return &trustping.Result{PingID: "ping-id", Responded: true, Latency: time.Millisecond}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		result, err := client.Ping("conn", trustping.WithTimeout(time.Second))
+		require.NoError(t, err)
+		require.True(t, result.Responded)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{PingErr: trustping.ErrNoResponse}})
+		require.NoError(t, err)
+
+		_, err = client.Ping("conn")
+		require.ErrorIs(t, err, trustping.ErrNoResponse)
+		require.Contains(t, err.Error(), "trust ping client - ping")
+	})
+}
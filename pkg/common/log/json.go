@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+// JSONProvider is a LoggerProvider writing one JSON object per log line, for instance:
+//
+//	{"time":"2021-05-20T05:52:14.123Z","level":"INFO","module":"didexchange","msg":"message sent","thid":"abc"}
+//
+// The fields of structured logs follow the time, level, module and msg members in their logging order.
+type JSONProvider struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONProvider returns a JSONProvider writing to w, or to os.Stdout if w is nil. It is set up with
+// Initialize(NewJSONProvider(w)).
+func NewJSONProvider(w io.Writer) *JSONProvider {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	return &JSONProvider{mu: &sync.Mutex{}, w: w}
+}
+
+// GetLogger returns the JSON logger of module.
+func (p *JSONProvider) GetLogger(module string) log.Logger {
+	return &jsonLogger{provider: p, module: module}
+}
+
+type jsonLogger struct {
+	provider *JSONProvider
+	module   string
+}
+
+func (l *jsonLogger) Fatalf(msg string, args ...interface{}) {
+	l.Logw(log.CRITICAL, fmt.Sprintf(msg, args...))
+	os.Exit(1)
+}
+
+func (l *jsonLogger) Panicf(msg string, args ...interface{}) {
+	l.Logw(log.CRITICAL, fmt.Sprintf(msg, args...))
+	panic(fmt.Sprintf(msg, args...))
+}
+
+func (l *jsonLogger) Debugf(msg string, args ...interface{}) {
+	l.Logw(log.DEBUG, fmt.Sprintf(msg, args...))
+}
+
+func (l *jsonLogger) Infof(msg string, args ...interface{}) {
+	l.Logw(log.INFO, fmt.Sprintf(msg, args...))
+}
+
+func (l *jsonLogger) Warnf(msg string, args ...interface{}) {
+	l.Logw(log.WARNING, fmt.Sprintf(msg, args...))
+}
+
+func (l *jsonLogger) Errorf(msg string, args ...interface{}) {
+	l.Logw(log.ERROR, fmt.Sprintf(msg, args...))
+}
+
+func (l *jsonLogger) Logw(level log.Level, msg string, keysAndValues ...interface{}) {
+	var buf bytes.Buffer
+
+	buf.WriteString(`{"time":`)
+	writeJSONValue(&buf, time.Now().UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(&buf, metadata.ParseString(level))
+	buf.WriteString(`,"module":`)
+	writeJSONValue(&buf, l.module)
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(&buf, msg)
+
+	keys, values := modlog.Fields(keysAndValues)
+
+	for i, key := range keys {
+		buf.WriteByte(',')
+		writeJSONValue(&buf, key)
+		buf.WriteByte(':')
+		writeJSONValue(&buf, values[i])
+	}
+
+	buf.WriteString("}\n")
+
+	l.provider.mu.Lock()
+	defer l.provider.mu.Unlock()
+
+	if _, err := l.provider.w.Write(buf.Bytes()); err != nil {
+		fmt.Printf("error writing JSON log: %v\n", err) //nolint:forbidigo
+	}
+}
+
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	switch val := v.(type) {
+	case json.Marshaler:
+		// marshaled as is
+	case error:
+		v = val.Error()
+	case fmt.Stringer:
+		v = val.String()
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		raw, _ = json.Marshal(fmt.Sprint(v)) //nolint:errcheck
+	}
+
+	buf.Write(raw)
+}
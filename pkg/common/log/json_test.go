@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+func TestJSONProvider(t *testing.T) {
+	const module = "sample-module-json"
+
+	SetLevel(module, log.DEBUG)
+
+	var out bytes.Buffer
+
+	logger := newTestLog(module, NewJSONProvider(&out))
+
+	t.Run("f-methods", func(t *testing.T) {
+		defer out.Reset()
+
+		logger.Debugf("debug %d", 1)
+		logger.Infof("info %d", 2)
+		logger.Warnf("warn %d", 3)
+		logger.Errorf("error %d", 4)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 4)
+
+		for i, level := range []string{"DEBUG", "INFO", "WARNING", "ERROR"} {
+			require.Equal(t, level, lines[i]["level"])
+			require.Equal(t, module, lines[i]["module"])
+			require.NotEmpty(t, lines[i]["time"])
+		}
+
+		require.Equal(t, "warn 3", lines[2]["msg"])
+	})
+
+	t.Run("fields in order", func(t *testing.T) {
+		defer out.Reset()
+
+		child := logger.With(FieldConnectionID, "conn-1", FieldThreadID, "thid-1")
+		child.Infow("message sent", FieldMessageType, "https://didcomm.org/basicmessage/1.0/message",
+			"error", errors.New("timeout"), "count", 3, "dangling")
+
+		line := out.String()
+		require.True(t, strings.HasSuffix(line, "}\n"))
+		require.Contains(t, line, `"msg":"message sent","connectionID":"conn-1","thid":"thid-1","msgType":`)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.Equal(t, "timeout", lines[0]["error"])
+		require.EqualValues(t, 3, lines[0]["count"])
+		require.Equal(t, "dangling", lines[0][modlog.BadKey])
+	})
+
+	t.Run("critical", func(t *testing.T) {
+		defer out.Reset()
+
+		require.Panics(t, func() {
+			logger.With(FieldPIID, "piid-1").Panicf("failed %s", "badly")
+		})
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.Equal(t, "CRITICAL", lines[0]["level"])
+		require.Equal(t, "failed badly", lines[0]["msg"])
+		require.Equal(t, "piid-1", lines[0][FieldPIID])
+	})
+}
+
+func newTestLog(module string, provider log.LoggerProvider) *Log {
+	l := New(module)
+	l.once.Do(func() {
+		l.instance = modlog.NewModLog(provider.GetLogger(module), module)
+	})
+
+	return l
+}
+
+func decodeJSONLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(out.Bytes()))
+
+	for dec.More() {
+		line := map[string]interface{}{}
+		require.NoError(t, dec.Decode(&line))
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/modlog"
 	"github.com/hyperledger/aries-framework-go/spi/log"
 )
 
@@ -20,12 +23,32 @@ const (
 	loggerModule            = "aries-framework/common"
 )
 
+// Keys of the fields attached to the logs of DIDComm messages.
+const (
+	// FieldPIID is the key of the protocol instance ID field.
+	FieldPIID = "piid"
+	// FieldThreadID is the key of the thread ID field.
+	FieldThreadID = "thid"
+	// FieldConnectionID is the key of the connection ID field.
+	FieldConnectionID = "connectionID"
+	// FieldMessageType is the key of the message type field.
+	FieldMessageType = "msgType"
+)
+
+// fieldLogger is implemented by the moduled loggers to write structured logs.
+type fieldLogger interface {
+	Logw(level log.Level, msg string, keysAndValues ...interface{})
+}
+
 // Log is an implementation of Logger interface.
 // It encapsulates default or custom logger to provide module and level based logging.
+// Logs can carry key/value fields, see With.
 type Log struct {
 	instance log.Logger
 	module   string
 	once     sync.Once
+	base     *Log
+	fields   []interface{}
 }
 
 // New creates and returns a Logger implementation based on given module name.
@@ -36,39 +59,175 @@ func New(module string) *Log {
 	return &Log{module: module}
 }
 
+// With returns a child logger of the same module which adds the fields given as alternating keys and values in
+// keysAndValues to every log line, for instance:
+//
+//	logger.With(log.FieldConnectionID, connID, log.FieldThreadID, thid).Infof("message sent")
+//
+// Loggers implementing spi/log.FieldLogger receive the fields as structured data, the others get them appended to
+// the message text. The field values are redacted with the policy set by SetRedactionPolicy.
+func (l *Log) With(keysAndValues ...interface{}) *Log {
+	base := l
+	if l.base != nil {
+		base = l.base
+	}
+
+	fields := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	fields = append(fields, l.fields...)
+	fields = append(fields, redactValues(keysAndValues)...)
+
+	return &Log{module: l.module, base: base, fields: fields}
+}
+
 // Fatalf calls Fatalf function of underlying logger
 // should possibly cause system shutdown based on implementation.
 func (l *Log) Fatalf(msg string, args ...interface{}) {
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.fieldLogger().Logw(log.CRITICAL, fmt.Sprintf(msg, args...), l.fields...)
+		os.Exit(1)
+	}
+
 	l.logger().Fatalf(msg, args...)
 }
 
 // Panicf calls Panic function of underlying logger
 // should possibly cause panic based on implementation.
 func (l *Log) Panicf(msg string, args ...interface{}) {
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.fieldLogger().Logw(log.CRITICAL, fmt.Sprintf(msg, args...), l.fields...)
+		panic(fmt.Sprintf(msg, args...))
+	}
+
 	l.logger().Panicf(msg, args...)
 }
 
 // Debugf calls Debugf function of underlying logger.
 func (l *Log) Debugf(msg string, args ...interface{}) {
+	if !metadata.IsEnabledFor(l.module, log.DEBUG) {
+		return
+	}
+
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.logf(log.DEBUG, msg, args...)
+
+		return
+	}
+
 	l.logger().Debugf(msg, args...)
 }
 
 // Infof calls Infof function of underlying logger.
 func (l *Log) Infof(msg string, args ...interface{}) {
+	if !metadata.IsEnabledFor(l.module, log.INFO) {
+		return
+	}
+
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.logf(log.INFO, msg, args...)
+
+		return
+	}
+
 	l.logger().Infof(msg, args...)
 }
 
 // Warnf calls Warnf function of underlying logger.
 func (l *Log) Warnf(msg string, args ...interface{}) {
+	if !metadata.IsEnabledFor(l.module, log.WARNING) {
+		return
+	}
+
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.logf(log.WARNING, msg, args...)
+
+		return
+	}
+
 	l.logger().Warnf(msg, args...)
 }
 
 // Errorf calls Errorf function of underlying logger.
 func (l *Log) Errorf(msg string, args ...interface{}) {
+	if !metadata.IsEnabledFor(l.module, log.ERROR) {
+		return
+	}
+
+	args = redactValues(args)
+
+	if len(l.fields) > 0 {
+		l.logf(log.ERROR, msg, args...)
+
+		return
+	}
+
 	l.logger().Errorf(msg, args...)
 }
 
+// Debugw writes a DEBUG structured log with the fields of the logger and the ones given as alternating keys and
+// values in keysAndValues.
+func (l *Log) Debugw(msg string, keysAndValues ...interface{}) {
+	l.logw(log.DEBUG, msg, keysAndValues)
+}
+
+// Infow writes an INFO structured log, see Debugw.
+func (l *Log) Infow(msg string, keysAndValues ...interface{}) {
+	l.logw(log.INFO, msg, keysAndValues)
+}
+
+// Warnw writes a WARNING structured log, see Debugw.
+func (l *Log) Warnw(msg string, keysAndValues ...interface{}) {
+	l.logw(log.WARNING, msg, keysAndValues)
+}
+
+// Errorw writes an ERROR structured log, see Debugw.
+func (l *Log) Errorw(msg string, keysAndValues ...interface{}) {
+	l.logw(log.ERROR, msg, keysAndValues)
+}
+
+func (l *Log) logf(level log.Level, msg string, args ...interface{}) {
+	l.fieldLogger().Logw(level, fmt.Sprintf(msg, args...), l.fields...)
+}
+
+func (l *Log) logw(level log.Level, msg string, keysAndValues []interface{}) {
+	if !metadata.IsEnabledFor(l.module, level) {
+		return
+	}
+
+	fields := l.fields
+
+	if len(keysAndValues) > 0 {
+		fields = make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+		fields = append(fields, l.fields...)
+		fields = append(fields, redactValues(keysAndValues)...)
+	}
+
+	l.fieldLogger().Logw(level, msg, fields...)
+}
+
+func (l *Log) fieldLogger() fieldLogger {
+	if fl, ok := l.logger().(fieldLogger); ok {
+		return fl
+	}
+
+	// loggers set up without the moduled logger provider, in tests for instance, write the fields as text
+	return modlog.NewModLog(l.logger(), l.module)
+}
+
 func (l *Log) logger() log.Logger {
+	if l.base != nil {
+		return l.base.logger()
+	}
+
 	l.once.Do(func() {
 		l.instance = loggerProvider().GetLogger(l.module)
 	})
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package log
 
 import (
+	"bytes"
 	"sync"
 	"testing"
 
@@ -126,3 +127,44 @@ func verifyLevels(t *testing.T, module string, enabled, disabled []log.Level) {
 			"expected level [%s] to be disabled for module [%s]", levelStr, module)
 	}
 }
+
+// TestWith tests child loggers adding fields to the default logger output.
+func TestWith(t *testing.T) {
+	const module = "sample-module-fields"
+
+	SetLevel(module, log.INFO)
+	ShowCallerInfo(module, log.INFO)
+
+	var out bytes.Buffer
+
+	defLog := modlog.NewDefLog(module)
+	defLog.SetOutput(&out)
+
+	logger := New(module)
+	logger.once.Do(func() {
+		logger.instance = modlog.NewModLog(defLog, module)
+	})
+
+	child := logger.With(FieldConnectionID, "conn-1")
+	grandChild := child.With(FieldThreadID, "thid-1")
+
+	grandChild.Infof("sent %s", "ping")
+	require.Regexp(t, `- log.TestWith -> INFO sent ping connectionID=conn-1 thid=thid-1\n$`, out.String())
+	out.Reset()
+
+	child.Warnw("no response", FieldMessageType, "ping")
+	require.Regexp(t, `- log.TestWith -> WARNING no response connectionID=conn-1 msgType=ping\n$`, out.String())
+	out.Reset()
+
+	// the child fields do not leak to the parent
+	logger.Errorw("failed")
+	require.Regexp(t, `- log.TestWith -> ERROR failed\n$`, out.String())
+	out.Reset()
+
+	child.Debugf("hidden")
+	child.Debugw("hidden")
+	require.Empty(t, out.String())
+
+	logger.Infof("no fields")
+	require.Regexp(t, `- log.TestWith -> INFO no fields\n$`, out.String())
+}
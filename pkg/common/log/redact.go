@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
+)
+
+//nolint:gochecknoglobals
+var (
+	redactionPolicyMu sync.RWMutex
+	redactionPolicy   = redact.DefaultPolicy()
+)
+
+// SetRedactionPolicy sets the policy masking the sensitive members of the logged values, redact.DefaultPolicy() by
+// default. A nil policy disables redaction.
+//
+// The policy applies to the format arguments and the field values which are JSON documents, as strings or bytes,
+// or which are marshaled to JSON, like DIDComm messages and credentials. Values with masked members are logged as
+// their redacted JSON document.
+func SetRedactionPolicy(p *redact.Policy) {
+	redactionPolicyMu.Lock()
+	redactionPolicy = p
+	redactionPolicyMu.Unlock()
+}
+
+func currentRedactionPolicy() *redact.Policy {
+	redactionPolicyMu.RLock()
+	defer redactionPolicyMu.RUnlock()
+
+	return redactionPolicy
+}
+
+// redactValues returns values with the sensitive members of each value masked. values is not modified.
+func redactValues(values []interface{}) []interface{} {
+	p := currentRedactionPolicy()
+	if p == nil {
+		return values
+	}
+
+	var res []interface{}
+
+	for i, v := range values {
+		redacted, changed := redactValue(p, v)
+		if !changed {
+			continue
+		}
+
+		if res == nil {
+			res = append([]interface{}(nil), values...)
+		}
+
+		res[i] = redacted
+	}
+
+	if res == nil {
+		return values
+	}
+
+	return res
+}
+
+func redactValue(p *redact.Policy, v interface{}) (interface{}, bool) {
+	switch val := v.(type) {
+	case nil, error, bool, int, int64, uint, uint64, float64:
+		return v, false
+	case string:
+		if !redact.IsJSON([]byte(val)) {
+			return v, false
+		}
+
+		redacted, changed := p.RedactJSON([]byte(val))
+
+		return string(redacted), changed
+	case []byte:
+		return p.RedactJSON(val)
+	case json.RawMessage:
+		redacted, changed := p.RedactJSON(val)
+
+		return redactedJSON(redacted), changed
+	}
+
+	switch reflect.ValueOf(v).Kind() { //nolint:exhaustive
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array, reflect.Ptr, reflect.Interface:
+	default:
+		return v, false
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v, false
+	}
+
+	redacted, changed := p.RedactJSON(raw)
+	if !changed {
+		return v, false
+	}
+
+	return redactedJSON(redacted), true
+}
+
+// redactedJSON is a redacted JSON document, formatted as text by the text loggers and embedded as is by the JSON
+// ones.
+type redactedJSON []byte
+
+func (r redactedJSON) String() string {
+	return string(r)
+}
+
+func (r redactedJSON) MarshalJSON() ([]byte, error) {
+	return r, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+func TestRedaction(t *testing.T) {
+	const (
+		module = "sample-module-redact"
+		vc     = `{"credentialSubject":{"id":"did:example:alice"},"proof":{"jws":"eyJ..sig"}}`
+		masked = `{"credentialSubject":{"id":"[REDACTED]"},"proof":{"jws":"[REDACTED]"}}`
+	)
+
+	SetLevel(module, log.DEBUG)
+
+	var out bytes.Buffer
+
+	logger := newTestLog(module, NewJSONProvider(&out))
+
+	t.Run("format arguments", func(t *testing.T) {
+		defer out.Reset()
+
+		msg := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(vc), &msg))
+
+		logger.Infof("string %s", vc)
+		logger.Infof("bytes %s", []byte(vc))
+		logger.Infof("raw %s", json.RawMessage(vc))
+		logger.Infof("map %v", msg)
+		logger.Infof("untouched %s %d", "credentialSubject", 1)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 5)
+		require.Equal(t, "string "+masked, lines[0]["msg"])
+		require.Equal(t, "bytes "+masked, lines[1]["msg"])
+		require.Equal(t, "raw "+masked, lines[2]["msg"])
+		require.Equal(t, "map "+masked, lines[3]["msg"])
+		require.Equal(t, "untouched credentialSubject 1", lines[4]["msg"])
+
+		require.Equal(t, "did:example:alice", msg["credentialSubject"].(map[string]interface{})["id"])
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		defer out.Reset()
+
+		logger.With("vc", json.RawMessage(vc)).Infow("issued", "raw", vc)
+
+		require.Contains(t, out.String(), `"vc":`+masked)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.Equal(t, masked, lines[0]["raw"])
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		defer out.Reset()
+		defer SetRedactionPolicy(redact.DefaultPolicy())
+
+		SetRedactionPolicy(redact.NewPolicy(redact.WithPaths("$.proof"), redact.WithMask("***")))
+
+		logger.Infof("%s", vc)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.JSONEq(t, `{"credentialSubject":{"id":"did:example:alice"},"proof":"***"}`, lines[0]["msg"].(string))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		defer out.Reset()
+		defer SetRedactionPolicy(redact.DefaultPolicy())
+
+		SetRedactionPolicy(nil)
+
+		logger.Infow("issued", "vc", vc)
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.Equal(t, vc, lines[0]["vc"])
+	})
+}
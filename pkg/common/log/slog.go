@@ -0,0 +1,92 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+// LevelCritical is the slog level of the CRITICAL logs.
+const LevelCritical = slog.LevelError + 4
+
+// SlogProvider is a LoggerProvider handing the logs over to a log/slog logger. The logs carry the module in the
+// module attribute and the fields of structured logs as attributes.
+type SlogProvider struct {
+	logger *slog.Logger
+}
+
+// NewSlogProvider returns a SlogProvider logging to logger, or to slog.Default() if logger is nil. It is set up
+// with Initialize(NewSlogProvider(logger)).
+func NewSlogProvider(logger *slog.Logger) *SlogProvider {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &SlogProvider{logger: logger}
+}
+
+// GetLogger returns the slog logger of module.
+func (p *SlogProvider) GetLogger(module string) log.Logger {
+	return &slogLogger{logger: p.logger.With(slog.String("module", module))}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l *slogLogger) Fatalf(msg string, args ...interface{}) {
+	l.Logw(log.CRITICAL, fmt.Sprintf(msg, args...))
+	os.Exit(1)
+}
+
+func (l *slogLogger) Panicf(msg string, args ...interface{}) {
+	l.Logw(log.CRITICAL, fmt.Sprintf(msg, args...))
+	panic(fmt.Sprintf(msg, args...))
+}
+
+func (l *slogLogger) Debugf(msg string, args ...interface{}) {
+	l.Logw(log.DEBUG, fmt.Sprintf(msg, args...))
+}
+
+func (l *slogLogger) Infof(msg string, args ...interface{}) {
+	l.Logw(log.INFO, fmt.Sprintf(msg, args...))
+}
+
+func (l *slogLogger) Warnf(msg string, args ...interface{}) {
+	l.Logw(log.WARNING, fmt.Sprintf(msg, args...))
+}
+
+func (l *slogLogger) Errorf(msg string, args ...interface{}) {
+	l.Logw(log.ERROR, fmt.Sprintf(msg, args...))
+}
+
+func (l *slogLogger) Logw(level log.Level, msg string, keysAndValues ...interface{}) {
+	l.logger.Log(context.Background(), slogLevel(level), msg, keysAndValues...)
+}
+
+func slogLevel(level log.Level) slog.Level {
+	switch level {
+	case log.CRITICAL:
+		return LevelCritical
+	case log.ERROR:
+		return slog.LevelError
+	case log.WARNING:
+		return slog.LevelWarn
+	case log.INFO:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+func TestSlogProvider(t *testing.T) {
+	const module = "sample-module-slog"
+
+	SetLevel(module, log.DEBUG)
+
+	var out bytes.Buffer
+
+	handler := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := newTestLog(module, NewSlogProvider(slog.New(handler)))
+
+	t.Run("levels", func(t *testing.T) {
+		defer out.Reset()
+
+		logger.Debugf("debug")
+		logger.Infof("info")
+		logger.Warnf("warn")
+		logger.Errorf("error")
+
+		require.Panics(t, func() { logger.Panicf("critical") })
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 5)
+
+		for i, level := range []string{"DEBUG", "INFO", "WARN", "ERROR", "ERROR+4"} {
+			require.Equal(t, level, lines[i]["level"])
+			require.Equal(t, module, lines[i]["module"])
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		defer out.Reset()
+
+		logger.With(FieldThreadID, "thid-1").Infow("message received", FieldMessageType, "ping")
+
+		lines := decodeJSONLines(t, &out)
+		require.Len(t, lines, 1)
+		require.Equal(t, "message received", lines[0]["msg"])
+		require.Equal(t, "thid-1", lines[0][FieldThreadID])
+		require.Equal(t, "ping", lines[0][FieldMessageType])
+	})
+
+	t.Run("default logger", func(t *testing.T) {
+		require.NotNil(t, NewSlogProvider(nil).GetLogger(module))
+	})
+}
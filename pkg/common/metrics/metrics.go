@@ -0,0 +1,410 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics provides counters, gauges and histograms exposed in the Prometheus text exposition format.
+//
+// Framework packages register their metrics on the Default registry, which can be served by the agent with
+// Handler():
+//
+//	http.Handle("/metrics", metrics.Handler())
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/common/metrics") //nolint:gochecknoglobals
+
+const (
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// DefBuckets are the default histogram buckets, in seconds, tailored to measure DIDComm processing latency.
+// nolint:gochecknoglobals
+var DefBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// nolint:gochecknoglobals
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry the framework metrics are registered on.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Handler returns the http.Handler serving the metrics of the Default registry.
+func Handler() http.Handler {
+	return defaultRegistry
+}
+
+// NewCounter registers a counter on the Default registry.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return defaultRegistry.NewCounter(name, help, labels...)
+}
+
+// NewGauge registers a gauge on the Default registry.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return defaultRegistry.NewGauge(name, help, labels...)
+}
+
+// NewHistogram registers a histogram on the Default registry.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return defaultRegistry.NewHistogram(name, help, buckets, labels...)
+}
+
+// Status returns the value of the status label of an operation which returned err: "success" or "error".
+func Status(err error) string {
+	if err != nil {
+		return statusError
+	}
+
+	return statusSuccess
+}
+
+// Registry holds a set of metrics and writes them in the Prometheus text exposition format.
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]*metric
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]*metric{}}
+}
+
+// NewCounter registers a counter with the given label names. Registering a name twice returns the counter
+// registered first, so that packages can declare their metrics as package variables.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{m: r.register(name, help, counterType, nil, labels)}
+}
+
+// NewGauge registers a gauge with the given label names.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{m: r.register(name, help, gaugeType, nil, labels)}
+}
+
+// NewHistogram registers a histogram with the given upper bounds and label names. DefBuckets are used when
+// buckets is empty.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	return &Histogram{m: r.register(name, help, histogramType, sorted, labels)}
+}
+
+func (r *Registry) register(name, help, typ string, buckets []float64, labels []string) *metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, ok := r.metrics[name]; ok {
+		if m.typ != typ || len(m.labels) != len(labels) {
+			panic(fmt.Sprintf("metrics: %s is already registered as a %s with %d labels", name, m.typ, len(m.labels)))
+		}
+
+		return m
+	}
+
+	m := &metric{
+		name:    name,
+		help:    help,
+		typ:     typ,
+		labels:  labels,
+		buckets: buckets,
+		entries: map[string]*series{},
+	}
+
+	r.metrics[name] = m
+
+	return m
+}
+
+// WriteTo writes all metrics to w in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.RLock()
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+
+	metrics := make([]*metric, len(names))
+
+	sort.Strings(names)
+
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+
+	r.mu.RUnlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	for _, m := range metrics {
+		m.write(cw)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (r *Registry) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", contentType)
+
+	if _, err := r.WriteTo(rw); err != nil {
+		logger.Warnf("failed to write metrics: %s", err)
+	}
+}
+
+// Counter is a metric that only goes up.
+type Counter struct {
+	m *metric
+}
+
+// Inc increments the counter of the series identified by labelValues by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v, which must not be negative, to the counter of the series identified by labelValues.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+
+	s := c.m.get(labelValues)
+
+	s.mu.Lock()
+	s.value += v
+	s.mu.Unlock()
+}
+
+// Gauge is a metric that can go up and down.
+type Gauge struct {
+	m *metric
+}
+
+// Set sets the gauge of the series identified by labelValues to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	s := g.m.get(labelValues)
+
+	s.mu.Lock()
+	s.value = v
+	s.mu.Unlock()
+}
+
+// Add adds v to the gauge of the series identified by labelValues.
+func (g *Gauge) Add(v float64, labelValues ...string) {
+	s := g.m.get(labelValues)
+
+	s.mu.Lock()
+	s.value += v
+	s.mu.Unlock()
+}
+
+// Inc increments the gauge of the series identified by labelValues by one.
+func (g *Gauge) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements the gauge of the series identified by labelValues by one.
+func (g *Gauge) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Histogram samples observations in configurable buckets.
+type Histogram struct {
+	m *metric
+}
+
+// Observe adds v to the histogram of the series identified by labelValues.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	s := h.m.get(labelValues)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.value += v
+	s.count++
+
+	for i, upperBound := range h.m.buckets {
+		if v <= upperBound {
+			s.buckets[i]++
+		}
+	}
+}
+
+type metric struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+
+	mu      sync.RWMutex
+	entries map[string]*series
+}
+
+type series struct {
+	labelValues []string
+
+	mu      sync.Mutex
+	value   float64
+	count   uint64
+	buckets []uint64
+}
+
+func (m *metric) get(labelValues []string) *series {
+	if len(labelValues) != len(m.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", m.name, len(m.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+
+	m.mu.RLock()
+	s, ok := m.entries[key]
+	m.mu.RUnlock()
+
+	if ok {
+		return s
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if s, ok = m.entries[key]; ok {
+		return s
+	}
+
+	s = &series{labelValues: append([]string(nil), labelValues...)}
+
+	if m.typ == histogramType {
+		s.buckets = make([]uint64, len(m.buckets))
+	}
+
+	m.entries[key] = s
+
+	return s
+}
+
+func (m *metric) write(w *countingWriter) {
+	m.mu.RLock()
+
+	entries := make([]*series, 0, len(m.entries))
+	for _, s := range m.entries {
+		entries = append(entries, s)
+	}
+
+	m.mu.RUnlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.Join(entries[i].labelValues, "\xff") < strings.Join(entries[j].labelValues, "\xff")
+	})
+
+	w.printf("# HELP %s %s\n", m.name, escapeHelp(m.help))
+	w.printf("# TYPE %s %s\n", m.name, m.typ)
+
+	for _, s := range entries {
+		s.mu.Lock()
+
+		labels := m.formatLabels(s.labelValues, "")
+
+		switch m.typ {
+		case histogramType:
+			for i, upperBound := range m.buckets {
+				w.printf("%s_bucket%s %d\n", m.name, m.formatLabels(s.labelValues, formatFloat(upperBound)), s.buckets[i])
+			}
+
+			w.printf("%s_bucket%s %d\n", m.name, m.formatLabels(s.labelValues, "+Inf"), s.count)
+			w.printf("%s_sum%s %s\n", m.name, labels, formatFloat(s.value))
+			w.printf("%s_count%s %d\n", m.name, labels, s.count)
+		default:
+			w.printf("%s%s %s\n", m.name, labels, formatFloat(s.value))
+		}
+
+		s.mu.Unlock()
+	}
+}
+
+func (m *metric) formatLabels(values []string, le string) string {
+	if len(values) == 0 && le == "" {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(values)+1)
+
+	for i, v := range values {
+		pairs = append(pairs, m.labels[i]+`="`+escapeLabelValue(v)+`"`)
+	}
+
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(help)
+}
+
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) printf(format string, args ...interface{}) {
+	if c.err != nil {
+		return
+	}
+
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("counter", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		c := r.NewCounter("aries_test_total", "Test counter.", "status")
+		c.Inc("ok")
+		c.Add(2, "ok")
+		c.Inc("error")
+		c.Add(-1, "error")
+
+		require.Equal(t, `# HELP aries_test_total Test counter.
+# TYPE aries_test_total counter
+aries_test_total{status="error"} 1
+aries_test_total{status="ok"} 3
+`, write(t, r))
+	})
+
+	t.Run("gauge", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		g := r.NewGauge("aries_test_connections", "Test gauge.")
+		g.Set(5)
+		g.Inc()
+		g.Dec()
+		g.Dec()
+
+		require.Equal(t, `# HELP aries_test_connections Test gauge.
+# TYPE aries_test_connections gauge
+aries_test_connections 4
+`, write(t, r))
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		h := r.NewHistogram("aries_test_seconds", "Test histogram.", []float64{1, 0.1}, "op")
+		h.Observe(0.05, "sign")
+		h.Observe(0.5, "sign")
+		h.Observe(2, "sign")
+
+		require.Equal(t, `# HELP aries_test_seconds Test histogram.
+# TYPE aries_test_seconds histogram
+aries_test_seconds_bucket{op="sign",le="0.1"} 1
+aries_test_seconds_bucket{op="sign",le="1"} 2
+aries_test_seconds_bucket{op="sign",le="+Inf"} 3
+aries_test_seconds_sum{op="sign"} 2.55
+aries_test_seconds_count{op="sign"} 3
+`, write(t, r))
+	})
+
+	t.Run("label values are escaped", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		r.NewCounter("aries_test_total", "Test\ncounter.", "type").Inc("a\"b\\c\nd")
+
+		require.Equal(t, `# HELP aries_test_total Test\ncounter.
+# TYPE aries_test_total counter
+aries_test_total{type="a\"b\\c\nd"} 1
+`, write(t, r))
+	})
+
+	t.Run("metrics without series are omitted", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		r.NewCounter("aries_test_total", "Test counter.")
+
+		require.Empty(t, write(t, r))
+	})
+
+	t.Run("register twice", func(t *testing.T) {
+		r := metrics.NewRegistry()
+
+		r.NewCounter("aries_test_total", "Test counter.", "status").Inc("ok")
+		r.NewCounter("aries_test_total", "Test counter.", "status").Inc("ok")
+
+		require.Contains(t, write(t, r), `aries_test_total{status="ok"} 2`)
+
+		require.Panics(t, func() {
+			r.NewGauge("aries_test_total", "Test gauge.", "status")
+		})
+	})
+
+	t.Run("label values mismatch", func(t *testing.T) {
+		c := metrics.NewRegistry().NewCounter("aries_test_total", "Test counter.", "status")
+
+		require.Panics(t, func() {
+			c.Inc()
+		})
+	})
+}
+
+func TestStatus(t *testing.T) {
+	require.Equal(t, "success", metrics.Status(nil))
+	require.Equal(t, "error", metrics.Status(errors.New("failed")))
+}
+
+func TestHandler(t *testing.T) {
+	metrics.NewCounter("aries_handler_test_total", "Test counter.").Inc()
+
+	rr := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rr.Header().Get("Content-Type"))
+	require.Contains(t, rr.Body.String(), "aries_handler_test_total 1\n")
+}
+
+func write(t *testing.T, r *metrics.Registry) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	n, err := r.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	return buf.String()
+}
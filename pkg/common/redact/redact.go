@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package redact masks sensitive members of JSON documents, like credential subjects, proofs and private keys,
+// before they are written to logs or sent in notifications.
+//
+// A Policy lists the members to mask by path. A path is a dot-separated list of member names, where * matches any
+// member. Paths match at any depth of the document unless they start with $, for instance credentialSubject.*
+// masks all claims of every credential found in a DIDComm message, while $.proof.jws only masks the jws of the
+// proof of the document itself. Arrays are transparent: proof.jws matches the jws of every proof of a proof set.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// Mask replaces the masked values.
+	Mask = "[REDACTED]"
+
+	rootSegment = "$"
+	wildcard    = "*"
+	jwkKeyType  = "kty"
+)
+
+// DefaultPaths are the paths masked by the DefaultPolicy.
+// nolint:gochecknoglobals
+var DefaultPaths = []string{
+	"credentialSubject.*",
+	"proof.jws",
+	"proof.proofValue",
+}
+
+// privateJWKMembers are the members of the private keys of the JWK key types, see RFC 7518 section 6.
+// nolint:gochecknoglobals
+var privateJWKMembers = map[string]bool{
+	"d": true, "p": true, "q": true, "dp": true, "dq": true, "qi": true, "oth": true, "k": true,
+}
+
+// Policy defines the members masked in JSON documents. A nil *Policy masks nothing.
+type Policy struct {
+	paths      []path
+	mask       string
+	privateJWK bool
+}
+
+type path struct {
+	segments []string
+	anchored bool
+}
+
+// Opt configures a Policy.
+type Opt func(p *Policy)
+
+// WithPaths adds paths to the masked paths.
+func WithPaths(paths ...string) Opt {
+	return func(p *Policy) {
+		for _, raw := range paths {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+
+			segments := strings.Split(raw, ".")
+			anchored := segments[0] == rootSegment
+
+			if anchored {
+				segments = segments[1:]
+			}
+
+			if len(segments) == 0 {
+				continue
+			}
+
+			p.paths = append(p.paths, path{segments: segments, anchored: anchored})
+		}
+	}
+}
+
+// WithMask sets the value replacing the masked values, Mask by default.
+func WithMask(mask string) Opt {
+	return func(p *Policy) {
+		p.mask = mask
+	}
+}
+
+// WithPrivateJWK enables masking the private members (d, p, q, dp, dq, qi, oth and k) of the JSON Web Keys, which
+// are the objects having a kty member.
+func WithPrivateJWK(enable bool) Opt {
+	return func(p *Policy) {
+		p.privateJWK = enable
+	}
+}
+
+// NewPolicy returns a Policy masking nothing but what opts configure.
+func NewPolicy(opts ...Opt) *Policy {
+	p := &Policy{mask: Mask}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// DefaultPolicy returns a Policy masking the DefaultPaths and the private JWK members, followed by opts.
+func DefaultPolicy(opts ...Opt) *Policy {
+	return NewPolicy(append([]Opt{WithPaths(DefaultPaths...), WithPrivateJWK(true)}, opts...)...)
+}
+
+// Value returns v, a value decoded from JSON, with the members of the policy masked. v is not modified: the
+// objects and arrays holding masked members are copied.
+func (p *Policy) Value(v interface{}) interface{} {
+	if p == nil {
+		return v
+	}
+
+	res, _ := p.redact(v, nil)
+
+	return res
+}
+
+// JSON returns the data JSON document with the members of the policy masked. data is returned as is if it has
+// nothing to mask or is not a JSON document.
+func (p *Policy) JSON(data []byte) []byte {
+	res, _ := p.RedactJSON(data)
+
+	return res
+}
+
+// RedactJSON is JSON which also reports whether members were masked.
+func (p *Policy) RedactJSON(data []byte) ([]byte, bool) {
+	if p == nil || !IsJSON(data) {
+		return data, false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return data, false
+	}
+
+	res, changed := p.redact(v, nil)
+	if !changed {
+		return data, false
+	}
+
+	redacted, err := json.Marshal(res)
+	if err != nil {
+		return data, false
+	}
+
+	return redacted, true
+}
+
+// IsJSON reports whether data looks like a JSON object or array.
+func IsJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+
+	return len(data) > 1 && (data[0] == '{' || data[0] == '[')
+}
+
+func (p *Policy) redact(v interface{}, parents []string) (interface{}, bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return p.redactObject(val, parents)
+	case []interface{}:
+		return p.redactArray(val, parents)
+	default:
+		return v, false
+	}
+}
+
+func (p *Policy) redactObject(obj map[string]interface{}, parents []string) (interface{}, bool) {
+	var res map[string]interface{}
+
+	_, isJWK := obj[jwkKeyType].(string)
+	isJWK = isJWK && p.privateJWK
+
+	for key, value := range obj {
+		memberPath := append(parents[:len(parents):len(parents)], key) //nolint:gocritic
+
+		var (
+			redacted interface{}
+			changed  bool
+		)
+
+		if (isJWK && privateJWKMembers[key]) || p.matches(memberPath) {
+			redacted, changed = p.mask, true
+		} else {
+			redacted, changed = p.redact(value, memberPath)
+		}
+
+		if !changed {
+			continue
+		}
+
+		if res == nil {
+			res = make(map[string]interface{}, len(obj))
+
+			for k, v := range obj {
+				res[k] = v
+			}
+		}
+
+		res[key] = redacted
+	}
+
+	if res == nil {
+		return obj, false
+	}
+
+	return res, true
+}
+
+func (p *Policy) redactArray(arr []interface{}, parents []string) (interface{}, bool) {
+	var res []interface{}
+
+	for i, value := range arr {
+		redacted, changed := p.redact(value, parents)
+		if !changed {
+			continue
+		}
+
+		if res == nil {
+			res = append([]interface{}(nil), arr...)
+		}
+
+		res[i] = redacted
+	}
+
+	if res == nil {
+		return arr, false
+	}
+
+	return res, true
+}
+
+func (p *Policy) matches(memberPath []string) bool {
+	for _, pt := range p.paths {
+		if pt.matches(memberPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (pt path) matches(memberPath []string) bool {
+	if len(memberPath) < len(pt.segments) || (pt.anchored && len(memberPath) != len(pt.segments)) {
+		return false
+	}
+
+	suffix := memberPath[len(memberPath)-len(pt.segments):]
+
+	for i, segment := range pt.segments {
+		if segment != wildcard && segment != suffix[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const issueCredentialMsg = `{
+	"@id": "msg-1",
+	"@type": "https://didcomm.org/issue-credential/2.0/issue-credential",
+	"credentials~attach": [{
+		"data": {
+			"json": {
+				"type": ["VerifiableCredential"],
+				"credentialSubject": {"id": "did:example:alice", "degree": {"name": "Bachelor"}},
+				"proof": [
+					{"type": "Ed25519Signature2018", "jws": "eyJ..sig1"},
+					{"type": "BbsBlsSignature2020", "proofValue": "z123"}
+				]
+			}
+		}
+	}]
+}`
+
+func TestDefaultPolicy(t *testing.T) {
+	t.Run("DIDComm message", func(t *testing.T) {
+		res, changed := DefaultPolicy().RedactJSON([]byte(issueCredentialMsg))
+		require.True(t, changed)
+		require.JSONEq(t, `{
+			"@id": "msg-1",
+			"@type": "https://didcomm.org/issue-credential/2.0/issue-credential",
+			"credentials~attach": [{
+				"data": {
+					"json": {
+						"type": ["VerifiableCredential"],
+						"credentialSubject": {"id": "[REDACTED]", "degree": "[REDACTED]"},
+						"proof": [
+							{"type": "Ed25519Signature2018", "jws": "[REDACTED]"},
+							{"type": "BbsBlsSignature2020", "proofValue": "[REDACTED]"}
+						]
+					}
+				}
+			}]
+		}`, string(res))
+	})
+
+	t.Run("private JWK", func(t *testing.T) {
+		res := DefaultPolicy().JSON([]byte(`{"privateKeyJwk":{"kty":"OKP","crv":"Ed25519","x":"pub","d":"priv"},` +
+			`"d":"not a key member"}`))
+		require.JSONEq(t, `{"privateKeyJwk":{"kty":"OKP","crv":"Ed25519","x":"pub","d":"[REDACTED]"},`+
+			`"d":"not a key member"}`, string(res))
+	})
+
+	t.Run("nothing to mask", func(t *testing.T) {
+		data := []byte(`{"@type":"https://didcomm.org/trust_ping/2.0/ping", "count": 12345678901234567890}`)
+
+		res, changed := DefaultPolicy().RedactJSON(data)
+		require.False(t, changed)
+		require.Equal(t, data, res)
+	})
+
+	t.Run("not JSON", func(t *testing.T) {
+		for _, data := range []string{"", "credentialSubject", "{not json", `"string"`} {
+			res, changed := DefaultPolicy().RedactJSON([]byte(data))
+			require.False(t, changed)
+			require.Equal(t, data, string(res))
+		}
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	doc := `{"proof":{"jws":"sig"},"nested":{"proof":{"jws":"sig"}},"attributes":{"ssn":"123","name":"Alice"}}`
+
+	t.Run("anchored path", func(t *testing.T) {
+		res := NewPolicy(WithPaths("$.proof.jws", "  ", "$")).JSON([]byte(doc))
+		require.JSONEq(t, `{"proof":{"jws":"[REDACTED]"},"nested":{"proof":{"jws":"sig"}},`+
+			`"attributes":{"ssn":"123","name":"Alice"}}`, string(res))
+	})
+
+	t.Run("wildcard and custom mask", func(t *testing.T) {
+		res := NewPolicy(WithPaths("*.ssn", "nested.*"), WithMask("***")).JSON([]byte(doc))
+		require.JSONEq(t, `{"proof":{"jws":"sig"},"nested":{"proof":"***"},`+
+			`"attributes":{"ssn":"***","name":"Alice"}}`, string(res))
+	})
+
+	t.Run("default paths with additional ones", func(t *testing.T) {
+		res := DefaultPolicy(WithPaths("attributes.ssn")).JSON([]byte(doc))
+		require.JSONEq(t, `{"proof":{"jws":"[REDACTED]"},"nested":{"proof":{"jws":"[REDACTED]"}},`+
+			`"attributes":{"ssn":"[REDACTED]","name":"Alice"}}`, string(res))
+	})
+
+	t.Run("value is not modified", func(t *testing.T) {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(doc), &v))
+
+		res := DefaultPolicy().Value(v)
+		require.Equal(t, "[REDACTED]", res.(map[string]interface{})["proof"].(map[string]interface{})["jws"])
+		require.Equal(t, "sig", v.(map[string]interface{})["proof"].(map[string]interface{})["jws"])
+	})
+
+	t.Run("nil policy", func(t *testing.T) {
+		var p *Policy
+
+		require.Equal(t, doc, string(p.JSON([]byte(doc))))
+		require.Equal(t, "value", p.Value("value"))
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package shutdown tracks the in-flight work of the framework components so that it can be finished before the
+// framework is closed.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrShuttingDown is returned for the work refused once the tracker is draining.
+var ErrShuttingDown = errors.New("shutting down")
+
+// Tracker counts the in-flight work of a component. The zero value is ready to use.
+type Tracker struct {
+	mu       sync.Mutex
+	inFlight int
+	draining bool
+	idle     chan struct{}
+}
+
+// Add registers a unit of in-flight work, the returned done function being called once the work completes.
+// ErrShuttingDown is returned once the tracker is draining.
+func (t *Tracker) Add() (func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.draining {
+		return nil, ErrShuttingDown
+	}
+
+	t.inFlight++
+
+	var once sync.Once
+
+	return func() {
+		once.Do(t.done)
+	}, nil
+}
+
+// Go runs f in a new goroutine tracked as in-flight work. ErrShuttingDown is returned, and f isn't run, once the
+// tracker is draining.
+func (t *Tracker) Go(f func()) error {
+	done, err := t.Add()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer done()
+
+		f()
+	}()
+
+	return nil
+}
+
+// Drain refuses the new work and waits for the in-flight work to complete, or for ctx to be done.
+func (t *Tracker) Drain(ctx context.Context) error {
+	t.mu.Lock()
+
+	t.draining = true
+
+	if t.inFlight == 0 {
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+
+	idle := t.idle
+
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d in-flight operations not completed: %w", t.InFlight(), ctx.Err())
+	}
+}
+
+// InFlight returns the number of in-flight operations.
+func (t *Tracker) InFlight() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.inFlight
+}
+
+func (t *Tracker) done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+
+	if t.inFlight == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	t.Run("drain without in-flight work", func(t *testing.T) {
+		tracker := &Tracker{}
+
+		require.NoError(t, tracker.Drain(context.Background()))
+
+		_, err := tracker.Add()
+		require.ErrorIs(t, err, ErrShuttingDown)
+		require.ErrorIs(t, tracker.Go(func() {}), ErrShuttingDown)
+	})
+
+	t.Run("drain waits for the in-flight work", func(t *testing.T) {
+		tracker := &Tracker{}
+
+		done, err := tracker.Add()
+		require.NoError(t, err)
+
+		release := make(chan struct{})
+		finished := make(chan struct{})
+
+		require.NoError(t, tracker.Go(func() {
+			<-release
+			close(finished)
+		}))
+
+		require.Equal(t, 2, tracker.InFlight())
+
+		drained := make(chan error)
+
+		go func() {
+			drained <- tracker.Drain(context.Background())
+		}()
+
+		done()
+		done()
+
+		select {
+		case <-drained:
+			require.Fail(t, "drained before the in-flight work completed")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+
+		require.NoError(t, <-drained)
+		require.Zero(t, tracker.InFlight())
+		<-finished
+	})
+
+	t.Run("drain deadline", func(t *testing.T) {
+		tracker := &Tracker{}
+
+		_, err := tracker.Add()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err = tracker.Drain(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "1 in-flight operations not completed")
+	})
+}
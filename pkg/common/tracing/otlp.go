@@ -0,0 +1,289 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/common/tracing") //nolint:gochecknoglobals
+
+const (
+	otlpTracesPath = "/v1/traces"
+
+	defaultServiceName   = "aries-framework-go"
+	defaultBatchSize     = 512
+	defaultQueueSize     = 2048
+	defaultFlushInterval = 5 * time.Second
+
+	// OTLP span kind and status codes.
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// OTLPExporter exports spans in batches to an OpenTelemetry collector with the OTLP/HTTP JSON protocol.
+type OTLPExporter struct {
+	url           string
+	serviceName   string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+
+	queue    chan *SpanData
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// OTLPOpt configures the OTLPExporter.
+type OTLPOpt func(e *OTLPExporter)
+
+// WithServiceName sets the service.name resource attribute of the exported spans.
+func WithServiceName(name string) OTLPOpt {
+	return func(e *OTLPExporter) {
+		e.serviceName = name
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the collector.
+func WithHTTPClient(client *http.Client) OTLPOpt {
+	return func(e *OTLPExporter) {
+		e.client = client
+	}
+}
+
+// WithBatchSize sets the maximum number of spans sent in one request.
+func WithBatchSize(size int) OTLPOpt {
+	return func(e *OTLPExporter) {
+		e.batchSize = size
+	}
+}
+
+// WithFlushInterval sets the maximum time a span waits in the queue before being sent.
+func WithFlushInterval(interval time.Duration) OTLPOpt {
+	return func(e *OTLPExporter) {
+		e.flushInterval = interval
+	}
+}
+
+// NewOTLPExporter returns an exporter sending spans to the OTLP/HTTP collector at endpoint, for instance
+// http://localhost:4318. The spans are posted to the /v1/traces path of the endpoint. Call Shutdown to send the
+// queued spans and stop the exporter.
+func NewOTLPExporter(endpoint string, opts ...OTLPOpt) *OTLPExporter {
+	e := &OTLPExporter{
+		url:           strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		serviceName:   defaultServiceName,
+		client:        &http.Client{Timeout: 10 * time.Second}, //nolint:gomnd
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		queue:         make(chan *SpanData, defaultQueueSize),
+		done:          make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	e.wg.Add(1)
+
+	go e.run()
+
+	return e
+}
+
+// ExportSpan queues span for export. The span is dropped if the queue is full.
+func (e *OTLPExporter) ExportSpan(span *SpanData) {
+	select {
+	case e.queue <- span:
+	default:
+		logger.Warnf("otlp exporter queue is full, dropping span %s", span.Name)
+	}
+}
+
+// Shutdown sends the queued spans and stops the exporter.
+func (e *OTLPExporter) Shutdown() {
+	e.stopOnce.Do(func() {
+		close(e.done)
+		e.wg.Wait()
+	})
+}
+
+func (e *OTLPExporter) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*SpanData, 0, e.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := e.send(batch); err != nil {
+			logger.Warnf("failed to export %d spans: %s", len(batch), err)
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+
+			if len(batch) >= e.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+				default:
+					flush()
+
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *OTLPExporter) send(spans []*SpanData) error {
+	body, err := json.Marshal(e.toRequest(spans))
+	if err != nil {
+		return fmt.Errorf("marshal export request: %w", err)
+	}
+
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("post export request: %w", err)
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		_ = resp.Body.Close()                 //nolint:errcheck
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *OTLPExporter) toRequest(spans []*SpanData) *otlpRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+
+	for i, s := range spans {
+		otlpSpans[i] = otlpSpan{
+			TraceID:           s.TraceID.String(),
+			SpanID:            s.SpanID.String(),
+			Name:              s.Name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+			Attributes:        toOTLPAttributes(s.Attributes),
+		}
+
+		if s.ParentSpanID.IsValid() {
+			otlpSpans[i].ParentSpanID = s.ParentSpanID.String()
+		}
+
+		if s.Error != "" {
+			otlpSpans[i].Status = &otlpStatus{Code: statusCodeError, Message: s.Error}
+		}
+	}
+
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: toOTLPAttributes([]Attribute{Attr("service.name", e.serviceName)}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: defaultServiceName},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func toOTLPAttributes(attrs []Attribute) []otlpAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	res := make([]otlpAttribute, len(attrs))
+
+	for i, a := range attrs {
+		res[i] = otlpAttribute{Key: a.Key, Value: otlpValue{StringValue: a.Value}}
+	}
+
+	return res
+}
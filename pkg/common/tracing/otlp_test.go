@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan map[string]interface{}, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		requests <- req
+	}))
+	defer srv.Close()
+
+	t.Run("exports the spans on shutdown", func(t *testing.T) {
+		e := tracing.NewOTLPExporter(srv.URL+"/", tracing.WithServiceName("agent"),
+			tracing.WithHTTPClient(srv.Client()), tracing.WithFlushInterval(time.Hour))
+
+		start := time.Unix(1, 0)
+
+		e.ExportSpan(&tracing.SpanData{
+			TraceID:    tracing.ThreadTraceID("thid"),
+			SpanID:     tracing.SpanID{1},
+			Name:       "packager.UnpackMessage",
+			StartTime:  start,
+			EndTime:    start.Add(time.Second),
+			Attributes: []tracing.Attribute{tracing.Attr("didcomm.thid", "thid")},
+			Error:      "failed",
+		})
+
+		e.Shutdown()
+		e.Shutdown()
+
+		req := <-requests
+
+		resourceSpans := req["resourceSpans"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, map[string]interface{}{
+			"attributes": []interface{}{
+				map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": "agent"}},
+			},
+		}, resourceSpans["resource"])
+
+		span := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})[0]
+		require.Equal(t, map[string]interface{}{
+			"traceId":           tracing.ThreadTraceID("thid").String(),
+			"spanId":            "0100000000000000",
+			"name":              "packager.UnpackMessage",
+			"kind":              float64(1),
+			"startTimeUnixNano": "1000000000",
+			"endTimeUnixNano":   "2000000000",
+			"attributes": []interface{}{
+				map[string]interface{}{"key": "didcomm.thid", "value": map[string]interface{}{"stringValue": "thid"}},
+			},
+			"status": map[string]interface{}{"code": float64(2), "message": "failed"},
+		}, span)
+	})
+
+	t.Run("exports full batches", func(t *testing.T) {
+		e := tracing.NewOTLPExporter(srv.URL, tracing.WithBatchSize(2), tracing.WithFlushInterval(time.Hour))
+		defer e.Shutdown()
+
+		e.ExportSpan(&tracing.SpanData{Name: "a", ParentSpanID: tracing.SpanID{2}})
+		e.ExportSpan(&tracing.SpanData{Name: "b"})
+
+		select {
+		case req := <-requests:
+			spans := req["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{}) //nolint:lll
+			require.Len(t, spans, 2)
+			require.Equal(t, "0200000000000000", spans[0].(map[string]interface{})["parentSpanId"])
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "spans were not exported")
+		}
+	})
+}
@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing records spans of the DIDComm message pipeline and hands them over to an Exporter, for instance
+// the OTLPExporter.
+//
+// Most of the pipeline has no context.Context to carry a trace from one stage to the next. Instead, spans join the
+// trace of the DIDComm thread they process through Span.SetThreadID: the trace ID is derived from the thread ID,
+// so every span of a protocol thread, whichever agent or stage records it, belongs to the same trace.
+//
+// Tracing is disabled until an exporter is set with SetExporter, in which case Start returns a nil *Span on which
+// all methods are no-ops.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the hex encoding of the trace ID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid reports whether the trace ID is not all zeros.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex encoding of the span ID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid reports whether the span ID is not all zeros.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// ThreadTraceID returns the ID of the trace of the DIDComm thread thid.
+func ThreadTraceID(thid string) TraceID {
+	var id TraceID
+
+	sum := sha256.Sum256([]byte(thid))
+	copy(id[:], sum[:])
+
+	return id
+}
+
+// ThreadID returns the thread ID of a plaintext DIDComm V1 or V2 message, or an empty string if msg is not a
+// DIDComm message.
+func ThreadID(msg []byte) string {
+	var m struct {
+		Thread *struct {
+			ID string `json:"thid"`
+		} `json:"~thread"`
+		ThreadID string `json:"thid"`
+		IDV1     string `json:"@id"`
+		IDV2     string `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return ""
+	}
+
+	switch {
+	case m.Thread != nil && m.Thread.ID != "":
+		return m.Thread.ID
+	case m.ThreadID != "":
+		return m.ThreadID
+	case m.IDV1 != "":
+		return m.IDV1
+	default:
+		return m.IDV2
+	}
+}
+
+// Attribute describes a span.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Attr creates an attribute.
+func Attr(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData is the record of an ended span.
+type SpanData struct {
+	TraceID      TraceID
+	SpanID       SpanID
+	ParentSpanID SpanID
+	Name         string
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   []Attribute
+	// Error is the message of the error recorded on the span, if any.
+	Error string
+}
+
+// Exporter receives the ended spans. ExportSpan is called by the goroutine ending the span and must not block.
+type Exporter interface {
+	ExportSpan(span *SpanData)
+}
+
+//nolint:gochecknoglobals
+var (
+	exporterMu sync.RWMutex
+	exporter   Exporter
+)
+
+// SetExporter sets the exporter of the ended spans and enables tracing. A nil exporter disables tracing.
+func SetExporter(e Exporter) {
+	exporterMu.Lock()
+	exporter = e
+	exporterMu.Unlock()
+}
+
+func currentExporter() Exporter {
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+
+	return exporter
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying s, which becomes the parent of the spans started from it.
+func ContextWithSpan(ctx context.Context, s *Span) context.Context {
+	if s == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// FromContext returns the span carried by ctx, or nil.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span) //nolint:errcheck
+
+	return s
+}
+
+// Span is an operation of the DIDComm pipeline. A nil *Span is valid and records nothing.
+type Span struct {
+	exporter Exporter
+	parent   *Span
+	id       SpanID
+	name     string
+	start    time.Time
+
+	mu       sync.Mutex
+	traceID  TraceID
+	threaded bool
+	attrs    []Attribute
+	err      string
+	ended    bool
+}
+
+// Start starts a span named name. The span carried by ctx, if any, becomes its parent. The returned context
+// carries the new span.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	e := currentExporter()
+	if e == nil {
+		return ctx, nil
+	}
+
+	s := &Span{
+		exporter: e,
+		parent:   FromContext(ctx),
+		id:       newSpanID(),
+		name:     name,
+		start:    time.Now(),
+		attrs:    attrs,
+	}
+
+	if s.parent == nil {
+		s.traceID = newTraceID()
+	}
+
+	return ContextWithSpan(ctx, s), s
+}
+
+// SetThreadID makes the span, and its ancestors not bound to a thread yet, join the trace of the DIDComm thread
+// thid.
+func (s *Span) SetThreadID(thid string) {
+	if s == nil || thid == "" {
+		return
+	}
+
+	s.mu.Lock()
+	threaded := s.threaded
+
+	if !threaded {
+		s.traceID = ThreadTraceID(thid)
+		s.threaded = true
+		s.attrs = append(s.attrs, Attr("didcomm.thid", thid))
+	}
+	s.mu.Unlock()
+
+	if !threaded {
+		s.parent.SetThreadID(thid)
+	}
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// RecordError marks the span as failed with err. A nil error is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.err = err.Error()
+	s.mu.Unlock()
+}
+
+// TraceID returns the ID of the trace the span belongs to.
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+
+	s.mu.Lock()
+	threaded, traceID := s.threaded, s.traceID
+	s.mu.Unlock()
+
+	if threaded || s.parent == nil {
+		return traceID
+	}
+
+	return s.parent.TraceID()
+}
+
+// SpanID returns the ID of the span.
+func (s *Span) SpanID() SpanID {
+	if s == nil {
+		return SpanID{}
+	}
+
+	return s.id
+}
+
+// End ends the span and exports it. Calls after the first one are ignored.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	end := time.Now()
+	traceID := s.TraceID()
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.ended = true
+
+	data := &SpanData{
+		TraceID:      traceID,
+		SpanID:       s.id,
+		ParentSpanID: s.parent.SpanID(),
+		Name:         s.name,
+		StartTime:    s.start,
+		EndTime:      end,
+		Attributes:   append([]Attribute(nil), s.attrs...),
+		Error:        s.err,
+	}
+	s.mu.Unlock()
+
+	s.exporter.ExportSpan(data)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+
+	_, _ = rand.Read(id[:]) //nolint:errcheck
+
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+
+	_, _ = rand.Read(id[:]) //nolint:errcheck
+
+	return id
+}
@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	spans []*tracing.SpanData
+}
+
+func (r *recorder) ExportSpan(span *tracing.SpanData) {
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+}
+
+func TestStart(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ctx, span := tracing.Start(context.Background(), "op")
+		require.Nil(t, span)
+		require.Nil(t, tracing.FromContext(ctx))
+
+		// no-ops on a nil span
+		span.SetThreadID("thid")
+		span.SetAttributes(tracing.Attr("k", "v"))
+		span.RecordError(errors.New("error"))
+		span.End()
+		require.False(t, span.TraceID().IsValid())
+		require.False(t, span.SpanID().IsValid())
+	})
+
+	t.Run("parent and child", func(t *testing.T) {
+		r := enable(t)
+
+		ctx, parent := tracing.Start(context.Background(), "parent", tracing.Attr("k", "v"))
+		require.Equal(t, parent, tracing.FromContext(ctx))
+
+		_, child := tracing.Start(ctx, "child")
+		child.RecordError(errors.New("failed"))
+		child.End()
+		child.End()
+		parent.End()
+
+		require.Len(t, r.spans, 2)
+		require.Equal(t, "child", r.spans[0].Name)
+		require.Equal(t, "failed", r.spans[0].Error)
+		require.Equal(t, parent.SpanID(), r.spans[0].ParentSpanID)
+		require.Equal(t, parent.TraceID(), r.spans[0].TraceID)
+		require.True(t, r.spans[0].TraceID.IsValid())
+		require.False(t, r.spans[1].EndTime.Before(r.spans[1].StartTime))
+		require.Equal(t, []tracing.Attribute{{Key: "k", Value: "v"}}, r.spans[1].Attributes)
+		require.False(t, r.spans[1].ParentSpanID.IsValid())
+	})
+
+	t.Run("spans join the trace of their thread", func(t *testing.T) {
+		r := enable(t)
+
+		ctx, parent := tracing.Start(context.Background(), "inbound")
+		_, child := tracing.Start(ctx, "unpack")
+		child.SetThreadID("thread-1")
+		child.End()
+		parent.End()
+
+		_, other := tracing.Start(context.Background(), "outbound")
+		other.SetThreadID("thread-1")
+		other.SetThreadID("thread-2")
+		other.End()
+
+		traceID := tracing.ThreadTraceID("thread-1")
+
+		require.Len(t, r.spans, 3)
+
+		for _, s := range r.spans {
+			require.Equal(t, traceID, s.TraceID)
+			require.Contains(t, s.Attributes, tracing.Attr("didcomm.thid", "thread-1"))
+		}
+	})
+}
+
+func TestThreadID(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		thid string
+	}{
+		{name: "DIDComm V1 thread", msg: `{"@id":"id","~thread":{"thid":"thid"}}`, thid: "thid"},
+		{name: "DIDComm V1 message ID", msg: `{"@id":"id","~thread":{}}`, thid: "id"},
+		{name: "DIDComm V2 thread", msg: `{"id":"id","thid":"thid"}`, thid: "thid"},
+		{name: "DIDComm V2 message ID", msg: `{"id":"id"}`, thid: "id"},
+		{name: "not JSON", msg: `{`, thid: ""},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.thid, tracing.ThreadID([]byte(tc.msg)))
+		})
+	}
+}
+
+func enable(t *testing.T) *recorder {
+	t.Helper()
+
+	r := &recorder{}
+
+	tracing.SetExporter(r)
+	t.Cleanup(func() { tracing.SetExporter(nil) })
+
+	return r
+}
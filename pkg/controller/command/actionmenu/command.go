@@ -0,0 +1,361 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/actionmenu")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.ActionMenu)
+
+	// PublishMenuErrorCode is for failures in publish menu command.
+	PublishMenuErrorCode
+
+	// MenuErrorCode is for failures in menu and their menu commands.
+	MenuErrorCode
+
+	// RequestMenuErrorCode is for failures in request menu command.
+	RequestMenuErrorCode
+
+	// PerformErrorCode is for failures in perform command.
+	PerformErrorCode
+
+	// ActionsErrorCode is for failures in actions command.
+	ActionsErrorCode
+
+	// AcceptPerformErrorCode is for failures in accept perform command.
+	AcceptPerformErrorCode
+
+	// DeclinePerformErrorCode is for failures in decline perform command.
+	DeclinePerformErrorCode
+)
+
+// constants for the action menu controller.
+const (
+	// command name.
+	CommandName = "actionmenu"
+
+	// command methods.
+	PublishMenuCommandMethod    = "PublishMenu"
+	MenuCommandMethod           = "Menu"
+	TheirMenuCommandMethod      = "TheirMenu"
+	RequestMenuCommandMethod    = "RequestMenu"
+	PerformCommandMethod        = "Perform"
+	ActionsCommandMethod        = "Actions"
+	AcceptPerformCommandMethod  = "AcceptPerform"
+	DeclinePerformCommandMethod = "DeclinePerform"
+
+	// error messages.
+	errEmptyConnectionID = "connectionID is mandatory"
+	errEmptyPIID         = "piid is mandatory"
+
+	// log constants.
+	connectionID  = "connectionID"
+	piid          = "piid"
+	successString = "success"
+
+	_actions = "_actions"
+	_states  = "_states"
+)
+
+// provider contains dependencies for the action menu command and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by action menu controller.
+type Command struct {
+	client *client.Client
+}
+
+// New returns new action menu controller command instance. The perform requests and the received menus are
+// published to the notifier.
+func New(ctx provider, notifier command.Notifier) (*Command, error) {
+	actionMenuClient, err := client.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu client : %w", err)
+	}
+
+	actions := make(chan service.DIDCommAction)
+	if err = actionMenuClient.RegisterActionEvent(actions); err != nil {
+		return nil, fmt.Errorf("register action event : %w", err)
+	}
+
+	states := make(chan service.StateMsg)
+	if err = actionMenuClient.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event : %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterAction(actionmenu.ActionMenu+_actions, actions)
+	obs.RegisterStateMsg(actionmenu.ActionMenu+_states, states)
+
+	return &Command{client: actionMenuClient}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, PublishMenuCommandMethod, c.PublishMenu),
+		cmdutil.NewCommandHandler(CommandName, MenuCommandMethod, c.Menu),
+		cmdutil.NewCommandHandler(CommandName, TheirMenuCommandMethod, c.TheirMenu),
+		cmdutil.NewCommandHandler(CommandName, RequestMenuCommandMethod, c.RequestMenu),
+		cmdutil.NewCommandHandler(CommandName, PerformCommandMethod, c.Perform),
+		cmdutil.NewCommandHandler(CommandName, ActionsCommandMethod, c.Actions),
+		cmdutil.NewCommandHandler(CommandName, AcceptPerformCommandMethod, c.AcceptPerform),
+		cmdutil.NewCommandHandler(CommandName, DeclinePerformCommandMethod, c.DeclinePerform),
+	}
+}
+
+// PublishMenu makes the menu the current menu offered over the connection and sends it to the other agent.
+func (c *Command) PublishMenu(rw io.Writer, req io.Reader) command.Error {
+	var args PublishMenuArgs
+
+	if err := decode(req, &args, PublishMenuCommandMethod); err != nil {
+		return err
+	}
+
+	if err := validateConnectionID(args.ConnectionID, PublishMenuCommandMethod); err != nil {
+		return err
+	}
+
+	if args.Menu == nil {
+		logutil.LogDebug(logger, CommandName, PublishMenuCommandMethod, "missing menu")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("menu is mandatory"))
+	}
+
+	menu, err := c.client.PublishMenu(args.ConnectionID, args.Menu)
+	if err != nil {
+		logutil.LogError(logger, CommandName, PublishMenuCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(PublishMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MenuResponse{Menu: menu}, logger)
+
+	logutil.LogDebug(logger, CommandName, PublishMenuCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
+
+// Menu returns the current menu offered over the connection.
+func (c *Command) Menu(rw io.Writer, req io.Reader) command.Error {
+	return c.menu(rw, req, MenuCommandMethod, c.client.Menu)
+}
+
+// TheirMenu returns the last menu received over the connection.
+func (c *Command) TheirMenu(rw io.Writer, req io.Reader) command.Error {
+	return c.menu(rw, req, TheirMenuCommandMethod, c.client.TheirMenu)
+}
+
+// RequestMenu requests the agent at the other end of the connection to send its current menu.
+func (c *Command) RequestMenu(rw io.Writer, req io.Reader) command.Error {
+	var args MenuArgs
+
+	if err := decode(req, &args, RequestMenuCommandMethod); err != nil {
+		return err
+	}
+
+	if err := validateConnectionID(args.ConnectionID, RequestMenuCommandMethod); err != nil {
+		return err
+	}
+
+	if err := c.client.RequestMenu(args.ConnectionID); err != nil {
+		logutil.LogError(logger, CommandName, RequestMenuCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(RequestMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestMenuCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
+
+// Perform requests the agent at the other end of the connection to perform an option of its menu.
+func (c *Command) Perform(rw io.Writer, req io.Reader) command.Error {
+	var args PerformArgs
+
+	if err := decode(req, &args, PerformCommandMethod); err != nil {
+		return err
+	}
+
+	if err := validateConnectionID(args.ConnectionID, PerformCommandMethod); err != nil {
+		return err
+	}
+
+	if args.Name == "" {
+		logutil.LogDebug(logger, CommandName, PerformCommandMethod, "missing name")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("name is mandatory"))
+	}
+
+	if err := c.client.Perform(args.ConnectionID, args.Name, args.Params); err != nil {
+		logutil.LogError(logger, CommandName, PerformCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(PerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, PerformCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
+
+// Actions returns the perform requests waiting to be accepted or declined.
+func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
+	actions, err := c.client.Actions()
+	if err != nil {
+		logutil.LogError(logger, CommandName, ActionsCommandMethod, err.Error())
+
+		return command.NewExecuteError(ActionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ActionsResponse{Actions: actions}, logger)
+
+	logutil.LogDebug(logger, CommandName, ActionsCommandMethod, successString)
+
+	return nil
+}
+
+// AcceptPerform accepts a perform request, publishing the given menu as the new current menu of the connection.
+func (c *Command) AcceptPerform(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptPerformArgs
+
+	if err := decode(req, &args, AcceptPerformCommandMethod); err != nil {
+		return err
+	}
+
+	if err := validatePIID(args.PIID, AcceptPerformCommandMethod); err != nil {
+		return err
+	}
+
+	if err := c.client.AcceptPerform(args.PIID, args.Menu); err != nil {
+		logutil.LogError(logger, CommandName, AcceptPerformCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(piid, args.PIID))
+
+		return command.NewExecuteError(AcceptPerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptPerformCommandMethod, successString,
+		logutil.CreateKeyValueString(piid, args.PIID))
+
+	return nil
+}
+
+// DeclinePerform declines a perform request, the reason being sent back as error message of the current menu.
+func (c *Command) DeclinePerform(rw io.Writer, req io.Reader) command.Error {
+	var args DeclinePerformArgs
+
+	if err := decode(req, &args, DeclinePerformCommandMethod); err != nil {
+		return err
+	}
+
+	if err := validatePIID(args.PIID, DeclinePerformCommandMethod); err != nil {
+		return err
+	}
+
+	if err := c.client.DeclinePerform(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclinePerformCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(piid, args.PIID))
+
+		return command.NewExecuteError(DeclinePerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclinePerformCommandMethod, successString,
+		logutil.CreateKeyValueString(piid, args.PIID))
+
+	return nil
+}
+
+func (c *Command) menu(rw io.Writer, req io.Reader, method string,
+	getMenu func(string) (*actionmenu.Menu, error)) command.Error {
+	var args MenuArgs
+
+	if err := decode(req, &args, method); err != nil {
+		return err
+	}
+
+	if err := validateConnectionID(args.ConnectionID, method); err != nil {
+		return err
+	}
+
+	menu, err := getMenu(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, method, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(MenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &MenuResponse{Menu: menu}, logger)
+
+	logutil.LogDebug(logger, CommandName, method, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
+
+func decode(req io.Reader, args interface{}, method string) command.Error {
+	if err := json.NewDecoder(req).Decode(args); err != nil {
+		logutil.LogInfo(logger, CommandName, method, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	return nil
+}
+
+func validateConnectionID(id, method string) command.Error {
+	if id == "" {
+		logutil.LogDebug(logger, CommandName, method, "missing connectionID")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnectionID))
+	}
+
+	return nil
+}
+
+func validatePIID(id, method string) command.Error {
+	if id == "" {
+		logutil.LogDebug(logger, CommandName, method, "missing piid")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	return nil
+}
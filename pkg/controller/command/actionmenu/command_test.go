@@ -0,0 +1,267 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	mockactionmenu "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/actionmenu"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func newCommand(t *testing.T, svc *mockactionmenu.MockActionMenuSvc) *Command {
+	t.Helper()
+
+	cmd, err := New(&mockprovider.Provider{ServiceValue: svc}, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	return cmd
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+		require.Len(t, cmd.GetHandlers(), 8)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create action menu client")
+	})
+}
+
+func TestCommand_PublishMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.PublishMenu(&b, bytes.NewBufferString(
+			`{"connectionID":"conn","menu":{"title":"Main","options":[{"name":"a","title":"A"}]}}`)))
+
+		var resp MenuResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Equal(t, "Main", resp.Menu.Title)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+
+		for request, msg := range map[string]string{
+			`{`:                    "request decode",
+			`{"menu":{}}`:          errEmptyConnectionID,
+			`{"connectionID":"c"}`: "menu is mandatory",
+		} {
+			err := cmd.PublishMenu(&bytes.Buffer{}, bytes.NewBufferString(request))
+			require.Error(t, err)
+			require.Equal(t, InvalidRequestErrorCode, err.Code())
+			require.Equal(t, command.ValidationError, err.Type())
+			require.Contains(t, err.Error(), msg)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{PublishMenuErr: errors.New("publish error")})
+
+		err := cmd.PublishMenu(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"c","menu":{}}`))
+		require.Error(t, err)
+		require.Equal(t, PublishMenuErrorCode, err.Code())
+		require.Equal(t, command.ExecuteError, err.Type())
+		require.Contains(t, err.Error(), "publish error")
+	})
+}
+
+func TestCommand_Menu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{
+			MyMenuValue:    &actionmenu.Menu{Title: "mine"},
+			TheirMenuValue: &actionmenu.Menu{Title: "theirs"},
+		})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Menu(&b, bytes.NewBufferString(`{"connectionID":"conn"}`)))
+
+		var resp MenuResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Equal(t, "mine", resp.Menu.Title)
+
+		b.Reset()
+		require.NoError(t, cmd.TheirMenu(&b, bytes.NewBufferString(`{"connectionID":"conn"}`)))
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Equal(t, "theirs", resp.Menu.Title)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{
+			MyMenuErr:    actionmenu.ErrMenuNotFound,
+			TheirMenuErr: actionmenu.ErrMenuNotFound,
+		})
+
+		err := cmd.Menu(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.TheirMenu(&bytes.Buffer{}, bytes.NewBufferString(`{`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.Menu(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.Error(t, err)
+		require.Equal(t, MenuErrorCode, err.Code())
+		require.Contains(t, err.Error(), actionmenu.ErrMenuNotFound.Error())
+
+		err = cmd.TheirMenu(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.Error(t, err)
+		require.Equal(t, MenuErrorCode, err.Code())
+	})
+}
+
+func TestCommand_RequestMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+		require.NoError(t, cmd.RequestMenu(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn"}`)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{RequestMenuErr: errors.New("send error")})
+
+		err := cmd.RequestMenu(&bytes.Buffer{}, bytes.NewBufferString(`{`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.RequestMenu(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.RequestMenu(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.Error(t, err)
+		require.Equal(t, RequestMenuErrorCode, err.Code())
+		require.Contains(t, err.Error(), "send error")
+	})
+}
+
+func TestCommand_Perform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{
+			PerformFunc: func(connectionID, name string, params map[string]string) error {
+				require.Equal(t, "conn", connectionID)
+				require.Equal(t, "a", name)
+				require.Equal(t, map[string]string{"x": "1"}, params)
+
+				return nil
+			},
+		})
+		require.NoError(t, cmd.Perform(&bytes.Buffer{},
+			bytes.NewBufferString(`{"connectionID":"conn","name":"a","params":{"x":"1"}}`)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{
+			PerformFunc: func(string, string, map[string]string) error {
+				return errors.New("perform error")
+			},
+		})
+
+		for request, msg := range map[string]string{
+			`{`:                    "request decode",
+			`{"name":"a"}`:         errEmptyConnectionID,
+			`{"connectionID":"c"}`: "name is mandatory",
+		} {
+			err := cmd.Perform(&bytes.Buffer{}, bytes.NewBufferString(request))
+			require.Error(t, err)
+			require.Equal(t, InvalidRequestErrorCode, err.Code())
+			require.Contains(t, err.Error(), msg)
+		}
+
+		err := cmd.Perform(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"c","name":"a"}`))
+		require.Error(t, err)
+		require.Equal(t, PerformErrorCode, err.Code())
+		require.Contains(t, err.Error(), "perform error")
+	})
+}
+
+func TestCommand_Actions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{
+			ActionsValue: []actionmenu.Action{{PIID: "piid", ConnectionID: "conn"}},
+		})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Actions(&b, nil))
+
+		var resp ActionsResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Len(t, resp.Actions, 1)
+		require.Equal(t, "piid", resp.Actions[0].PIID)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{ActionsErr: errors.New("query error")})
+
+		err := cmd.Actions(&bytes.Buffer{}, nil)
+		require.Error(t, err)
+		require.Equal(t, ActionsErrorCode, err.Code())
+	})
+}
+
+func TestCommand_AcceptPerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+		require.NoError(t, cmd.AcceptPerform(&bytes.Buffer{},
+			bytes.NewBufferString(`{"piid":"piid","menu":{"title":"Next"}}`)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{ActionContinueErr: errors.New("continue error")})
+
+		err := cmd.AcceptPerform(&bytes.Buffer{}, bytes.NewBufferString(`{`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.AcceptPerform(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyPIID)
+
+		err = cmd.AcceptPerform(&bytes.Buffer{}, bytes.NewBufferString(`{"piid":"piid"}`))
+		require.Error(t, err)
+		require.Equal(t, AcceptPerformErrorCode, err.Code())
+		require.Contains(t, err.Error(), "continue error")
+	})
+}
+
+func TestCommand_DeclinePerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{})
+		require.NoError(t, cmd.DeclinePerform(&bytes.Buffer{},
+			bytes.NewBufferString(`{"piid":"piid","reason":"not now"}`)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockactionmenu.MockActionMenuSvc{ActionStopErr: errors.New("stop error")})
+
+		err := cmd.DeclinePerform(&bytes.Buffer{}, bytes.NewBufferString(`{`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+
+		err = cmd.DeclinePerform(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyPIID)
+
+		err = cmd.DeclinePerform(&bytes.Buffer{}, bytes.NewBufferString(`{"piid":"piid"}`))
+		require.Error(t, err)
+		require.Equal(t, DeclinePerformErrorCode, err.Code())
+		require.Contains(t, err.Error(), "stop error")
+	})
+}
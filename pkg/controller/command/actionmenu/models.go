@@ -0,0 +1,80 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+// PublishMenuArgs model
+//
+// This is used for publishing the menu offered over a connection.
+type PublishMenuArgs struct {
+	// ConnectionID of the connection the menu is offered over.
+	ConnectionID string `json:"connectionID"`
+
+	// Menu offered.
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// MenuArgs model
+//
+// This is used for the menu operations of a connection.
+type MenuArgs struct {
+	// ConnectionID of the connection.
+	ConnectionID string `json:"connectionID"`
+}
+
+// MenuResponse model
+//
+// This is the menu of a connection.
+type MenuResponse struct {
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// PerformArgs model
+//
+// This is used for requesting the agent at the other end of a connection to perform an option of its menu.
+type PerformArgs struct {
+	// ConnectionID of the connection.
+	ConnectionID string `json:"connectionID"`
+
+	// Name of the menu option.
+	Name string `json:"name"`
+
+	// Params are the values of the option form.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ActionsResponse model
+//
+// This is the list of the perform requests waiting to be accepted or declined.
+type ActionsResponse struct {
+	Actions []actionmenu.Action `json:"actions"`
+}
+
+// AcceptPerformArgs model
+//
+// This is used for accepting a perform request.
+type AcceptPerformArgs struct {
+	// PIID of the perform request.
+	PIID string `json:"piid"`
+
+	// Menu published as the new current menu of the connection, if any.
+	Menu *actionmenu.Menu `json:"menu,omitempty"`
+}
+
+// DeclinePerformArgs model
+//
+// This is used for declining a perform request.
+type DeclinePerformArgs struct {
+	// PIID of the perform request.
+	PIID string `json:"piid"`
+
+	// Reason sent back to the other agent as error message of the current menu, if any.
+	Reason string `json:"reason,omitempty"`
+}
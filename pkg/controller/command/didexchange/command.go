@@ -44,6 +44,11 @@ const (
 	ReceiveInvitationCommandMethod        = "ReceiveInvitation"
 	CreateConnectionCommandMethod         = "CreateConnection"
 	RemoveConnectionCommandMethod         = "RemoveConnection"
+	UpdateConnectionMetadataCommandMethod = "UpdateConnectionMetadata"
+	SetConnectionTagsCommandMethod        = "SetConnectionTags"
+	ActivateConnectionCommandMethod       = "ActivateConnection"
+	MarkConnectionInactiveCommandMethod   = "MarkConnectionInactive"
+	ArchiveConnectionCommandMethod        = "ArchiveConnection"
 
 	// log constants.
 	connectionIDString = "connectionID"
@@ -80,6 +85,9 @@ const (
 	// CreateConnectionErrorCode is for failures in create connection command.
 	CreateConnectionErrorCode
 
+	// UpdateConnectionErrorCode is for failures in the commands updating the metadata, tags or status of a connection.
+	UpdateConnectionErrorCode
+
 	_actions = "_actions"
 	_states  = "_states"
 )
@@ -169,6 +177,11 @@ func (c *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, QueryConnectionsCommandMethod, c.QueryConnections),
 		cmdutil.NewCommandHandler(CommandName, AcceptExchangeRequestCommandMethod, c.AcceptExchangeRequest),
 		cmdutil.NewCommandHandler(CommandName, CreateImplicitInvitationCommandMethod, c.CreateImplicitInvitation),
+		cmdutil.NewCommandHandler(CommandName, UpdateConnectionMetadataCommandMethod, c.UpdateConnectionMetadata),
+		cmdutil.NewCommandHandler(CommandName, SetConnectionTagsCommandMethod, c.SetConnectionTags),
+		cmdutil.NewCommandHandler(CommandName, ActivateConnectionCommandMethod, c.ActivateConnection),
+		cmdutil.NewCommandHandler(CommandName, MarkConnectionInactiveCommandMethod, c.MarkConnectionInactive),
+		cmdutil.NewCommandHandler(CommandName, ArchiveConnectionCommandMethod, c.ArchiveConnection),
 	}
 }
 
@@ -463,9 +476,10 @@ func (c *Command) CreateConnection(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
-// RemoveConnection removes given connection record.
+// RemoveConnection removes given connection record, along with its DID exchange event data and thread ID mapping and
+// its stored credentials and presentations if removeStoredData is set.
 func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
-	var request ConnectionIDArg
+	var request RemoveConnectionRequest
 
 	err := json.NewDecoder(req).Decode(&request)
 	if err != nil {
@@ -482,7 +496,13 @@ func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
 
 	logger.Debugf("Removing connection record for id [%s]", request.ID)
 
-	err = c.client.RemoveConnection(request.ID)
+	var opts []didexchange.RemoveOpt
+
+	if request.RemoveStoredData {
+		opts = append(opts, didexchange.WithStoredData())
+	}
+
+	err = c.client.RemoveConnection(request.ID, opts...)
 	if err != nil {
 		logutil.LogError(logger, CommandName, RemoveConnectionCommandMethod, err.Error(),
 			logutil.CreateKeyValueString(connectionIDString, request.ID))
@@ -495,3 +515,85 @@ func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// UpdateConnectionMetadata merges the given metadata into the metadata of the connection.
+func (c *Command) UpdateConnectionMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateConnectionMetadataArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateConnectionMetadataCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	return c.updateConnection(UpdateConnectionMetadataCommandMethod, request.ID, func() error {
+		return c.client.UpdateConnectionMetadata(request.ID, request.Metadata)
+	})
+}
+
+// SetConnectionTags replaces the tags of the connection.
+func (c *Command) SetConnectionTags(rw io.Writer, req io.Reader) command.Error {
+	var request SetConnectionTagsArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetConnectionTagsCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	return c.updateConnection(SetConnectionTagsCommandMethod, request.ID, func() error {
+		return c.client.SetConnectionTags(request.ID, request.Tags...)
+	})
+}
+
+// ActivateConnection sets the lifecycle status of the connection back to active.
+func (c *Command) ActivateConnection(rw io.Writer, req io.Reader) command.Error {
+	return c.setConnectionStatus(ActivateConnectionCommandMethod, req, c.client.ActivateConnection)
+}
+
+// MarkConnectionInactive sets the lifecycle status of the connection to inactive.
+func (c *Command) MarkConnectionInactive(rw io.Writer, req io.Reader) command.Error {
+	return c.setConnectionStatus(MarkConnectionInactiveCommandMethod, req, c.client.MarkConnectionInactive)
+}
+
+// ArchiveConnection sets the lifecycle status of the connection to archived.
+func (c *Command) ArchiveConnection(rw io.Writer, req io.Reader) command.Error {
+	return c.setConnectionStatus(ArchiveConnectionCommandMethod, req, c.client.ArchiveConnection)
+}
+
+func (c *Command) setConnectionStatus(method string, req io.Reader, setStatus func(string) error) command.Error {
+	var request ConnectionIDArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, method, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	return c.updateConnection(method, request.ID, func() error {
+		return setStatus(request.ID)
+	})
+}
+
+func (c *Command) updateConnection(method, connectionID string, update func() error) command.Error {
+	if connectionID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyConnID)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyConnID))
+	}
+
+	if err := update(); err != nil {
+		logutil.LogError(logger, CommandName, method, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, connectionID))
+
+		return command.NewExecuteError(UpdateConnectionErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, method, successString,
+		logutil.CreateKeyValueString(connectionIDString, connectionID))
+
+	return nil
+}
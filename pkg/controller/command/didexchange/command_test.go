@@ -961,6 +961,108 @@ func TestCommand_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestCommand_ConnectionLifecycle(t *testing.T) {
+	const connID = "1234"
+
+	newCommand := func(t *testing.T) *Command {
+		t.Helper()
+
+		prov := mockProvider()
+		prov.StorageProviderValue = mem.NewProvider()
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connID,
+			State:        connection.StateNameCompleted,
+			ThreadID:     "th1234",
+			Namespace:    connection.MyNSPrefix,
+		}))
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	queryConnections := func(t *testing.T, cmd *Command, request string) []*connectionResult {
+		t.Helper()
+
+		var b bytes.Buffer
+
+		require.NoError(t, cmd.QueryConnections(&b, bytes.NewBufferString(request)))
+
+		var response struct {
+			Results []*connectionResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+
+		return response.Results
+	}
+
+	t.Run("metadata, tags and status", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+
+		require.NoError(t, cmd.UpdateConnectionMetadata(&b,
+			bytes.NewBufferString(`{"id":"1234","metadata":{"crm_id":"crm:42"}}`)))
+		require.NoError(t, cmd.SetConnectionTags(&b, bytes.NewBufferString(`{"id":"1234","tags":["vip"]}`)))
+		require.NoError(t, cmd.MarkConnectionInactive(&b, bytes.NewBufferString(`{"id":"1234"}`)))
+
+		results := queryConnections(t, cmd, `{"metadata":{"crm_id":"crm:42"},"tags":["vip"],"status":"inactive"}`)
+		require.Len(t, results, 1)
+		require.Equal(t, map[string]string{"crm_id": "crm:42"}, results[0].Metadata)
+		require.Equal(t, []string{"vip"}, results[0].Tags)
+
+		require.Empty(t, queryConnections(t, cmd, `{"status":"active"}`))
+
+		require.NoError(t, cmd.ArchiveConnection(&b, bytes.NewBufferString(`{"id":"1234"}`)))
+		require.Len(t, queryConnections(t, cmd, `{"status":"archived"}`), 1)
+
+		require.NoError(t, cmd.ActivateConnection(&b, bytes.NewBufferString(`{"id":"1234"}`)))
+		require.Len(t, queryConnections(t, cmd, `{"status":"active"}`), 1)
+
+		require.NoError(t, cmd.RemoveConnection(&b, bytes.NewBufferString(`{"id":"1234","removeStoredData":true}`)))
+		require.Empty(t, queryConnections(t, cmd, `{}`))
+	})
+
+	t.Run("validation and execution errors", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		handlers := map[string]command.Exec{
+			UpdateConnectionMetadataCommandMethod: cmd.UpdateConnectionMetadata,
+			SetConnectionTagsCommandMethod:        cmd.SetConnectionTags,
+			ActivateConnectionCommandMethod:       cmd.ActivateConnection,
+			MarkConnectionInactiveCommandMethod:   cmd.MarkConnectionInactive,
+			ArchiveConnectionCommandMethod:        cmd.ArchiveConnection,
+		}
+
+		for method, handler := range handlers {
+			var b bytes.Buffer
+
+			cmdErr := handler(&b, bytes.NewBufferString(`--`))
+			require.Error(t, cmdErr, method)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+			cmdErr = handler(&b, bytes.NewBufferString(`{"id":""}`))
+			require.Error(t, cmdErr, method)
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), errEmptyConnID)
+
+			cmdErr = handler(&b, bytes.NewBufferString(`{"id":"unknown"}`))
+			require.Error(t, cmdErr, method)
+			require.Equal(t, UpdateConnectionErrorCode, cmdErr.Code())
+			require.Equal(t, command.ExecuteError, cmdErr.Type())
+		}
+	})
+}
+
+type connectionResult struct {
+	Metadata map[string]string
+	Tags     []string
+}
+
 func mockProvider() *mockprovider.Provider {
 	return &mockprovider.Provider{
 		ProtocolStateStorageProviderValue: mem.NewProvider(),
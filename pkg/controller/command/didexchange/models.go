@@ -301,6 +301,35 @@ type ExchangeResponse struct {
 type RemoveConnectionRequest struct {
 	// The ID of the connection record to remove
 	ID string `json:"id"`
+
+	// RemoveStoredData removes the DID exchange event data and thread ID mapping and the stored credentials and
+	// presentations of the connection too, the DID key mappings and the state of the other protocols run over the
+	// connection being kept
+	RemoveStoredData bool `json:"removeStoredData,omitempty"`
+}
+
+// UpdateConnectionMetadataArgs model
+//
+// This is used for updating the metadata of a connection.
+//
+type UpdateConnectionMetadataArgs struct {
+	// Connection ID
+	ID string `json:"id"`
+
+	// Metadata merged into the connection metadata, the keys with an empty value being removed
+	Metadata map[string]string `json:"metadata"`
+}
+
+// SetConnectionTagsArgs model
+//
+// This is used for replacing the tags of a connection.
+//
+type SetConnectionTagsArgs struct {
+	// Connection ID
+	ID string `json:"id"`
+
+	// Tags of the connection
+	Tags []string `json:"tags"`
 }
 
 // ConnectionIDArg model
@@ -0,0 +1,169 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/discoverfeatures")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.DiscoverFeatures)
+
+	// QueryErrorCode is for failures in query command.
+	QueryErrorCode
+)
+
+// constants for the discover features controller.
+const (
+	// command name.
+	CommandName = "discoverfeatures"
+
+	// command methods.
+	QueryCommandMethod    = "Query"
+	FeaturesCommandMethod = "Features"
+
+	// protocol versions.
+	versionV1 = "1.0"
+	versionV2 = "2.0"
+
+	// log constants.
+	connectionID  = "connectionID"
+	successString = "success"
+)
+
+// provider contains dependencies for the discover features command and is typically created by using
+// aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by discover features controller.
+type Command struct {
+	client *client.Client
+}
+
+// New returns new discover features controller command instance.
+func New(ctx provider) (*Command, error) {
+	discoverClient, err := client.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features client : %w", err)
+	}
+
+	return &Command{client: discoverClient}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, QueryCommandMethod, c.Query),
+		cmdutil.NewCommandHandler(CommandName, FeaturesCommandMethod, c.Features),
+	}
+}
+
+// Query queries the features of the agent at the other end of the connection.
+func (c *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	var args QueryArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, QueryCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, QueryCommandMethod, "missing connectionID")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("connectionID is mandatory"))
+	}
+
+	if len(args.Matches) == 0 {
+		logutil.LogDebug(logger, CommandName, QueryCommandMethod, "missing matches")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("matches are mandatory"))
+	}
+
+	opts, err := queryOpts(&args)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, QueryCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	disclosures, err := c.client.Query(args.ConnectionID, args.Matches, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(QueryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DisclosuresResponse{Disclosures: disclosures}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
+
+// Features lists the features of this agent.
+func (c *Command) Features(rw io.Writer, req io.Reader) command.Error {
+	var args FeaturesArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, FeaturesCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(args.Matches) == 0 {
+		args.Matches = []string{"*"}
+	}
+
+	command.WriteNillableResponse(rw, &DisclosuresResponse{Disclosures: c.client.Features(args.Matches...)}, logger)
+
+	logutil.LogDebug(logger, CommandName, FeaturesCommandMethod, successString)
+
+	return nil
+}
+
+func queryOpts(args *QueryArgs) ([]discoverfeatures.QueryOpt, error) {
+	var opts []discoverfeatures.QueryOpt
+
+	switch args.Version {
+	case "", versionV2:
+	case versionV1:
+		opts = append(opts, discoverfeatures.WithSpecV1())
+	default:
+		return nil, fmt.Errorf("unsupported version [%s]", args.Version)
+	}
+
+	if args.Timeout != "" {
+		timeout, err := time.ParseDuration(args.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout [%s]", args.Timeout)
+		}
+
+		opts = append(opts, discoverfeatures.WithTimeout(timeout))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscover "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+var disclosures = []discoverfeatures.Disclosure{
+	{FeatureType: discoverfeatures.FeatureTypeProtocol, ID: "https://didcomm.org/present-proof/2.0"},
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create discover features client")
+	})
+}
+
+func TestCommand_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+			QueryFunc: func(connectionID string, matches []string,
+				opts ...discoverfeatures.QueryOpt) ([]discoverfeatures.Disclosure, error) {
+				require.Equal(t, "conn", connectionID)
+				require.Equal(t, []string{"https://didcomm.org/present-proof/*"}, matches)
+				require.Len(t, opts, 2)
+
+				return disclosures, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Query(&b, bytes.NewBufferString(`{"connectionID":"conn",
+			"matches":["https://didcomm.org/present-proof/*"],"version":"1.0","timeout":"1s"}`)))
+
+		var resp DisclosuresResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Equal(t, disclosures, resp.Disclosures)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		for request, msg := range map[string]string{
+			`{`:                    "request decode",
+			`{"matches":["*"]}`:    "connectionID is mandatory",
+			`{"connectionID":"c"}`: "matches are mandatory",
+			`{"connectionID":"c","matches":["*"],"version":"3.0"}`: "unsupported version [3.0]",
+			`{"connectionID":"c","matches":["*"],"timeout":"x"}`:   "invalid timeout [x]",
+		} {
+			cmdErr := cmd.Query(&bytes.Buffer{}, bytes.NewBufferString(request))
+			require.Error(t, cmdErr)
+			require.Contains(t, cmdErr.Error(), msg)
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+			QueryErr: discoverfeatures.ErrNoDisclosure,
+		}})
+		require.NoError(t, err)
+
+		cmdErr := cmd.Query(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn","matches":["*"]}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), discoverfeatures.ErrNoDisclosure.Error())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Equal(t, QueryErrorCode, cmdErr.Code())
+	})
+}
+
+func TestCommand_Features(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+		Disclosures: disclosures,
+	}})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.Features(&b, bytes.NewBufferString(`{}`)))
+
+	var resp DisclosuresResponse
+	require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+	require.Equal(t, disclosures, resp.Disclosures)
+
+	cmdErr := cmd.Features(&b, bytes.NewBufferString(`{`))
+	require.Error(t, cmdErr)
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+}
@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+
+// QueryArgs model
+//
+// This is used for querying the features of the agent at the other end of a connection.
+type QueryArgs struct {
+	// ConnectionID of the connection to query.
+	ConnectionID string `json:"connectionID"`
+
+	// Matches are the protocol identifiers to query, a `*` standing for any sequence of characters.
+	Matches []string `json:"matches"`
+
+	// Version of the discover features protocol, "1.0" or "2.0" (the default), 1.0 supporting a single match.
+	Version string `json:"version,omitempty"`
+
+	// Timeout is how long to wait for the disclosure as a duration string, e.g. "5s", 10 seconds by default.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// FeaturesArgs model
+//
+// This is used for listing the features of this agent.
+type FeaturesArgs struct {
+	// Matches are the protocol identifiers to list, all the protocols being listed by default.
+	Matches []string `json:"matches,omitempty"`
+}
+
+// DisclosuresResponse model
+//
+// This is the list of the disclosed features.
+type DisclosuresResponse struct {
+	Disclosures []discoverfeatures.Disclosure `json:"disclosures"`
+}
@@ -73,6 +73,15 @@ const (
 
 	// RFC0593 error group for RFC0593 command errors.
 	RFC0593 = 13000
+
+	// TrustPing error group for trust ping command errors.
+	TrustPing = 14000
+
+	// DiscoverFeatures error group for discover features command errors.
+	DiscoverFeatures = 15000
+
+	// ActionMenu error group for action menu command errors.
+	ActionMenu = 16000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +28,15 @@ const (
 
 	// AddContextErrorCode for add context error.
 	AddContextErrorCode
+
+	// ListContextsErrorCode for list contexts error.
+	ListContextsErrorCode
+
+	// RefreshContextErrorCode for refresh context error.
+	RefreshContextErrorCode
+
+	// RemoveContextErrorCode for remove context error.
+	RemoveContextErrorCode
 )
 
 const (
@@ -36,6 +44,12 @@ const (
 	CommandName = "context"
 	// AddContextCommandMethod is a command method for adding context.
 	AddContextCommandMethod = "Add"
+	// ListContextsCommandMethod is a command method for listing contexts.
+	ListContextsCommandMethod = "List"
+	// RefreshContextCommandMethod is a command method for refreshing context.
+	RefreshContextCommandMethod = "Refresh"
+	// RemoveContextCommandMethod is a command method for removing context.
+	RemoveContextCommandMethod = "Remove"
 )
 
 var logger = log.New("aries-framework/command/jsonld/context")
@@ -43,27 +57,41 @@ var logger = log.New("aries-framework/command/jsonld/context")
 // provider contains dependencies for the JSON-LD context commands.
 type provider interface {
 	StorageProvider() storage.Provider
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// contextRefresher is implemented by the document loaders able to load the contexts from their remote URL again,
+// like jsonld.DocumentLoader.
+type contextRefresher interface {
+	RefreshContext(u string) (*ld.RemoteDocument, error)
 }
 
 // Command contains command operations provided by context.
 type Command struct {
-	store storage.Store
+	store          storage.Store
+	documentLoader ld.DocumentLoader
 }
 
 // New returns a new JSON-LD context command instance.
 func New(p provider) (*Command, error) {
-	store, err := p.StorageProvider().OpenStore(jsonld.ContextsDBName)
+	store, err := jsonld.OpenStore(p.StorageProvider())
 	if err != nil {
-		return nil, fmt.Errorf("open store: %w", err)
+		return nil, err
 	}
 
-	return &Command{store: store}, nil
+	return &Command{
+		store:          store,
+		documentLoader: p.JSONLDDocumentLoader(),
+	}, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
 func (c *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, AddContextCommandMethod, c.Add),
+		cmdutil.NewCommandHandler(CommandName, ListContextsCommandMethod, c.List),
+		cmdutil.NewCommandHandler(CommandName, RefreshContextCommandMethod, c.Refresh),
+		cmdutil.NewCommandHandler(CommandName, RemoveContextCommandMethod, c.Remove),
 	}
 }
 
@@ -73,51 +101,118 @@ func (c *Command) Add(rw io.Writer, req io.Reader) command.Error {
 
 	err := json.NewDecoder(req).Decode(&request)
 	if err != nil {
-		return commandError(InvalidRequestErrorCode, fmt.Errorf("decode request: %w", err))
+		return commandError(AddContextCommandMethod, InvalidRequestErrorCode, fmt.Errorf("decode request: %w", err))
 	}
 
-	var ops []storage.Operation
-
 	for _, doc := range request.Documents {
 		if doc.URL == "" {
-			return commandError(AddContextErrorCode, errors.New("context URL is mandatory"))
+			return commandError(AddContextCommandMethod, AddContextErrorCode, errors.New("context URL is mandatory"))
 		}
 
 		if doc.Content == nil {
-			return commandError(AddContextErrorCode, errors.New("content is mandatory"))
+			return commandError(AddContextCommandMethod, AddContextErrorCode, errors.New("content is mandatory"))
 		}
+	}
 
-		content, err := ld.DocumentFromReader(bytes.NewReader(doc.Content))
-		if err != nil {
-			return commandError(AddContextErrorCode, fmt.Errorf("document from reader: %w", err))
-		}
+	if err := jsonld.SaveContexts(c.store, request.Documents); err != nil {
+		return commandError(AddContextCommandMethod, AddContextErrorCode, fmt.Errorf("save contexts: %w", err))
+	}
 
-		rd := ld.RemoteDocument{
-			DocumentURL: doc.DocumentURL,
-			Document:    content,
-		}
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, AddContextCommandMethod, "success")
+
+	return nil
+}
+
+// List command lists the JSON-LD contexts of the underlying storage, the embedded, added and remote ones.
+func (c *Command) List(rw io.Writer, _ io.Reader) command.Error {
+	contexts, err := jsonld.ListContexts(c.store)
+	if err != nil {
+		return executeError(ListContextsCommandMethod, ListContextsErrorCode, fmt.Errorf("list contexts: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &ListResponse{Contexts: contexts}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListContextsCommandMethod, "success")
+
+	return nil
+}
+
+// Refresh command loads a JSON-LD context from its remote URL again, under the policy of the remote loader.
+// The stored context is kept if loading fails.
+func (c *Command) Refresh(rw io.Writer, req io.Reader) command.Error {
+	var request RefreshRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		return commandError(RefreshContextCommandMethod, InvalidRequestErrorCode, fmt.Errorf("decode request: %w", err))
+	}
+
+	if request.URL == "" {
+		return commandError(RefreshContextCommandMethod, RefreshContextErrorCode, errors.New("context URL is mandatory"))
+	}
+
+	refresher, ok := c.documentLoader.(contextRefresher)
+	if !ok {
+		return commandError(RefreshContextCommandMethod, RefreshContextErrorCode,
+			errors.New("the JSON-LD document loader does not support refreshing contexts"))
+	}
+
+	if _, err = refresher.RefreshContext(request.URL); err != nil {
+		return executeError(RefreshContextCommandMethod, RefreshContextErrorCode, fmt.Errorf("refresh context: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RefreshContextCommandMethod, "success",
+		logutil.CreateKeyValueString("url", request.URL))
+
+	return nil
+}
+
+// Remove command removes a JSON-LD context from the underlying storage. An embedded context is preloaded again on
+// the next start of the agent.
+func (c *Command) Remove(rw io.Writer, req io.Reader) command.Error {
+	var request RemoveRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		return commandError(RemoveContextCommandMethod, InvalidRequestErrorCode, fmt.Errorf("decode request: %w", err))
+	}
 
-		b, err := json.Marshal(rd)
-		if err != nil {
-			return commandError(AddContextErrorCode, fmt.Errorf("marshal remote document: %w", err))
+	if request.URL == "" {
+		return commandError(RemoveContextCommandMethod, RemoveContextErrorCode, errors.New("context URL is mandatory"))
+	}
+
+	if _, err = c.store.Get(request.URL); err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return commandError(RemoveContextCommandMethod, RemoveContextErrorCode, jsonld.ErrContextNotFound)
 		}
 
-		ops = append(ops, storage.Operation{Key: doc.URL, Value: b})
+		return executeError(RemoveContextCommandMethod, RemoveContextErrorCode, fmt.Errorf("get context: %w", err))
 	}
 
-	if err := c.store.Batch(ops); err != nil {
-		return commandError(AddContextErrorCode, fmt.Errorf("save contexts: %w", err))
+	if err = c.store.Delete(request.URL); err != nil {
+		return executeError(RemoveContextCommandMethod, RemoveContextErrorCode, fmt.Errorf("remove context: %w", err))
 	}
 
 	command.WriteNillableResponse(rw, nil, logger)
 
-	logutil.LogDebug(logger, CommandName, AddContextCommandMethod, "success")
+	logutil.LogDebug(logger, CommandName, RemoveContextCommandMethod, "success",
+		logutil.CreateKeyValueString("url", request.URL))
 
 	return nil
 }
 
-func commandError(errorCode command.Code, err error) command.Error {
-	logutil.LogInfo(logger, CommandName, AddContextCommandMethod, err.Error())
+func commandError(method string, errorCode command.Code, err error) command.Error {
+	logutil.LogInfo(logger, CommandName, method, err.Error())
 
 	return command.NewValidationError(errorCode, err)
 }
+
+func executeError(method string, errorCode command.Code, err error) command.Error {
+	logutil.LogError(logger, CommandName, method, err.Error())
+
+	return command.NewExecuteError(errorCode, err)
+}
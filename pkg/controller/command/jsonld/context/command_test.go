@@ -12,6 +12,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/jsonld/context"
@@ -27,7 +28,7 @@ func TestNew(t *testing.T) {
 
 		require.NotNil(t, cmd)
 		require.NoError(t, err)
-		require.Len(t, cmd.GetHandlers(), 1)
+		require.Len(t, cmd.GetHandlers(), 4)
 	})
 
 	t.Run("Fail to open store", func(t *testing.T) {
@@ -123,6 +124,215 @@ func TestCommand_Add(t *testing.T) {
 	})
 }
 
+func TestCommand_List(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := context.New(newMockProvider(t))
+		require.NoError(t, err)
+
+		contexts := jsonldtest.Contexts()
+
+		b, err := json.Marshal(context.AddRequest{Documents: contexts})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		require.NoError(t, cmd.Add(&rw, bytes.NewReader(b)))
+
+		rw.Reset()
+		require.NoError(t, cmd.List(&rw, nil))
+
+		var resp context.ListResponse
+		require.NoError(t, json.Unmarshal(rw.Bytes(), &resp))
+		require.Len(t, resp.Contexts, len(contexts))
+	})
+
+	t.Run("Fail to query contexts", func(t *testing.T) {
+		storage := mockstorage.NewMockStoreProvider()
+		storage.Store.ErrQuery = errors.New("query error")
+
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue: storage,
+		})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.List(&rw, nil)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query error")
+	})
+}
+
+func TestCommand_Refresh(t *testing.T) {
+	const contextURL = "https://example.com/context.jsonld"
+
+	t.Run("Success", func(t *testing.T) {
+		storage := mockstorage.NewMockStoreProvider()
+
+		remoteLoader := &mockRemoteLoader{doc: map[string]interface{}{"@context": map[string]interface{}{}}}
+
+		loader, err := jsonld.NewDocumentLoader(storage, jsonld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue:      storage,
+			JSONLDDocumentLoaderValue: loader,
+		})
+		require.NoError(t, err)
+
+		b, err := json.Marshal(context.RefreshRequest{URL: contextURL})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		require.NoError(t, cmd.Refresh(&rw, bytes.NewReader(b)))
+		require.Contains(t, storage.Store.Store, contextURL)
+	})
+
+	t.Run("Fail: context URL is mandatory", func(t *testing.T) {
+		cmd, err := context.New(newMockProvider(t))
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Refresh(&rw, bytes.NewReader([]byte("{}")))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context URL is mandatory")
+	})
+
+	t.Run("Fail: document loader does not support refreshing contexts", func(t *testing.T) {
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue:      mockstorage.NewMockStoreProvider(),
+			JSONLDDocumentLoaderValue: &mockRemoteLoader{},
+		})
+		require.NoError(t, err)
+
+		b, err := json.Marshal(context.RefreshRequest{URL: contextURL})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Refresh(&rw, bytes.NewReader(b))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support refreshing contexts")
+	})
+
+	t.Run("Fail to load context", func(t *testing.T) {
+		storage := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storage,
+			jsonld.WithRemoteDocumentLoader(&mockRemoteLoader{err: errors.New("load error")}))
+		require.NoError(t, err)
+
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue:      storage,
+			JSONLDDocumentLoaderValue: loader,
+		})
+		require.NoError(t, err)
+
+		b, err := json.Marshal(context.RefreshRequest{URL: contextURL})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Refresh(&rw, bytes.NewReader(b))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load error")
+	})
+
+	t.Run("Fail to decode request", func(t *testing.T) {
+		cmd, err := context.New(newMockProvider(t))
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Refresh(&rw, bytes.NewReader([]byte("invalid")))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode request")
+	})
+}
+
+func TestCommand_Remove(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		storage := mockstorage.NewMockStoreProvider()
+
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue: storage,
+		})
+		require.NoError(t, err)
+
+		contexts := jsonldtest.Contexts()
+
+		b, err := json.Marshal(context.AddRequest{Documents: contexts})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		require.NoError(t, cmd.Add(&rw, bytes.NewReader(b)))
+
+		b, err = json.Marshal(context.RemoveRequest{URL: contexts[0].URL})
+		require.NoError(t, err)
+
+		require.NoError(t, cmd.Remove(&rw, bytes.NewReader(b)))
+		require.NotContains(t, storage.Store.Store, contexts[0].URL)
+		require.Len(t, storage.Store.Store, len(contexts)-1)
+	})
+
+	t.Run("Fail: context URL is mandatory", func(t *testing.T) {
+		cmd, err := context.New(newMockProvider(t))
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Remove(&rw, bytes.NewReader([]byte("{}")))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context URL is mandatory")
+	})
+
+	t.Run("Fail: context not found", func(t *testing.T) {
+		cmd, err := context.New(newMockProvider(t))
+		require.NoError(t, err)
+
+		b, err := json.Marshal(context.RemoveRequest{URL: "https://example.com/context.jsonld"})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Remove(&rw, bytes.NewReader(b))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), jsonld.ErrContextNotFound.Error())
+	})
+
+	t.Run("Fail to get context", func(t *testing.T) {
+		storage := mockstorage.NewMockStoreProvider()
+		storage.Store.ErrGet = errors.New("get error")
+
+		cmd, err := context.New(&mockprovider.Provider{
+			StorageProviderValue: storage,
+		})
+		require.NoError(t, err)
+
+		b, err := json.Marshal(context.RemoveRequest{URL: "https://example.com/context.jsonld"})
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		err = cmd.Remove(&rw, bytes.NewReader(b))
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get error")
+	})
+}
+
+type mockRemoteLoader struct {
+	doc interface{}
+	err error
+}
+
+func (m *mockRemoteLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &ld.RemoteDocument{DocumentURL: u, Document: m.doc}, nil
+}
+
 func newMockProvider(t *testing.T) *mockprovider.Provider {
 	t.Helper()
 
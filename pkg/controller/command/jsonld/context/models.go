@@ -12,3 +12,18 @@ import "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 type AddRequest struct {
 	Documents []jsonld.ContextDocument `json:"documents"`
 }
+
+// ListResponse is a response model for listing JSON-LD contexts.
+type ListResponse struct {
+	Contexts []jsonld.StoredContext `json:"contexts"`
+}
+
+// RefreshRequest is a request model for loading a JSON-LD context from its remote URL again.
+type RefreshRequest struct {
+	URL string `json:"url"`
+}
+
+// RemoveRequest is a request model for removing a JSON-LD context.
+type RemoveRequest struct {
+	URL string `json:"url"`
+}
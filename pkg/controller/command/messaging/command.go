@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -40,6 +41,10 @@ const (
 	errMsgDestSvcEndpointMissing     = "missing service endpoint in message destination"
 	errMsgDestSvcEndpointKeysMissing = "missing service endpoint recipient/routing keys in message destination"
 	errMsgIDEmpty                    = "empty message ID"
+	errMsgConnectionIDEmpty          = "empty connection ID"
+	errMsgContentEmpty               = "empty message content"
+	errMsgInvalidVersion             = "invalid basic message version %s, expected 1.0 or 2.0"
+	errMsgInvalidPage                = "offset and limit must not be negative"
 
 	// command methods.
 	RegisteredServicesCommandMethod         = "Services"
@@ -48,9 +53,17 @@ const (
 	RegisterHTTPMessageServiceCommandMethod = "RegisterHTTPService"
 	SendNewMessageCommandMethod             = "Send"
 	SendReplyMessageCommandMethod           = "Reply"
+	SendBasicMessageCommandMethod           = "SendBasicMessage"
+	BasicMessageHistoryCommandMethod        = "BasicMessageHistory"
+	MarkBasicMessagesReadCommandMethod      = "MarkBasicMessagesRead"
+
+	// basic message versions.
+	basicMessageV1 = "1.0"
+	basicMessageV2 = "2.0"
 
 	// log constants.
 	replyTo       = "replyTo"
+	connectionID  = "connectionID"
 	successString = "success"
 
 	// default timeout.
@@ -73,6 +86,15 @@ const (
 
 	// SendMsgReplyError is for failures while sending message replies.
 	SendMsgReplyError
+
+	// SendBasicMsgError is for failures while sending basic messages.
+	SendBasicMsgError
+
+	// BasicMsgHistoryError is for failures while querying the basic message history.
+	BasicMsgHistoryError
+
+	// MarkBasicMsgReadError is for failures while marking basic messages as read.
+	MarkBasicMsgReadError
 )
 
 // provider contains dependencies for the messaging controller command operations
@@ -109,6 +131,9 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, RegisterHTTPMessageServiceCommandMethod, o.RegisterHTTPService),
 		cmdutil.NewCommandHandler(CommandName, SendNewMessageCommandMethod, o.Send),
 		cmdutil.NewCommandHandler(CommandName, SendReplyMessageCommandMethod, o.Reply),
+		cmdutil.NewCommandHandler(CommandName, SendBasicMessageCommandMethod, o.SendBasicMessage),
+		cmdutil.NewCommandHandler(CommandName, BasicMessageHistoryCommandMethod, o.BasicMessageHistory),
+		cmdutil.NewCommandHandler(CommandName, MarkBasicMessagesReadCommandMethod, o.MarkBasicMessagesRead),
 	}
 }
 
@@ -252,6 +277,135 @@ func (o *Command) Reply(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// SendBasicMessage sends a basic message over the connection, the sent message being kept in its history.
+func (o *Command) SendBasicMessage(rw io.Writer, req io.Reader) command.Error {
+	var request SendBasicMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SendBasicMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, errMsgConnectionIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgConnectionIDEmpty))
+	}
+
+	if request.Content == "" {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, errMsgContentEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgContentEmpty))
+	}
+
+	opts := []basicmessage.SendOpt{basicmessage.WithLocale(request.Locale)}
+
+	switch request.Version {
+	case "", basicMessageV1:
+	case basicMessageV2:
+		opts = append(opts, basicmessage.WithSpecV2())
+	default:
+		err = fmt.Errorf(errMsgInvalidVersion, request.Version)
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	record, err := o.msgClient.SendBasicMessage(request.ConnectionID, request.Content, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendBasicMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+		return command.NewExecuteError(SendBasicMsgError, err)
+	}
+
+	command.WriteNillableResponse(rw, BasicMessageResponse{Message: record}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// BasicMessageHistory returns the basic messages sent and received over the connection.
+func (o *Command) BasicMessageHistory(rw io.Writer, req io.Reader) command.Error {
+	var request BasicMessageHistoryArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, BasicMessageHistoryCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, BasicMessageHistoryCommandMethod, errMsgConnectionIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgConnectionIDEmpty))
+	}
+
+	if request.Offset < 0 || request.Limit < 0 {
+		logutil.LogDebug(logger, CommandName, BasicMessageHistoryCommandMethod, errMsgInvalidPage)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgInvalidPage))
+	}
+
+	opts := []basicmessage.HistoryOpt{basicmessage.WithPage(request.Offset, request.Limit)}
+
+	if request.NewestFirst {
+		opts = append(opts, basicmessage.WithNewestFirst())
+	}
+
+	if request.UnreadOnly {
+		opts = append(opts, basicmessage.WithUnreadOnly())
+	}
+
+	history, err := o.msgClient.BasicMessageHistory(request.ConnectionID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, BasicMessageHistoryCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+		return command.NewExecuteError(BasicMsgHistoryError, err)
+	}
+
+	command.WriteNillableResponse(rw, BasicMessageHistoryResponse{
+		Messages: history.Messages,
+		Total:    history.Total,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, BasicMessageHistoryCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// MarkBasicMessagesRead marks received basic messages of the connection as read.
+func (o *Command) MarkBasicMessagesRead(rw io.Writer, req io.Reader) command.Error {
+	var request MarkBasicMessagesReadArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, MarkBasicMessagesReadCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, MarkBasicMessagesReadCommandMethod, errMsgConnectionIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgConnectionIDEmpty))
+	}
+
+	marked, err := o.msgClient.MarkBasicMessagesRead(request.ConnectionID, request.MessageIDs...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, MarkBasicMessagesReadCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+		return command.NewExecuteError(MarkBasicMsgReadError, err)
+	}
+
+	command.WriteNillableResponse(rw, MarkBasicMessagesReadResponse{Marked: marked}, logger)
+
+	logutil.LogDebug(logger, CommandName, MarkBasicMessagesReadCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
 // RegisterHTTPService registers new http over didcomm service to message handler registrar.
 func (o *Command) RegisterHTTPService(rw io.Writer, req io.Reader) command.Error {
 	var request RegisterHTTPMsgSvcArgs
@@ -9,6 +9,7 @@ package messaging
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -19,10 +20,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockbasicmessage "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mocksvc "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -719,3 +722,136 @@ func TestCommand_Reply(t *testing.T) {
 		require.NoError(t, cmdErr)
 	})
 }
+
+func TestCommand_BasicMessage(t *testing.T) {
+	newCommand := func(t *testing.T, svc *mockbasicmessage.MockBasicMessageSvc) *Command {
+		t.Helper()
+
+		cmd, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{basicmessage.BasicMessage: svc},
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("send basic message", func(t *testing.T) {
+		cmd := newCommand(t, &mockbasicmessage.MockBasicMessageSvc{
+			SendFunc: func(connID, content string, opts ...basicmessage.SendOpt) (*basicmessage.Record, error) {
+				require.Len(t, opts, 2)
+
+				return &basicmessage.Record{ID: "msg-1", ConnectionID: connID, Content: content}, nil
+			},
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendBasicMessage(&b,
+			bytes.NewBufferString(`{"connection_ID":"conn","content":"hello","version":"2.0"}`))
+		require.NoError(t, cmdErr)
+
+		var response BasicMessageResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, "msg-1", response.Message.ID)
+		require.Equal(t, "hello", response.Message.Content)
+	})
+
+	t.Run("basic message history", func(t *testing.T) {
+		cmd := newCommand(t, &mockbasicmessage.MockBasicMessageSvc{
+			HistoryFunc: func(connID string, opts ...basicmessage.HistoryOpt) (*basicmessage.History, error) {
+				require.Equal(t, "conn", connID)
+				require.Len(t, opts, 3)
+
+				return &basicmessage.History{
+					Messages: []*basicmessage.Record{{ID: "msg-1"}},
+					Total:    4,
+				}, nil
+			},
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.BasicMessageHistory(&b, bytes.NewBufferString(
+			`{"connection_ID":"conn","offset":1,"limit":1,"newest_first":true,"unread_only":true}`))
+		require.NoError(t, cmdErr)
+
+		var response BasicMessageHistoryResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, 4, response.Total)
+		require.Len(t, response.Messages, 1)
+	})
+
+	t.Run("mark basic messages read", func(t *testing.T) {
+		cmd := newCommand(t, &mockbasicmessage.MockBasicMessageSvc{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.MarkBasicMessagesRead(&b,
+			bytes.NewBufferString(`{"connection_ID":"conn","message_IDs":["msg-1","msg-2"]}`))
+		require.NoError(t, cmdErr)
+
+		var response MarkBasicMessagesReadResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, 2, response.Marked)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockbasicmessage.MockBasicMessageSvc{})
+
+		tests := []struct {
+			name     string
+			handler  command.Exec
+			request  string
+			errorMsg string
+		}{
+			{"send - invalid request", cmd.SendBasicMessage, `{`, "unexpected EOF"},
+			{"send - missing connection ID", cmd.SendBasicMessage, `{"content":"hello"}`, errMsgConnectionIDEmpty},
+			{"send - missing content", cmd.SendBasicMessage, `{"connection_ID":"conn"}`, errMsgContentEmpty},
+			{
+				"send - invalid version", cmd.SendBasicMessage,
+				`{"connection_ID":"conn","content":"hello","version":"3.0"}`,
+				"invalid basic message version 3.0, expected 1.0 or 2.0",
+			},
+			{"history - invalid request", cmd.BasicMessageHistory, `{`, "unexpected EOF"},
+			{"history - missing connection ID", cmd.BasicMessageHistory, `{}`, errMsgConnectionIDEmpty},
+			{
+				"history - invalid page", cmd.BasicMessageHistory, `{"connection_ID":"conn","offset":-1}`,
+				errMsgInvalidPage,
+			},
+			{"mark read - invalid request", cmd.MarkBasicMessagesRead, `{`, "unexpected EOF"},
+			{"mark read - missing connection ID", cmd.MarkBasicMessagesRead, `{}`, errMsgConnectionIDEmpty},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				var b bytes.Buffer
+				cmdErr := tc.handler(&b, bytes.NewBufferString(tc.request))
+				require.Error(t, cmdErr)
+				require.Equal(t, command.ValidationError, cmdErr.Type())
+				require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+
+	t.Run("execute errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockbasicmessage.MockBasicMessageSvc{
+			SendErr:     errors.New("send error"),
+			HistoryErr:  errors.New("history error"),
+			MarkReadErr: errors.New("mark error"),
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendBasicMessage(&b, bytes.NewBufferString(`{"connection_ID":"conn","content":"hello"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Equal(t, SendBasicMsgError, cmdErr.Code())
+
+		cmdErr = cmd.BasicMessageHistory(&b, bytes.NewBufferString(`{"connection_ID":"conn"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, BasicMsgHistoryError, cmdErr.Code())
+
+		cmdErr = cmd.MarkBasicMessagesRead(&b, bytes.NewBufferString(`{"connection_ID":"conn"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, MarkBasicMsgReadError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "mark error")
+	})
+}
@@ -9,6 +9,8 @@ package messaging
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 )
 
 // RegisterMsgSvcArgs contains parameters for registering a message service to message handler.
@@ -120,3 +122,70 @@ type RegisterHTTPMsgSvcArgs struct {
 	// If not provided then all incoming messages of HTTP over DIDComm type will be handled by operation.
 	Purpose []string `json:"purpose"`
 }
+
+// SendBasicMessageArgs contains parameters for sending a basic message.
+type SendBasicMessageArgs struct {
+	// ID of the connection over which the message is sent
+	// required: true
+	ConnectionID string `json:"connection_ID"`
+
+	// Content of the message
+	// required: true
+	Content string `json:"content"`
+
+	// Locale of the message content
+	Locale string `json:"locale,omitempty"`
+
+	// Version of the basic message protocol, "1.0" (default) or "2.0"
+	Version string `json:"version,omitempty"`
+}
+
+// BasicMessageResponse is response for send basic message feature.
+type BasicMessageResponse struct {
+	// Message sent
+	Message *basicmessage.Record `json:"message"`
+}
+
+// BasicMessageHistoryArgs contains parameters for querying the basic messages exchanged over a connection.
+type BasicMessageHistoryArgs struct {
+	// ID of the connection
+	// required: true
+	ConnectionID string `json:"connection_ID"`
+
+	// Number of messages skipped
+	Offset int `json:"offset,omitempty"`
+
+	// Maximum number of messages returned, all of them being returned when not provided
+	Limit int `json:"limit,omitempty"`
+
+	// NewestFirst orders the messages from the newest one, they being ordered from the oldest one by default
+	NewestFirst bool `json:"newest_first,omitempty"`
+
+	// UnreadOnly returns the unread received messages only
+	UnreadOnly bool `json:"unread_only,omitempty"`
+}
+
+// BasicMessageHistoryResponse is response for basic message history feature.
+type BasicMessageHistoryResponse struct {
+	// Messages of the requested page
+	Messages []*basicmessage.Record `json:"messages"`
+
+	// Total number of messages matching the query
+	Total int `json:"total"`
+}
+
+// MarkBasicMessagesReadArgs contains parameters for marking received basic messages as read.
+type MarkBasicMessagesReadArgs struct {
+	// ID of the connection
+	// required: true
+	ConnectionID string `json:"connection_ID"`
+
+	// IDs of the messages marked as read, all the unread messages of the connection being marked when not provided
+	MessageIDs []string `json:"message_IDs,omitempty"`
+}
+
+// MarkBasicMessagesReadResponse is response for mark basic messages read feature.
+type MarkBasicMessagesReadResponse struct {
+	// Number of messages newly marked as read
+	Marked int `json:"marked"`
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/trustping")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.TrustPing)
+
+	// PingErrorCode is for failures in ping command.
+	PingErrorCode
+)
+
+// constants for the trust ping controller.
+const (
+	// command name.
+	CommandName = "trustping"
+
+	// command methods.
+	PingCommandMethod = "Ping"
+
+	// log constants.
+	connectionID  = "connectionID"
+	successString = "success"
+)
+
+// provider contains dependencies for the trust ping command and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by trust ping controller.
+type Command struct {
+	client *client.Client
+}
+
+// New returns new trust ping controller command instance.
+func New(ctx provider) (*Command, error) {
+	trustpingClient, err := client.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping client : %w", err)
+	}
+
+	return &Command{client: trustpingClient}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, PingCommandMethod, c.Ping),
+	}
+}
+
+// Ping sends a trust ping to the agent at the other end of the connection.
+func (c *Command) Ping(rw io.Writer, req io.Reader) command.Error {
+	var args PingArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, PingCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, PingCommandMethod, "missing connectionID")
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("connectionID is mandatory"))
+	}
+
+	var opts []trustping.PingOpt
+
+	if args.ResponseRequested != nil && !*args.ResponseRequested {
+		opts = append(opts, trustping.WithoutResponse())
+	}
+
+	if args.Timeout != "" {
+		timeout, err := time.ParseDuration(args.Timeout)
+		if err != nil || timeout <= 0 {
+			logutil.LogDebug(logger, CommandName, PingCommandMethod, "invalid timeout")
+
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("invalid timeout [%s]", args.Timeout))
+		}
+
+		opts = append(opts, trustping.WithTimeout(timeout))
+	}
+
+	if args.Comment != "" {
+		opts = append(opts, trustping.WithComment(args.Comment))
+	}
+
+	result, err := c.client.Ping(args.ConnectionID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, PingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+		return command.NewExecuteError(PingErrorCode, err)
+	}
+
+	resp := &PingResponse{PingID: result.PingID, Responded: result.Responded}
+
+	if result.Responded {
+		resp.Latency = result.Latency.String()
+	}
+
+	command.WriteNillableResponse(rw, resp, logger)
+
+	logutil.LogDebug(logger, CommandName, PingCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, args.ConnectionID))
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping client")
+	})
+}
+
+func TestCommand_Ping(t *testing.T) {
+	t.Run("ping with response", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(connectionID string, opts ...trustping.PingOpt) (*trustping.Result, error) {
+				require.Equal(t, "conn", connectionID)
+				require.Len(t, opts, 2)
+
+				return &trustping.Result{PingID: "ping-id", Responded: true, Latency: 1500 * time.Microsecond}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"conn","timeout":"5s","comment":"hi"}`)))
+
+		var resp PingResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &resp))
+		require.Equal(t, PingResponse{PingID: "ping-id", Responded: true, Latency: "1.5ms"}, resp)
+	})
+
+	t.Run("ping without response", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(_ string, opts ...trustping.PingOpt) (*trustping.Result, error) {
+				require.Len(t, opts, 1)
+
+				return &trustping.Result{PingID: "ping-id"}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"conn","response_requested":false}`)))
+		require.JSONEq(t, `{"ping_id":"ping-id","responded":false}`, b.String())
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		for request, msg := range map[string]string{
+			`{`:                                  "request decode",
+			`{}`:                                 "connectionID is mandatory",
+			`{"connectionID":"c","timeout":"x"}`: "invalid timeout [x]",
+			`{"connectionID":"c","timeout":"0"}`: "invalid timeout [0]",
+		} {
+			cmdErr := cmd.Ping(&bytes.Buffer{}, bytes.NewBufferString(request))
+			require.Error(t, cmdErr)
+			require.Contains(t, cmdErr.Error(), msg)
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		}
+	})
+
+	t.Run("ping error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: trustping.ErrNoResponse,
+		}})
+		require.NoError(t, err)
+
+		cmdErr := cmd.Ping(&bytes.Buffer{}, bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), trustping.ErrNoResponse.Error())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Equal(t, PingErrorCode, cmdErr.Code())
+	})
+}
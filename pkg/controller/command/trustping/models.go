@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+// PingArgs model
+//
+// This is used for sending a trust ping to the agent at the other end of a connection.
+type PingArgs struct {
+	// ConnectionID of the connection to ping.
+	ConnectionID string `json:"connectionID"`
+
+	// ResponseRequested tells whether a response is requested, which it is by default.
+	ResponseRequested *bool `json:"response_requested,omitempty"`
+
+	// Timeout is how long to wait for the response as a duration string, e.g. "5s", 10 seconds by default.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Comment of the ping.
+	Comment string `json:"comment,omitempty"`
+}
+
+// PingResponse model
+//
+// This is the outcome of a trust ping.
+type PingResponse struct {
+	// PingID is the ID of the ping message.
+	PingID string `json:"ping_id"`
+
+	// Responded tells whether the other agent responded to the ping.
+	Responded bool `json:"responded"`
+
+	// Latency is the round trip time of the ping as a duration string, set when the other agent responded.
+	Latency string `json:"latency,omitempty"`
+}
@@ -8,6 +8,7 @@ package vdr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -39,6 +40,21 @@ const (
 
 	// CreateDIDErrorCode for create did error.
 	CreateDIDErrorCode
+
+	// DereferenceDIDErrorCode for dereference did url error.
+	DereferenceDIDErrorCode
+
+	// DIDNotFoundErrorCode for did resolution notFound error.
+	DIDNotFoundErrorCode
+
+	// InvalidDIDErrorCode for did resolution invalidDid and invalidDidUrl errors.
+	InvalidDIDErrorCode
+
+	// DIDMethodNotSupportedErrorCode for did resolution methodNotSupported error.
+	DIDMethodNotSupportedErrorCode
+
+	// DIDDeactivatedErrorCode for did resolution deactivated error.
+	DIDDeactivatedErrorCode
 )
 
 // constants for the VDR controller's methods.
@@ -47,16 +63,18 @@ const (
 	CommandName = "vdr"
 
 	// command methods.
-	SaveDIDCommandMethod    = "SaveDID"
-	GetDIDsCommandMethod    = "GetDIDRecords"
-	GetDIDCommandMethod     = "GetDID"
-	ResolveDIDCommandMethod = "ResolveDID"
-	CreateDIDCommandMethod  = "CreateDID"
+	SaveDIDCommandMethod        = "SaveDID"
+	GetDIDsCommandMethod        = "GetDIDRecords"
+	GetDIDCommandMethod         = "GetDID"
+	ResolveDIDCommandMethod     = "ResolveDID"
+	CreateDIDCommandMethod      = "CreateDID"
+	DereferenceDIDCommandMethod = "DereferenceDID"
 
 	// error messages.
 	errEmptyDIDName   = "name is mandatory"
 	errEmptyDIDID     = "did is mandatory"
 	errEmptyDIDMETHOD = "did method is mandatory"
+	errEmptyDIDURL    = "did url is mandatory"
 
 	// log constants.
 	didID = "did"
@@ -96,6 +114,7 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetDIDsCommandMethod, o.GetDIDRecords),
 		cmdutil.NewCommandHandler(CommandName, ResolveDIDCommandMethod, o.ResolveDID),
 		cmdutil.NewCommandHandler(CommandName, CreateDIDCommandMethod, o.CreateDID),
+		cmdutil.NewCommandHandler(CommandName, DereferenceDIDCommandMethod, o.DereferenceDID),
 	}
 }
 
@@ -174,7 +193,14 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 		logutil.LogError(logger, CommandName, ResolveDIDCommandMethod, "resolve did doc: "+err.Error(),
 			logutil.CreateKeyValueString(didID, request.ID))
 
-		return command.NewValidationError(ResolveDIDErrorCode, fmt.Errorf("resolve did doc: %w", err))
+		return resolutionError(ResolveDIDErrorCode, fmt.Errorf("resolve did doc: %w", err))
+	}
+
+	if doc != nil && doc.DocumentMetadata != nil && doc.DocumentMetadata.Deactivated {
+		logutil.LogDebug(logger, CommandName, ResolveDIDCommandMethod, "did deactivated",
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return resolutionError(ResolveDIDErrorCode, fmt.Errorf("resolve did doc: %w", vdrapi.ErrDeactivated))
 	}
 
 	command.WriteNillableResponse(rw, doc, logger)
@@ -185,6 +211,45 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// DereferenceDID dereferences a did url.
+func (o *Command) DereferenceDID(rw io.Writer, req io.Reader) command.Error {
+	var request IDArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DereferenceDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ID == "" {
+		logutil.LogDebug(logger, CommandName, DereferenceDIDCommandMethod, errEmptyDIDURL)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDURL))
+	}
+
+	result, err := o.ctx.VDRegistry().Dereference(request.ID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DereferenceDIDCommandMethod, "dereference did url: "+err.Error(),
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return resolutionError(DereferenceDIDErrorCode, fmt.Errorf("dereference did url: %w", err))
+	}
+
+	resultBytes, err := result.JSONBytes()
+	if err != nil {
+		logutil.LogError(logger, CommandName, DereferenceDIDCommandMethod, "marshal result: "+err.Error(),
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return command.NewExecuteError(DereferenceDIDErrorCode, fmt.Errorf("marshal result: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, json.RawMessage(resultBytes), logger)
+
+	logutil.LogDebug(logger, CommandName, DereferenceDIDCommandMethod, "success",
+		logutil.CreateKeyValueString(didID, request.ID))
+
+	return nil
+}
+
 // SaveDID saves the did doc to the store.
 func (o *Command) SaveDID(rw io.Writer, req io.Reader) command.Error {
 	request := &DIDArgs{}
@@ -274,3 +339,20 @@ func (o *Command) GetDIDRecords(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// resolutionError returns a command error carrying the error code of the did resolution error,
+// or the given default code if the error has no standard did resolution error code.
+func resolutionError(defaultCode command.Code, err error) command.Error {
+	switch {
+	case errors.Is(err, vdrapi.ErrNotFound):
+		return command.NewExecuteError(DIDNotFoundErrorCode, err)
+	case errors.Is(err, vdrapi.ErrInvalidDID), errors.Is(err, vdrapi.ErrInvalidDIDURL):
+		return command.NewValidationError(InvalidDIDErrorCode, err)
+	case errors.Is(err, vdrapi.ErrMethodNotSupported):
+		return command.NewExecuteError(DIDMethodNotSupportedErrorCode, err)
+	case errors.Is(err, vdrapi.ErrDeactivated):
+		return command.NewExecuteError(DIDDeactivatedErrorCode, err)
+	default:
+		return command.NewValidationError(defaultCode, err)
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -52,7 +54,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 5, len(handlers))
+		require.Equal(t, 6, len(handlers))
 	})
 
 	t.Run("test new command - did store error", func(t *testing.T) {
@@ -333,6 +335,114 @@ func TestResolveDID(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to resolve")
 	})
+
+	t.Run("test resolve did - resolution error codes", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			code command.Code
+		}{
+			{err: vdrapi.ErrNotFound, code: DIDNotFoundErrorCode},
+			{err: vdrapi.ErrInvalidDID, code: InvalidDIDErrorCode},
+			{err: vdrapi.ErrMethodNotSupported, code: DIDMethodNotSupportedErrorCode},
+		}
+
+		for _, tc := range tests {
+			cmd, err := New(&mockprovider.Provider{
+				StorageProviderValue: mockstore.NewMockStoreProvider(),
+				VDRegistryValue:      &mockvdr.MockVDRegistry{ResolveErr: fmt.Errorf("wrapped: %w", tc.err)},
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			cmdErr := cmd.ResolveDID(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+			require.Error(t, cmdErr)
+			require.Equal(t, tc.code, cmdErr.Code())
+		}
+	})
+
+	t.Run("test resolve did - deactivated", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{
+						DIDDocument:      didDoc,
+						DocumentMetadata: &did.DocumentMetadata{Deactivated: true},
+					}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ResolveDID(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, DIDDeactivatedErrorCode, cmdErr.Code())
+	})
+}
+
+func TestDereferenceDID(t *testing.T) {
+	t.Run("test dereference did - success", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				DereferenceFunc: func(didURL string, _ ...vdrapi.DIDMethodOption) (*did.DereferencingResult, error) {
+					require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp#keys-1", didURL)
+
+					return &did.DereferencingResult{
+						Content:               &didDoc.VerificationMethod[0],
+						DereferencingMetadata: &did.ResolutionMetadata{ContentType: "application/did+ld+json"},
+					}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DereferenceDID(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp#keys-1"}`))
+		require.NoError(t, cmdErr)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, did.DereferencingContext, response["@context"])
+		require.Equal(t, "did:peer:123456789abcdefghi#keys-1",
+			response["contentStream"].(map[string]interface{})["id"])
+	})
+
+	t.Run("test dereference did - invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DereferenceDID(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.DereferenceDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "did url is mandatory")
+	})
+
+	t.Run("test dereference did - not found", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      &mockvdr.MockVDRegistry{DereferenceErr: vdrapi.ErrNotFound},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DereferenceDID(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp#key-2"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, DIDNotFoundErrorCode, cmdErr.Code())
+	})
 }
 
 func TestGetDID(t *testing.T) {
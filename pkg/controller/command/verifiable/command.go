@@ -83,6 +83,9 @@ const (
 
 	// DeriveCredentialErrorCode for derive credential error.
 	DeriveCredentialErrorCode
+
+	// VerifyCredentialErrorCode for verify credential against policy error.
+	VerifyCredentialErrorCode
 )
 
 // constants for the Verifiable protocol.
@@ -97,6 +100,7 @@ const (
 	GetCredentialsCommandMethod           = "GetCredentials"
 	SignCredentialCommandMethod           = "SignCredential"
 	DeriveCredentialCommandMethod         = "DeriveCredential"
+	VerifyCredentialCommandMethod         = "VerifyCredential"
 	SavePresentationCommandMethod         = "SavePresentation"
 	GetPresentationCommandMethod          = "GetPresentation"
 	GetPresentationsCommandMethod         = "GetPresentations"
@@ -113,6 +117,7 @@ const (
 	errEmptyDID              = "did is mandatory"
 	errEmptyCredential       = "credential is mandatory is mandatory"
 	errEmptyFrame            = "frame is mandatory is mandatory"
+	errEmptyPolicy           = "policy is mandatory"
 
 	// log constants.
 	vcID   = "vcID"
@@ -212,11 +217,12 @@ type provider interface {
 
 // Command contains command operations provided by verifiable credential controller.
 type Command struct {
-	verifiableStore verifiablestore.Store
-	didStore        *didstore.Store
-	resolver        keyResolver
-	ctx             provider
-	documentLoader  ld.DocumentLoader
+	verifiableStore   verifiablestore.Store
+	didStore          *didstore.Store
+	resolver          keyResolver
+	ctx               provider
+	documentLoader    ld.DocumentLoader
+	statusListFetcher verifiable.StatusListCredentialFetcher
 }
 
 // New returns new verifiable credential controller command instance.
@@ -231,13 +237,17 @@ func New(p provider) (*Command, error) {
 		return nil, fmt.Errorf("new did store : %w", err)
 	}
 
-	return &Command{
+	cmd := &Command{
 		verifiableStore: verifiableStore,
 		didStore:        didStore,
 		resolver:        verifiable.NewVDRKeyResolver(p.VDRegistry()),
 		ctx:             p,
 		documentLoader:  p.JSONLDDocumentLoader(),
-	}, nil
+	}
+
+	cmd.statusListFetcher = verifiable.NewHTTPStatusListFetcher(nil, cmd.getCredentialOpts(false)...)
+
+	return cmd, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -250,6 +260,7 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetCredentialsCommandMethod, o.GetCredentials),
 		cmdutil.NewCommandHandler(CommandName, SignCredentialCommandMethod, o.SignCredential),
 		cmdutil.NewCommandHandler(CommandName, DeriveCredentialCommandMethod, o.DeriveCredential),
+		cmdutil.NewCommandHandler(CommandName, VerifyCredentialCommandMethod, o.VerifyCredential),
 		cmdutil.NewCommandHandler(CommandName, GeneratePresentationCommandMethod, o.GeneratePresentation),
 		cmdutil.NewCommandHandler(CommandName, GeneratePresentationByIDCommandMethod, o.GeneratePresentationByID),
 		cmdutil.NewCommandHandler(CommandName, SavePresentationCommandMethod, o.SavePresentation),
@@ -288,6 +299,67 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+// VerifyCredential verifies the verifiable credential against the verification policy.
+// The result of each check of the policy is returned in the verification report, the credential being verified
+// only if all of them passed. The credential statuses are checked against their Bitstring Status List or Status
+// List 2021 credentials, downloaded and verified on each check.
+func (o *Command) VerifyCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &VerifyCredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.VerifiableCredential == "" {
+		logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, errEmptyCredential)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredential))
+	}
+
+	if len(request.Policy) == 0 {
+		logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, errEmptyPolicy)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPolicy))
+	}
+
+	policy, err := parsePolicy(request.Policy)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "parse policy : "+err.Error())
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf("parse policy : %w", err))
+	}
+
+	statusChecker := verifiable.NewStatusListChecker(o.statusListFetcher)
+
+	report, err := verifiable.NewPolicyEngine(policy, verifiable.WithStatusChecker(statusChecker)).
+		Verify([]byte(request.VerifiableCredential), o.getCredentialOpts(false)...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "verify vc : "+err.Error())
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf("verify vc : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &VerifyCredentialResponse{Report: report}, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, "success")
+
+	return nil
+}
+
+// parsePolicy parses the verification policy defined either as JSON object or as JSON or YAML document string.
+func parsePolicy(policy json.RawMessage) (*verifiable.VerificationPolicy, error) {
+	var policyDoc string
+
+	if err := json.Unmarshal(policy, &policyDoc); err == nil {
+		return verifiable.ParseVerificationPolicy([]byte(policyDoc))
+	}
+
+	return verifiable.ParseVerificationPolicy(policy)
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &CredentialExt{}
@@ -422,7 +422,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 14, len(handlers))
+		require.Equal(t, 15, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -492,6 +492,141 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue:      mockstore.NewMockStoreProvider(),
+		JSONLDDocumentLoaderValue: loader,
+	})
+	require.NoError(t, err)
+
+	verify := func(policy string) (*VerifyCredentialResponse, error) {
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{
+			VerifiableCredential: vc,
+			Policy:               json.RawMessage(policy),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		if cmdErr := cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes)); cmdErr != nil {
+			return nil, cmdErr
+		}
+
+		response := &VerifyCredentialResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), response))
+
+		return response, nil
+	}
+
+	t.Run("test verify vc - policy as JSON object", func(t *testing.T) {
+		response, err := verify(`{"credentialTypes":["VerifiableCredential"]}`)
+		require.NoError(t, err)
+		require.True(t, response.Report.Verified)
+		require.Len(t, response.Report.Checks, 2)
+	})
+
+	t.Run("test verify vc - issuer of unsecured credential", func(t *testing.T) {
+		response, err := verify(`{"trustedIssuers":[{"id":"did:example:09s12ec712ebc6f1c671ebfeb1f"}]}`)
+		require.NoError(t, err)
+		require.False(t, response.Report.Verified)
+		require.Equal(t, "issuer", response.Report.Checks[1].Rule)
+		require.Contains(t, response.Report.Checks[1].Error, "credential is not secured by the issuer")
+	})
+
+	t.Run("test verify vc - policy as YAML document", func(t *testing.T) {
+		response, err := verify(`"credentialTypes:\n  - UniversityDegreeCredential\n"`)
+		require.NoError(t, err)
+		require.False(t, response.Report.Verified)
+		require.Equal(t, "credentialType", response.Report.Checks[1].Rule)
+		require.False(t, response.Report.Checks[1].Passed)
+	})
+
+	t.Run("test verify vc - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		err = cmd.VerifyCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request decode")
+
+		err = cmd.VerifyCredential(&b, bytes.NewBufferString(`{"policy":{}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyCredential)
+
+		err = cmd.VerifyCredential(&b, bytes.NewBufferString(`{"verifiableCredential":"{}"}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyPolicy)
+	})
+
+	t.Run("test verify vc - invalid policy", func(t *testing.T) {
+		_, err := verify(`{"clockSkew":"forever"}`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse policy")
+	})
+
+	t.Run("test verify vc - status check", func(t *testing.T) {
+		response, err := verify(`{"checkStatus":true}`)
+		require.NoError(t, err)
+		require.False(t, response.Report.Verified)
+		require.Equal(t, "status", response.Report.Checks[1].Rule)
+		require.Contains(t, response.Report.Checks[1].Error,
+			"unsupported credential status type CredentialStatusList2017")
+
+		defer func(fetcher verifiable.StatusListCredentialFetcher) { cmd.statusListFetcher = fetcher }(
+			cmd.statusListFetcher)
+
+		// the status list of 16 entries has the entry 1 set.
+		encodedList := "H4sIAAAAAAAC_3NgAAD6XaCxAgAAAA"
+
+		cmd.statusListFetcher = func(url string) (*verifiable.Credential, error) {
+			require.Equal(t, "https://example.gov/status/65", url)
+
+			return &verifiable.Credential{
+				Issuer:  verifiable.Issuer{ID: "did:example:09s12ec712ebc6f1c671ebfeb1f"},
+				Subject: map[string]interface{}{"statusPurpose": "revocation", "encodedList": encodedList},
+			}, nil
+		}
+
+		statusVC := strings.Replace(vc, `"type":"CredentialStatusList2017"`, `"type":"StatusList2021Entry",
+      "statusPurpose":"revocation",
+      "statusListIndex":"0",
+      "statusListCredential":"https://example.gov/status/65"`, 1)
+
+		for index, verified := range map[string]bool{"0": true, "1": false} {
+			reqBytes, err := json.Marshal(VerifyCredentialRequest{
+				VerifiableCredential: strings.Replace(statusVC, `"statusListIndex":"0"`,
+					`"statusListIndex":"`+index+`"`, 1),
+				Policy: json.RawMessage(`{"checkStatus":true}`),
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+
+			require.NoError(t, cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes)))
+
+			response := &VerifyCredentialResponse{}
+			require.NoError(t, json.Unmarshal(b.Bytes(), response))
+			require.Equal(t, verified, response.Report.Verified, response.Report.Checks)
+		}
+	})
+
+	t.Run("test verify vc - invalid credential", func(t *testing.T) {
+		reqBytes, err := json.Marshal(VerifyCredentialRequest{
+			VerifiableCredential: invalidVC,
+			Policy:               json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		err = cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify vc")
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	loader, err := jsonldtest.DocumentLoader()
 	require.NoError(t, err)
@@ -19,6 +19,19 @@ type Credential struct {
 	VerifiableCredential string `json:"verifiableCredential,omitempty"`
 }
 
+// VerifyCredentialRequest is model for verifying a verifiable credential against a verification policy.
+type VerifyCredentialRequest struct {
+	// VerifiableCredential in JSON or JWS form.
+	VerifiableCredential string `json:"verifiableCredential,omitempty"`
+	// Policy is the verification policy, either a JSON object or a JSON or YAML document string.
+	Policy json.RawMessage `json:"policy,omitempty"`
+}
+
+// VerifyCredentialResponse is model for the verification report of a verifiable credential.
+type VerifyCredentialResponse struct {
+	Report *docverifiable.VerificationReport `json:"report"`
+}
+
 // PresentationRequest is model for verifiable presentation request.
 type PresentationRequest struct {
 	VerifiableCredentials []json.RawMessage `json:"verifiableCredential,omitempty"`
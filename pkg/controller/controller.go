@@ -9,8 +9,11 @@ package controller
 import (
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	actionmenucmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	discoverfeaturescmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	jsonldcontextcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/jsonld/context"
@@ -19,11 +22,14 @@ import (
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vcwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	actionmenurest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	discoverfeaturesrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/discoverfeatures"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	jsonldcontextrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/jsonld/context"
@@ -33,6 +39,7 @@ import (
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/rfc0593"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
@@ -48,6 +55,7 @@ type allOpts struct {
 	msgHandler         command.MessageHandler
 	notifier           command.Notifier
 	walletConf         *vcwalletcmd.Config
+	notifierOpts       []webnotifier.Opt
 }
 
 const wsPath = "/ws"
@@ -69,6 +77,15 @@ func WithNotifier(notifier command.Notifier) Opt {
 	}
 }
 
+// WithRedactionPolicy is an option for setting the policy masking the sensitive members of the messages sent by the
+// webhook and websocket notifier, redact.DefaultPolicy() by default. A nil policy disables redaction.
+// It has no effect on the notifier set by WithNotifier.
+func WithRedactionPolicy(policy *redact.Policy) Opt {
+	return func(opts *allOpts) {
+		opts.notifierOpts = append(opts.notifierOpts, webnotifier.WithRedactionPolicy(policy))
+	}
+}
+
 // WithDefaultLabel is an option allowing for the defaultLabel to be set.
 func WithDefaultLabel(defaultLabel string) Opt {
 	return func(opts *allOpts) {
@@ -114,7 +131,7 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 
 	notifier := restAPIOpts.notifier
 	if notifier == nil {
-		notifier = webnotifier.New(wsPath, restAPIOpts.webhookURLs)
+		notifier = webnotifier.New(wsPath, restAPIOpts.webhookURLs, restAPIOpts.notifierOpts...)
 	}
 
 	// DID Exchange REST operation
@@ -180,6 +197,24 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create outofband rest command : %w", err)
 	}
 
+	// trust ping REST operation
+	trustpingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping rest command : %w", err)
+	}
+
+	// discover features REST operation
+	discoverfeaturesOp, err := discoverfeaturesrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features rest command : %w", err)
+	}
+
+	// action menu REST operation
+	actionmenuOp, err := actionmenurest.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu rest command : %w", err)
+	}
+
 	// kms command operation
 	kmscmd := kmsrest.New(ctx)
 
@@ -204,6 +239,9 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, presentproofOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustpingOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, discoverfeaturesOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, actionmenuOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, contextOp.GetRESTHandlers()...)
@@ -230,7 +268,7 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 
 	notifier := cmdOpts.notifier
 	if notifier == nil {
-		notifier = webnotifier.New(wsPath, cmdOpts.webhookURLs)
+		notifier = webnotifier.New(wsPath, cmdOpts.webhookURLs, cmdOpts.notifierOpts...)
 	}
 
 	// did exchange command operation
@@ -288,6 +326,24 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create outofband command : %w", err)
 	}
 
+	// trust ping command operation
+	trustping, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping command : %w", err)
+	}
+
+	// discover features command operation
+	discoverfeatures, err := discoverfeaturescmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features command : %w", err)
+	}
+
+	// action menu command operation
+	actionmenu, err := actionmenucmd.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu command : %w", err)
+	}
+
 	// kms command operation
 	kmscmd := kms.New(ctx)
 
@@ -311,6 +367,9 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
+	allHandlers = append(allHandlers, trustping.GetHandlers()...)
+	allHandlers = append(allHandlers, discoverfeatures.GetHandlers()...)
+	allHandlers = append(allHandlers, actionmenu.GetHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetHandlers()...)
 	allHandlers = append(allHandlers, contextcmd.GetHandlers()...)
 
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+// publishMenuRequest model
+//
+// This is used for publishing the menu offered over a connection.
+//
+// swagger:parameters publishMenu
+type publishMenuRequest struct { // nolint: unused,deadcode
+	// The connection ID.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// in: body
+	Menu protocol.Menu
+}
+
+// menuRequest model
+//
+// This is used for the menu operations of a connection.
+//
+// swagger:parameters menu theirMenu requestMenu
+type menuRequest struct { // nolint: unused,deadcode
+	// The connection ID.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// menuResponse model
+//
+// This is the menu of a connection.
+//
+// swagger:response menuResponse
+type menuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body actionmenu.MenuResponse
+}
+
+// performRequest model
+//
+// This is used for requesting the agent at the other end of a connection to perform an option of its menu.
+//
+// swagger:parameters perform
+type performRequest struct { // nolint: unused,deadcode
+	// The connection ID.
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// in: body
+	Body struct {
+		// Name of the menu option.
+		//
+		// required: true
+		Name string `json:"name"`
+
+		// Params are the values of the option form.
+		Params map[string]string `json:"params,omitempty"`
+	}
+}
+
+// actionMenuActionsResponse model
+//
+// This is the list of the perform requests waiting to be accepted or declined.
+//
+// swagger:response actionMenuActionsResponse
+type actionMenuActionsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body actionmenu.ActionsResponse
+}
+
+// acceptPerformRequest model
+//
+// This is used for accepting a perform request.
+//
+// swagger:parameters acceptPerform
+type acceptPerformRequest struct { // nolint: unused,deadcode
+	// The perform request ID.
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// Menu published as the new current menu of the connection, if any.
+		Menu *protocol.Menu `json:"menu,omitempty"`
+	}
+}
+
+// declinePerformRequest model
+//
+// This is used for declining a perform request.
+//
+// swagger:parameters declinePerform
+type declinePerformRequest struct { // nolint: unused,deadcode
+	// The perform request ID.
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason sent back as error message of the current menu.
+	//
+	// in: query
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+// constants for the action menu operations.
+const (
+	OperationID    = "/action-menu"
+	MenuPath       = OperationID + "/{id}/menu"
+	TheirMenuPath  = OperationID + "/{id}/their-menu"
+	RequestMenu    = OperationID + "/{id}/request-menu"
+	Perform        = OperationID + "/{id}/perform"
+	Actions        = OperationID + "/actions"
+	AcceptPerform  = OperationID + "/{piid}/accept-perform"
+	DeclinePerform = OperationID + "/{piid}/decline-perform"
+)
+
+// provider contains dependencies for the action menu protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains basic common operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  *actionmenu.Command
+}
+
+// New returns new action menu rest client instance.
+func New(ctx provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := actionmenu.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(MenuPath, http.MethodPost, o.PublishMenu),
+		cmdutil.NewHTTPHandler(MenuPath, http.MethodGet, o.Menu),
+		cmdutil.NewHTTPHandler(TheirMenuPath, http.MethodGet, o.TheirMenu),
+		cmdutil.NewHTTPHandler(RequestMenu, http.MethodPost, o.RequestMenu),
+		cmdutil.NewHTTPHandler(Perform, http.MethodPost, o.Perform),
+		cmdutil.NewHTTPHandler(Actions, http.MethodGet, o.Actions),
+		cmdutil.NewHTTPHandler(AcceptPerform, http.MethodPost, o.AcceptPerform),
+		cmdutil.NewHTTPHandler(DeclinePerform, http.MethodPost, o.DeclinePerform),
+	}
+}
+
+// PublishMenu swagger:route POST /action-menu/{id}/menu action-menu publishMenu
+//
+// Makes the menu the current menu offered over the connection and sends it to the other agent.
+//
+// Responses:
+//    default: genericError
+//    200: menuResponse
+func (o *Operation) PublishMenu(rw http.ResponseWriter, req *http.Request) {
+	request := actionmenu.PublishMenuArgs{}
+
+	if req.ContentLength != 0 {
+		request.Menu = &protocol.Menu{}
+
+		if err := json.NewDecoder(req.Body).Decode(request.Menu); err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, actionmenu.InvalidRequestErrorCode, err)
+
+			return
+		}
+	}
+
+	request.ConnectionID = mux.Vars(req)["id"]
+
+	execute(o.command.PublishMenu, rw, &request)
+}
+
+// Menu swagger:route GET /action-menu/{id}/menu action-menu menu
+//
+// Returns the current menu offered over the connection.
+//
+// Responses:
+//    default: genericError
+//    200: menuResponse
+func (o *Operation) Menu(rw http.ResponseWriter, req *http.Request) {
+	execute(o.command.Menu, rw, &actionmenu.MenuArgs{ConnectionID: mux.Vars(req)["id"]})
+}
+
+// TheirMenu swagger:route GET /action-menu/{id}/their-menu action-menu theirMenu
+//
+// Returns the last menu received over the connection.
+//
+// Responses:
+//    default: genericError
+//    200: menuResponse
+func (o *Operation) TheirMenu(rw http.ResponseWriter, req *http.Request) {
+	execute(o.command.TheirMenu, rw, &actionmenu.MenuArgs{ConnectionID: mux.Vars(req)["id"]})
+}
+
+// RequestMenu swagger:route POST /action-menu/{id}/request-menu action-menu requestMenu
+//
+// Requests the agent at the other end of the connection to send its current menu.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RequestMenu(rw http.ResponseWriter, req *http.Request) {
+	execute(o.command.RequestMenu, rw, &actionmenu.MenuArgs{ConnectionID: mux.Vars(req)["id"]})
+}
+
+// Perform swagger:route POST /action-menu/{id}/perform action-menu perform
+//
+// Requests the agent at the other end of the connection to perform an option of its menu.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Perform(rw http.ResponseWriter, req *http.Request) {
+	request := actionmenu.PerformArgs{}
+
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, actionmenu.InvalidRequestErrorCode, err)
+
+			return
+		}
+	}
+
+	request.ConnectionID = mux.Vars(req)["id"]
+
+	execute(o.command.Perform, rw, &request)
+}
+
+// Actions swagger:route GET /action-menu/actions action-menu actionMenuActions
+//
+// Returns the perform requests waiting to be accepted or declined.
+//
+// Responses:
+//    default: genericError
+//    200: actionMenuActionsResponse
+func (o *Operation) Actions(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(o.command.Actions, rw, nil)
+}
+
+// AcceptPerform swagger:route POST /action-menu/{piid}/accept-perform action-menu acceptPerform
+//
+// Accepts a perform request, the given menu becoming the current menu of the connection.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) AcceptPerform(rw http.ResponseWriter, req *http.Request) {
+	request := actionmenu.AcceptPerformArgs{}
+
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, actionmenu.InvalidRequestErrorCode, err)
+
+			return
+		}
+	}
+
+	request.PIID = mux.Vars(req)["piid"]
+
+	execute(o.command.AcceptPerform, rw, &request)
+}
+
+// DeclinePerform swagger:route POST /action-menu/{piid}/decline-perform action-menu declinePerform
+//
+// Declines a perform request, the reason being sent back as error message of the current menu.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeclinePerform(rw http.ResponseWriter, req *http.Request) {
+	execute(o.command.DeclinePerform, rw, &actionmenu.DeclinePerformArgs{
+		PIID:   mux.Vars(req)["piid"],
+		Reason: req.URL.Query().Get("reason"),
+	})
+}
+
+func execute(exec command.Exec, rw http.ResponseWriter, request interface{}) {
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, actionmenu.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(exec, rw, bytes.NewBuffer(reqBytes))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	mockactionmenu "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/actionmenu"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func newOperation(t *testing.T, svc *mockactionmenu.MockActionMenuSvc) *Operation {
+	t.Helper()
+
+	op, err := New(&mockprovider.Provider{ServiceValue: svc}, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	return op
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op := newOperation(t, &mockactionmenu.MockActionMenuSvc{})
+		require.Len(t, op.GetRESTHandlers(), 8)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")}, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create action menu command")
+	})
+}
+
+func TestOperation_Menus(t *testing.T) {
+	op := newOperation(t, &mockactionmenu.MockActionMenuSvc{
+		MyMenuValue:    &protocol.Menu{Title: "mine"},
+		TheirMenuErr:   protocol.ErrMenuNotFound,
+		RequestMenuErr: errors.New("send error"),
+	})
+
+	t.Run("publish menu", func(t *testing.T) {
+		buf, code := sendRequest(t, op, MenuPath, http.MethodPost, "/action-menu/conn/menu",
+			bytes.NewBufferString(`{"title":"Main","options":[{"name":"a","title":"A"}]}`))
+		require.Equal(t, http.StatusOK, code)
+
+		var resp actionmenu.MenuResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		require.Equal(t, "Main", resp.Menu.Title)
+	})
+
+	t.Run("publish menu - invalid body", func(t *testing.T) {
+		_, code := sendRequest(t, op, MenuPath, http.MethodPost, "/action-menu/conn/menu", bytes.NewBufferString(`{`))
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("publish menu - missing menu", func(t *testing.T) {
+		_, code := sendRequest(t, op, MenuPath, http.MethodPost, "/action-menu/conn/menu", nil)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("menu", func(t *testing.T) {
+		buf, code := sendRequest(t, op, MenuPath, http.MethodGet, "/action-menu/conn/menu", nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, buf.String(), "mine")
+	})
+
+	t.Run("their menu", func(t *testing.T) {
+		buf, code := sendRequest(t, op, TheirMenuPath, http.MethodGet, "/action-menu/conn/their-menu", nil)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), protocol.ErrMenuNotFound.Error())
+	})
+
+	t.Run("request menu", func(t *testing.T) {
+		buf, code := sendRequest(t, op, RequestMenu, http.MethodPost, "/action-menu/conn/request-menu", nil)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), "send error")
+	})
+}
+
+func TestOperation_Perform(t *testing.T) {
+	op := newOperation(t, &mockactionmenu.MockActionMenuSvc{
+		PerformFunc: func(connectionID, name string, params map[string]string) error {
+			require.Equal(t, "conn", connectionID)
+			require.Equal(t, "a", name)
+			require.Equal(t, map[string]string{"x": "1"}, params)
+
+			return nil
+		},
+	})
+
+	t.Run("success", func(t *testing.T) {
+		_, code := sendRequest(t, op, Perform, http.MethodPost, "/action-menu/conn/perform",
+			bytes.NewBufferString(`{"name":"a","params":{"x":"1"}}`))
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, code := sendRequest(t, op, Perform, http.MethodPost, "/action-menu/conn/perform", bytes.NewBufferString(`{`))
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		buf, code := sendRequest(t, op, Perform, http.MethodPost, "/action-menu/conn/perform", nil)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Contains(t, buf.String(), "name is mandatory")
+	})
+}
+
+func TestOperation_Actions(t *testing.T) {
+	op := newOperation(t, &mockactionmenu.MockActionMenuSvc{
+		ActionsValue:      []protocol.Action{{PIID: "piid", ConnectionID: "conn"}},
+		ActionContinueErr: errors.New("continue error"),
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		buf, code := sendRequest(t, op, Actions, http.MethodGet, Actions, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var resp actionmenu.ActionsResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		require.Len(t, resp.Actions, 1)
+	})
+
+	t.Run("accept perform", func(t *testing.T) {
+		buf, code := sendRequest(t, op, AcceptPerform, http.MethodPost, "/action-menu/piid/accept-perform",
+			bytes.NewBufferString(`{"menu":{"title":"Next"}}`))
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), "continue error")
+	})
+
+	t.Run("accept perform - invalid body", func(t *testing.T) {
+		_, code := sendRequest(t, op, AcceptPerform, http.MethodPost, "/action-menu/piid/accept-perform",
+			bytes.NewBufferString(`{`))
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("decline perform", func(t *testing.T) {
+		_, code := sendRequest(t, op, DeclinePerform, http.MethodPost,
+			"/action-menu/piid/decline-perform?reason=not+now", nil)
+		require.Equal(t, http.StatusOK, code)
+	})
+}
+
+func handlerLookup(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequest sends the request to the handler registered for the given path and method, returning its response.
+func sendRequest(t *testing.T, op *Operation, path, method, url string, body io.Reader) (*bytes.Buffer, int) {
+	t.Helper()
+
+	handler := handlerLookup(t, op, path, method)
+
+	if body == nil {
+		body = http.NoBody
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
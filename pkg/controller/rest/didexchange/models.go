@@ -222,6 +222,12 @@ type RemoveConnectionRequest struct {
 	// in: path
 	// required: true
 	ID string `json:"id"`
+
+	// Removes the DID exchange event data and thread ID mapping and the stored credentials and presentations of the
+	// connection too, the DID key mappings and the state of the other protocols run over the connection being kept
+	//
+	// in: query
+	RemoveStoredData bool `json:"removeStoredData"`
 }
 
 // RemoveConnectionResponse model
@@ -260,3 +266,64 @@ type createConnectionRequest struct { // nolint: unused,deadcode
 	// required: true
 	Request didexchange.CreateConnectionRequest
 }
+
+// updateConnectionMetadataRequest model
+//
+// This is used for updating the metadata of a connection
+//
+// swagger:parameters updateConnectionMetadata
+type updateConnectionMetadataRequest struct { // nolint: unused,deadcode
+	// The ID of the connection
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Metadata merged into the connection metadata, the keys with an empty value being removed
+	//
+	// in: body
+	// required: true
+	Metadata map[string]string
+}
+
+// setConnectionTagsRequest model
+//
+// This is used for replacing the tags of a connection
+//
+// swagger:parameters setConnectionTags
+type setConnectionTagsRequest struct { // nolint: unused,deadcode
+	// The ID of the connection
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Tags of the connection
+	//
+	// in: body
+	// required: true
+	Tags []string
+}
+
+// connectionStatusRequest model
+//
+// This is used for changing the lifecycle status of a connection
+//
+// swagger:parameters activateConnection markConnectionInactive archiveConnection
+type connectionStatusRequest struct { // nolint: unused,deadcode
+	// The ID of the connection
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// updateConnectionResponse model
+//
+// response of the connection metadata, tags and status updates
+//
+// swagger:response updateConnectionResponse
+type updateConnectionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -35,6 +36,15 @@ const (
 	AcceptExchangeRequest        = OperationID + "/{id}/accept-request"
 	CreateConnection             = OperationID + "/create"
 	RemoveConnection             = OperationID + "/{id}/remove"
+	ConnectionMetadata           = OperationID + "/{id}/metadata"
+	ConnectionTags               = OperationID + "/{id}/tags"
+	ActivateConnection           = OperationID + "/{id}/activate"
+	MarkConnectionInactive       = OperationID + "/{id}/mark-inactive"
+	ArchiveConnection            = OperationID + "/{id}/archive"
+
+	// metadataQueryPrefix prefixes the metadata keys in the query connections parameters.
+	metadataQueryPrefix = "metadata."
+	tagsQueryParam      = "tags"
 )
 
 // provider contains dependencies for the Exchange protocol and is typically created by using aries.Context().
@@ -85,6 +95,11 @@ func (c *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(AcceptExchangeRequest, http.MethodPost, c.AcceptExchangeRequest),
 		cmdutil.NewHTTPHandler(CreateConnection, http.MethodPost, c.CreateConnection),
 		cmdutil.NewHTTPHandler(RemoveConnection, http.MethodPost, c.RemoveConnection),
+		cmdutil.NewHTTPHandler(ConnectionMetadata, http.MethodPost, c.UpdateConnectionMetadata),
+		cmdutil.NewHTTPHandler(ConnectionTags, http.MethodPost, c.SetConnectionTags),
+		cmdutil.NewHTTPHandler(ActivateConnection, http.MethodPost, c.ActivateConnection),
+		cmdutil.NewHTTPHandler(MarkConnectionInactive, http.MethodPost, c.MarkConnectionInactive),
+		cmdutil.NewHTTPHandler(ArchiveConnection, http.MethodPost, c.ArchiveConnection),
 	}
 }
 
@@ -174,12 +189,14 @@ func (c *Operation) AcceptExchangeRequest(rw http.ResponseWriter, req *http.Requ
 // QueryConnections swagger:route GET /connections did-exchange queryConnections
 //
 // query agent to agent connections.
+// The connections are queried by metadata with metadata.<key>=<value> parameters, and by tags with repeated tags
+// parameters.
 //
 // Responses:
 //    default: genericError
 //        200: queryConnectionsResponse
 func (c *Operation) QueryConnections(rw http.ResponseWriter, req *http.Request) {
-	reqBytes, err := queryValuesAsJSON(req.URL.Query())
+	reqBytes, err := queryConnectionsAsJSON(req.URL.Query())
 	if err != nil {
 		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
 		return
@@ -230,9 +247,137 @@ func (c *Operation) RemoveConnection(rw http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	request := fmt.Sprintf(`{"id":"%s"}`, id)
+	request, err := json.Marshal(&didexchange.RemoveConnectionRequest{
+		ID:               id,
+		RemoveStoredData: req.URL.Query().Get("removeStoredData") == "true",
+	})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(c.command.RemoveConnection, rw, bytes.NewReader(request))
+}
+
+// UpdateConnectionMetadata swagger:route POST /connections/{id}/metadata did-exchange updateConnectionMetadata
+//
+// Merges the given metadata into the connection metadata, the keys with an empty value being removed.
+//
+// Responses:
+//    default: genericError
+//    200: updateConnectionResponse
+func (c *Operation) UpdateConnectionMetadata(rw http.ResponseWriter, req *http.Request) {
+	id, found := getIDFromRequest(rw, req)
+	if !found {
+		return
+	}
+
+	request := didexchange.UpdateConnectionMetadataArgs{ID: id}
 
-	rest.Execute(c.command.RemoveConnection, rw, bytes.NewBufferString(request))
+	if err := json.NewDecoder(req.Body).Decode(&request.Metadata); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	c.executeWithID(c.command.UpdateConnectionMetadata, rw, &request)
+}
+
+// SetConnectionTags swagger:route POST /connections/{id}/tags did-exchange setConnectionTags
+//
+// Replaces the tags of the connection.
+//
+// Responses:
+//    default: genericError
+//    200: updateConnectionResponse
+func (c *Operation) SetConnectionTags(rw http.ResponseWriter, req *http.Request) {
+	id, found := getIDFromRequest(rw, req)
+	if !found {
+		return
+	}
+
+	request := didexchange.SetConnectionTagsArgs{ID: id}
+
+	if err := json.NewDecoder(req.Body).Decode(&request.Tags); err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	c.executeWithID(c.command.SetConnectionTags, rw, &request)
+}
+
+// ActivateConnection swagger:route POST /connections/{id}/activate did-exchange activateConnection
+//
+// Sets the lifecycle status of the connection back to active.
+//
+// Responses:
+//    default: genericError
+//    200: updateConnectionResponse
+func (c *Operation) ActivateConnection(rw http.ResponseWriter, req *http.Request) {
+	if id, found := getIDFromRequest(rw, req); found {
+		c.executeWithID(c.command.ActivateConnection, rw, &didexchange.ConnectionIDArg{ID: id})
+	}
+}
+
+// MarkConnectionInactive swagger:route POST /connections/{id}/mark-inactive did-exchange markConnectionInactive
+//
+// Sets the lifecycle status of the connection to inactive.
+//
+// Responses:
+//    default: genericError
+//    200: updateConnectionResponse
+func (c *Operation) MarkConnectionInactive(rw http.ResponseWriter, req *http.Request) {
+	if id, found := getIDFromRequest(rw, req); found {
+		c.executeWithID(c.command.MarkConnectionInactive, rw, &didexchange.ConnectionIDArg{ID: id})
+	}
+}
+
+// ArchiveConnection swagger:route POST /connections/{id}/archive did-exchange archiveConnection
+//
+// Sets the lifecycle status of the connection to archived.
+//
+// Responses:
+//    default: genericError
+//    200: updateConnectionResponse
+func (c *Operation) ArchiveConnection(rw http.ResponseWriter, req *http.Request) {
+	if id, found := getIDFromRequest(rw, req); found {
+		c.executeWithID(c.command.ArchiveConnection, rw, &didexchange.ConnectionIDArg{ID: id})
+	}
+}
+
+// executeWithID executes the command with the request built from the connection ID of the path.
+func (c *Operation) executeWithID(exec command.Exec, rw http.ResponseWriter, request interface{}) {
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(exec, rw, bytes.NewReader(reqBytes))
+}
+
+// queryConnectionsAsJSON converts the query connections parameters to JSON bytes, the metadata.<key> and the
+// repeated tags parameters being gathered into the metadata and tags criteria.
+func queryConnectionsAsJSON(vals url.Values) ([]byte, error) {
+	args := make(map[string]interface{})
+	metadata := make(map[string]string)
+
+	for k, v := range vals {
+		switch {
+		case len(v) == 0:
+		case k == tagsQueryParam:
+			args[k] = v
+		case strings.HasPrefix(k, metadataQueryPrefix):
+			metadata[strings.TrimPrefix(k, metadataQueryPrefix)] = v[0]
+		default:
+			args[k] = v[0]
+		}
+	}
+
+	if len(metadata) > 0 {
+		args["metadata"] = metadata
+	}
+
+	return json.Marshal(args)
 }
 
 // queryValuesAsJSON converts query strings to `map[string]string`
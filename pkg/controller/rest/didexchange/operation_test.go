@@ -403,6 +403,96 @@ func TestOperation_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestOperation_ConnectionLifecycle(t *testing.T) {
+	prov := &mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		StorageProviderValue:              mem.NewProvider(),
+		ServiceMap: map[string]interface{}{
+			didexsvc.DIDExchange:  &mockdidexchange.MockDIDExchangeSvc{},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "1234",
+		State:        connection.StateNameCompleted,
+		ThreadID:     "th1234",
+		Namespace:    connection.MyNSPrefix,
+	}))
+
+	op, err := New(prov, webnotifier.NewHTTPNotifier(nil), "", false)
+	require.NoError(t, err)
+
+	queryConnections := func(t *testing.T, query string) []json.RawMessage {
+		t.Helper()
+
+		buf, err := getSuccessResponseFromHandler(handlerLookup(t, op, Connections), nil, OperationID+query)
+		require.NoError(t, err)
+
+		var response struct {
+			Results []json.RawMessage `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+
+		return response.Results
+	}
+
+	t.Run("update metadata, tags and status", func(t *testing.T) {
+		_, err := getSuccessResponseFromHandler(handlerLookup(t, op, ConnectionMetadata),
+			bytes.NewBufferString(`{"crm_id":"crm:42"}`), OperationID+"/1234/metadata")
+		require.NoError(t, err)
+
+		_, err = getSuccessResponseFromHandler(handlerLookup(t, op, ConnectionTags),
+			bytes.NewBufferString(`["vip","partner"]`), OperationID+"/1234/tags")
+		require.NoError(t, err)
+
+		_, err = getSuccessResponseFromHandler(handlerLookup(t, op, MarkConnectionInactive), nil,
+			OperationID+"/1234/mark-inactive")
+		require.NoError(t, err)
+
+		require.Len(t, queryConnections(t, "?metadata.crm_id=crm:42&tags=vip&tags=partner&status=inactive"), 1)
+		require.Empty(t, queryConnections(t, "?tags=vip&tags=unknown"))
+		require.Empty(t, queryConnections(t, "?metadata.crm_id=crm:43"))
+
+		_, err = getSuccessResponseFromHandler(handlerLookup(t, op, ArchiveConnection), nil,
+			OperationID+"/1234/archive")
+		require.NoError(t, err)
+		require.Len(t, queryConnections(t, "?status=archived"), 1)
+
+		_, err = getSuccessResponseFromHandler(handlerLookup(t, op, ActivateConnection), nil,
+			OperationID+"/1234/activate")
+		require.NoError(t, err)
+		require.Len(t, queryConnections(t, "?status=active"), 1)
+
+		_, err = getSuccessResponseFromHandler(handlerLookup(t, op, RemoveConnection), nil,
+			OperationID+"/1234/remove?removeStoredData=true")
+		require.NoError(t, err)
+		require.Empty(t, queryConnections(t, ""))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handlerLookup(t, op, ConnectionMetadata),
+			bytes.NewBufferString(`["not", "metadata"]`), OperationID+"/1234/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handlerLookup(t, op, ConnectionTags),
+			bytes.NewBufferString(`{}`), OperationID+"/1234/tags")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handlerLookup(t, op, ArchiveConnection), nil,
+			OperationID+"/unknown/archive")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyRESTError(t, didexchange.UpdateConnectionErrorCode, buf.Bytes())
+	})
+}
+
 func TestGetIDFromRequest(t *testing.T) {
 	id, found := getIDFromRequest(httptest.NewRecorder(), &http.Request{})
 	require.False(t, found)
@@ -429,6 +519,8 @@ func TestEmptyID(t *testing.T) {
 
 	restHandlers := []http.HandlerFunc{
 		op.AcceptInvitation, op.AcceptExchangeRequest, op.QueryConnectionByID, op.RemoveConnection,
+		op.UpdateConnectionMetadata, op.SetConnectionTags, op.ActivateConnection, op.MarkConnectionInactive,
+		op.ArchiveConnection,
 	}
 	for _, handler := range restHandlers {
 		rw := httptest.NewRecorder()
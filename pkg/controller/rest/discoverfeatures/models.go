@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+
+// queryFeaturesRequest model
+//
+// This is used for querying the features of the agent at the other end of a connection.
+//
+// swagger:parameters queryFeaturesRequest
+type queryFeaturesRequest struct { // nolint: unused,deadcode
+	// in: body
+	Params discoverfeatures.QueryArgs
+}
+
+// featuresRequest model
+//
+// This is used for listing the features of this agent.
+//
+// swagger:parameters featuresRequest
+type featuresRequest struct { // nolint: unused,deadcode
+	// Protocol identifiers to list, a `*` standing for any sequence of characters
+	//
+	// in: query
+	Match []string `json:"match"`
+}
+
+// disclosuresResponse model
+//
+// This is the list of the disclosed features.
+//
+// swagger:response disclosuresResponse
+type disclosuresResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body discoverfeatures.DisclosuresResponse
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the discover features operations.
+const (
+	OperationID  = "/discover-features"
+	QueryPath    = OperationID + "/query"
+	FeaturesPath = OperationID + "/features"
+)
+
+// provider contains dependencies for the discover features protocol and is typically created by using
+// aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains basic common operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  *discoverfeatures.Command
+}
+
+// New returns new discover features rest client instance.
+func New(ctx provider) (*Operation, error) {
+	cmd, err := discoverfeatures.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(QueryPath, http.MethodPost, o.Query),
+		cmdutil.NewHTTPHandler(FeaturesPath, http.MethodGet, o.Features),
+	}
+}
+
+// Query swagger:route POST /discover-features/query discover-features queryFeaturesRequest
+//
+// Queries the protocols supported by the agent at the other end of the connection.
+//
+// Responses:
+//    default: genericError
+//    200: disclosuresResponse
+func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Query, rw, req.Body)
+}
+
+// Features swagger:route GET /discover-features/features discover-features featuresRequest
+//
+// Lists the protocols supported by this agent, filtered by the match query parameters.
+//
+// Responses:
+//    default: genericError
+//    200: disclosuresResponse
+func (o *Operation) Features(rw http.ResponseWriter, req *http.Request) {
+	args := discoverfeatures.FeaturesArgs{Matches: req.URL.Query()["match"]}
+
+	request, err := json.Marshal(args)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, discoverfeatures.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.Features, rw, bytes.NewBuffer(request))
+}
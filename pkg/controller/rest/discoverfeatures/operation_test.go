@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscover "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+var disclosures = []protocol.Disclosure{
+	{FeatureType: protocol.FeatureTypeProtocol, ID: "https://didcomm.org/present-proof/2.0"},
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 2)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create discover features command")
+	})
+}
+
+func TestOperation_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+			Disclosures: disclosures,
+		}})
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handlerLookup(t, op, QueryPath),
+			bytes.NewBufferString(`{"connectionID":"conn","matches":["*"]}`), QueryPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var resp discoverfeatures.DisclosuresResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		require.Equal(t, disclosures, resp.Disclosures)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+			QueryErr: protocol.ErrNoDisclosure,
+		}})
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(handlerLookup(t, op, QueryPath),
+			bytes.NewBufferString(`{"connectionID":"conn","matches":["*"]}`), QueryPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+}
+
+func TestOperation_Features(t *testing.T) {
+	op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscover.MockDiscoverFeaturesSvc{
+		Disclosures: disclosures,
+	}})
+	require.NoError(t, err)
+
+	buf, code, err := sendRequestToHandler(handlerLookup(t, op, FeaturesPath), nil,
+		FeaturesPath+"?match=https://didcomm.org/present-proof/*&match=*")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp discoverfeatures.DisclosuresResponse
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+	require.Equal(t, disclosures, resp.Disclosures)
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
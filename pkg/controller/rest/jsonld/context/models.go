@@ -17,3 +17,43 @@ type addContextReq struct { //nolint: unused,deadcode
 	// in: body
 	Documents []jsonld.ContextDocument `json:"documents"`
 }
+
+// listContextsResp model
+//
+// This is used as the response model for listing JSON-LD contexts.
+//
+// swagger:response listContextsResp
+type listContextsResp struct { // nolint: unused,deadcode
+	// in: body
+	Contexts []jsonld.StoredContext `json:"contexts"`
+}
+
+// refreshContextReq model
+//
+// This is used for loading a JSON-LD context from its remote URL again.
+//
+// swagger:parameters refreshContextReq
+type refreshContextReq struct { //nolint: unused,deadcode
+	// in: body
+	Params struct {
+		// Context URL
+		//
+		// required: true
+		URL string `json:"url"`
+	}
+}
+
+// removeContextReq model
+//
+// This is used for removing a JSON-LD context.
+//
+// swagger:parameters removeContextReq
+type removeContextReq struct { //nolint: unused,deadcode
+	// in: body
+	Params struct {
+		// Context URL
+		//
+		// required: true
+		URL string `json:"url"`
+	}
+}
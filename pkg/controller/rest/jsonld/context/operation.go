@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/piprate/json-gold/ld"
+
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/jsonld/context"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
@@ -18,12 +20,16 @@ import (
 
 // constants for the JSON-LD context operations.
 const (
-	OperationID    = "/context"
-	AddContextPath = OperationID + "/add"
+	OperationID        = "/context"
+	AddContextPath     = OperationID + "/add"
+	ListContextsPath   = OperationID
+	RefreshContextPath = OperationID + "/refresh"
+	RemoveContextPath  = OperationID + "/remove"
 )
 
 type provider interface {
 	StorageProvider() storage.Provider
+	JSONLDDocumentLoader() ld.DocumentLoader
 }
 
 // Operation contains REST operations provided by JSON-LD context API.
@@ -48,6 +54,9 @@ func New(p provider) (*Operation, error) {
 func (o *Operation) registerHandlers() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(AddContextPath, http.MethodPost, o.Add),
+		cmdutil.NewHTTPHandler(ListContextsPath, http.MethodGet, o.List),
+		cmdutil.NewHTTPHandler(RefreshContextPath, http.MethodPost, o.Refresh),
+		cmdutil.NewHTTPHandler(RemoveContextPath, http.MethodPost, o.Remove),
 	}
 }
 
@@ -65,3 +74,34 @@ func (o *Operation) GetRESTHandlers() []rest.Handler {
 func (o *Operation) Add(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Add, rw, req.Body)
 }
+
+// List swagger:route GET /context context listContexts
+//
+// Lists the JSON-LD context documents of the underlying storage.
+//
+// Responses:
+//    default: genericError
+//        200: listContextsResp
+func (o *Operation) List(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.List, rw, req.Body)
+}
+
+// Refresh swagger:route POST /context/refresh context refreshContextReq
+//
+// Loads a JSON-LD context document from its remote URL again.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Refresh(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Refresh, rw, req.Body)
+}
+
+// Remove swagger:route POST /context/remove context removeContextReq
+//
+// Removes a JSON-LD context document from the underlying storage.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Remove(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Remove, rw, req.Body)
+}
@@ -35,7 +35,7 @@ func TestNew(t *testing.T) {
 
 		require.NotNil(t, op)
 		require.NoError(t, err)
-		require.Equal(t, 1, len(op.GetRESTHandlers()))
+		require.Equal(t, 4, len(op.GetRESTHandlers()))
 	})
 
 	t.Run("Fail to create jsonld context command", func(t *testing.T) {
@@ -69,6 +69,58 @@ func TestOperation_Add(t *testing.T) {
 	require.Equal(t, http.StatusOK, code)
 }
 
+func TestOperation_List(t *testing.T) {
+	op, err := jsonldcontextrest.New(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, op, jsonldcontextrest.ListContextsPath, http.MethodGet)
+	_, code := sendRequestToHandler(t, handler, nil, jsonldcontextrest.ListContextsPath)
+
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestOperation_Refresh(t *testing.T) {
+	op, err := jsonldcontextrest.New(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	reqBytes, err := json.Marshal(jsonldcontextcmd.RefreshRequest{URL: "https://example.com/context.jsonld"})
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, op, jsonldcontextrest.RefreshContextPath, http.MethodPost)
+	_, code := sendRequestToHandler(t, handler, bytes.NewBuffer(reqBytes), jsonldcontextrest.RefreshContextPath)
+
+	// the mock provider has no JSON-LD document loader able to refresh contexts
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestOperation_Remove(t *testing.T) {
+	op, err := jsonldcontextrest.New(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	contexts := jsonldtest.Contexts()
+
+	reqBytes, err := json.Marshal(jsonldcontextcmd.AddRequest{Documents: contexts})
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, op, jsonldcontextrest.AddContextPath, http.MethodPost)
+	_, code := sendRequestToHandler(t, handler, bytes.NewBuffer(reqBytes), jsonldcontextrest.AddContextPath)
+	require.Equal(t, http.StatusOK, code)
+
+	reqBytes, err = json.Marshal(jsonldcontextcmd.RemoveRequest{URL: contexts[0].URL})
+	require.NoError(t, err)
+
+	handler = lookupHandler(t, op, jsonldcontextrest.RemoveContextPath, http.MethodPost)
+	_, code = sendRequestToHandler(t, handler, bytes.NewBuffer(reqBytes), jsonldcontextrest.RemoveContextPath)
+
+	require.Equal(t, http.StatusOK, code)
+}
+
 func lookupHandler(t *testing.T, op *jsonldcontextrest.Operation, path, method string) rest.Handler {
 	t.Helper()
 
@@ -92,3 +92,99 @@ type sendMessageResponse struct { // nolint: unused,deadcode
 	// in: body
 	Response json.RawMessage `json:"response,omitempty"`
 }
+
+// sendBasicMessageRequest model
+//
+// This is used for sending a basic message over a connection
+//
+// swagger:parameters sendBasicMessage
+type sendBasicMessageRequest struct { // nolint: unused,deadcode
+	// Params for sending a basic message
+	//
+	// in: body
+	Params messaging.SendBasicMessageArgs
+}
+
+// basicMessageResponse model
+//
+// Response of send basic message feature, containing the message sent.
+//
+// swagger:response basicMessageResponse
+type basicMessageResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.BasicMessageResponse
+}
+
+// basicMessageHistoryRequest model
+//
+// This is used for querying the basic messages exchanged over a connection
+//
+// swagger:parameters basicMessageHistory
+type basicMessageHistoryRequest struct { // nolint: unused,deadcode
+	// The connection ID
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Number of messages skipped
+	//
+	// in: query
+	Offset int `json:"offset"`
+
+	// Maximum number of messages returned
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// Orders the messages from the newest one
+	//
+	// in: query
+	NewestFirst bool `json:"newest_first"`
+
+	// Returns the unread received messages only
+	//
+	// in: query
+	UnreadOnly bool `json:"unread_only"`
+}
+
+// basicMessageHistoryResponse model
+//
+// Response of basic message history feature.
+//
+// swagger:response basicMessageHistoryResponse
+type basicMessageHistoryResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.BasicMessageHistoryResponse
+}
+
+// markBasicMessagesReadRequest model
+//
+// This is used for marking received basic messages as read
+//
+// swagger:parameters markBasicMessagesRead
+type markBasicMessagesReadRequest struct { // nolint: unused,deadcode
+	// The connection ID
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Params for marking basic messages as read
+	//
+	// in: body
+	Params struct {
+		// IDs of the messages marked as read, all the unread messages being marked when not provided
+		MessageIDs []string `json:"message_IDs"`
+	}
+}
+
+// markBasicMessagesReadResponse model
+//
+// Response of mark basic messages read feature.
+//
+// swagger:response markBasicMessagesReadResponse
+type markBasicMessagesReadResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.MarkBasicMessagesReadResponse
+}
@@ -7,8 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package messaging
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
@@ -33,6 +38,12 @@ const (
 	MsgServiceList        = MsgServiceOperationID + "/services"
 	SendNewMsg            = MsgServiceOperationID + "/send"
 	SendReplyMsg          = MsgServiceOperationID + "/reply"
+
+	// basic message endpoints.
+	BasicMsgOperationID = MsgServiceOperationID + "/basic"
+	SendBasicMsg        = BasicMsgOperationID + "/send"
+	BasicMsgHistory     = BasicMsgOperationID + "/{id}/history"
+	MarkBasicMsgsRead   = BasicMsgOperationID + "/{id}/read"
 )
 
 // provider contains dependencies for the common controller operations
@@ -79,6 +90,9 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(SendNewMsg, http.MethodPost, o.Send),
 		cmdutil.NewHTTPHandler(SendReplyMsg, http.MethodPost, o.Reply),
 		cmdutil.NewHTTPHandler(RegisterHTTPOverDIDCommService, http.MethodPost, o.RegisterHTTPService),
+		cmdutil.NewHTTPHandler(SendBasicMsg, http.MethodPost, o.SendBasicMessage),
+		cmdutil.NewHTTPHandler(BasicMsgHistory, http.MethodGet, o.BasicMessageHistory),
+		cmdutil.NewHTTPHandler(MarkBasicMsgsRead, http.MethodPost, o.MarkBasicMessagesRead),
 	}
 }
 
@@ -144,3 +158,88 @@ func (o *Operation) Reply(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) RegisterHTTPService(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.RegisterHTTPService, rw, req.Body)
 }
+
+// SendBasicMessage swagger:route POST /message/basic/send message sendBasicMessage
+//
+// sends a basic message over the connection, the sent message being kept in its history
+//
+// Responses:
+//    default: genericError
+//    200: basicMessageResponse
+func (o *Operation) SendBasicMessage(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SendBasicMessage, rw, req.Body)
+}
+
+// BasicMessageHistory swagger:route GET /message/basic/{id}/history message basicMessageHistory
+//
+// returns the basic messages sent and received over the connection
+//
+// Responses:
+//    default: genericError
+//    200: basicMessageHistoryResponse
+func (o *Operation) BasicMessageHistory(rw http.ResponseWriter, req *http.Request) {
+	request := messaging.BasicMessageHistoryArgs{ConnectionID: mux.Vars(req)["id"]}
+	query := req.URL.Query()
+
+	var err error
+
+	if v := query.Get("offset"); v != "" {
+		request.Offset, err = strconv.Atoi(v)
+	}
+
+	if v := query.Get("limit"); v != "" && err == nil {
+		request.Limit, err = strconv.Atoi(v)
+	}
+
+	if v := query.Get("newest_first"); v != "" && err == nil {
+		request.NewestFirst, err = strconv.ParseBool(v)
+	}
+
+	if v := query.Get("unread_only"); v != "" && err == nil {
+		request.UnreadOnly, err = strconv.ParseBool(v)
+	}
+
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, messaging.InvalidRequestErrorCode,
+			fmt.Errorf("invalid query parameter: %w", err))
+
+		return
+	}
+
+	execute(o.command.BasicMessageHistory, rw, &request)
+}
+
+// MarkBasicMessagesRead swagger:route POST /message/basic/{id}/read message markBasicMessagesRead
+//
+// marks received basic messages of the connection as read, all its unread messages being marked when no message ID
+// is given
+//
+// Responses:
+//    default: genericError
+//    200: markBasicMessagesReadResponse
+func (o *Operation) MarkBasicMessagesRead(rw http.ResponseWriter, req *http.Request) {
+	request := messaging.MarkBasicMessagesReadArgs{}
+
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, messaging.InvalidRequestErrorCode, err)
+
+			return
+		}
+	}
+
+	request.ConnectionID = mux.Vars(req)["id"]
+
+	execute(o.command.MarkBasicMessagesRead, rw, &request)
+}
+
+func execute(exec command.Exec, rw http.ResponseWriter, request interface{}) {
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, messaging.InvalidRequestErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(exec, rw, bytes.NewBuffer(reqBytes))
+}
@@ -24,10 +24,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	svchttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockbasicmessage "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mocksvc "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -718,6 +720,98 @@ func TestOperation_Reply(t *testing.T) {
 	})
 }
 
+func TestOperation_BasicMessage(t *testing.T) {
+	newOperation := func(t *testing.T, svc *mockbasicmessage.MockBasicMessageSvc) *Operation {
+		t.Helper()
+
+		op, err := New(&protocol.MockProvider{
+			ServiceMap: map[string]interface{}{basicmessage.BasicMessage: svc},
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		return op
+	}
+
+	t.Run("send basic message", func(t *testing.T) {
+		op := newOperation(t, &mockbasicmessage.MockBasicMessageSvc{})
+		handler := lookupCreatePublicDIDHandler(t, op, SendBasicMsg)
+
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(`{"connection_ID":"conn","content":"hello"}`), SendBasicMsg)
+		require.NoError(t, err)
+
+		response := messaging.BasicMessageResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, "conn", response.Message.ConnectionID)
+		require.Equal(t, "hello", response.Message.Content)
+	})
+
+	t.Run("basic message history", func(t *testing.T) {
+		op := newOperation(t, &mockbasicmessage.MockBasicMessageSvc{
+			HistoryFunc: func(connID string, opts ...basicmessage.HistoryOpt) (*basicmessage.History, error) {
+				require.Equal(t, "conn", connID)
+				require.Len(t, opts, 3)
+
+				return &basicmessage.History{Messages: []*basicmessage.Record{{ID: "msg-1"}}, Total: 3}, nil
+			},
+		})
+		handler := lookupCreatePublicDIDHandler(t, op, BasicMsgHistory)
+
+		buf, err := getSuccessResponseFromHandler(handler, nil,
+			BasicMsgOperationID+"/conn/history?offset=1&limit=1&newest_first=true&unread_only=true")
+		require.NoError(t, err)
+
+		response := messaging.BasicMessageHistoryResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, 3, response.Total)
+		require.Equal(t, "msg-1", response.Messages[0].ID)
+	})
+
+	t.Run("basic message history - invalid query parameter", func(t *testing.T) {
+		op := newOperation(t, &mockbasicmessage.MockBasicMessageSvc{})
+		handler := lookupCreatePublicDIDHandler(t, op, BasicMsgHistory)
+
+		buf, code, err := sendRequestToHandler(handler, nil, BasicMsgOperationID+"/conn/history?limit=ten")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, messaging.InvalidRequestErrorCode, "invalid query parameter", buf.Bytes())
+
+		buf, code, err = sendRequestToHandler(handler, nil, BasicMsgOperationID+"/conn/history?offset=-1")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, messaging.InvalidRequestErrorCode, "offset and limit must not be negative", buf.Bytes())
+	})
+
+	t.Run("mark basic messages read", func(t *testing.T) {
+		op := newOperation(t, &mockbasicmessage.MockBasicMessageSvc{
+			MarkReadFunc: func(connID string, msgIDs ...string) (int, error) {
+				require.Equal(t, "conn", connID)
+
+				return len(msgIDs) + 1, nil
+			},
+		})
+		handler := lookupCreatePublicDIDHandler(t, op, MarkBasicMsgsRead)
+
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"message_IDs":["msg-1"]}`),
+			BasicMsgOperationID+"/conn/read")
+		require.NoError(t, err)
+
+		response := messaging.MarkBasicMessagesReadResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, 2, response.Marked)
+
+		buf, err = getSuccessResponseFromHandler(handler, nil, BasicMsgOperationID+"/conn/read")
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, 1, response.Marked)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{`), BasicMsgOperationID+"/conn/read")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, messaging.InvalidRequestErrorCode, "", buf.Bytes())
+	})
+}
+
 func lookupCreatePublicDIDHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	t.Helper()
 
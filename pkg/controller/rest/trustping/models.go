@@ -0,0 +1,29 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+
+// pingRequest model
+//
+// This is used for sending a trust ping.
+//
+// swagger:parameters pingRequest
+type pingRequest struct { // nolint: unused,deadcode
+	// in: body
+	Params trustping.PingArgs
+}
+
+// pingResponse model
+//
+// This is the outcome of a trust ping.
+//
+// swagger:response pingResponse
+type pingResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body trustping.PingResponse
+}
@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 1)
+	})
+
+	t.Run("command error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping command")
+	})
+}
+
+func TestOperation_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(string, ...protocol.PingOpt) (*protocol.Result, error) {
+				return &protocol.Result{PingID: "ping-id", Responded: true, Latency: time.Second}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(op.GetRESTHandlers()[0], bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var resp trustping.PingResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		require.Equal(t, trustping.PingResponse{PingID: "ping-id", Responded: true, Latency: "1s"}, resp)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: protocol.ErrNoResponse,
+		}})
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(op.GetRESTHandlers()[0], bytes.NewBufferString(`{"connectionID":"conn"}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+
+		_, code, err = sendRequestToHandler(op.GetRESTHandlers()[0], bytes.NewBufferString(`{}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader) (*bytes.Buffer, int, error) {
+	req, err := http.NewRequest(handler.Method(), handler.Path(), requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
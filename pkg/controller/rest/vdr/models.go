@@ -64,6 +64,30 @@ type resolveDIDReq struct { // nolint: unused,deadcode
 	ID string `json:"id"`
 }
 
+// dereferenceDIDReq model
+//
+// This is used to dereference a did url.
+//
+// swagger:parameters dereferenceDIDReq
+type dereferenceDIDReq struct { // nolint: unused,deadcode
+	// DID URL - pass the base64 encoded did url
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// dereferenceDIDResponse model
+//
+// This is used for returning DID URL dereferencing result.
+//
+// swagger:response dereferenceDIDResponse
+type dereferenceDIDResponse struct { // nolint: unused,deadcode
+
+	// in: body
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
 // documentRes model
 //
 // This is used for returning query connection result for single record search
@@ -9,11 +9,14 @@ package vdr
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
@@ -23,15 +26,25 @@ import (
 
 // constants for the VDR operations.
 const (
-	VDROperationID    = "/vdr"
-	vdrDIDPath        = VDROperationID + "/did"
-	SaveDIDPath       = vdrDIDPath
-	GetDIDPath        = vdrDIDPath + "/{id}"
-	ResolveDIDPath    = vdrDIDPath + "/resolve/{id}"
-	CreateDIDPath     = vdrDIDPath + "/create"
-	GetDIDRecordsPath = vdrDIDPath + "/records"
+	VDROperationID     = "/vdr"
+	vdrDIDPath         = VDROperationID + "/did"
+	SaveDIDPath        = vdrDIDPath
+	GetDIDPath         = vdrDIDPath + "/{id}"
+	ResolveDIDPath     = vdrDIDPath + "/resolve/{id}"
+	CreateDIDPath      = vdrDIDPath + "/create"
+	GetDIDRecordsPath  = vdrDIDPath + "/records"
+	DereferenceDIDPath = vdrDIDPath + "/dereference/{id}"
 )
 
+// resolutionHTTPStatus maps did resolution error codes to the HTTP status codes defined by the
+// DID resolution HTTP(S) binding (https://w3c-ccg.github.io/did-resolution/#bindings-https).
+var resolutionHTTPStatus = map[command.Code]int{ //nolint:gochecknoglobals
+	vdr.DIDNotFoundErrorCode:           http.StatusNotFound,
+	vdr.InvalidDIDErrorCode:            http.StatusBadRequest,
+	vdr.DIDMethodNotSupportedErrorCode: http.StatusNotImplemented,
+	vdr.DIDDeactivatedErrorCode:        http.StatusGone,
+}
+
 // provider contains dependencies for the common controller operations
 // and is typically created by using aries.Context().
 type provider interface {
@@ -72,6 +85,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(CreateDIDPath, http.MethodPost, o.CreateDID),
 		cmdutil.NewHTTPHandler(GetDIDRecordsPath, http.MethodGet, o.GetDIDRecords),
 		cmdutil.NewHTTPHandler(GetDIDPath, http.MethodGet, o.GetDID),
+		cmdutil.NewHTTPHandler(DereferenceDIDPath, http.MethodGet, o.DereferenceDID),
 	}
 }
 
@@ -135,7 +149,32 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 
 	request := fmt.Sprintf(`{"id":"%s"}`, string(decodedID))
 
-	rest.Execute(o.command.ResolveDID, rw, bytes.NewBufferString(request))
+	executeResolution(o.command.ResolveDID, rw, bytes.NewBufferString(request))
+}
+
+// DereferenceDID swagger:route GET /vdr/did/dereference/{id} vdr dereferenceDIDReq
+//
+// Dereference did url
+//
+// Responses:
+//    default: genericError
+//        200: dereferenceDIDResponse
+func (o *Operation) DereferenceDID(rw http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)["id"]
+
+	decodedID, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, vdr.InvalidRequestErrorCode, fmt.Errorf("invalid id"))
+		return
+	}
+
+	request, err := json.Marshal(&vdr.IDArg{ID: string(decodedID)})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, vdr.InvalidRequestErrorCode, fmt.Errorf("invalid id"))
+		return
+	}
+
+	executeResolution(o.command.DereferenceDID, rw, bytes.NewBuffer(request))
 }
 
 // GetDIDRecords swagger:route GET /vdr/did/records vdr getDIDRecords
@@ -148,3 +187,22 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) GetDIDRecords(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.GetDIDRecords, rw, req.Body)
 }
+
+// executeResolution executes the given did resolution command and maps resolution errors to their HTTP status.
+func executeResolution(exec command.Exec, rw http.ResponseWriter, req io.Reader) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	err := exec(rw, req)
+	if err == nil {
+		return
+	}
+
+	status, ok := resolutionHTTPStatus[err.Code()]
+	if !ok {
+		rest.SendError(rw, err)
+
+		return
+	}
+
+	rest.SendHTTPStatusError(rw, status, err.Code(), err)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -58,7 +59,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 6, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -258,6 +259,88 @@ func TestResolveDID(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, code)
 		verifyError(t, vdr.InvalidRequestErrorCode, "invalid id", buf.Bytes())
 	})
+
+	t.Run("test resolve did - resolution error statuses", func(t *testing.T) {
+		tests := []struct {
+			err    error
+			code   command.Code
+			status int
+		}{
+			{err: vdrapi.ErrNotFound, code: vdr.DIDNotFoundErrorCode, status: http.StatusNotFound},
+			{err: vdrapi.ErrInvalidDID, code: vdr.InvalidDIDErrorCode, status: http.StatusBadRequest},
+			{err: vdrapi.ErrMethodNotSupported, code: vdr.DIDMethodNotSupportedErrorCode, status: http.StatusNotImplemented},
+			{err: vdrapi.ErrDeactivated, code: vdr.DIDDeactivatedErrorCode, status: http.StatusGone},
+			{err: fmt.Errorf("resolve error"), code: vdr.ResolveDIDErrorCode, status: http.StatusBadRequest},
+		}
+
+		for _, tc := range tests {
+			cmd, err := New(&mockprovider.Provider{
+				StorageProviderValue: mockstore.NewMockStoreProvider(),
+				VDRegistryValue:      &mockvdr.MockVDRegistry{ResolveErr: tc.err},
+			})
+			require.NoError(t, err)
+
+			handler := lookupHandler(t, cmd, ResolveDIDPath, http.MethodGet)
+			buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/resolve/%s`,
+				vdrDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.status, code)
+			verifyError(t, tc.code, tc.err.Error(), buf.Bytes())
+		}
+	})
+}
+
+func TestDereferenceDID(t *testing.T) {
+	t.Run("test dereference did - success", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      &mockvdr.MockVDRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, DereferenceDIDPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, fmt.Sprintf(`%s/dereference/%s`,
+			vdrDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, didDoc.ID, response["contentStream"].(map[string]interface{})["id"])
+	})
+
+	t.Run("test dereference did - invalid id", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, DereferenceDIDPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/dereference/%s`, vdrDIDPath, "abc"))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdr.InvalidRequestErrorCode, "invalid id", buf.Bytes())
+	})
+
+	t.Run("test dereference did - not found", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue:      &mockvdr.MockVDRegistry{DereferenceErr: vdrapi.ErrNotFound},
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, DereferenceDIDPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/dereference/%s`,
+			vdrDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp#key-3"))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusNotFound, code)
+		verifyError(t, vdr.DIDNotFoundErrorCode, "DID not found", buf.Bytes())
+	})
 }
 
 func TestGetDIDRecords(t *testing.T) {
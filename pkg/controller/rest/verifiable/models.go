@@ -25,6 +25,28 @@ type validateCredentialReq struct { // nolint: unused,deadcode
 	Params verifiable.Credential
 }
 
+// verifyCredentialReq model
+//
+// This is used to verify the verifiable credential against a verification policy.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable credential (pass the vc document as a string)
+	//
+	// in: body
+	Params verifiable.VerifyCredentialRequest
+}
+
+// verifyCredentialRes model
+//
+// This is used for returning the verification report of the verifiable credential.
+//
+// swagger:response verifyCredentialRes
+type verifyCredentialRes struct { // nolint: unused,deadcode
+	// in: body
+	verifiable.VerifyCredentialResponse
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
@@ -33,6 +33,7 @@ const (
 
 	// credential paths.
 	ValidateCredentialPath     = verifiableCredentialPath + "/validate"
+	VerifyCredentialPath       = verifiableCredentialPath + "/verify"
 	SaveCredentialPath         = verifiableCredentialPath
 	GetCredentialPath          = verifiableCredentialPath + "/{id}"
 	GetCredentialByNamePath    = verifiableCredentialPath + "/name" + "/{name}"
@@ -87,6 +88,7 @@ func (o *Operation) GetRESTHandlers() []rest.Handler {
 func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(ValidateCredentialPath, http.MethodPost, o.ValidateCredential),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
 		cmdutil.NewHTTPHandler(SaveCredentialPath, http.MethodPost, o.SaveCredential),
 		cmdutil.NewHTTPHandler(GetCredentialPath, http.MethodGet, o.GetCredential),
 		cmdutil.NewHTTPHandler(GetCredentialByNamePath, http.MethodGet, o.GetCredentialByName),
@@ -114,6 +116,17 @@ func (o *Operation) ValidateCredential(rw http.ResponseWriter, req *http.Request
 	rest.Execute(o.command.ValidateCredential, rw, req.Body)
 }
 
+// VerifyCredential swagger:route POST /verifiable/credential/verify verifiable verifyCredentialReq
+//
+// Verifies the verifiable credential against the verification policy.
+//
+// Responses:
+//    default: genericError
+//        200: verifyCredentialRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyCredential, rw, req.Body)
+}
+
 // SaveCredential swagger:route POST /verifiable/credential verifiable saveCredentialReq
 //
 // Saves the verifiable credential.
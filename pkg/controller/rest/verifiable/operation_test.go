@@ -280,7 +280,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 14, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 15, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -343,6 +343,51 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	t.Run("test verify vc - success", func(t *testing.T) {
+		loader, err := jsonldtest.DocumentLoader()
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue:      mockstore.NewMockStoreProvider(),
+			JSONLDDocumentLoaderValue: loader,
+		})
+		require.NoError(t, err)
+
+		jsonStr, err := json.Marshal(verifiable.VerifyCredentialRequest{
+			VerifiableCredential: vc,
+			Policy:               json.RawMessage(`{"credentialTypes":["VerifiableCredential"]}`),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verifyCredentialRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.True(t, response.Report.Verified)
+		require.Len(t, response.Report.Checks, 2)
+	})
+
+	t.Run("test verify vc - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"verifiableCredential":"{}","policy":{"clockSkew":1}}`), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyCredentialErrorCode, "parse policy", buf.Bytes())
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	t.Run("test save vc - success", func(t *testing.T) {
 		loader, err := jsonldtest.DocumentLoader()
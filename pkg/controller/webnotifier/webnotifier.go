@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 )
@@ -31,26 +32,45 @@ var logger = log.New("aries-framework/webnotifier")
 type WebNotifier struct {
 	notifiers []command.Notifier
 	handlers  []rest.Handler
+	policy    *redact.Policy
+}
+
+// Opt configures the WebNotifier.
+type Opt func(n *WebNotifier)
+
+// WithRedactionPolicy sets the policy masking the sensitive members of the notified messages,
+// redact.DefaultPolicy() by default. A nil policy disables redaction.
+func WithRedactionPolicy(policy *redact.Policy) Opt {
+	return func(n *WebNotifier) {
+		n.policy = policy
+	}
 }
 
 // New returns a new instance of a WebNotifier.
-func New(wsPath string, webhookURLs []string) *WebNotifier {
+func New(wsPath string, webhookURLs []string, opts ...Opt) *WebNotifier {
 	webhook := NewHTTPNotifier(webhookURLs)
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
 		notifiers: []command.Notifier{webhook, ws},
 		handlers:  ws.GetRESTHandlers(),
+		policy:    redact.DefaultPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(&n)
 	}
 
 	return &n
 }
 
-// Notify sends the given message to all of the subscribers.
+// Notify sends the given message, redacted with the redaction policy, to all of the subscribers.
 // If multiple errors are encountered, then the first one is returned.
 func (n *WebNotifier) Notify(topic string, message []byte) error {
 	var allErrs error
 
+	message = n.policy.JSON(message)
+
 	for _, notifier := range n.notifiers {
 		err := notifier.Notify(topic, message)
 		allErrs = appendError(allErrs, err)
@@ -10,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/redact"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 )
 
 func TestNew(t *testing.T) {
@@ -43,3 +46,50 @@ func TestGetHandlers(t *testing.T) {
 	handlers := n.GetRESTHandlers()
 	require.Equal(t, 1, len(handlers))
 }
+
+func TestNotifyRedaction(t *testing.T) {
+	const msg = `{"credentialSubject":{"id":"did:example:123","name":"Alice"},"proof":{"jws":"eyJ.sig"}}`
+
+	t.Run("default policy", func(t *testing.T) {
+		recorder := &recordingNotifier{}
+
+		n := New("/", nil)
+		n.notifiers = []command.Notifier{recorder}
+
+		require.NoError(t, n.Notify("example", []byte(msg)))
+		require.JSONEq(t,
+			`{"credentialSubject":{"id":"[REDACTED]","name":"[REDACTED]"},"proof":{"jws":"[REDACTED]"}}`,
+			string(recorder.message))
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		recorder := &recordingNotifier{}
+
+		n := New("/", nil, WithRedactionPolicy(redact.NewPolicy(redact.WithPaths("$.proof"))))
+		n.notifiers = []command.Notifier{recorder}
+
+		require.NoError(t, n.Notify("example", []byte(msg)))
+		require.JSONEq(t, `{"credentialSubject":{"id":"did:example:123","name":"Alice"},"proof":"[REDACTED]"}`,
+			string(recorder.message))
+	})
+
+	t.Run("redaction disabled", func(t *testing.T) {
+		recorder := &recordingNotifier{}
+
+		n := New("/", nil, WithRedactionPolicy(nil))
+		n.notifiers = []command.Notifier{recorder}
+
+		require.NoError(t, n.Notify("example", []byte(msg)))
+		require.Equal(t, msg, string(recorder.message))
+	})
+}
+
+type recordingNotifier struct {
+	message []byte
+}
+
+func (r *recordingNotifier) Notify(_ string, message []byte) error {
+	r.message = message
+
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instrumented provides a crypto.Crypto decorator recording the duration and outcome of each operation in
+// the metrics and the traces.
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+//nolint:gochecknoglobals
+var operationDuration = metrics.NewHistogram("aries_crypto_operation_duration_seconds",
+	"Time spent by the crypto operations.", nil, "operation", "status")
+
+// Crypto decorates a crypto.Crypto with metrics and traces.
+type Crypto struct {
+	crypto crypto.Crypto
+}
+
+// New returns c decorated with metrics and traces.
+func New(c crypto.Crypto) *Crypto {
+	return &Crypto{crypto: c}
+}
+
+// Unwrap returns the decorated crypto.Crypto.
+func (c *Crypto) Unwrap() crypto.Crypto {
+	return c.crypto
+}
+
+// Encrypt calls Encrypt of the decorated crypto.
+func (c *Crypto) Encrypt(msg, aad []byte, kh interface{}) ([]byte, []byte, error) {
+	done := observe("Encrypt")
+
+	cipher, nonce, err := c.crypto.Encrypt(msg, aad, kh)
+
+	done(err)
+
+	return cipher, nonce, err
+}
+
+// Decrypt calls Decrypt of the decorated crypto.
+func (c *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, error) {
+	done := observe("Decrypt")
+
+	msg, err := c.crypto.Decrypt(cipher, aad, nonce, kh)
+
+	done(err)
+
+	return msg, err
+}
+
+// Sign calls Sign of the decorated crypto.
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	done := observe("Sign")
+
+	sig, err := c.crypto.Sign(msg, kh)
+
+	done(err)
+
+	return sig, err
+}
+
+// Verify calls Verify of the decorated crypto.
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	done := observe("Verify")
+
+	err := c.crypto.Verify(signature, msg, kh)
+
+	done(err)
+
+	return err
+}
+
+// ComputeMAC calls ComputeMAC of the decorated crypto.
+func (c *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
+	done := observe("ComputeMAC")
+
+	mac, err := c.crypto.ComputeMAC(data, kh)
+
+	done(err)
+
+	return mac, err
+}
+
+// VerifyMAC calls VerifyMAC of the decorated crypto.
+func (c *Crypto) VerifyMAC(mac, data []byte, kh interface{}) error {
+	done := observe("VerifyMAC")
+
+	err := c.crypto.VerifyMAC(mac, data, kh)
+
+	done(err)
+
+	return err
+}
+
+// WrapKey calls WrapKey of the decorated crypto.
+func (c *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
+	opts ...crypto.WrapKeyOpts) (*crypto.RecipientWrappedKey, error) {
+	done := observe("WrapKey")
+
+	wk, err := c.crypto.WrapKey(cek, apu, apv, recPubKey, opts...)
+
+	done(err)
+
+	return wk, err
+}
+
+// UnwrapKey calls UnwrapKey of the decorated crypto.
+func (c *Crypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, kh interface{},
+	opts ...crypto.WrapKeyOpts) ([]byte, error) {
+	done := observe("UnwrapKey")
+
+	cek, err := c.crypto.UnwrapKey(recWK, kh, opts...)
+
+	done(err)
+
+	return cek, err
+}
+
+// SignMulti calls SignMulti of the decorated crypto.
+func (c *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	done := observe("SignMulti")
+
+	sig, err := c.crypto.SignMulti(messages, kh)
+
+	done(err)
+
+	return sig, err
+}
+
+// VerifyMulti calls VerifyMulti of the decorated crypto.
+func (c *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	done := observe("VerifyMulti")
+
+	err := c.crypto.VerifyMulti(messages, signature, kh)
+
+	done(err)
+
+	return err
+}
+
+// VerifyProof calls VerifyProof of the decorated crypto.
+func (c *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error {
+	done := observe("VerifyProof")
+
+	err := c.crypto.VerifyProof(revealedMessages, proof, nonce, kh)
+
+	done(err)
+
+	return err
+}
+
+// DeriveProof calls DeriveProof of the decorated crypto.
+func (c *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
+	kh interface{}) ([]byte, error) {
+	done := observe("DeriveProof")
+
+	proof, err := c.crypto.DeriveProof(messages, bbsSignature, nonce, revealedIndexes, kh)
+
+	done(err)
+
+	return proof, err
+}
+
+func observe(operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(context.Background(), "crypto."+operation)
+
+	return func(err error) {
+		span.RecordError(err)
+		span.End()
+
+		operationDuration.Observe(time.Since(start).Seconds(), operation, metrics.Status(err))
+	}
+}
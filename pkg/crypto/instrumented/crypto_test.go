@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instrumented_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/instrumented"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+)
+
+type recorder struct {
+	spans []*tracing.SpanData
+}
+
+func (r *recorder) ExportSpan(span *tracing.SpanData) {
+	r.spans = append(r.spans, span)
+}
+
+func TestCrypto(t *testing.T) {
+	r := &recorder{}
+
+	tracing.SetExporter(r)
+	defer tracing.SetExporter(nil)
+
+	errFailed := errors.New("failed")
+
+	mock := &mockcrypto.Crypto{
+		EncryptValue:      []byte("cipher"),
+		EncryptNonceValue: []byte("nonce"),
+		DecryptValue:      []byte("msg"),
+		SignValue:         []byte("signature"),
+		VerifyErr:         errFailed,
+		ComputeMACValue:   []byte("mac"),
+		WrapValue:         &cryptoapi.RecipientWrappedKey{KID: "kid"},
+		UnwrapValue:       []byte("cek"),
+		BBSSignValue:      []byte("bbs signature"),
+		DeriveProofValue:  []byte("proof"),
+	}
+
+	c := instrumented.New(mock)
+	require.Equal(t, mock, c.Unwrap())
+
+	cipher, nonce, err := c.Encrypt(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cipher"), cipher)
+	require.Equal(t, []byte("nonce"), nonce)
+
+	msg, err := c.Decrypt(nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("msg"), msg)
+
+	sig, err := c.Sign(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("signature"), sig)
+
+	require.ErrorIs(t, c.Verify(nil, nil, nil), errFailed)
+
+	mac, err := c.ComputeMAC(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("mac"), mac)
+	require.NoError(t, c.VerifyMAC(nil, nil, nil))
+
+	wk, err := c.WrapKey(nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "kid", wk.KID)
+
+	cek, err := c.UnwrapKey(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cek"), cek)
+
+	sig, err = c.SignMulti(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("bbs signature"), sig)
+	require.NoError(t, c.VerifyMulti(nil, nil, nil))
+	require.NoError(t, c.VerifyProof(nil, nil, nil, nil))
+
+	proof, err := c.DeriveProof(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("proof"), proof)
+
+	require.Len(t, r.spans, 12)
+	require.Equal(t, "crypto.Verify", r.spans[3].Name)
+	require.Equal(t, "failed", r.spans[3].Error)
+
+	var buf bytes.Buffer
+
+	_, err = metrics.Default().WriteTo(&buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(),
+		`aries_crypto_operation_duration_seconds_count{operation="Verify",status="error"} 1`)
+	require.Contains(t, buf.String(),
+		`aries_crypto_operation_duration_seconds_count{operation="Sign",status="success"} 1`)
+}
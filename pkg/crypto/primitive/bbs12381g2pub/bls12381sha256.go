@@ -0,0 +1,744 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	bls12381 "github.com/kilic/bls12-381"
+
+	bls12381intern "github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub/internal/kilic/bls12-381"
+)
+
+const (
+	// CiphersuiteBLS12381SHA256 is the identifier of the BLS12-381-SHA-256 ciphersuite of the IETF BBS draft.
+	CiphersuiteBLS12381SHA256 = "BBS_BLS12381G1_XMD:SHA-256_SSWU_RO_"
+
+	// BLS12381SHA256SignatureLen is the length of a BLS12-381-SHA-256 signature (A || e).
+	BLS12381SHA256SignatureLen = g1CompressedSize + frCompressedSize
+
+	// apiID is the identifier of the "hash to scalar messages" interface of the draft.
+	apiID = CiphersuiteBLS12381SHA256 + "H2G_HM2S_"
+
+	// expandLen is the number of bytes expanded when hashing to a scalar.
+	expandLen = 48
+
+	// proofFixedLen is the length of the proof without the undisclosed messages responses:
+	// Abar, Bbar, D (G1 points) and e^, r1^, r3^, challenge (scalars).
+	proofFixedLen = 3*g1CompressedSize + 4*frCompressedSize
+
+	// Number of random scalars needed by ProofInit besides the undisclosed messages blindings.
+	proofRandomScalars = 5
+
+	// Octets used to serialize integers (I2OSP(x, 8)).
+	intOctets = 8
+
+	keyMaterialMinLen = 32
+)
+
+//nolint:gochecknoglobals
+var (
+	// curveOrder is the order r of the G1 and G2 groups.
+	curveOrder = bls12381.NewG1().Q()
+
+	// p1Hex is the P1 fixed point of the BLS12-381-SHA-256 ciphersuite.
+	p1Hex = "a8ce256102840821a3e94ea9025e4662b205762f9776b3a766c872b948f1fd225e7c59698588e70d11406d161b4e28c9"
+
+	keyGenDST      = []byte(apiID + "KEYGEN_DST_")
+	mapMessageDST  = []byte(apiID + "MAP_MSG_TO_SCALAR_AS_HASH_")
+	hashScalarDST  = []byte(apiID + "H2S_")
+	seedDST        = []byte(apiID + "SIG_GENERATOR_SEED_")
+	generatorDST   = []byte(apiID + "SIG_GENERATOR_DST_")
+	generatorSeed  = []byte(apiID + "MESSAGE_GENERATOR_SEED")
+	errInvalidSigs = errors.New("invalid BLS12-381-SHA-256 signature")
+)
+
+// BLS12381SHA256 implements the BBS signature scheme of the IETF draft
+// (https://datatracker.ietf.org/doc/draft-irtf-cfrg-bbs-signatures/) using the BLS12-381-SHA-256 ciphersuite.
+// Messages are mapped to scalars with the "hash to scalar" operation. Keys are the same BLS12-381 keys as for
+// BBSG2Pub: a 32 bytes private scalar and a compressed G2 public key.
+//
+// Sign, Verify, DeriveProof and VerifyProof follow the BBSG2Pub method signatures so both schemes can be used
+// interchangeably by the crypto service. Derived proofs are prefixed with the number of signed messages and the
+// revealed indexes; the nonce is used as the draft's presentation header.
+type BLS12381SHA256 struct{}
+
+// NewBLS12381SHA256 creates a new BLS12381SHA256.
+func NewBLS12381SHA256() *BLS12381SHA256 {
+	return &BLS12381SHA256{}
+}
+
+// GenerateKeyPairBLS12381SHA256 derives a key pair from keyMaterial (at least 32 bytes) and an optional keyInfo as
+// defined by the KeyGen operation of the draft.
+func GenerateKeyPairBLS12381SHA256(keyMaterial, keyInfo []byte) (*PublicKey, *PrivateKey, error) {
+	if len(keyMaterial) < keyMaterialMinLen {
+		return nil, nil, errors.New("key material is too short")
+	}
+
+	if len(keyInfo) > 65535 { //nolint:gomnd
+		return nil, nil, errors.New("key info is too long")
+	}
+
+	deriveInput := make([]byte, 0, len(keyMaterial)+2+len(keyInfo))
+	deriveInput = append(deriveInput, keyMaterial...)
+	deriveInput = append(deriveInput, byte(len(keyInfo)>>8), byte(len(keyInfo))) //nolint:gomnd
+	deriveInput = append(deriveInput, keyInfo...)
+
+	sk, err := hashToScalar(deriveInput, keyGenDST)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sk.Sign() == 0 {
+		return nil, nil, errors.New("invalid key material")
+	}
+
+	privKey := &PrivateKey{FR: parseFr(scalarToBytes(sk))}
+
+	return privKey.PublicKey(), privKey, nil
+}
+
+// Sign signs the one or more messages using private key in compressed form.
+func (bbs *BLS12381SHA256) Sign(messages [][]byte, privKeyBytes []byte) ([]byte, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("messages are not defined")
+	}
+
+	return bbs.SignWithHeader(nil, messages, privKeyBytes)
+}
+
+// Verify makes BLS12-381-SHA-256 signature verification.
+func (bbs *BLS12381SHA256) Verify(messages [][]byte, sigBytes, pubKeyBytes []byte) error {
+	return bbs.VerifyWithHeader(nil, messages, sigBytes, pubKeyBytes)
+}
+
+// DeriveProof derives a proof of the signature with some messages disclosed. The nonce is bound to the proof as its
+// presentation header.
+func (bbs *BLS12381SHA256) DeriveProof(messages [][]byte, sigBytes, nonce, pubKeyBytes []byte,
+	revealedIndexes []int) ([]byte, error) {
+	if len(revealedIndexes) == 0 {
+		return nil, errors.New("no message to reveal")
+	}
+
+	sort.Ints(revealedIndexes)
+
+	proof, err := bbs.DeriveProofWithHeader(nil, nonce, messages, sigBytes, pubKeyBytes, revealedIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadBytes, err := newPoKPayload(len(messages), revealedIndexes).toBytes()
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: paylod to bytes: %w", err)
+	}
+
+	return append(payloadBytes, proof...), nil
+}
+
+// VerifyProof verifies a proof created by DeriveProof for the revealed messages.
+func (bbs *BLS12381SHA256) VerifyProof(messages [][]byte, proof, nonce, pubKeyBytes []byte) error {
+	payload, err := parsePoKPayload(proof)
+	if err != nil {
+		return fmt.Errorf("parse signature proof: %w", err)
+	}
+
+	if len(payload.revealed) != len(messages) {
+		return errors.New("revealed messages do not match the proof")
+	}
+
+	return bbs.VerifyProofWithHeader(nil, nonce, messages, proof[payload.lenInBytes():], pubKeyBytes,
+		payload.messagesCount, payload.revealed)
+}
+
+// SignWithHeader signs messages and binds the signature to the given header. As in the draft, the signature can
+// cover no message at all and then only binds the header.
+func (bbs *BLS12381SHA256) SignWithHeader(header []byte, messages [][]byte, privKeyBytes []byte) ([]byte, error) {
+	privKey, err := UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	pubKeyBytes, err := privKey.PublicKey().Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	sk := privKey.FR.RedToBig()
+
+	msgScalars, err := messagesToScalars(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	gens, err := createGenerators(len(messages) + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := calculateDomain(pubKeyBytes, gens, header)
+	if err != nil {
+		return nil, err
+	}
+
+	eInput := scalarToBytes(sk)
+	for _, m := range msgScalars {
+		eInput = append(eInput, scalarToBytes(m)...)
+	}
+
+	eInput = append(eInput, scalarToBytes(domain)...)
+
+	e, err := hashToScalar(eInput, hashScalarDST)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := computeBSHA256(domain, msgScalars, gens)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := new(big.Int).Add(sk, e)
+	exp.Mod(exp, curveOrder)
+
+	if exp.Sign() == 0 {
+		return nil, errors.New("invalid private key")
+	}
+
+	exp.ModInverse(exp, curveOrder)
+
+	a := g1.New()
+	g1.MulScalarBig(a, b, exp)
+
+	sig := make([]byte, 0, BLS12381SHA256SignatureLen)
+	sig = append(sig, g1.ToCompressed(a)...)
+	sig = append(sig, scalarToBytes(e)...)
+
+	return sig, nil
+}
+
+// VerifyWithHeader verifies a signature created by SignWithHeader.
+func (bbs *BLS12381SHA256) VerifyWithHeader(header []byte, messages [][]byte, sigBytes, pubKeyBytes []byte) error {
+	a, e, err := parseSignatureSHA256(sigBytes)
+	if err != nil {
+		return fmt.Errorf("parse signature: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+
+	msgScalars, err := messagesToScalars(messages)
+	if err != nil {
+		return err
+	}
+
+	gens, err := createGenerators(len(messages) + 1)
+	if err != nil {
+		return err
+	}
+
+	domain, err := calculateDomain(pubKeyBytes, gens, header)
+	if err != nil {
+		return err
+	}
+
+	b, err := computeBSHA256(domain, msgScalars, gens)
+	if err != nil {
+		return err
+	}
+
+	// e(A, W + BP2 * e) * e(B, -BP2) == Identity_GT
+	q1 := g2.New()
+	g2.MulScalarBig(q1, g2.One(), e)
+	g2.Add(q1, q1, pubKey.PointG2)
+
+	negB := g1.New()
+	g1.Neg(negB, b)
+
+	if !compareTwoPairings(a, q1, negB, g2.One()) {
+		return errInvalidSigs
+	}
+
+	return nil
+}
+
+// DeriveProofWithHeader generates a proof of knowledge of the signature over messages, with the messages at
+// revealedIndexes disclosed. The proof is bound to the signature header and to the presentation header ph.
+// The returned proof follows the draft's serialization and does not carry the revealed indexes.
+func (bbs *BLS12381SHA256) DeriveProofWithHeader(header, ph []byte, messages [][]byte, sigBytes, pubKeyBytes []byte,
+	revealedIndexes []int) ([]byte, error) {
+	a, e, err := parseSignatureSHA256(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signature: %w", err)
+	}
+
+	if _, err = UnmarshalPublicKey(pubKeyBytes); err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	revealed, err := revealedSet(revealedIndexes, len(messages))
+	if err != nil {
+		return nil, err
+	}
+
+	msgScalars, err := messagesToScalars(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	gens, err := createGenerators(len(messages) + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := calculateDomain(pubKeyBytes, gens, header)
+	if err != nil {
+		return nil, err
+	}
+
+	undisclosed := make([]int, 0, len(messages)-len(revealedIndexes))
+
+	for i := range messages {
+		if !revealed[i] {
+			undisclosed = append(undisclosed, i)
+		}
+	}
+
+	random, err := randomScalars(proofRandomScalars + len(undisclosed))
+	if err != nil {
+		return nil, err
+	}
+
+	r1, r2, eTilde, r1Tilde, r3Tilde, mTilde := random[0], random[1], random[2], random[3], random[4], random[5:]
+
+	b, err := computeBSHA256(domain, msgScalars, gens)
+	if err != nil {
+		return nil, err
+	}
+
+	d := g1.New()
+	g1.MulScalarBig(d, b, r2)
+
+	aBar := g1.New()
+	g1.MulScalarBig(aBar, a, modMul(r1, r2))
+
+	// Bbar = D * r1 - Abar * e
+	bBar := g1.New()
+	g1.MulScalarBig(bBar, d, r1)
+	g1.Sub(bBar, bBar, mulG1(aBar, e))
+
+	// T1 = Abar * e~ + D * r1~
+	t1 := mulG1(aBar, eTilde)
+	g1.Add(t1, t1, mulG1(d, r1Tilde))
+
+	// T2 = D * r3~ + H_j1 * m~_j1 + ... + H_jU * m~_jU
+	t2 := mulG1(d, r3Tilde)
+	for i, j := range undisclosed {
+		g1.Add(t2, t2, mulG1(gens[j+1], mTilde[i]))
+	}
+
+	disclosedScalars := make([]*big.Int, len(revealedIndexes))
+	for k, i := range revealedIndexes {
+		disclosedScalars[k] = msgScalars[i]
+	}
+
+	challenge, err := proofChallenge(revealedIndexes, disclosedScalars, aBar, bBar, d, t1, t2, domain, ph)
+	if err != nil {
+		return nil, err
+	}
+
+	r3 := new(big.Int).ModInverse(r2, curveOrder)
+
+	proof := make([]byte, 0, proofFixedLen+len(undisclosed)*frCompressedSize)
+	proof = append(proof, g1.ToCompressed(aBar)...)
+	proof = append(proof, g1.ToCompressed(bBar)...)
+	proof = append(proof, g1.ToCompressed(d)...)
+	proof = append(proof, scalarToBytes(modAdd(eTilde, modMul(e, challenge)))...)
+	proof = append(proof, scalarToBytes(modSub(r1Tilde, modMul(r1, challenge)))...)
+	proof = append(proof, scalarToBytes(modSub(r3Tilde, modMul(r3, challenge)))...)
+
+	for i, j := range undisclosed {
+		proof = append(proof, scalarToBytes(modAdd(mTilde[i], modMul(msgScalars[j], challenge)))...)
+	}
+
+	proof = append(proof, scalarToBytes(challenge)...)
+
+	return proof, nil
+}
+
+// VerifyProofWithHeader verifies a proof created by DeriveProofWithHeader. messages are the revealed messages, in
+// the order of revealedIndexes, and messagesCount is the number of messages originally signed.
+func (bbs *BLS12381SHA256) VerifyProofWithHeader(header, ph []byte, messages [][]byte, proof, pubKeyBytes []byte,
+	messagesCount int, revealedIndexes []int) error {
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+
+	if len(messages) != len(revealedIndexes) {
+		return errors.New("revealed messages do not match the revealed indexes")
+	}
+
+	revealed, err := revealedSet(revealedIndexes, messagesCount)
+	if err != nil {
+		return err
+	}
+
+	undisclosedCount := messagesCount - len(revealedIndexes)
+
+	p, err := parseProofSHA256(proof, undisclosedCount)
+	if err != nil {
+		return fmt.Errorf("parse signature proof: %w", err)
+	}
+
+	msgScalars, err := messagesToScalars(messages)
+	if err != nil {
+		return err
+	}
+
+	gens, err := createGenerators(messagesCount + 1)
+	if err != nil {
+		return err
+	}
+
+	domain, err := calculateDomain(pubKeyBytes, gens, header)
+	if err != nil {
+		return err
+	}
+
+	// T1 = Bbar * c + Abar * e^ + D * r1^
+	t1 := mulG1(p.bBar, p.challenge)
+	g1.Add(t1, t1, mulG1(p.aBar, p.eHat))
+	g1.Add(t1, t1, mulG1(p.d, p.r1Hat))
+
+	// Bv = P1 + Q_1 * domain + H_i1 * msg_i1 + ... + H_iR * msg_iR
+	bv, err := p1Point()
+	if err != nil {
+		return err
+	}
+
+	g1.Add(bv, bv, mulG1(gens[0], domain))
+
+	for k, i := range revealedIndexes {
+		g1.Add(bv, bv, mulG1(gens[i+1], msgScalars[k]))
+	}
+
+	// T2 = Bv * c + D * r3^ + H_j1 * m^_j1 + ... + H_jU * m^_jU
+	t2 := mulG1(bv, p.challenge)
+	g1.Add(t2, t2, mulG1(p.d, p.r3Hat))
+
+	u := 0
+
+	for j := 0; j < messagesCount; j++ {
+		if revealed[j] {
+			continue
+		}
+
+		g1.Add(t2, t2, mulG1(gens[j+1], p.mHat[u]))
+		u++
+	}
+
+	challenge, err := proofChallenge(revealedIndexes, msgScalars, p.aBar, p.bBar, p.d, t1, t2, domain, ph)
+	if err != nil {
+		return err
+	}
+
+	if challenge.Cmp(p.challenge) != 0 {
+		return errors.New("invalid BLS12-381-SHA-256 signature proof")
+	}
+
+	// e(Abar, W) * e(Bbar, -BP2) == Identity_GT
+	negBP2 := g2.New()
+	g2.Neg(negBP2, g2.One())
+
+	if g1.IsZero(p.aBar) || !compareTwoPairings(p.aBar, pubKey.PointG2, p.bBar, negBP2) {
+		return errors.New("invalid BLS12-381-SHA-256 signature proof")
+	}
+
+	return nil
+}
+
+type proofSHA256 struct {
+	aBar, bBar, d      *bls12381.PointG1
+	eHat, r1Hat, r3Hat *big.Int
+	mHat               []*big.Int
+	challenge          *big.Int
+}
+
+// ProofUndisclosedCount returns the number of messages left undisclosed by a proof created by
+// DeriveProofWithHeader. Like the draft's ProofVerify, it is told by the length of the proof.
+func ProofUndisclosedCount(proof []byte) (int, error) {
+	if len(proof) < proofFixedLen || (len(proof)-proofFixedLen)%frCompressedSize != 0 {
+		return 0, errors.New("invalid size of signature proof")
+	}
+
+	return (len(proof) - proofFixedLen) / frCompressedSize, nil
+}
+
+func parseProofSHA256(proof []byte, undisclosedCount int) (*proofSHA256, error) {
+	if len(proof) != proofFixedLen+undisclosedCount*frCompressedSize {
+		return nil, errors.New("invalid size of proof")
+	}
+
+	points := make([]*bls12381.PointG1, 3) //nolint:gomnd
+
+	for i := range points {
+		p, err := g1.FromCompressed(proof[i*g1CompressedSize : (i+1)*g1CompressedSize])
+		if err != nil {
+			return nil, fmt.Errorf("deserialize G1 compressed point: %w", err)
+		}
+
+		points[i] = p
+	}
+
+	scalarsBytes := proof[3*g1CompressedSize:]
+	scalars := make([]*big.Int, len(scalarsBytes)/frCompressedSize)
+
+	for i := range scalars {
+		s, err := scalarFromBytes(scalarsBytes[i*frCompressedSize : (i+1)*frCompressedSize])
+		if err != nil {
+			return nil, err
+		}
+
+		scalars[i] = s
+	}
+
+	return &proofSHA256{
+		aBar:      points[0],
+		bBar:      points[1],
+		d:         points[2],
+		eHat:      scalars[0],
+		r1Hat:     scalars[1],
+		r3Hat:     scalars[2],
+		mHat:      scalars[3 : len(scalars)-1],
+		challenge: scalars[len(scalars)-1],
+	}, nil
+}
+
+func parseSignatureSHA256(sigBytes []byte) (*bls12381.PointG1, *big.Int, error) {
+	if len(sigBytes) != BLS12381SHA256SignatureLen {
+		return nil, nil, errors.New("invalid size of signature")
+	}
+
+	a, err := g1.FromCompressed(sigBytes[:g1CompressedSize])
+	if err != nil {
+		return nil, nil, fmt.Errorf("deserialize G1 compressed signature: %w", err)
+	}
+
+	if g1.IsZero(a) {
+		return nil, nil, errors.New("invalid signature point")
+	}
+
+	e, err := scalarFromBytes(sigBytes[g1CompressedSize:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a, e, nil
+}
+
+func proofChallenge(revealedIndexes []int, revealedScalars []*big.Int, aBar, bBar, d, t1, t2 *bls12381.PointG1,
+	domain *big.Int, ph []byte) (*big.Int, error) {
+	// c_arr = (R, i1, msg_i1, ..., iR, msg_iR, Abar, Bbar, D, T1, T2, domain)
+	cOcts := i2osp8(len(revealedIndexes))
+
+	for k, i := range revealedIndexes {
+		cOcts = append(cOcts, i2osp8(i)...)
+		cOcts = append(cOcts, scalarToBytes(revealedScalars[k])...)
+	}
+
+	for _, p := range []*bls12381.PointG1{aBar, bBar, d, t1, t2} {
+		cOcts = append(cOcts, g1.ToCompressed(p)...)
+	}
+
+	cOcts = append(cOcts, scalarToBytes(domain)...)
+	cOcts = append(cOcts, i2osp8(len(ph))...)
+	cOcts = append(cOcts, ph...)
+
+	return hashToScalar(cOcts, hashScalarDST)
+}
+
+func revealedSet(revealedIndexes []int, messagesCount int) (map[int]bool, error) {
+	revealed := make(map[int]bool, len(revealedIndexes))
+
+	for k, i := range revealedIndexes {
+		if i < 0 || i >= messagesCount {
+			return nil, fmt.Errorf("invalid revealed index %d", i)
+		}
+
+		if k > 0 && revealedIndexes[k-1] >= i {
+			return nil, errors.New("revealed indexes must be unique and sorted")
+		}
+
+		revealed[i] = true
+	}
+
+	return revealed, nil
+}
+
+func computeBSHA256(domain *big.Int, msgScalars []*big.Int, gens []*bls12381.PointG1) (*bls12381.PointG1, error) {
+	// B = P1 + Q_1 * domain + H_1 * msg_1 + ... + H_L * msg_L
+	b, err := p1Point()
+	if err != nil {
+		return nil, err
+	}
+
+	g1.Add(b, b, mulG1(gens[0], domain))
+
+	for i, m := range msgScalars {
+		g1.Add(b, b, mulG1(gens[i+1], m))
+	}
+
+	return b, nil
+}
+
+func calculateDomain(pubKeyBytes []byte, gens []*bls12381.PointG1, header []byte) (*big.Int, error) {
+	// dom_input = PK || serialize((L, Q_1, H_1, ..., H_L)) || api_id || I2OSP(length(header), 8) || header
+	domInput := append([]byte{}, pubKeyBytes...)
+	domInput = append(domInput, i2osp8(len(gens)-1)...)
+
+	for _, p := range gens {
+		domInput = append(domInput, g1.ToCompressed(p)...)
+	}
+
+	domInput = append(domInput, apiID...)
+	domInput = append(domInput, i2osp8(len(header))...)
+	domInput = append(domInput, header...)
+
+	return hashToScalar(domInput, hashScalarDST)
+}
+
+// createGenerators returns Q_1 followed by count-1 message generators.
+func createGenerators(count int) ([]*bls12381.PointG1, error) {
+	v, err := expandMessage(generatorSeed, seedDST, expandLen)
+	if err != nil {
+		return nil, err
+	}
+
+	gens := make([]*bls12381.PointG1, count)
+
+	for i := 1; i <= count; i++ {
+		v, err = expandMessage(append(v, i2osp8(i)...), seedDST, expandLen)
+		if err != nil {
+			return nil, err
+		}
+
+		gens[i-1], err = g1.HashToCurve(v, generatorDST)
+		if err != nil {
+			return nil, fmt.Errorf("create generator: %w", err)
+		}
+	}
+
+	return gens, nil
+}
+
+func messagesToScalars(messages [][]byte) ([]*big.Int, error) {
+	scalars := make([]*big.Int, len(messages))
+
+	for i, m := range messages {
+		s, err := hashToScalar(m, mapMessageDST)
+		if err != nil {
+			return nil, fmt.Errorf("map message to scalar: %w", err)
+		}
+
+		scalars[i] = s
+	}
+
+	return scalars, nil
+}
+
+func hashToScalar(msg, dst []byte) (*big.Int, error) {
+	uniformBytes, err := expandMessage(msg, dst, expandLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(uniformBytes), curveOrder), nil
+}
+
+func expandMessage(msg, dst []byte, outLen int) ([]byte, error) {
+	return bls12381intern.ExpandMsgXMD(sha256.New, msg, dst, outLen)
+}
+
+func randomScalars(count int) ([]*big.Int, error) {
+	scalars := make([]*big.Int, count)
+
+	for i := range scalars {
+		b := make([]byte, expandLen)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		scalars[i] = new(big.Int).Mod(new(big.Int).SetBytes(b), curveOrder)
+	}
+
+	return scalars, nil
+}
+
+func p1Point() (*bls12381.PointG1, error) {
+	p1Bytes, err := hex.DecodeString(p1Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	return g1.FromCompressed(p1Bytes)
+}
+
+func mulG1(p *bls12381.PointG1, s *big.Int) *bls12381.PointG1 {
+	return g1.MulScalarBig(g1.New(), p, s)
+}
+
+func modMul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+
+	return r.Mod(r, curveOrder)
+}
+
+func modAdd(a, b *big.Int) *big.Int {
+	r := new(big.Int).Add(a, b)
+
+	return r.Mod(r, curveOrder)
+}
+
+func modSub(a, b *big.Int) *big.Int {
+	r := new(big.Int).Sub(a, b)
+
+	return r.Mod(r, curveOrder)
+}
+
+func scalarToBytes(s *big.Int) []byte {
+	b := make([]byte, frCompressedSize)
+
+	return s.FillBytes(b)
+}
+
+func scalarFromBytes(b []byte) (*big.Int, error) {
+	s := new(big.Int).SetBytes(b)
+
+	if s.Cmp(curveOrder) >= 0 {
+		return nil, errors.New("invalid scalar")
+	}
+
+	return s, nil
+}
+
+func i2osp8(i int) []byte {
+	b := make([]byte, intOctets)
+
+	binary.BigEndian.PutUint64(b, uint64(i))
+
+	return b
+}
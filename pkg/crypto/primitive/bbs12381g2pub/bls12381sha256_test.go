@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	bbs "github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+//nolint:lll
+const (
+	sha256SKHex       = "60e55110f76883a13d030b2f6bd11883422d5abde717569fc0731f51237169fc"
+	sha256PKHex       = "a820f230f6ae38503b86c70dc50b61c58a77e45c39ab25c0652bbaa8fa136f2851bd4781c9dcde39fc9d1d52c9e60268061e7d7632171d91aa8d460acee0e96f1e7c4cfb12d3ff9ab5d5dc91c277db75c845d649ef3c4f63aebc364cd55ded0c"
+	sha256HeaderHex   = "11223344556677889900aabbccddeeff"
+	sha256MsgHex      = "9872ad089e452c7b6e283dfac2a80d58e8d0ff71cc4d5e310a1debdda4a45f02"
+	sha256SigHex      = "84773160b824e194073a57493dac1a20b667af70cd2352d8af241c77658da5253aa8458317cca0eae615690d55b1f27164657dcafee1d5c1973947aa70e2cfbb4c892340be5969920d0916067b4565a0"
+	sha256MultiSigHex = "8339b285a4acd89dec7777c09543a43e3cc60684b0a6f8ab335da4825c96e1463e28f8c5f4fd0641d19cec5920d3a8ff4bedb6c9691454597bbd298288abed3632078557b2ace7d44caed846e1a0a1e8"
+)
+
+// sha256MultiMsgHex are the messages of the multi-message signature fixture of the draft.
+var sha256MultiMsgHex = []string{ //nolint:gochecknoglobals
+	"9872ad089e452c7b6e283dfac2a80d58e8d0ff71cc4d5e310a1debdda4a45f02",
+	"c344136d9ab02da4dd5908bbba913ae6f58c2cc844b802a6f811f5fb075f9b80",
+	"7372e9daa5ed31e6cd5c825eac1b855e84476a1d94932aa348e07b73",
+	"77fe97eb97a1ebe2e81e4e3597a3ee740a66e9ef2412472c",
+	"496694774c5604ab1b2544eababcf0f53278ff50",
+	"515ae153e22aae04ad16f759e07237b4",
+	"d183ddc6e2665aa4e2f088af",
+	"ac55fb33a75909ed",
+	"96012096",
+	"",
+}
+
+func TestBLS12381SHA256_Vectors(t *testing.T) {
+	sk := decodeHex(t, sha256SKHex)
+	pk := decodeHex(t, sha256PKHex)
+	header := decodeHex(t, sha256HeaderHex)
+	messages := [][]byte{decodeHex(t, sha256MsgHex)}
+
+	privKey, err := bbs.UnmarshalPrivateKey(sk)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := privKey.PublicKey().Marshal()
+	require.NoError(t, err)
+	require.Equal(t, pk, pubKeyBytes)
+
+	b := bbs.NewBLS12381SHA256()
+
+	t.Run("sign single message", func(t *testing.T) {
+		sig, err := b.SignWithHeader(header, messages, sk)
+		require.NoError(t, err)
+		require.Equal(t, sha256SigHex, hex.EncodeToString(sig))
+	})
+
+	t.Run("verify single message", func(t *testing.T) {
+		require.NoError(t, b.VerifyWithHeader(header, messages, decodeHex(t, sha256SigHex), pk))
+	})
+
+	t.Run("verify with another header", func(t *testing.T) {
+		err := b.VerifyWithHeader([]byte("other"), messages, decodeHex(t, sha256SigHex), pk)
+		require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature")
+	})
+
+	multiMessages := make([][]byte, len(sha256MultiMsgHex))
+	for i, m := range sha256MultiMsgHex {
+		multiMessages[i] = decodeHex(t, m)
+	}
+
+	t.Run("sign multiple messages", func(t *testing.T) {
+		sig, err := b.SignWithHeader(header, multiMessages, sk)
+		require.NoError(t, err)
+		require.Equal(t, sha256MultiSigHex, hex.EncodeToString(sig))
+	})
+
+	t.Run("derive and verify proof of multiple messages", func(t *testing.T) {
+		ph := []byte("presentation header")
+		revealed := []int{0, 2, 4, 6}
+
+		proof, err := b.DeriveProofWithHeader(header, ph, multiMessages, decodeHex(t, sha256MultiSigHex), pk, revealed)
+		require.NoError(t, err)
+
+		undisclosed, err := bbs.ProofUndisclosedCount(proof)
+		require.NoError(t, err)
+		require.Equal(t, len(multiMessages)-len(revealed), undisclosed)
+
+		revealedMessages := [][]byte{multiMessages[0], multiMessages[2], multiMessages[4], multiMessages[6]}
+
+		require.NoError(t, b.VerifyProofWithHeader(header, ph, revealedMessages, proof, pk,
+			len(multiMessages), revealed))
+
+		_, err = bbs.ProofUndisclosedCount(proof[1:])
+		require.EqualError(t, err, "invalid size of signature proof")
+	})
+
+	t.Run("sign no message", func(t *testing.T) {
+		sig, err := b.SignWithHeader(header, nil, sk)
+		require.NoError(t, err)
+		require.NoError(t, b.VerifyWithHeader(header, nil, sig, pk))
+	})
+}
+
+func TestBLS12381SHA256_SignVerify(t *testing.T) {
+	pubKey, privKey := generateSHA256KeyPair(t)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	messages := [][]byte{[]byte("message1"), []byte("message2"), []byte("message3")}
+
+	b := bbs.NewBLS12381SHA256()
+
+	sig, err := b.Sign(messages, privKeyBytes)
+	require.NoError(t, err)
+	require.Len(t, sig, bbs.BLS12381SHA256SignatureLen)
+
+	t.Run("valid signature", func(t *testing.T) {
+		require.NoError(t, b.Verify(messages, sig, pubKeyBytes))
+	})
+
+	t.Run("invalid messages", func(t *testing.T) {
+		err := b.Verify([][]byte{messages[1], messages[0], messages[2]}, sig, pubKeyBytes)
+		require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature")
+	})
+
+	t.Run("BBS+ signature is not accepted", func(t *testing.T) {
+		bbsPlusSig, err := bbs.New().Sign(messages, privKeyBytes)
+		require.NoError(t, err)
+
+		err = b.Verify(messages, bbsPlusSig, pubKeyBytes)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		err := b.Verify(messages, sig, []byte("invalid"))
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		_, err := b.Sign(messages, []byte("invalid"))
+		require.EqualError(t, err, "unmarshal private key: invalid size of private key")
+	})
+
+	t.Run("no messages", func(t *testing.T) {
+		_, err := b.Sign(nil, privKeyBytes)
+		require.EqualError(t, err, "messages are not defined")
+	})
+}
+
+func TestBLS12381SHA256_DeriveProof(t *testing.T) {
+	pubKey, privKey := generateSHA256KeyPair(t)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	messages := [][]byte{
+		[]byte("message1"),
+		[]byte("message2"),
+		[]byte("message3"),
+		[]byte("message4"),
+	}
+
+	b := bbs.NewBLS12381SHA256()
+
+	sig, err := b.Sign(messages, privKeyBytes)
+	require.NoError(t, err)
+
+	nonce := []byte("nonce")
+	revealedIndexes := []int{0, 2}
+	revealedMessages := [][]byte{messages[0], messages[2]}
+
+	proof, err := b.DeriveProof(messages, sig, nonce, pubKeyBytes, revealedIndexes)
+	require.NoError(t, err)
+
+	t.Run("valid proof", func(t *testing.T) {
+		require.NoError(t, b.VerifyProof(revealedMessages, proof, nonce, pubKeyBytes))
+	})
+
+	t.Run("another nonce", func(t *testing.T) {
+		err := b.VerifyProof(revealedMessages, proof, []byte("other nonce"), pubKeyBytes)
+		require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature proof")
+	})
+
+	t.Run("wrong revealed message", func(t *testing.T) {
+		err := b.VerifyProof([][]byte{messages[0], messages[1]}, proof, nonce, pubKeyBytes)
+		require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature proof")
+	})
+
+	t.Run("revealed messages count mismatch", func(t *testing.T) {
+		err := b.VerifyProof(messages, proof, nonce, pubKeyBytes)
+		require.EqualError(t, err, "revealed messages do not match the proof")
+	})
+
+	t.Run("truncated proof", func(t *testing.T) {
+		err := b.VerifyProof(revealedMessages, proof[:len(proof)-1], nonce, pubKeyBytes)
+		require.EqualError(t, err, "parse signature proof: invalid size of proof")
+	})
+
+	t.Run("another public key", func(t *testing.T) {
+		otherPubKey, _ := generateSHA256KeyPair(t)
+
+		otherPubKeyBytes, err := otherPubKey.Marshal()
+		require.NoError(t, err)
+
+		err = b.VerifyProof(revealedMessages, proof, nonce, otherPubKeyBytes)
+		require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature proof")
+	})
+
+	t.Run("reveal all messages", func(t *testing.T) {
+		allProof, err := b.DeriveProof(messages, sig, nonce, pubKeyBytes, []int{0, 1, 2, 3})
+		require.NoError(t, err)
+
+		require.NoError(t, b.VerifyProof(messages, allProof, nonce, pubKeyBytes))
+	})
+
+	t.Run("no message to reveal", func(t *testing.T) {
+		_, err := b.DeriveProof(messages, sig, nonce, pubKeyBytes, nil)
+		require.EqualError(t, err, "no message to reveal")
+	})
+
+	t.Run("invalid revealed index", func(t *testing.T) {
+		_, err := b.DeriveProof(messages, sig, nonce, pubKeyBytes, []int{4})
+		require.EqualError(t, err, "invalid revealed index 4")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := b.DeriveProof(messages, sig[1:], nonce, pubKeyBytes, revealedIndexes)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+	})
+}
+
+func TestBLS12381SHA256_DeriveProofWithHeader(t *testing.T) {
+	sk := decodeHex(t, sha256SKHex)
+	pk := decodeHex(t, sha256PKHex)
+	header := decodeHex(t, sha256HeaderHex)
+	messages := [][]byte{decodeHex(t, sha256MsgHex), []byte("second message")}
+	ph := []byte("presentation header")
+
+	b := bbs.NewBLS12381SHA256()
+
+	sig, err := b.SignWithHeader(header, messages, sk)
+	require.NoError(t, err)
+
+	proof, err := b.DeriveProofWithHeader(header, ph, messages, sig, pk, []int{1})
+	require.NoError(t, err)
+
+	require.NoError(t, b.VerifyProofWithHeader(header, ph, messages[1:], proof, pk, len(messages), []int{1}))
+
+	err = b.VerifyProofWithHeader(nil, ph, messages[1:], proof, pk, len(messages), []int{1})
+	require.EqualError(t, err, "invalid BLS12-381-SHA-256 signature proof")
+
+	err = b.VerifyProofWithHeader(header, ph, messages[1:], proof, pk, len(messages), []int{0})
+	require.Error(t, err)
+}
+
+func TestGenerateKeyPairBLS12381SHA256(t *testing.T) {
+	keyMaterial := make([]byte, 32)
+
+	_, err := rand.Read(keyMaterial)
+	require.NoError(t, err)
+
+	pubKey, privKey, err := bbs.GenerateKeyPairBLS12381SHA256(keyMaterial, []byte("key info"))
+	require.NoError(t, err)
+	require.Equal(t, privKey.PublicKey(), pubKey)
+
+	samePubKey, _, err := bbs.GenerateKeyPairBLS12381SHA256(keyMaterial, []byte("key info"))
+	require.NoError(t, err)
+	require.Equal(t, pubKey, samePubKey)
+
+	_, _, err = bbs.GenerateKeyPairBLS12381SHA256(keyMaterial[:31], nil)
+	require.EqualError(t, err, "key material is too short")
+
+	_, _, err = bbs.GenerateKeyPairBLS12381SHA256(keyMaterial, make([]byte, 65536))
+	require.EqualError(t, err, "key info is too long")
+}
+
+func generateSHA256KeyPair(t *testing.T) (*bbs.PublicKey, *bbs.PrivateKey) {
+	t.Helper()
+
+	keyMaterial := make([]byte, 32)
+
+	_, err := rand.Read(keyMaterial)
+	require.NoError(t, err)
+
+	pubKey, privKey, err := bbs.GenerateKeyPairBLS12381SHA256(keyMaterial, nil)
+	require.NoError(t, err)
+
+	return pubKey, privKey
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
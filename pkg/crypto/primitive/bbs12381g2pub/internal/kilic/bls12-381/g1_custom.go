@@ -28,3 +28,9 @@ func HashToCurve(msg, domain []byte, hashFunc func() hash.Hash) ([]byte, error)
 	ClearCofactor(p0)
 	return ToBytes(Affine(p0)), nil
 }
+
+// ExpandMsgXMD implements expand_message_xmd as defined in the hash-to-curve specification using the given hash
+// function.
+func ExpandMsgXMD(hashFunc func() hash.Hash, msg, domain []byte, outLen int) ([]byte, error) {
+	return expandMsgXMD(hashFunc, msg, domain, outLen)
+}
@@ -303,6 +303,7 @@ func (t *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, recipientKH int
 }
 
 // SignMulti will create a BBS+ signature of messages using the signer's private key in signerKH handle.
+// The BBS scheme (BBS+ or the BLS12-381-SHA-256 ciphersuite of the IETF BBS draft) is selected by the key parameters.
 // returns:
 // 		signature in []byte
 //		error in case of errors
@@ -25,6 +25,7 @@ import (
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
@@ -767,3 +768,42 @@ func TestBBSCrypto_SignVerify_DeriveProofVerifyProof(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestBBSCrypto_BLS12381SHA256(t *testing.T) {
+	c := Crypto{}
+	msg := [][]byte{[]byte(testMessage + "0"), []byte(testMessage + "1"), []byte(testMessage + "2")}
+
+	kh, err := keyset.NewHandle(bbs.BLS12381G2SHA256KeyTemplate())
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	s, err := c.SignMulti(msg, kh)
+	require.NoError(t, err)
+	require.Len(t, s, bbs12381g2pub.BLS12381SHA256SignatureLen)
+
+	require.NoError(t, c.VerifyMulti(msg, s, pubKH))
+
+	nonce := make([]byte, 32)
+
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	proof, err := c.DeriveProof(msg, s, nonce, []int{1}, pubKH)
+	require.NoError(t, err)
+
+	require.NoError(t, c.VerifyProof([][]byte{msg[1]}, proof, nonce, pubKH))
+
+	err = c.VerifyProof([][]byte{msg[0]}, proof, nonce, pubKH)
+	require.Error(t, err)
+
+	// a BBS+ key does not verify a BLS12-381-SHA-256 signature.
+	bbsPlusKH, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	bbsPlusPubKH, err := bbsPlusKH.Public()
+	require.NoError(t, err)
+
+	require.Error(t, c.VerifyMulti(msg, s, bbsPlusPubKH))
+}
@@ -16,16 +16,26 @@ import (
 
 // BLS12381G2KeyTemplate creates a Tink key template for BBS+ on BLS12-381 curve with G2 group.
 func BLS12381G2KeyTemplate() *tinkpb.KeyTemplate {
-	return createKeyTemplate(bbspb.BBSCurveType_BLS12_381, bbspb.GroupField_G2, commonpb.HashType_SHA256)
+	return createKeyTemplate(bbspb.BBSCurveType_BLS12_381, bbspb.GroupField_G2, commonpb.HashType_SHA256,
+		bbspb.BBSCiphersuite_BBS_PLUS)
+}
+
+// BLS12381G2SHA256KeyTemplate creates a Tink key template for BBS signatures using the BLS12-381-SHA-256
+// ciphersuite of the IETF BBS draft.
+func BLS12381G2SHA256KeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(bbspb.BBSCurveType_BLS12_381, bbspb.GroupField_G2, commonpb.HashType_SHA256,
+		bbspb.BBSCiphersuite_BLS12_381_SHA_256)
 }
 
 // createKeyTemplate for BBS+ keys.
-func createKeyTemplate(curve bbspb.BBSCurveType, group bbspb.GroupField, hash commonpb.HashType) *tinkpb.KeyTemplate {
+func createKeyTemplate(curve bbspb.BBSCurveType, group bbspb.GroupField, hash commonpb.HashType,
+	ciphersuite bbspb.BBSCiphersuite) *tinkpb.KeyTemplate {
 	format := &bbspb.BBSKeyFormat{
 		Params: &bbspb.BBSParams{
-			HashType: hash,
-			Curve:    curve,
-			Group:    group,
+			HashType:    hash,
+			Curve:       curve,
+			Group:       group,
+			Ciphersuite: ciphersuite,
 		},
 	}
 
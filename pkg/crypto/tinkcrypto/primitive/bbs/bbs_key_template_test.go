@@ -11,11 +11,22 @@ import (
 	"testing"
 
 	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/stretchr/testify/require"
 )
 
 func TestBBSKeyTemplateSuccess(t *testing.T) {
-	kt := BLS12381G2KeyTemplate()
+	t.Run("BBS+", func(t *testing.T) {
+		testKeyTemplate(t, BLS12381G2KeyTemplate())
+	})
+
+	t.Run("BLS12-381-SHA-256", func(t *testing.T) {
+		testKeyTemplate(t, BLS12381G2SHA256KeyTemplate())
+	})
+}
+
+func testKeyTemplate(t *testing.T, kt *tinkpb.KeyTemplate) {
+	t.Helper()
 
 	kh, err := keyset.NewHandle(kt)
 	require.NoError(t, err)
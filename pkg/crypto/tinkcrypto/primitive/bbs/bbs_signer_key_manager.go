@@ -60,6 +60,10 @@ func (km *bbsSignerKeyManager) Primitive(serializedKey []byte) (interface{}, err
 		return nil, fmt.Errorf(errInvalidBBSSignerKey.Error()+": %w", err)
 	}
 
+	if key.PublicKey.Params.Ciphersuite == bbspb.BBSCiphersuite_BLS12_381_SHA_256 {
+		return bbssubtle.NewBLS12381SHA256Signer(key.KeyValue), nil
+	}
+
 	return bbssubtle.NewBLS12381G2Signer(key.KeyValue), nil
 }
 
@@ -96,9 +100,14 @@ func (km *bbsSignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message
 			return nil, err
 		}
 
-		hFunc := subtle.GetHashFunc(keyFormat.Params.HashType.String())
+		if keyFormat.Params.Ciphersuite == bbspb.BBSCiphersuite_BLS12_381_SHA_256 {
+			pubKey, privKey, err = bbs12381g2pub.GenerateKeyPairBLS12381SHA256(seed, nil)
+		} else {
+			hFunc := subtle.GetHashFunc(keyFormat.Params.HashType.String())
+
+			pubKey, privKey, err = bbs12381g2pub.GenerateKeyPair(hFunc, seed)
+		}
 
-		pubKey, privKey, err = bbs12381g2pub.GenerateKeyPair(hFunc, seed)
 		if err != nil {
 			return nil, err
 		}
@@ -212,5 +221,15 @@ func validateKeyParams(params *bbspb.BBSParams) error {
 		return fmt.Errorf("unsupported hash type '%s'", params.HashType)
 	}
 
+	switch params.Ciphersuite {
+	case bbspb.BBSCiphersuite_BBS_PLUS:
+	case bbspb.BBSCiphersuite_BLS12_381_SHA_256:
+		if params.Group != bbspb.GroupField_G2 || params.HashType != commonpb.HashType_SHA256 {
+			return fmt.Errorf("ciphersuite '%s' requires G2 group and SHA256 hash type", params.Ciphersuite)
+		}
+	default:
+		return fmt.Errorf("bad ciphersuite '%s'", params.Ciphersuite)
+	}
+
 	return nil
 }
@@ -53,6 +53,10 @@ func (km *bbsVerifierKeyManager) Primitive(serializedKey []byte) (interface{}, e
 		return nil, errInvalidBBSVerifierKey
 	}
 
+	if bbsPubKey.Params.Ciphersuite == bbspb.BBSCiphersuite_BLS12_381_SHA_256 {
+		return subtle.NewBLS12381SHA256Verifier(bbsPubKey.KeyValue), nil
+	}
+
 	return subtle.NewBLS12381G2Verifier(bbsPubKey.KeyValue), nil
 }
 
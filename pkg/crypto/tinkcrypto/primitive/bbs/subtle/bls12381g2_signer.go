@@ -15,7 +15,11 @@ import (
 // Other BBS+ signers can be added later if needed.
 type BLS12381G2Signer struct {
 	privateKeyBytes []byte
-	bbsPrimitive    *bbs12381g2pub.BBSG2Pub
+	bbsPrimitive    bbsSigner
+}
+
+type bbsSigner interface {
+	Sign(messages [][]byte, privKeyBytes []byte) ([]byte, error)
 }
 
 // NewBLS12381G2Signer creates a new instance of BLS12381G2Signer with the provided privateKey.
@@ -26,6 +30,15 @@ func NewBLS12381G2Signer(privateKey []byte) *BLS12381G2Signer {
 	}
 }
 
+// NewBLS12381SHA256Signer creates a new instance of BLS12381G2Signer with the provided privateKey using the
+// BLS12-381-SHA-256 ciphersuite of the IETF BBS draft instead of BBS+.
+func NewBLS12381SHA256Signer(privateKey []byte) *BLS12381G2Signer {
+	return &BLS12381G2Signer{
+		privateKeyBytes: privateKey,
+		bbsPrimitive:    bbs12381g2pub.NewBLS12381SHA256(),
+	}
+}
+
 // Sign will sign create signature of each message and aggregate it into a single signature using the signer's
 // private key.
 // returns:
@@ -15,7 +15,13 @@ import (
 // Other BBS+ verifiers can be added later if needed.
 type BLS12381G2Verifier struct {
 	signerPubKeyBytes []byte
-	bbsPrimitive      *bbs12381g2pub.BBSG2Pub
+	bbsPrimitive      bbsVerifier
+}
+
+type bbsVerifier interface {
+	Verify(messages [][]byte, sigBytes, pubKeyBytes []byte) error
+	VerifyProof(messages [][]byte, proof, nonce, pubKeyBytes []byte) error
+	DeriveProof(messages [][]byte, sigBytes, nonce, pubKeyBytes []byte, revealedIndexes []int) ([]byte, error)
 }
 
 // NewBLS12381G2Verifier creates a new instance of BLS12381G2Verifier with the provided signerPublicKey.
@@ -26,6 +32,15 @@ func NewBLS12381G2Verifier(signerPublicKey []byte) *BLS12381G2Verifier {
 	}
 }
 
+// NewBLS12381SHA256Verifier creates a new instance of BLS12381G2Verifier with the provided signerPublicKey using the
+// BLS12-381-SHA-256 ciphersuite of the IETF BBS draft instead of BBS+.
+func NewBLS12381SHA256Verifier(signerPublicKey []byte) *BLS12381G2Verifier {
+	return &BLS12381G2Verifier{
+		signerPubKeyBytes: signerPublicKey,
+		bbsPrimitive:      bbs12381g2pub.NewBLS12381SHA256(),
+	}
+}
+
 // Verify will verify an aggregated signature of one or more messages against the signer's public key.
 // returns:
 // 		error in case of errors or nil if signature verification was successful
@@ -121,14 +121,61 @@ func (GroupField) EnumDescriptor() ([]byte, []int) {
 	return file_proto_bbs_proto_rawDescGZIP(), []int{1}
 }
 
+type BBSCiphersuite int32
+
+const (
+	BBSCiphersuite_BBS_PLUS          BBSCiphersuite = 0
+	BBSCiphersuite_BLS12_381_SHA_256 BBSCiphersuite = 1
+)
+
+// Enum value maps for BBSCiphersuite.
+var (
+	BBSCiphersuite_name = map[int32]string{
+		0: "BBS_PLUS",
+		1: "BLS12_381_SHA_256",
+	}
+	BBSCiphersuite_value = map[string]int32{
+		"BBS_PLUS":          0,
+		"BLS12_381_SHA_256": 1,
+	}
+)
+
+func (x BBSCiphersuite) Enum() *BBSCiphersuite {
+	p := new(BBSCiphersuite)
+	*p = x
+	return p
+}
+
+func (x BBSCiphersuite) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BBSCiphersuite) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bbs_proto_enumTypes[2].Descriptor()
+}
+
+func (BBSCiphersuite) Type() protoreflect.EnumType {
+	return &file_proto_bbs_proto_enumTypes[2]
+}
+
+func (x BBSCiphersuite) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BBSCiphersuite.Descriptor instead.
+func (BBSCiphersuite) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bbs_proto_rawDescGZIP(), []int{2}
+}
+
 type BBSParams struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HashType common_go_proto.HashType `protobuf:"varint,1,opt,name=hash_type,json=hashType,proto3,enum=google.crypto.tink.HashType" json:"hash_type,omitempty"`
-	Curve    BBSCurveType             `protobuf:"varint,2,opt,name=curve,proto3,enum=google.crypto.tink.BBSCurveType" json:"curve,omitempty"`
-	Group    GroupField               `protobuf:"varint,3,opt,name=group,proto3,enum=google.crypto.tink.GroupField" json:"group,omitempty"`
+	HashType    common_go_proto.HashType `protobuf:"varint,1,opt,name=hash_type,json=hashType,proto3,enum=google.crypto.tink.HashType" json:"hash_type,omitempty"`
+	Curve       BBSCurveType             `protobuf:"varint,2,opt,name=curve,proto3,enum=google.crypto.tink.BBSCurveType" json:"curve,omitempty"`
+	Group       GroupField               `protobuf:"varint,3,opt,name=group,proto3,enum=google.crypto.tink.GroupField" json:"group,omitempty"`
+	Ciphersuite BBSCiphersuite           `protobuf:"varint,4,opt,name=ciphersuite,proto3,enum=google.crypto.tink.BBSCiphersuite" json:"ciphersuite,omitempty"`
 }
 
 func (x *BBSParams) Reset() {
@@ -184,6 +231,13 @@ func (x *BBSParams) GetGroup() GroupField {
 	return GroupField_UNKNOWN_GROUP_FIELD
 }
 
+func (x *BBSParams) GetCiphersuite() BBSCiphersuite {
+	if x != nil {
+		return x.Ciphersuite
+	}
+	return BBSCiphersuite_BBS_PLUS
+}
+
 type BBSPublicKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -363,7 +417,7 @@ var file_proto_bbs_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x62, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x12, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
 	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x1a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb4, 0x01, 0x0a, 0x09, 0x42, 0x42,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfa, 0x01, 0x0a, 0x09, 0x42, 0x42,
 	0x53, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x68, 0x5f,
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e,
@@ -375,44 +429,51 @@ var file_proto_bbs_proto_rawDesc = []byte{
 	0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x47,
 	0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
-	0x22, 0x7c, 0x0a, 0x0c, 0x42, 0x42, 0x53, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
-	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x06, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e,
-	0x42, 0x42, 0x53, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x87,
-	0x01, 0x0a, 0x0d, 0x42, 0x42, 0x53, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79,
-	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x12, 0x44, 0x0a, 0x0b, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x73, 0x75, 0x69, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42, 0x53, 0x43, 0x69,
+	0x70, 0x68, 0x65, 0x72, 0x73, 0x75, 0x69, 0x74, 0x65, 0x52, 0x0b, 0x63, 0x69, 0x70, 0x68, 0x65,
+	0x72, 0x73, 0x75, 0x69, 0x74, 0x65, 0x22, 0x7c, 0x0a, 0x0c, 0x42, 0x42, 0x53, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x35, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42, 0x53, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x0d, 0x42, 0x42, 0x53, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42, 0x53, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x45,
+	0x0a, 0x0c, 0x42, 0x42, 0x53, 0x4b, 0x65, 0x79, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x35,
+	0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74,
-	0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42, 0x53, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
-	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6b,
-	0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x45, 0x0a, 0x0c, 0x42, 0x42, 0x53, 0x4b,
-	0x65, 0x79, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x35, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61,
-	0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42,
-	0x53, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2a,
-	0x39, 0x0a, 0x0c, 0x42, 0x42, 0x53, 0x43, 0x75, 0x72, 0x76, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x42, 0x42, 0x53, 0x5f, 0x43,
-	0x55, 0x52, 0x56, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x42,
-	0x4c, 0x53, 0x31, 0x32, 0x5f, 0x33, 0x38, 0x31, 0x10, 0x01, 0x2a, 0x35, 0x0a, 0x0a, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x10,
-	0x00, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x31, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x32, 0x10,
-	0x02, 0x42, 0x87, 0x01, 0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x5c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2f, 0x61, 0x72, 0x69,
-	0x65, 0x73, 0x2d, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x67, 0x6f, 0x2f,
-	0x70, 0x6b, 0x67, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x6e, 0x6b, 0x63,
-	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x62, 0x73, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0xa2, 0x02, 0x06, 0x54, 0x49, 0x4e, 0x4b, 0x50, 0x42, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x69, 0x6e, 0x6b, 0x2e, 0x42, 0x42, 0x53, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x2a, 0x39, 0x0a, 0x0c, 0x42, 0x42, 0x53, 0x43, 0x75, 0x72, 0x76,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x5f, 0x42, 0x42, 0x53, 0x5f, 0x43, 0x55, 0x52, 0x56, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x5f, 0x33, 0x38, 0x31, 0x10, 0x01,
+	0x2a, 0x35, 0x0a, 0x0a, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x17,
+	0x0a, 0x13, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f,
+	0x46, 0x49, 0x45, 0x4c, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x31, 0x10, 0x01, 0x12,
+	0x06, 0x0a, 0x02, 0x47, 0x32, 0x10, 0x02, 0x2a, 0x35, 0x0a, 0x0e, 0x42, 0x42, 0x53, 0x43, 0x69,
+	0x70, 0x68, 0x65, 0x72, 0x73, 0x75, 0x69, 0x74, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x42, 0x53,
+	0x5f, 0x50, 0x4c, 0x55, 0x53, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x4c, 0x53, 0x31, 0x32,
+	0x5f, 0x33, 0x38, 0x31, 0x5f, 0x53, 0x48, 0x41, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x01, 0x42, 0x87,
+	0x01, 0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x5c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79,
+	0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2f, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2d,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x6e, 0x6b, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x62, 0x62, 0x73, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0xa2,
+	0x02, 0x06, 0x54, 0x49, 0x4e, 0x4b, 0x50, 0x42, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -427,29 +488,31 @@ func file_proto_bbs_proto_rawDescGZIP() []byte {
 	return file_proto_bbs_proto_rawDescData
 }
 
-var file_proto_bbs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_bbs_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
 var file_proto_bbs_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_bbs_proto_goTypes = []interface{}{
 	(BBSCurveType)(0),             // 0: google.crypto.tink.BBSCurveType
 	(GroupField)(0),               // 1: google.crypto.tink.GroupField
-	(*BBSParams)(nil),             // 2: google.crypto.tink.BBSParams
-	(*BBSPublicKey)(nil),          // 3: google.crypto.tink.BBSPublicKey
-	(*BBSPrivateKey)(nil),         // 4: google.crypto.tink.BBSPrivateKey
-	(*BBSKeyFormat)(nil),          // 5: google.crypto.tink.BBSKeyFormat
-	(common_go_proto.HashType)(0), // 6: google.crypto.tink.HashType
+	(BBSCiphersuite)(0),           // 2: google.crypto.tink.BBSCiphersuite
+	(*BBSParams)(nil),             // 3: google.crypto.tink.BBSParams
+	(*BBSPublicKey)(nil),          // 4: google.crypto.tink.BBSPublicKey
+	(*BBSPrivateKey)(nil),         // 5: google.crypto.tink.BBSPrivateKey
+	(*BBSKeyFormat)(nil),          // 6: google.crypto.tink.BBSKeyFormat
+	(common_go_proto.HashType)(0), // 7: google.crypto.tink.HashType
 }
 var file_proto_bbs_proto_depIdxs = []int32{
-	6, // 0: google.crypto.tink.BBSParams.hash_type:type_name -> google.crypto.tink.HashType
+	7, // 0: google.crypto.tink.BBSParams.hash_type:type_name -> google.crypto.tink.HashType
 	0, // 1: google.crypto.tink.BBSParams.curve:type_name -> google.crypto.tink.BBSCurveType
 	1, // 2: google.crypto.tink.BBSParams.group:type_name -> google.crypto.tink.GroupField
-	2, // 3: google.crypto.tink.BBSPublicKey.params:type_name -> google.crypto.tink.BBSParams
-	3, // 4: google.crypto.tink.BBSPrivateKey.public_key:type_name -> google.crypto.tink.BBSPublicKey
-	2, // 5: google.crypto.tink.BBSKeyFormat.params:type_name -> google.crypto.tink.BBSParams
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	2, // 3: google.crypto.tink.BBSParams.ciphersuite:type_name -> google.crypto.tink.BBSCiphersuite
+	3, // 4: google.crypto.tink.BBSPublicKey.params:type_name -> google.crypto.tink.BBSParams
+	4, // 5: google.crypto.tink.BBSPrivateKey.public_key:type_name -> google.crypto.tink.BBSPublicKey
+	3, // 6: google.crypto.tink.BBSKeyFormat.params:type_name -> google.crypto.tink.BBSParams
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_bbs_proto_init() }
@@ -512,7 +575,7 @@ func file_proto_bbs_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_bbs_proto_rawDesc,
-			NumEnums:      2,
+			NumEnums:      3,
 			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
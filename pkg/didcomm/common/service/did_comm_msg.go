@@ -21,6 +21,8 @@ import (
 const (
 	jsonID             = "@id"
 	jsonType           = "@type"
+	jsonIDV2           = "id"
+	jsonTypeV2         = "type"
 	jsonThread         = "~thread"
 	jsonThreadID       = "thid"
 	jsonParentThreadID = "pthid"
@@ -81,7 +83,7 @@ func ParseDIDCommMsgMap(payload []byte) (DIDCommMsgMap, error) {
 
 	// Interop: accept old PIURI when it's used, as we handle backwards-compatibility at a more fine-grained level.
 	if typ := msg.Type(); typ != "" {
-		msg[jsonType] = strings.Replace(typ, oldPIURI, basePIURI, 1)
+		msg[msg.typeKey()] = strings.Replace(typ, oldPIURI, basePIURI, 1)
 	}
 
 	return msg, nil
@@ -101,6 +103,7 @@ func NewDIDCommMsgMap(v interface{}) DIDCommMsgMap {
 
 // ThreadID returns msg ~thread.thid if there is no ~thread.thid returns msg @id
 // message is invalid if ~thread.thid exist and @id is absent.
+// The thread of the DIDComm v2 messages is given by their thid.
 func (m DIDCommMsgMap) ThreadID() (string, error) {
 	if m == nil {
 		return "", ErrInvalidMessage
@@ -109,6 +112,10 @@ func (m DIDCommMsgMap) ThreadID() (string, error) {
 	msgID := m.ID()
 	thread, ok := m[jsonThread].(map[string]interface{})
 
+	if m.isDIDCommV2() {
+		thread, ok = m, true
+	}
+
 	if ok && thread[jsonThreadID] != nil {
 		var thID string
 		if v, ok := thread[jsonThreadID].(string); ok {
@@ -147,13 +154,13 @@ func (m DIDCommMsgMap) Metadata() map[string]interface{} {
 	return metadata
 }
 
-// Type returns the message type.
+// Type returns the message type, the `type` of the DIDComm v2 messages or the `@type` of the others.
 func (m DIDCommMsgMap) Type() string {
-	if m == nil || m[jsonType] == nil {
+	if m == nil || m[m.typeKey()] == nil {
 		return ""
 	}
 
-	res, ok := m[jsonType].(string)
+	res, ok := m[m.typeKey()].(string)
 	if !ok {
 		return ""
 	}
@@ -176,13 +183,13 @@ func (m DIDCommMsgMap) ParentThreadID() string {
 	return ""
 }
 
-// ID returns the message id.
+// ID returns the message id, the `id` of the DIDComm v2 messages or the `@id` of the others.
 func (m DIDCommMsgMap) ID() string {
-	if m == nil || m[jsonID] == nil {
+	if m == nil || m[m.idKey()] == nil {
 		return ""
 	}
 
-	res, ok := m[jsonID].(string)
+	res, ok := m[m.idKey()].(string)
 	if !ok {
 		return ""
 	}
@@ -196,11 +203,35 @@ func (m DIDCommMsgMap) SetID(id string) error {
 		return ErrNilMessage
 	}
 
-	m[jsonID] = id
+	m[m.idKey()] = id
 
 	return nil
 }
 
+// isDIDCommV2 tells whether the message is a DIDComm v2 message, identified by its `type` in place of `@type`.
+func (m DIDCommMsgMap) isDIDCommV2() bool {
+	_, hasType := m[jsonType]
+	_, hasTypeV2 := m[jsonTypeV2]
+
+	return hasTypeV2 && !hasType
+}
+
+func (m DIDCommMsgMap) typeKey() string {
+	if m.isDIDCommV2() {
+		return jsonTypeV2
+	}
+
+	return jsonType
+}
+
+func (m DIDCommMsgMap) idKey() string {
+	if m.isDIDCommV2() {
+		return jsonIDV2
+	}
+
+	return jsonID
+}
+
 // Decode converts message to  struct.
 func (m DIDCommMsgMap) Decode(v interface{}) error {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
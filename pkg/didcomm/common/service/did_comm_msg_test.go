@@ -38,6 +38,11 @@ func TestDIDCommMsgMap_ID(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonID: "ID"},
 			expected: "ID",
 		},
+		{
+			name:     "Success (DIDComm v2)",
+			msg:      DIDCommMsgMap{jsonIDV2: "ID", jsonTypeV2: "Type"},
+			expected: "ID",
+		},
 	}
 
 	for i := range tests {
@@ -58,6 +63,12 @@ func TestDIDCommMsgMap_SetID(t *testing.T) {
 
 	require.NoError(t, m.SetID(ID))
 	require.Equal(t, ID, m.ID())
+
+	m = DIDCommMsgMap{jsonTypeV2: "Type"}
+
+	require.NoError(t, m.SetID(ID))
+	require.Equal(t, ID, m[jsonIDV2])
+	require.Equal(t, ID, m.ID())
 }
 
 func TestDIDCommMsgMap_MetaData(t *testing.T) {
@@ -114,6 +125,16 @@ func TestDIDCommMsgMap_Type(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonType: "Type"},
 			expected: "Type",
 		},
+		{
+			name:     "Success (DIDComm v2)",
+			msg:      DIDCommMsgMap{jsonTypeV2: "Type"},
+			expected: "Type",
+		},
+		{
+			name:     "Success (type in body of the message)",
+			msg:      DIDCommMsgMap{jsonType: "Type", jsonTypeV2: "BodyType"},
+			expected: "Type",
+		},
 	}
 
 	for i := range tests {
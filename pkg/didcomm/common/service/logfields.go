@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+// LogFields returns the thread ID and message type fields of msg for structured logs, for instance:
+//
+//	logger.With(service.LogFields(msg)...).Debugf("message received")
+//
+// The thread ID is omitted if msg has none.
+func LogFields(msg DIDCommMsg) []interface{} {
+	if msg == nil {
+		return nil
+	}
+
+	fields := []interface{}{log.FieldMessageType, msg.Type()}
+
+	if thid, err := msg.ThreadID(); err == nil && thid != "" {
+		fields = append(fields, log.FieldThreadID, thid)
+	}
+
+	return fields
+}
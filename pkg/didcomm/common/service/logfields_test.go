@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+func TestLogFields(t *testing.T) {
+	t.Run("thread", func(t *testing.T) {
+		msg := NewDIDCommMsgMap(struct {
+			ID     string `json:"@id"`
+			Type   string `json:"@type"`
+			Thread struct {
+				ID string `json:"thid"`
+			} `json:"~thread"`
+		}{ID: "id-1", Type: "https://didcomm.org/trust_ping/1.0/ping", Thread: struct {
+			ID string `json:"thid"`
+		}{ID: "thid-1"}})
+
+		require.Equal(t, []interface{}{
+			log.FieldMessageType, "https://didcomm.org/trust_ping/1.0/ping",
+			log.FieldThreadID, "thid-1",
+		}, LogFields(msg))
+	})
+
+	t.Run("no thread", func(t *testing.T) {
+		msg := DIDCommMsgMap{"@type": "https://didcomm.org/trust_ping/1.0/ping"}
+
+		require.Equal(t, []interface{}{log.FieldMessageType, "https://didcomm.org/trust_ping/1.0/ping"}, LogFields(msg))
+	})
+
+	t.Run("nil message", func(t *testing.T) {
+		require.Nil(t, LogFields(nil))
+	})
+}
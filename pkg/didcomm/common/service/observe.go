@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	gocontext "context"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+// maxStateSpans bounds the number of state spans waiting for their PostState message, since a failing state may
+// never send one.
+const maxStateSpans = 10000
+
+//nolint:gochecknoglobals
+var (
+	stateTransitions = metrics.NewCounter("aries_protocol_state_transitions_total",
+		"Number of states entered by the protocol services.", "protocol", "state")
+
+	stateSpansMu sync.Mutex
+	stateSpans   = map[string]*tracing.Span{}
+)
+
+// ObserveStateMsg records the state transition notified by msg in the metrics and the traces. Protocol services
+// call it for each state message they send, whether or not a client registered for these messages. The span of a
+// state covers its execution, from its PreState to its PostState message, and joins the trace of the thread of
+// the protocol message.
+func ObserveStateMsg(msg *StateMsg) {
+	var thid string
+
+	if msg.Msg != nil {
+		thid, _ = msg.Msg.ThreadID() //nolint:errcheck
+	}
+
+	key := msg.ProtocolName + "/" + thid + "/" + msg.StateID
+
+	switch msg.Type {
+	case PreState:
+		stateTransitions.Inc(msg.ProtocolName, msg.StateID)
+
+		_, span := tracing.Start(gocontext.Background(), "protocol.state",
+			tracing.Attr("protocol", msg.ProtocolName), tracing.Attr("state", msg.StateID))
+		if span == nil {
+			return
+		}
+
+		span.SetThreadID(thid)
+
+		stateSpansMu.Lock()
+		defer stateSpansMu.Unlock()
+
+		if prev, ok := stateSpans[key]; ok {
+			prev.End()
+		} else if len(stateSpans) >= maxStateSpans {
+			return
+		}
+
+		stateSpans[key] = span
+	case PostState:
+		stateSpansMu.Lock()
+		span := stateSpans[key]
+		delete(stateSpans, key)
+		stateSpansMu.Unlock()
+
+		span.End()
+	}
+}
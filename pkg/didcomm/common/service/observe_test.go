@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+type spanRecorder struct {
+	spans []*tracing.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(span *tracing.SpanData) {
+	r.spans = append(r.spans, span)
+}
+
+func TestObserveStateMsg(t *testing.T) {
+	r := &spanRecorder{}
+
+	tracing.SetExporter(r)
+	defer tracing.SetExporter(nil)
+
+	msg := NewDIDCommMsgMap(struct {
+		ID     string                 `json:"@id"`
+		Type   string                 `json:"@type"`
+		Thread map[string]interface{} `json:"~thread"`
+	}{ID: "id", Type: "type", Thread: map[string]interface{}{"thid": "thid"}})
+
+	ObserveStateMsg(&StateMsg{ProtocolName: "test-protocol", Type: PreState, StateID: "request-sent", Msg: msg})
+	require.Empty(t, r.spans)
+
+	ObserveStateMsg(&StateMsg{ProtocolName: "test-protocol", Type: PostState, StateID: "request-sent", Msg: msg})
+	require.Len(t, r.spans, 1)
+	require.Equal(t, "protocol.state", r.spans[0].Name)
+	require.Equal(t, tracing.ThreadTraceID("thid"), r.spans[0].TraceID)
+	require.Contains(t, r.spans[0].Attributes, tracing.Attr("state", "request-sent"))
+
+	// a PostState message without a PreState one is ignored
+	ObserveStateMsg(&StateMsg{ProtocolName: "test-protocol", Type: PostState, StateID: "done"})
+	require.Len(t, r.spans, 1)
+	require.Empty(t, stateSpans)
+
+	var buf bytes.Buffer
+
+	_, err := metrics.Default().WriteTo(&buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(),
+		`aries_protocol_state_transitions_total{protocol="test-protocol",state="request-sent"} 1`)
+}
@@ -201,6 +201,16 @@ func TestDIDCommMsg_ThreadID(t *testing.T) {
 		msg:  DIDCommMsgMap{jsonThread: map[string]interface{}{jsonThreadID: "thID"}},
 		val:  "",
 		err:  ErrInvalidMessage.Error(),
+	}, {
+		name: "DIDComm v2 ID without Thread ID",
+		msg:  DIDCommMsgMap{jsonIDV2: "ID", jsonTypeV2: "type"},
+		val:  "ID",
+		err:  "",
+	}, {
+		name: "DIDComm v2 Thread ID with ID",
+		msg:  DIDCommMsgMap{jsonIDV2: "ID", jsonTypeV2: "type", jsonThreadID: "thID"},
+		val:  "thID",
+		err:  "",
 	}, {
 		name: "No Thread ID and ID",
 		msg:  DIDCommMsgMap{},
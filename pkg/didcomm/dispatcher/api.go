@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 )
 
@@ -37,3 +39,17 @@ type Outbound interface {
 	// Forward forwards the message without packing to the destination.
 	Forward(interface{}, *service.Destination) error
 }
+
+// Drainer is implemented by the dispatchers and protocol services holding in-flight work. The framework drains them
+// on close, before the storage providers are closed.
+type Drainer interface {
+	// Drain refuses the new work and waits for the in-flight work to complete and its state to be persisted, or for
+	// ctx to be done.
+	Drain(ctx context.Context) error
+}
+
+// MessageTypeProvider is implemented by the protocol and message services listing the message types they handle,
+// the features disclosed to the other agents being built from them.
+type MessageTypeProvider interface {
+	MessageTypes() []string
+}
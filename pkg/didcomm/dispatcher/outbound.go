@@ -7,12 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -24,6 +30,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+//nolint:gochecknoglobals
+var (
+	logger = log.New("aries-framework/didcomm/dispatcher")
+
+	outboundDuration = metrics.NewHistogram("aries_dispatcher_outbound_duration_seconds",
+		"Time spent to pack and send an outbound message.", nil, "status")
+)
+
 // provider interface for outbound ctx.
 type provider interface {
 	Packager() transport.Packager
@@ -48,6 +62,7 @@ type OutboundDispatcher struct {
 	vdRegistry           vdr.Registry
 	kms                  kms.KeyManager
 	connections          connectionLookup
+	inFlight             shutdown.Tracker
 }
 
 // NewOutbound return new dispatcher outbound instance.
@@ -72,6 +87,13 @@ func NewOutbound(prov provider) (*OutboundDispatcher, error) {
 
 // SendToDID sends a message from myDID to the agent who owns theirDID.
 func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
+	done, err := o.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.SendToDID: %w", err)
+	}
+
+	defer done()
+
 	connID, err := o.connections.GetConnectionIDByDIDs(myDID, theirDID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch connection ID for myDID=%s theirDID=%s: %w", myDID, theirDID, err)
@@ -102,12 +124,46 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 	// TODO: relies on hardcoded key type
 	key := src.RecipientKeys[0]
 
-	return o.Send(msg, key, dest)
+	return o.observedSend(logger.With(log.FieldConnectionID, connID), msg, key, dest)
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-// nolint:gocyclo
 func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *service.Destination) error {
+	done, err := o.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: %w", err)
+	}
+
+	defer done()
+
+	return o.observedSend(logger, msg, senderVerKey, des)
+}
+
+func (o *OutboundDispatcher) observedSend(msgLogger *log.Log, msg interface{}, senderVerKey string,
+	des *service.Destination) error {
+	start := time.Now()
+	_, span := tracing.Start(context.Background(), "dispatcher.Send")
+
+	if m, ok := msg.(service.DIDCommMsg); ok {
+		msgLogger = msgLogger.With(service.LogFields(m)...)
+	}
+
+	err := o.send(span, msgLogger, msg, senderVerKey, des)
+	if err != nil {
+		msgLogger.Debugf("failed to send message: %s", err)
+	}
+
+	span.RecordError(err)
+	span.End()
+
+	outboundDuration.Observe(time.Since(start).Seconds(), metrics.Status(err))
+
+	return err
+}
+
+// nolint:gocyclo
+func (o *OutboundDispatcher) send(span *tracing.Span, msgLogger *log.Log, msg interface{}, senderVerKey string,
+	des *service.Destination) error {
 	for _, v := range o.outboundTransports {
 		// check if outbound accepts routing keys, else use recipient keys
 		keys := des.RecipientKeys
@@ -126,6 +182,8 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
 		}
 
+		span.SetThreadID(tracing.ThreadID(req))
+
 		// update the outbound message with transport return route option [all or thread]
 		req, err = o.addTransportRouteOptions(req, des)
 		if err != nil {
@@ -160,6 +218,8 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 		}
 
+		msgLogger.Debugf("sent message to %s", des.ServiceEndpoint)
+
 		return nil
 	}
 
@@ -168,6 +228,13 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 
 // Forward forwards the message without packing to the destination.
 func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
+	done, err := o.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: %w", err)
+	}
+
+	defer done()
+
 	for _, v := range o.outboundTransports {
 		if !v.AcceptRecipient(des.RecipientKeys) {
 			if !v.Accept(des.ServiceEndpoint) {
@@ -191,6 +258,15 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
 }
 
+// Drain refuses the new messages and waits for the messages being sent, or for ctx to be done.
+func (o *OutboundDispatcher) Drain(ctx context.Context) error {
+	if err := o.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("outboundDispatcher.Drain: %w", err)
+	}
+
+	return nil
+}
+
 func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
 	if len(des.RoutingKeys) == 0 {
 		return msg, nil
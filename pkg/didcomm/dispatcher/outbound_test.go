@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -171,6 +173,28 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_Drain(t *testing.T) {
+	o, err := NewOutbound(&mockProvider{
+		packagerValue:           &mockpackager.Packager{},
+		outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+		storageProvider:         mockstore.NewMockStoreProvider(),
+		protoStorageProvider:    mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"}))
+	require.NoError(t, o.Drain(context.Background()))
+
+	err = o.Send("data", mockdiddoc.MockDIDKey(t), &service.Destination{ServiceEndpoint: "url"})
+	require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+
+	err = o.SendToDID("data", "myDID", "theirDID")
+	require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+
+	err = o.Forward("data", &service.Destination{ServiceEndpoint: "url"})
+	require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+}
+
 func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	mockDoc := mockdiddoc.GetMockDIDDoc(t)
 
@@ -16,6 +16,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -27,6 +29,9 @@ const (
 	jsonThread         = "~thread"
 	jsonThreadID       = "thid"
 	jsonParentThreadID = "pthid"
+	jsonService        = "~service"
+
+	connectionlessPrefix = "connectionless_"
 )
 
 // record is an internal structure and keeps payload about inbound message.
@@ -37,16 +42,26 @@ type record struct {
 	ParentThreadID string `json:"parent_thread_id,omitempty"`
 }
 
+// connectionlessRecord keeps the service decorators of a thread exchanged without a connection.
+type connectionlessRecord struct {
+	MyService    *decorator.Service `json:"my_service,omitempty"`
+	TheirService *decorator.Service `json:"their_service,omitempty"`
+}
+
 // Provider contains dependencies for the Messenger.
 type Provider interface {
 	OutboundDispatcher() dispatcher.Outbound
 	StorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
 }
 
 // Messenger describes the messenger structure.
 type Messenger struct {
 	store      storage.Store
 	dispatcher dispatcher.Outbound
+	// ctx provides the KMS and service endpoint of the connectionless exchanges, only needed by those.
+	ctx Provider
 }
 
 var logger = log.New("aries-framework/pkg/didcomm/messenger")
@@ -61,6 +76,7 @@ func NewMessenger(ctx Provider) (*Messenger, error) {
 	return &Messenger{
 		store:      store,
 		dispatcher: ctx.OutboundDispatcher(),
+		ctx:        ctx,
 	}, nil
 }
 
@@ -91,6 +107,9 @@ func (m *Messenger) HandleInbound(msg service.DIDCommMsgMap, ctx service.DIDComm
 // Send sends the message by starting a new thread.
 // Do not provide a message with ~thread decorator. It will be removed.
 // Use ReplyTo function instead. It will keep ~thread decorator automatically.
+//
+// A message without DIDs but with a ~service decorator starts a connectionless thread: the message is delivered
+// out-of-band by the caller, the messenger only keeps its ~service decorator to reply in the thread.
 func (m *Messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
 	// fills missing fields
 	fillIfMissing(msg)
@@ -99,6 +118,15 @@ func (m *Messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) erro
 		jsonThreadID: msg.ID(),
 	}
 
+	if myDID == "" && theirDID == "" && msg[jsonService] != nil {
+		myService, err := getService(msg)
+		if err != nil {
+			return err
+		}
+
+		return m.saveConnectionlessRecord(msg.ID(), &connectionlessRecord{MyService: myService})
+	}
+
 	return m.dispatcher.SendToDID(msg, myDID, theirDID)
 }
 
@@ -145,6 +173,9 @@ func (m *Messenger) ReplyTo(msgID string, msg service.DIDCommMsgMap) error {
 // ReplyToMsg replies to the given message.
 // The function adds ~thread decorator to the message according to the given msgID.
 // Do not provide a message with ~thread decorator. It will be rewritten.
+//
+// A message received without a connection, with no DIDs, is replied to the ~service decorator it carries. The reply
+// carries the ~service decorator of this agent in the thread.
 func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
 	// fills missing fields
 	fillIfMissing(out)
@@ -166,6 +197,15 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 
 	out[jsonThread] = thread
 
+	if myDID == "" && theirDID == "" && in[jsonService] != nil {
+		theirService, err := getService(in)
+		if err != nil {
+			return err
+		}
+
+		return m.replyConnectionless(out, thID, theirService)
+	}
+
 	return m.dispatcher.SendToDID(out, myDID, theirDID)
 }
 
@@ -184,9 +224,54 @@ func (m *Messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.Neste
 	// sets parent threadID
 	msg[jsonThread] = map[string]interface{}{jsonParentThreadID: opts.ThreadID}
 
+	if opts.MyDID == "" && opts.TheirDID == "" {
+		rec, err := m.getConnectionlessRecord(opts.ThreadID)
+		if err == nil && rec.TheirService != nil {
+			return m.replyConnectionless(msg, opts.ThreadID, rec.TheirService)
+		}
+	}
+
 	return m.dispatcher.SendToDID(msg, opts.MyDID, opts.TheirDID)
 }
 
+// replyConnectionless sends msg to their service decorator, with the service decorator of this agent in the thread.
+// The first reply of a thread creates the decorator of this agent, with a new key.
+func (m *Messenger) replyConnectionless(msg service.DIDCommMsgMap, thID string, theirService *decorator.Service) error {
+	if len(theirService.RecipientKeys) == 0 {
+		return errors.New("connectionless reply: no recipient keys in the service decorator")
+	}
+
+	rec, err := m.getConnectionlessRecord(thID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		rec, err = &connectionlessRecord{}, nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("connectionless reply: get record: %w", err)
+	}
+
+	if rec.MyService == nil {
+		rec.MyService, err = decorator.NewService(m.ctx.KMS(), m.ctx.ServiceEndpoint())
+		if err != nil {
+			return fmt.Errorf("connectionless reply: %w", err)
+		}
+	}
+
+	rec.TheirService = theirService
+
+	if err = m.saveConnectionlessRecord(thID, rec); err != nil {
+		return fmt.Errorf("connectionless reply: %w", err)
+	}
+
+	msg[jsonService] = rec.MyService
+
+	return m.dispatcher.Send(msg, rec.MyService.RecipientKeys[0], &service.Destination{
+		RecipientKeys:   theirService.RecipientKeys,
+		RoutingKeys:     theirService.RoutingKeys,
+		ServiceEndpoint: theirService.ServiceEndpoint,
+	})
+}
+
 // fillIfMissing populates message with common fields such as ID.
 func fillIfMissing(msg service.DIDCommMsgMap) {
 	// if ID is empty we will create a new one
@@ -220,6 +305,46 @@ func (m *Messenger) saveRecord(msgID string, rec record) error {
 	return m.store.Put(msgID, src)
 }
 
+// getService returns the ~service decorator of the message.
+func getService(msg service.DIDCommMsgMap) (*decorator.Service, error) {
+	raw, err := json.Marshal(msg[jsonService])
+	if err != nil {
+		return nil, fmt.Errorf("marshal service decorator: %w", err)
+	}
+
+	var s *decorator.Service
+	if err = json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal service decorator: %w", err)
+	}
+
+	return s, nil
+}
+
+// getConnectionlessRecord returns the service decorators of a connectionless thread.
+func (m *Messenger) getConnectionlessRecord(thID string) (*connectionlessRecord, error) {
+	src, err := m.store.Get(connectionlessPrefix + thID)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *connectionlessRecord
+	if err = json.Unmarshal(src, &r); err != nil {
+		return nil, fmt.Errorf("unmarshal connectionless record: %w", err)
+	}
+
+	return r, nil
+}
+
+// saveConnectionlessRecord saves the service decorators of a connectionless thread.
+func (m *Messenger) saveConnectionlessRecord(thID string, rec *connectionlessRecord) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal connectionless record: %w", err)
+	}
+
+	return m.store.Put(connectionlessPrefix+thID, src)
+}
+
 // fillNestedReplyOption prefills missing nested reply options from record.
 func (m *Messenger) fillNestedReplyOption(opts *service.NestedReplyOpts) error {
 	if opts.ThreadID != "" && opts.TheirDID != "" && opts.MyDID != "" {
@@ -14,11 +14,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
 	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 )
 
 const (
@@ -410,3 +412,138 @@ func TestMessenger_ReplyToMsg(t *testing.T) {
 		}, service.DIDCommMsgMap{}, "", ""), "get threadID: invalid message")
 	})
 }
+
+func TestMessenger_Connectionless(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const endpoint = "http://agent.example.com"
+
+	theirService := &decorator.Service{
+		RecipientKeys:   []string{"did:key:z6MkjtX1z1n1Tp3bZ9aYaLm6BBW7tZtU8oUQfsRGKBg2vyVQ"},
+		RoutingKeys:     []string{"did:key:z6MkfGmaSTUwzjKfHeJFpcZJ8dCeaT4XKtUSGm89eHkVqWuE"},
+		ServiceEndpoint: "http://their.example.com",
+	}
+
+	newMessenger := func(t *testing.T, outbound *dispatcherMocks.MockOutbound) *Messenger {
+		t.Helper()
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+		provider.EXPECT().KMS().
+			Return(&mockkms.KeyManager{CrAndExportPubKeyValue: []byte("0123456789abcdef0123456789abcdef")}).AnyTimes()
+		provider.EXPECT().ServiceEndpoint().Return(endpoint).AnyTimes()
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+
+		return msgr
+	}
+
+	t.Run("send keeps the service decorator of the thread", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+
+		msgr := newMessenger(t, outbound)
+
+		myService := &decorator.Service{RecipientKeys: []string{"did:key:mine"}, ServiceEndpoint: endpoint}
+
+		msg := service.DIDCommMsgMap{jsonID: ID, jsonService: myService}
+		require.NoError(t, msgr.Send(msg, "", ""))
+
+		rec, err := msgr.getConnectionlessRecord(ID)
+		require.NoError(t, err)
+		require.Equal(t, myService, rec.MyService)
+		require.Nil(t, rec.TheirService)
+
+		// the replies to the thread are sent with the key of the service decorator
+		outbound.EXPECT().Send(gomock.Any(), "did:key:mine", gomock.Any()).Return(nil)
+
+		require.NoError(t, msgr.ReplyToMsg(service.DIDCommMsgMap{
+			jsonID:      "presentation",
+			jsonThread:  map[string]interface{}{jsonThreadID: ID},
+			jsonService: theirService,
+		}, service.DIDCommMsgMap{}, "", ""))
+	})
+
+	t.Run("reply to the service decorator", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+
+		var sender string
+
+		outbound.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(msg interface{}, senderVerKey string, des *service.Destination) error {
+				out, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+
+				// the problem reports are nested replies
+				thID := out.ParentThreadID()
+				if thID == "" {
+					thID, _ = out.ThreadID() // nolint: errcheck
+				}
+
+				require.Equal(t, ID, thID)
+
+				myService, err := getService(out)
+				require.NoError(t, err)
+				require.Equal(t, endpoint, myService.ServiceEndpoint)
+				require.Equal(t, []string{senderVerKey}, myService.RecipientKeys)
+
+				require.Equal(t, theirService.RecipientKeys, des.RecipientKeys)
+				require.Equal(t, theirService.RoutingKeys, des.RoutingKeys)
+				require.Equal(t, theirService.ServiceEndpoint, des.ServiceEndpoint)
+
+				// the replies of a thread are sent with the same key
+				if sender != "" {
+					require.Equal(t, sender, senderVerKey)
+				}
+
+				sender = senderVerKey
+
+				return nil
+			})
+
+		msgr := newMessenger(t, outbound)
+
+		// the request, received out-of-band
+		in := service.DIDCommMsgMap{jsonID: ID, jsonService: theirService}
+
+		require.NoError(t, msgr.ReplyToMsg(in, service.DIDCommMsgMap{}, "", ""))
+		require.NoError(t, msgr.ReplyToNested(service.DIDCommMsgMap{}, &service.NestedReplyOpts{ThreadID: ID}))
+	})
+
+	t.Run("reply without recipient keys", func(t *testing.T) {
+		msgr := newMessenger(t, dispatcherMocks.NewMockOutbound(ctrl))
+
+		err := msgr.ReplyToMsg(service.DIDCommMsgMap{
+			jsonID:      ID,
+			jsonService: &decorator.Service{ServiceEndpoint: endpoint},
+		}, service.DIDCommMsgMap{}, "", "")
+		require.EqualError(t, err, "connectionless reply: no recipient keys in the service decorator")
+	})
+
+	t.Run("reply with an invalid service decorator", func(t *testing.T) {
+		msgr := newMessenger(t, dispatcherMocks.NewMockOutbound(ctrl))
+
+		err := msgr.ReplyToMsg(service.DIDCommMsgMap{
+			jsonID:      ID,
+			jsonService: "service",
+		}, service.DIDCommMsgMap{}, "", "")
+		require.Contains(t, err.Error(), "unmarshal service decorator")
+	})
+
+	t.Run("reply with a key creation error", func(t *testing.T) {
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+		provider.EXPECT().OutboundDispatcher().Return(dispatcherMocks.NewMockOutbound(ctrl))
+		provider.EXPECT().KMS().Return(&mockkms.KeyManager{CrAndExportPubKeyErr: errors.New(errMsg)})
+		provider.EXPECT().ServiceEndpoint().Return(endpoint)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+
+		err = msgr.ReplyToMsg(service.DIDCommMsgMap{jsonID: ID, jsonService: theirService},
+			service.DIDCommMsgMap{}, "", "")
+		require.Contains(t, err.Error(), errMsg)
+	})
+}
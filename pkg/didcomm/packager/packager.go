@@ -7,12 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package packager
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -23,6 +27,10 @@ import (
 
 const authSuffix = "-authcrypt"
 
+//nolint:gochecknoglobals
+var unpackDuration = metrics.NewHistogram("aries_packager_unpack_duration_seconds",
+	"Time spent to unpack an inbound message.", nil, "status")
+
 // Provider contains dependencies for the base packager and is typically created by using aries.Context().
 type Provider interface {
 	Packers() []packer.Packer
@@ -176,6 +184,23 @@ func getEncodingType(encMessage []byte) (string, error) {
 
 // UnpackMessage Unpack a message.
 func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	start := time.Now()
+	_, span := tracing.Start(context.Background(), "packager.UnpackMessage")
+
+	envelope, err := bp.unpackMessage(encMessage)
+	if err == nil {
+		span.SetThreadID(tracing.ThreadID(envelope.Message))
+	}
+
+	span.RecordError(err)
+	span.End()
+
+	unpackDuration.Observe(time.Since(start).Seconds(), metrics.Status(err))
+
+	return envelope, err
+}
+
+func (bp *Packager) unpackMessage(encMessage []byte) (*transport.Envelope, error) {
 	encType, err := getEncodingType(encMessage)
 	if err != nil {
 		return nil, fmt.Errorf("getEncodingType: %w", err)
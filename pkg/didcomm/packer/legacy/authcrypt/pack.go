@@ -162,6 +162,16 @@ func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []b
 }
 
 func newCryptoBox(manager kms.KeyManager) (kms.CryptoBox, error) {
+	// the crypto box needs the concrete KMS, not a decorator of it
+	for {
+		d, ok := manager.(interface{ Unwrap() kms.KeyManager })
+		if !ok {
+			break
+		}
+
+		manager = d.Unwrap()
+	}
+
 	switch manager.(type) {
 	case *localkms.LocalKMS:
 		return localkms.NewCryptoBox(manager)
@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Menu is the menu of actions offered by an agent to the agent at the other end of the connection.
+type Menu struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ErrorMsg    string            `json:"errormsg,omitempty"`
+	Options     []MenuOption      `json:"options"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// MenuOption is an action of the menu.
+type MenuOption struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Form        *Form  `json:"form,omitempty"`
+}
+
+// Form describes the parameters requested to perform a menu option.
+type Form struct {
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Params      []FormParam `json:"params,omitempty"`
+	SubmitLabel string      `json:"submit-label,omitempty"`
+}
+
+// FormParam is a parameter of a form.
+type FormParam struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// MenuRequest requests the agent at the other end of the connection to send its current menu.
+type MenuRequest struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// Perform requests the agent that sent the menu to perform one of its options.
+type Perform struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
@@ -0,0 +1,663 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// ActionMenu defines the protocol name.
+	ActionMenu = "action-menu"
+	// PIURI is the action menu protocol's protocol instance URI.
+	PIURI = "https://didcomm.org/action-menu/1.0"
+	// MenuMsgType defines the menu message type.
+	MenuMsgType = PIURI + "/menu"
+	// MenuRequestMsgType defines the menu-request message type.
+	MenuRequestMsgType = PIURI + "/menu-request"
+	// PerformMsgType defines the perform message type.
+	PerformMsgType = PIURI + "/perform"
+
+	// StateMenuReceived is the state ID of the message events sent when a menu is received.
+	StateMenuReceived = "menu-received"
+	// StatePerformRequested is the state ID of the message events sent when a perform request is received.
+	StatePerformRequested = "perform-requested"
+
+	myMenuKey    = "mymenu_%s"
+	theirMenuKey = "theirmenu_%s"
+	// actionKey is the key of the perform requests, by connection ID and perform message ID.
+	actionKey = "action_%s_%s"
+	// actionTag tags the perform requests with their PIID.
+	actionTag = "actionmenu_action"
+)
+
+var (
+	// ErrMenuNotFound is returned when no menu is known for the connection.
+	ErrMenuNotFound = errors.New("menu not found")
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+
+	logger = log.New("aries-framework/actionmenu")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
+}
+
+// Action contains helpful information about a perform request waiting to be handled.
+type Action struct {
+	// Protocol instance ID
+	PIID         string
+	Msg          service.DIDCommMsgMap
+	ProtocolName string
+	ConnectionID string
+	MyDID        string
+	TheirDID     string
+}
+
+// Opt describes option signature for the Continue function.
+type Opt func(opts *continueOpts)
+
+type continueOpts struct {
+	menu *Menu
+}
+
+// WithMenu publishes the menu once the perform request is handled, the actions offered to the other agent changing
+// as a result of the performed one.
+func WithMenu(menu *Menu) Opt {
+	return func(opts *continueOpts) {
+		opts.menu = menu
+	}
+}
+
+// Service for the action menu protocol.
+type Service struct {
+	service.Action
+	service.Message
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	menuStore        storage.Store
+	actionStore      storage.Store
+	inFlight         shutdown.Tracker
+}
+
+// New returns the action menu service.
+func New(prov provider) (*Service, error) {
+	menuStore, err := prov.StorageProvider().OpenStore(ActionMenu)
+	if err != nil {
+		return nil, fmt.Errorf("open action menu store : %w", err)
+	}
+
+	actionStore, err := prov.ProtocolStateStorageProvider().OpenStore(ActionMenu)
+	if err != nil {
+		return nil, fmt.Errorf("open action menu protocol state store : %w", err)
+	}
+
+	err = prov.ProtocolStateStorageProvider().SetStoreConfig(ActionMenu,
+		storage.StoreConfiguration{TagNames: []string{actionTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set action menu protocol state store config : %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		menuStore:        menuStore,
+		actionStore:      actionStore,
+	}, nil
+}
+
+// HandleInbound handles the action menu messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if !s.Accept(msg.Type()) {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	connectionID, err := s.connectionLookup.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", err, ErrConnectionNotFound)
+	}
+
+	switch msg.Type() {
+	case MenuMsgType:
+		return "", s.handleMenu(msg, connectionID)
+	case MenuRequestMsgType:
+		return "", s.handleMenuRequest(connectionID, ctx)
+	default:
+		return "", s.handlePerform(msg, connectionID, ctx)
+	}
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case MenuMsgType, MenuRequestMsgType, PerformMsgType:
+		return true
+	}
+
+	return false
+}
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{MenuMsgType, MenuRequestMsgType, PerformMsgType}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return ActionMenu
+}
+
+// Drain refuses the new perform requests and waits for the dispatch of the action events in flight, or for ctx to
+// be done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", ActionMenu, err)
+	}
+
+	return nil
+}
+
+// PublishMenu makes the menu the current menu offered over the connection and sends it to the other agent.
+// The menu is kept as the current one even if it can't be sent, a menu request getting it later.
+func (s *Service) PublishMenu(connectionID string, menu *Menu) (*Menu, error) {
+	if err := validateMenu(menu); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	published := *menu
+	published.Type = MenuMsgType
+	published.ID = uuid.New().String()
+	published.Thread = nil
+
+	if err = s.putMenu(fmt.Sprintf(myMenuKey, connectionID), &published); err != nil {
+		return nil, err
+	}
+
+	if err = s.outbound.SendToDID(&published, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send menu: %w", err)
+	}
+
+	return &published, nil
+}
+
+// MyMenu returns the current menu offered over the connection.
+func (s *Service) MyMenu(connectionID string) (*Menu, error) {
+	return s.getMenu(fmt.Sprintf(myMenuKey, connectionID))
+}
+
+// TheirMenu returns the last menu received over the connection.
+func (s *Service) TheirMenu(connectionID string) (*Menu, error) {
+	return s.getMenu(fmt.Sprintf(theirMenuKey, connectionID))
+}
+
+// RequestMenu requests the agent at the other end of the connection to send its current menu.
+func (s *Service) RequestMenu(connectionID string) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	request := &MenuRequest{Type: MenuRequestMsgType, ID: uuid.New().String()}
+
+	if err = s.outbound.SendToDID(request, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send menu request: %w", err)
+	}
+
+	return nil
+}
+
+// Perform requests the agent at the other end of the connection to perform an option of the last menu received
+// from it, the params being the values of the option form.
+func (s *Service) Perform(connectionID, name string, params map[string]string) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	menu, err := s.TheirMenu(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err = validatePerform(menu, name, params); err != nil {
+		return err
+	}
+
+	perform := &Perform{
+		Type:   PerformMsgType,
+		ID:     uuid.New().String(),
+		Name:   name,
+		Params: params,
+		Thread: &decorator.Thread{ID: menu.ID},
+	}
+
+	if err = s.outbound.SendToDID(perform, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send perform: %w", err)
+	}
+
+	return nil
+}
+
+// Actions returns the perform requests waiting to be handled.
+func (s *Service) Actions() ([]Action, error) {
+	records, err := s.actionStore.Query(actionTag)
+	if err != nil {
+		return nil, fmt.Errorf("query actions: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	actions := []Action{}
+
+	for {
+		more, err := records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("actions iterator next: %w", err)
+		}
+
+		if !more {
+			return actions, nil
+		}
+
+		value, err := records.Value()
+		if err != nil {
+			return nil, fmt.Errorf("actions iterator value: %w", err)
+		}
+
+		var action Action
+
+		if err = json.Unmarshal(value, &action); err != nil {
+			return nil, fmt.Errorf("unmarshal action: %w", err)
+		}
+
+		actions = append(actions, action)
+	}
+}
+
+// ActionContinue marks the perform request with the piID as handled, publishing the menu given with WithMenu if any.
+func (s *Service) ActionContinue(piID string, opt Opt) error {
+	key, action, err := s.getAction(piID)
+	if err != nil {
+		return err
+	}
+
+	opts := &continueOpts{}
+
+	if opt != nil {
+		opt(opts)
+	}
+
+	if opts.menu != nil {
+		if _, err = s.PublishMenu(action.ConnectionID, opts.menu); err != nil {
+			return err
+		}
+	}
+
+	if err = s.actionStore.Delete(key); err != nil {
+		return fmt.Errorf("delete action: %w", err)
+	}
+
+	return nil
+}
+
+// ActionStop declines the perform request with the piID. When a cause is given, the current menu is sent back with
+// it as error message.
+func (s *Service) ActionStop(piID string, cause error) error {
+	key, action, err := s.getAction(piID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.actionStore.Delete(key); err != nil {
+		return fmt.Errorf("delete action: %w", err)
+	}
+
+	if cause == nil {
+		return nil
+	}
+
+	menu, err := s.MyMenu(action.ConnectionID)
+	if err != nil {
+		return err
+	}
+
+	menu.ErrorMsg = cause.Error()
+
+	if err = s.outbound.SendToDID(menu, action.MyDID, action.TheirDID); err != nil {
+		return fmt.Errorf("send menu: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleMenu(msg service.DIDCommMsg, connectionID string) error {
+	menu := &Menu{}
+
+	if err := msg.Decode(menu); err != nil {
+		return fmt.Errorf("menu unmarshal: %w", err)
+	}
+
+	if err := s.putMenu(fmt.Sprintf(theirMenuKey, connectionID), menu); err != nil {
+		return err
+	}
+
+	s.sendMsgEvent(StateMenuReceived, msg, &eventProps{ConnID: connectionID})
+
+	return nil
+}
+
+func (s *Service) handleMenuRequest(connectionID string, ctx service.DIDCommContext) error {
+	menu, err := s.MyMenu(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.outbound.SendToDID(menu, ctx.MyDID(), ctx.TheirDID()); err != nil {
+		return fmt.Errorf("send menu: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handlePerform(msg service.DIDCommMsg, connectionID string, ctx service.DIDCommContext) error {
+	perform := &Perform{}
+
+	if err := msg.Decode(perform); err != nil {
+		return fmt.Errorf("perform unmarshal: %w", err)
+	}
+
+	if perform.Name == "" {
+		return errors.New("perform without menu option name")
+	}
+
+	// only the options of the menu published to the connection are performed
+	menu, err := s.MyMenu(connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err = validatePerform(menu, perform.Name, perform.Params); err != nil {
+		return err
+	}
+
+	events := s.ActionEvent()
+	if events == nil {
+		return fmt.Errorf("no clients registered to handle action events for %s protocol", ActionMenu)
+	}
+
+	msgMap, ok := msg.(service.DIDCommMsgMap)
+	if !ok {
+		msgMap = service.NewDIDCommMsgMap(perform)
+	}
+
+	action := &Action{
+		PIID:         uuid.New().String(),
+		Msg:          msgMap.Clone(),
+		ProtocolName: ActionMenu,
+		ConnectionID: connectionID,
+		MyDID:        ctx.MyDID(),
+		TheirDID:     ctx.TheirDID(),
+	}
+
+	msgID := msg.ID()
+	if msgID == "" {
+		msgID = action.PIID
+	}
+
+	// the message IDs are only unique to the connection they are received from
+	key := fmt.Sprintf(actionKey, connectionID, msgID)
+
+	_, err = s.actionStore.Get(key)
+	if err == nil {
+		return fmt.Errorf("perform %s already received", msgID)
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get action: %w", err)
+	}
+
+	actionBytes, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("marshal action: %w", err)
+	}
+
+	err = s.actionStore.Put(key, actionBytes, storage.Tag{Name: actionTag, Value: action.PIID})
+	if err != nil {
+		return fmt.Errorf("store action: %w", err)
+	}
+
+	props := &eventProps{ConnID: connectionID, PIID: action.PIID, Name: perform.Name, Params: perform.Params}
+
+	s.sendMsgEvent(StatePerformRequested, action.Msg, props)
+
+	err = s.inFlight.Go(func() {
+		events <- service.DIDCommAction{
+			ProtocolName: ActionMenu,
+			Message:      action.Msg,
+			Continue: func(args interface{}) {
+				opt, _ := args.(Opt)
+
+				if err := s.ActionContinue(action.PIID, opt); err != nil {
+					logger.Errorf("continue perform %s: %s", action.PIID, err)
+				}
+			},
+			Stop: func(cause error) {
+				if err := s.ActionStop(action.PIID, cause); err != nil {
+					logger.Errorf("stop perform %s: %s", action.PIID, err)
+				}
+			},
+			Properties: props,
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("dispatch perform action: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) sendMsgEvent(stateID string, msg service.DIDCommMsg, props service.EventProperties) {
+	stateMsg := service.StateMsg{
+		ProtocolName: ActionMenu,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Msg:          msg,
+		Properties:   props,
+	}
+
+	for _, handler := range s.MsgEvents() {
+		go func(handler chan<- service.StateMsg) {
+			handler <- stateMsg
+		}(handler)
+	}
+}
+
+// getAction returns the perform request with the piID and its key.
+func (s *Service) getAction(piID string) (string, *Action, error) {
+	records, err := s.actionStore.Query(actionTag + ":" + piID)
+	if err != nil {
+		return "", nil, fmt.Errorf("get action %s: %w", piID, err)
+	}
+
+	defer storage.Close(records, logger)
+
+	more, err := records.Next()
+	if err != nil {
+		return "", nil, fmt.Errorf("get action %s: %w", piID, err)
+	}
+
+	if !more {
+		return "", nil, fmt.Errorf("get action %s: %w", piID, storage.ErrDataNotFound)
+	}
+
+	key, err := records.Key()
+	if err != nil {
+		return "", nil, fmt.Errorf("get action %s: %w", piID, err)
+	}
+
+	actionBytes, err := records.Value()
+	if err != nil {
+		return "", nil, fmt.Errorf("get action %s: %w", piID, err)
+	}
+
+	action := &Action{}
+
+	if err = json.Unmarshal(actionBytes, action); err != nil {
+		return "", nil, fmt.Errorf("unmarshal action: %w", err)
+	}
+
+	return key, action, nil
+}
+
+func (s *Service) putMenu(key string, menu *Menu) error {
+	menuBytes, err := json.Marshal(menu)
+	if err != nil {
+		return fmt.Errorf("marshal menu: %w", err)
+	}
+
+	if err = s.menuStore.Put(key, menuBytes); err != nil {
+		return fmt.Errorf("store menu: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getMenu(key string) (*Menu, error) {
+	menuBytes, err := s.menuStore.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrMenuNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get menu: %w", err)
+	}
+
+	menu := &Menu{}
+
+	if err = json.Unmarshal(menuBytes, menu); err != nil {
+		return nil, fmt.Errorf("unmarshal menu: %w", err)
+	}
+
+	return menu, nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func validateMenu(menu *Menu) error {
+	if menu == nil {
+		return errors.New("menu is required")
+	}
+
+	names := make(map[string]bool, len(menu.Options))
+
+	for _, option := range menu.Options {
+		if option.Name == "" {
+			return errors.New("menu option without name")
+		}
+
+		if names[option.Name] {
+			return fmt.Errorf("duplicate menu option %s", option.Name)
+		}
+
+		names[option.Name] = true
+	}
+
+	return nil
+}
+
+func validatePerform(menu *Menu, name string, params map[string]string) error {
+	for _, option := range menu.Options {
+		if option.Name != name {
+			continue
+		}
+
+		if option.Disabled {
+			return fmt.Errorf("menu option %s is disabled", name)
+		}
+
+		if option.Form == nil {
+			return nil
+		}
+
+		for _, param := range option.Form.Params {
+			if param.Required && param.Default == "" && params[param.Name] == "" {
+				return fmt.Errorf("missing required parameter %s of menu option %s", param.Name, name)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown menu option %s", name)
+}
+
+// eventProps are the properties of the action menu events.
+type eventProps struct {
+	ConnID string
+	PIID   string
+	Name   string
+	Params map[string]string
+}
+
+func (e *eventProps) All() map[string]interface{} {
+	all := map[string]interface{}{"connectionID": e.ConnID}
+
+	if e.PIID != "" {
+		all["piid"] = e.PIID
+		all["name"] = e.Name
+		all["params"] = e.Params
+	}
+
+	return all
+}
@@ -0,0 +1,515 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	myDID    = "sample-my-did"
+	theirDID = "sample-their-did"
+	connID   = "conn"
+)
+
+func TestService(t *testing.T) {
+	t.Run("new service", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.Equal(t, ActionMenu, svc.Name())
+		require.True(t, svc.Accept(MenuMsgType))
+		require.True(t, svc.Accept(MenuRequestMsgType))
+		require.True(t, svc.Accept(PerformMsgType))
+		require.False(t, svc.Accept("https://didcomm.org/action-menu/1.0/unknown"))
+		require.Equal(t, []string{MenuMsgType, MenuRequestMsgType, PerformMsgType}, svc.MessageTypes())
+
+		_, err := svc.HandleOutbound(nil, myDID, theirDID)
+		require.EqualError(t, err, "not implemented")
+	})
+
+	t.Run("new service - store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.EqualError(t, err, "open action menu store : open error")
+
+		_, err = New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+		})
+		require.EqualError(t, err, "open action menu protocol state store : open error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+			Type string `json:"@type"`
+		}{Type: "unknown"}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("unknown connection", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType}),
+			service.NewDIDCommContext(myDID, "other", nil))
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+}
+
+func TestService_PublishMenu(t *testing.T) {
+	t.Run("publishes the menu", func(t *testing.T) {
+		sent := make(chan interface{}, 2)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, theirDID, their)
+
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		published, err := svc.PublishMenu(connID, sampleMenu())
+		require.NoError(t, err)
+		require.Equal(t, MenuMsgType, published.Type)
+		require.NotEmpty(t, published.ID)
+		require.Equal(t, published, <-sent)
+
+		menu, err := svc.MyMenu(connID)
+		require.NoError(t, err)
+		require.Equal(t, published, menu)
+
+		// the current menu is sent back on request
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType, ID: "request"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, published, <-sent)
+	})
+
+	t.Run("invalid menus", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.PublishMenu(connID, nil)
+		require.EqualError(t, err, "menu is required")
+
+		_, err = svc.PublishMenu(connID, &Menu{Options: []MenuOption{{Title: "no name"}}})
+		require.EqualError(t, err, "menu option without name")
+
+		_, err = svc.PublishMenu(connID, &Menu{Options: []MenuOption{{Name: "a"}, {Name: "a"}}})
+		require.EqualError(t, err, "duplicate menu option a")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.PublishMenu("unknown", sampleMenu())
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		require.ErrorIs(t, svc.RequestMenu("unknown"), ErrConnectionNotFound)
+		require.ErrorIs(t, svc.Perform("unknown", "library-card", nil), ErrConnectionNotFound)
+	})
+
+	t.Run("send error keeps the menu as the current one", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		stubConnection(svc)
+
+		_, err := svc.PublishMenu(connID, sampleMenu())
+		require.EqualError(t, err, "send menu: send error")
+
+		_, err = svc.MyMenu(connID)
+		require.NoError(t, err)
+	})
+
+	t.Run("menu request without menu", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.MyMenu(connID)
+		require.ErrorIs(t, err, ErrMenuNotFound)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.ErrorIs(t, err, ErrMenuNotFound)
+	})
+}
+
+func TestService_TheirMenu(t *testing.T) {
+	t.Run("stores the received menu and performs its options", func(t *testing.T) {
+		sent := make(chan interface{}, 2)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		states := make(chan service.StateMsg, 1)
+		require.NoError(t, svc.RegisterMsgEvent(states))
+
+		menu := sampleMenu()
+		menu.Type = MenuMsgType
+		menu.ID = "menu-id"
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(menu), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		state := <-states
+		require.Equal(t, StateMenuReceived, state.StateID)
+		require.Equal(t, connID, state.Properties.All()["connectionID"])
+
+		received, err := svc.TheirMenu(connID)
+		require.NoError(t, err)
+		require.Equal(t, menu, received)
+
+		require.NoError(t, svc.RequestMenu(connID))
+
+		request, ok := (<-sent).(*MenuRequest)
+		require.True(t, ok)
+		require.Equal(t, MenuRequestMsgType, request.Type)
+
+		require.NoError(t, svc.Perform(connID, "library-card", map[string]string{"name": "Alice"}))
+
+		perform, ok := (<-sent).(*Perform)
+		require.True(t, ok)
+		require.Equal(t, PerformMsgType, perform.Type)
+		require.Equal(t, "library-card", perform.Name)
+		require.Equal(t, "Alice", perform.Params["name"])
+		require.Equal(t, "menu-id", perform.Thread.ID)
+	})
+
+	t.Run("invalid perform", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		require.ErrorIs(t, svc.Perform(connID, "library-card", nil), ErrMenuNotFound)
+
+		require.NoError(t, svc.putMenu("theirmenu_"+connID, sampleMenu()))
+
+		require.EqualError(t, svc.Perform(connID, "unknown", nil), "unknown menu option unknown")
+		require.EqualError(t, svc.Perform(connID, "closed", nil), "menu option closed is disabled")
+		require.EqualError(t, svc.Perform(connID, "library-card", nil),
+			"missing required parameter name of menu option library-card")
+		require.NoError(t, svc.Perform(connID, "prove-age", nil))
+	})
+
+	t.Run("send errors", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		stubConnection(svc)
+
+		require.NoError(t, svc.putMenu("theirmenu_"+connID, sampleMenu()))
+
+		require.EqualError(t, svc.RequestMenu(connID), "send menu request: send error")
+		require.EqualError(t, svc.Perform(connID, "prove-age", nil), "send perform: send error")
+	})
+}
+
+func TestService_Perform(t *testing.T) {
+	receivePerform := func(t *testing.T, svc *Service) service.DIDCommAction {
+		t.Helper()
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, connID), sampleMenu()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{
+			Type:   PerformMsgType,
+			ID:     "perform-id",
+			Name:   "library-card",
+			Params: map[string]string{"name": "Alice"},
+			Thread: &decorator.Thread{ID: "menu-id"},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		action := <-actions
+		require.Equal(t, ActionMenu, action.ProtocolName)
+		require.NotEmpty(t, action.Properties.All()["piid"])
+		require.Equal(t, "library-card", action.Properties.All()["name"])
+
+		return action
+	}
+
+	t.Run("continue with a new menu", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		action := receivePerform(t, svc)
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Equal(t, action.Properties.All()["piid"], actions[0].PIID)
+		require.Equal(t, connID, actions[0].ConnectionID)
+
+		action.Continue(WithMenu(&Menu{Title: "Done", Options: []MenuOption{{Name: "back"}}}))
+
+		menu, ok := (<-sent).(*Menu)
+		require.True(t, ok)
+		require.Equal(t, "Done", menu.Title)
+
+		actions, err = svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("continue without menu", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		piID, ok := receivePerform(t, svc).Properties.All()["piid"].(string)
+		require.True(t, ok)
+
+		require.NoError(t, svc.ActionContinue(piID, nil))
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+
+		require.ErrorIs(t, svc.ActionContinue(piID, nil), storage.ErrDataNotFound)
+	})
+
+	t.Run("stop sends the current menu with the error", func(t *testing.T) {
+		sent := make(chan interface{}, 2)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		_, err := svc.PublishMenu(connID, sampleMenu())
+		require.NoError(t, err)
+		<-sent
+
+		action := receivePerform(t, svc)
+		action.Stop(errors.New("library closed"))
+
+		menu, ok := (<-sent).(*Menu)
+		require.True(t, ok)
+		require.Equal(t, "library closed", menu.ErrorMsg)
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("stop without cause", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		piID, ok := receivePerform(t, svc).Properties.All()["piid"].(string)
+		require.True(t, ok)
+
+		require.NoError(t, svc.ActionStop(piID, nil))
+		require.ErrorIs(t, svc.ActionStop(piID, nil), storage.ErrDataNotFound)
+	})
+
+	t.Run("same message ID on two connections", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, connID), sampleMenu()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{
+			Type: PerformMsgType, ID: "perform-id", Name: "prove-age",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		first := <-actions
+
+		// another connection sends a perform with the same message ID
+		other := &connection.Record{ConnectionID: "other", MyDID: "my-other-did", TheirDID: "their-other-did"}
+		svc.connectionLookup = &connectionsStub{record: other}
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, other.ConnectionID), sampleMenu()))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{
+			Type: PerformMsgType, ID: "perform-id", Name: "prove-age",
+		}), service.NewDIDCommContext(other.MyDID, other.TheirDID, nil))
+		require.NoError(t, err)
+
+		second := <-actions
+		require.NotEqual(t, first.Properties.All()["piid"], second.Properties.All()["piid"])
+
+		stored, err := svc.Actions()
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+
+		second.Continue(nil)
+
+		stored, err = svc.Actions()
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, connID, stored[0].ConnectionID)
+
+		// the perform is not received twice from the same connection
+		svc.connectionLookup = &connectionsStub{record: &connection.Record{
+			ConnectionID: connID, MyDID: myDID, TheirDID: theirDID,
+		}}
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{
+			Type: PerformMsgType, ID: "perform-id", Name: "prove-age",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "perform perform-id already received")
+	})
+
+	t.Run("perform not validated against the menu", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType, Name: "library-card"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.ErrorIs(t, err, ErrMenuNotFound)
+
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, connID), sampleMenu()))
+
+		for name, errMsg := range map[string]string{
+			"unknown":      "unknown menu option unknown",
+			"closed":       "menu option closed is disabled",
+			"library-card": "missing required parameter name of menu option library-card",
+		} {
+			_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType, Name: name}),
+				service.NewDIDCommContext(myDID, theirDID, nil))
+			require.EqualError(t, err, errMsg)
+		}
+
+		actions, err := svc.Actions()
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("no action event registered", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, connID), sampleMenu()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType, Name: "prove-age"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "no clients registered to handle action events for action-menu protocol")
+	})
+
+	t.Run("perform without name", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "perform without menu option name")
+	})
+
+	t.Run("refuses the perform requests once draining", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+		require.NoError(t, svc.putMenu(fmt.Sprintf(myMenuKey, connID), sampleMenu()))
+		require.NoError(t, svc.Drain(context.Background()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType, Name: "prove-age"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shutting down")
+	})
+}
+
+func sampleMenu() *Menu {
+	return &Menu{
+		Title:       "Welcome to the library",
+		Description: "Library services",
+		Options: []MenuOption{
+			{
+				Name:  "library-card",
+				Title: "Request library card",
+				Form: &Form{
+					Title:       "Library card",
+					Params:      []FormParam{{Name: "name", Title: "Name", Required: true}},
+					SubmitLabel: "Request",
+				},
+			},
+			{Name: "prove-age", Title: "Prove age"},
+			{Name: "closed", Title: "Closed", Disabled: true},
+		},
+	}
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+	t.Helper()
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func stubConnection(svc *Service) {
+	svc.connectionLookup = &connectionsStub{record: &connection.Record{
+		ConnectionID: connID, MyDID: myDID, TheirDID: theirDID, State: connection.StateNameCompleted,
+	}}
+}
+
+type connectionsStub struct {
+	record *connection.Record
+}
+
+func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, error) {
+	if id != c.record.ConnectionID {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return c.record, nil
+}
+
+func (c *connectionsStub) GetConnectionIDByDIDs(my, their string) (string, error) {
+	if my != c.record.MyDID || their != c.record.TheirDID {
+		return "", storage.ErrDataNotFound
+	}
+
+	return c.record.ConnectionID, nil
+}
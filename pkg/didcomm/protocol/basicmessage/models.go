@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// MessageV1 is the basic message 1.0.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0095-basic-message
+type MessageV1 struct {
+	Type     string            `json:"@type,omitempty"`
+	ID       string            `json:"@id,omitempty"`
+	L10n     *L10n             `json:"~l10n,omitempty"`
+	SentTime time.Time         `json:"sent_time"`
+	Content  string            `json:"content"`
+	Thread   *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// L10n is the localization decorator of the basic message 1.0.
+type L10n struct {
+	Locale string `json:"locale,omitempty"`
+}
+
+// MessageV2 is the basic message 2.0, a DIDComm v2 message, its created time being in seconds since the epoch.
+// https://didcomm.org/basicmessage/2.0/
+type MessageV2 struct {
+	Type        string        `json:"type,omitempty"`
+	ID          string        `json:"id,omitempty"`
+	ThreadID    string        `json:"thid,omitempty"`
+	Lang        string        `json:"lang,omitempty"`
+	CreatedTime int64         `json:"created_time"`
+	Body        MessageV2Body `json:"body"`
+}
+
+// MessageV2Body is the body of the basic message 2.0.
+type MessageV2Body struct {
+	Content string `json:"content"`
+}
+
+// Record is a sent or received basic message of the history.
+type Record struct {
+	// ID is the message ID.
+	ID string `json:"id"`
+	// ConnectionID of the connection the message was exchanged over, empty if unknown.
+	ConnectionID string `json:"connection_id,omitempty"`
+	// MyDID and TheirDID are the DIDs the message was exchanged between.
+	MyDID    string `json:"my_did,omitempty"`
+	TheirDID string `json:"their_did,omitempty"`
+	// Direction is either DirectionSent or DirectionReceived.
+	Direction string `json:"direction"`
+	// Type is the message type, telling the protocol version.
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	Locale  string `json:"locale,omitempty"`
+	// SentTime is the time the message was sent at, as stated by the sender.
+	SentTime time.Time `json:"sent_time"`
+	// ReceivedTime is the time a received message was stored at.
+	ReceivedTime time.Time `json:"received_time,omitempty"`
+	// ReadTime is the time a received message was marked as read at, nil when it is unread.
+	ReadTime *time.Time `json:"read_time,omitempty"`
+}
+
+// History is a page of the message history of a connection.
+type History struct {
+	// Messages of the page.
+	Messages []*Record `json:"messages"`
+	// Total is the number of messages matching the query, regardless of the pagination.
+	Total int `json:"total"`
+}
@@ -0,0 +1,586 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// BasicMessage defines the protocol name.
+	BasicMessage = "basicmessage"
+	// SpecV1 defines the protocol spec V1.
+	SpecV1 = "https://didcomm.org/basicmessage/1.0/"
+	// MessageMsgTypeV1 defines the protocol message type V1.
+	MessageMsgTypeV1 = SpecV1 + "message"
+	// SpecV2 defines the protocol spec V2.
+	SpecV2 = "https://didcomm.org/basicmessage/2.0/"
+	// MessageMsgTypeV2 defines the protocol message type V2.
+	MessageMsgTypeV2 = SpecV2 + "message"
+
+	// DirectionSent is the direction of the messages sent by this agent.
+	DirectionSent = "sent"
+	// DirectionReceived is the direction of the messages received by this agent.
+	DirectionReceived = "received"
+
+	// Namespace is the namespace of the basic message history store.
+	Namespace = "basicmessage"
+
+	connectionTagName = "basicmsg_conn"
+	unreadTagName     = "basicmsg_unread"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+
+	logger = log.New("aries-framework/basicmessage")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// messageServices is implemented by the providers supplying the message services, the received messages being
+// handed to the message services accepting them once stored.
+type messageServices interface {
+	MessageServices() []dispatcher.MessageService
+}
+
+// messengerProvider is implemented by the providers supplying the messenger, the messages handed to the message
+// services going through it first as the ones routed to the message services by the framework.
+type messengerProvider interface {
+	Messenger() service.Messenger
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
+}
+
+// SendOpt configures a sent message.
+type SendOpt func(opts *sendOpts)
+
+type sendOpts struct {
+	specV2 bool
+	locale string
+}
+
+// WithSpecV2 sends the message with the basic message 2.0 protocol.
+func WithSpecV2() SendOpt {
+	return func(opts *sendOpts) {
+		opts.specV2 = true
+	}
+}
+
+// WithLocale sets the locale of the message content.
+func WithLocale(locale string) SendOpt {
+	return func(opts *sendOpts) {
+		opts.locale = locale
+	}
+}
+
+// HistoryOpt configures a history query.
+type HistoryOpt func(opts *historyOpts)
+
+type historyOpts struct {
+	offset      int
+	limit       int
+	newestFirst bool
+	unreadOnly  bool
+}
+
+// WithPage returns the messages from offset on, at most limit of them when limit is positive.
+func WithPage(offset, limit int) HistoryOpt {
+	return func(opts *historyOpts) {
+		opts.offset = offset
+		opts.limit = limit
+	}
+}
+
+// WithNewestFirst orders the history from the newest message, it being ordered from the oldest one by default.
+func WithNewestFirst() HistoryOpt {
+	return func(opts *historyOpts) {
+		opts.newestFirst = true
+	}
+}
+
+// WithUnreadOnly restricts the history to the unread received messages.
+func WithUnreadOnly() HistoryOpt {
+	return func(opts *historyOpts) {
+		opts.unreadOnly = true
+	}
+}
+
+// Service for the basic message protocol, keeping the history of the sent and received messages.
+type Service struct {
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	store            storage.Store
+	messageServices  messageServices
+	messenger        service.MessengerHandler
+	storeLock        sync.Mutex
+	inFlight         shutdown.Tracker
+}
+
+// New returns the basic message service.
+func New(prov provider) (*Service, error) {
+	store, err := prov.StorageProvider().OpenStore(Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("open basic message store : %w", err)
+	}
+
+	err = prov.StorageProvider().SetStoreConfig(Namespace,
+		storage.StoreConfiguration{TagNames: []string{connectionTagName, unreadTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("set basic message store config : %w", err)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		store:            store,
+	}
+
+	if ms, ok := prov.(messageServices); ok {
+		svc.messageServices = ms
+	}
+
+	if mp, ok := prov.(messengerProvider); ok {
+		if messenger, ok := mp.Messenger().(service.MessengerHandler); ok {
+			svc.messenger = messenger
+		}
+	}
+
+	return svc, nil
+}
+
+// HandleInbound stores the received basic message, and hands it to the message service accepting it if any.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if !s.Accept(msg.Type()) {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return "", fmt.Errorf("handle inbound: %w", err)
+	}
+
+	defer done()
+
+	record, err := decodeMessage(msg)
+	if err != nil {
+		return "", err
+	}
+
+	// the messages are stored by ID, a message without one would overwrite the previous one.
+	if record.ID == "" {
+		return "", errors.New("basic message has no id")
+	}
+
+	record.Direction = DirectionReceived
+	record.MyDID = ctx.MyDID()
+	record.TheirDID = ctx.TheirDID()
+	record.ReceivedTime = time.Now().UTC()
+
+	if record.MyDID != "" && record.TheirDID != "" {
+		record.ConnectionID, err = s.connectionLookup.GetConnectionIDByDIDs(record.MyDID, record.TheirDID)
+		if err != nil {
+			logger.Debugf("no connection for the basic message %s: %s", record.ID, err)
+		}
+	}
+
+	if err = s.save(record); err != nil {
+		return "", err
+	}
+
+	return msg.ID(), s.delegate(msg, ctx)
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == MessageMsgTypeV1 || msgType == MessageMsgTypeV2
+}
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{MessageMsgTypeV1, MessageMsgTypeV2}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return BasicMessage
+}
+
+// Drain refuses the new inbound messages and waits for the messages being stored, or for ctx to be done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", BasicMessage, err)
+	}
+
+	return nil
+}
+
+// Send sends the content to the agent at the other end of the connection and stores the sent message.
+func (s *Service) Send(connectionID, content string, opts ...SendOpt) (*Record, error) {
+	options := &sendOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{
+		ID:           uuid.New().String(),
+		ConnectionID: connectionID,
+		MyDID:        conn.MyDID,
+		TheirDID:     conn.TheirDID,
+		Direction:    DirectionSent,
+		Content:      content,
+		Locale:       options.locale,
+		SentTime:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	var msg interface{}
+
+	if options.specV2 {
+		record.Type = MessageMsgTypeV2
+		msg = &MessageV2{
+			Type:        record.Type,
+			ID:          record.ID,
+			Lang:        record.Locale,
+			CreatedTime: record.SentTime.Unix(),
+			Body:        MessageV2Body{Content: content},
+		}
+	} else {
+		record.Type = MessageMsgTypeV1
+		v1 := &MessageV1{Type: record.Type, ID: record.ID, SentTime: record.SentTime, Content: content}
+
+		if record.Locale != "" {
+			v1.L10n = &L10n{Locale: record.Locale}
+		}
+
+		msg = v1
+	}
+
+	if err = s.outbound.SendToDID(msg, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send basic message: %w", err)
+	}
+
+	if err = s.save(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// History returns the messages exchanged over the connection, ordered by the time they were sent at, then by ID.
+func (s *Service) History(connectionID string, opts ...HistoryOpt) (*History, error) {
+	options := &historyOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.offset < 0 || options.limit < 0 {
+		return nil, errors.New("invalid page: offset and limit must not be negative")
+	}
+
+	tagName := connectionTagName
+	if options.unreadOnly {
+		tagName = unreadTagName
+	}
+
+	records, err := s.query(tagName + ":" + encodeTagValue(connectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if options.newestFirst {
+			i, j = j, i
+		}
+
+		if !records[i].SentTime.Equal(records[j].SentTime) {
+			return records[i].SentTime.Before(records[j].SentTime)
+		}
+
+		return records[i].ID < records[j].ID
+	})
+
+	history := &History{Messages: []*Record{}, Total: len(records)}
+
+	if options.offset >= len(records) {
+		return history, nil
+	}
+
+	records = records[options.offset:]
+
+	if options.limit > 0 && options.limit < len(records) {
+		records = records[:options.limit]
+	}
+
+	history.Messages = records
+
+	return history, nil
+}
+
+// MarkRead marks the given received messages of the connection as read, all its unread messages being marked when
+// no message ID is given. It returns the number of messages newly marked as read.
+func (s *Service) MarkRead(connectionID string, msgIDs ...string) (int, error) {
+	s.storeLock.Lock()
+	defer s.storeLock.Unlock()
+
+	var (
+		records []*Record
+		err     error
+	)
+
+	if len(msgIDs) == 0 {
+		records, err = s.query(unreadTagName + ":" + encodeTagValue(connectionID))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, msgID := range msgIDs {
+		record, e := s.get(recordKey(DirectionReceived, msgID))
+		if errors.Is(e, storage.ErrDataNotFound) {
+			return 0, fmt.Errorf("received message %s not found: %w", msgID, e)
+		}
+
+		if e != nil {
+			return 0, e
+		}
+
+		if record.ConnectionID != connectionID {
+			return 0, fmt.Errorf("received message %s not found: %w", msgID, storage.ErrDataNotFound)
+		}
+
+		records = append(records, record)
+	}
+
+	now := time.Now().UTC()
+	marked := 0
+
+	for _, record := range records {
+		if record.ReadTime != nil {
+			continue
+		}
+
+		record.ReadTime = &now
+
+		if err = s.put(record); err != nil {
+			return marked, err
+		}
+
+		marked++
+	}
+
+	return marked, nil
+}
+
+// delegate hands the message to the first message service accepting it, for the handlers registered before the
+// history was kept to keep receiving the basic messages.
+func (s *Service) delegate(msg service.DIDCommMsg, ctx service.DIDCommContext) error {
+	if s.messageServices == nil {
+		return nil
+	}
+
+	h := struct {
+		Purpose []string `json:"~purpose"`
+	}{}
+
+	if err := msg.Decode(&h); err != nil {
+		return fmt.Errorf("decode purpose: %w", err)
+	}
+
+	for _, svc := range s.messageServices.MessageServices() {
+		if !svc.Accept(msg.Type(), h.Purpose) {
+			continue
+		}
+
+		if msgMap, ok := msg.(service.DIDCommMsgMap); ok && s.messenger != nil {
+			if err := s.messenger.HandleInbound(msgMap, ctx); err != nil {
+				return fmt.Errorf("messenger HandleInbound: %w", err)
+			}
+		}
+
+		_, err := svc.HandleInbound(msg, ctx)
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) save(record *Record) error {
+	s.storeLock.Lock()
+	defer s.storeLock.Unlock()
+
+	return s.put(record)
+}
+
+func (s *Service) put(record *Record) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal basic message record: %w", err)
+	}
+
+	tags := []storage.Tag{{Name: connectionTagName, Value: encodeTagValue(record.ConnectionID)}}
+
+	if record.Direction == DirectionReceived && record.ReadTime == nil {
+		tags = append(tags, storage.Tag{Name: unreadTagName, Value: encodeTagValue(record.ConnectionID)})
+	}
+
+	if err = s.store.Put(recordKey(record.Direction, record.ID), recordBytes, tags...); err != nil {
+		return fmt.Errorf("store basic message record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) get(key string) (*Record, error) {
+	recordBytes, err := s.store.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("get basic message record: %w", err)
+	}
+
+	record := &Record{}
+
+	if err = json.Unmarshal(recordBytes, record); err != nil {
+		return nil, fmt.Errorf("unmarshal basic message record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *Service) query(expression string) ([]*Record, error) {
+	itr, err := s.store.Query(expression)
+	if err != nil {
+		return nil, fmt.Errorf("query basic message records: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close basic message records iterator: %s", errClose)
+		}
+	}()
+
+	var records []*Record
+
+	for {
+		ok, err := itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("basic message records iterator next: %w", err)
+		}
+
+		if !ok {
+			return records, nil
+		}
+
+		recordBytes, err := itr.Value()
+		if err != nil {
+			return nil, fmt.Errorf("basic message records iterator value: %w", err)
+		}
+
+		record := &Record{}
+
+		if err = json.Unmarshal(recordBytes, record); err != nil {
+			return nil, fmt.Errorf("unmarshal basic message record: %w", err)
+		}
+
+		records = append(records, record)
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+// decodeMessage returns the history record of a basic message 1.0 or 2.0.
+func decodeMessage(msg service.DIDCommMsg) (*Record, error) {
+	if msg.Type() == MessageMsgTypeV2 {
+		v2 := &MessageV2{}
+
+		if err := msg.Decode(v2); err != nil {
+			return nil, fmt.Errorf("basic message unmarshal: %w", err)
+		}
+
+		return &Record{
+			ID:       v2.ID,
+			Type:     v2.Type,
+			Content:  v2.Body.Content,
+			Locale:   v2.Lang,
+			SentTime: time.Unix(v2.CreatedTime, 0).UTC(),
+		}, nil
+	}
+
+	v1 := &MessageV1{}
+
+	if err := msg.Decode(v1); err != nil {
+		return nil, fmt.Errorf("basic message unmarshal: %w", err)
+	}
+
+	record := &Record{ID: v1.ID, Type: v1.Type, Content: v1.Content, SentTime: v1.SentTime.UTC()}
+
+	if v1.L10n != nil {
+		record.Locale = v1.L10n.Locale
+	}
+
+	return record, nil
+}
+
+func recordKey(direction, msgID string) string {
+	return direction + "_" + msgID
+}
+
+// encodeTagValue encodes the tag values, the ':' of the connection IDs breaking the query expressions.
+func encodeTagValue(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
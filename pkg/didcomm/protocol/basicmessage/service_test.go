@@ -0,0 +1,528 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	myDID    = "sample-my-did"
+	theirDID = "sample-their-did"
+	connID   = "did:example:conn"
+)
+
+func TestService(t *testing.T) {
+	t.Run("new service", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.Equal(t, BasicMessage, svc.Name())
+		require.True(t, svc.Accept(MessageMsgTypeV1))
+		require.True(t, svc.Accept(MessageMsgTypeV2))
+		require.False(t, svc.Accept("https://didcomm.org/basicmessage/3.0/message"))
+		require.Equal(t, []string{MessageMsgTypeV1, MessageMsgTypeV2}, svc.MessageTypes())
+
+		_, err := svc.HandleOutbound(nil, myDID, theirDID)
+		require.EqualError(t, err, "not implemented")
+	})
+
+	t.Run("new service - store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.EqualError(t, err, "open basic message store : open error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+			Type string `json:"@type"`
+		}{Type: "unknown"}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("stores the received messages", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		sentTime := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV1{
+			Type:     MessageMsgTypeV1,
+			ID:       "msg-1",
+			L10n:     &L10n{Locale: "en"},
+			SentTime: sentTime,
+			Content:  "hello",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV2{
+			Type:        MessageMsgTypeV2,
+			ID:          "msg-2",
+			Lang:        "fr",
+			CreatedTime: sentTime.Add(time.Minute).Unix(),
+			Body:        MessageV2Body{Content: "bonjour"},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		history, err := svc.History(connID)
+		require.NoError(t, err)
+		require.Equal(t, 2, history.Total)
+		require.Len(t, history.Messages, 2)
+
+		first := history.Messages[0]
+		require.Equal(t, "msg-1", first.ID)
+		require.Equal(t, connID, first.ConnectionID)
+		require.Equal(t, DirectionReceived, first.Direction)
+		require.Equal(t, MessageMsgTypeV1, first.Type)
+		require.Equal(t, "hello", first.Content)
+		require.Equal(t, "en", first.Locale)
+		require.True(t, sentTime.Equal(first.SentTime))
+		require.False(t, first.ReceivedTime.IsZero())
+		require.Nil(t, first.ReadTime)
+
+		second := history.Messages[1]
+		require.Equal(t, "msg-2", second.ID)
+		require.Equal(t, MessageMsgTypeV2, second.Type)
+		require.Equal(t, "bonjour", second.Content)
+		require.Equal(t, "fr", second.Locale)
+	})
+
+	t.Run("stores the DIDComm v2 messages by their id", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		for _, id := range []string{"msg-1", "msg-2"} {
+			msg, err := service.ParseDIDCommMsgMap([]byte(`{
+				"id": "` + id + `",
+				"type": "https://didcomm.org/basicmessage/2.0/message",
+				"thid": "thread-1",
+				"lang": "en",
+				"created_time": 1547577721,
+				"body": {"content": "Your hovercraft is full of eels."}
+			}`))
+			require.NoError(t, err)
+
+			msgID, err := svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+			require.NoError(t, err)
+			require.Equal(t, id, msgID)
+		}
+
+		history, err := svc.History(connID)
+		require.NoError(t, err)
+		require.Equal(t, 2, history.Total)
+		require.Equal(t, "msg-1", history.Messages[0].ID)
+		require.Equal(t, "msg-2", history.Messages[1].ID)
+		require.Equal(t, MessageMsgTypeV2, history.Messages[1].Type)
+		require.Equal(t, "Your hovercraft is full of eels.", history.Messages[1].Content)
+		require.Equal(t, time.Unix(1547577721, 0).UTC(), history.Messages[1].SentTime)
+	})
+
+	t.Run("rejects the messages without id", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(
+			`{"type":"https://didcomm.org/basicmessage/2.0/message","body":{"content":"hello"}}`))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "basic message has no id")
+	})
+
+	t.Run("stores the messages without a connection", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV1{
+			Type: MessageMsgTypeV1, ID: "msg-1", Content: "hello",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		history, err := svc.History("")
+		require.NoError(t, err)
+		require.Equal(t, 1, history.Total)
+	})
+
+	t.Run("hands the message to the message service accepting it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(nil)
+
+		handled := make(chan service.DIDCommMsg, 1)
+
+		svc, err := New(&messageServicesProvider{
+			Provider: newProvider(&mockdispatcher.MockOutbound{}),
+			services: []dispatcher.MessageService{
+				&messageServiceStub{purpose: "other"},
+				&messageServiceStub{purpose: "chat", handled: handled},
+			},
+			messenger: messenger,
+		})
+		require.NoError(t, err)
+		stubConnection(svc)
+
+		msg := service.NewDIDCommMsgMap(&MessageV1{Type: MessageMsgTypeV1, ID: "msg-1", Content: "hello"})
+		msg["~purpose"] = []string{"chat"}
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		require.Equal(t, "msg-1", (<-handled).ID())
+	})
+
+	t.Run("messenger error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any()).Return(errors.New("messenger error"))
+
+		svc, err := New(&messageServicesProvider{
+			Provider:  newProvider(&mockdispatcher.MockOutbound{}),
+			services:  []dispatcher.MessageService{&messageServiceStub{}},
+			messenger: messenger,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV1{
+			Type: MessageMsgTypeV1, ID: "msg-1", Content: "hello",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "messenger HandleInbound: messenger error")
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		svc.store = &mockstore.MockStore{Store: map[string]mockstore.DBEntry{}, ErrPut: errors.New("put error")}
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV1{
+			Type: MessageMsgTypeV1, ID: "msg-1", Content: "hello",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "store basic message record: put error")
+	})
+
+	t.Run("refuses the messages once draining", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.NoError(t, svc.Drain(context.Background()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&MessageV1{
+			Type: MessageMsgTypeV1, ID: "msg-1", Content: "hello",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shutting down")
+	})
+}
+
+func TestService_Send(t *testing.T) {
+	t.Run("sends a basic message 1.0", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, theirDID, their)
+
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		record, err := svc.Send(connID, "hello", WithLocale("en"))
+		require.NoError(t, err)
+		require.Equal(t, DirectionSent, record.Direction)
+		require.Equal(t, MessageMsgTypeV1, record.Type)
+
+		msg, ok := (<-sent).(*MessageV1)
+		require.True(t, ok)
+		require.Equal(t, record.ID, msg.ID)
+		require.Equal(t, "hello", msg.Content)
+		require.Equal(t, "en", msg.L10n.Locale)
+
+		history, err := svc.History(connID)
+		require.NoError(t, err)
+		require.Len(t, history.Messages, 1)
+		require.Equal(t, record.ID, history.Messages[0].ID)
+	})
+
+	t.Run("sends a basic message 2.0", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg
+
+				return nil
+			},
+		})
+		stubConnection(svc)
+
+		record, err := svc.Send(connID, "hello", WithSpecV2(), WithLocale("en"))
+		require.NoError(t, err)
+		require.Equal(t, MessageMsgTypeV2, record.Type)
+
+		msg, ok := (<-sent).(*MessageV2)
+		require.True(t, ok)
+		require.Equal(t, "hello", msg.Body.Content)
+		require.Equal(t, "en", msg.Lang)
+		require.Equal(t, record.SentTime.Unix(), msg.CreatedTime)
+
+		msgMap := service.NewDIDCommMsgMap(msg)
+		require.Equal(t, MessageMsgTypeV2, msgMap["type"])
+		require.Equal(t, record.ID, msgMap["id"])
+		require.NotContains(t, msgMap, "@type")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.Send("unknown", "hello")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		stubConnection(svc)
+
+		_, err := svc.Send(connID, "hello")
+		require.EqualError(t, err, "send basic message: send error")
+
+		history, err := svc.History(connID)
+		require.NoError(t, err)
+		require.Zero(t, history.Total)
+	})
+}
+
+func TestService_History(t *testing.T) {
+	svc := newService(t, &mockdispatcher.MockOutbound{})
+	stubConnection(svc)
+
+	start := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"msg-3", "msg-1", "msg-2"} {
+		offset := map[string]time.Duration{"msg-1": 0, "msg-2": time.Minute, "msg-3": 2 * time.Minute}[id]
+
+		require.NoError(t, svc.save(&Record{
+			ID:           id,
+			ConnectionID: connID,
+			Direction:    []string{DirectionReceived, DirectionSent, DirectionReceived}[i],
+			SentTime:     start.Add(offset),
+		}))
+	}
+
+	require.NoError(t, svc.save(&Record{ID: "other", ConnectionID: "other", Direction: DirectionReceived}))
+
+	ids := func(history *History) []string {
+		var result []string
+
+		for _, record := range history.Messages {
+			result = append(result, record.ID)
+		}
+
+		return result
+	}
+
+	t.Run("ordered from the oldest message", func(t *testing.T) {
+		history, err := svc.History(connID)
+		require.NoError(t, err)
+		require.Equal(t, 3, history.Total)
+		require.Equal(t, []string{"msg-1", "msg-2", "msg-3"}, ids(history))
+	})
+
+	t.Run("ordered from the newest message", func(t *testing.T) {
+		history, err := svc.History(connID, WithNewestFirst())
+		require.NoError(t, err)
+		require.Equal(t, []string{"msg-3", "msg-2", "msg-1"}, ids(history))
+	})
+
+	t.Run("page", func(t *testing.T) {
+		history, err := svc.History(connID, WithPage(1, 1))
+		require.NoError(t, err)
+		require.Equal(t, 3, history.Total)
+		require.Equal(t, []string{"msg-2"}, ids(history))
+
+		history, err = svc.History(connID, WithPage(5, 0))
+		require.NoError(t, err)
+		require.Equal(t, 3, history.Total)
+		require.Empty(t, history.Messages)
+
+		_, err = svc.History(connID, WithPage(-1, 0))
+		require.EqualError(t, err, "invalid page: offset and limit must not be negative")
+	})
+
+	t.Run("unread messages", func(t *testing.T) {
+		history, err := svc.History(connID, WithUnreadOnly())
+		require.NoError(t, err)
+		require.Equal(t, []string{"msg-2", "msg-3"}, ids(history))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		s := newService(t, &mockdispatcher.MockOutbound{})
+		s.store = &mockstore.MockStore{ErrQuery: errors.New("query error")}
+
+		_, err := s.History(connID)
+		require.EqualError(t, err, "query basic message records: query error")
+	})
+}
+
+func TestService_MarkRead(t *testing.T) {
+	setup := func(t *testing.T) *Service {
+		t.Helper()
+
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		for _, id := range []string{"msg-1", "msg-2"} {
+			require.NoError(t, svc.save(&Record{ID: id, ConnectionID: connID, Direction: DirectionReceived}))
+		}
+
+		return svc
+	}
+
+	t.Run("marks the given messages as read", func(t *testing.T) {
+		svc := setup(t)
+
+		marked, err := svc.MarkRead(connID, "msg-1")
+		require.NoError(t, err)
+		require.Equal(t, 1, marked)
+
+		marked, err = svc.MarkRead(connID, "msg-1")
+		require.NoError(t, err)
+		require.Zero(t, marked)
+
+		history, err := svc.History(connID, WithUnreadOnly())
+		require.NoError(t, err)
+		require.Len(t, history.Messages, 1)
+		require.Equal(t, "msg-2", history.Messages[0].ID)
+
+		history, err = svc.History(connID)
+		require.NoError(t, err)
+		require.NotNil(t, history.Messages[0].ReadTime)
+	})
+
+	t.Run("marks all the messages as read", func(t *testing.T) {
+		svc := setup(t)
+
+		marked, err := svc.MarkRead(connID)
+		require.NoError(t, err)
+		require.Equal(t, 2, marked)
+
+		history, err := svc.History(connID, WithUnreadOnly())
+		require.NoError(t, err)
+		require.Zero(t, history.Total)
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		svc := setup(t)
+
+		_, err := svc.MarkRead(connID, "unknown")
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+		_, err = svc.MarkRead("other", "msg-1")
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+	t.Helper()
+
+	svc, err := New(newProvider(outbound))
+	require.NoError(t, err)
+
+	return svc
+}
+
+func newProvider(outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+}
+
+func stubConnection(svc *Service) {
+	svc.connectionLookup = &connectionsStub{record: &connection.Record{
+		ConnectionID: connID, MyDID: myDID, TheirDID: theirDID, State: connection.StateNameCompleted,
+	}}
+}
+
+type connectionsStub struct {
+	record *connection.Record
+}
+
+func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, error) {
+	if id != c.record.ConnectionID {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return c.record, nil
+}
+
+func (c *connectionsStub) GetConnectionIDByDIDs(my, their string) (string, error) {
+	if my != c.record.MyDID || their != c.record.TheirDID {
+		return "", storage.ErrDataNotFound
+	}
+
+	return c.record.ConnectionID, nil
+}
+
+type messageServicesProvider struct {
+	*mockprovider.Provider
+	services  []dispatcher.MessageService
+	messenger service.Messenger
+}
+
+func (p *messageServicesProvider) MessageServices() []dispatcher.MessageService {
+	return p.services
+}
+
+func (p *messageServicesProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+type messageServiceStub struct {
+	purpose string
+	handled chan service.DIDCommMsg
+}
+
+func (m *messageServiceStub) HandleInbound(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+	if m.handled != nil {
+		m.handled <- msg
+	}
+
+	return "", nil
+}
+
+func (m *messageServiceStub) Accept(msgType string, purpose []string) bool {
+	return msgType == MessageMsgTypeV1 && (m.purpose == "" || len(purpose) == 1 && purpose[0] == m.purpose)
+}
+
+func (m *messageServiceStub) Name() string {
+	return m.purpose
+}
@@ -82,6 +82,54 @@ type Attachment struct {
 	Data AttachmentData `json:"data,omitempty"`
 }
 
+// AttachmentV2 is a DIDComm v2 attachment, carried by the `attachments` property of the message.
+// https://identity.foundation/didcomm-messaging/spec/#attachments
+type AttachmentV2 struct {
+	// ID uniquely identifies the attachment within the scope of the message.
+	ID string `json:"id,omitempty"`
+	// Description is an optional human-readable description of the content.
+	Description string `json:"description,omitempty"`
+	// FileName is a hint about the name that might be used if this attachment is persisted as a file.
+	FileName string `json:"filename,omitempty"`
+	// MediaType describes the media type of the attached content.
+	MediaType string `json:"media_type,omitempty"`
+	// Format further describes the format of the attached content, within the media type.
+	Format string `json:"format,omitempty"`
+	// LastModTime is a hint about when the content in this attachment was last modified.
+	LastModTime time.Time `json:"lastmod_time,omitempty"`
+	// ByteCount is an optional, and mostly relevant when content is included by reference instead of by value.
+	ByteCount int64 `json:"byte_count,omitempty"`
+	// Data is a JSON object that gives access to the actual content of the attachment.
+	Data AttachmentData `json:"data,omitempty"`
+}
+
+// Service is the service decorator, an inline service block telling the recipient of a message where and with which
+// keys to reply when there is no connection between the agents.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0056-service-decorator
+type Service struct {
+	// RecipientKeys are the did:key keys the replies are packed for.
+	RecipientKeys []string `json:"recipientKeys"`
+	// RoutingKeys are the did:key keys of the mediators forwarding the replies, if any.
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+	// ServiceEndpoint is the endpoint the replies are sent to.
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// NewService returns a service decorator with a new ED25519 recipient key created with keyManager.
+func NewService(keyManager kms.KeyManager, serviceEndpoint string) (*Service, error) {
+	_, pubKeyBytes, err := keyManager.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create recipient key: %w", err)
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(pubKeyBytes)
+
+	return &Service{
+		RecipientKeys:   []string{didKey},
+		ServiceEndpoint: serviceEndpoint,
+	}, nil
+}
+
 // AttachmentData contains attachment payload.
 type AttachmentData struct {
 	// Sha256 is a hash of the content. Optional. Used as an integrity check if content is inlined.
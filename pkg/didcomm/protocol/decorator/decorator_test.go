@@ -28,6 +28,7 @@ import (
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 func TestAttachmentData_Fetch(t *testing.T) {
@@ -343,6 +344,30 @@ func TestSignVerify(t *testing.T) {
 	})
 }
 
+func TestNewService(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := NewService(newKMS(t), "http://agent.example.com")
+		require.NoError(t, err)
+		require.Equal(t, "http://agent.example.com", svc.ServiceEndpoint)
+		require.Len(t, svc.RecipientKeys, 1)
+		require.Empty(t, svc.RoutingKeys)
+
+		pubKey, err := fingerprint.PubKeyFromDIDKey(svc.RecipientKeys[0])
+		require.NoError(t, err)
+		require.Len(t, pubKey, ed25519.PublicKeySize)
+
+		raw, err := json.Marshal(svc)
+		require.NoError(t, err)
+		require.JSONEq(t, fmt.Sprintf(`{"recipientKeys":["%s"],"serviceEndpoint":"http://agent.example.com"}`,
+			svc.RecipientKeys[0]), string(raw))
+	})
+
+	t.Run("key creation error", func(t *testing.T) {
+		_, err := NewService(&mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test error")}, "")
+		require.EqualError(t, err, "create recipient key: test error")
+	})
+}
+
 func mockAttachmentData() *AttachmentData {
 	return &AttachmentData{Base64: base64.RawURLEncoding.EncodeToString([]byte(`lorem ipsum dolor sit amet`))}
 }
@@ -138,6 +138,7 @@ func buildJWKFromBytes(pubKeyBytes []byte) (*jose.JWK, error) {
 var vmType = map[kms.KeyType]string{
 	kms.ED25519Type:            ed25519VerificationKey2018,
 	kms.BLS12381G2Type:         bls12381G2Key2020,
+	kms.BLS12381G2SHA256Type:   bls12381G2Key2020,
 	kms.ECDSAP256TypeDER:       jsonWebKey2020,
 	kms.ECDSAP256TypeIEEEP1363: jsonWebKey2020,
 	kms.ECDSAP384TypeDER:       jsonWebKey2020,
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	gocontext "context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -70,6 +72,19 @@ type message struct {
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
 	err error
+	// done is called once the callback is handled.
+	done func()
+}
+
+// logger returns the logger of the message processing.
+func (m *message) logger() *log.Log {
+	fields := service.LogFields(m.Msg)
+
+	if m.ConnRecord != nil {
+		fields = append(fields, log.FieldConnectionID, m.ConnRecord.ConnectionID)
+	}
+
+	return logger.With(fields...)
 }
 
 // provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context().
@@ -101,6 +116,7 @@ type Service struct {
 	callbackChannel    chan *message
 	connectionRecorder *connection.Recorder
 	connectionStore    didstore.ConnectionStore
+	inFlight           shutdown.Tracker
 }
 
 type context struct {
@@ -198,7 +214,7 @@ func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 
 // HandleInbound handles inbound didexchange messages.
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
-	logger.Debugf("receive inbound message : %s", msg)
+	logger.With(service.LogFields(msg)...).Debugf("receive inbound message : %s", msg)
 
 	// fetch the thread id
 	thID, err := msg.ThreadID()
@@ -228,19 +244,24 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		ConnRecord:    connRecord,
 	}
 
-	go func(msg *message, aEvent chan<- service.DIDCommAction) {
-		if err = s.handle(msg, aEvent); err != nil {
-			logutil.LogError(logger, DIDExchange, "processMessage", err.Error(),
-				logutil.CreateKeyValueString("msgType", msg.Msg.Type()),
-				logutil.CreateKeyValueString("msgID", msg.Msg.ID()),
-				logutil.CreateKeyValueString("connectionID", msg.ConnRecord.ConnectionID))
+	aEvent := s.ActionEvent()
+
+	err = s.inFlight.Go(func() {
+		if handleErr := s.handle(internalMsg, aEvent); handleErr != nil {
+			logutil.LogError(logger, DIDExchange, "processMessage", handleErr.Error(),
+				logutil.CreateKeyValueString("msgType", internalMsg.Msg.Type()),
+				logutil.CreateKeyValueString("msgID", internalMsg.Msg.ID()),
+				logutil.CreateKeyValueString("connectionID", internalMsg.ConnRecord.ConnectionID))
 		}
 
 		logutil.LogDebug(logger, DIDExchange, "processMessage", "success",
-			logutil.CreateKeyValueString("msgType", msg.Msg.Type()),
-			logutil.CreateKeyValueString("msgID", msg.Msg.ID()),
-			logutil.CreateKeyValueString("connectionID", msg.ConnRecord.ConnectionID))
-	}(internalMsg, s.ActionEvent())
+			logutil.CreateKeyValueString("msgType", internalMsg.Msg.Type()),
+			logutil.CreateKeyValueString("msgID", internalMsg.Msg.ID()),
+			logutil.CreateKeyValueString("connectionID", internalMsg.ConnRecord.ConnectionID))
+	})
+	if err != nil {
+		return "", fmt.Errorf("handle inbound : %w", err)
+	}
 
 	logutil.LogDebug(logger, DIDExchange, "handleInbound", "success",
 		logutil.CreateKeyValueString("msgType", msg.Type()),
@@ -273,6 +294,13 @@ func (s *Service) Accept(msgType string) bool {
 		msgType == CompleteMsgType
 }
 
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		InvitationMsgType, RequestMsgType, ResponseMsgType, AckMsgType, CompleteMsgType,
+	}
+}
+
 // HandleOutbound handles outbound didexchange messages.
 func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
 	return "", errors.New("not implemented")
@@ -308,7 +336,8 @@ func (s *Service) nextState(msgType, thID string) (state, error) {
 }
 
 func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) error { //nolint:funlen,gocyclo
-	logger.Debugf("handling msg: %+v", msg)
+	msgLogger := msg.logger()
+	msgLogger.Debugf("handling msg: %+v", msg)
 
 	next, err := stateFromName(msg.NextStateName)
 	if err != nil {
@@ -323,7 +352,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			StateID:      next.Name(),
 			Properties:   createEventProperties(msg.ConnRecord.ConnectionID, msg.ConnRecord.InvitationID),
 		})
-		logger.Debugf("sent pre event for state %s", next.Name())
+		msgLogger.Debugf("sent pre event for state %s", next.Name())
 
 		var (
 			action           stateAction
@@ -345,7 +374,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 		}
 
 		connectionRecord.State = next.Name()
-		logger.Debugf("finished execute state: %s", next.Name())
+		msgLogger.Debugf("finished execute state: %s", next.Name())
 
 		if err = s.update(msg.Msg.Type(), connectionRecord); err != nil {
 			return fmt.Errorf("failed to persist state %s %w", next.Name(), err)
@@ -362,7 +391,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			return fmt.Errorf("failed to execute state action '%s': %w", next.Name(), err)
 		}
 
-		logger.Debugf("finish execute state action: '%s'", next.Name())
+		msgLogger.Debugf("finish execute state action: '%s'", next.Name())
 
 		prev := next
 		next = followup
@@ -370,7 +399,7 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 
 		// trigger action event based on message type for inbound messages
 		if msg.Msg.Type() != oobMsgType && canTriggerActionEvents(connectionRecord.State, connectionRecord.Namespace) {
-			logger.Debugf("action event triggered for msg type: %s", msg.Msg.Type())
+			msgLogger.Debugf("action event triggered for msg type: %s", msg.Msg.Type())
 
 			msg.NextStateName = next.Name()
 			if err = s.sendActionEvent(msg, aEvent); err != nil {
@@ -387,10 +416,10 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			StateID:      prev.Name(),
 			Properties:   createEventProperties(connectionRecord.ConnectionID, connectionRecord.InvitationID),
 		})
-		logger.Debugf("sent post event for state %s", prev.Name())
+		msgLogger.Debugf("sent post event for state %s", prev.Name())
 
 		if haltExecution {
-			logger.Debugf("halted execution before state=%s", msg.NextStateName)
+			msgLogger.Debugf("halted execution before state=%s", msg.NextStateName)
 
 			break
 		}
@@ -434,6 +463,13 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 			ProtocolName: DIDExchange,
 			Message:      internalMsg.Msg.Clone(),
 			Continue: func(args interface{}) {
+				done, addErr := s.inFlight.Add()
+				if addErr != nil {
+					internalMsg.logger().Warnf("action left pending: %s", addErr)
+
+					return
+				}
+
 				switch v := args.(type) {
 				case opts:
 					internalMsg.Options = &options{
@@ -445,17 +481,24 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 					// nothing to do
 				}
 
-				s.processCallback(internalMsg)
+				s.processCallback(internalMsg, done)
 			},
 			Stop: func(err error) {
+				done, addErr := s.inFlight.Add()
+				if addErr != nil {
+					internalMsg.logger().Warnf("action left pending: %s", addErr)
+
+					return
+				}
+
 				// sets an error to the message
 				internalMsg.err = err
-				s.processCallback(internalMsg)
+				s.processCallback(internalMsg, done)
 			},
 			Properties: createEventProperties(internalMsg.ConnRecord.ConnectionID, internalMsg.ConnRecord.InvitationID),
 		}
 
-		logger.Debugf("dispatched action for msg: %+v", internalMsg.Msg)
+		internalMsg.logger().Debugf("dispatched action for msg: %+v", internalMsg.Msg)
 	}
 
 	return nil
@@ -463,6 +506,8 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 
 // sendEvent triggers the message events.
 func (s *Service) sendMsgEvents(msg *service.StateMsg) {
+	service.ObserveStateMsg(msg)
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
 		handler <- *msg
@@ -474,23 +519,41 @@ func (s *Service) sendMsgEvents(msg *service.StateMsg) {
 // startInternalListener listens to messages in gochannel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbackChannel {
-		// TODO https://github.com/hyperledger/aries-framework-go/issues/242 - retry logic
-		// if no error - do handle
-		if msg.err == nil {
-			msg.err = s.handleWithoutAction(msg)
-		}
+		s.handleCallback(msg)
 
-		// no error - continue
-		if msg.err == nil {
-			continue
+		if msg.done != nil {
+			msg.done()
 		}
+	}
+}
 
-		if err := s.abandon(msg.ThreadID, msg.Msg, msg.err); err != nil {
-			logger.Errorf("process callback : %s", err)
-		}
+func (s *Service) handleCallback(msg *message) {
+	// TODO https://github.com/hyperledger/aries-framework-go/issues/242 - retry logic
+	// if no error - do handle
+	if msg.err == nil {
+		msg.err = s.handleWithoutAction(msg)
+	}
+
+	// no error - continue
+	if msg.err == nil {
+		return
+	}
+
+	if err := s.abandon(msg.ThreadID, msg.Msg, msg.err); err != nil {
+		msg.logger().Errorf("process callback : %s", err)
 	}
 }
 
+// Drain refuses the new inbound messages, invitations and action callbacks, leaving the actions pending until the
+// service is restarted, and waits for the messages and callbacks being handled, or for ctx to be done.
+func (s *Service) Drain(ctx gocontext.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s : %w", DIDExchange, err)
+	}
+
+	return nil
+}
+
 // AcceptInvitation accepts/approves connection invitation.
 func (s *Service) AcceptInvitation(connectionID, publicDID, label string, routerConnections []string) error {
 	return s.accept(connectionID, publicDID, label, StateIDInvited,
@@ -528,6 +591,13 @@ func (s *Service) SaveInvitation(i *OOBInvitation) error {
 }
 
 func (s *Service) accept(connectionID, publicDID, label, stateID, errMsg string, routerConnections []string) error {
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("%s : %w", errMsg, err)
+	}
+
+	defer done()
+
 	msg, err := s.getEventProtocolStateData(connectionID)
 	if err != nil {
 		return fmt.Errorf("failed to accept invitation for connectionID=%s : %s : %w", connectionID, errMsg, err)
@@ -605,7 +675,9 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 	return nil
 }
 
-func (s *Service) processCallback(msg *message) {
+func (s *Service) processCallback(msg *message, done func()) {
+	msg.done = done
+
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
 	s.callbackChannel <- msg
@@ -926,11 +998,16 @@ func (s *Service) CreateImplicitInvitation(inviterLabel, inviterDID,
 	}
 	internalMsg.Options = &options{publicDID: inviteeDID, label: inviteeLabel, routerConnections: routerConnections}
 
-	go func(msg *message, aEvent chan<- service.DIDCommAction) {
-		if err = s.handle(msg, aEvent); err != nil {
-			logger.Errorf("error from handle for implicit invitation: %s", err)
+	aEvent := s.ActionEvent()
+
+	err = s.inFlight.Go(func() {
+		if handleErr := s.handle(internalMsg, aEvent); handleErr != nil {
+			logger.Errorf("error from handle for implicit invitation: %s", handleErr)
 		}
-	}(internalMsg, s.ActionEvent())
+	})
+	if err != nil {
+		return "", fmt.Errorf("implicit invitation : %w", err)
+	}
 
 	return connRecord.ConnectionID, nil
 }
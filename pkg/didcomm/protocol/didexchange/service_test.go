@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package didexchange
 
 import (
+	gocontext "context"
 	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
@@ -20,6 +21,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -914,6 +916,46 @@ func TestEventsSuccess(t *testing.T) {
 	}
 }
 
+func TestService_Drain(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	k := newKMS(t, sp)
+	ctx := &context{
+		kms:              k,
+		keyType:          kms.ED25519Type,
+		keyAgreementType: kms.X25519ECDHKWType,
+	}
+
+	svc, err := New(&protocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		CustomKMS:             k,
+		KeyTypeValue:          ctx.keyType,
+		KeyAgreementTypeValue: ctx.keyAgreementType,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Drain(gocontext.Background()))
+
+	pubKey, _ := newSigningAndEncryptionDIDKeys(t, ctx)
+	invite, err := json.Marshal(&Invitation{
+		Type:          InvitationMsgType,
+		ID:            randomString(),
+		Label:         "test",
+		RecipientKeys: []string{pubKey},
+	})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(invite)
+	require.NoError(t, err)
+
+	_, err = svc.HandleInbound(didMsg, service.EmptyDIDCommContext())
+	require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+
+	err = svc.AcceptExchangeRequest("connectionID", "", "", nil)
+	require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+}
+
 func TestContinueWithPublicDID(t *testing.T) {
 	sp := mockstorage.NewMockStoreProvider()
 	k := newKMS(t, sp)
@@ -1049,7 +1091,7 @@ func TestEventStoreError(t *testing.T) {
 	go func() {
 		for e := range actionCh {
 			e.Continue = func(args interface{}) {
-				svc.processCallback(&message{Msg: service.NewDIDCommMsgMap(struct{}{})})
+				svc.processCallback(&message{Msg: service.NewDIDCommMsgMap(struct{}{})}, func() {})
 			}
 			e.Continue(&service.Empty{})
 		}
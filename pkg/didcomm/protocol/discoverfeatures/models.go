@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// QueryV1 asks the other agent which of the protocols matching the query it supports.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0031-discover-features#query-message-type
+type QueryV1 struct {
+	Type    string `json:"@type,omitempty"`
+	ID      string `json:"@id,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// DiscloseV1 is the answer to the query, listing the supported protocols.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0031-discover-features#disclose-message-type
+type DiscloseV1 struct {
+	Type      string               `json:"@type,omitempty"`
+	ID        string               `json:"@id,omitempty"`
+	Thread    *decorator.Thread    `json:"~thread,omitempty"`
+	Protocols []ProtocolDescriptor `json:"protocols"`
+}
+
+// ProtocolDescriptor describes a disclosed protocol.
+type ProtocolDescriptor struct {
+	PID   string   `json:"pid"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// QueriesV2 asks the other agent which of the features matching the queries it supports.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0557-discover-features-v2#queries-message-type
+type QueriesV2 struct {
+	Type    string         `json:"@type,omitempty"`
+	ID      string         `json:"@id,omitempty"`
+	Queries []FeatureQuery `json:"queries"`
+}
+
+// FeatureQuery matches the features of a type, the match holding `*` wildcards.
+type FeatureQuery struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// DiscloseV2 is the answer to the queries, listing the supported features.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0557-discover-features-v2#disclose-message-type
+type DiscloseV2 struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+	Disclosures []Disclosure      `json:"disclosures"`
+}
+
+// Disclosure describes a disclosed feature.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
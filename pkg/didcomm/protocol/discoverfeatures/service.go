@@ -0,0 +1,478 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DiscoverFeatures defines the protocol name.
+	DiscoverFeatures = "discover-features"
+	// SpecV1 defines the protocol spec V1.
+	SpecV1 = "https://didcomm.org/discover-features/1.0/"
+	// QueryMsgTypeV1 defines the protocol query message type.
+	QueryMsgTypeV1 = SpecV1 + "query"
+	// DiscloseMsgTypeV1 defines the protocol disclose message type.
+	DiscloseMsgTypeV1 = SpecV1 + "disclose"
+	// SpecV2 defines the protocol spec V2.
+	SpecV2 = "https://didcomm.org/discover-features/2.0/"
+	// QueriesMsgTypeV2 defines the protocol queries message type.
+	QueriesMsgTypeV2 = SpecV2 + "queries"
+	// DiscloseMsgTypeV2 defines the protocol disclose message type.
+	DiscloseMsgTypeV2 = SpecV2 + "disclose"
+
+	// FeatureTypeProtocol is the feature type of the protocols, the only one disclosed by the service.
+	FeatureTypeProtocol = "protocol"
+)
+
+// defaultTimeout is how long Query waits for the disclosure by default.
+const defaultTimeout = 10 * time.Second
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrNoDisclosure is returned when the other agent didn't disclose its features in time.
+	ErrNoDisclosure = errors.New("no disclosure")
+
+	logger = log.New("aries-framework/discoverfeatures")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Registry supplies the services the disclosures are built from, the protocols of the services implementing
+// dispatcher.MessageTypeProvider being disclosed.
+type Registry interface {
+	ProtocolServices() []dispatcher.ProtocolService
+	MessageServices() []dispatcher.MessageService
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// QueryOpt configures a feature query.
+type QueryOpt func(opts *queryOpts)
+
+type queryOpts struct {
+	specV1  bool
+	timeout time.Duration
+	comment string
+}
+
+// WithSpecV1 sends the query with the discover features 1.0 protocol, which supports a single match.
+func WithSpecV1() QueryOpt {
+	return func(opts *queryOpts) {
+		opts.specV1 = true
+	}
+}
+
+// WithTimeout sets how long Query waits for the disclosure, 10 seconds by default.
+func WithTimeout(timeout time.Duration) QueryOpt {
+	return func(opts *queryOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// WithComment sets the comment of a discover features 1.0 query.
+func WithComment(comment string) QueryOpt {
+	return func(opts *queryOpts) {
+		opts.comment = comment
+	}
+}
+
+// Service for the discover features protocol.
+type Service struct {
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	registry         Registry
+	disclosures      map[string]chan []Disclosure
+	disclosuresLock  sync.Mutex
+	inFlight         shutdown.Tracker
+}
+
+// New returns the discover features service.
+func New(prov provider, registry Registry) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		registry:         registry,
+		disclosures:      make(map[string]chan []Disclosure),
+	}, nil
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if !s.Accept(msg.Type()) {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	inbound := msg.Clone()
+
+	err := s.inFlight.Go(func() {
+		var err error
+
+		switch inbound.Type() {
+		case QueryMsgTypeV1:
+			err = s.handleQueryV1(inbound, ctx.MyDID(), ctx.TheirDID())
+		case QueriesMsgTypeV2:
+			err = s.handleQueriesV2(inbound, ctx.MyDID(), ctx.TheirDID())
+		case DiscloseMsgTypeV1:
+			err = s.handleDiscloseV1(inbound)
+		case DiscloseMsgTypeV2:
+			err = s.handleDiscloseV2(inbound)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%s)", err)
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("handle inbound: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QueryMsgTypeV1, DiscloseMsgTypeV1, QueriesMsgTypeV2, DiscloseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{QueryMsgTypeV1, DiscloseMsgTypeV1, QueriesMsgTypeV2, DiscloseMsgTypeV2}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Drain refuses the new inbound messages and waits for the messages being handled to complete, or for ctx to be
+// done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", DiscoverFeatures, err)
+	}
+
+	return nil
+}
+
+// Disclose returns the protocols supported by this agent matching any of the given matches, a `*` in a match
+// standing for any sequence of characters.
+func (s *Service) Disclose(matches ...string) []Disclosure {
+	var disclosures []Disclosure
+
+	for _, pid := range s.protocols() {
+		for _, match := range matches {
+			if matchWildcard(match, pid) {
+				disclosures = append(disclosures, Disclosure{FeatureType: FeatureTypeProtocol, ID: pid})
+
+				break
+			}
+		}
+	}
+
+	return disclosures
+}
+
+// Query asks the agent at the other end of the connection which of the protocols matching the given matches it
+// supports, and waits for its disclosure. ErrNoDisclosure is returned when the disclosure doesn't come in time.
+func (s *Service) Query(connectionID string, matches []string, opts ...QueryOpt) ([]Disclosure, error) {
+	options := &queryOpts{timeout: defaultTimeout}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if len(matches) == 0 {
+		return nil, errors.New("no match to query")
+	}
+
+	if options.specV1 && len(matches) > 1 {
+		return nil, fmt.Errorf("discover features 1.0 supports a single match, got %d", len(matches))
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	var msg interface{} = &QueryV1{Type: QueryMsgTypeV1, ID: msgID, Query: matches[0], Comment: options.comment}
+
+	if !options.specV1 {
+		queries := make([]FeatureQuery, len(matches))
+
+		for i, match := range matches {
+			queries[i] = FeatureQuery{FeatureType: FeatureTypeProtocol, Match: match}
+		}
+
+		msg = &QueriesV2{Type: QueriesMsgTypeV2, ID: msgID, Queries: queries}
+	}
+
+	disclosureCh := make(chan []Disclosure, 1)
+	s.setDisclosureCh(msgID, disclosureCh)
+
+	defer s.setDisclosureCh(msgID, nil)
+
+	if err = s.outbound.SendToDID(msg, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send query: %w", err)
+	}
+
+	select {
+	case disclosures := <-disclosureCh:
+		return disclosures, nil
+	case <-time.After(options.timeout):
+		return nil, fmt.Errorf("query %s: %w", msgID, ErrNoDisclosure)
+	}
+}
+
+func (s *Service) handleQueryV1(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &QueryV1{}
+
+	if err := msg.Decode(query); err != nil {
+		return fmt.Errorf("query message unmarshal: %w", err)
+	}
+
+	disclose := &DiscloseV1{
+		Type:      DiscloseMsgTypeV1,
+		ID:        uuid.New().String(),
+		Thread:    &decorator.Thread{ID: msg.ID()},
+		Protocols: []ProtocolDescriptor{},
+	}
+
+	for _, disclosure := range s.Disclose(query.Query) {
+		disclose.Protocols = append(disclose.Protocols, ProtocolDescriptor{PID: disclosure.ID})
+	}
+
+	if err := s.outbound.SendToDID(disclose, myDID, theirDID); err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleQueriesV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	queries := &QueriesV2{}
+
+	if err := msg.Decode(queries); err != nil {
+		return fmt.Errorf("queries message unmarshal: %w", err)
+	}
+
+	var matches []string
+
+	// the queries of the unknown feature types are ignored
+	for _, query := range queries.Queries {
+		if query.FeatureType == FeatureTypeProtocol {
+			matches = append(matches, query.Match)
+		}
+	}
+
+	disclose := &DiscloseV2{
+		Type:        DiscloseMsgTypeV2,
+		ID:          uuid.New().String(),
+		Thread:      &decorator.Thread{ID: msg.ID()},
+		Disclosures: append([]Disclosure{}, s.Disclose(matches...)...),
+	}
+
+	if err := s.outbound.SendToDID(disclose, myDID, theirDID); err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleDiscloseV1(msg service.DIDCommMsg) error {
+	disclose := &DiscloseV1{}
+
+	if err := msg.Decode(disclose); err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	disclosures := make([]Disclosure, len(disclose.Protocols))
+
+	for i, protocol := range disclose.Protocols {
+		disclosures[i] = Disclosure{FeatureType: FeatureTypeProtocol, ID: protocol.PID, Roles: protocol.Roles}
+	}
+
+	return s.deliver(msg, disclosures)
+}
+
+func (s *Service) handleDiscloseV2(msg service.DIDCommMsg) error {
+	disclose := &DiscloseV2{}
+
+	if err := msg.Decode(disclose); err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	return s.deliver(msg, disclose.Disclosures)
+}
+
+// deliver passes the disclosures to the query waiting for them.
+func (s *Service) deliver(msg service.DIDCommMsg, disclosures []Disclosure) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("disclose thread ID: %w", err)
+	}
+
+	s.disclosuresLock.Lock()
+	defer s.disclosuresLock.Unlock()
+
+	disclosureCh, ok := s.disclosures[thID]
+	if !ok {
+		logger.Debugf("dropped the disclosure of the unknown query %s", thID)
+
+		return nil
+	}
+
+	select {
+	case disclosureCh <- disclosures:
+	default:
+	}
+
+	return nil
+}
+
+// protocols returns the sorted IDs of the protocols supported by this agent.
+func (s *Service) protocols() []string {
+	var services []interface{}
+
+	// this service is disclosed even when not registered
+	services = append(services, s)
+
+	if s.registry != nil {
+		for _, svc := range s.registry.ProtocolServices() {
+			services = append(services, svc)
+		}
+
+		for _, svc := range s.registry.MessageServices() {
+			services = append(services, svc)
+		}
+	}
+
+	set := make(map[string]struct{})
+
+	for _, svc := range services {
+		provider, ok := svc.(dispatcher.MessageTypeProvider)
+		if !ok {
+			continue
+		}
+
+		for _, msgType := range provider.MessageTypes() {
+			if pid := protocolID(msgType); pid != "" {
+				set[pid] = struct{}{}
+			}
+		}
+	}
+
+	pids := make([]string, 0, len(set))
+
+	for pid := range set {
+		pids = append(pids, pid)
+	}
+
+	sort.Strings(pids)
+
+	return pids
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) setDisclosureCh(msgID string, disclosureCh chan []Disclosure) {
+	s.disclosuresLock.Lock()
+	defer s.disclosuresLock.Unlock()
+
+	if disclosureCh == nil {
+		delete(s.disclosures, msgID)
+	} else {
+		s.disclosures[msgID] = disclosureCh
+	}
+}
+
+// protocolID returns the protocol identifier of the message type, the message type without its last path segment.
+func protocolID(msgType string) string {
+	i := strings.LastIndex(msgType, "/")
+	if i <= 0 {
+		return ""
+	}
+
+	return msgType[:i]
+}
+
+// matchWildcard tells whether id matches the pattern, a `*` in the pattern standing for any sequence of characters.
+func matchWildcard(pattern, id string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == id
+	}
+
+	if !strings.HasPrefix(id, parts[0]) {
+		return false
+	}
+
+	id = id[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(id, part)
+		if i < 0 {
+			return false
+		}
+
+		id = id[i+len(part):]
+	}
+
+	return strings.HasSuffix(id, parts[len(parts)-1])
+}
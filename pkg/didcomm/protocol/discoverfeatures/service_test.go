@@ -0,0 +1,357 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	myDID    = "sample-my-did"
+	theirDID = "sample-their-did"
+	connID   = "conn"
+)
+
+func TestService(t *testing.T) {
+	t.Run("new service", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.Equal(t, DiscoverFeatures, svc.Name())
+
+		for _, msgType := range svc.MessageTypes() {
+			require.True(t, svc.Accept(msgType))
+		}
+
+		require.False(t, svc.Accept("unknown"))
+
+		_, err := svc.HandleOutbound(nil, myDID, theirDID)
+		require.EqualError(t, err, "not implemented")
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&QueryV1{Type: "unknown"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("new service - store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &registry{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+}
+
+func TestService_Disclose(t *testing.T) {
+	svc := newService(t, &mockdispatcher.MockOutbound{})
+
+	require.Equal(t, []Disclosure{
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/basicmessage/1.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/1.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/2.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"},
+	}, svc.Disclose("*"))
+
+	require.Equal(t, []Disclosure{
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/2.0"},
+	}, svc.Disclose("https://didcomm.org/discover-features/2.*"))
+
+	require.Equal(t, []Disclosure{
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/2.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+	}, svc.Disclose("*/2.0", "https://didcomm.org/trust-ping/2.0"))
+
+	require.Empty(t, svc.Disclose("https://didcomm.org/present-proof/*"))
+	require.Empty(t, svc.Disclose())
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern string
+		id      string
+		match   bool
+	}{
+		{pattern: "*", id: "https://didcomm.org/present-proof/2.0", match: true},
+		{pattern: "https://didcomm.org/present-proof/2.0", id: "https://didcomm.org/present-proof/2.0", match: true},
+		{pattern: "https://didcomm.org/present-proof/2.0", id: "https://didcomm.org/present-proof/3.0"},
+		{pattern: "https://didcomm.org/present-proof/*", id: "https://didcomm.org/present-proof/3.0", match: true},
+		{pattern: "https://didcomm.org/*/2.0", id: "https://didcomm.org/present-proof/2.0", match: true},
+		{pattern: "https://didcomm.org/*/2.0", id: "https://didcomm.org/present-proof/3.0"},
+		{pattern: "*proof*2.*", id: "https://didcomm.org/present-proof/2.0", match: true},
+		{pattern: "*proof*3.*", id: "https://didcomm.org/present-proof/2.0"},
+		{pattern: "*2.0*2.0", id: "https://didcomm.org/present-proof/2.0"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.match, matchWildcard(test.pattern, test.id), test.pattern)
+	}
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("discloses to a 1.0 query", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+		svc := newService(t, sendTo(t, sent))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&QueryV1{
+			Type:  QueryMsgTypeV1,
+			ID:    "query-id",
+			Query: "https://didcomm.org/trust-ping/*",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		disclose, ok := (<-sent).(*DiscloseV1)
+		require.True(t, ok)
+		require.Equal(t, DiscloseMsgTypeV1, disclose.Type)
+		require.Equal(t, "query-id", disclose.Thread.ID)
+		require.Equal(t, []ProtocolDescriptor{{PID: "https://didcomm.org/trust-ping/2.0"}}, disclose.Protocols)
+	})
+
+	t.Run("discloses to 2.0 queries", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+		svc := newService(t, sendTo(t, sent))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&QueriesV2{
+			Type: QueriesMsgTypeV2,
+			ID:   "query-id",
+			Queries: []FeatureQuery{
+				{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/basicmessage/*"},
+				{FeatureType: "goal-code", Match: "*"},
+			},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		disclose, ok := (<-sent).(*DiscloseV2)
+		require.True(t, ok)
+		require.Equal(t, DiscloseMsgTypeV2, disclose.Type)
+		require.Equal(t, "query-id", disclose.Thread.ID)
+		require.Equal(t, []Disclosure{
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/basicmessage/1.0"},
+		}, disclose.Disclosures)
+	})
+
+	t.Run("discloses nothing to unknown feature types", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+		svc := newService(t, sendTo(t, sent))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&QueriesV2{
+			Type:    QueriesMsgTypeV2,
+			ID:      "query-id",
+			Queries: []FeatureQuery{{FeatureType: "goal-code", Match: "*"}},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		disclose, ok := (<-sent).(*DiscloseV2)
+		require.True(t, ok)
+		require.NotNil(t, disclose.Disclosures)
+		require.Empty(t, disclose.Disclosures)
+	})
+
+	t.Run("refuses the messages once draining", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.NoError(t, svc.Drain(context.Background()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&QueryV1{Type: QueryMsgTypeV1, ID: "query-id"}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shutting down")
+	})
+}
+
+func TestService_Query(t *testing.T) {
+	t.Run("query with 2.0", func(t *testing.T) {
+		// the other agent is an instance of the service
+		peer := newService(t, &mockdispatcher.MockOutbound{})
+
+		var svc *Service
+
+		svc = newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: relay(t, peer, func() *Service { return svc }),
+		})
+		stubConnection(svc)
+
+		disclosures, err := svc.Query(connID, []string{"https://didcomm.org/trust-ping/*", "*/1.0"})
+		require.NoError(t, err)
+		require.Equal(t, []Disclosure{
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/basicmessage/1.0"},
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/1.0"},
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"},
+		}, disclosures)
+	})
+
+	t.Run("query with 1.0", func(t *testing.T) {
+		peer := newService(t, &mockdispatcher.MockOutbound{})
+
+		var svc *Service
+
+		svc = newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: relay(t, peer, func() *Service { return svc }),
+		})
+		stubConnection(svc)
+
+		disclosures, err := svc.Query(connID, []string{"https://didcomm.org/discover-features/*"},
+			WithSpecV1(), WithComment("features?"))
+		require.NoError(t, err)
+		require.Equal(t, []Disclosure{
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/1.0"},
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/2.0"},
+		}, disclosures)
+	})
+
+	t.Run("invalid matches", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.Query(connID, nil)
+		require.EqualError(t, err, "no match to query")
+
+		_, err = svc.Query(connID, []string{"*", "*"}, WithSpecV1())
+		require.EqualError(t, err, "discover features 1.0 supports a single match, got 2")
+	})
+
+	t.Run("no disclosure", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(svc)
+
+		_, err := svc.Query(connID, []string{"*"}, WithTimeout(10*time.Millisecond))
+		require.ErrorIs(t, err, ErrNoDisclosure)
+
+		// a late disclosure is dropped
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&DiscloseV2{
+			Type:   DiscloseMsgTypeV2,
+			ID:     "disclose-id",
+			Thread: &decorator.Thread{ID: "unknown"},
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+		require.NoError(t, svc.Drain(context.Background()))
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.Query("unknown", []string{"*"})
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		stubConnection(svc)
+
+		_, err := svc.Query(connID, []string{"*"})
+		require.EqualError(t, err, "send query: send error")
+	})
+}
+
+// sendTo returns an outbound dispatcher passing the messages sent by the service to sent.
+func sendTo(t *testing.T, sent chan interface{}) *mockdispatcher.MockOutbound {
+	t.Helper()
+
+	return &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, my, their string) error {
+			require.Equal(t, myDID, my)
+			require.Equal(t, theirDID, their)
+
+			sent <- msg
+
+			return nil
+		},
+	}
+}
+
+// relay returns a send function handing the query to the peer, and the disclosure of the peer back to the service.
+func relay(t *testing.T, peer *Service, svc func() *Service) func(interface{}, string, string) error {
+	t.Helper()
+
+	peer.outbound = &mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, my, their string) error {
+			_, err := svc().HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(their, my, nil))
+
+			return err
+		},
+	}
+
+	return func(msg interface{}, my, their string) error {
+		_, err := peer.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(their, my, nil))
+
+		return err
+	}
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+	t.Helper()
+
+	basicMsgSvc, err := basic.NewMessageService("basic", func(basic.Message, service.DIDCommContext) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	pingSvc, err := trustping.New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}, &registry{
+		protocolServices: []dispatcher.ProtocolService{pingSvc},
+		messageServices:  []dispatcher.MessageService{basicMsgSvc},
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+type registry struct {
+	protocolServices []dispatcher.ProtocolService
+	messageServices  []dispatcher.MessageService
+}
+
+func (r *registry) ProtocolServices() []dispatcher.ProtocolService {
+	return r.protocolServices
+}
+
+func (r *registry) MessageServices() []dispatcher.MessageService {
+	return r.messageServices
+}
+
+func stubConnection(svc *Service) {
+	svc.connectionLookup = &connectionsStub{record: &connection.Record{
+		ConnectionID: connID, MyDID: myDID, TheirDID: theirDID, State: connection.StateNameCompleted,
+	}}
+}
+
+type connectionsStub struct {
+	record *connection.Record
+}
+
+func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, error) {
+	if id != c.record.ConnectionID {
+		return nil, errors.New("unexpected connection")
+	}
+
+	return c.record, nil
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package introduce
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -98,6 +100,13 @@ type metaData struct {
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
 	err error
+	// done is called once the callback is handled.
+	done func()
+}
+
+// logger returns the logger of the protocol instance.
+func (md *metaData) logger() *log.Log {
+	return logger.With(append(service.LogFields(md.msgClone), log.FieldPIID, md.PIID)...)
 }
 
 // Service for introduce protocol.
@@ -108,6 +117,7 @@ type Service struct {
 	callbacks chan *metaData
 	oobEvent  chan service.StateMsg
 	messenger service.Messenger
+	inFlight  shutdown.Tracker
 }
 
 // Provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context().
@@ -162,22 +172,10 @@ func (s *Service) startInternalListener() {
 	for {
 		select {
 		case msg := <-s.callbacks:
-			// if no error or it was rejected do handle
-			if msg.err == nil || msg.rejected {
-				msg.err = s.handle(msg)
-			}
+			s.handleCallback(msg)
 
-			// no error - continue
-			if msg.err == nil {
-				continue
-			}
-
-			msg.state = &abandoning{Code: codeInternalError}
-
-			logInternalError(msg.err)
-
-			if err := s.handle(msg); err != nil {
-				logger.Errorf("listener handle: %s", err)
+			if msg.done != nil {
+				msg.done()
 			}
 		case event := <-s.oobEvent:
 			if err := s.OOBMessageReceived(event); err != nil {
@@ -187,9 +185,39 @@ func (s *Service) startInternalListener() {
 	}
 }
 
-func logInternalError(err error) {
-	if !errors.As(err, &customError{}) {
-		logger.Errorf("go to abandoning: %v", err)
+func (s *Service) handleCallback(msg *metaData) {
+	// if no error or it was rejected do handle
+	if msg.err == nil || msg.rejected {
+		msg.err = s.handle(msg)
+	}
+
+	// no error - continue
+	if msg.err == nil {
+		return
+	}
+
+	msg.state = &abandoning{Code: codeInternalError}
+
+	logInternalError(msg)
+
+	if err := s.handle(msg); err != nil {
+		msg.logger().Errorf("listener handle: %s", err)
+	}
+}
+
+// Drain refuses the new action callbacks, leaving their actions pending until the service is restarted, and waits
+// for the callbacks being handled, or for ctx to be done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", Introduce, err)
+	}
+
+	return nil
+}
+
+func logInternalError(md *metaData) {
+	if !errors.As(md.err, &customError{}) {
+		md.logger().Errorf("go to abandoning: %v", md.err)
 	}
 }
 
@@ -328,15 +356,19 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
+	msg := service.StateMsg{
+		ProtocolName: Introduce,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	}
+
+	service.ObserveStateMsg(&msg)
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Introduce,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
+		handler <- msg
 	}
 }
 
@@ -345,19 +377,33 @@ func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
 	// create the message for the channel
 	// trigger the registered action event
 	actionStop := func(err error) {
+		done, addErr := s.inFlight.Add()
+		if addErr != nil {
+			md.logger().Warnf("action left pending: %s", addErr)
+
+			return
+		}
+
 		// if introducee received Proposal rejected must be true
 		if md.Msg.Type() == ProposalMsgType {
 			md.rejected = true
 		}
 
 		md.err = err
-		s.processCallback(md)
+		s.processCallback(md, done)
 	}
 
 	return service.DIDCommAction{
 		ProtocolName: Introduce,
 		Message:      md.msgClone,
 		Continue: func(opt interface{}) {
+			done, addErr := s.inFlight.Add()
+			if addErr != nil {
+				md.logger().Warnf("action left pending: %s", addErr)
+
+				return
+			}
+
 			if fn, ok := opt.(Opt); ok {
 				fn(md.Msg.Metadata())
 			}
@@ -369,10 +415,10 @@ func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
 			}
 
 			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-				logger.Errorf("delete transitional payload", err)
+				md.logger().Errorf("delete transitional payload: %s", err)
 			}
 
-			s.processCallback(md)
+			s.processCallback(md, done)
 		},
 		Stop: func(err error) {
 			if err == nil {
@@ -410,11 +456,16 @@ func (s *Service) ActionContinue(piID string, opt Opt) error {
 		}
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action continue: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-		logger.Errorf("delete transitional payload", err)
+		md.logger().Errorf("delete transitional payload: %s", err)
 	}
 
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
@@ -434,7 +485,14 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		saveMetadata:        s.saveMetadata,
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action stop: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		done()
+
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
@@ -448,12 +506,14 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 	}
 
 	md.err = customError{error: cErr}
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
 
-func (s *Service) processCallback(msg *metaData) {
+func (s *Service) processCallback(msg *metaData, done func()) {
+	msg.done = done
+
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
 	s.callbacks <- msg
@@ -781,6 +841,8 @@ func (s *Service) getParticipants(piID string) ([]*participant, error) {
 }
 
 func (s *Service) execute(next state, md *metaData) (state, stateAction, error) {
+	md.logger().Debugf("executing state %s", next.Name())
+
 	md.state = next
 	s.sendMsgEvents(md, next.Name(), service.PreState)
 
@@ -860,3 +922,10 @@ func (s *Service) Accept(msgType string) bool {
 
 	return false
 }
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		ProposalMsgType, RequestMsgType, ResponseMsgType, AckMsgType, ProblemReportMsgType,
+	}
+}
@@ -13,6 +13,10 @@ const (
 	theirDIDPropKey = "theirDID"
 	piidPropKey     = "piid"
 	errorPropKey    = "error"
+
+	// trustDecisionPropKey is the property of the trust decision about the issuers claimed by the offered or issued
+	// credentials, which are not verified when the decision is made.
+	trustDecisionPropKey = "trustDecision"
 )
 
 type eventProps struct {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,7 +15,10 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -80,6 +84,13 @@ type MetaData struct {
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function.
 	err error
+	// done is called once the callback is handled.
+	done func()
+}
+
+// logger returns the logger of the protocol instance.
+func (md *MetaData) logger() *log.Log {
+	return logger.With(append(service.LogFields(md.msgClone), log.FieldPIID, md.PIID)...)
 }
 
 // Message is the didcomm message.
@@ -180,14 +191,21 @@ type Provider interface {
 	StorageProvider() storage.Provider
 }
 
+// trustRegistryProvider is implemented by the providers supporting trust registry lookups.
+type trustRegistryProvider interface {
+	TrustRegistry() trustregistry.Provider
+}
+
 // Service for the issuecredential protocol.
 type Service struct {
 	service.Action
 	service.Message
-	store      storage.Store
-	callbacks  chan *MetaData
-	messenger  service.Messenger
-	middleware Handler
+	store         storage.Store
+	callbacks     chan *MetaData
+	messenger     service.Messenger
+	middleware    Handler
+	inFlight      shutdown.Tracker
+	trustRegistry trustregistry.Provider
 }
 
 // New returns the issuecredential service.
@@ -209,6 +227,10 @@ func New(p Provider) (*Service, error) {
 		middleware: initialHandler,
 	}
 
+	if trp, ok := p.(trustRegistryProvider); ok {
+		svc.trustRegistry = trp.TrustRegistry()
+	}
+
 	// start the listener
 	go svc.startInternalListener()
 
@@ -234,7 +256,7 @@ func (s *Service) AddMiddleware(mw ...Middleware) {
 
 // HandleInbound handles inbound message (issuecredential protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
-	logger.Debugf("handling inbound: %+v", msg)
+	logger.With(service.LogFields(msg)...).Debugf("handling inbound: %+v", msg)
 
 	aEvent := s.ActionEvent()
 
@@ -260,6 +282,8 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			return "", fmt.Errorf("save transitional payload: %w", err)
 		}
 
+		s.addTrustDecision(md)
+
 		aEvent <- s.newDIDCommActionMsg(md)
 
 		return "", nil
@@ -345,23 +369,41 @@ func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool) (*MetaData, er
 // startInternalListener listens to messages in go channel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbacks {
-		// if no error do handle
-		if msg.err == nil {
-			msg.err = s.handle(msg)
-		}
+		s.handleCallback(msg)
 
-		// no error - continue
-		if msg.err == nil {
-			continue
+		if msg.done != nil {
+			msg.done()
 		}
+	}
+}
 
-		logger.Errorf("abandoning: %s", msg.err)
-		msg.state = &abandoning{Code: codeInternalError}
+func (s *Service) handleCallback(msg *MetaData) {
+	// if no error do handle
+	if msg.err == nil {
+		msg.err = s.handle(msg)
+	}
 
-		if err := s.handle(msg); err != nil {
-			logger.Errorf("listener handle: %s", err)
-		}
+	// no error - continue
+	if msg.err == nil {
+		return
 	}
+
+	msg.logger().Errorf("abandoning: %s", msg.err)
+	msg.state = &abandoning{Code: codeInternalError}
+
+	if err := s.handle(msg); err != nil {
+		msg.logger().Errorf("listener handle: %s", err)
+	}
+}
+
+// Drain refuses the new action callbacks, leaving their actions pending until the service is restarted, and waits
+// for the callbacks being handled, or for ctx to be done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", Name, err)
+	}
+
+	return nil
 }
 
 func isNoOp(s state) bool {
@@ -546,11 +588,18 @@ func (s *Service) ActionContinue(piID string, opt Opt) error {
 		opt(md)
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action continue: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		done()
+
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
@@ -570,7 +619,14 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		properties:          map[string]interface{}{},
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action stop: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		done()
+
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
@@ -579,7 +635,7 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 	}
 
 	md.err = customError{error: cErr}
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
@@ -622,12 +678,131 @@ func (s *Service) Actions() ([]Action, error) {
 	return actions, nil
 }
 
-func (s *Service) processCallback(msg *MetaData) {
+func (s *Service) processCallback(msg *MetaData, done func()) {
+	msg.done = done
+
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
 	s.callbacks <- msg
 }
 
+// addTrustDecision adds the trust decision about the issuers of the offered or issued credentials to the properties
+// of the action event, if the service has a trust registry. The decision is made on the issuers claimed by the
+// attached credentials, of which the proofs are not verified: the credentials are to be verified before relying on
+// it. The attachments of which the issuer cannot be read, e.g. the JWT credentials, add undetermined checks.
+func (s *Service) addTrustDecision(md *MetaData) {
+	if s.trustRegistry == nil {
+		return
+	}
+
+	var (
+		attachments []decorator.Attachment
+		err         error
+	)
+
+	switch md.Msg.Type() {
+	case OfferCredentialMsgType:
+		offer := OfferCredential{}
+		err = md.Msg.Decode(&offer)
+		attachments = offer.OffersAttach
+	case IssueCredentialMsgType:
+		issue := IssueCredential{}
+		err = md.Msg.Decode(&issue)
+		attachments = issue.CredentialsAttach
+	default:
+		return
+	}
+
+	decision := trustregistry.NewDecision()
+
+	if err != nil {
+		decision.AddUndetermined(trustregistry.RoleIssuer, fmt.Sprintf("decode %s: %s", md.Msg.Type(), err))
+		md.properties[trustDecisionPropKey] = decision
+
+		return
+	}
+
+	if len(attachments) == 0 {
+		return
+	}
+
+	for i := range attachments {
+		vc, vcErr := attachedCredentialOf(&attachments[i])
+		if vcErr != nil {
+			decision.AddUndetermined(trustregistry.RoleIssuer, fmt.Sprintf("attachment %s: %s", attachments[i].ID, vcErr))
+
+			continue
+		}
+
+		decision.CheckIssuer(s.trustRegistry, vc.issuerID(), vc.types())
+	}
+
+	md.properties[trustDecisionPropKey] = decision
+}
+
+// attachedCredential is an attached JSON credential, or the JSON credential of an attached ld-proof-vc-detail,
+// of which only the issuer and the types are needed.
+type attachedCredential struct {
+	Issuer     interface{}         `json:"issuer,omitempty"`
+	Types      interface{}         `json:"type,omitempty"`
+	Credential *attachedCredential `json:"credential,omitempty"`
+}
+
+// attachedCredentialOf returns the JSON credential attached, the attachments in other formats being refused.
+func attachedCredentialOf(attachment *decorator.Attachment) (*attachedCredential, error) {
+	src, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+
+	vc := &attachedCredential{}
+	if err = json.Unmarshal(src, vc); err != nil {
+		return nil, errors.New("not a JSON credential")
+	}
+
+	if vc.Credential != nil {
+		vc = vc.Credential
+	}
+
+	if vc.issuerID() == "" {
+		return nil, errors.New("credential without issuer")
+	}
+
+	return vc, nil
+}
+
+func (vc *attachedCredential) issuerID() string {
+	switch issuer := vc.Issuer.(type) {
+	case string:
+		return issuer
+	case map[string]interface{}:
+		id, _ := issuer["id"].(string) // nolint: errcheck
+
+		return id
+	default:
+		return ""
+	}
+}
+
+func (vc *attachedCredential) types() []string {
+	switch types := vc.Types.(type) {
+	case string:
+		return []string{types}
+	case []interface{}:
+		var result []string
+
+		for _, t := range types {
+			if s, ok := t.(string); ok {
+				result = append(result, s)
+			}
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
+
 // newDIDCommActionMsg creates new DIDCommAction message.
 func (s *Service) newDIDCommActionMsg(md *MetaData) service.DIDCommAction {
 	// create the message for the channel
@@ -636,19 +811,33 @@ func (s *Service) newDIDCommActionMsg(md *MetaData) service.DIDCommAction {
 		ProtocolName: Name,
 		Message:      md.msgClone,
 		Continue: func(opt interface{}) {
+			done, addErr := s.inFlight.Add()
+			if addErr != nil {
+				md.logger().Warnf("action left pending: %s", addErr)
+
+				return
+			}
+
 			if fn, ok := opt.(Opt); ok {
 				fn(md)
 			}
 
 			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-				logger.Errorf("delete transitional payload", err)
+				md.logger().Errorf("delete transitional payload: %s", err)
 			}
 
-			s.processCallback(md)
+			s.processCallback(md, done)
 		},
 		Stop: func(cErr error) {
+			done, addErr := s.inFlight.Add()
+			if addErr != nil {
+				md.logger().Warnf("action left pending: %s", addErr)
+
+				return
+			}
+
 			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-				logger.Errorf("delete transitional payload", err)
+				md.logger().Errorf("delete transitional payload: %s", err)
 			}
 
 			if cErr == nil {
@@ -656,13 +845,15 @@ func (s *Service) newDIDCommActionMsg(md *MetaData) service.DIDCommAction {
 			}
 
 			md.err = customError{error: cErr}
-			s.processCallback(md)
+			s.processCallback(md, done)
 		},
 		Properties: newEventProps(md),
 	}
 }
 
 func (s *Service) execute(next state, md *MetaData) (state, stateAction, error) {
+	md.logger().Debugf("executing state %s", next.Name())
+
 	md.state = next
 	s.sendMsgEvents(md, next.Name(), service.PreState)
 
@@ -684,15 +875,19 @@ func (s *Service) execute(next state, md *MetaData) (state, stateAction, error)
 
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *MetaData, stateID string, stateType service.StateMsgType) {
+	msg := service.StateMsg{
+		ProtocolName: Name,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	}
+
+	service.ObserveStateMsg(&msg)
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Name,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
+		handler <- msg
 	}
 }
 
@@ -711,3 +906,11 @@ func (s *Service) Accept(msgType string) bool {
 
 	return false
 }
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		ProposeCredentialMsgType, OfferCredentialMsgType, RequestCredentialMsgType,
+		IssueCredentialMsgType, AckMsgType, ProblemReportMsgType,
+	}
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -17,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -25,6 +29,8 @@ import (
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
+	trustregistryfile "github.com/hyperledger/aries-framework-go/pkg/trustregistry/file"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -1386,6 +1392,46 @@ func TestService_ActionStop(t *testing.T) {
 	})
 }
 
+func TestService_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := issuecredentialMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	msg := service.NewDIDCommMsgMap(ProposeCredential{Type: ProposeCredentialMsgType})
+	require.NoError(t, msg.SetID(uuid.New().String()))
+
+	_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	action := <-ch
+
+	require.NoError(t, svc.Drain(context.Background()))
+
+	// the action stays pending once the service is draining
+	action.Continue(WithOfferCredential(&OfferCredential{}))
+	action.Stop(nil)
+
+	actions, err := svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.ErrorIs(t, svc.ActionContinue(actions[0].PIID, nil), shutdown.ErrShuttingDown)
+	require.ErrorIs(t, svc.ActionStop(actions[0].PIID, nil), shutdown.ErrShuttingDown)
+
+	actions, err = svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoning), &abandoning{})
@@ -1494,3 +1540,169 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
+
+func TestService_TrustDecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry, err := trustregistryfile.Parse([]byte(`
+issuers:
+  - did: did:example:university
+    credentialTypes: [UniversityDegreeCredential]
+`))
+	require.NoError(t, err)
+
+	storeProvider := mem.NewProvider()
+
+	newService := func(registry trustregistry.Provider) *Service {
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+		var p Provider = provider
+		if registry != nil {
+			p = &mockTrustRegistryProvider{Provider: provider, registry: registry}
+		}
+
+		svc, err := New(p)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	credential := func(issuer interface{}, types ...string) decorator.Attachment {
+		return decorator.Attachment{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+			"@context": []string{"https://www.w3.org/2018/credentials/v1"},
+			"type":     append([]string{"VerifiableCredential"}, types...),
+			"issuer":   issuer,
+		}}}
+	}
+
+	receive := func(svc *Service, msg interface{}) map[string]interface{} {
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		defer func() { require.NoError(t, svc.UnregisterActionEvent(ch)) }()
+
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		in, err := service.ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+		require.NoError(t, in.SetID(uuid.New().String()))
+
+		_, err = svc.HandleInbound(in, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		return (<-ch).Properties.All()
+	}
+
+	t.Run("Offer of authorized issuer", func(t *testing.T) {
+		detail := decorator.Attachment{Data: decorator.AttachmentData{JSON: map[string]interface{}{
+			"credential": credential(map[string]interface{}{"id": "did:example:university"},
+				"UniversityDegreeCredential").Data.JSON,
+			"options": map[string]interface{}{"proofType": "Ed25519Signature2018"},
+		}}}
+
+		properties := receive(newService(registry), &OfferCredential{
+			Type:         OfferCredentialMsgType,
+			OffersAttach: []decorator.Attachment{detail},
+		})
+
+		require.Equal(t, &trustregistry.Decision{
+			Authorized: true,
+			Checks: []trustregistry.Check{{
+				Role: trustregistry.RoleIssuer, DID: "did:example:university",
+				CredentialTypes: []string{"UniversityDegreeCredential"}, Authorized: true,
+			}},
+		}, properties["trustDecision"])
+	})
+
+	t.Run("Issued credentials of issuer not authorized", func(t *testing.T) {
+		thID := uuid.New().String()
+
+		store, err := storeProvider.OpenStore(Name)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(stateNameKey+thID, []byte(stateNameRequestSent)))
+
+		properties := receive(newService(registry), &struct {
+			IssueCredential
+			Thread decorator.Thread `json:"~thread"`
+		}{
+			IssueCredential: IssueCredential{
+				Type: IssueCredentialMsgType,
+				CredentialsAttach: []decorator.Attachment{
+					credential("did:example:university", "UniversityDegreeCredential"),
+					credential("did:example:university", "PassportCredential"),
+				},
+			},
+			Thread: decorator.Thread{ID: thID},
+		})
+
+		decision, ok := properties["trustDecision"].(*trustregistry.Decision)
+		require.True(t, ok)
+		require.False(t, decision.Authorized)
+		require.Len(t, decision.Checks, 2)
+		require.True(t, decision.Checks[0].Authorized)
+		require.False(t, decision.Checks[1].Authorized)
+	})
+
+	t.Run("Undetermined issuers", func(t *testing.T) {
+		properties := receive(newService(registry), &OfferCredential{
+			Type: OfferCredentialMsgType,
+			OffersAttach: []decorator.Attachment{
+				credential("did:example:university", "UniversityDegreeCredential"),
+				{ID: "jwt", Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(
+					[]byte("eyJhbGciOiJub25lIn0.eyJpc3MiOiJkaWQ6ZXhhbXBsZTp1bml2ZXJzaXR5In0."))}},
+				{ID: "no-issuer", Data: decorator.AttachmentData{JSON: map[string]interface{}{"type": "VerifiableCredential"}}},
+				{ID: "empty", Data: decorator.AttachmentData{}},
+			},
+		})
+
+		decision, ok := properties["trustDecision"].(*trustregistry.Decision)
+		require.True(t, ok)
+		require.False(t, decision.Authorized)
+		require.Len(t, decision.Checks, 4)
+		require.True(t, decision.Checks[0].Authorized)
+
+		for i, reason := range []string{
+			"attachment jwt: not a JSON credential",
+			"attachment no-issuer: credential without issuer",
+			"attachment empty: fetch: ",
+		} {
+			check := decision.Checks[i+1]
+			require.False(t, check.Authorized)
+			require.True(t, check.Undetermined)
+			require.Equal(t, trustregistry.RoleIssuer, check.Role)
+			require.Contains(t, check.Error, reason)
+		}
+	})
+
+	t.Run("No trust decision", func(t *testing.T) {
+		// no credential of which to check the issuer
+		require.NotContains(t, receive(newService(registry), &OfferCredential{
+			Type: OfferCredentialMsgType,
+		}), "trustDecision")
+
+		// no trust registry
+		require.NotContains(t, receive(newService(nil), &OfferCredential{
+			Type:         OfferCredentialMsgType,
+			OffersAttach: []decorator.Attachment{credential("did:example:university", "UniversityDegreeCredential")},
+		}), "trustDecision")
+
+		// no trust decision about proposals
+		require.NotContains(t, receive(newService(registry), &ProposeCredential{
+			Type:          ProposeCredentialMsgType,
+			FiltersAttach: []decorator.Attachment{credential("did:example:university", "UniversityDegreeCredential")},
+		}), "trustDecision")
+	})
+}
+
+type mockTrustRegistryProvider struct {
+	Provider
+	registry trustregistry.Provider
+}
+
+func (p *mockTrustRegistryProvider) TrustRegistry() trustregistry.Provider {
+	return p.registry
+}
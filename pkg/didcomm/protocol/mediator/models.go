@@ -55,3 +55,149 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// Deny route deny message, sent back when the mediation request is rejected.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0211-route-coordination#mediation-deny
+type Deny struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// KeylistQuery route keylist query message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0211-route-coordination#key-list-query
+type KeylistQuery struct {
+	Type     string    `json:"@type,omitempty"`
+	ID       string    `json:"@id,omitempty"`
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate selects the page of recipient keys returned by a keylist query.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// Keylist route keylist message, answering a keylist query.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0211-route-coordination#key-list
+type Keylist struct {
+	Type       string            `json:"@type,omitempty"`
+	ID         string            `json:"@id,omitempty"`
+	Keys       []KeylistKey      `json:"keys"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	Thread     *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// KeylistKey route keylist entry.
+type KeylistKey struct {
+	RecipientKey string `json:"recipient_key"`
+}
+
+// Pagination describes the page of recipient keys of a keylist.
+type Pagination struct {
+	// Count is the number of keys of the page.
+	Count int `json:"count"`
+	// Offset is the number of keys skipped before the page.
+	Offset int `json:"offset"`
+	// Remaining is the number of keys after the page.
+	Remaining int `json:"remaining"`
+}
+
+// RequestV2 coordinate mediation 2.0 mediate request message. The coordinate mediation 2.0 messages are DIDComm v2
+// messages, threaded by thid.
+// https://didcomm.org/coordinate-mediation/2.0/
+type RequestV2 struct {
+	Type string   `json:"type,omitempty"`
+	ID   string   `json:"id,omitempty"`
+	Body struct{} `json:"body"`
+}
+
+// GrantV2 coordinate mediation 2.0 mediate grant message.
+type GrantV2 struct {
+	Type     string      `json:"type,omitempty"`
+	ID       string      `json:"id,omitempty"`
+	Body     GrantV2Body `json:"body"`
+	ThreadID string      `json:"thid,omitempty"`
+}
+
+// GrantV2Body is the body of the coordinate mediation 2.0 mediate grant message.
+type GrantV2Body struct {
+	RoutingDID []string `json:"routing_did"`
+}
+
+// DenyV2 coordinate mediation 2.0 mediate deny message.
+type DenyV2 struct {
+	Type     string   `json:"type,omitempty"`
+	ID       string   `json:"id,omitempty"`
+	Body     struct{} `json:"body"`
+	ThreadID string   `json:"thid,omitempty"`
+}
+
+// KeylistUpdateV2 coordinate mediation 2.0 keylist update message.
+type KeylistUpdateV2 struct {
+	Type string              `json:"type,omitempty"`
+	ID   string              `json:"id,omitempty"`
+	Body KeylistUpdateV2Body `json:"body"`
+}
+
+// KeylistUpdateV2Body is the body of the coordinate mediation 2.0 keylist update message.
+type KeylistUpdateV2Body struct {
+	Updates []UpdateV2 `json:"updates"`
+}
+
+// UpdateV2 coordinate mediation 2.0 recipient DID update.
+type UpdateV2 struct {
+	RecipientDID string `json:"recipient_did"`
+	Action       string `json:"action"`
+}
+
+// KeylistUpdateResponseV2 coordinate mediation 2.0 keylist update response message.
+type KeylistUpdateResponseV2 struct {
+	Type     string                      `json:"type,omitempty"`
+	ID       string                      `json:"id,omitempty"`
+	Body     KeylistUpdateResponseV2Body `json:"body"`
+	ThreadID string                      `json:"thid,omitempty"`
+}
+
+// KeylistUpdateResponseV2Body is the body of the coordinate mediation 2.0 keylist update response message.
+type KeylistUpdateResponseV2Body struct {
+	Updated []UpdateResponseV2 `json:"updated"`
+}
+
+// UpdateResponseV2 coordinate mediation 2.0 recipient DID update result.
+type UpdateResponseV2 struct {
+	RecipientDID string `json:"recipient_did"`
+	Action       string `json:"action"`
+	Result       string `json:"result"`
+}
+
+// KeylistQueryV2 coordinate mediation 2.0 keylist query message.
+type KeylistQueryV2 struct {
+	Type string             `json:"type,omitempty"`
+	ID   string             `json:"id,omitempty"`
+	Body KeylistQueryV2Body `json:"body"`
+}
+
+// KeylistQueryV2Body is the body of the coordinate mediation 2.0 keylist query message.
+type KeylistQueryV2Body struct {
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// KeylistV2 coordinate mediation 2.0 keylist message.
+type KeylistV2 struct {
+	Type     string        `json:"type,omitempty"`
+	ID       string        `json:"id,omitempty"`
+	Body     KeylistV2Body `json:"body"`
+	ThreadID string        `json:"thid,omitempty"`
+}
+
+// KeylistV2Body is the body of the coordinate mediation 2.0 keylist message.
+type KeylistV2Body struct {
+	Keys       []KeylistKeyV2 `json:"keys"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// KeylistKeyV2 coordinate mediation 2.0 keylist entry.
+type KeylistKeyV2 struct {
+	RecipientDID string `json:"recipient_did"`
+}
@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -51,6 +56,42 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// DenyMsgType defines the route coordination request deny message type.
+	DenyMsgType = CoordinationSpec + "mediate-deny"
+
+	// KeylistQueryMsgType defines the route coordination key list query message type.
+	KeylistQueryMsgType = CoordinationSpec + "keylist-query"
+
+	// KeylistMsgType defines the route coordination key list message type.
+	KeylistMsgType = CoordinationSpec + "keylist"
+)
+
+// constants for coordinate mediation 2.0 spec types, used by DIDComm v2 recipients.
+const (
+	// CoordinationSpecV2 defines the coordinate mediation 2.0 spec.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestMsgTypeV2 defines the coordinate mediation 2.0 request message type.
+	RequestMsgTypeV2 = CoordinationSpecV2 + "mediate-request"
+
+	// GrantMsgTypeV2 defines the coordinate mediation 2.0 grant message type.
+	GrantMsgTypeV2 = CoordinationSpecV2 + "mediate-grant"
+
+	// DenyMsgTypeV2 defines the coordinate mediation 2.0 deny message type.
+	DenyMsgTypeV2 = CoordinationSpecV2 + "mediate-deny"
+
+	// KeylistUpdateMsgTypeV2 defines the coordinate mediation 2.0 key list update message type.
+	KeylistUpdateMsgTypeV2 = CoordinationSpecV2 + "keylist-update"
+
+	// KeylistUpdateResponseMsgTypeV2 defines the coordinate mediation 2.0 key list update response message type.
+	KeylistUpdateResponseMsgTypeV2 = CoordinationSpecV2 + "keylist-update-response"
+
+	// KeylistQueryMsgTypeV2 defines the coordinate mediation 2.0 key list query message type.
+	KeylistQueryMsgTypeV2 = CoordinationSpecV2 + "keylist-query"
+
+	// KeylistMsgTypeV2 defines the coordinate mediation 2.0 key list message type.
+	KeylistMsgTypeV2 = CoordinationSpecV2 + "keylist"
 )
 
 // constants for key list update processing
@@ -65,6 +106,12 @@ const (
 	// server error while storing the key.
 	serverError = "server_error"
 
+	// invalid update, like the removal of a key of another agent.
+	clientError = "client_error"
+
+	// removed key was not in the store.
+	noChange = "no_change"
+
 	// key save success.
 	success = "success"
 )
@@ -77,6 +124,11 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	routeDenyKey = "deny_%s"
+
+	// tag of the route keys, its value being the DID of the agent the key belongs to.
+	routeKeyTag = "route_key"
 )
 
 const (
@@ -89,6 +141,9 @@ var ErrConnectionNotFound = errors.New("connection not found")
 // ErrRouterNotRegistered router not registered error.
 var ErrRouterNotRegistered = errors.New("router not registered")
 
+// ErrMediationDenied is returned when the router denies the mediation request.
+var ErrMediationDenied = errors.New("mediation denied")
+
 // provider contains dependencies for the Routing protocol and is typically created by using aries.Context().
 type provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -120,11 +175,14 @@ type callback struct {
 	theirDID string
 	options  *Options
 	err      error
+	// done is called once the callback is handled.
+	done func()
 }
 
 type connections interface {
 	GetConnectionIDByDIDs(string, string) (string, error)
 	GetConnectionRecord(string) (*connection.Record, error)
+	QueryConnectionRecords() ([]*connection.Record, error)
 }
 
 // Service for Route Coordination protocol.
@@ -139,9 +197,13 @@ type Service struct {
 	kms                  kms.KeyManager
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
+	keylistMap           map[string]chan *Keylist
 	keylistUpdateMapLock sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
+	inFlight             shutdown.Tracker
+	// checkedRouteKeys holds the route keys of which the tags were checked.
+	checkedRouteKeys sync.Map
 }
 
 // New return route coordination service.
@@ -152,7 +214,7 @@ func New(prov provider) (*Service, error) {
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeKeyTag}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -180,12 +242,14 @@ func New(prov provider) (*Service, error) {
 		vdRegistry:       prov.VDRegistry(),
 		connectionLookup: connectionLookup,
 		keylistUpdateMap: make(map[string]chan *KeylistUpdateResponse),
+		keylistMap:       make(map[string]chan *Keylist),
 		callbacks:        make(chan *callback),
 		messagePickupSvc: messagePickupSvc,
 	}
 
 	logger.Debugf("default endpoint: %s", s.endpoint)
 
+	go s.tagRouteKeys(connectionLookup)
 	go s.listenForCallbacks()
 
 	return s, nil
@@ -193,32 +257,71 @@ func New(prov provider) (*Service, error) {
 
 func (s *Service) listenForCallbacks() {
 	for c := range s.callbacks {
-		logger.Debugf("handling user callback %+v with options %+v", c, c.options)
+		s.handleCallback(c)
 
-		if c.err != nil {
-			go s.handleUserRejection(c)
-
-			continue
+		if c.done != nil {
+			c.done()
 		}
+	}
+}
 
-		switch c.msg.Type() {
-		case RequestMsgType:
-			err := s.handleInboundRequest(c)
-			if err != nil {
-				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
-			}
-		default:
-			logger.Warnf("ignoring unsupported message type %s", c.msg.Type())
+func (s *Service) handleCallback(c *callback) {
+	logger.Debugf("handling user callback %+v with options %+v", c, c.options)
+
+	if c.err != nil {
+		s.handleUserRejection(c)
+
+		return
+	}
+
+	switch c.msg.Type() {
+	case RequestMsgType, RequestMsgTypeV2:
+		err := s.handleInboundRequest(c)
+		if err != nil {
+			logger.Errorf("failed to handle inbound request: %+v : %s", c.msg, err)
 		}
+	default:
+		logger.Warnf("ignoring unsupported message type %s", c.msg.Type())
+	}
+}
+
+// Drain refuses the new inbound messages and action callbacks, and waits for the messages and callbacks being
+// handled, or for ctx to be done. The mediation requests whose action is continued while draining are dropped.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", Coordination, err)
+	}
+
+	return nil
+}
+
+// processCallback passes c to the callback listener, or drops it if the service is draining.
+func (s *Service) processCallback(c *callback) {
+	done, err := s.inFlight.Add()
+	if err != nil {
+		logger.Warnf("dropped user callback for msgID=%s: %s", c.msg.ID(), err)
+
+		return
 	}
+
+	c.done = done
+	s.callbacks <- c
 }
 
 func (s *Service) handleUserRejection(c *callback) {
 	logger.Infof("user aborted response action for msgID=%s", c.msg.ID())
+
+	if c.msg.Type() != RequestMsgType && c.msg.Type() != RequestMsgTypeV2 {
+		return
+	}
+
+	if err := s.outbound.SendToDID(outboundDeny(c.msg), c.myDID, c.theirDID); err != nil {
+		logger.Errorf("failed to send mediate deny for msgID=%s : %s", c.msg.ID(), err)
+	}
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestMsgTypeV2
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -249,12 +352,12 @@ func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string
 					c.options = &Options{}
 				}
 
-				s.callbacks <- c
+				s.processCallback(c)
 			},
 			Stop: func(err error) {
 				c.err = err
 
-				s.callbacks <- c
+				s.processCallback(c)
 			},
 		}
 	}()
@@ -271,16 +374,22 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	}
 
 	// perform action on inbound message asynchronously
-	go func(msg service.DIDCommMsg) {
+	handle := func(msg service.DIDCommMsg) {
 		var err error
 
 		switch msg.Type() {
-		case GrantMsgType:
+		case GrantMsgType, GrantMsgTypeV2:
 			err = s.saveGrant(msg)
-		case KeylistUpdateMsgType:
+		case DenyMsgType, DenyMsgTypeV2:
+			err = s.saveDeny(msg)
+		case KeylistUpdateMsgType, KeylistUpdateMsgTypeV2:
 			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
-		case KeylistUpdateResponseMsgType:
+		case KeylistUpdateResponseMsgType, KeylistUpdateResponseMsgTypeV2:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistQueryMsgType, KeylistQueryMsgTypeV2:
+			err = s.handleKeylistQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistMsgType, KeylistMsgTypeV2:
+			err = s.handleKeylist(msg)
 		case service.ForwardMsgType:
 			err = s.handleForward(msg)
 		}
@@ -308,7 +417,13 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 				logutil.CreateKeyValueString("msgID", msg.ID()),
 				connectionIDLog)
 		}
-	}(msg.Clone())
+	}
+
+	inbound := msg.Clone()
+
+	if err := s.inFlight.Go(func() { handle(inbound) }); err != nil {
+		return "", fmt.Errorf("handle inbound: %w", err)
+	}
 
 	return msg.ID(), nil
 }
@@ -331,14 +446,26 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
-	switch msgType {
-	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType:
-		return true
+	for _, t := range s.MessageTypes() {
+		if t == msgType {
+			return true
+		}
 	}
 
 	return false
 }
 
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		RequestMsgType, GrantMsgType, DenyMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType,
+		KeylistQueryMsgType, KeylistMsgType,
+		RequestMsgTypeV2, GrantMsgTypeV2, DenyMsgTypeV2, KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+		KeylistQueryMsgTypeV2, KeylistMsgTypeV2,
+		service.ForwardMsgType,
+	}
+}
+
 // Name of the service.
 func (s *Service) Name() string {
 	return Coordination
@@ -376,9 +503,28 @@ func (s *Service) handleInboundRequest(c *callback) error {
 		return fmt.Errorf("handleInboundRequest: failed to handle inbound request : %w", err)
 	}
 
+	if c.msg.Type() == RequestMsgTypeV2 {
+		return s.outbound.SendToDID(&GrantV2{
+			Type:     GrantMsgTypeV2,
+			ID:       uuid.New().String(),
+			Body:     GrantV2Body{RoutingDID: grant.RoutingKeys},
+			ThreadID: c.msg.ID(),
+		}, c.myDID, c.theirDID)
+	}
+
 	return s.outbound.SendToDID(grant, c.myDID, c.theirDID)
 }
 
+func outboundDeny(request service.DIDCommMsg) interface{} {
+	thread := &decorator.Thread{ID: request.ID()}
+
+	if request.Type() == RequestMsgTypeV2 {
+		return &DenyV2{Type: DenyMsgTypeV2, ID: uuid.New().String(), ThreadID: request.ID()}
+	}
+
+	return &Deny{Type: DenyMsgType, ID: uuid.New().String(), Thread: thread}
+}
+
 func outboundGrant(
 	msgID string, opts *Options,
 	defaultEndpoint string, defaultKey func() (string, error)) (*Grant, error) {
@@ -408,68 +554,140 @@ func outboundGrant(
 }
 
 func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID string) error {
-	// unmarshal the payload
-	keyUpdate := &KeylistUpdate{}
-
-	err := msg.Decode(keyUpdate)
+	updates, err := decodeKeylistUpdate(msg)
 	if err != nil {
 		return fmt.Errorf("route key list update message unmarshal : %w", err)
 	}
 
-	var updates []UpdateResponse
+	results := make([]UpdateResponse, 0, len(updates))
 
 	// update the db
-	for _, v := range keyUpdate.Updates {
-		if v.Action == add {
-			val := theirDID
-			result := success
-
-			err = s.routeStore.Put(dataKey(v.RecipientKey), []byte(val))
-			if err != nil {
-				logger.Errorf("failed to add the route key to store : %s", err)
-
-				result = serverError
-			}
+	for _, v := range updates {
+		results = append(results, UpdateResponse{
+			RecipientKey: v.RecipientKey,
+			Action:       v.Action,
+			Result:       s.applyUpdate(v, theirDID),
+		})
+	}
 
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       result,
-			})
-		} else if v.Action == remove {
-			// TODO remove from the store
-
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       serverError,
-			})
+	// send the key update response
+	if msg.Type() == KeylistUpdateMsgTypeV2 {
+		updated := make([]UpdateResponseV2, len(results))
+		for i, r := range results {
+			updated[i] = UpdateResponseV2{RecipientDID: r.RecipientKey, Action: r.Action, Result: r.Result}
 		}
+
+		return s.outbound.SendToDID(&KeylistUpdateResponseV2{
+			Type:     KeylistUpdateResponseMsgTypeV2,
+			ID:       uuid.New().String(),
+			Body:     KeylistUpdateResponseV2Body{Updated: updated},
+			ThreadID: msg.ID(),
+		}, myDID, theirDID)
 	}
 
-	// send the key update response
-	updateResponse := &KeylistUpdateResponse{
+	return s.outbound.SendToDID(&KeylistUpdateResponse{
 		Type:    KeylistUpdateResponseMsgType,
 		ID:      msg.ID(),
-		Updated: updates,
+		Updated: results,
+	}, myDID, theirDID)
+}
+
+// applyUpdate adds or removes the route key of the agent identified by theirDID, and returns the result of the
+// update. An agent can't remove the keys of another agent.
+func (s *Service) applyUpdate(update Update, theirDID string) string {
+	switch update.Action {
+	case add:
+		err := s.routeStore.Put(dataKey(update.RecipientKey), []byte(theirDID),
+			storage.Tag{Name: routeKeyTag, Value: routeKeyTagValue(theirDID)})
+		if err != nil {
+			logger.Errorf("failed to add the route key to store : %s", err)
+
+			return serverError
+		}
+
+		return success
+	case remove:
+		owner, err := s.routeStore.Get(dataKey(update.RecipientKey))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return noChange
+		}
+
+		if err != nil {
+			logger.Errorf("failed to get the route key from store : %s", err)
+
+			return serverError
+		}
+
+		if string(owner) != theirDID {
+			return clientError
+		}
+
+		if err = s.routeStore.Delete(dataKey(update.RecipientKey)); err != nil {
+			logger.Errorf("failed to remove the route key from store : %s", err)
+
+			return serverError
+		}
+
+		return success
+	default:
+		return clientError
 	}
+}
+
+func decodeKeylistUpdate(msg service.DIDCommMsg) ([]Update, error) {
+	if msg.Type() == KeylistUpdateMsgTypeV2 {
+		keyUpdate := &KeylistUpdateV2{}
 
-	return s.outbound.SendToDID(updateResponse, myDID, theirDID)
+		if err := msg.Decode(keyUpdate); err != nil {
+			return nil, err
+		}
+
+		updates := make([]Update, len(keyUpdate.Body.Updates))
+		for i, u := range keyUpdate.Body.Updates {
+			updates[i] = Update{RecipientKey: u.RecipientDID, Action: u.Action}
+		}
+
+		return updates, nil
+	}
+
+	keyUpdate := &KeylistUpdate{}
+
+	if err := msg.Decode(keyUpdate); err != nil {
+		return nil, err
+	}
+
+	return keyUpdate.Updates, nil
 }
 
 func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	respMsg := &KeylistUpdateResponse{}
 
-	err := msg.Decode(respMsg)
-	if err != nil {
+	if msg.Type() == KeylistUpdateResponseMsgTypeV2 {
+		v2 := &KeylistUpdateResponseV2{}
+
+		if err := msg.Decode(v2); err != nil {
+			return fmt.Errorf("route keylist update response message unmarshal : %w", err)
+		}
+
+		for _, u := range v2.Body.Updated {
+			respMsg.Updated = append(respMsg.Updated, UpdateResponse{
+				RecipientKey: u.RecipientDID,
+				Action:       u.Action,
+				Result:       u.Result,
+			})
+		}
+	} else if err := msg.Decode(respMsg); err != nil {
 		return fmt.Errorf("route keylist update response message unmarshal : %w", err)
 	}
 
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("route keylist update response thread ID : %w", err)
+	}
+
 	// check if there are any channels registered for the message ID
-	keylistUpdateCh := s.getKeyUpdateResponseCh(respMsg.ID)
+	keylistUpdateCh := s.getKeyUpdateResponseCh(thID)
 
 	if keylistUpdateCh != nil {
 		// invoke the channel for the incoming message
@@ -479,6 +697,215 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleKeylistQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var paginate *Paginate
+
+	if msg.Type() == KeylistQueryMsgTypeV2 {
+		query := &KeylistQueryV2{}
+
+		if err := msg.Decode(query); err != nil {
+			return fmt.Errorf("route keylist query message unmarshal : %w", err)
+		}
+
+		paginate = query.Body.Paginate
+	} else {
+		query := &KeylistQuery{}
+
+		if err := msg.Decode(query); err != nil {
+			return fmt.Errorf("route keylist query message unmarshal : %w", err)
+		}
+
+		paginate = query.Paginate
+	}
+
+	keys, err := s.routeKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("route keylist query : %w", err)
+	}
+
+	page, pagination := paginateKeys(keys, paginate)
+	thread := &decorator.Thread{ID: msg.ID()}
+
+	if msg.Type() == KeylistQueryMsgTypeV2 {
+		body := KeylistV2Body{Keys: make([]KeylistKeyV2, len(page)), Pagination: pagination}
+		for i, k := range page {
+			body.Keys[i] = KeylistKeyV2{RecipientDID: k}
+		}
+
+		return s.outbound.SendToDID(&KeylistV2{
+			Type:     KeylistMsgTypeV2,
+			ID:       uuid.New().String(),
+			Body:     body,
+			ThreadID: msg.ID(),
+		}, myDID, theirDID)
+	}
+
+	keylist := &Keylist{
+		Type:       KeylistMsgType,
+		ID:         uuid.New().String(),
+		Keys:       make([]KeylistKey, len(page)),
+		Pagination: pagination,
+		Thread:     thread,
+	}
+
+	for i, k := range page {
+		keylist.Keys[i] = KeylistKey{RecipientKey: k}
+	}
+
+	return s.outbound.SendToDID(keylist, myDID, theirDID)
+}
+
+// tagRouteKeys tags the route keys saved untagged by previous versions, for them to be listed in the keylists.
+// The store cannot list the untagged keys, so the keys known from the connection records are tagged at startup,
+// the other ones being tagged once a message is forwarded to them.
+func (s *Service) tagRouteKeys(lookup connections) {
+	records, err := lookup.QueryConnectionRecords()
+	if err != nil {
+		logger.Errorf("failed to tag the route keys: query connections: %s", err)
+
+		return
+	}
+
+	for _, record := range records {
+		for _, key := range append([]string{record.TheirDID}, record.RecipientKeys...) {
+			if key == "" {
+				continue
+			}
+
+			owner, err := s.routeStore.Get(dataKey(key))
+			if errors.Is(err, storage.ErrDataNotFound) {
+				continue
+			}
+
+			if err != nil {
+				logger.Warnf("failed to get route key %s : %s", key, err)
+
+				continue
+			}
+
+			s.tagRouteKey(key, string(owner))
+		}
+	}
+}
+
+// tagRouteKey tags the route key saved untagged by previous versions, for it to be listed in the keylist of the
+// agent identified by theirDID.
+func (s *Service) tagRouteKey(key, theirDID string) {
+	if _, checked := s.checkedRouteKeys.LoadOrStore(key, struct{}{}); checked {
+		return
+	}
+
+	tags, err := s.routeStore.GetTags(dataKey(key))
+	if err != nil {
+		logger.Warnf("failed to get the tags of route key %s : %s", key, err)
+
+		return
+	}
+
+	for _, tag := range tags {
+		if tag.Name == routeKeyTag {
+			return
+		}
+	}
+
+	err = s.routeStore.Put(dataKey(key), []byte(theirDID),
+		storage.Tag{Name: routeKeyTag, Value: routeKeyTagValue(theirDID)})
+	if err != nil {
+		logger.Warnf("failed to tag route key %s : %s", key, err)
+	}
+}
+
+// routeKeys returns the sorted route keys of the agent identified by theirDID.
+// The route keys saved untagged by previous versions are listed once tagged, see tagRouteKeys.
+func (s *Service) routeKeys(theirDID string) ([]string, error) {
+	records, err := s.routeStore.Query(routeKeyTag + ":" + routeKeyTagValue(theirDID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var keys []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// paginateKeys returns the page of keys selected by paginate, all the keys being returned when it is nil.
+func paginateKeys(keys []string, paginate *Paginate) ([]string, *Pagination) {
+	offset, end := 0, len(keys)
+
+	if paginate != nil {
+		if paginate.Offset > 0 {
+			offset = paginate.Offset
+		}
+
+		if offset > len(keys) {
+			offset = len(keys)
+		}
+
+		if paginate.Limit > 0 && offset+paginate.Limit < len(keys) {
+			end = offset + paginate.Limit
+		}
+	}
+
+	return keys[offset:end], &Pagination{Count: end - offset, Offset: offset, Remaining: len(keys) - end}
+}
+
+func (s *Service) handleKeylist(msg service.DIDCommMsg) error {
+	keylist := &Keylist{}
+
+	if msg.Type() == KeylistMsgTypeV2 {
+		v2 := &KeylistV2{}
+
+		if err := msg.Decode(v2); err != nil {
+			return fmt.Errorf("route keylist message unmarshal : %w", err)
+		}
+
+		keylist.Pagination = v2.Body.Pagination
+		for _, k := range v2.Body.Keys {
+			keylist.Keys = append(keylist.Keys, KeylistKey{RecipientKey: k.RecipientDID})
+		}
+	} else if err := msg.Decode(keylist); err != nil {
+		return fmt.Errorf("route keylist message unmarshal : %w", err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("route keylist thread ID : %w", err)
+	}
+
+	if keylistCh := s.getKeylistCh(thID); keylistCh != nil {
+		select {
+		case keylistCh <- keylist:
+		default:
+			logger.Warnf("dropped keylist for thread %s, a keylist was already received", thID)
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	forward := &model.Forward{}
@@ -495,6 +922,8 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
 
+	s.tagRouteKey(forward.To, string(theirDID))
+
 	dest, err := service.GetDestination(string(theirDID), s.vdRegistry)
 	if err != nil {
 		return fmt.Errorf("get destination : %w", err)
@@ -579,6 +1008,11 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 
 	err = backoff.Retry(func() error {
 		src, err = s.routeStore.Get(fmt.Sprintf(routeGrantKey, id))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			if _, denyErr := s.routeStore.Get(fmt.Sprintf(routeDenyKey, id)); denyErr == nil {
+				return backoff.Permanent(ErrMediationDenied)
+			}
+		}
 
 		return err
 	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), uint64(timeout/time.Second)))
@@ -597,13 +1031,46 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 	return grant, nil
 }
 
+// saveGrant saves the grant under the ID of the request it answers, the coordinate mediation 2.0 grants being
+// saved as 1.0 grants.
 func (s *Service) saveGrant(grant service.DIDCommMsg) error {
-	src, err := json.Marshal(grant)
+	var (
+		src []byte
+		err error
+	)
+
+	if grant.Type() == GrantMsgTypeV2 {
+		v2 := &GrantV2{}
+
+		if err = grant.Decode(v2); err != nil {
+			return fmt.Errorf("decode grant: %w", err)
+		}
+
+		src, err = json.Marshal(&Grant{Type: GrantMsgType, ID: v2.ID, RoutingKeys: v2.Body.RoutingDID})
+	} else {
+		src, err = json.Marshal(grant)
+	}
+
 	if err != nil {
 		return fmt.Errorf("marshal grant: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
+	thID, err := grant.ThreadID()
+	if err != nil {
+		return fmt.Errorf("grant thread ID: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, thID), src)
+}
+
+// saveDeny saves the deny under the ID of the request it answers.
+func (s *Service) saveDeny(deny service.DIDCommMsg) error {
+	thID, err := deny.ThreadID()
+	if err != nil {
+		return fmt.Errorf("deny thread ID: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeDenyKey, thID), []byte(deny.ID()))
 }
 
 // Unregister unregisters the agent with the router.
@@ -660,16 +1127,19 @@ func (s *Service) GetConnections() ([]string, error) {
 // TODO https://github.com/hyperledger/aries-framework-go/issues/1105 Support to Add multiple
 //  recKeys to the Router
 func (s *Service) AddKey(connID, recKey string) error {
-	// check if router is already registered
-	err := s.ensureConnectionExists(connID)
-	if err != nil {
-		return fmt.Errorf("ensure connection exists: %w", err)
-	}
+	return s.updateKeylist(connID, recKey, add)
+}
 
-	// get the connection record for the ID to fetch DID information
-	conn, err := s.getConnection(connID)
+// RemoveKey removes a recKey of the agent from the registered router, which then stops forwarding the messages
+// sent to it. This method blocks until a response is received from the router or it times out.
+func (s *Service) RemoveKey(connID, recKey string) error {
+	return s.updateKeylist(connID, recKey, remove)
+}
+
+func (s *Service) updateKeylist(connID, recKey, action string) error {
+	conn, err := s.routerConnection(connID)
 	if err != nil {
-		return fmt.Errorf("get connection: %w", err)
+		return err
 	}
 
 	// generate message ID
@@ -679,13 +1149,16 @@ func (s *Service) AddKey(connID, recKey string) error {
 	keyUpdateCh := make(chan *KeylistUpdateResponse)
 	s.setKeyUpdateResponseCh(msgID, keyUpdateCh)
 
+	// remove the channel once its been processed
+	defer s.setKeyUpdateResponseCh(msgID, nil)
+
 	keyUpdate := &KeylistUpdate{
 		ID:   msgID,
 		Type: KeylistUpdateMsgType,
 		Updates: []Update{
 			{
 				RecipientKey: recKey,
-				Action:       add,
+				Action:       action,
 			},
 		},
 	}
@@ -696,17 +1169,61 @@ func (s *Service) AddKey(connID, recKey string) error {
 
 	select {
 	case keyUpdateResp := <-keyUpdateCh:
-		if err := processKeylistUpdateResp(recKey, keyUpdateResp); err != nil {
-			return err
-		}
+		return processKeylistUpdateResp(recKey, action, keyUpdateResp)
 	case <-time.After(updateTimeout):
 		return errors.New("timeout waiting for keylist update response from the router")
 	}
+}
 
-	// remove the channel once its been processed
-	s.setKeyUpdateResponseCh(msgID, nil)
+// KeylistQuery returns the keys of the agent registered with the router, the page of the keys being selected by
+// paginate, or all the keys being returned when it is nil. This method blocks until a response is received from
+// the router or it times out.
+func (s *Service) KeylistQuery(connID string, paginate *Paginate) (*Keylist, error) {
+	conn, err := s.routerConnection(connID)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
+	msgID := uuid.New().String()
+
+	keylistCh := make(chan *Keylist, 1)
+	s.setKeylistCh(msgID, keylistCh)
+
+	defer s.setKeylistCh(msgID, nil)
+
+	query := &KeylistQuery{
+		ID:       msgID,
+		Type:     KeylistQueryMsgType,
+		Paginate: paginate,
+	}
+
+	if err := s.outbound.SendToDID(query, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send keylist query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return keylist, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for keylist from the router")
+	}
+}
+
+// routerConnection returns the record of the connection with the registered router.
+func (s *Service) routerConnection(connID string) (*connection.Record, error) {
+	// check if router is already registered
+	err := s.ensureConnectionExists(connID)
+	if err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	return conn, nil
 }
 
 // Config fetches the router config - endpoint and routingKeys.
@@ -719,11 +1236,18 @@ func (s *Service) Config(connID string) (*Config, error) {
 	return s.getRouterConfig(connID)
 }
 
-func processKeylistUpdateResp(recKey string, keyUpdateResp *KeylistUpdateResponse) error {
+func processKeylistUpdateResp(recKey, action string, keyUpdateResp *KeylistUpdateResponse) error {
 	for _, result := range keyUpdateResp.Updated {
-		if result.RecipientKey == recKey && result.Action == add && result.Result != success {
-			return errors.New("failed to update the recipient key with the router")
+		if result.RecipientKey != recKey || result.Action != action || result.Result == success {
+			continue
 		}
+
+		// removing a key the router doesn't have is not a failure
+		if action == remove && result.Result == noChange {
+			continue
+		}
+
+		return errors.New("failed to update the recipient key with the router")
 	}
 
 	return nil
@@ -747,6 +1271,24 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getKeylistCh(thID string) chan *Keylist {
+	s.keylistUpdateMapLock.RLock()
+	defer s.keylistUpdateMapLock.RUnlock()
+
+	return s.keylistMap[thID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *Keylist) {
+	s.keylistUpdateMapLock.Lock()
+	defer s.keylistUpdateMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
 func (s *Service) ensureConnectionExists(connID string) error {
 	_, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
 	if errors.Is(err, storage.ErrDataNotFound) {
@@ -832,6 +1374,11 @@ func dataKey(id string) string {
 	return "route-" + id
 }
 
+// routeKeyTagValue encodes the DID as tag value, the ':' of the DID splitting the query expression otherwise.
+func routeKeyTagValue(did string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(did))
+}
+
 func parseClientOpts(options ...ClientOption) *ClientOptions {
 	opts := &ClientOptions{
 		Timeout: updateTimeout,
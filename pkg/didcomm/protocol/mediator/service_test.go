@@ -30,6 +30,7 @@ import (
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -393,8 +394,8 @@ func TestEvents(t *testing.T) {
 		}
 	})
 
-	t.Run("stopping inbound request event does not dispatch outbound grant", func(t *testing.T) {
-		dispatched := make(chan struct{})
+	t.Run("stopping inbound request event dispatches outbound deny", func(t *testing.T) {
+		dispatched := make(chan interface{})
 		svc, err := New(&mockprovider.Provider{
 			ServiceMap: map[string]interface{}{
 				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
@@ -404,7 +405,7 @@ func TestEvents(t *testing.T) {
 			KMSValue:                          &mockkms.KeyManager{},
 			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
 				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
-					dispatched <- struct{}{}
+					dispatched <- msg
 					return nil
 				},
 			},
@@ -427,9 +428,13 @@ func TestEvents(t *testing.T) {
 		}
 
 		select {
-		case <-dispatched:
-			require.Fail(t, "stopping the protocol flow should not result in an outbound message dispatch")
+		case msg := <-dispatched:
+			deny, ok := msg.(*Deny)
+			require.True(t, ok, "stopping the protocol flow should result in an outbound deny")
+			require.Equal(t, DenyMsgType, deny.Type)
+			require.Equal(t, "123", deny.Thread.ID)
 		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
 		}
 	})
 
@@ -614,7 +619,7 @@ func TestServiceUpdateKeyListMsg(t *testing.T) {
 	t.Run("test service handle request msg - verify outbound message", func(t *testing.T) {
 		update := make(map[string]updateResult)
 		update["ABC"] = updateResult{action: add, result: success}
-		update["XYZ"] = updateResult{action: remove, result: serverError}
+		update["XYZ"] = updateResult{action: remove, result: noChange}
 		update[""] = updateResult{action: add, result: success}
 
 		svc, err := New(&mockprovider.Provider{
@@ -1272,6 +1277,444 @@ func TestKeylistUpdate(t *testing.T) {
 	})
 }
 
+func TestKeylistRemoval(t *testing.T) {
+	svc, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, success, svc.applyUpdate(Update{RecipientKey: "key1", Action: add}, THEIRDID))
+
+	t.Run("key of another agent is not removed", func(t *testing.T) {
+		require.Equal(t, clientError, svc.applyUpdate(Update{RecipientKey: "key1", Action: remove}, "other"))
+
+		_, err = svc.routeStore.Get(dataKey("key1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("key is removed", func(t *testing.T) {
+		require.Equal(t, success, svc.applyUpdate(Update{RecipientKey: "key1", Action: remove}, THEIRDID))
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), "key1", nil))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("unknown key is not changed", func(t *testing.T) {
+		require.Equal(t, noChange, svc.applyUpdate(Update{RecipientKey: "key1", Action: remove}, THEIRDID))
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		require.Equal(t, clientError, svc.applyUpdate(Update{RecipientKey: "key1", Action: "replace"}, THEIRDID))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		svc.routeStore = &mockstore.MockStore{Store: map[string]mockstore.DBEntry{}, ErrGet: errors.New("get error")}
+		require.Equal(t, serverError, svc.applyUpdate(Update{RecipientKey: "key1", Action: remove}, THEIRDID))
+
+		svc.routeStore = &mockstore.MockStore{
+			Store:     map[string]mockstore.DBEntry{dataKey("key1"): {Value: []byte(THEIRDID)}},
+			ErrDelete: errors.New("delete error"),
+		}
+		require.Equal(t, serverError, svc.applyUpdate(Update{RecipientKey: "key1", Action: remove}, THEIRDID))
+	})
+}
+
+func TestRemoveKey(t *testing.T) {
+	for _, result := range []string{success, noChange} {
+		result := result
+
+		t.Run("remove key - "+result, func(t *testing.T) {
+			var svc *Service
+
+			svc = newRouterClient(t, func(msg interface{}) error {
+				update, ok := msg.(*KeylistUpdate)
+				require.True(t, ok)
+				require.Equal(t, remove, update.Updates[0].Action)
+
+				go func() {
+					require.NoError(t, svc.handleKeylistUpdateResponse(generateKeylistUpdateResponseMsgPayload(
+						t, update.ID, []UpdateResponse{{RecipientKey: "recKey", Action: remove, Result: result}})))
+				}()
+
+				return nil
+			})
+
+			require.NoError(t, svc.RemoveKey("conn", "recKey"))
+		})
+	}
+
+	t.Run("remove key - failure", func(t *testing.T) {
+		var svc *Service
+
+		svc = newRouterClient(t, func(msg interface{}) error {
+			update, ok := msg.(*KeylistUpdate)
+			require.True(t, ok)
+
+			go func() {
+				require.NoError(t, svc.handleKeylistUpdateResponse(generateKeylistUpdateResponseMsgPayload(
+					t, update.ID, []UpdateResponse{{RecipientKey: "recKey", Action: remove, Result: clientError}})))
+			}()
+
+			return nil
+		})
+
+		err := svc.RemoveKey("conn", "recKey")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to update the recipient key with the router")
+	})
+
+	t.Run("remove key - router not registered", func(t *testing.T) {
+		svc := newRouterClient(t, nil)
+
+		err := svc.RemoveKey("conn2", "recKey")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+}
+
+func TestKeylistQuery(t *testing.T) {
+	router, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+	})
+	require.NoError(t, err)
+
+	for _, key := range []string{"key3", "key1", "key2"} {
+		require.Equal(t, success, router.applyUpdate(Update{RecipientKey: key, Action: add}, "did:example:client"))
+	}
+
+	require.Equal(t, success, router.applyUpdate(Update{RecipientKey: "other", Action: add}, "did:example:other"))
+
+	var client *Service
+
+	client = newRouterClient(t, func(msg interface{}) error {
+		query, ok := msg.(*KeylistQuery)
+		require.True(t, ok)
+
+		router.outbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				go func() {
+					require.NoError(t, client.handleKeylist(service.NewDIDCommMsgMap(msg)))
+				}()
+
+				return nil
+			},
+		}
+
+		return router.handleKeylistQuery(service.NewDIDCommMsgMap(query), THEIRDID, "did:example:client")
+	})
+
+	t.Run("all keys", func(t *testing.T) {
+		keylist, err := client.KeylistQuery("conn", nil)
+		require.NoError(t, err)
+		require.Equal(t, []KeylistKey{{"key1"}, {"key2"}, {"key3"}}, keylist.Keys)
+		require.Equal(t, &Pagination{Count: 3}, keylist.Pagination)
+	})
+
+	t.Run("page of keys", func(t *testing.T) {
+		keylist, err := client.KeylistQuery("conn", &Paginate{Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, []KeylistKey{{"key2"}}, keylist.Keys)
+		require.Equal(t, &Pagination{Count: 1, Offset: 1, Remaining: 1}, keylist.Pagination)
+
+		keylist, err = client.KeylistQuery("conn", &Paginate{Offset: 5})
+		require.NoError(t, err)
+		require.Empty(t, keylist.Keys)
+		require.Equal(t, &Pagination{Offset: 3}, keylist.Pagination)
+	})
+
+	t.Run("keys saved untagged listed once forwarded to", func(t *testing.T) {
+		require.NoError(t, router.routeStore.Put(dataKey("key0"), []byte("did:example:client")))
+
+		keylist, err := client.KeylistQuery("conn", nil)
+		require.NoError(t, err)
+		require.Len(t, keylist.Keys, 3)
+
+		router.vdRegistry = &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}
+
+		err = router.handleForward(generateForwardMsgPayload(t, randomID(), "key0", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get destination")
+
+		keylist, err = client.KeylistQuery("conn", nil)
+		require.NoError(t, err)
+		require.Equal(t, []KeylistKey{{"key0"}, {"key1"}, {"key2"}, {"key3"}}, keylist.Keys)
+	})
+
+	t.Run("router not registered", func(t *testing.T) {
+		_, err := client.KeylistQuery("conn2", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		router.routeStore = &mockstore.MockStore{Store: map[string]mockstore.DBEntry{}, ErrQuery: errors.New("query error")}
+
+		err := router.handleKeylistQuery(service.NewDIDCommMsgMap(&KeylistQuery{ID: "1", Type: KeylistQueryMsgType}),
+			MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query error")
+	})
+}
+
+func TestTagRouteKeys(t *testing.T) {
+	t.Run("tags the keys of the connections at startup", func(t *testing.T) {
+		storeProvider := mem.NewProvider()
+
+		store, err := storeProvider.OpenStore(Coordination)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(dataKey("key0"), []byte("did:example:client")))
+		require.NoError(t, store.Put(dataKey("did:example:client"), []byte("did:example:client")))
+
+		provider := &mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              storeProvider,
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+		}
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: "did:example:client", State: "completed",
+			RecipientKeys: []string{"key0", "unknown"},
+		}))
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			keys, err := svc.routeKeys("did:example:client")
+			require.NoError(t, err)
+
+			return len(keys) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		keys, err := svc.routeKeys("did:example:client")
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:client", "key0"}, keys)
+	})
+
+	t.Run("query connections error", func(t *testing.T) {
+		svc := &Service{}
+
+		svc.tagRouteKeys(&connectionsStub{queryConnRecords: func() ([]*connection.Record, error) {
+			return nil, errors.New("query error")
+		}})
+	})
+
+	t.Run("get route key error", func(t *testing.T) {
+		svc := &Service{
+			routeStore: &mockstore.MockStore{Store: map[string]mockstore.DBEntry{}, ErrGet: errors.New("get error")},
+		}
+
+		svc.tagRouteKeys(&connectionsStub{queryConnRecords: func() ([]*connection.Record, error) {
+			return []*connection.Record{{TheirDID: "did:example:client"}}, nil
+		}})
+	})
+}
+
+func TestMediationDeny(t *testing.T) {
+	var svc *Service
+
+	svc = newRouterClient(t, func(msg interface{}) error {
+		request, ok := msg.(*Request)
+		require.True(t, ok)
+
+		router := &Service{outbound: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				return svc.saveDeny(service.NewDIDCommMsgMap(msg))
+			},
+		}}
+
+		router.handleUserRejection(&callback{msg: service.NewDIDCommMsgMap(request), err: errors.New("rejected")})
+
+		return nil
+	})
+
+	require.NoError(t, svc.deleteRouterConnectionID("conn"))
+
+	err := svc.Register("conn")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrMediationDenied))
+}
+
+func TestCoordinationV2(t *testing.T) {
+	sent := make(chan interface{}, 1)
+
+	svc, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		KMSValue:                          &mockkms.KeyManager{},
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg
+
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("accepts the 2.0 message types", func(t *testing.T) {
+		for _, msgType := range []string{
+			RequestMsgTypeV2, GrantMsgTypeV2, DenyMsgTypeV2, KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+			KeylistQueryMsgTypeV2, KeylistMsgTypeV2,
+		} {
+			require.True(t, svc.Accept(msgType))
+		}
+	})
+
+	t.Run("grant and deny", func(t *testing.T) {
+		request, err := service.ParseDIDCommMsgMap([]byte(`{"id":"request","type":"` + RequestMsgTypeV2 +
+			`","body":{}}`))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handleInboundRequest(&callback{msg: request, options: &Options{
+			RoutingKeys: []string{"did:example:routing"},
+		}}))
+
+		grant, ok := (<-sent).(*GrantV2)
+		require.True(t, ok)
+		require.Equal(t, []string{"did:example:routing"}, grant.Body.RoutingDID)
+		require.Equal(t, "request", grant.ThreadID)
+
+		grantMsg := service.NewDIDCommMsgMap(grant)
+		require.Equal(t, GrantMsgTypeV2, grantMsg["type"])
+		require.NotContains(t, grantMsg, "@type")
+
+		thID, err := grantMsg.ThreadID()
+		require.NoError(t, err)
+		require.Equal(t, "request", thID)
+
+		require.NoError(t, svc.saveGrant(service.NewDIDCommMsgMap(grant)))
+
+		saved, err := svc.getGrant("request", time.Second)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:routing"}, saved.RoutingKeys)
+
+		svc.handleUserRejection(&callback{msg: request, err: errors.New("rejected")})
+
+		deny, ok := (<-sent).(*DenyV2)
+		require.True(t, ok)
+		require.Equal(t, DenyMsgTypeV2, deny.Type)
+		require.Equal(t, "request", deny.ThreadID)
+	})
+
+	t.Run("keylist update", func(t *testing.T) {
+		require.NoError(t, svc.handleKeylistUpdate(service.NewDIDCommMsgMap(&KeylistUpdateV2{
+			ID:   "update",
+			Type: KeylistUpdateMsgTypeV2,
+			Body: KeylistUpdateV2Body{Updates: []UpdateV2{
+				{RecipientDID: "did:key:z1", Action: add},
+				{RecipientDID: "did:key:z2", Action: remove},
+			}},
+		}), MYDID, THEIRDID))
+
+		resp, ok := (<-sent).(*KeylistUpdateResponseV2)
+		require.True(t, ok)
+		require.Equal(t, "update", resp.ThreadID)
+		require.Equal(t, []UpdateResponseV2{
+			{RecipientDID: "did:key:z1", Action: add, Result: success},
+			{RecipientDID: "did:key:z2", Action: remove, Result: noChange},
+		}, resp.Body.Updated)
+
+		ch := make(chan *KeylistUpdateResponse, 1)
+		svc.setKeyUpdateResponseCh("update", ch)
+
+		require.NoError(t, svc.handleKeylistUpdateResponse(service.NewDIDCommMsgMap(resp)))
+		require.Equal(t, "did:key:z1", (<-ch).Updated[0].RecipientKey)
+	})
+
+	t.Run("keylist query", func(t *testing.T) {
+		require.NoError(t, svc.handleKeylistQuery(service.NewDIDCommMsgMap(&KeylistQueryV2{
+			ID:   "query",
+			Type: KeylistQueryMsgTypeV2,
+		}), MYDID, THEIRDID))
+
+		keylist, ok := (<-sent).(*KeylistV2)
+		require.True(t, ok)
+		require.Equal(t, "query", keylist.ThreadID)
+		require.Equal(t, []KeylistKeyV2{{RecipientDID: "did:key:z1"}}, keylist.Body.Keys)
+
+		ch := make(chan *Keylist, 1)
+		svc.setKeylistCh("query", ch)
+
+		require.NoError(t, svc.handleKeylist(service.NewDIDCommMsgMap(keylist)))
+		require.Equal(t, []KeylistKey{{RecipientKey: "did:key:z1"}}, (<-ch).Keys)
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		for msgType, handle := range map[string]func(service.DIDCommMsg) error{
+			KeylistUpdateMsgTypeV2: func(msg service.DIDCommMsg) error {
+				return svc.handleKeylistUpdate(msg, MYDID, THEIRDID)
+			},
+			KeylistUpdateResponseMsgTypeV2: svc.handleKeylistUpdateResponse,
+			KeylistQueryMsgTypeV2: func(msg service.DIDCommMsg) error {
+				return svc.handleKeylistQuery(msg, MYDID, THEIRDID)
+			},
+			KeylistQueryMsgType: func(msg service.DIDCommMsg) error {
+				return svc.handleKeylistQuery(msg, MYDID, THEIRDID)
+			},
+			KeylistMsgTypeV2: svc.handleKeylist,
+			KeylistMsgType:   svc.handleKeylist,
+			GrantMsgTypeV2:   svc.saveGrant,
+		} {
+			err := handle(service.DIDCommMsgMap{"@type": msgType, "@id": "1", "body": "invalid", "keys": "invalid",
+				"paginate": "invalid"})
+			require.Error(t, err, msgType)
+		}
+	})
+}
+
+// newRouterClient returns a service registered with a router through the connection "conn", send being called
+// with the messages sent to the router.
+func newRouterClient(t *testing.T, send func(msg interface{}) error) *Service {
+	t.Helper()
+
+	provider := &mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				return send(msg)
+			},
+		},
+	}
+
+	r, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+	}))
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+	require.NoError(t, svc.saveRouterConnectionID("conn"))
+
+	return svc
+}
+
 func TestConfig(t *testing.T) {
 	routingKeys := []string{"abc", "xyz"}
 
@@ -1504,8 +1947,17 @@ func randomID() string {
 }
 
 type connectionsStub struct {
-	getConnIDByDIDs func(string, string) (string, error)
-	getConnRecord   func(string) (*connection.Record, error)
+	getConnIDByDIDs  func(string, string) (string, error)
+	getConnRecord    func(string) (*connection.Record, error)
+	queryConnRecords func() ([]*connection.Record, error)
+}
+
+func (c *connectionsStub) QueryConnectionRecords() ([]*connection.Record, error) {
+	if c.queryConnRecords != nil {
+		return c.queryConnRecords()
+	}
+
+	return nil, nil
 }
 
 func (c *connectionsStub) GetConnectionIDByDIDs(myDID, theirDID string) (string, error) {
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultPushTimeout is the timeout of the client posting the push notifications when none is given.
+const defaultPushTimeout = 10 * time.Second
+
+// HTTPPushNotifier posts the push notifications as JSON to an HTTP endpoint, like a push gateway relaying them to
+// the mobile clients.
+type HTTPPushNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPushNotifier returns a notifier posting to url with client, a client with a 10 seconds timeout being used
+// when client is nil.
+func NewHTTPPushNotifier(url string, client *http.Client) *HTTPPushNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: defaultPushTimeout}
+	}
+
+	return &HTTPPushNotifier{url: url, client: client}
+}
+
+// Notify posts the notification, the endpoint being expected to respond with a 2xx status.
+func (n *HTTPPushNotifier) Notify(notification *PushNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal push notification: %w", err)
+	}
+
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("post push notification: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close push notification response body: %s", e)
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("push notification endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,330 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// tag of the inboxes, used to find them when sweeping the expired messages.
+	inboxTag = "mailbox_inbox"
+
+	defaultExpiryInterval = time.Minute
+)
+
+// ErrQueueFull is returned by AddMessage when the message doesn't fit in the queue of the recipient, either because
+// the queue is full and the RejectNew eviction policy is set, or because the message alone exceeds the byte limit.
+var ErrQueueFull = errors.New("message queue full")
+
+// EvictionPolicy tells how a message is queued for a recipient whose queue is full.
+type EvictionPolicy int
+
+const (
+	// EvictOldest drops the oldest queued messages to make room for the new one.
+	EvictOldest EvictionPolicy = iota
+	// RejectNew keeps the queued messages, the new one being rejected with ErrQueueFull.
+	RejectNew
+)
+
+// PushNotification tells that a message was queued for a recipient.
+type PushNotification struct {
+	// DID of the recipient.
+	DID string `json:"did"`
+	// MessageID is the ID of the queued message.
+	MessageID string `json:"message_id"`
+	// MessageCount and TotalSize describe the queue of the recipient once the message is added.
+	MessageCount int `json:"message_count"`
+	TotalSize    int `json:"total_size"`
+}
+
+// PushNotifier is notified of the messages queued for the recipients, to wake up the offline mobile clients so that
+// they pick their messages up.
+type PushNotifier interface {
+	Notify(notification *PushNotification) error
+}
+
+// Opt configures the queues of the messages kept for the recipients the mediator couldn't deliver to.
+type Opt func(opts *queueOpts)
+
+type queueOpts struct {
+	maxMessages    int
+	maxBytes       int
+	ttl            time.Duration
+	expiryInterval time.Duration
+	eviction       EvictionPolicy
+	notifier       PushNotifier
+}
+
+// WithMaxMessages limits the number of messages queued per recipient, the queues being unbounded by default.
+func WithMaxMessages(n int) Opt {
+	return func(opts *queueOpts) {
+		opts.maxMessages = n
+	}
+}
+
+// WithMaxBytes limits the size in bytes of the messages queued per recipient, the queues being unbounded by
+// default.
+func WithMaxBytes(n int) Opt {
+	return func(opts *queueOpts) {
+		opts.maxBytes = n
+	}
+}
+
+// WithMessageTTL drops the messages queued for longer than ttl, the messages being kept until picked up by default.
+func WithMessageTTL(ttl time.Duration) Opt {
+	return func(opts *queueOpts) {
+		opts.ttl = ttl
+	}
+}
+
+// WithExpiryInterval sets how often the expired messages are swept from the queues, every minute by default. The
+// expired messages are also dropped whenever a queue is read or written.
+func WithExpiryInterval(interval time.Duration) Opt {
+	return func(opts *queueOpts) {
+		opts.expiryInterval = interval
+	}
+}
+
+// WithEvictionPolicy sets how a message is queued for a recipient whose queue is full, EvictOldest by default.
+func WithEvictionPolicy(policy EvictionPolicy) Opt {
+	return func(opts *queueOpts) {
+		opts.eviction = policy
+	}
+}
+
+// WithPushNotifier sets the notifier of the queued messages.
+func WithPushNotifier(notifier PushNotifier) Opt {
+	return func(opts *queueOpts) {
+		opts.notifier = notifier
+	}
+}
+
+// dropExpired returns the messages which are not expired at now.
+func (o *queueOpts) dropExpired(msgs []*Message, now time.Time) []*Message {
+	if o.ttl <= 0 {
+		return msgs
+	}
+
+	kept := msgs[:0]
+
+	for _, m := range msgs {
+		if now.Sub(m.AddedTime) < o.ttl {
+			kept = append(kept, m)
+		}
+	}
+
+	return kept
+}
+
+// enqueue appends m to msgs, applying the limits and the eviction policy.
+func (o *queueOpts) enqueue(msgs []*Message, m *Message) ([]*Message, error) {
+	size, err := messageSize(m)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.maxBytes > 0 && size > o.maxBytes {
+		return nil, fmt.Errorf("message of %d bytes exceeds the %d bytes limit: %w", size, o.maxBytes, ErrQueueFull)
+	}
+
+	sizes := make([]int, len(msgs), len(msgs)+1)
+	total := size
+
+	for i, queued := range msgs {
+		if sizes[i], err = messageSize(queued); err != nil {
+			return nil, err
+		}
+
+		total += sizes[i]
+	}
+
+	full := func(count, bytes int) bool {
+		return (o.maxMessages > 0 && count > o.maxMessages) || (o.maxBytes > 0 && bytes > o.maxBytes)
+	}
+
+	if !full(len(msgs)+1, total) {
+		return append(msgs, m), nil
+	}
+
+	if o.eviction == RejectNew {
+		return nil, ErrQueueFull
+	}
+
+	evicted := 0
+	for full(len(msgs)-evicted+1, total) {
+		total -= sizes[evicted]
+		evicted++
+	}
+
+	logger.Infof("evicted %d queued messages to make room for message %s", evicted, m.ID)
+
+	return append(msgs[evicted:], m), nil
+}
+
+func messageSize(m *Message) (int, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return 0, fmt.Errorf("marshal message: %w", err)
+	}
+
+	return len(b), nil
+}
+
+// expireMessages sweeps the expired messages every interval, until the service is drained.
+func (s *Service) expireMessages(interval time.Duration) {
+	if err := s.tagInboxes(); err != nil {
+		logger.Errorf("failed to tag the inboxes: %s", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.sweepExpired(time.Now()); err != nil {
+				logger.Errorf("failed to sweep the expired messages: %s", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// sweepExpired drops the messages expired at now from all the queues.
+func (s *Service) sweepExpired(now time.Time) error {
+	iter, err := s.msgStore.Query(inboxTag)
+	if err != nil {
+		return fmt.Errorf("query inboxes: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var dids []string
+
+	more, err := iter.Next()
+	for ; err == nil && more; more, err = iter.Next() {
+		var key string
+
+		if key, err = iter.Key(); err != nil {
+			break
+		}
+
+		dids = append(dids, key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("iterate inboxes: %w", err)
+	}
+
+	var sweepErr error
+
+	for _, did := range dids {
+		if err := s.sweepInbox(did, now); err != nil {
+			logger.Warnf("failed to sweep the expired messages of %s: %s", did, err)
+
+			if sweepErr == nil {
+				sweepErr = fmt.Errorf("sweep inbox %s: %w", did, err)
+			}
+		}
+	}
+
+	return sweepErr
+}
+
+// tagInboxes tags the inboxes of the connections saved untagged by previous versions, for them to be swept.
+func (s *Service) tagInboxes() error {
+	records, err := s.connectionLookup.QueryConnectionRecords()
+	if err != nil {
+		return fmt.Errorf("query connections: %w", err)
+	}
+
+	for _, record := range records {
+		if record.TheirDID == "" {
+			continue
+		}
+
+		if err = s.tagInbox(record.TheirDID); err != nil {
+			logger.Warnf("failed to tag the inbox of %s: %s", record.TheirDID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) tagInbox(theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	outbox, err := s.getInbox(theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get inbox: %w", err)
+	}
+
+	return s.putInbox(theirDID, outbox)
+}
+
+func (s *Service) sweepInbox(theirDID string, now time.Time) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil {
+		return fmt.Errorf("get inbox: %w", err)
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return fmt.Errorf("decode inbox: %w", err)
+	}
+
+	kept := s.queue.dropExpired(msgs, now)
+	if len(kept) == len(msgs) {
+		return nil
+	}
+
+	logger.Debugf("dropped %d expired messages of %s", len(msgs)-len(kept), theirDID)
+
+	outbox.LastRemovedTime = now
+
+	if err = outbox.EncodeMessages(kept); err != nil {
+		return fmt.Errorf("encode inbox: %w", err)
+	}
+
+	if err = s.putInbox(theirDID, outbox); err != nil {
+		return fmt.Errorf("put inbox: %w", err)
+	}
+
+	return nil
+}
+
+// notify sends the push notification of the message queued for theirDID, in the background.
+func (s *Service) notify(notification *PushNotification) {
+	if s.queue.notifier == nil {
+		return
+	}
+
+	err := s.inFlight.Go(func() {
+		if err := s.queue.notifier.Notify(notification); err != nil {
+			logger.Warnf("push notification of message %s to %s failed: %s",
+				notification.MessageID, notification.DID, err)
+		}
+	})
+	if err != nil {
+		logger.Warnf("dropped push notification of message %s: %s", notification.MessageID, err)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestQueueLimits(t *testing.T) {
+	t.Run("evicts the oldest messages past the count limit", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMaxMessages(2))
+
+		for _, text := range []string{"first", "second", "third"} {
+			require.NoError(t, svc.AddMessage(envelope(text), THEIRDID))
+		}
+
+		require.Equal(t, []string{"second", "third"}, queuedTexts(t, store))
+	})
+
+	t.Run("evicts the oldest messages past the byte limit", func(t *testing.T) {
+		size, err := messageSize(&Message{
+			ID:        "00000000-0000-0000-0000-000000000000",
+			AddedTime: time.Now(),
+			Message:   envelope("first"),
+		})
+		require.NoError(t, err)
+
+		svc, store := newQueueService(t, WithMaxBytes(2*size+size/2))
+
+		for _, text := range []string{"first", "second", "third"} {
+			require.NoError(t, svc.AddMessage(envelope(text), THEIRDID))
+		}
+
+		require.Equal(t, []string{"second", "third"}, queuedTexts(t, store))
+	})
+
+	t.Run("rejects the new messages with the reject new policy", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMaxMessages(2), WithEvictionPolicy(RejectNew))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+		require.NoError(t, svc.AddMessage(envelope("second"), THEIRDID))
+
+		err := svc.AddMessage(envelope("third"), THEIRDID)
+		require.True(t, errors.Is(err, ErrQueueFull))
+
+		require.Equal(t, []string{"first", "second"}, queuedTexts(t, store))
+	})
+
+	t.Run("rejects a message larger than the byte limit", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMaxBytes(10))
+
+		err := svc.AddMessage(envelope("first"), THEIRDID)
+		require.True(t, errors.Is(err, ErrQueueFull))
+		require.Empty(t, queuedTexts(t, store))
+	})
+
+	t.Run("limits the queues per recipient", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMaxMessages(1))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+		require.NoError(t, svc.AddMessage(envelope("second"), "other-did"))
+
+		require.Equal(t, []string{"first"}, queuedTexts(t, store))
+	})
+}
+
+func TestQueueExpiry(t *testing.T) {
+	t.Run("drops the expired messages when adding", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(time.Hour))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+		ageMessages(t, store, 2*time.Hour)
+		require.NoError(t, svc.AddMessage(envelope("second"), THEIRDID))
+
+		require.Equal(t, []string{"second"}, queuedTexts(t, store))
+	})
+
+	t.Run("sweeps the expired messages", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(time.Hour))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+		require.NoError(t, svc.sweepExpired(time.Now()))
+		require.Equal(t, []string{"first"}, queuedTexts(t, store))
+
+		require.NoError(t, svc.sweepExpired(time.Now().Add(2*time.Hour)))
+		require.Empty(t, queuedTexts(t, store))
+	})
+
+	t.Run("sweeps the expired messages in the background", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(50*time.Millisecond),
+			WithExpiryInterval(10*time.Millisecond))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+
+		require.Eventually(t, func() bool {
+			return len(queuedTexts(t, store)) == 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, svc.Drain(context.Background()))
+	})
+
+	t.Run("sweeps the other inboxes when one fails", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(time.Hour))
+
+		require.NoError(t, store.Store.Put("did:example:broken", []byte("{"), storage.Tag{Name: inboxTag}))
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+
+		err := svc.sweepExpired(time.Now().Add(2 * time.Hour))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sweep inbox did:example:broken")
+		require.Empty(t, queuedTexts(t, store))
+	})
+
+	t.Run("sweeps the inboxes saved untagged once tagged", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(time.Hour))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+		require.NoError(t, store.Store.Put(THEIRDID, store.Store.Store[THEIRDID].Value))
+
+		require.NoError(t, svc.sweepExpired(time.Now().Add(2*time.Hour)))
+		require.Equal(t, []string{"first"}, queuedTexts(t, store))
+
+		// stops the background sweeping before replacing the connections
+		require.NoError(t, svc.Drain(context.Background()))
+
+		svc.connectionLookup = &connectionsStub{
+			queryConnRecords: func() ([]*connection.Record, error) {
+				return []*connection.Record{{TheirDID: THEIRDID}, {TheirDID: "did:example:no-inbox"}, {}}, nil
+			},
+		}
+
+		require.NoError(t, svc.tagInboxes())
+		require.NoError(t, svc.sweepExpired(time.Now().Add(2*time.Hour)))
+		require.Empty(t, queuedTexts(t, store))
+	})
+
+	t.Run("fails to query the connections to tag the inboxes", func(t *testing.T) {
+		svc, _ := newQueueService(t, WithMessageTTL(time.Hour))
+
+		// stops the background sweeping before replacing the connections
+		require.NoError(t, svc.Drain(context.Background()))
+
+		svc.connectionLookup = &connectionsStub{
+			queryConnRecords: func() ([]*connection.Record, error) {
+				return nil, errors.New("query error")
+			},
+		}
+
+		require.EqualError(t, svc.tagInboxes(), "query connections: query error")
+	})
+
+	t.Run("sweep fails to query the inboxes", func(t *testing.T) {
+		svc, store := newQueueService(t, WithMessageTTL(time.Hour))
+		store.Store.ErrQuery = errors.New("query error")
+
+		err := svc.sweepExpired(time.Now())
+		require.EqualError(t, err, "query inboxes: query error")
+	})
+}
+
+func TestPushNotifier(t *testing.T) {
+	t.Run("notifies the queued messages", func(t *testing.T) {
+		notifier := &mockNotifier{notifications: make(chan *PushNotification, 1)}
+		svc, _ := newQueueService(t, WithPushNotifier(notifier))
+
+		require.NoError(t, svc.AddMessage(envelope("first"), THEIRDID))
+
+		select {
+		case n := <-notifier.notifications:
+			require.Equal(t, THEIRDID, n.DID)
+			require.NotEmpty(t, n.MessageID)
+			require.Equal(t, 1, n.MessageCount)
+			require.NotZero(t, n.TotalSize)
+		case <-time.After(time.Second):
+			require.Fail(t, "push notification not sent")
+		}
+
+		require.NoError(t, svc.Drain(context.Background()))
+	})
+
+	t.Run("doesn't notify the rejected messages", func(t *testing.T) {
+		notifier := &mockNotifier{notifications: make(chan *PushNotification, 1)}
+		svc, _ := newQueueService(t, WithPushNotifier(notifier), WithMaxBytes(10))
+
+		require.Error(t, svc.AddMessage(envelope("first"), THEIRDID))
+		require.NoError(t, svc.Drain(context.Background()))
+		require.Empty(t, notifier.notifications)
+	})
+
+	t.Run("posts the notifications over HTTP", func(t *testing.T) {
+		received := make(chan *PushNotification, 1)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := &PushNotification{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(n))
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			received <- n
+		}))
+		defer server.Close()
+
+		err := NewHTTPPushNotifier(server.URL, nil).Notify(&PushNotification{DID: THEIRDID, MessageID: "id"})
+		require.NoError(t, err)
+		require.Equal(t, &PushNotification{DID: THEIRDID, MessageID: "id"}, <-received)
+	})
+
+	t.Run("HTTP endpoint responds with an error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewHTTPPushNotifier(server.URL, server.Client()).Notify(&PushNotification{DID: THEIRDID})
+		require.EqualError(t, err, "push notification endpoint responded with status 503")
+	})
+
+	t.Run("HTTP client with a timeout by default", func(t *testing.T) {
+		require.Equal(t, defaultPushTimeout, NewHTTPPushNotifier("http://127.0.0.1:0", nil).client.Timeout)
+	})
+
+	t.Run("HTTP endpoint unreachable", func(t *testing.T) {
+		err := NewHTTPPushNotifier("http://127.0.0.1:0", nil).Notify(&PushNotification{DID: THEIRDID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "post push notification")
+	})
+}
+
+func TestServiceDrain(t *testing.T) {
+	svc, _ := newQueueService(t, WithMessageTTL(time.Hour))
+
+	require.NoError(t, svc.Drain(context.Background()))
+	require.NoError(t, svc.Drain(context.Background()))
+}
+
+func newQueueService(t *testing.T, opts ...Opt) (*Service, *mockstore.MockStoreProvider) {
+	t.Helper()
+
+	store := mockstore.NewMockStoreProvider()
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:              store,
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	}, &mockTransportProvider{
+		packagerValue: &mockPackager{},
+	}, opts...)
+	require.NoError(t, err)
+
+	return svc, store
+}
+
+func envelope(text string) *model.Envelope {
+	return &model.Envelope{CipherText: text}
+}
+
+func queuedTexts(t *testing.T, store *mockstore.MockStoreProvider) []string {
+	t.Helper()
+
+	b, err := store.Store.Get(THEIRDID)
+	if err != nil {
+		return nil
+	}
+
+	ibx := &inbox{}
+	require.NoError(t, json.Unmarshal(b, ibx))
+
+	msgs, err := ibx.DecodeMessages()
+	require.NoError(t, err)
+
+	var texts []string
+	for _, m := range msgs {
+		texts = append(texts, m.Message.CipherText)
+	}
+
+	return texts
+}
+
+func ageMessages(t *testing.T, store *mockstore.MockStoreProvider, age time.Duration) {
+	t.Helper()
+
+	b, err := store.Store.Get(THEIRDID)
+	require.NoError(t, err)
+
+	ibx := &inbox{}
+	require.NoError(t, json.Unmarshal(b, ibx))
+
+	msgs, err := ibx.DecodeMessages()
+	require.NoError(t, err)
+
+	for _, m := range msgs {
+		m.AddedTime = m.AddedTime.Add(-age)
+	}
+
+	require.NoError(t, ibx.EncodeMessages(msgs))
+
+	b, err = json.Marshal(ibx)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Store.Put(THEIRDID, b, store.Store.Store[THEIRDID].Tags...))
+}
+
+type mockNotifier struct {
+	notifications chan *PushNotification
+}
+
+func (n *mockNotifier) Notify(notification *PushNotification) error {
+	n.notifications <- notification
+
+	return nil
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package messagepickup
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -16,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -63,6 +65,7 @@ type provider interface {
 
 type connections interface {
 	GetConnectionRecord(string) (*connection.Record, error)
+	QueryConnectionRecords() ([]*connection.Record, error)
 }
 
 // Service for the messagepickup protocol.
@@ -79,15 +82,25 @@ type Service struct {
 	statusMap        map[string]chan Status
 	statusMapLock    sync.RWMutex
 	inboxLock        sync.Mutex
+	queue            queueOpts
+	stop             chan struct{}
+	stopOnce         sync.Once
+	inFlight         shutdown.Tracker
 }
 
-// New returns the messagepickup service.
-func New(prov provider, tp transport.Provider) (*Service, error) {
+// New returns the messagepickup service. The queues of the messages kept for the recipients are unbounded unless
+// limited with opts.
+func New(prov provider, tp transport.Provider, opts ...Opt) (*Service, error) {
 	store, err := prov.StorageProvider().OpenStore(Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("open mailbox store : %w", err)
 	}
 
+	err = prov.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{TagNames: []string{inboxTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set mailbox store config : %w", err)
+	}
+
 	connectionLookup, err := connection.NewLookup(prov)
 	if err != nil {
 		return nil, err
@@ -101,6 +114,23 @@ func New(prov provider, tp transport.Provider) (*Service, error) {
 		msgHandler:       tp.InboundMessageHandler(),
 		batchMap:         make(map[string]chan Batch),
 		statusMap:        make(map[string]chan Status),
+		stop:             make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(&svc.queue)
+	}
+
+	if svc.queue.ttl > 0 {
+		interval := svc.queue.expiryInterval
+		if interval <= 0 {
+			interval = defaultExpiryInterval
+		}
+
+		err = svc.inFlight.Go(func() { svc.expireMessages(interval) })
+		if err != nil {
+			return nil, fmt.Errorf("start message expiry : %w", err)
+		}
 	}
 
 	return svc, nil
@@ -126,7 +156,7 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		}
 
 		if err != nil {
-			logger.Errorf("Error handling message: (%w)\n", err)
+			logger.Errorf("Error handling message: (%s)\n", err)
 		}
 	}()
 
@@ -138,6 +168,17 @@ func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, err
 	return "", errors.New("not implemented")
 }
 
+// Drain stops sweeping the expired messages and waits for the push notifications being sent, or for ctx to be done.
+func (s *Service) Drain(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", MessagePickup, err)
+	}
+
+	return nil
+}
+
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -148,6 +189,13 @@ func (s *Service) Accept(msgType string) bool {
 	return false
 }
 
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType,
+	}
+}
+
 // Name of the service.
 func (s *Service) Name() string {
 	return MessagePickup
@@ -191,6 +239,11 @@ func (s *Service) handleStatusRequest(msg service.DIDCommMsg, myDID, theirDID st
 		return fmt.Errorf("error in status request getting inbox: %w", err)
 	}
 
+	err = s.dropExpired(outbox)
+	if err != nil {
+		return fmt.Errorf("status request drop expired: %w", err)
+	}
+
 	resp := &Status{
 		Type:              StatusMsgType,
 		ID:                msg.ID(),
@@ -230,6 +283,8 @@ func (s *Service) handleBatchPickup(msg service.DIDCommMsg, myDID, theirDID stri
 		return fmt.Errorf("batch pickup decode : %w", err)
 	}
 
+	msgs = s.queue.dropExpired(msgs, time.Now())
+
 	end := len(msgs)
 	if request.BatchSize < end {
 		end = request.BatchSize
@@ -349,8 +404,12 @@ func (s *Service) AddMessage(message *model.Envelope, theirDID string) error {
 		Message:   message,
 	}
 
-	msgs = append(msgs, &m)
+	msgs, err = s.queue.enqueue(s.queue.dropExpired(msgs, m.AddedTime), &m)
+	if err != nil {
+		return fmt.Errorf("unable to queue message for %s: %w", theirDID, err)
+	}
 
+	outbox.LastAddedTime = m.AddedTime
 	outbox.LastDeliveredTime = time.Now()
 	outbox.LastRemovedTime = outbox.LastDeliveredTime
 
@@ -364,9 +423,35 @@ func (s *Service) AddMessage(message *model.Envelope, theirDID string) error {
 		return fmt.Errorf("unable to put messages: %w", err)
 	}
 
+	s.notify(&PushNotification{
+		DID:          theirDID,
+		MessageID:    m.ID,
+		MessageCount: outbox.MessageCount,
+		TotalSize:    outbox.TotalSize,
+	})
+
 	return nil
 }
 
+// dropExpired drops the expired messages from outbox, the messages being encoded back only when some expired.
+func (s *Service) dropExpired(outbox *inbox) error {
+	if s.queue.ttl <= 0 {
+		return nil
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return err
+	}
+
+	kept := s.queue.dropExpired(msgs, time.Now())
+	if len(kept) == len(msgs) {
+		return nil
+	}
+
+	return outbox.EncodeMessages(kept)
+}
+
 func (s *Service) createInbox(theirDID string) (*inbox, error) {
 	msgs, err := s.getInbox(theirDID)
 	if err != nil && errors.Is(err, storage.ErrDataNotFound) {
@@ -410,7 +495,7 @@ func (s *Service) putInbox(theirDID string, o *inbox) error {
 		return err
 	}
 
-	return s.msgStore.Put(theirDID, b)
+	return s.msgStore.Put(theirDID, b, storage.Tag{Name: inboxTag})
 }
 
 // StatusRequest request a status message.
@@ -493,7 +578,7 @@ func (s *Service) BatchPickup(connectionID string, size int) (int, error) {
 		for _, msg := range batchResp.Messages {
 			err := s.handle(msg)
 			if err != nil {
-				logger.Errorf("error handling batch message %s: %w", msg.ID, err)
+				logger.Errorf("error handling batch message %s: %s", msg.ID, err)
 
 				continue
 			}
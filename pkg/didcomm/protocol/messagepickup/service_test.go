@@ -1021,8 +1021,9 @@ func (m *mockPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, er
 }
 
 type connectionsStub struct {
-	getConnIDByDIDs func(string, string) (string, error)
-	getConnRecord   func(string) (*connection.Record, error)
+	getConnIDByDIDs  func(string, string) (string, error)
+	getConnRecord    func(string) (*connection.Record, error)
+	queryConnRecords func() ([]*connection.Record, error)
 }
 
 func (c *connectionsStub) GetConnectionIDByDIDs(myDID, theirDID string) (string, error) {
@@ -1040,3 +1041,11 @@ func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, er
 
 	return nil, nil
 }
+
+func (c *connectionsStub) QueryConnectionRecords() ([]*connection.Record, error) {
+	if c.queryConnRecords != nil {
+		return c.queryConnRecords()
+	}
+
+	return nil, nil
+}
@@ -32,3 +32,22 @@ type HandshakeReuseAccepted struct {
 	ID   string `json:"@id"`
 	Type string `json:"@type"`
 }
+
+// InvitationV2 is the out-of-band 2.0 `invitation` message.
+// https://identity.foundation/didcomm-messaging/spec/#out-of-band-messages
+type InvitationV2 struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+	// From is the DID of the sender, which the receiver messages to proceed.
+	From        string                    `json:"from"`
+	Body        *InvitationBody           `json:"body"`
+	Attachments []*decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// InvitationBody is the body of the out-of-band 2.0 `invitation` message.
+type InvitationBody struct {
+	Goal     string   `json:"goal,omitempty"`
+	GoalCode string   `json:"goal_code,omitempty"`
+	Accept   []string `json:"accept,omitempty"`
+}
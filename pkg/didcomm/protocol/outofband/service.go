@@ -39,6 +39,10 @@ const (
 	HandshakeReuseMsgType = PIURI + "/handshake-reuse"
 	// HandshakeReuseAcceptedMsgType is the '@type' for the reuse-accepted message.
 	HandshakeReuseAcceptedMsgType = PIURI + "/handshake-reuse-accepted"
+	// PIURIV2 is the Out-of-Band 2.0 protocol's protocol instance URI.
+	PIURIV2 = "https://didcomm.org/out-of-band/2.0"
+	// InvitationMsgTypeV2 is the '@type' for the out-of-band 2.0 invitation message.
+	InvitationMsgTypeV2 = PIURIV2 + "/invitation"
 
 	// TODO channel size - https://github.com/hyperledger/aries-framework-go/issues/246
 	callbackChannelSize = 10
@@ -143,6 +147,17 @@ type context struct {
 	RouterConnections  []string
 }
 
+// logger returns the logger of the protocol instance.
+func (c *context) logger() *log.Log {
+	fields := append(service.LogFields(c.Msg), log.FieldPIID, c.PIID)
+
+	if c.ConnectionID != "" {
+		fields = append(fields, log.FieldConnectionID, c.ConnectionID)
+	}
+
+	return logger.With(fields...)
+}
+
 // Provider provides this service's dependencies.
 type Provider interface {
 	Service(id string) (interface{}, error)
@@ -216,16 +231,25 @@ func (s *Service) Name() string {
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case InvitationMsgType, HandshakeReuseMsgType, HandshakeReuseAcceptedMsgType, OldInvitationMsgType:
+	case InvitationMsgType, HandshakeReuseMsgType, HandshakeReuseAcceptedMsgType, OldInvitationMsgType,
+		InvitationMsgTypeV2:
 		return true
 	}
 
 	return false
 }
 
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		InvitationMsgType, HandshakeReuseMsgType, HandshakeReuseAcceptedMsgType, OldInvitationMsgType,
+		InvitationMsgTypeV2,
+	}
+}
+
 // HandleInbound handles inbound messages.
 func (s *Service) HandleInbound(msg service.DIDCommMsg, didCommCtx service.DIDCommContext) (string, error) {
-	logger.Debugf("inbound message: %s", msg)
+	logger.With(service.LogFields(msg)...).Debugf("inbound message: %s", msg)
 
 	if !s.Accept(msg.Type()) {
 		return "", fmt.Errorf("unsupported message type %s", msg.Type())
@@ -253,7 +277,7 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, didCommCtx service.DIDCo
 }
 
 func (s *Service) handleContext(ctx *context) error { // nolint:funlen
-	logger.Debugf("context: %+v", ctx)
+	ctx.logger().Debugf("context: %+v", ctx)
 
 	current, err := stateFromName(ctx.CurrentStateName)
 	if err != nil {
@@ -274,7 +298,7 @@ func (s *Service) handleContext(ctx *context) error { // nolint:funlen
 	)
 
 	for !stop {
-		logger.Debugf("start executing state %s", current.Name())
+		ctx.logger().Debugf("start executing state %s", current.Name())
 
 		msgCopy := ctx.Msg.Clone()
 
@@ -291,7 +315,7 @@ func (s *Service) handleContext(ctx *context) error { // nolint:funlen
 			return fmt.Errorf("failed to execute state %s: %w", current.Name(), err)
 		}
 
-		logger.Debugf("completed %s.Execute()", current.Name())
+		ctx.logger().Debugf("completed %s.Execute()", current.Name())
 
 		ctx.CurrentStateName = next.Name()
 
@@ -309,7 +333,7 @@ func (s *Service) handleContext(ctx *context) error { // nolint:funlen
 
 		sendPostStateMsg(&eventProps{ConnID: ctx.ConnectionID})
 
-		logger.Debugf("end executing state %s", current.Name())
+		ctx.logger().Debugf("end executing state %s", current.Name())
 
 		current = next
 	}
@@ -326,7 +350,7 @@ func (s *Service) updateContext(ctx *context, next state, sendPostStateMsg func(
 			return fmt.Errorf("failed to delete context: %w", err)
 		}
 
-		logger.Debugf("deleted context: %+v", ctx)
+		ctx.logger().Debugf("deleted context: %+v", ctx)
 
 		return nil
 	}
@@ -338,7 +362,7 @@ func (s *Service) updateContext(ctx *context, next state, sendPostStateMsg func(
 		return fmt.Errorf("failed to update context: %w", err)
 	}
 
-	logger.Debugf("updated context: %+v", ctx)
+	ctx.logger().Debugf("updated context: %+v", ctx)
 
 	return nil
 }
@@ -369,20 +393,20 @@ func (s *Service) requestApproval(ctx *context, events chan<- service.DIDCommAct
 				ctx:      ctx,
 			}
 
-			logger.Debugf("continued with options: %+v", opts)
+			ctx.logger().Debugf("continued with options: %+v", opts)
 		},
 		Stop: func(er error) {
-			logger.Infof("user requested protocol to stop: %s", er)
+			ctx.logger().Infof("user requested protocol to stop: %s", er)
 
 			if err := s.deleteContext(ctx.PIID); err != nil {
-				logger.Errorf("delete context: %s", err)
+				ctx.logger().Errorf("delete context: %s", err)
 			}
 		},
 	}
 
 	events <- event
 
-	logger.Debugf("dispatched event: %+v", event)
+	ctx.logger().Debugf("dispatched event: %+v", event)
 }
 
 // Actions returns actions for the async usage.
@@ -505,6 +529,8 @@ func sendMsgEvent(
 
 	logger.Debugf("sending state msg: %+v\n", stateMsg)
 
+	service.ObserveStateMsg(&stateMsg)
+
 	for _, handler := range l.MsgEvents() {
 		handler <- stateMsg
 	}
@@ -517,7 +543,8 @@ func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, err
 }
 
 func (s *Service) currentContext(msg service.DIDCommMsg, ctx service.DIDCommContext, opts Options) (*context, error) {
-	if msg.Type() == InvitationMsgType || msg.Type() == HandshakeReuseMsgType {
+	switch msg.Type() {
+	case InvitationMsgType, InvitationMsgTypeV2, HandshakeReuseMsgType:
 		myContext := &context{
 			Action: Action{
 				PIID:         msg.ID(),
@@ -556,8 +583,18 @@ func (s *Service) currentContext(msg service.DIDCommMsg, ctx service.DIDCommCont
 
 // AcceptInvitation from another agent and return the connection ID.
 func (s *Service) AcceptInvitation(i *Invitation, options Options) (string, error) {
-	msg := service.NewDIDCommMsgMap(i)
+	return s.acceptInvitation(service.NewDIDCommMsgMap(i), options)
+}
 
+// AcceptInvitationV2 accepts an out-of-band 2.0 invitation from another agent. The invitation is accepted without a
+// handshake, its attachments being handled right away, so no connection ID is returned.
+func (s *Service) AcceptInvitationV2(i *InvitationV2, options Options) error {
+	_, err := s.acceptInvitation(service.NewDIDCommMsgMap(i), options)
+
+	return err
+}
+
+func (s *Service) acceptInvitation(msg service.DIDCommMsg, options Options) (string, error) {
 	err := validateInvitationAcceptance(msg, options)
 	if err != nil {
 		return "", fmt.Errorf("unable to accept invitation: %w", err)
@@ -619,7 +656,7 @@ func listener(
 			select {
 			case c := <-callbacks:
 				switch c.msg.Type() {
-				case InvitationMsgType, HandshakeReuseMsgType, OldInvitationMsgType:
+				case InvitationMsgType, HandshakeReuseMsgType, OldInvitationMsgType, InvitationMsgTypeV2:
 					_, err := handleCallbackFunc(c)
 					if err != nil {
 						logutil.LogError(logger, Name, "handleCallback", err.Error(),
@@ -649,7 +686,7 @@ func listener(
 
 func (s *Service) handleCallback(c *callback) (string, error) {
 	switch c.msg.Type() {
-	case InvitationMsgType, OldInvitationMsgType:
+	case InvitationMsgType, OldInvitationMsgType, InvitationMsgTypeV2:
 		return s.handleInvitationCallback(c)
 	case HandshakeReuseMsgType:
 		return "", s.handleHandshakeReuseCallback(c)
@@ -818,7 +855,7 @@ func (s *Service) extractDIDCommMsg(state *attachmentHandlingState) (service.DID
 }
 
 func validateInvitationAcceptance(msg service.DIDCommMsg, opts Options) error { // nolint:gocyclo
-	if msg.Type() != InvitationMsgType {
+	if msg.Type() != InvitationMsgType && msg.Type() != InvitationMsgTypeV2 {
 		return nil
 	}
 
@@ -826,9 +863,7 @@ func validateInvitationAcceptance(msg service.DIDCommMsg, opts Options) error {
 		return errors.New("cannot reuse any connection and also reuse a specific connection")
 	}
 
-	inv := &Invitation{}
-
-	err := msg.Decode(inv)
+	inv, err := decodeInvitation(msg)
 	if err != nil {
 		return fmt.Errorf("validateInvitationAcceptance: failed to decode invitation: %w", err)
 	}
@@ -871,13 +906,16 @@ func validateInvitationAcceptance(msg service.DIDCommMsg, opts Options) error {
 }
 
 func decodeDIDInvitationAndOOBInvitation(c *callback) (*didexchange.OOBInvitation, *Invitation, error) {
-	oobInv := &Invitation{}
-
-	err := c.msg.Decode(oobInv)
+	oobInv, err := decodeInvitation(c.msg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode out-of-band invitation mesesage : %w", err)
 	}
 
+	// the connectionless invitations are handled without did-exchange
+	if connectionless(oobInv) {
+		return nil, oobInv, nil
+	}
+
 	target, err := chooseTarget(oobInv.Services)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to choose a target to connect against : %w", err)
@@ -895,6 +933,59 @@ func decodeDIDInvitationAndOOBInvitation(c *callback) (*didexchange.OOBInvitatio
 	return didInv, oobInv, nil
 }
 
+// decodeInvitation decodes the invitations of both versions of the protocol, the out-of-band 2.0 invitations being
+// converted to the invitation message of this protocol.
+func decodeInvitation(msg service.DIDCommMsg) (*Invitation, error) {
+	if msg.Type() != InvitationMsgTypeV2 {
+		inv := &Invitation{}
+
+		return inv, msg.Decode(inv)
+	}
+
+	invV2 := &InvitationV2{}
+
+	err := msg.Decode(invV2)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &Invitation{
+		ID:    invV2.ID,
+		Type:  invV2.Type,
+		Label: invV2.Label,
+	}
+
+	if invV2.From != "" {
+		inv.Services = []interface{}{invV2.From}
+	}
+
+	if invV2.Body != nil {
+		inv.Goal = invV2.Body.Goal
+		inv.GoalCode = invV2.Body.GoalCode
+		inv.Accept = invV2.Body.Accept
+	}
+
+	for _, a := range invV2.Attachments {
+		inv.Requests = append(inv.Requests, &decorator.Attachment{
+			ID:          a.ID,
+			Description: a.Description,
+			FileName:    a.FileName,
+			MimeType:    a.MediaType,
+			LastModTime: a.LastModTime,
+			ByteCount:   a.ByteCount,
+			Data:        a.Data,
+		})
+	}
+
+	return inv, nil
+}
+
+// connectionless tells whether an invitation is accepted without handshake: the out-of-band 2.0 invitations and the
+// invitations having requests but no handshake protocols. Their requests are handled right away.
+func connectionless(inv *Invitation) bool {
+	return inv.Type == InvitationMsgTypeV2 || len(inv.Protocols) == 0 && len(inv.Requests) > 0
+}
+
 func chooseTarget(svcs []interface{}) (interface{}, error) {
 	for i := range svcs {
 		switch svc := svcs[i].(type) {
@@ -104,6 +104,7 @@ func TestAccept(t *testing.T) {
 		s, err := New(testProvider())
 		require.NoError(t, err)
 		require.True(t, s.Accept("https://didcomm.org/out-of-band/1.0/invitation"))
+		require.True(t, s.Accept("https://didcomm.org/out-of-band/2.0/invitation"))
 	})
 	t.Run("rejects unsupported messages", func(t *testing.T) {
 		s, err := New(testProvider())
@@ -858,6 +859,130 @@ func TestAcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestAcceptConnectionlessInvitation(t *testing.T) {
+	newProvider := func(didSvcInvoked *bool, dispatched chan service.DIDCommMsg) *protocol.MockProvider {
+		provider := testProvider()
+		provider.ServiceMap[didexchange.DIDExchange] = &mockdidexchange.MockDIDExchangeSvc{
+			RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+				*didSvcInvoked = true
+
+				return "", nil
+			},
+		}
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+				dispatched <- msg
+
+				return "", nil
+			}}
+		}
+
+		return provider
+	}
+
+	t.Run("dispatches the request of an invitation without handshake protocols", func(t *testing.T) {
+		didSvcInvoked := false
+		dispatched := make(chan service.DIDCommMsg, 1)
+		s := newAutoService(t, newProvider(&didSvcInvoked, dispatched))
+
+		inv := newInvitation()
+		inv.Protocols = nil
+
+		connID, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Empty(t, connID)
+		require.False(t, didSvcInvoked)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("accepts out-of-band 2.0 invitations", func(t *testing.T) {
+		didSvcInvoked := false
+		dispatched := make(chan service.DIDCommMsg, 1)
+		s := newAutoService(t, newProvider(&didSvcInvoked, dispatched))
+
+		err := s.AcceptInvitationV2(newInvitationV2(), &userOptions{})
+		require.NoError(t, err)
+		require.False(t, didSvcInvoked)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("accepts out-of-band 2.0 invitations without attachments", func(t *testing.T) {
+		didSvcInvoked := false
+		dispatched := make(chan service.DIDCommMsg, 1)
+		s := newAutoService(t, newProvider(&didSvcInvoked, dispatched))
+
+		inv := newInvitationV2()
+		inv.Attachments = nil
+
+		require.NoError(t, s.AcceptInvitationV2(inv, &userOptions{}))
+		require.False(t, didSvcInvoked)
+		require.Empty(t, dispatched)
+	})
+
+	t.Run("error if out-of-band 2.0 invitation has invalid accept values", func(t *testing.T) {
+		s := newAutoService(t, testProvider())
+
+		inv := newInvitationV2()
+		inv.Body.Accept = []string{"INVALID"}
+
+		err := s.AcceptInvitationV2(inv, &userOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid media type profile")
+	})
+
+	t.Run("handles inbound out-of-band 2.0 invitations", func(t *testing.T) {
+		didSvcInvoked := false
+		dispatched := make(chan service.DIDCommMsg, 1)
+		s := newAutoService(t, newProvider(&didSvcInvoked, dispatched))
+
+		_, err := s.HandleInbound(service.NewDIDCommMsgMap(newInvitationV2()), service.NewDIDCommContext(myDID, "", nil))
+		require.NoError(t, err)
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, "test-type", msg.Type())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		require.False(t, didSvcInvoked)
+	})
+}
+
+func TestDecodeInvitation(t *testing.T) {
+	t.Run("converts out-of-band 2.0 invitations", func(t *testing.T) {
+		expected := newInvitationV2()
+
+		inv, err := decodeInvitation(service.NewDIDCommMsgMap(expected))
+		require.NoError(t, err)
+		require.Equal(t, expected.ID, inv.ID)
+		require.Equal(t, InvitationMsgTypeV2, inv.Type)
+		require.Equal(t, []interface{}{expected.From}, inv.Services)
+		require.Equal(t, expected.Body.GoalCode, inv.GoalCode)
+		require.Equal(t, expected.Body.Accept, inv.Accept)
+		require.Len(t, inv.Requests, 1)
+		require.Equal(t, expected.Attachments[0].MediaType, inv.Requests[0].MimeType)
+		require.True(t, connectionless(inv))
+	})
+
+	t.Run("fails to decode out-of-band 2.0 invitations", func(t *testing.T) {
+		_, err := decodeInvitation(service.DIDCommMsgMap{"type": InvitationMsgTypeV2, "body": "invalid"})
+		require.Error(t, err)
+	})
+}
+
 func TestSaveInvitation(t *testing.T) {
 	t.Run("saves invitation", func(t *testing.T) {
 		savedInStore := false
@@ -1008,6 +1133,31 @@ func newInvitation() *Invitation {
 	}
 }
 
+func newInvitationV2() *InvitationV2 {
+	return &InvitationV2{
+		ID:   uuid.New().String(),
+		Type: InvitationMsgTypeV2,
+		From: "did:example:1235",
+		Body: &InvitationBody{
+			Goal:     "test",
+			GoalCode: "test",
+			Accept:   []string{MediaTypeProfileDIDCommV2},
+		},
+		Attachments: []*decorator.AttachmentV2{
+			{
+				ID:        uuid.New().String(),
+				MediaType: "application/json",
+				Data: decorator.AttachmentData{
+					JSON: map[string]interface{}{
+						"@id":   "123",
+						"@type": "test-type",
+					},
+				},
+			},
+		},
+	}
+}
+
 func newCallback() *callback {
 	inv := newInvitation()
 
@@ -68,7 +68,7 @@ func stateFromName(n string) (state, error) {
 
 func requiresApproval(msg service.DIDCommMsg) bool {
 	switch msg.Type() {
-	case InvitationMsgType, HandshakeReuseMsgType:
+	case InvitationMsgType, InvitationMsgTypeV2, HandshakeReuseMsgType:
 		return true
 	}
 
@@ -180,6 +180,10 @@ func (s *statePrepareResponse) Execute(ctx *context, deps *dependencies) (state,
 		return s.connectionReuse(ctx, deps)
 	}
 
+	if connectionless(ctx.Invitation) {
+		return s.connectionless(ctx, deps)
+	}
+
 	logger.Debugf("creating new connection using context: %+v", ctx)
 
 	connID, err := deps.didSvc.RespondTo(ctx.DIDExchangeInv, ctx.RouterConnections)
@@ -205,6 +209,26 @@ func (s *statePrepareResponse) Execute(ctx *context, deps *dependencies) (state,
 	return &stateDone{}, noAction, false, nil
 }
 
+func (s *statePrepareResponse) connectionless(ctx *context, deps *dependencies) (state, finisher, bool, error) {
+	logger.Debugf("accepting invitation without handshake using context: %+v", ctx)
+
+	if len(ctx.Invitation.Requests) == 0 {
+		return &stateDone{}, noAction, false, nil
+	}
+
+	err := deps.saveAttchStateFunc(&attachmentHandlingState{
+		ID:         ctx.Invitation.ID,
+		Invitation: ctx.Invitation,
+	})
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("failed to save attachment handling state: %w", err)
+	}
+
+	return &stateDone{}, func(service.Messenger) error {
+		return deps.dispatchAttachmntFunc(ctx.Invitation.ID, ctx.MyDID, ctx.TheirDID)
+	}, false, nil
+}
+
 func (s *statePrepareResponse) connectionReuse(ctx *context, deps *dependencies) (state, finisher, bool, error) {
 	logger.Debugf("reusing connection using context: %+v", ctx)
 
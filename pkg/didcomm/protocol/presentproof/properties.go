@@ -13,6 +13,8 @@ const (
 	theirDIDPropKey = "theirDID"
 	piidPropKey     = "piid"
 	errorPropKey    = "error"
+
+	trustDecisionPropKey = "trustDecision"
 )
 
 type eventProps struct {
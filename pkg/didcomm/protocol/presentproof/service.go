@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package presentproof
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,8 +15,12 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -41,6 +46,8 @@ const (
 const (
 	internalDataKey        = "internal_data_"
 	transitionalPayloadKey = "transitionalPayload_%s"
+
+	presentationDefinitionFormat = "dif/presentation-exchange/definitions@v1.0"
 )
 
 // nolint:gochecknoglobals
@@ -77,6 +84,13 @@ type metaData struct {
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
 	err error
+	// done is called once the callback is handled.
+	done func()
+}
+
+// logger returns the logger of the protocol instance.
+func (md *metaData) logger() *log.Log {
+	return logger.With(append(service.LogFields(md.msgClone), log.FieldPIID, md.PIID)...)
 }
 
 func (md *metaData) Message() service.DIDCommMsg {
@@ -177,14 +191,29 @@ type Provider interface {
 	StorageProvider() storage.Provider
 }
 
+// trustRegistryProvider is implemented by the providers supporting trust registry lookups.
+type trustRegistryProvider interface {
+	TrustRegistry() trustregistry.Provider
+}
+
+// connectionLookupProvider is implemented by the providers of the connection records, used to look up the public
+// DID of the verifiers in the trust registry.
+type connectionLookupProvider interface {
+	ProtocolStateStorageProvider() storage.Provider
+	StorageProvider() storage.Provider
+}
+
 // Service for the presentproof protocol.
 type Service struct {
 	service.Action
 	service.Message
-	store      storage.Store
-	callbacks  chan *metaData
-	messenger  service.Messenger
-	middleware Handler
+	store         storage.Store
+	callbacks     chan *metaData
+	messenger     service.Messenger
+	middleware    Handler
+	inFlight      shutdown.Tracker
+	trustRegistry trustregistry.Provider
+	connections   *connection.Lookup
 }
 
 // New returns the presentproof service.
@@ -206,6 +235,17 @@ func New(p Provider) (*Service, error) {
 		middleware: initialHandler,
 	}
 
+	if trp, ok := p.(trustRegistryProvider); ok {
+		svc.trustRegistry = trp.TrustRegistry()
+	}
+
+	if clp, ok := p.(connectionLookupProvider); ok && svc.trustRegistry != nil {
+		svc.connections, err = connection.NewLookup(clp)
+		if err != nil {
+			return nil, fmt.Errorf("new connection lookup: %w", err)
+		}
+	}
+
 	// start the listener
 	go svc.startInternalListener()
 
@@ -224,7 +264,8 @@ func (s *Service) Use(items ...Middleware) {
 
 // HandleInbound handles inbound message (presentproof protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
-	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
+	logger.With(service.LogFields(msg)...).Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s",
+		msg, ctx.MyDID(), ctx.TheirDID())
 
 	msgMap := msg.Clone()
 
@@ -251,6 +292,9 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		if err != nil {
 			return "", fmt.Errorf("save transitional payload: %w", err)
 		}
+
+		s.addTrustDecision(md)
+
 		aEvent <- s.newDIDCommActionMsg(md)
 
 		return "", nil
@@ -325,24 +369,42 @@ func (s *Service) doHandle(msg service.DIDCommMsgMap) (*metaData, error) {
 // startInternalListener listens to messages in go channel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbacks {
-		// if no error do handle
-		if msg.err == nil {
-			msg.err = s.handle(msg)
-		}
+		s.handleCallback(msg)
 
-		// no error - continue
-		if msg.err == nil {
-			continue
+		if msg.done != nil {
+			msg.done()
 		}
+	}
+}
+
+func (s *Service) handleCallback(msg *metaData) {
+	// if no error do handle
+	if msg.err == nil {
+		msg.err = s.handle(msg)
+	}
 
-		logger.Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), msg.err)
+	// no error - continue
+	if msg.err == nil {
+		return
+	}
 
-		msg.state = &abandoned{Code: codeInternalError}
+	msg.logger().Errorf("failed to handle msgID=%s : %s", msg.Msg.ID(), msg.err)
 
-		if err := s.handle(msg); err != nil {
-			logger.Errorf("listener handle: %s", err)
-		}
+	msg.state = &abandoned{Code: codeInternalError}
+
+	if err := s.handle(msg); err != nil {
+		msg.logger().Errorf("listener handle: %s", err)
+	}
+}
+
+// Drain refuses the new action callbacks, leaving their actions pending until the service is restarted, and waits
+// for the callbacks being handled, or for ctx to be done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", Name, err)
 	}
+
+	return nil
 }
 
 func isNoOp(s state) bool {
@@ -565,11 +627,18 @@ func (s *Service) ActionContinue(piID string, opt Opt) error {
 		opt(md)
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action continue: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		done()
+
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
@@ -588,7 +657,14 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 		properties:          map[string]interface{}{},
 	}
 
+	done, err := s.inFlight.Add()
+	if err != nil {
+		return fmt.Errorf("action stop: %w", err)
+	}
+
 	if err := s.deleteTransitionalPayload(md.PIID); err != nil {
+		done()
+
 		return fmt.Errorf("delete transitional payload: %w", err)
 	}
 
@@ -597,17 +673,105 @@ func (s *Service) ActionStop(piID string, cErr error) error {
 	}
 
 	md.err = customError{error: cErr}
-	s.processCallback(md)
+	s.processCallback(md, done)
 
 	return nil
 }
 
-func (s *Service) processCallback(msg *metaData) {
+func (s *Service) processCallback(msg *metaData, done func()) {
+	msg.done = done
+
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
 	s.callbacks <- msg
 }
 
+// addTrustDecision adds the trust decision about the verifier requesting the presentation to the properties of the
+// action event, if the service has a trust registry.
+func (s *Service) addTrustDecision(md *metaData) {
+	if s.trustRegistry == nil || md.Msg.Type() != RequestPresentationMsgType {
+		return
+	}
+
+	pd, err := presentationDefinition(md.Msg)
+	if err != nil {
+		md.logger().Warnf("no trust decision: presentation definition: %s", err)
+
+		return
+	}
+
+	decision := trustregistry.NewDecision()
+	decision.CheckVerifier(s.trustRegistry, s.verifierDID(md), pd)
+
+	md.properties[trustDecisionPropKey] = decision
+}
+
+// verifierDID returns the DID of the verifier looked up in the trust registry: the public DID of the invitation
+// of the verifier if the connection was established by one, otherwise their DID of the connection. The latter
+// usually being a pairwise DID (e.g. did:peer) unknown to trust registries, verifiers are only recognized once
+// they invite with their public DID. The checked DID is part of the trust decision.
+func (s *Service) verifierDID(md *metaData) string {
+	if s.connections == nil {
+		return md.TheirDID
+	}
+
+	connID, err := s.connections.GetConnectionIDByDIDs(md.MyDID, md.TheirDID)
+	if err != nil {
+		return md.TheirDID
+	}
+
+	record, err := s.connections.GetConnectionRecord(connID)
+	if err != nil {
+		md.logger().Warnf("trust decision: get connection record: %s", err)
+
+		return md.TheirDID
+	}
+
+	// the invitation DID of the connection records of received invitations is the DID of the inviter.
+	if record.Namespace == connection.MyNSPrefix && record.InvitationDID != "" {
+		return record.InvitationDID
+	}
+
+	return md.TheirDID
+}
+
+// presentationDefinition returns the presentation definition of the request, nil if it does not define one.
+func presentationDefinition(msg service.DIDCommMsgMap) (*presexch.PresentationDefinition, error) {
+	request := RequestPresentation{}
+	if err := msg.Decode(&request); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	for _, format := range request.Formats {
+		if format.Format != presentationDefinitionFormat {
+			continue
+		}
+
+		for i := range request.RequestPresentationsAttach {
+			if request.RequestPresentationsAttach[i].ID != format.AttachID {
+				continue
+			}
+
+			src, err := request.RequestPresentationsAttach[i].Data.Fetch()
+			if err != nil {
+				return nil, fmt.Errorf("fetch attachment: %w", err)
+			}
+
+			var payload struct {
+				PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition"`
+			}
+
+			if err = json.Unmarshal(src, &payload); err != nil {
+				return nil, fmt.Errorf("unmarshal definition: %w", err)
+			}
+
+			return payload.PresentationDefinition, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // newDIDCommActionMsg creates new DIDCommAction message.
 func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
 	// create the message for the channel
@@ -616,19 +780,33 @@ func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
 		ProtocolName: Name,
 		Message:      md.msgClone,
 		Continue: func(opt interface{}) {
+			done, addErr := s.inFlight.Add()
+			if addErr != nil {
+				md.logger().Warnf("action left pending: %s", addErr)
+
+				return
+			}
+
 			if fn, ok := opt.(Opt); ok {
 				fn(md)
 			}
 
 			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-				logger.Errorf("continue: delete transitional payload: %v", err)
+				md.logger().Errorf("continue: delete transitional payload: %v", err)
 			}
 
-			s.processCallback(md)
+			s.processCallback(md, done)
 		},
 		Stop: func(cErr error) {
+			done, addErr := s.inFlight.Add()
+			if addErr != nil {
+				md.logger().Warnf("action left pending: %s", addErr)
+
+				return
+			}
+
 			if err := s.deleteTransitionalPayload(md.PIID); err != nil {
-				logger.Errorf("stop: delete transitional payload: %v", err)
+				md.logger().Errorf("stop: delete transitional payload: %v", err)
 			}
 
 			if cErr == nil {
@@ -636,13 +814,15 @@ func (s *Service) newDIDCommActionMsg(md *metaData) service.DIDCommAction {
 			}
 
 			md.err = customError{error: cErr}
-			s.processCallback(md)
+			s.processCallback(md, done)
 		},
 		Properties: newEventProps(md),
 	}
 }
 
 func (s *Service) execute(next state, md *metaData) (state, stateAction, error) {
+	md.logger().Debugf("executing state %s", next.Name())
+
 	md.state = next
 	s.sendMsgEvents(md, next.Name(), service.PreState)
 
@@ -659,15 +839,19 @@ func (s *Service) execute(next state, md *metaData) (state, stateAction, error)
 
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
+	msg := service.StateMsg{
+		ProtocolName: Name,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	}
+
+	service.ObserveStateMsg(&msg)
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Name,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
+		handler <- msg
 	}
 }
 
@@ -686,3 +870,11 @@ func (s *Service) Accept(msgType string) bool {
 
 	return false
 }
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{
+		ProposePresentationMsgType, RequestPresentationMsgType,
+		PresentationMsgType, AckMsgType, ProblemReportMsgType,
+	}
+}
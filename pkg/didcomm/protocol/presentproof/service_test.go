@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package presentproof
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -19,12 +20,23 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
+	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
 	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
+	trustregistryfile "github.com/hyperledger/aries-framework-go/pkg/trustregistry/file"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -209,6 +221,40 @@ func TestService_ActionContinue(t *testing.T) {
 	})
 }
 
+func TestService_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := presentproofMocks.NewMockProvider(ctrl)
+	provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+	provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	ch := make(chan service.DIDCommAction, 1)
+	require.NoError(t, svc.RegisterActionEvent(ch))
+
+	_, err = svc.HandleInbound(randomInboundMessage(ProposePresentationMsgType),
+		service.NewDIDCommContext(Alice, Bob, nil))
+	require.NoError(t, err)
+
+	action := <-ch
+
+	require.NoError(t, svc.Drain(context.Background()))
+
+	// the action stays pending once the service is draining
+	action.Continue(WithRequestPresentation(&RequestPresentation{}))
+	action.Stop(nil)
+
+	actions, err := svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.ErrorIs(t, svc.ActionContinue(actions[0].PIID, nil), shutdown.ErrShuttingDown)
+	require.ErrorIs(t, svc.ActionStop(actions[0].PIID, nil), shutdown.ErrShuttingDown)
+}
+
 func TestService_ActionStop(t *testing.T) {
 	t.Run("Error transitional payload (get)", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -1165,3 +1211,314 @@ func Test_nextState(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, next)
 }
+
+func TestService_Connectionless(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newService := func(outbound dispatcher.Outbound, pubKey []byte, endpoint string) *Service {
+		msgrProvider := messengerMocks.NewMockProvider(ctrl)
+		msgrProvider.EXPECT().StorageProvider().Return(mem.NewProvider())
+		msgrProvider.EXPECT().OutboundDispatcher().Return(outbound)
+		msgrProvider.EXPECT().KMS().Return(&mockkms.KeyManager{CrAndExportPubKeyValue: pubKey}).AnyTimes()
+		msgrProvider.EXPECT().ServiceEndpoint().Return(endpoint).AnyTimes()
+
+		msgr, err := messenger.NewMessenger(msgrProvider)
+		require.NoError(t, err)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(msgr)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).Times(2)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	// transmit simulates the transport of a message: the recipient gets its JSON.
+	transmit := func(msg interface{}) service.DIDCommMsgMap {
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		in, err := service.ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+
+		return in
+	}
+
+	verifierKey, _ := fingerprint.CreateDIDKey([]byte("verifier-0123456789abcdef0123456"))
+	proverKey, _ := fingerprint.CreateDIDKey([]byte("prover-0123456789abcdef012345678"))
+
+	verifierService := &decorator.Service{
+		RecipientKeys:   []string{verifierKey},
+		ServiceEndpoint: "http://verifier.example.com",
+	}
+
+	verifierOutbound := dispatcherMocks.NewMockOutbound(ctrl)
+	verifier := newService(verifierOutbound, nil, verifierService.ServiceEndpoint)
+
+	proverOutbound := dispatcherMocks.NewMockOutbound(ctrl)
+	prover := newService(proverOutbound, []byte("prover-0123456789abcdef012345678"), "http://prover.example.com")
+
+	verifierActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, verifier.RegisterActionEvent(verifierActions))
+
+	proverActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, prover.RegisterActionEvent(proverActions))
+
+	// the verifier starts the thread, with a request delivered out-of-band
+	request := service.NewDIDCommMsgMap(&RequestPresentation{Type: RequestPresentationMsgType, WillConfirm: true})
+	request["@id"] = uuid.New().String()
+	request["~service"] = verifierService
+
+	thID, err := verifier.HandleInbound(request, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+	require.Equal(t, request.ID(), thID)
+
+	request["~thread"] = &decorator.Thread{ID: thID}
+
+	// the prover replies to the service decorator of the request
+	_, err = prover.HandleInbound(transmit(request), service.EmptyDIDCommContext())
+	require.NoError(t, err)
+
+	presentations := make(chan service.DIDCommMsgMap, 1)
+
+	proverOutbound.EXPECT().Send(gomock.Any(), proverKey, gomock.Any()).
+		DoAndReturn(func(msg interface{}, _ string, des *service.Destination) error {
+			require.Equal(t, verifierService.RecipientKeys, des.RecipientKeys)
+			require.Equal(t, verifierService.ServiceEndpoint, des.ServiceEndpoint)
+
+			presentations <- transmit(msg)
+
+			return nil
+		})
+
+	(<-proverActions).Continue(WithPresentation(&Presentation{}))
+
+	presentation := <-presentations
+	require.Equal(t, PresentationMsgType, presentation.Type())
+
+	presentationThID, err := presentation.ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, thID, presentationThID)
+
+	// the verifier acks to the service decorator of the presentation, with the key of its request
+	_, err = verifier.HandleInbound(presentation, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+
+	acks := make(chan service.DIDCommMsgMap, 1)
+
+	verifierOutbound.EXPECT().Send(gomock.Any(), verifierKey, gomock.Any()).
+		DoAndReturn(func(msg interface{}, _ string, des *service.Destination) error {
+			require.Equal(t, []string{proverKey}, des.RecipientKeys)
+			require.Equal(t, "http://prover.example.com", des.ServiceEndpoint)
+
+			acks <- transmit(msg)
+
+			return nil
+		})
+
+	(<-verifierActions).Continue(WithFriendlyNames())
+
+	ack := <-acks
+	require.Equal(t, AckMsgType, ack.Type())
+
+	ackThID, err := ack.ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, thID, ackThID)
+
+	// the prover completes the thread
+	done := make(chan struct{})
+	states := make(chan service.StateMsg, 10)
+	require.NoError(t, prover.RegisterMsgEvent(states))
+
+	go func() {
+		for st := range states {
+			if st.Type == service.PostState && st.StateID == stateNameDone {
+				close(done)
+
+				return
+			}
+		}
+	}()
+
+	_, err = prover.HandleInbound(ack, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("timeout")
+	}
+}
+
+func TestService_TrustDecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry, err := trustregistryfile.Parse([]byte(`{"verifiers":[{"did":"Bob","credentialTypes":["PRC"]}]}`))
+	require.NoError(t, err)
+
+	newService := func(registry trustregistry.Provider) *Service {
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).AnyTimes()
+
+		var p Provider = provider
+		if registry != nil {
+			p = &mockTrustRegistryProvider{Provider: provider, registry: registry}
+		}
+
+		svc, err := New(p)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	// request returns a request-presentation of the presentation definition of the schema URIs, if any.
+	request := func(schemaURIs ...string) service.DIDCommMsgMap {
+		msg := &RequestPresentation{Type: RequestPresentationMsgType}
+
+		if len(schemaURIs) > 0 {
+			pd := &presexch.PresentationDefinition{ID: uuid.New().String()}
+
+			for _, uri := range schemaURIs {
+				pd.InputDescriptors = append(pd.InputDescriptors, &presexch.InputDescriptor{
+					ID:     uuid.New().String(),
+					Schema: []*presexch.Schema{{URI: uri}},
+				})
+			}
+
+			msg.Formats = []Format{{AttachID: "pd", Format: presentationDefinitionFormat}}
+			msg.RequestPresentationsAttach = []decorator.Attachment{{
+				ID: "pd",
+				Data: decorator.AttachmentData{
+					JSON: map[string]interface{}{"presentation_definition": pd},
+				},
+			}}
+		}
+
+		raw, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		in, err := service.ParseDIDCommMsgMap(raw)
+		require.NoError(t, err)
+
+		in["@id"] = uuid.New().String()
+		in["~thread"] = decorator.Thread{ID: uuid.New().String()}
+
+		return in
+	}
+
+	receive := func(svc *Service, msg service.DIDCommMsgMap, theirDID string) map[string]interface{} {
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		defer func() { require.NoError(t, svc.UnregisterActionEvent(ch)) }()
+
+		_, err := svc.HandleInbound(msg, service.NewDIDCommContext(Alice, theirDID, nil))
+		require.NoError(t, err)
+
+		return (<-ch).Properties.All()
+	}
+
+	t.Run("Authorized verifier", func(t *testing.T) {
+		properties := receive(newService(registry), request("https://w3id.org/citizenship/v1#PRC"), Bob)
+
+		require.Equal(t, &trustregistry.Decision{
+			Authorized: true,
+			Checks: []trustregistry.Check{{
+				Role: trustregistry.RoleVerifier, DID: Bob, CredentialTypes: []string{"PRC"}, Authorized: true,
+			}},
+		}, properties["trustDecision"])
+	})
+
+	t.Run("Verifier not authorized", func(t *testing.T) {
+		svc := newService(registry)
+
+		properties := receive(svc, request("https://w3id.org/citizenship/v1#PRC", "https://example.com/v1#DL"), Bob)
+		require.False(t, properties["trustDecision"].(*trustregistry.Decision).Authorized)
+
+		properties = receive(svc, request(), "Carol")
+		require.False(t, properties["trustDecision"].(*trustregistry.Decision).Authorized)
+	})
+
+	t.Run("Invalid presentation definition", func(t *testing.T) {
+		msg := request("https://w3id.org/citizenship/v1#PRC")
+		msg["request_presentations~attach"] = []interface{}{map[string]interface{}{
+			"@id":  "pd",
+			"data": map[string]interface{}{"json": "not a presentation definition"},
+		}}
+
+		require.NotContains(t, receive(newService(registry), msg, Bob), "trustDecision")
+	})
+
+	t.Run("Public DID of the verifier invitation", func(t *testing.T) {
+		storeProvider := mem.NewProvider()
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(serviceMocks.NewMockMessenger(ctrl))
+		provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+		p := &mockConnectionsProvider{
+			mockTrustRegistryProvider: mockTrustRegistryProvider{Provider: provider, registry: registry},
+			protocolStateStore:        mem.NewProvider(),
+		}
+
+		recorder, err := connection.NewRecorder(p)
+		require.NoError(t, err)
+
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID:  uuid.New().String(),
+			State:         connection.StateNameCompleted,
+			MyDID:         Alice,
+			TheirDID:      "did:peer:bob",
+			InvitationDID: Bob,
+			Namespace:     connection.MyNSPrefix,
+		}))
+
+		svc, err := New(p)
+		require.NoError(t, err)
+
+		properties := receive(svc, request("https://w3id.org/citizenship/v1#PRC"), "did:peer:bob")
+		require.Equal(t, &trustregistry.Decision{
+			Authorized: true,
+			Checks: []trustregistry.Check{{
+				Role: trustregistry.RoleVerifier, DID: Bob, CredentialTypes: []string{"PRC"}, Authorized: true,
+			}},
+		}, properties["trustDecision"])
+
+		// their pairwise DID is checked without a connection established by a public invitation.
+		properties = receive(svc, request("https://w3id.org/citizenship/v1#PRC"), "did:peer:carol")
+		require.False(t, properties["trustDecision"].(*trustregistry.Decision).Authorized)
+		require.Equal(t, "did:peer:carol", properties["trustDecision"].(*trustregistry.Decision).Checks[0].DID)
+	})
+
+	t.Run("No trust registry", func(t *testing.T) {
+		require.NotContains(t, receive(newService(nil), request(), Bob), "trustDecision")
+	})
+
+	t.Run("No trust decision about proposals", func(t *testing.T) {
+		require.NotContains(t, receive(newService(registry), randomInboundMessage(ProposePresentationMsgType), Bob),
+			"trustDecision")
+	})
+}
+
+type mockTrustRegistryProvider struct {
+	Provider
+	registry trustregistry.Provider
+}
+
+func (p *mockTrustRegistryProvider) TrustRegistry() trustregistry.Provider {
+	return p.registry
+}
+
+type mockConnectionsProvider struct {
+	mockTrustRegistryProvider
+	protocolStateStore storage.Provider
+}
+
+func (p *mockConnectionsProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStore
+}
@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Ping is sent to check that the agent at the other end of a connection is reachable.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type Ping struct {
+	Type string   `json:"type,omitempty"`
+	ID   string   `json:"id,omitempty"`
+	Body PingBody `json:"body"`
+}
+
+// PingBody is the body of the ping, the response being requested unless it states otherwise.
+type PingBody struct {
+	ResponseRequested bool   `json:"response_requested"`
+	Comment           string `json:"comment,omitempty"`
+}
+
+// PingResponse is sent back to the agent which requested a response to its ping.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingResponse struct {
+	Type     string `json:"type,omitempty"`
+	ID       string `json:"id,omitempty"`
+	ThreadID string `json:"thid,omitempty"`
+}
+
+// PingResponseV1 is sent back to the agent which requested a response to its trust ping 1.0.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping
+type PingResponseV1 struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Result is the outcome of a trust ping.
+type Result struct {
+	// PingID is the ID of the ping message.
+	PingID string `json:"ping_id"`
+	// Responded tells whether the other agent responded to the ping.
+	Responded bool `json:"responded"`
+	// Latency is the round trip time of the ping, set when the other agent responded.
+	Latency time.Duration `json:"latency,omitempty"`
+}
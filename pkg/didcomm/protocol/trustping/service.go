@@ -0,0 +1,322 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/trust-ping/2.0/"
+	// PingMsgType defines the protocol ping message type.
+	PingMsgType = Spec + "ping"
+	// PingResponseMsgType defines the protocol ping-response message type.
+	PingResponseMsgType = Spec + "ping-response"
+
+	// SpecV1 defines the trust ping 1.0 protocol spec.
+	SpecV1 = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgTypeV1 defines the trust ping 1.0 ping message type.
+	PingMsgTypeV1 = SpecV1 + "ping"
+	// PingResponseMsgTypeV1 defines the trust ping 1.0 ping_response message type.
+	PingResponseMsgTypeV1 = SpecV1 + "ping_response"
+)
+
+// defaultTimeout is how long Ping waits for the response by default.
+const defaultTimeout = 10 * time.Second
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrNoResponse is returned when the other agent didn't respond to the ping in time.
+	ErrNoResponse = errors.New("no ping response")
+
+	logger = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// PingOpt configures a trust ping.
+type PingOpt func(opts *pingOpts)
+
+type pingOpts struct {
+	withoutResponse bool
+	timeout         time.Duration
+	comment         string
+}
+
+// WithoutResponse sends the ping without requesting a response, Ping returning once the ping is sent.
+func WithoutResponse() PingOpt {
+	return func(opts *pingOpts) {
+		opts.withoutResponse = true
+	}
+}
+
+// WithTimeout sets how long Ping waits for the response, 10 seconds by default.
+func WithTimeout(timeout time.Duration) PingOpt {
+	return func(opts *pingOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// WithComment sets the comment of the ping.
+func WithComment(comment string) PingOpt {
+	return func(opts *pingOpts) {
+		opts.comment = comment
+	}
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	responses        map[string]chan struct{}
+	responsesLock    sync.Mutex
+	inFlight         shutdown.Tracker
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		responses:        make(map[string]chan struct{}),
+	}, nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if !s.Accept(msg.Type()) {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	inbound := msg.Clone()
+
+	err := s.inFlight.Go(func() {
+		var err error
+
+		switch inbound.Type() {
+		case PingMsgType, PingMsgTypeV1:
+			err = s.handlePing(inbound, ctx.MyDID(), ctx.TheirDID())
+		case PingResponseMsgType, PingResponseMsgTypeV1:
+			err = s.handlePingResponse(inbound)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%s)", err)
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("handle inbound: %w", err)
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	for _, t := range s.MessageTypes() {
+		if t == msgType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MessageTypes returns the message types handled by the service.
+func (s *Service) MessageTypes() []string {
+	return []string{PingMsgType, PingResponseMsgType, PingMsgTypeV1, PingResponseMsgTypeV1}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// Drain refuses the new inbound messages and waits for the messages being handled to complete, or for ctx to be
+// done.
+func (s *Service) Drain(ctx context.Context) error {
+	if err := s.inFlight.Drain(ctx); err != nil {
+		return fmt.Errorf("drain %s: %w", TrustPing, err)
+	}
+
+	return nil
+}
+
+// Ping sends a ping to the agent at the other end of the connection. Unless WithoutResponse is given, Ping waits for
+// the response and measures its latency, ErrNoResponse being returned when it doesn't come in time.
+func (s *Service) Ping(connectionID string, opts ...PingOpt) (*Result, error) {
+	options := &pingOpts{timeout: defaultTimeout}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ping := &Ping{
+		Type: PingMsgType,
+		ID:   uuid.New().String(),
+		Body: PingBody{
+			ResponseRequested: !options.withoutResponse,
+			Comment:           options.comment,
+		},
+	}
+
+	if options.withoutResponse {
+		if err = s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+			return nil, fmt.Errorf("send ping: %w", err)
+		}
+
+		return &Result{PingID: ping.ID}, nil
+	}
+
+	responseCh := make(chan struct{}, 1)
+	s.setResponseCh(ping.ID, responseCh)
+
+	defer s.setResponseCh(ping.ID, nil)
+
+	start := time.Now()
+
+	if err = s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send ping: %w", err)
+	}
+
+	select {
+	case <-responseCh:
+		return &Result{PingID: ping.ID, Responded: true, Latency: time.Since(start)}, nil
+	case <-time.After(options.timeout):
+		return nil, fmt.Errorf("ping %s: %w", ping.ID, ErrNoResponse)
+	}
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// the response is requested unless the ping states otherwise
+	ping := struct {
+		ResponseRequested *bool `json:"response_requested"`
+		Body              struct {
+			ResponseRequested *bool `json:"response_requested"`
+		} `json:"body"`
+	}{}
+
+	if err := msg.Decode(&ping); err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	var resp interface{} = &PingResponse{
+		Type:     PingResponseMsgType,
+		ID:       uuid.New().String(),
+		ThreadID: msg.ID(),
+	}
+
+	responseRequested := ping.Body.ResponseRequested
+
+	if msg.Type() == PingMsgTypeV1 {
+		resp = &PingResponseV1{
+			Type:   PingResponseMsgTypeV1,
+			ID:     uuid.New().String(),
+			Thread: &decorator.Thread{ID: msg.ID()},
+		}
+		responseRequested = ping.ResponseRequested
+	}
+
+	if responseRequested != nil && !*responseRequested {
+		return nil
+	}
+
+	if err := s.outbound.SendToDID(resp, myDID, theirDID); err != nil {
+		return fmt.Errorf("send ping response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("ping response thread ID: %w", err)
+	}
+
+	s.responsesLock.Lock()
+	defer s.responsesLock.Unlock()
+
+	responseCh, ok := s.responses[thID]
+	if !ok {
+		logger.Debugf("dropped the response to the unknown ping %s", thID)
+
+		return nil
+	}
+
+	select {
+	case responseCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) setResponseCh(pingID string, responseCh chan struct{}) {
+	s.responsesLock.Lock()
+	defer s.responsesLock.Unlock()
+
+	if responseCh == nil {
+		delete(s.responses, pingID)
+	} else {
+		s.responses[pingID] = responseCh
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	myDID    = "sample-my-did"
+	theirDID = "sample-their-did"
+	connID   = "conn"
+)
+
+func TestService(t *testing.T) {
+	t.Run("new service", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.Equal(t, TrustPing, svc.Name())
+		require.True(t, svc.Accept(PingMsgType))
+		require.True(t, svc.Accept(PingResponseMsgType))
+		require.True(t, svc.Accept("https://didcomm.org/trust_ping/1.0/ping"))
+		require.False(t, svc.Accept("https://didcomm.org/trust_ping/1.0/unknown"))
+		require.Equal(t, []string{PingMsgType, PingResponseMsgType, PingMsgTypeV1, PingResponseMsgTypeV1},
+			svc.MessageTypes())
+
+		_, err := svc.HandleOutbound(nil, myDID, theirDID)
+		require.EqualError(t, err, "not implemented")
+	})
+
+	t.Run("new service - store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("open error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open error")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(struct {
+			Type string `json:"@type"`
+		}{Type: "unknown"}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("responds to the ping", func(t *testing.T) {
+		sent := make(chan *PingResponse, 1)
+
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				require.Equal(t, myDID, my)
+				require.Equal(t, theirDID, their)
+
+				resp, ok := msg.(*PingResponse)
+				require.True(t, ok)
+
+				sent <- resp
+
+				return nil
+			},
+		})
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{"id": "ping-id", "type": "` + PingMsgType + `", "body": {}}`))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, PingResponseMsgType, resp.Type)
+			require.Equal(t, "ping-id", resp.ThreadID)
+
+			respMsg := service.NewDIDCommMsgMap(resp)
+			require.Equal(t, PingResponseMsgType, respMsg["type"])
+			require.NotContains(t, respMsg, "@type")
+
+			thID, err := respMsg.ThreadID()
+			require.NoError(t, err)
+			require.Equal(t, "ping-id", thID)
+		case <-time.After(time.Second):
+			require.Fail(t, "ping response not sent")
+		}
+
+		require.NoError(t, svc.Drain(context.Background()))
+	})
+
+	t.Run("responds to the trust ping 1.0", func(t *testing.T) {
+		for msgJSON, responds := range map[string]bool{
+			`{"@id": "ping-id", "@type": "` + PingMsgTypeV1 + `"}`:                              true,
+			`{"@id": "ping-id", "@type": "` + PingMsgTypeV1 + `", "response_requested": false}`: false,
+			// the 1.0 ping carries the flag at the top level
+			`{"@id": "ping-id", "@type": "` + PingMsgTypeV1 + `", "body": {"response_requested": false}}`: true,
+			// the 2.0 ping carries the flag in its body
+			`{"id": "ping-id", "type": "` + PingMsgType + `", "response_requested": false}`: true,
+		} {
+			sent := make(chan interface{}, 1)
+
+			svc := newService(t, &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, _, _ string) error {
+					sent <- msg
+
+					return nil
+				},
+			})
+
+			msg, err := service.ParseDIDCommMsgMap([]byte(msgJSON))
+			require.NoError(t, err)
+
+			_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+			require.NoError(t, err)
+			require.NoError(t, svc.Drain(context.Background()))
+
+			select {
+			case resp := <-sent:
+				require.True(t, responds, msgJSON)
+
+				if msg.Type() == PingMsgTypeV1 {
+					respV1, ok := resp.(*PingResponseV1)
+					require.True(t, ok, msgJSON)
+					require.Equal(t, PingResponseMsgTypeV1, respV1.Type, msgJSON)
+					require.Equal(t, "ping-id", respV1.Thread.ID)
+
+					respMsg := service.NewDIDCommMsgMap(respV1)
+					require.Equal(t, PingResponseMsgTypeV1, respMsg["@type"])
+				} else {
+					respV2, ok := resp.(*PingResponse)
+					require.True(t, ok, msgJSON)
+					require.Equal(t, PingResponseMsgType, respV2.Type, msgJSON)
+					require.Equal(t, "ping-id", respV2.ThreadID)
+				}
+			default:
+				require.False(t, responds, msgJSON)
+			}
+		}
+	})
+
+	t.Run("doesn't respond when no response is requested", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(interface{}, string, string) error {
+				require.Fail(t, "unexpected ping response")
+
+				return nil
+			},
+		})
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{
+			Type: PingMsgType,
+			ID:   "ping-id",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Drain(context.Background()))
+	})
+
+	t.Run("refuses the messages once draining", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		require.NoError(t, svc.Drain(context.Background()))
+
+		_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&Ping{
+			Type: PingMsgType,
+			ID:   "ping-id",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "shutting down")
+	})
+}
+
+func TestService_Ping(t *testing.T) {
+	t.Run("ping with response", func(t *testing.T) {
+		var svc *Service
+
+		svc = newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, my, their string) error {
+				ping, ok := msg.(*Ping)
+				require.True(t, ok)
+				require.True(t, ping.Body.ResponseRequested)
+				require.Equal(t, "hello", ping.Body.Comment)
+
+				pingMsg := service.NewDIDCommMsgMap(ping)
+				require.Equal(t, PingMsgType, pingMsg["type"])
+				require.Equal(t, ping.ID, pingMsg.ID())
+				require.NotContains(t, pingMsg, "@type")
+
+				// the other agent responds
+				_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{
+					Type:     PingResponseMsgType,
+					ID:       "response-id",
+					ThreadID: ping.ID,
+				}), service.NewDIDCommContext(my, their, nil))
+
+				return err
+			},
+		})
+		stubConnection(t, svc)
+
+		result, err := svc.Ping(connID, WithComment("hello"))
+		require.NoError(t, err)
+		require.True(t, result.Responded)
+		require.NotEmpty(t, result.PingID)
+		require.Greater(t, int64(result.Latency), int64(0))
+	})
+
+	t.Run("ping without response", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				ping, ok := msg.(*Ping)
+				require.True(t, ok)
+				require.False(t, ping.Body.ResponseRequested)
+
+				return nil
+			},
+		})
+		stubConnection(t, svc)
+
+		result, err := svc.Ping(connID, WithoutResponse())
+		require.NoError(t, err)
+		require.False(t, result.Responded)
+		require.Zero(t, result.Latency)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+		stubConnection(t, svc)
+
+		_, err := svc.Ping(connID, WithTimeout(10*time.Millisecond))
+		require.ErrorIs(t, err, ErrNoResponse)
+
+		// a late response is dropped
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponse{
+			Type:     PingResponseMsgType,
+			ID:       "response-id",
+			ThreadID: "unknown",
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{})
+
+		_, err := svc.Ping("unknown")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newService(t, &mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		stubConnection(t, svc)
+
+		_, err := svc.Ping(connID)
+		require.EqualError(t, err, "send ping: send error")
+
+		_, err = svc.Ping(connID, WithoutResponse())
+		require.EqualError(t, err, "send ping: send error")
+	})
+}
+
+func newService(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+	t.Helper()
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func stubConnection(t *testing.T, svc *Service) {
+	t.Helper()
+
+	svc.connectionLookup = &connectionsStub{record: &connection.Record{
+		ConnectionID: connID, MyDID: myDID, TheirDID: theirDID, State: connection.StateNameCompleted,
+	}}
+}
+
+type connectionsStub struct {
+	record *connection.Record
+}
+
+func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, error) {
+	if id != c.record.ConnectionID {
+		return nil, errors.New("unexpected connection")
+	}
+
+	return c.record, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+// ConnectionEventType is the type of a ConnectionEvent.
+type ConnectionEventType string
+
+const (
+	// ConnectionOpened is emitted when a connection is added to the pool, or reconnected.
+	ConnectionOpened ConnectionEventType = "connected"
+	// ConnectionClosed is emitted when a pooled connection is dropped or closed.
+	ConnectionClosed ConnectionEventType = "disconnected"
+	// ConnectionReconnectFailed is emitted when a dropped connection could not be reconnected and is removed from
+	// the pool.
+	ConnectionReconnectFailed ConnectionEventType = "reconnect-failed"
+)
+
+// ConnectionEvent is an event of the pool of the connections a transport keeps open, for instance to receive the
+// return route messages.
+//
+// A reconnected connection is not known of the peer until a message with the return route option is sent on it:
+// mediator clients should send one, like a message pickup status request, on ConnectionOpened events whose
+// Attempt is not 0.
+type ConnectionEvent struct {
+	Type ConnectionEventType
+	// Transport is the name of the transport, like ws.
+	Transport string
+	// Endpoint is the service endpoint of the outbound connections, empty for the inbound ones.
+	Endpoint string
+	// Keys are the keys the connection is pooled for.
+	Keys []string
+	// Attempt is the reconnection attempt, 0 for the first connection.
+	Attempt int
+	// Err is the cause of the disconnection or reconnection failure.
+	Err error
+}
+
+// ConnectionEventSource is implemented by the transports keeping a pool of connections.
+type ConnectionEventSource interface {
+	// RegisterConnectionEvent registers ch to receive the events of the pool. The events are dropped for the
+	// channels which are not ready to receive them.
+	RegisterConnectionEvent(ch chan<- ConnectionEvent) error
+	// UnregisterConnectionEvent unregisters ch.
+	UnregisterConnectionEvent(ch chan<- ConnectionEvent) error
+}
@@ -0,0 +1,228 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package guard protects the inbound transports against oversized envelopes and floods.
+//
+// A Guard limits the size of the envelopes, the rate of the messages per source IP and per recipient key and the
+// number of envelopes unpacked concurrently. It may also reject the envelopes which are not addressed to keys of
+// the agent before running any crypto. A nil *Guard lets everything through.
+package guard
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal/recipient"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+var (
+	// ErrEnvelopeTooLarge is returned for the envelopes larger than the maximum envelope size.
+	ErrEnvelopeTooLarge = errors.New("envelope too large")
+	// ErrRateLimited is returned when the source or a recipient of an envelope exceeded its rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrTooManyUnpacks is returned when the maximum number of envelopes are already being unpacked.
+	ErrTooManyUnpacks = errors.New("too many envelopes being unpacked")
+	// ErrUnknownRecipient is returned for the envelopes having no recipient key owned by the agent.
+	ErrUnknownRecipient = errors.New("envelope not addressed to a key of the agent")
+)
+
+//nolint:gochecknoglobals
+var rejected = metrics.NewCounter("aries_transport_inbound_rejected_total",
+	"Number of inbound envelopes rejected by the transport guard.", "reason")
+
+// Guard checks the envelopes received by the inbound transports before they are unpacked.
+type Guard struct {
+	maxEnvelopeSize int64
+	sources         *limiter
+	recipients      *limiter
+	unpacks         chan struct{}
+	checkRecipients bool
+
+	mu  sync.RWMutex
+	kms kms.KeyManager
+}
+
+// Opt configures a Guard.
+type Opt func(g *Guard)
+
+// WithMaxEnvelopeSize sets the maximum size in bytes of the envelopes. Zero, the default, means no limit.
+func WithMaxEnvelopeSize(size int64) Opt {
+	return func(g *Guard) {
+		g.maxEnvelopeSize = size
+	}
+}
+
+// WithSourceRateLimit limits the messages received from each source IP to rate per second, with bursts of up to
+// burst messages. A zero rate, the default, means no limit.
+func WithSourceRateLimit(rate float64, burst int) Opt {
+	return func(g *Guard) {
+		g.sources = nil
+
+		if rate > 0 {
+			g.sources = newLimiter(rate, burst)
+		}
+	}
+}
+
+// WithRecipientRateLimit limits the messages received for each recipient key to rate per second, with bursts of up
+// to burst messages. A zero rate, the default, means no limit.
+func WithRecipientRateLimit(rate float64, burst int) Opt {
+	return func(g *Guard) {
+		g.recipients = nil
+
+		if rate > 0 {
+			g.recipients = newLimiter(rate, burst)
+		}
+	}
+}
+
+// WithMaxConcurrentUnpacks sets the maximum number of envelopes unpacked concurrently, the envelopes received
+// meanwhile being rejected. Zero, the default, means no limit.
+func WithMaxConcurrentUnpacks(n int) Opt {
+	return func(g *Guard) {
+		g.unpacks = nil
+
+		if n > 0 {
+			g.unpacks = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithRecipientCheck enables rejecting the envelopes whose recipient keys are not found in the KMS of the agent.
+func WithRecipientCheck(enable bool) Opt {
+	return func(g *Guard) {
+		g.checkRecipients = enable
+	}
+}
+
+// New returns a Guard configured with opts.
+func New(opts ...Opt) *Guard {
+	g := &Guard{}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+type kmsProvider interface {
+	KMS() kms.KeyManager
+}
+
+// Start binds the guard to the KMS of prov, the framework context given to the inbound transports, to check the
+// recipients of the envelopes. The recipients are not checked if prov has no KMS.
+func (g *Guard) Start(prov interface{}) {
+	if g == nil {
+		return
+	}
+
+	if p, ok := prov.(kmsProvider); ok {
+		g.mu.Lock()
+		g.kms = p.KMS()
+		g.mu.Unlock()
+	}
+}
+
+// MaxEnvelopeSize returns the maximum size of the envelopes, 0 if unlimited.
+func (g *Guard) MaxEnvelopeSize() int64 {
+	if g == nil {
+		return 0
+	}
+
+	return g.maxEnvelopeSize
+}
+
+// AllowSource applies the rate limit of the source IP of remoteAddr, a host or host:port address.
+func (g *Guard) AllowSource(remoteAddr string) error {
+	if g == nil || g.sources == nil {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	if !g.sources.allow(host) {
+		return reject(ErrRateLimited, "source")
+	}
+
+	return nil
+}
+
+// Admit checks the size, the recipients and the recipient rate limits of envelope, then takes an unpacking slot.
+// release must be called once the envelope is unpacked.
+func (g *Guard) Admit(envelope []byte) (release func(), err error) {
+	release = func() {}
+
+	if g == nil {
+		return release, nil
+	}
+
+	if g.maxEnvelopeSize > 0 && int64(len(envelope)) > g.maxEnvelopeSize {
+		return release, reject(ErrEnvelopeTooLarge, "size")
+	}
+
+	if g.checkRecipients || g.recipients != nil {
+		if err = g.checkEnvelopeRecipients(envelope); err != nil {
+			return release, err
+		}
+	}
+
+	if g.unpacks == nil {
+		return release, nil
+	}
+
+	select {
+	case g.unpacks <- struct{}{}:
+		return func() { <-g.unpacks }, nil
+	default:
+		return release, reject(ErrTooManyUnpacks, "concurrency")
+	}
+}
+
+func (g *Guard) checkEnvelopeRecipients(envelope []byte) error {
+	recipients := recipient.Parse(envelope)
+
+	g.mu.RLock()
+	km := g.kms
+	g.mu.RUnlock()
+
+	if g.checkRecipients && km != nil {
+		recipients = recipient.Owned(km, recipients)
+		if len(recipients) == 0 {
+			return reject(ErrUnknownRecipient, "recipient")
+		}
+	}
+
+	if g.recipients == nil {
+		return nil
+	}
+
+	for _, r := range recipients {
+		// limit by KMS key ID, so that the legacy and the JWE envelopes for the same key share a rate limit.
+		kid, err := r.KeyID()
+		if err != nil {
+			kid = r.KID
+		}
+
+		if !g.recipients.allow(kid) {
+			return reject(ErrRateLimited, "recipient_rate")
+		}
+	}
+
+	return nil
+}
+
+func reject(err error, reason string) error {
+	rejected.Inc(reason)
+
+	return err
+}
@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package guard
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+)
+
+func TestGuard(t *testing.T) {
+	t.Run("nil guard lets everything through", func(t *testing.T) {
+		var g *Guard
+
+		g.Start(&kmsProv{})
+		require.Zero(t, g.MaxEnvelopeSize())
+		require.NoError(t, g.AllowSource("127.0.0.1:1234"))
+
+		release, err := g.Admit([]byte("anything"))
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("envelope size", func(t *testing.T) {
+		g := New(WithMaxEnvelopeSize(4))
+		require.EqualValues(t, 4, g.MaxEnvelopeSize())
+
+		_, err := g.Admit([]byte("1234"))
+		require.NoError(t, err)
+
+		_, err = g.Admit([]byte("12345"))
+		require.ErrorIs(t, err, ErrEnvelopeTooLarge)
+	})
+
+	t.Run("source rate limit", func(t *testing.T) {
+		g := New(WithSourceRateLimit(1, 2))
+
+		require.NoError(t, g.AllowSource("10.0.0.1:1000"))
+		require.NoError(t, g.AllowSource("10.0.0.1:1001"))
+		require.ErrorIs(t, g.AllowSource("10.0.0.1:1002"), ErrRateLimited)
+
+		require.NoError(t, g.AllowSource("10.0.0.2"))
+
+		require.NoError(t, New(WithSourceRateLimit(0, 0)).AllowSource("10.0.0.1:1000"))
+	})
+
+	t.Run("concurrent unpacks", func(t *testing.T) {
+		g := New(WithMaxConcurrentUnpacks(1))
+
+		release, err := g.Admit([]byte("first"))
+		require.NoError(t, err)
+
+		_, err = g.Admit([]byte("second"))
+		require.ErrorIs(t, err, ErrTooManyUnpacks)
+
+		release()
+
+		release, err = g.Admit([]byte("third"))
+		require.NoError(t, err)
+		release()
+	})
+}
+
+func TestGuard_Recipients(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	legacyKID, err := localkms.CreateKID(pub, kms.ED25519Type)
+	require.NoError(t, err)
+
+	owned := &kmsProv{km: &ownedKeys{kids: map[string]bool{"owned-kid": true, legacyKID: true}}}
+
+	envelopes := map[string][]byte{
+		"compact JWE":   compactJWE(t, "owned-kid"),
+		"general JWE":   generalJWE(t, "other-kid", "owned-kid"),
+		"flattened JWE": []byte(`{"protected":"e30","header":{"kid":"owned-kid"},"ciphertext":"abc"}`),
+		"legacy":        legacyEnvelope(t, base58.Encode(pub)),
+	}
+
+	for name, envelope := range envelopes {
+		envelope := envelope
+
+		t.Run(name, func(t *testing.T) {
+			g := New(WithRecipientCheck(true))
+			g.Start(owned)
+
+			_, err := g.Admit(envelope)
+			require.NoError(t, err)
+
+			g.Start(&kmsProv{km: &ownedKeys{}})
+
+			_, err = g.Admit(envelope)
+			require.ErrorIs(t, err, ErrUnknownRecipient)
+		})
+	}
+
+	t.Run("unparsable envelope", func(t *testing.T) {
+		g := New(WithRecipientCheck(true))
+		g.Start(owned)
+
+		for _, envelope := range []string{"{not json", "not.a.jwe", `{"protected":"!!!"}`} {
+			_, err := g.Admit([]byte(envelope))
+			require.ErrorIs(t, err, ErrUnknownRecipient)
+		}
+	})
+
+	t.Run("no KMS", func(t *testing.T) {
+		g := New(WithRecipientCheck(true))
+		g.Start(struct{}{})
+
+		_, err := g.Admit(compactJWE(t, "any-kid"))
+		require.NoError(t, err)
+	})
+
+	t.Run("recipient rate limit", func(t *testing.T) {
+		g := New(WithRecipientCheck(true), WithRecipientRateLimit(1, 1))
+		g.Start(owned)
+
+		_, err := g.Admit(compactJWE(t, "owned-kid"))
+		require.NoError(t, err)
+
+		_, err = g.Admit(generalJWE(t, "unknown-kid", "owned-kid"))
+		require.ErrorIs(t, err, ErrRateLimited)
+
+		_, err = g.Admit(legacyEnvelope(t, base58.Encode(pub)))
+		require.NoError(t, err)
+
+		_, err = g.Admit(compactJWE(t, legacyKID))
+		require.ErrorIs(t, err, ErrRateLimited)
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+
+	l := newLimiter(2, 2)
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.allow("key"))
+	require.True(t, l.allow("key"))
+	require.False(t, l.allow("key"))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.allow("key"))
+	require.False(t, l.allow("key"))
+
+	require.True(t, l.allow("other"))
+	require.Len(t, l.buckets, 2)
+
+	// the buckets refilled since are removed
+	now = now.Add(2 * sweepInterval)
+	require.True(t, l.allow("new"))
+	require.Len(t, l.buckets, 1)
+
+	t.Run("concurrent use", func(t *testing.T) {
+		l := newLimiter(1, 10)
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if l.allow("key") {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 10, allowed)
+	})
+}
+
+type kmsProv struct {
+	km kms.KeyManager
+}
+
+func (p *kmsProv) KMS() kms.KeyManager {
+	return p.km
+}
+
+type ownedKeys struct {
+	mockkms.KeyManager
+	kids map[string]bool
+}
+
+func (k *ownedKeys) Get(keyID string) (interface{}, error) {
+	if k.kids[keyID] {
+		return nil, nil
+	}
+
+	return nil, errors.New("key not found")
+}
+
+func compactJWE(t *testing.T, kid string) []byte {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"typ": "application/didcomm-encrypted+json", "kid": kid})
+	require.NoError(t, err)
+
+	return []byte(base64.RawURLEncoding.EncodeToString(header) + ".key.iv.ciphertext.tag")
+}
+
+func generalJWE(t *testing.T, kids ...string) []byte {
+	t.Helper()
+
+	var recipients []map[string]interface{}
+
+	for _, kid := range kids {
+		recipients = append(recipients, map[string]interface{}{
+			"header":        map[string]string{"kid": kid},
+			"encrypted_key": "key",
+		})
+	}
+
+	envelope, err := json.Marshal(map[string]interface{}{
+		"protected":  base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"application/didcomm-encrypted+json"}`)),
+		"recipients": recipients,
+		"ciphertext": "ciphertext",
+	})
+	require.NoError(t, err)
+
+	return envelope
+}
+
+func legacyEnvelope(t *testing.T, verKey string) []byte {
+	t.Helper()
+
+	protected, err := json.Marshal(map[string]interface{}{
+		"typ": "JWM/1.0",
+		"alg": "Authcrypt",
+		"recipients": []map[string]interface{}{
+			{"encrypted_key": "key", "header": map[string]string{"kid": verKey}},
+		},
+	})
+	require.NoError(t, err)
+
+	envelope, err := json.Marshal(map[string]string{
+		"protected":  base64.URLEncoding.EncodeToString(protected),
+		"ciphertext": "ciphertext",
+	})
+	require.NoError(t, err)
+
+	return envelope
+}
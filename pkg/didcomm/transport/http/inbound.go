@@ -10,48 +10,95 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/rs/cors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/guard"
 )
 
 var logger = log.New("aries-framework/http")
 
+const httpTransport = "http"
+
 // TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
 
+type inboundCommHTTPOpts struct {
+	guard *guard.Guard
+}
+
+// InboundHTTPOpt is an inbound HTTP transport option.
+type InboundHTTPOpt func(opts *inboundCommHTTPOpts)
+
+// WithInboundGuard sets the guard checking the size, source, recipients and rate of the envelopes before they are
+// unpacked. The rejected requests get a 413, 429, 503 or 400 status code.
+func WithInboundGuard(g *guard.Guard) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.guard = g
+	}
+}
+
 // NewInboundHandler will create a new handler to enforce Did-Comm HTTP transport specs
 // then routes processing to the mandatory 'msgHandler' argument.
 //
 // Arguments:
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
-func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
+func NewInboundHandler(prov transport.Provider, opts ...InboundHTTPOpt) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
+	inOpts := &inboundCommHTTPOpts{}
+
+	for _, opt := range opts {
+		opt(inOpts)
+	}
+
+	inOpts.guard.Start(prov)
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
+		processPOSTRequest(w, r, prov, inOpts.guard)
 	})
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider, g *guard.Guard) {
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
 
-	if valid := validatePayload(r, w); !valid {
+	if err := g.AllowSource(r.RemoteAddr); err != nil {
+		rejectRequest(w, r, err)
+
+		return
+	}
+
+	if valid := validatePayload(r, w, g.MaxEnvelopeSize()); !valid {
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
+	var err error
+
+	start := time.Now()
+	_, span := tracing.Start(r.Context(), "transport.inbound", tracing.Attr("transport", httpTransport))
+
+	defer func() {
+		span.RecordError(err)
+		span.End()
+		transport.ObserveInbound(httpTransport, start, err)
+	}()
+
+	body, err := readPayload(r, g.MaxEnvelopeSize())
 	if err != nil {
 		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "Failed to read payload", http.StatusInternalServerError)
@@ -59,7 +106,17 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	release, err := g.Admit(body)
+	if err != nil {
+		rejectRequest(w, r, err)
+
+		return
+	}
+
 	unpackMsg, err := prov.Packager().UnpackMessage(body)
+
+	release()
+
 	if err != nil {
 		logger.Errorf("failed to unpack msg: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "failed to unpack msg", http.StatusInternalServerError)
@@ -67,10 +124,16 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	span.SetThreadID(tracing.ThreadID(unpackMsg.Message))
+
 	messageHandler := prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg)
-	if err != nil {
+	if errors.Is(err, shutdown.ErrShuttingDown) {
+		logger.Warnf("refused incoming msg: %s - returning Code: %d", err, http.StatusServiceUnavailable)
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	} else if err != nil {
 		// TODO https://github.com/hyperledger/aries-framework-go/issues/271 HTTP Response Codes based on errors
 		//  from service
 		logger.Errorf("incoming msg processing failed: %s", err)
@@ -81,15 +144,50 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 }
 
 // validatePayload validate and get the payload from the request.
-func validatePayload(r *http.Request, w http.ResponseWriter) bool {
+func validatePayload(r *http.Request, w http.ResponseWriter, maxSize int64) bool {
 	if r.ContentLength == 0 { // empty payload should not be accepted
 		http.Error(w, "Empty payload", http.StatusBadRequest)
 		return false
 	}
 
+	if maxSize > 0 && r.ContentLength > maxSize {
+		rejectRequest(w, r, guard.ErrEnvelopeTooLarge)
+		return false
+	}
+
 	return true
 }
 
+// readPayload reads the request body, up to maxSize bytes plus one so that the guard rejects the larger ones.
+func readPayload(r *http.Request, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return ioutil.ReadAll(r.Body)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(r.Body, maxSize+1))
+}
+
+// rejectRequest replies to a request rejected by the guard.
+func rejectRequest(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadRequest
+
+	switch {
+	case errors.Is(err, guard.ErrEnvelopeTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, guard.ErrRateLimited):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, guard.ErrTooManyUnpacks):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+
+	logger.Warnf("rejected inbound request from %s: %s - returning Code: %d", r.RemoteAddr, err, status)
+	http.Error(w, err.Error(), status)
+}
+
 // validateHTTPMethod validate HTTP method and content-type.
 func validateHTTPMethod(w http.ResponseWriter, r *http.Request) bool {
 	if r.Method != "POST" {
@@ -112,10 +210,11 @@ type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	certFile, keyFile string
+	opts              []InboundHTTPOpt
 }
 
 // NewInbound creates a new HTTP inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundHTTPOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("http address is mandatory")
 	}
@@ -129,12 +228,13 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
+		opts:         opts,
 	}, nil
 }
 
 // Start the http server.
 func (i *Inbound) Start(prov transport.Provider) error {
-	handler, err := NewInboundHandler(prov)
+	handler, err := NewInboundHandler(prov, i.opts...)
 	if err != nil {
 		return fmt.Errorf("HTTP server start failed: %w", err)
 	}
@@ -160,7 +260,12 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting requests and returns once the requests being handled complete, or ctx is done.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("HTTP server shutdown failed: %w", err)
 	}
 
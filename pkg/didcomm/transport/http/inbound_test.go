@@ -15,13 +15,17 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/guard"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 )
 
 type mockProvider struct {
@@ -130,6 +134,119 @@ func TestInboundHandler(t *testing.T) {
 	require.NoError(t, resp.Body.Close())
 }
 
+func TestInboundHandlerGuard(t *testing.T) {
+	post := func(handler http.Handler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", commContentType)
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	newHandler := func(t *testing.T, packager transport.Packager, opts ...guard.Opt) http.Handler {
+		t.Helper()
+
+		handler, err := NewInboundHandler(&mockProvider{packagerValue: packager}, WithInboundGuard(guard.New(opts...)))
+		require.NoError(t, err)
+
+		return handler
+	}
+
+	unpacked := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}}
+
+	t.Run("envelope too large", func(t *testing.T) {
+		handler := newHandler(t, unpacked, guard.WithMaxEnvelopeSize(4))
+
+		require.Equal(t, http.StatusAccepted, post(handler, "1234").Code)
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(handler, "12345").Code)
+
+		// without content length, the body read is limited
+		req := httptest.NewRequest(http.MethodPost, "/", ioutil.NopCloser(bytes.NewBufferString("12345")))
+		req.Header.Set("Content-Type", commContentType)
+		req.ContentLength = -1
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("source rate limited", func(t *testing.T) {
+		handler := newHandler(t, unpacked, guard.WithSourceRateLimit(1, 1))
+
+		require.Equal(t, http.StatusAccepted, post(handler, "data").Code)
+
+		rec := post(handler, "data")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.NotEmpty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("too many concurrent unpacks", func(t *testing.T) {
+		unpacking := make(chan struct{})
+		done := make(chan struct{})
+
+		handler := newHandler(t, &blockingPackager{Packager: unpacked, unpacking: unpacking, done: done},
+			guard.WithMaxConcurrentUnpacks(1))
+
+		first := make(chan int)
+
+		go func() {
+			first <- post(handler, "data").Code
+		}()
+
+		<-unpacking
+
+		rec := post(handler, "data")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+		close(done)
+		require.Equal(t, http.StatusAccepted, <-first)
+	})
+
+	t.Run("unknown recipient", func(t *testing.T) {
+		prov := &kmsProvider{
+			mockProvider: &mockProvider{packagerValue: unpacked},
+			km:           &mockkms.KeyManager{GetKeyErr: errors.New("key not found")},
+		}
+
+		handler, err := NewInboundHandler(prov, WithInboundGuard(guard.New(guard.WithRecipientCheck(true))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, post(handler, `{"protected":"e30","header":{"kid":"kid"}}`).Code)
+
+		prov.km = &mockkms.KeyManager{}
+		handler, err = NewInboundHandler(prov, WithInboundGuard(guard.New(guard.WithRecipientCheck(true))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusAccepted, post(handler, `{"protected":"e30","header":{"kid":"kid"}}`).Code)
+	})
+}
+
+type kmsProvider struct {
+	*mockProvider
+	km kms.KeyManager
+}
+
+func (p *kmsProvider) KMS() kms.KeyManager {
+	return p.km
+}
+
+type blockingPackager struct {
+	*mockpackager.Packager
+	unpacking chan struct{}
+	done      chan struct{}
+}
+
+func (p *blockingPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	p.unpacking <- struct{}{}
+	<-p.done
+
+	return p.Packager.UnpackMessage(encMessage)
+}
+
 func TestInboundTransport(t *testing.T) {
 	t.Run("test inbound transport - with host/port", func(t *testing.T) {
 		port := "26601"
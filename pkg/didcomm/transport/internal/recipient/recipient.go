@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package recipient finds the recipient keys of the inbound envelopes without decrypting them.
+package recipient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+)
+
+// Recipient is a recipient key of an envelope.
+type Recipient struct {
+	KID string
+	// Legacy is set for the base58 verification keys of the legacy envelopes, which are not KMS key IDs.
+	Legacy bool
+}
+
+type recipientHeader struct {
+	KID string `json:"kid,omitempty"`
+}
+
+type jsonEnvelope struct {
+	Protected  string `json:"protected,omitempty"`
+	Recipients []struct {
+		Header recipientHeader `json:"header,omitempty"`
+	} `json:"recipients,omitempty"`
+	Header recipientHeader `json:"header,omitempty"`
+}
+
+type protectedHeader struct {
+	KID string `json:"kid,omitempty"`
+	// Recipients are found in the protected header of the legacy envelopes.
+	Recipients []struct {
+		Header recipientHeader `json:"header,omitempty"`
+	} `json:"recipients,omitempty"`
+}
+
+// Parse returns the recipients of the JWE (compact, flattened or general JSON serialization) or legacy envelope,
+// without decrypting it.
+func Parse(envelope []byte) []Recipient {
+	env := &jsonEnvelope{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(envelope), []byte("{")) {
+		if err := json.Unmarshal(envelope, env); err != nil {
+			return nil
+		}
+	} else {
+		env.Protected = strings.Split(string(envelope), ".")[0]
+	}
+
+	var recipients []Recipient
+
+	for _, r := range env.Recipients {
+		if r.Header.KID != "" {
+			recipients = append(recipients, Recipient{KID: r.Header.KID})
+		}
+	}
+
+	if env.Header.KID != "" {
+		recipients = append(recipients, Recipient{KID: env.Header.KID})
+	}
+
+	prot := &protectedHeader{}
+
+	if err := json.Unmarshal(decodeBase64(env.Protected), prot); err != nil {
+		return recipients
+	}
+
+	if prot.KID != "" {
+		recipients = append(recipients, Recipient{KID: prot.KID})
+	}
+
+	for _, r := range prot.Recipients {
+		if r.Header.KID != "" {
+			recipients = append(recipients, Recipient{KID: r.Header.KID, Legacy: true})
+		}
+	}
+
+	return recipients
+}
+
+// KeyID returns the KMS key ID of the recipient key, computed from the verification key for the legacy envelopes.
+func (r Recipient) KeyID() (string, error) {
+	if !r.Legacy {
+		return r.KID, nil
+	}
+
+	return localkms.CreateKID(base58.Decode(r.KID), kms.ED25519Type)
+}
+
+// Owned returns the recipients whose key is found in km.
+func Owned(km kms.KeyManager, recipients []Recipient) []Recipient {
+	var owned []Recipient
+
+	for _, r := range recipients {
+		kid, err := r.KeyID()
+		if err != nil {
+			continue
+		}
+
+		if _, err = km.Get(kid); err == nil {
+			owned = append(owned, r)
+		}
+	}
+
+	return owned
+}
+
+func decodeBase64(s string) []byte {
+	if b, err := base64.URLEncoding.DecodeString(s); err == nil {
+		return b
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package recipient
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+)
+
+func TestParse(t *testing.T) {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	tests := map[string]struct {
+		envelope string
+		expected []Recipient
+	}{
+		"compact JWE": {
+			envelope: encode([]byte(`{"kid":"kid-1"}`)) + ".key.iv.ciphertext.tag",
+			expected: []Recipient{{KID: "kid-1"}},
+		},
+		"general JWE": {
+			envelope: `{"protected":"e30","recipients":[{"header":{"kid":"kid-1"}},{"header":{"kid":"kid-2"}}]}`,
+			expected: []Recipient{{KID: "kid-1"}, {KID: "kid-2"}},
+		},
+		"flattened JWE": {
+			envelope: `{"protected":"e30","header":{"kid":"kid-1"}}`,
+			expected: []Recipient{{KID: "kid-1"}},
+		},
+		"legacy": {
+			envelope: `{"protected":"` +
+				base64.URLEncoding.EncodeToString([]byte(`{"recipients":[{"header":{"kid":"verkey"}}]}`)) + `"}`,
+			expected: []Recipient{{KID: "verkey", Legacy: true}},
+		},
+		"invalid JSON":             {envelope: "{not json"},
+		"invalid compact JWE":      {envelope: "not.a.jwe"},
+		"invalid protected header": {envelope: `{"protected":"!!!"}`},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Parse([]byte(tc.envelope)))
+		})
+	}
+}
+
+func TestRecipient_KeyID(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	expected, err := localkms.CreateKID(pub, kms.ED25519Type)
+	require.NoError(t, err)
+
+	kid, err := Recipient{KID: base58.Encode(pub), Legacy: true}.KeyID()
+	require.NoError(t, err)
+	require.Equal(t, expected, kid)
+
+	kid, err = Recipient{KID: "kid-1"}.KeyID()
+	require.NoError(t, err)
+	require.Equal(t, "kid-1", kid)
+}
+
+func TestOwned(t *testing.T) {
+	km := &ownedKeys{kids: map[string]bool{"kid-2": true}}
+
+	require.Equal(t, []Recipient{{KID: "kid-2"}}, Owned(km, []Recipient{{KID: "kid-1"}, {KID: "kid-2"}}))
+	require.Empty(t, Owned(km, []Recipient{{KID: "kid-1"}, {KID: "not base58", Legacy: true}}))
+}
+
+type ownedKeys struct {
+	mockkms.KeyManager
+	kids map[string]bool
+}
+
+func (k *ownedKeys) Get(keyID string) (interface{}, error) {
+	if k.kids[keyID] {
+		return nil, nil
+	}
+
+	return nil, errors.New("key not found")
+}
@@ -0,0 +1,23 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+//nolint:gochecknoglobals
+var inboundDuration = metrics.NewHistogram("aries_transport_inbound_duration_seconds",
+	"Time spent by the inbound transports to unpack and handle a received message.", nil, "transport", "status")
+
+// ObserveInbound records in the metrics a message received at start by the inbound transport named transportName
+// and whose handling returned err.
+func ObserveInbound(transportName string, start time.Time, err error) {
+	inboundDuration.Observe(time.Since(start).Seconds(), transportName, metrics.Status(err))
+}
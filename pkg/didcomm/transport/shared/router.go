@@ -0,0 +1,473 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package shared shares inbound transports between several agents hosted by the same process, like the tenants of a
+// multi-tenant cloud agent.
+//
+// The Router starts the inbound transports once and routes each inbound envelope to the agent owning one of its
+// recipient keys. Each agent is given stand-ins of the shared transports, which register it with the Router when
+// the agent starts them and unregister it when the agent stops them.
+//
+// The keys created by the KMS of the agents created with Router.KMS are indexed in the storage of the Router, so
+// that the agent owning an envelope is found in constant time. The other agents are asked in turn whether they own
+// the keys of the envelope.
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal/recipient"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	routerID = "aries-shared-inbound-router"
+
+	// keyStoreName is the name of the store of the index of the agent keys.
+	keyStoreName = "sharedinboundkeys"
+	// agentTag tags the indexed keys with the ID of their agent.
+	agentTag = "agent"
+
+	// routeTTL is how long the agent which unpacked an envelope is kept for the inbound transport to hand the
+	// envelope over, the routes of the envelopes which are never handed over being dropped after it.
+	routeTTL = time.Minute
+)
+
+var (
+	// ErrNoAgent is returned for the envelopes having no recipient key owned by a registered agent.
+	ErrNoAgent = errors.New("no agent found for the envelope recipients")
+
+	logger = log.New("aries-framework/transport/shared")
+)
+
+type kmsProvider interface {
+	KMS() kms.KeyManager
+}
+
+// gracefulInbound is implemented by the inbound transports which can stop within a deadline.
+type gracefulInbound interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Router routes the envelopes received by the shared inbound transports to the registered agents.
+type Router struct {
+	inbounds []transport.InboundTransport
+	// agents counts the registrations of the agents, by agent.
+	agents map[transport.Provider]int
+	// indexed holds the registered agents whose keys are indexed, by agent ID.
+	indexed map[string]transport.Provider
+	keys    storage.Store
+	lock    sync.RWMutex
+	// routes holds the agent which unpacked each envelope until the envelope is handled or the route expires.
+	routes    sync.Map
+	sweepLock sync.Mutex
+	lastSweep time.Time
+}
+
+// route is the agent which unpacked an envelope.
+type route struct {
+	prov    transport.Provider
+	created time.Time
+}
+
+// NewRouter returns a router sharing inbounds, indexing the keys of the agents in storeProvider.
+func NewRouter(storeProvider storage.Provider, inbounds ...transport.InboundTransport) (*Router, error) {
+	keys, err := storeProvider.OpenStore(keyStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open key index store: %w", err)
+	}
+
+	err = storeProvider.SetStoreConfig(keyStoreName, storage.StoreConfiguration{TagNames: []string{agentTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set key index store config: %w", err)
+	}
+
+	return &Router{
+		inbounds:  inbounds,
+		agents:    make(map[transport.Provider]int),
+		indexed:   make(map[string]transport.Provider),
+		keys:      keys,
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// Start starts the shared inbound transports.
+func (r *Router) Start() error {
+	for _, inbound := range r.inbounds {
+		if err := inbound.Start(r); err != nil {
+			return fmt.Errorf("start shared inbound transport: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the shared inbound transports.
+func (r *Router) Stop() error {
+	for _, inbound := range r.inbounds {
+		if err := inbound.Stop(); err != nil {
+			return fmt.Errorf("stop shared inbound transport: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown stops the shared inbound transports, the ones supporting it waiting for the messages being received to
+// be handled or for ctx to be done.
+func (r *Router) Shutdown(ctx context.Context) error {
+	for _, inbound := range r.inbounds {
+		var err error
+
+		if g, ok := inbound.(gracefulInbound); ok {
+			err = g.Shutdown(ctx)
+		} else {
+			err = inbound.Stop()
+		}
+
+		if err != nil {
+			return fmt.Errorf("shut down shared inbound transport: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Inbounds returns the stand-ins of the shared inbound transports to give to an agent, with aries.WithInboundTransport
+// for instance. The agent is routed the envelopes addressed to its keys once it started them.
+func (r *Router) Inbounds() []transport.InboundTransport {
+	inbounds := make([]transport.InboundTransport, len(r.inbounds))
+
+	for i, inbound := range r.inbounds {
+		inbounds[i] = &agentInbound{router: r, shared: inbound}
+	}
+
+	return inbounds
+}
+
+// KMS returns the KMS creator of the agent agentID, wrapping creator so that the keys created by the KMS of the
+// agent are indexed. Give it to the agent with aries.WithKMS for instance.
+func (r *Router) KMS(agentID string, creator kms.Creator) kms.Creator {
+	return func(p kms.Provider) (kms.KeyManager, error) {
+		km, err := creator(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return &indexedKMS{KeyManager: km, router: r, agentID: agentID}, nil
+	}
+}
+
+// RemoveAgent removes the keys of the agent agentID from the index, e.g. once the agent is deleted.
+func (r *Router) RemoveAgent(agentID string) error {
+	iter, err := r.keys.Query(agentTag + ":" + agentID)
+	if err != nil {
+		return fmt.Errorf("query agent keys: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var ops []storage.Operation
+
+	more, err := iter.Next()
+	for ; err == nil && more; more, err = iter.Next() {
+		var kid string
+
+		if kid, err = iter.Key(); err != nil {
+			break
+		}
+
+		ops = append(ops, storage.Operation{Key: kid})
+	}
+
+	if err != nil {
+		return fmt.Errorf("read agent keys: %w", err)
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err = r.keys.Batch(ops); err != nil {
+		return fmt.Errorf("remove agent keys: %w", err)
+	}
+
+	return nil
+}
+
+// InboundMessageHandler routes the unpacked envelopes to the agent which unpacked them.
+func (r *Router) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(envelope *transport.Envelope) error {
+		v, ok := r.routes.LoadAndDelete(envelope)
+		if !ok {
+			return ErrNoAgent
+		}
+
+		return v.(*route).prov.InboundMessageHandler()(envelope)
+	}
+}
+
+// Packager unpacks the envelopes with the packager of the agent owning one of their recipient keys.
+func (r *Router) Packager() transport.Packager {
+	return &routingPackager{router: r}
+}
+
+// AriesFrameworkID returns the ID of the router, the shared inbound transports being started once for all the
+// agents.
+func (r *Router) AriesFrameworkID() string {
+	return routerID
+}
+
+func (r *Router) register(prov transport.Provider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.agents[prov]++
+
+	if km := r.indexedKMS(prov); km != nil {
+		r.indexed[km.agentID] = prov
+	}
+}
+
+func (r *Router) unregister(prov transport.Provider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.agents[prov]--; r.agents[prov] > 0 {
+		return
+	}
+
+	delete(r.agents, prov)
+
+	if km := r.indexedKMS(prov); km != nil && r.indexed[km.agentID] == prov {
+		delete(r.indexed, km.agentID)
+	}
+}
+
+// indexedKMS returns the KMS of prov, or the KMS it decorates, if it indexes its keys with the router.
+func (r *Router) indexedKMS(prov transport.Provider) *indexedKMS {
+	p, ok := prov.(kmsProvider)
+	if !ok {
+		return nil
+	}
+
+	km := p.KMS()
+
+	for {
+		if indexed, ok := km.(*indexedKMS); ok && indexed.router == r {
+			return indexed
+		}
+
+		d, ok := km.(interface{ Unwrap() kms.KeyManager })
+		if !ok {
+			return nil
+		}
+
+		km = d.Unwrap()
+	}
+}
+
+func (r *Router) indexKey(kid, agentID string) error {
+
+	if err := r.keys.Put(kid, []byte(agentID), storage.Tag{Name: agentTag, Value: agentID}); err != nil {
+		return fmt.Errorf("index key: %w", err)
+	}
+
+	return nil
+}
+
+// agent returns the registered agent owning one of the recipient keys of envelope: the agent indexing the key, or
+// else the agent not indexing its keys which owns it.
+func (r *Router) agent(envelope []byte) (transport.Provider, error) {
+	recipients := recipient.Parse(envelope)
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, rec := range recipients {
+		kid, err := rec.KeyID()
+		if err != nil {
+			continue
+		}
+
+		agentID, err := r.keys.Get(kid)
+		if err != nil {
+			if !errors.Is(err, storage.ErrDataNotFound) {
+				logger.Warnf("failed to look up the agent of key %s: %s", kid, err)
+			}
+
+			continue
+		}
+
+		if prov, ok := r.indexed[string(agentID)]; ok {
+			return prov, nil
+		}
+	}
+
+	for prov := range r.agents {
+		p, ok := prov.(kmsProvider)
+		if !ok || r.indexedKMS(prov) != nil {
+			continue
+		}
+
+		if len(recipient.Owned(p.KMS(), recipients)) > 0 {
+			return prov, nil
+		}
+	}
+
+	return nil, ErrNoAgent
+}
+
+// addRoute keeps the agent which unpacked envelope until the envelope is handed over, dropping the expired routes.
+func (r *Router) addRoute(envelope *transport.Envelope, prov transport.Provider) {
+	now := time.Now()
+
+	r.routes.Store(envelope, &route{prov: prov, created: now})
+
+	r.sweepLock.Lock()
+	defer r.sweepLock.Unlock()
+
+	if now.Sub(r.lastSweep) < routeTTL {
+		return
+	}
+
+	r.lastSweep = now
+
+	r.routes.Range(func(k, v interface{}) bool {
+		if now.Sub(v.(*route).created) >= routeTTL {
+			r.routes.Delete(k)
+		}
+
+		return true
+	})
+}
+
+type routingPackager struct {
+	router *Router
+}
+
+func (p *routingPackager) PackMessage(*transport.Envelope) ([]byte, error) {
+	return nil, errors.New("the shared inbound transports don't pack messages")
+}
+
+func (p *routingPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	prov, err := p.router.agent(encMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := prov.Packager().UnpackMessage(encMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	p.router.addRoute(envelope, prov)
+
+	return envelope, nil
+}
+
+// agentInbound stands in for a shared inbound transport in an agent.
+type agentInbound struct {
+	router *Router
+	shared transport.InboundTransport
+	prov   transport.Provider
+	lock   sync.Mutex
+}
+
+// Start registers prov with the router.
+func (i *agentInbound) Start(prov transport.Provider) error {
+	if prov == nil || prov.InboundMessageHandler() == nil {
+		return errors.New("creation of inbound handler failed")
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.prov != nil {
+		return errors.New("shared inbound transport already started")
+	}
+
+	i.prov = prov
+	i.router.register(prov)
+
+	logger.Debugf("agent %s registered with shared inbound transport %s", prov.AriesFrameworkID(), i.Endpoint())
+
+	return nil
+}
+
+// Stop unregisters the agent from the router, the shared inbound transport being left running.
+func (i *agentInbound) Stop() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.prov != nil {
+		i.router.unregister(i.prov)
+		i.prov = nil
+	}
+
+	return nil
+}
+
+// Endpoint returns the endpoint of the shared inbound transport.
+func (i *agentInbound) Endpoint() string {
+	return i.shared.Endpoint()
+}
+
+// indexedKMS indexes the keys created by the KMS of an agent with the router.
+type indexedKMS struct {
+	kms.KeyManager
+	router  *Router
+	agentID string
+}
+
+// Unwrap returns the KMS of the agent.
+func (k *indexedKMS) Unwrap() kms.KeyManager {
+	return k.KeyManager
+}
+
+func (k *indexedKMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	kid, kh, err := k.KeyManager.Create(kt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kid, kh, k.router.indexKey(kid, k.agentID)
+}
+
+func (k *indexedKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	kid, kh, err := k.KeyManager.Rotate(kt, keyID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kid, kh, k.router.indexKey(kid, k.agentID)
+}
+
+func (k *indexedKMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	kid, pubKey, err := k.KeyManager.CreateAndExportPubKeyBytes(kt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kid, pubKey, k.router.indexKey(kid, k.agentID)
+}
+
+func (k *indexedKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	kid, kh, err := k.KeyManager.ImportPrivateKey(privKey, kt, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return kid, kh, k.router.indexKey(kid, k.agentID)
+}
@@ -0,0 +1,375 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shared
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestRouter(t *testing.T) {
+	t.Run("routes the envelopes to the agents owning their recipient keys", func(t *testing.T) {
+		shared := &fakeInbound{endpoint: "http://shared"}
+		router := newRouter(t, shared)
+		require.NoError(t, router.Start())
+		require.Equal(t, router, shared.prov)
+
+		alice := newAgent("alice", "alice-key")
+		bob := newAgent("bob", "bob-key")
+
+		for _, agent := range []*agent{alice, bob} {
+			inbounds := router.Inbounds()
+			require.Len(t, inbounds, 1)
+			require.Equal(t, "http://shared", inbounds[0].Endpoint())
+			require.NoError(t, inbounds[0].Start(agent))
+		}
+
+		require.NoError(t, shared.receive(compactJWE(t, "bob-key")))
+		require.NoError(t, shared.receive(compactJWE(t, "alice-key")))
+
+		require.Len(t, alice.received, 1)
+		require.Len(t, bob.received, 1)
+		require.Equal(t, compactJWE(t, "bob-key"), bob.received[0].Message)
+
+		err := shared.receive(compactJWE(t, "carol-key"))
+		require.True(t, errors.Is(err, ErrNoAgent))
+
+		require.NoError(t, router.Stop())
+		require.True(t, shared.stopped)
+	})
+
+	t.Run("stops routing to the agents which stopped the stand-ins", func(t *testing.T) {
+		shared := &fakeInbound{}
+		router := newRouter(t, shared)
+		require.NoError(t, router.Start())
+
+		alice := newAgent("alice", "alice-key")
+		inbound := router.Inbounds()[0]
+		require.NoError(t, inbound.Start(alice))
+		require.EqualError(t, inbound.Start(alice), "shared inbound transport already started")
+
+		require.NoError(t, inbound.Stop())
+		require.NoError(t, inbound.Stop())
+
+		err := shared.receive(compactJWE(t, "alice-key"))
+		require.True(t, errors.Is(err, ErrNoAgent))
+		require.False(t, shared.stopped)
+	})
+
+	t.Run("routes the envelopes to the agents by their indexed keys", func(t *testing.T) {
+		shared := &fakeInbound{}
+		router := newRouter(t, shared)
+		require.NoError(t, router.Start())
+
+		alice := newAgent("alice")
+		alice.km = newIndexedKMS(t, router, "alice", &mockkms.KeyManager{
+			CreateKeyID:         "alice-key",
+			RotateKeyID:         "alice-rotated-key",
+			CrAndExportPubKeyID: "alice-kw-key",
+			ImportPrivateKeyID:  "alice-imported-key",
+		})
+
+		for _, create := range []func() (string, error){
+			func() (string, error) {
+				kid, _, err := alice.km.Create(kms.ED25519Type)
+
+				return kid, err
+			},
+			func() (string, error) {
+				kid, _, err := alice.km.Rotate(kms.ED25519Type, "alice-key")
+
+				return kid, err
+			},
+			func() (string, error) {
+				kid, _, err := alice.km.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+
+				return kid, err
+			},
+			func() (string, error) {
+				kid, _, err := alice.km.ImportPrivateKey(nil, kms.ED25519Type)
+
+				return kid, err
+			},
+		} {
+			_, err := create()
+			require.NoError(t, err)
+		}
+
+		// the keys of the indexed agents are not looked up in their KMS.
+		bob := newAgent("bob", "bob-key")
+		bob.km = newIndexedKMS(t, router, "bob", bob.keys)
+
+		// the agents may decorate their KMS.
+		alice.km = &decoratedKMS{KeyManager: alice.km}
+
+		require.NoError(t, router.Inbounds()[0].Start(alice))
+		require.NoError(t, router.Inbounds()[0].Start(bob))
+
+		for _, kid := range []string{"alice-key", "alice-rotated-key", "alice-kw-key", "alice-imported-key"} {
+			require.NoError(t, shared.receive(compactJWE(t, kid)))
+		}
+
+		require.Len(t, alice.received, 4)
+
+		err := shared.receive(compactJWE(t, "bob-key"))
+		require.True(t, errors.Is(err, ErrNoAgent))
+
+		require.NoError(t, router.RemoveAgent("alice"))
+		require.NoError(t, router.RemoveAgent("carol"))
+
+		err = shared.receive(compactJWE(t, "alice-key"))
+		require.True(t, errors.Is(err, ErrNoAgent))
+	})
+
+	t.Run("KMS errors", func(t *testing.T) {
+		router := newRouter(t)
+
+		_, err := router.KMS("alice", func(kms.Provider) (kms.KeyManager, error) {
+			return nil, errors.New("kms error")
+		})(nil)
+		require.EqualError(t, err, "kms error")
+
+		km := newIndexedKMS(t, router, "alice", &mockkms.KeyManager{
+			CreateKeyErr:         errors.New("create error"),
+			RotateKeyErr:         errors.New("rotate error"),
+			CrAndExportPubKeyErr: errors.New("export error"),
+			ImportPrivateKeyErr:  errors.New("import error"),
+		})
+
+		_, _, err = km.Create(kms.ED25519Type)
+		require.EqualError(t, err, "create error")
+		_, _, err = km.Rotate(kms.ED25519Type, "kid")
+		require.EqualError(t, err, "rotate error")
+		_, _, err = km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.EqualError(t, err, "export error")
+		_, _, err = km.ImportPrivateKey(nil, kms.ED25519Type)
+		require.EqualError(t, err, "import error")
+
+		router.keys = &mockstorage.MockStore{
+			Store: map[string]mockstorage.DBEntry{}, ErrPut: errors.New("put error"), ErrQuery: errors.New("query error"),
+		}
+
+		_, _, err = newIndexedKMS(t, router, "alice", &mockkms.KeyManager{CreateKeyID: "kid"}).Create(kms.ED25519Type)
+		require.EqualError(t, err, "index key: put error")
+		require.EqualError(t, router.RemoveAgent("alice"), "query agent keys: query error")
+	})
+
+	t.Run("drops the expired routes", func(t *testing.T) {
+		router := newRouter(t)
+		alice := newAgent("alice")
+
+		unhandled := &transport.Envelope{}
+		router.addRoute(unhandled, alice)
+
+		router.lastSweep = time.Now().Add(-routeTTL)
+		router.routes.Store(unhandled, &route{prov: alice, created: time.Now().Add(-routeTTL)})
+
+		handled := &transport.Envelope{}
+		router.addRoute(handled, alice)
+
+		_, ok := router.routes.Load(unhandled)
+		require.False(t, ok)
+
+		require.NoError(t, router.InboundMessageHandler()(handled))
+		require.Len(t, alice.received, 1)
+	})
+
+	t.Run("key index store errors", func(t *testing.T) {
+		_, err := NewRouter(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")})
+		require.EqualError(t, err, "open key index store: open error")
+	})
+
+	t.Run("agent fails to unpack", func(t *testing.T) {
+		shared := &fakeInbound{}
+		router := newRouter(t, shared)
+		require.NoError(t, router.Start())
+
+		alice := newAgent("alice", "alice-key")
+		alice.unpackErr = errors.New("unpack error")
+		require.NoError(t, router.Inbounds()[0].Start(alice))
+
+		require.EqualError(t, shared.receive(compactJWE(t, "alice-key")), "unpack error")
+	})
+
+	t.Run("handles unknown envelopes", func(t *testing.T) {
+		router := newRouter(t)
+
+		err := router.InboundMessageHandler()(&transport.Envelope{})
+		require.True(t, errors.Is(err, ErrNoAgent))
+
+		_, err = router.Packager().PackMessage(&transport.Envelope{})
+		require.Error(t, err)
+		require.NotEmpty(t, router.AriesFrameworkID())
+	})
+
+	t.Run("agent without inbound message handler", func(t *testing.T) {
+		router := newRouter(t, &fakeInbound{})
+
+		require.Error(t, router.Inbounds()[0].Start(nil))
+	})
+
+	t.Run("shared inbound errors", func(t *testing.T) {
+		router := newRouter(t, &fakeInbound{err: errors.New("inbound error")})
+
+		require.EqualError(t, router.Start(), "start shared inbound transport: inbound error")
+		require.EqualError(t, router.Stop(), "stop shared inbound transport: inbound error")
+		require.EqualError(t, router.Shutdown(context.Background()),
+			"shut down shared inbound transport: inbound error")
+	})
+
+	t.Run("shuts the shared inbounds down", func(t *testing.T) {
+		shared := &fakeInbound{}
+
+		require.NoError(t, newRouter(t, shared).Shutdown(context.Background()))
+		require.True(t, shared.stopped)
+	})
+}
+
+func newRouter(t *testing.T, inbounds ...transport.InboundTransport) *Router {
+	t.Helper()
+
+	router, err := NewRouter(mem.NewProvider(), inbounds...)
+	require.NoError(t, err)
+
+	return router
+}
+
+func newIndexedKMS(t *testing.T, router *Router, agentID string, km kms.KeyManager) kms.KeyManager {
+	t.Helper()
+
+	indexed, err := router.KMS(agentID, func(kms.Provider) (kms.KeyManager, error) {
+		return km, nil
+	})(nil)
+	require.NoError(t, err)
+
+	return indexed
+}
+
+type fakeInbound struct {
+	endpoint string
+	prov     transport.Provider
+	stopped  bool
+	err      error
+}
+
+func (i *fakeInbound) Start(prov transport.Provider) error {
+	i.prov = prov
+
+	return i.err
+}
+
+func (i *fakeInbound) Stop() error {
+	i.stopped = true
+
+	return i.err
+}
+
+func (i *fakeInbound) Endpoint() string {
+	return i.endpoint
+}
+
+// receive handles an envelope the way the inbound transports do.
+func (i *fakeInbound) receive(envelope []byte) error {
+	unpacked, err := i.prov.Packager().UnpackMessage(envelope)
+	if err != nil {
+		return err
+	}
+
+	return i.prov.InboundMessageHandler()(unpacked)
+}
+
+type agent struct {
+	id        string
+	keys      *ownedKeys
+	km        kms.KeyManager
+	received  []*transport.Envelope
+	unpackErr error
+}
+
+func newAgent(id string, kids ...string) *agent {
+	keys := &ownedKeys{kids: map[string]bool{}}
+
+	for _, kid := range kids {
+		keys.kids[kid] = true
+	}
+
+	return &agent{id: id, keys: keys, km: keys}
+}
+
+func (a *agent) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(envelope *transport.Envelope) error {
+		a.received = append(a.received, envelope)
+
+		return nil
+	}
+}
+
+func (a *agent) Packager() transport.Packager {
+	return a
+}
+
+func (a *agent) AriesFrameworkID() string {
+	return a.id
+}
+
+func (a *agent) KMS() kms.KeyManager {
+	return a.km
+}
+
+func (a *agent) PackMessage(*transport.Envelope) ([]byte, error) {
+	return nil, nil
+}
+
+func (a *agent) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	if a.unpackErr != nil {
+		return nil, a.unpackErr
+	}
+
+	return &transport.Envelope{Message: encMessage}, nil
+}
+
+type decoratedKMS struct {
+	kms.KeyManager
+}
+
+func (k *decoratedKMS) Unwrap() kms.KeyManager {
+	return k.KeyManager
+}
+
+type ownedKeys struct {
+	mockkms.KeyManager
+	kids map[string]bool
+}
+
+func (k *ownedKeys) Get(keyID string) (interface{}, error) {
+	if k.kids[keyID] {
+		return nil, nil
+	}
+
+	return nil, errors.New("key not found")
+}
+
+func compactJWE(t *testing.T, kid string) []byte {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"typ": "application/didcomm-encrypted+json", "kid": kid})
+	require.NoError(t, err)
+
+	return []byte(base64.RawURLEncoding.EncodeToString(header) + ".key.iv.ciphertext.tag")
+}
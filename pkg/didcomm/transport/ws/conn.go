@@ -0,0 +1,205 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"nhooyr.io/websocket"
+)
+
+var errConnClosed = errors.New("websocket connection closed")
+
+// conn is a pooled websocket connection. Its socket is replaced when an outbound connection reconnects, the sends
+// waiting meanwhile for the new one.
+type conn struct {
+	endpoint string
+	// remoteAddr is the address of the peer of the inbound connections.
+	remoteAddr string
+	opts       *options
+	// queue holds a token for each pending send.
+	queue chan struct{}
+
+	mu     sync.Mutex
+	socket *websocket.Conn
+	// ready is closed once socket is connected.
+	ready chan struct{}
+	// done is closed once the connection is closed for good.
+	done   chan struct{}
+	closed bool
+	keys   []string
+}
+
+func newConn(socket *websocket.Conn, endpoint string, opts *options) *conn {
+	c := &conn{
+		endpoint: endpoint,
+		opts:     opts,
+		queue:    make(chan struct{}, opts.sendQueueSize),
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	c.connected(socket)
+
+	return c
+}
+
+// outbound reports whether the connection was dialed by the agent, and so may be reconnected.
+func (c *conn) outbound() bool {
+	return c.endpoint != ""
+}
+
+// reconnects reports whether the connection is redialed once dropped.
+func (c *conn) reconnects() bool {
+	return c.outbound() && c.opts.reconnectAttempts != 0
+}
+
+func (c *conn) current() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.socket
+}
+
+func (c *conn) connected(socket *websocket.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if max := c.opts.guard.MaxEnvelopeSize(); max > 0 {
+		socket.SetReadLimit(max)
+	}
+
+	c.socket = socket
+	close(c.ready)
+}
+
+func (c *conn) disconnected() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.socket != nil {
+		c.socket = nil
+		c.ready = make(chan struct{})
+	}
+}
+
+// close closes the connection for good and returns whether it was open.
+func (c *conn) close() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+
+	c.closed = true
+	close(c.done)
+
+	return true
+}
+
+func (c *conn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *conn) addKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, k := range c.keys {
+		if k == key {
+			return
+		}
+	}
+
+	c.keys = append(c.keys, key)
+}
+
+func (c *conn) poolKeys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.keys...)
+}
+
+// write sends data once there is room in the send queue and the connection is ready.
+func (c *conn) write(data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.sendTimeout)
+	defer cancel()
+
+	if err := c.enqueue(ctx); err != nil {
+		return err
+	}
+
+	defer func() { <-c.queue }()
+
+	socket, err := c.wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	return socket.Write(ctx, websocket.MessageText, data)
+}
+
+func (c *conn) enqueue(ctx context.Context) error {
+	select {
+	case c.queue <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if c.opts.sendQueuePolicy == DropWhenFull {
+		return ErrSendQueueFull
+	}
+
+	select {
+	case c.queue <- struct{}{}:
+		return nil
+	case <-c.done:
+		return errConnClosed
+	case <-ctx.Done():
+		return fmt.Errorf("wait for send queue : %w", ctx.Err())
+	}
+}
+
+func (c *conn) wait(ctx context.Context) (*websocket.Conn, error) {
+	for {
+		c.mu.Lock()
+		socket, ready, closed := c.socket, c.ready, c.closed
+		c.mu.Unlock()
+
+		if closed {
+			return nil, errConnClosed
+		}
+
+		if socket != nil {
+			return socket, nil
+		}
+
+		select {
+		case <-ready:
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for reconnection : %w", ctx.Err())
+		}
+	}
+}
+
+// peer returns the endpoint of the outbound connections, the remote address of the inbound ones.
+func (c *conn) peer() string {
+	if c.outbound() {
+		return c.endpoint
+	}
+
+	return c.remoteAddr
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendQueue(t *testing.T) {
+	// disconnectedConn returns a connection waiting for its reconnection, with its send queue full.
+	disconnectedConn := func(opts ...Opt) *conn {
+		c := &conn{
+			endpoint: "ws://example.com",
+			opts:     newOptions(append([]Opt{WithSendQueue(1, BlockWhenFull)}, opts...)),
+			ready:    make(chan struct{}),
+			done:     make(chan struct{}),
+		}
+		c.queue = make(chan struct{}, c.opts.sendQueueSize)
+		c.queue <- struct{}{}
+
+		return c
+	}
+
+	t.Run("drop when full", func(t *testing.T) {
+		c := disconnectedConn(WithSendQueue(1, DropWhenFull))
+
+		require.ErrorIs(t, c.write([]byte("dropped")), ErrSendQueueFull)
+	})
+
+	t.Run("block when full", func(t *testing.T) {
+		c := disconnectedConn(WithSendTimeout(20 * time.Millisecond))
+
+		err := c.write([]byte("blocked"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "wait for send queue")
+	})
+
+	t.Run("wait for reconnection", func(t *testing.T) {
+		c := disconnectedConn(WithSendTimeout(20 * time.Millisecond))
+		<-c.queue
+
+		err := c.write([]byte("waiting"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "wait for reconnection")
+		require.Empty(t, c.queue)
+	})
+
+	t.Run("closed while blocked", func(t *testing.T) {
+		c := disconnectedConn()
+
+		blocked := make(chan error)
+
+		go func() {
+			blocked <- c.write([]byte("blocked"))
+		}()
+
+		require.True(t, c.close())
+		require.False(t, c.close())
+		require.ErrorIs(t, <-blocked, errConnClosed)
+
+		<-c.queue
+		require.ErrorIs(t, c.write([]byte("closed")), errConnClosed)
+	})
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	opts := newOptions([]Opt{WithReconnectBackoff(time.Second, 5*time.Second)})
+
+	require.Equal(t, time.Second, opts.backoff(1))
+	require.Equal(t, 2*time.Second, opts.backoff(2))
+	require.Equal(t, 4*time.Second, opts.backoff(3))
+	require.Equal(t, 5*time.Second, opts.backoff(4))
+	require.Equal(t, 5*time.Second, opts.backoff(100))
+}
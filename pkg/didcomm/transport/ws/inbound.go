@@ -20,16 +20,20 @@ import (
 
 var logger = log.New("aries-framework/ws")
 
+var errInboundNotStarted = errors.New("websocket inbound transport not started")
+
 // Inbound http(ws) type.
 type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	pool              *connPool
 	certFile, keyFile string
+	opts              *options
 }
 
-// NewInbound creates a new WebSocket inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+// NewInbound creates a new WebSocket inbound transport instance. The ping, send timeout and send queue options
+// apply to the connections pooled for the return route, the reconnection options are ignored.
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...Opt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("websocket address is mandatory")
 	}
@@ -43,6 +47,7 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
+		opts:         newOptions(opts),
 	}, nil
 }
 
@@ -57,6 +62,7 @@ func (i *Inbound) Start(prov transport.Provider) error {
 	})
 
 	i.pool = getConnPool(prov)
+	i.opts.guard.Start(prov)
 
 	go func() {
 		if err := i.listenAndServe(); !errors.Is(err, http.ErrServerClosed) {
@@ -77,7 +83,14 @@ func (i *Inbound) listenAndServe() error {
 
 // Stop the http(ws) server.
 func (i *Inbound) Stop() error {
-	if err := i.server.Shutdown(context.Background()); err != nil {
+	return i.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting connections and returns once the connection upgrades being handled complete, or ctx is
+// done. The messages received on the connections already opened are refused by the inbound message handler once
+// the framework drains it.
+func (i *Inbound) Shutdown(ctx context.Context) error {
+	if err := i.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("websocket server shutdown failed: %w", err)
 	}
 
@@ -90,13 +103,44 @@ func (i *Inbound) Endpoint() string {
 }
 
 func (i *Inbound) processRequest(w http.ResponseWriter, r *http.Request) {
+	if err := i.opts.guard.AllowSource(r.RemoteAddr); err != nil {
+		logger.Warnf("rejected websocket connection from %s: %s", r.RemoteAddr, err)
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+
+		return
+	}
+
 	c, err := upgradeConnection(w, r)
 	if err != nil {
 		logger.Errorf("failed to upgrade the connection : %v", err)
 		return
 	}
 
-	i.pool.listener(c, false)
+	conn := newConn(c, "", i.opts)
+	conn.remoteAddr = r.RemoteAddr
+
+	i.pool.listener(conn)
+}
+
+// RegisterConnectionEvent registers ch to receive the connected and disconnected events of the pooled connections.
+func (i *Inbound) RegisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if i.pool == nil {
+		return errInboundNotStarted
+	}
+
+	return i.pool.registerListener(ch)
+}
+
+// UnregisterConnectionEvent unregisters ch.
+func (i *Inbound) UnregisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if i.pool == nil {
+		return errInboundNotStarted
+	}
+
+	i.pool.unregisterListener(ch)
+
+	return nil
 }
 
 func upgradeConnection(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
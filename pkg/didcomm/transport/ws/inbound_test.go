@@ -9,13 +9,16 @@ package ws
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/guard"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 )
@@ -165,3 +168,53 @@ func TestInboundDataProcessing(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestInboundGuard(t *testing.T) {
+	t.Run("test inbound transport - source rate limited upgrade", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		inbound, err := NewInbound(port, "", "", "", WithGuard(guard.New(guard.WithSourceRateLimit(1, 1))))
+		require.NoError(t, err)
+
+		mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}}
+		err = inbound.Start(&mockProvider{packagerValue: mockPackager})
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, inbound.Stop())
+		}()
+
+		_, cleanup := websocketClient(t, port)
+		defer cleanup()
+
+		_, resp, err := websocket.Dial(context.Background(), "ws://localhost"+port, nil) //nolint:bodyclose
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("test inbound transport - oversized message closes the connection", func(t *testing.T) {
+		port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		inbound, err := NewInbound(port, "", "", "", WithGuard(guard.New(guard.WithMaxEnvelopeSize(16))))
+		require.NoError(t, err)
+
+		mockPackager := &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: []byte("data")}}
+		err = inbound.Start(&mockProvider{packagerValue: mockPackager})
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, inbound.Stop())
+		}()
+
+		client, _ := websocketClient(t, port)
+
+		ctx := context.Background()
+
+		require.NoError(t, client.Write(ctx, websocket.MessageText, []byte(strings.Repeat("a", 64))))
+
+		_, _, err = client.Read(ctx)
+		require.Error(t, err)
+		require.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+	})
+}
@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/guard"
+)
+
+const (
+	defaultPingInterval      = 30 * time.Second
+	defaultPingTimeout       = 10 * time.Second
+	defaultSendTimeout       = 30 * time.Second
+	defaultSendQueueSize     = 100
+	defaultReconnectAttempts = 10
+	defaultMinBackoff        = 500 * time.Millisecond
+	defaultMaxBackoff        = 30 * time.Second
+)
+
+// ErrSendQueueFull is returned by the sends dropped because the send queue of the connection is full.
+var ErrSendQueueFull = errors.New("websocket send queue is full")
+
+// SendQueuePolicy defines what happens to a send when the send queue of the connection is full.
+type SendQueuePolicy int
+
+const (
+	// BlockWhenFull makes the send wait for a place in the queue, up to the send timeout.
+	BlockWhenFull SendQueuePolicy = iota
+	// DropWhenFull makes the send fail immediately with ErrSendQueueFull.
+	DropWhenFull
+)
+
+type options struct {
+	pingInterval      time.Duration
+	pingTimeout       time.Duration
+	sendTimeout       time.Duration
+	sendQueueSize     int
+	sendQueuePolicy   SendQueuePolicy
+	reconnectAttempts int
+	minBackoff        time.Duration
+	maxBackoff        time.Duration
+	guard             *guard.Guard
+}
+
+// Opt is a websocket transport option.
+type Opt func(opts *options)
+
+// WithPingInterval sets the interval of the pings sent on the pooled connections to keep them alive and detect dead
+// peers, 30 seconds by default. A zero interval disables the pings.
+func WithPingInterval(interval time.Duration) Opt {
+	return func(opts *options) {
+		opts.pingInterval = interval
+	}
+}
+
+// WithPingTimeout sets how long to wait for the pong of a ping, 10 seconds by default. The connection is considered
+// dead and closed if the pong doesn't arrive in time.
+func WithPingTimeout(timeout time.Duration) Opt {
+	return func(opts *options) {
+		opts.pingTimeout = timeout
+	}
+}
+
+// WithSendTimeout sets how long a send may wait for the send queue, the connection and the write, 30 seconds by
+// default.
+func WithSendTimeout(timeout time.Duration) Opt {
+	return func(opts *options) {
+		opts.sendTimeout = timeout
+	}
+}
+
+// WithSendQueue sets the number of sends which may be pending on a pooled connection, 100 by default, and what
+// happens to the sends when the queue is full, BlockWhenFull by default.
+func WithSendQueue(size int, policy SendQueuePolicy) Opt {
+	return func(opts *options) {
+		opts.sendQueueSize = size
+		opts.sendQueuePolicy = policy
+	}
+}
+
+// WithReconnectAttempts sets how many times an outbound pooled connection is redialed once dropped, 10 by default.
+// Zero disables reconnection, a negative number makes the attempts unlimited.
+func WithReconnectAttempts(attempts int) Opt {
+	return func(opts *options) {
+		opts.reconnectAttempts = attempts
+	}
+}
+
+// WithReconnectBackoff sets the delay before the first reconnection attempt, 500 milliseconds by default, which
+// doubles on each failed attempt up to maxBackoff, 30 seconds by default.
+func WithReconnectBackoff(minBackoff, maxBackoff time.Duration) Opt {
+	return func(opts *options) {
+		opts.minBackoff = minBackoff
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// WithGuard sets the guard checking the size, source, recipients and rate of the received envelopes before they are
+// unpacked. The rejected envelopes are dropped, and the connections sending larger envelopes than the maximum
+// envelope size are closed.
+func WithGuard(g *guard.Guard) Opt {
+	return func(opts *options) {
+		opts.guard = g
+	}
+}
+
+func newOptions(opts []Opt) *options {
+	o := &options{
+		pingInterval:      defaultPingInterval,
+		pingTimeout:       defaultPingTimeout,
+		sendTimeout:       defaultSendTimeout,
+		sendQueueSize:     defaultSendQueueSize,
+		reconnectAttempts: defaultReconnectAttempts,
+		minBackoff:        defaultMinBackoff,
+		maxBackoff:        defaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.sendQueueSize < 1 {
+		o.sendQueueSize = 1
+	}
+
+	return o
+}
+
+// backoff returns the delay before the given reconnection attempt, starting at 1.
+func (o *options) backoff(attempt int) time.Duration {
+	delay := o.minBackoff
+
+	for i := 1; i < attempt && delay < o.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > o.maxBackoff {
+		delay = o.maxBackoff
+	}
+
+	return delay
+}
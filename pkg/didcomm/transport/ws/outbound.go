@@ -7,7 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -20,21 +20,28 @@ import (
 
 const webSocketScheme = "ws"
 
+var errNotStarted = errors.New("websocket outbound transport not started")
+
 // OutboundClient websocket outbound.
 type OutboundClient struct {
 	pool *connPool
 	prov transport.Provider
+	opts *options
 }
 
 // NewOutbound creates a client for Outbound WS transport.
-func NewOutbound() *OutboundClient {
-	return &OutboundClient{}
+//
+// The connections opened for the destinations asking for the return route are pooled to receive the responses.
+// They are pinged to detect dead peers, and redialed with an exponential backoff once dropped.
+func NewOutbound(opts ...Opt) *OutboundClient {
+	return &OutboundClient{opts: newOptions(opts)}
 }
 
 // Start starts the outbound transport.
 func (cs *OutboundClient) Start(prov transport.Provider) error {
 	cs.pool = getConnPool(prov)
 	cs.prov = prov
+	cs.opts.guard.Start(prov)
 
 	return nil
 }
@@ -48,7 +55,7 @@ func (cs *OutboundClient) Send(data []byte, destination *service.Destination) (s
 		return "", fmt.Errorf("get websocket connection : %w", err)
 	}
 
-	err = conn.Write(context.Background(), websocket.MessageText, data)
+	err = conn.write(data)
 	if err != nil {
 		logger.Errorf("didcomm failed : transport=ws serviceEndpoint=%s errMsg=%s",
 			destination.ServiceEndpoint, err.Error())
@@ -69,8 +76,29 @@ func (cs *OutboundClient) AcceptRecipient(keys []string) bool {
 	return acceptRecipient(cs.pool, keys)
 }
 
-func (cs *OutboundClient) getConnection(destination *service.Destination) (*websocket.Conn, func(), error) {
-	var conn *websocket.Conn
+// RegisterConnectionEvent registers ch to receive the connected and disconnected events of the pooled connections,
+// the inbound ones included.
+func (cs *OutboundClient) RegisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if cs.pool == nil {
+		return errNotStarted
+	}
+
+	return cs.pool.registerListener(ch)
+}
+
+// UnregisterConnectionEvent unregisters ch.
+func (cs *OutboundClient) UnregisterConnectionEvent(ch chan<- transport.ConnectionEvent) error {
+	if cs.pool == nil {
+		return errNotStarted
+	}
+
+	cs.pool.unregisterListener(ch)
+
+	return nil
+}
+
+func (cs *OutboundClient) getConnection(destination *service.Destination) (*conn, func(), error) {
+	var c *conn
 
 	// get the connection for the routing or recipient keys
 	keys := destination.RecipientKeys
@@ -79,8 +107,8 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 	}
 
 	for _, v := range keys {
-		if c := cs.pool.fetch(v); c != nil {
-			conn = c
+		if pooled := cs.pool.fetch(v); pooled != nil {
+			c = pooled
 
 			break
 		}
@@ -88,34 +116,32 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	cleanup := func() {}
 
-	if conn != nil {
-		return conn, cleanup, nil
+	if c != nil {
+		return c, cleanup, nil
 	}
 
-	var err error
-
-	conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, nil)
+	socket, err := dial(destination.ServiceEndpoint, cs.opts)
 	if err != nil {
-		return nil, cleanup, fmt.Errorf("websocket client : %w", err)
+		return nil, cleanup, err
 	}
 
+	c = newConn(socket, destination.ServiceEndpoint, cs.opts)
+
 	// keep the connection open to listen to the response in case of return route option set
 	if destination.TransportReturnRoute == decorator.TransportReturnRouteAll {
-		for _, v := range destination.RecipientKeys {
-			cs.pool.add(v, conn)
-		}
+		cs.pool.add(c, destination.RecipientKeys...)
 
-		go cs.pool.listener(conn, true)
+		go cs.pool.listener(c)
 
-		return conn, cleanup, nil
+		return c, cleanup, nil
 	}
 
 	cleanup = func() {
-		err = conn.Close(websocket.StatusNormalClosure, "closing the connection")
+		err = socket.Close(websocket.StatusNormalClosure, "closing the connection")
 		if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 			logger.Errorf("failed to close connection: %v", err)
 		}
 	}
 
-	return conn, cleanup, nil
+	return c, cleanup, nil
 }
@@ -77,7 +77,7 @@ func TestClient(t *testing.T) {
 		verKey := "XYZ"
 		recKey := []string{verKey}
 
-		outbound := NewOutbound()
+		outbound := NewOutbound(WithReconnectAttempts(0))
 		require.NotNil(t, outbound)
 
 		require.NoError(t, outbound.Start(&mockProvider{
@@ -97,7 +97,7 @@ func TestClient(t *testing.T) {
 
 		// close the connection and verify
 		conn := outbound.pool.fetch(verKey)
-		require.NoError(t, conn.Close(websocket.StatusNormalClosure, "close conn"))
+		require.NoError(t, conn.current().Close(websocket.StatusNormalClosure, "close conn"))
 		require.False(t, outbound.AcceptRecipient(recKey))
 
 		// connection was remove in prev step
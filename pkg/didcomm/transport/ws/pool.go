@@ -8,26 +8,29 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
-const (
-	// TODO configure ping request frequency.
-	pingFrequency = 30 * time.Second
-)
+const wsTransport = "ws"
 
 type connPool struct {
-	connMap map[string]*websocket.Conn
+	connMap map[string]*conn
 	sync.RWMutex
 	packager   transport.Packager
 	msgHandler transport.InboundMessageHandler
+
+	listenersMu sync.RWMutex
+	listeners   []chan<- transport.ConnectionEvent
 }
 
 // nolint: gochecknoglobals
@@ -38,7 +41,7 @@ func getConnPool(prov transport.Provider) *connPool {
 
 	if _, ok := pool[id]; !ok {
 		pool[id] = &connPool{
-			connMap:    make(map[string]*websocket.Conn),
+			connMap:    make(map[string]*conn),
 			packager:   prov.Packager(),
 			msgHandler: prov.InboundMessageHandler(),
 		}
@@ -47,14 +50,29 @@ func getConnPool(prov transport.Provider) *connPool {
 	return pool[id]
 }
 
-func (d *connPool) add(verKey string, wsConn *websocket.Conn) {
+// add pools c for the verKeys, emitting a ConnectionOpened event if c wasn't pooled yet.
+func (d *connPool) add(c *conn, verKeys ...string) {
+	if len(verKeys) == 0 {
+		return
+	}
+
+	pooled := len(d.keys(c)) > 0
+
 	d.Lock()
-	defer d.Unlock()
 
-	d.connMap[verKey] = wsConn
+	for _, v := range verKeys {
+		d.connMap[v] = c
+		c.addKey(v)
+	}
+
+	d.Unlock()
+
+	if !pooled {
+		d.emit(transport.ConnectionOpened, c, 0, nil)
+	}
 }
 
-func (d *connPool) fetch(verKey string) *websocket.Conn {
+func (d *connPool) fetch(verKey string) *conn {
 	d.RLock()
 	defer d.RUnlock()
 
@@ -68,59 +86,243 @@ func (d *connPool) remove(verKey string) {
 	delete(d.connMap, verKey)
 }
 
-func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
-	verKeys := []string{}
+// keys returns the keys c is still pooled for, the keys being reassigned when other connections are pooled for
+// them.
+func (d *connPool) keys(c *conn) []string {
+	d.RLock()
+	defer d.RUnlock()
+
+	var keys []string
+
+	for _, k := range c.poolKeys() {
+		if d.connMap[k] == c {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+func (d *connPool) removeConn(c *conn) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, k := range c.poolKeys() {
+		if d.connMap[k] == c {
+			delete(d.connMap, k)
+		}
+	}
+}
+
+func (d *connPool) registerListener(ch chan<- transport.ConnectionEvent) error {
+	if ch == nil {
+		return errors.New("nil connection event channel")
+	}
+
+	d.listenersMu.Lock()
+	defer d.listenersMu.Unlock()
+
+	d.listeners = append(d.listeners, ch)
+
+	return nil
+}
+
+func (d *connPool) unregisterListener(ch chan<- transport.ConnectionEvent) {
+	d.listenersMu.Lock()
+	defer d.listenersMu.Unlock()
+
+	for i, l := range d.listeners {
+		if l == ch {
+			d.listeners = append(d.listeners[:i], d.listeners[i+1:]...)
+
+			return
+		}
+	}
+}
+
+func (d *connPool) emit(eventType transport.ConnectionEventType, c *conn, attempt int, err error) {
+	event := transport.ConnectionEvent{
+		Type:      eventType,
+		Transport: wsTransport,
+		Endpoint:  c.endpoint,
+		Keys:      d.keys(c),
+		Attempt:   attempt,
+		Err:       err,
+	}
+
+	d.listenersMu.RLock()
+	defer d.listenersMu.RUnlock()
+
+	for _, ch := range d.listeners {
+		select {
+		case ch <- event:
+		default:
+			logger.Warnf("dropped websocket %s event for keys %v: listener not ready", eventType, event.Keys)
+		}
+	}
+}
+
+// listener handles the messages received on c, reconnecting the outbound connections when they are dropped.
+func (d *connPool) listener(c *conn) {
+	for {
+		err := d.read(c, c.current())
+
+		keys := d.keys(c)
+		if c.isClosed() || len(keys) == 0 {
+			c.close()
+			d.removeConn(c)
+
+			return
+		}
+
+		c.disconnected()
+		d.emit(transport.ConnectionClosed, c, 0, err)
+
+		if !c.reconnects() {
+			c.close()
+			d.removeConn(c)
+
+			return
+		}
+
+		socket, attempt, err := d.reconnect(c)
+		if err != nil {
+			d.emit(transport.ConnectionReconnectFailed, c, attempt, err)
+			c.close()
+			d.removeConn(c)
+
+			return
+		}
+
+		c.connected(socket)
+		d.emit(transport.ConnectionOpened, c, attempt, nil)
+	}
+}
+
+// read handles the messages received on socket until it fails.
+func (d *connPool) read(c *conn, socket *websocket.Conn) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	defer d.close(conn, verKeys)
+	defer d.close(socket)
 
-	go keepConnAlive(conn, outbound, pingFrequency)
+	go keepConnAlive(ctx, d, c, socket)
 
 	for {
-		_, message, err := conn.Read(context.Background())
+		_, message, err := socket.Read(context.Background())
 		if err != nil {
 			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
 				logger.Errorf("Error reading request message: %v", err)
 			}
 
-			break
+			return err
 		}
 
-		unpackMsg, err := d.packager.UnpackMessage(message)
-		if err != nil {
-			logger.Errorf("failed to unpack msg: %v", err)
+		d.handleMessage(c, message)
+	}
+}
+
+func (d *connPool) reconnect(c *conn) (*websocket.Conn, int, error) {
+	var (
+		attempt int
+		err     error
+	)
 
-			continue
+	for attempt = 1; c.opts.reconnectAttempts < 0 || attempt <= c.opts.reconnectAttempts; attempt++ {
+		select {
+		case <-time.After(c.opts.backoff(attempt)):
+		case <-c.done:
+			return nil, attempt, errConnClosed
 		}
 
-		trans := &decorator.Transport{}
+		var socket *websocket.Conn
 
-		err = json.Unmarshal(unpackMsg.Message, trans)
-		if err != nil {
-			logger.Errorf("unmarshal transport decorator : %v", err)
+		socket, err = dial(c.endpoint, c.opts)
+		if err == nil {
+			return socket, attempt, nil
 		}
 
-		didKey, _ := fingerprint.CreateDIDKey(unpackMsg.FromKey)
+		logger.Warnf("failed to reconnect to %s (attempt %d): %s", c.endpoint, attempt, err)
+	}
 
-		if trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
-			d.add(didKey, conn)
-		}
+	return nil, attempt - 1, fmt.Errorf("reconnect to %s : %w", c.endpoint, err)
+}
 
-		messageHandler := d.msgHandler
+func (d *connPool) handleMessage(c *conn, message []byte) {
+	var err error
 
-		err = messageHandler(unpackMsg)
-		if err != nil {
-			logger.Errorf("incoming msg processing failed: %v", err)
+	start := time.Now()
+	_, span := tracing.Start(context.Background(), "transport.inbound", tracing.Attr("transport", wsTransport))
+
+	defer func() {
+		span.RecordError(err)
+		span.End()
+		transport.ObserveInbound(wsTransport, start, err)
+	}()
+
+	if !c.outbound() {
+		if err = c.opts.guard.AllowSource(c.remoteAddr); err != nil {
+			logger.Warnf("dropped message from %s: %s", c.remoteAddr, err)
+
+			return
 		}
 	}
+
+	release, err := c.opts.guard.Admit(message)
+	if err != nil {
+		logger.Warnf("dropped message from %s: %s", c.peer(), err)
+
+		return
+	}
+
+	unpackMsg, err := d.packager.UnpackMessage(message)
+
+	release()
+
+	if err != nil {
+		logger.Errorf("failed to unpack msg: %v", err)
+
+		return
+	}
+
+	span.SetThreadID(tracing.ThreadID(unpackMsg.Message))
+
+	trans := &decorator.Transport{}
+
+	// the transport decorator is optional, the message is handled even if it can't be read
+	if e := json.Unmarshal(unpackMsg.Message, trans); e != nil {
+		logger.Errorf("unmarshal transport decorator : %v", e)
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(unpackMsg.FromKey)
+
+	if trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
+		d.add(c, didKey)
+	}
+
+	messageHandler := d.msgHandler
+
+	err = messageHandler(unpackMsg)
+	if err != nil {
+		logger.Errorf("incoming msg processing failed: %v", err)
+	}
 }
 
-func (d *connPool) close(conn *websocket.Conn, verKeys []string) {
-	if err := conn.Close(websocket.StatusNormalClosure,
+func (d *connPool) close(socket *websocket.Conn) {
+	if err := socket.Close(websocket.StatusNormalClosure,
 		"closing the connection"); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
-		logger.Errorf("connection close error")
+		logger.Debugf("connection close error: %v", err)
 	}
+}
 
-	for _, v := range verKeys {
-		d.remove(v)
+func dial(endpoint string, opts *options) (*websocket.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.sendTimeout)
+	defer cancel()
+
+	socket, _, err := websocket.Dial(ctx, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("websocket client : %w", err)
 	}
+
+	return socket, nil
 }
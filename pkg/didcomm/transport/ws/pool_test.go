@@ -7,7 +7,9 @@ package ws
 
 import (
 	"context"
+	"net/http"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -133,3 +135,209 @@ func TestConnectionStore(t *testing.T) {
 		}
 	})
 }
+
+func TestConnectionPoolEvents(t *testing.T) {
+	recKey := []string{"XYZ"}
+
+	t.Run("reconnects a dropped connection", func(t *testing.T) {
+		server := newTestServer(t)
+
+		outbound := NewOutbound(WithReconnectBackoff(10*time.Millisecond, 20*time.Millisecond))
+		require.NoError(t, outbound.Start(&mockProvider{}))
+
+		events := make(chan transport.ConnectionEvent, 10)
+		require.NoError(t, outbound.RegisterConnectionEvent(events))
+
+		des := prepareDestinationWithTransport("ws://"+server.addr, decorator.TransportReturnRouteAll, recKey)
+
+		_, err := outbound.Send([]byte("first"), des)
+		require.NoError(t, err)
+		require.Equal(t, "first", server.nextMessage(t))
+
+		event := nextEvent(t, events)
+		require.Equal(t, transport.ConnectionOpened, event.Type)
+		require.Equal(t, "ws", event.Transport)
+		require.Equal(t, "ws://"+server.addr, event.Endpoint)
+		require.Equal(t, recKey, event.Keys)
+		require.Zero(t, event.Attempt)
+
+		// drop the connection from the server side
+		server.dropConnection(t)
+
+		event = nextEvent(t, events)
+		require.Equal(t, transport.ConnectionClosed, event.Type)
+		require.Error(t, event.Err)
+
+		event = nextEvent(t, events)
+		require.Equal(t, transport.ConnectionOpened, event.Type)
+		require.Equal(t, 1, event.Attempt)
+
+		require.True(t, outbound.AcceptRecipient(recKey))
+
+		_, err = outbound.Send([]byte("second"), des)
+		require.NoError(t, err)
+		require.Equal(t, "second", server.nextMessage(t))
+
+		require.NoError(t, outbound.UnregisterConnectionEvent(events))
+	})
+
+	t.Run("gives up reconnecting", func(t *testing.T) {
+		server := newTestServer(t)
+
+		outbound := NewOutbound(WithReconnectAttempts(2), WithReconnectBackoff(time.Millisecond, time.Millisecond))
+		require.NoError(t, outbound.Start(&mockProvider{}))
+
+		events := make(chan transport.ConnectionEvent, 10)
+		require.NoError(t, outbound.RegisterConnectionEvent(events))
+
+		_, err := outbound.Send([]byte("first"),
+			prepareDestinationWithTransport("ws://"+server.addr, decorator.TransportReturnRouteAll, recKey))
+		require.NoError(t, err)
+		require.Equal(t, transport.ConnectionOpened, nextEvent(t, events).Type)
+
+		server.refuse()
+		server.dropConnection(t)
+
+		require.Equal(t, transport.ConnectionClosed, nextEvent(t, events).Type)
+
+		event := nextEvent(t, events)
+		require.Equal(t, transport.ConnectionReconnectFailed, event.Type)
+		require.Equal(t, 2, event.Attempt)
+		require.Contains(t, event.Err.Error(), "reconnect to ws://"+server.addr)
+
+		require.False(t, outbound.AcceptRecipient(recKey))
+	})
+
+	t.Run("detects dead peers", func(t *testing.T) {
+		server := newTestServer(t)
+		server.mute()
+
+		outbound := NewOutbound(WithReconnectAttempts(0),
+			WithPingInterval(20*time.Millisecond), WithPingTimeout(20*time.Millisecond))
+		require.NoError(t, outbound.Start(&mockProvider{}))
+
+		events := make(chan transport.ConnectionEvent, 10)
+		require.NoError(t, outbound.RegisterConnectionEvent(events))
+
+		_, err := outbound.Send([]byte("first"),
+			prepareDestinationWithTransport("ws://"+server.addr, decorator.TransportReturnRouteAll, recKey))
+		require.NoError(t, err)
+		require.Equal(t, transport.ConnectionOpened, nextEvent(t, events).Type)
+
+		event := nextEvent(t, events)
+		require.Equal(t, transport.ConnectionClosed, event.Type)
+		require.Contains(t, event.Err.Error(), "failed to wait for pong")
+
+		require.False(t, outbound.AcceptRecipient(recKey))
+	})
+
+	t.Run("transport not started", func(t *testing.T) {
+		events := make(chan transport.ConnectionEvent)
+
+		require.ErrorIs(t, NewOutbound().RegisterConnectionEvent(events), errNotStarted)
+		require.ErrorIs(t, NewOutbound().UnregisterConnectionEvent(events), errNotStarted)
+
+		inbound, err := NewInbound(":0", "", "", "")
+		require.NoError(t, err)
+		require.ErrorIs(t, inbound.RegisterConnectionEvent(events), errInboundNotStarted)
+		require.ErrorIs(t, inbound.UnregisterConnectionEvent(events), errInboundNotStarted)
+	})
+
+	t.Run("nil channel", func(t *testing.T) {
+		outbound := NewOutbound()
+		require.NoError(t, outbound.Start(&mockProvider{}))
+		require.Error(t, outbound.RegisterConnectionEvent(nil))
+	})
+}
+
+// testServer is a websocket server whose connections can be dropped.
+type testServer struct {
+	addr     string
+	accepted chan *websocket.Conn
+	messages chan string
+	refused  int32
+	muted    int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		accepted: make(chan *websocket.Conn, 10),
+		messages: make(chan string, 10),
+	}
+
+	s.addr = startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&s.refused) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		c, err := Accept(w, r)
+		require.NoError(t, err)
+
+		s.accepted <- c
+
+		// a muted server doesn't read, and so doesn't answer the pings
+		if atomic.LoadInt32(&s.muted) == 1 {
+			<-r.Context().Done()
+
+			return
+		}
+
+		for {
+			_, message, err := c.Read(context.Background())
+			if err != nil {
+				return
+			}
+
+			s.messages <- string(message)
+		}
+	})
+
+	return s
+}
+
+func (s *testServer) refuse() {
+	atomic.StoreInt32(&s.refused, 1)
+}
+
+func (s *testServer) mute() {
+	atomic.StoreInt32(&s.muted, 1)
+}
+
+func (s *testServer) dropConnection(t *testing.T) {
+	t.Helper()
+
+	select {
+	case c := <-s.accepted:
+		_ = c.Close(websocket.StatusGoingAway, "restarting") // nolint: errcheck
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no connection accepted")
+	}
+}
+
+func (s *testServer) nextMessage(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case m := <-s.messages:
+		return m
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no message received")
+	}
+
+	return ""
+}
+
+func nextEvent(t *testing.T, events chan transport.ConnectionEvent) transport.ConnectionEvent {
+	t.Helper()
+
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no connection event received")
+	}
+
+	return transport.ConnectionEvent{}
+}
@@ -7,9 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"time"
 
 	"nhooyr.io/websocket"
 )
@@ -25,13 +25,13 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 		// check if the connection exists for the key
 		if c := pool.fetch(v); c != nil {
 			// TODO make sure connection is alive (conn.Ping() doesn't work with JS/WASM build)
-			return true
+			return c.current() != nil || c.reconnects()
 		}
 	}
 
 	return false
 }
 
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
+func keepConnAlive(_ context.Context, _ *connPool, _ *conn, _ *websocket.Conn) {
 	// TODO make sure connection is alive (conn.Ping() doesn't work with JS/WASM build)
 }
@@ -29,44 +29,78 @@ func Accept(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 func acceptRecipient(pool *connPool, keys []string) bool {
 	for _, v := range keys {
 		// check if the connection exists for the key
-		if c := pool.fetch(v); c != nil {
-			// verify the connection is alive
-			if err := c.Ping(context.Background()); err != nil {
-				// remove from the pool
-				pool.remove(v)
+		c := pool.fetch(v)
+		if c == nil {
+			continue
+		}
+
+		socket := c.current()
+		if socket == nil {
+			// the sends wait for the connection being reconnected
+			return c.reconnects()
+		}
 
-				logger.Infof("failed to ping to the connection for key=%s err=%v", err)
+		// verify the connection is alive
+		if err := ping(socket, c.opts); err != nil {
+			logger.Infof("failed to ping to the connection for key=%s err=%v", v, err)
 
-				return false
+			if c.reconnects() {
+				return true
 			}
 
-			return true
+			// remove from the pool
+			pool.remove(v)
+
+			return false
 		}
+
+		return true
 	}
 
 	return false
 }
 
-// keepConnAlive sends the pings the server based on time frequency. The web server, load balancer, network routers
-// between the client and server closes the TCP keepalives connection. This function calls websocket ping request
-// directly to the server and keeps the connection active.
-func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
-	if outbound {
-		ticker := time.NewTicker(frequency)
-		done := make(chan struct{})
-
-		for {
-			select {
-			case <-done:
-				return
-			case <-ticker.C:
-				if err := conn.Ping(context.Background()); err != nil {
-					logger.Errorf("websocket ping error : %v", err)
+// keepConnAlive pings the peer of the pooled connection c at the ping interval until ctx is done. The web server,
+// load balancer, network routers between the client and server closes the TCP keepalives connection. This function
+// calls websocket ping request directly to the peer and keeps the connection active. A peer which doesn't answer
+// a ping in time is considered dead: the socket is then closed, which makes the listener reconnect or drop it.
+func keepConnAlive(ctx context.Context, pool *connPool, c *conn, socket *websocket.Conn) {
+	if c.opts.pingInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.opts.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// only the pooled connections are kept alive
+			if len(pool.keys(c)) == 0 {
+				continue
+			}
+
+			if err := ping(socket, c.opts); err != nil {
+				logger.Errorf("websocket ping error : %v", err)
 
-					ticker.Stop()
-					done <- struct{}{}
-				}
+				return
 			}
 		}
 	}
 }
+
+// ping pings the peer of socket, which is closed if the pong doesn't arrive before the ping timeout.
+func ping(socket *websocket.Conn, opts *options) error {
+	ctx := context.Background()
+
+	if opts.pingTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.pingTimeout)
+		defer cancel()
+	}
+
+	return socket.Ping(ctx)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DereferencingContext is the JSON-LD context of a DID URL dereferencing result.
+const DereferencingContext = "https://w3id.org/did-resolution/v1"
+
+// DIDURL holds a DID URL parsed according to https://w3c.github.io/did-core/#did-url-syntax.
+type DIDURL struct { // nolint: golint
+	DID
+	Path     string
+	Queries  map[string][]string
+	Fragment string
+}
+
+// ParseDIDURL parses a DID URL string into a DIDURL.
+func ParseDIDURL(didURL string) (*DIDURL, error) {
+	rest := didURL
+	result := &DIDURL{}
+
+	if i := strings.Index(rest, "#"); i >= 0 {
+		result.Fragment = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "?"); i >= 0 {
+		queries, err := url.ParseQuery(rest[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid query in DID URL %s: %w", didURL, err)
+		}
+
+		result.Queries = queries
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "/"); i >= 0 {
+		result.Path = rest[i:]
+		rest = rest[:i]
+	}
+
+	did, err := Parse(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	result.DID = *did
+
+	return result, nil
+}
+
+// Query returns the first value of the given DID URL query parameter, or an empty string if it is not set.
+func (d *DIDURL) Query(name string) string {
+	if v := d.Queries[name]; len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+// String returns a string representation of this DID URL.
+func (d *DIDURL) String() string {
+	s := d.DID.String() + d.Path
+
+	if len(d.Queries) > 0 {
+		s += "?" + url.Values(d.Queries).Encode()
+	}
+
+	if d.Fragment != "" {
+		s += "#" + d.Fragment
+	}
+
+	return s
+}
+
+// DereferencingResult is the result of dereferencing a DID URL
+// (https://w3c-ccg.github.io/did-resolution/#dereferencing).
+type DereferencingResult struct {
+	// Content is the dereferenced resource: a *Doc, a *VerificationMethod, a *Service
+	// or, for service endpoint selection, the resulting URL as a string.
+	Content               interface{}
+	ContentMetadata       *DocumentMetadata
+	DereferencingMetadata *ResolutionMetadata
+}
+
+type rawDereferencingResult struct {
+	Context               interface{}         `json:"@context"`
+	ContentStream         json.RawMessage     `json:"contentStream,omitempty"`
+	ContentMetadata       *DocumentMetadata   `json:"contentMetadata,omitempty"`
+	DereferencingMetadata *ResolutionMetadata `json:"dereferencingMetadata,omitempty"`
+}
+
+// JSONBytes converts the dereferencing result to json bytes.
+func (r *DereferencingResult) JSONBytes() ([]byte, error) {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch c := r.Content.(type) {
+	case nil:
+	case *Doc:
+		content, err = c.JSONBytes()
+	case *VerificationMethod:
+		var rawVM map[string]interface{}
+
+		// dereferenced verification methods are standalone resources, so always serialize absolute ids.
+		vm := *c
+		vm.relativeURL = false
+
+		rawVM, err = populateRawVerificationMethod(ContextV1, "", "", &vm)
+		if err == nil {
+			content, err = json.Marshal(rawVM)
+		}
+	default:
+		content, err = json.Marshal(c)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("JSON marshalling of dereferenced content failed: %w", err)
+	}
+
+	return json.Marshal(&rawDereferencingResult{
+		Context:               DereferencingContext,
+		ContentStream:         content,
+		ContentMetadata:       r.ContentMetadata,
+		DereferencingMetadata: r.DereferencingMetadata,
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDIDURL(t *testing.T) {
+	t.Run("parse DID URL", func(t *testing.T) {
+		didURL, err := ParseDIDURL("did:example:123456/path?service=agent&relativeRef=%2Fcred&versionId=2#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123456", didURL.DID.String())
+		require.Equal(t, "/path", didURL.Path)
+		require.Equal(t, "agent", didURL.Query("service"))
+		require.Equal(t, "/cred", didURL.Query("relativeRef"))
+		require.Equal(t, "2", didURL.Query("versionId"))
+		require.Empty(t, didURL.Query("versionTime"))
+		require.Equal(t, "key-1", didURL.Fragment)
+
+		reparsed, err := ParseDIDURL(didURL.String())
+		require.NoError(t, err)
+		require.Equal(t, didURL, reparsed)
+	})
+
+	t.Run("parse bare DID", func(t *testing.T) {
+		didURL, err := ParseDIDURL("did:example:123456")
+		require.NoError(t, err)
+		require.Equal(t, "example", didURL.Method)
+		require.Empty(t, didURL.Path)
+		require.Empty(t, didURL.Queries)
+		require.Empty(t, didURL.Fragment)
+		require.Equal(t, "did:example:123456", didURL.String())
+	})
+
+	t.Run("invalid DID", func(t *testing.T) {
+		_, err := ParseDIDURL("example:123456#key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did")
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := ParseDIDURL("did:example:123456?service=%zz")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid query")
+	})
+}
+
+func TestDereferencingResult_JSONBytes(t *testing.T) {
+	doc, err := ParseDocument([]byte(validDoc))
+	require.NoError(t, err)
+
+	t.Run("DID document", func(t *testing.T) {
+		result := &DereferencingResult{
+			Content:               doc,
+			ContentMetadata:       &DocumentMetadata{VersionID: "1"},
+			DereferencingMetadata: &ResolutionMetadata{ContentType: "application/did+ld+json"},
+		}
+
+		resultBytes, err := result.JSONBytes()
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(resultBytes, &raw))
+		require.Equal(t, DereferencingContext, raw["@context"])
+		require.Equal(t, doc.ID, raw["contentStream"].(map[string]interface{})["id"])
+		require.Equal(t, "1", raw["contentMetadata"].(map[string]interface{})["versionId"])
+	})
+
+	t.Run("verification method", func(t *testing.T) {
+		result := &DereferencingResult{Content: &doc.VerificationMethod[0]}
+
+		resultBytes, err := result.JSONBytes()
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(resultBytes, &raw))
+		require.Equal(t, doc.VerificationMethod[0].ID, raw["contentStream"].(map[string]interface{})["id"])
+	})
+
+	t.Run("service endpoint", func(t *testing.T) {
+		result := &DereferencingResult{Content: "https://example.com/files"}
+
+		resultBytes, err := result.JSONBytes()
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(resultBytes, &raw))
+		require.Equal(t, "https://example.com/files", raw["contentStream"])
+	})
+}
@@ -54,6 +54,8 @@ const (
 	jsonldPublicKeyHex    = "publicKeyHex"
 	jsonldPublicKeyPem    = "publicKeyPem"
 	jsonldPublicKeyjwk    = "publicKeyJwk"
+
+	jsonldBlockchainAccountID = "blockchainAccountId"
 )
 
 var (
@@ -105,9 +107,34 @@ func Parse(did string) (*DID, error) {
 
 // DocResolution did resolution.
 type DocResolution struct {
-	Context          []string
-	DIDDocument      *Doc
-	DocumentMetadata *DocumentMetadata
+	Context            []string
+	DIDDocument        *Doc
+	DocumentMetadata   *DocumentMetadata
+	ResolutionMetadata *ResolutionMetadata
+}
+
+// Resolution metadata error codes (https://w3c-ccg.github.io/did-resolution/#errors).
+const (
+	// ResolutionErrorInvalidDID is returned when the DID does not conform to the DID syntax.
+	ResolutionErrorInvalidDID = "invalidDid"
+	// ResolutionErrorInvalidDIDURL is returned when the DID URL does not conform to the DID URL syntax.
+	ResolutionErrorInvalidDIDURL = "invalidDidUrl"
+	// ResolutionErrorNotFound is returned when the DID or the dereferenced resource was not found.
+	ResolutionErrorNotFound = "notFound"
+	// ResolutionErrorMethodNotSupported is returned when the DID method is not supported by the resolver.
+	ResolutionErrorMethodNotSupported = "methodNotSupported"
+	// ResolutionErrorDeactivated is returned when the DID has been deactivated.
+	ResolutionErrorDeactivated = "deactivated"
+)
+
+// ResolutionMetadata did resolution metadata.
+type ResolutionMetadata struct {
+	// ContentType is the media type of the returned representation.
+	ContentType string `json:"contentType,omitempty"`
+	// Error is the resolution error code.
+	Error string `json:"error,omitempty"`
+	// ErrorMessage is a human readable description of the error.
+	ErrorMessage string `json:"errorMessage,omitempty"`
 }
 
 // MethodMetadata method metadata.
@@ -130,12 +157,23 @@ type DocumentMetadata struct {
 	EquivalentID []string `json:"equivalentId,omitempty"`
 	// Method is used for method metadata within did document metadata.
 	Method *MethodMetadata `json:"method,omitempty"`
+	// Created is the time the DID document was created.
+	Created *time.Time `json:"created,omitempty"`
+	// Updated is the time the resolved DID document version was last updated.
+	Updated *time.Time `json:"updated,omitempty"`
+	// VersionID is the version of the resolved DID document.
+	VersionID string `json:"versionId,omitempty"`
+	// NextUpdate is the time of the next DID document version, if any.
+	NextUpdate *time.Time `json:"nextUpdate,omitempty"`
+	// NextVersionID is the version ID of the next DID document version, if any.
+	NextVersionID string `json:"nextVersionId,omitempty"`
 }
 
 type rawDocResolution struct {
-	Context          interface{}     `json:"@context"`
-	DIDDocument      json.RawMessage `json:"didDocument,omitempty"`
-	DocumentMetadata json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	Context            interface{}     `json:"@context"`
+	DIDDocument        json.RawMessage `json:"didDocument,omitempty"`
+	DocumentMetadata   json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	ResolutionMetadata json.RawMessage `json:"didResolutionMetadata,omitempty"`
 }
 
 // ParseDocumentResolution parse document resolution.
@@ -163,9 +201,44 @@ func ParseDocumentResolution(data []byte) (*DocResolution, error) {
 		}
 	}
 
+	var resolutionMeta *ResolutionMetadata
+
+	if len(raw.ResolutionMetadata) != 0 {
+		resolutionMeta = &ResolutionMetadata{}
+
+		if err := json.Unmarshal(raw.ResolutionMetadata, resolutionMeta); err != nil {
+			return nil, err
+		}
+	}
+
 	context, _ := parseContext(raw.Context)
 
-	return &DocResolution{Context: context, DIDDocument: doc, DocumentMetadata: docMeta}, nil
+	return &DocResolution{
+		Context:            context,
+		DIDDocument:        doc,
+		DocumentMetadata:   docMeta,
+		ResolutionMetadata: resolutionMeta,
+	}, nil
+}
+
+// ParseResolutionMetadata parses the didResolutionMetadata of a DID resolution result. It is typically used
+// when the resolution result does not contain a DID document, e.g. for resolution errors.
+func ParseResolutionMetadata(data []byte) (*ResolutionMetadata, error) {
+	raw := &rawDocResolution{}
+
+	if err := json.Unmarshal(data, raw); err != nil {
+		return nil, err
+	}
+
+	resolutionMeta := &ResolutionMetadata{}
+
+	if len(raw.ResolutionMetadata) != 0 {
+		if err := json.Unmarshal(raw.ResolutionMetadata, resolutionMeta); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolutionMeta, nil
 }
 
 // Doc DID Document definition.
@@ -193,6 +266,8 @@ type processingMeta struct {
 // VerificationMethod DID doc verification method.
 // The value of the verification method is defined either as raw public key bytes (Value field) or as JSON Web Key.
 // In the first case the Type field can hold additional information to understand the nature of the raw public key.
+// Verification methods of blockchain accounts may instead only define the BlockchainAccountID (a CAIP-10 account id),
+// e.g. when the public key has to be recovered from the signature.
 type VerificationMethod struct {
 	ID         string
 	Type       string
@@ -200,6 +275,8 @@ type VerificationMethod struct {
 
 	Value []byte
 
+	BlockchainAccountID string
+
 	jsonWebKey  *jose.JWK
 	relativeURL bool
 }
@@ -737,6 +814,8 @@ func populateVerificationMethod(context, didID, baseURI string,
 }
 
 func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
+	vm.BlockchainAccountID = stringEntry(rawPK[jsonldBlockchainAccountID])
+
 	if stringEntry(rawPK[jsonldPublicKeyBase58]) != "" {
 		vm.Value = base58.Decode(stringEntry(rawPK[jsonldPublicKeyBase58]))
 		return nil
@@ -768,6 +847,10 @@ func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
 		return decodeVMJwk(jwkMap, vm)
 	}
 
+	if vm.BlockchainAccountID != "" {
+		return nil
+	}
+
 	return errors.New("public key encoding not supported")
 }
 
@@ -940,6 +1023,13 @@ func (docResolution *DocResolution) JSONBytes() ([]byte, error) {
 		DocumentMetadata: documentMetadataBytes,
 	}
 
+	if docResolution.ResolutionMetadata != nil {
+		raw.ResolutionMetadata, err = json.Marshal(docResolution.ResolutionMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	byteDoc, err := json.Marshal(raw)
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling of document failed: %w", err)
@@ -1229,6 +1319,10 @@ func populateRawVerificationMethod(context, didID, baseURI string,
 		rawVM[jsonldPublicKeyBase58] = base58.Encode(vm.Value)
 	}
 
+	if vm.BlockchainAccountID != "" {
+		rawVM[jsonldBlockchainAccountID] = vm.BlockchainAccountID
+	}
+
 	return rawVM, nil
 }
 
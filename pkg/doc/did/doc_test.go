@@ -192,6 +192,38 @@ func TestDocResolution(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), ErrDIDDocumentNotExist.Error())
 	})
+
+	t.Run("test resolution metadata", func(t *testing.T) {
+		d, err := ParseDocumentResolution([]byte(validDocResolution))
+		require.NoError(t, err)
+		require.Nil(t, d.ResolutionMetadata)
+
+		d.ResolutionMetadata = &ResolutionMetadata{ContentType: "application/did+ld+json"}
+		d.DocumentMetadata.VersionID = "2"
+
+		bytes, err := d.JSONBytes()
+		require.NoError(t, err)
+
+		d, err = ParseDocumentResolution(bytes)
+		require.NoError(t, err)
+		require.Equal(t, "application/did+ld+json", d.ResolutionMetadata.ContentType)
+		require.Equal(t, "2", d.DocumentMetadata.VersionID)
+	})
+
+	t.Run("test resolution error metadata", func(t *testing.T) {
+		resolutionMeta, err := ParseResolutionMetadata([]byte(`{
+			"didResolutionMetadata": {"error": "notFound", "errorMessage": "no such DID"}
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, ResolutionErrorNotFound, resolutionMeta.Error)
+		require.Equal(t, "no such DID", resolutionMeta.ErrorMessage)
+
+		_, err = ParseResolutionMetadata([]byte(`{"didResolutionMetadata": "invalid"}`))
+		require.Error(t, err)
+
+		_, err = ParseResolutionMetadata([]byte(`[`))
+		require.Error(t, err)
+	})
 }
 
 func TestValid(t *testing.T) {
@@ -1098,6 +1130,30 @@ func TestJSONWebKey(t *testing.T) {
 	require.Equal(t, didDocBytes, parsedDidDocBytes)
 }
 
+func TestBlockchainAccountID(t *testing.T) {
+	const accountID = "eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a"
+
+	didDoc := &Doc{
+		Context: []string{ContextV1},
+		ID:      did,
+		VerificationMethod: []VerificationMethod{{
+			ID:                  did + "#blockchainAccountId",
+			Type:                "EcdsaSecp256k1RecoveryMethod2020",
+			Controller:          did,
+			BlockchainAccountID: accountID,
+		}},
+	}
+
+	didDocBytes, err := didDoc.JSONBytes()
+	require.NoError(t, err)
+	require.Contains(t, string(didDocBytes), `"blockchainAccountId":"`+accountID+`"`)
+
+	parsedDidDoc, err := ParseDocument(didDocBytes)
+	require.NoError(t, err)
+	require.Equal(t, accountID, parsedDidDoc.VerificationMethod[0].BlockchainAccountID)
+	require.Empty(t, parsedDidDoc.VerificationMethod[0].Value)
+}
+
 func TestVerifyProof(t *testing.T) {
 	docs := []string{validDoc, validDocV011}
 	for _, d := range docs {
@@ -105,7 +105,7 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*JWK, error) { // noli
 	switch keyType {
 	case kms.ED25519Type:
 		return JWKFromKey(ed25519.PublicKey(bytes))
-	case kms.BLS12381G2Type:
+	case kms.BLS12381G2Type, kms.BLS12381G2SHA256Type:
 		bbsKey, err := bbs12381g2pub.UnmarshalPublicKey(bytes)
 		if err != nil {
 			return nil, err
@@ -145,6 +145,13 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*JWK, error) { // noli
 		}
 
 		return JWKFromKey(ecdsaKey)
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(bytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(pubKey.ToECDSA())
 	case kms.X25519ECDHKWType:
 		return JWKFromX25519Key(bytes)
 	default:
@@ -325,6 +325,16 @@ func TestDecodePublicKey(t *testing.T) {
 						base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()))
 					require.Equal(t, "AZzRvW8NBytGNbF3dyNOMHB0DHCOzGp8oYBv_ZCyJbQUUnq-TYX7j8-PlKe9Ce5acxZzrcUKVtJ4I8JgI5x9oXIW",
 						base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()))
+				case "get public key bytes EC SECP256K1 JWK":
+					newJWK, err := PubKeyBytesToJWK(pkBytes, kms.ECDSASecp256k1TypeIEEEP1363)
+					require.NoError(t, err)
+					require.NotNil(t, newJWK)
+					require.Equal(t, secp256k1Crv, newJWK.Crv)
+					require.Equal(t, ecKty, newJWK.Kty)
+					ecKey, ok := newJWK.Key.(*ecdsa.PublicKey)
+					require.True(t, ok)
+					require.Equal(t, "YRrvJocKf39GpdTnd-zBFE0msGDqawR-Cmtc6yKoFsM",
+						base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()))
 				default:
 					jwkKey, err := JWKFromKey(jwk.Key)
 					require.NoError(t, err)
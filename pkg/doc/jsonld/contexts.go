@@ -13,6 +13,8 @@ import _ "embed"
 var (
 	//go:embed contexts/third_party/w3.org/credentials_v1.jsonld
 	w3orgCredentials []byte
+	//go:embed contexts/third_party/w3.org/credentials_v2.jsonld
+	w3orgCredentialsV2 []byte
 	//go:embed contexts/third_party/w3.org/did_v1.jsonld
 	w3orgDID []byte
 	//go:embed contexts/third_party/w3c-ccg.github.io/did_v0.11.jsonld
@@ -39,6 +41,8 @@ var (
 	x255192019 []byte
 	//go:embed contexts/third_party/ns.did.ai/secp256k1-2019_v1.jsonld
 	secp256k12019 []byte
+	//go:embed contexts/third_party/w3c.github.io/data-integrity_v1.jsonld
+	dataIntegrityV1 []byte
 )
 
 var embedContexts = []ContextDocument{ //nolint:gochecknoglobals
@@ -47,6 +51,11 @@ var embedContexts = []ContextDocument{ //nolint:gochecknoglobals
 		DocumentURL: "https://www.w3.org/2018/credentials/v1",
 		Content:     w3orgCredentials,
 	},
+	{
+		URL:         "https://www.w3.org/ns/credentials/v2",
+		DocumentURL: "https://www.w3.org/ns/credentials/v2",
+		Content:     w3orgCredentialsV2,
+	},
 	{
 		URL:         "https://www.w3.org/ns/did/v1",
 		DocumentURL: "https://www.w3.org/ns/did/v1",
@@ -122,4 +131,9 @@ var embedContexts = []ContextDocument{ //nolint:gochecknoglobals
 		DocumentURL: "https://ns.did.ai/suites/secp256k1-2019/v1/",
 		Content:     secp256k12019,
 	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v1",
+		DocumentURL: "https://w3c.github.io/vc-data-integrity/contexts/data-integrity/v1",
+		Content:     dataIntegrityV1,
+	},
 }
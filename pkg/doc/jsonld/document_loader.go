@@ -11,22 +11,58 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-// ContextsDBName is a name of DB for storing JSON-LD contexts.
-const ContextsDBName = "jsonldContexts"
+const (
+	// ContextsDBName is a name of DB for storing JSON-LD contexts.
+	ContextsDBName = "jsonldContexts"
+	// ContextRecordTag is the tag of the JSON-LD contexts in the underlying storage.
+	ContextRecordTag = "context"
+)
+
+var logger = log.New("aries-framework/doc/jsonld")
 
-// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
-var ErrContextNotFound = errors.New("context document not found")
+var (
+	// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
+	ErrContextNotFound = errors.New("context document not found")
+	// ErrRemoteLoadingDisabled is returned when refreshing a context document without a remote DocumentLoader.
+	ErrRemoteLoadingDisabled = errors.New("loading context documents from remote URLs is disabled")
+)
 
 // DocumentLoader is an implementation of ld.DocumentLoader backed by storage.
 type DocumentLoader struct {
 	store                storage.Store
 	remoteDocumentLoader ld.DocumentLoader
+	remoteContextTTL     time.Duration
+}
+
+// contextRecord is a JSON-LD context document in the underlying storage.
+type contextRecord struct {
+	ld.RemoteDocument
+	// FetchedAt is when the context document was loaded from its remote URL, if it was.
+	FetchedAt *time.Time `json:"fetchedAt,omitempty"`
+	// ExpiresAt is when the context document loaded from its remote URL is to be loaded again.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// StoredContext describes a JSON-LD context document of the underlying storage.
+type StoredContext struct {
+	// URL is the context URL that shows up in the documents.
+	URL string `json:"url"`
+	// DocumentURL is the final URL of the loaded context document.
+	DocumentURL string `json:"documentURL,omitempty"`
+	// FetchedAt is when the context document was loaded from its remote URL, it is absent for the preloaded and
+	// added context documents.
+	FetchedAt *time.Time `json:"fetchedAt,omitempty"`
+	// ExpiresAt is when the context document loaded from its remote URL is to be loaded again.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // NewDocumentLoader returns a new DocumentLoader instance.
@@ -35,7 +71,9 @@ type DocumentLoader struct {
 // Additional contexts can be set using WithExtraContexts() option.
 //
 // By default, missing contexts are not fetched from the remote URL. Use WithRemoteDocumentLoader() option
-// to specify a custom loader that can resolve context documents from the network.
+// to specify a custom loader that can resolve context documents from the network, like RemoteDocumentLoader.
+// The fetched context documents are saved into the underlying storage, for the duration set with
+// WithRemoteContextTTL().
 func NewDocumentLoader(storageProvider storage.Provider, opts ...DocumentLoaderOpts) (*DocumentLoader, error) {
 	options := &documentLoaderOpts{}
 
@@ -43,7 +81,7 @@ func NewDocumentLoader(storageProvider storage.Provider, opts ...DocumentLoaderO
 		opts[i](options)
 	}
 
-	store, err := storageProvider.OpenStore(ContextsDBName)
+	store, err := OpenStore(storageProvider)
 	if err != nil {
 		return nil, fmt.Errorf("new document loader: %w", err)
 	}
@@ -51,17 +89,35 @@ func NewDocumentLoader(storageProvider storage.Provider, opts ...DocumentLoaderO
 	contexts := append(embedContexts, options.extraContexts...)
 
 	// preload context documents into the underlying storage
-	if err = save(store, contexts); err != nil {
+	if err = SaveContexts(store, contexts); err != nil {
 		return nil, fmt.Errorf("save context documents: %w", err)
 	}
 
 	return &DocumentLoader{
 		store:                store,
 		remoteDocumentLoader: options.remoteDocumentLoader,
+		remoteContextTTL:     options.remoteContextTTL,
 	}, nil
 }
 
-func save(store storage.Store, docs []ContextDocument) error {
+// OpenStore opens the underlying storage of the DocumentLoader in storageProvider, with its contexts being queryable.
+func OpenStore(storageProvider storage.Provider) (storage.Store, error) {
+	store, err := storageProvider.OpenStore(ContextsDBName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	err = storageProvider.SetStoreConfig(ContextsDBName,
+		storage.StoreConfiguration{TagNames: []string{ContextRecordTag, schemaRecordTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set store config: %w", err)
+	}
+
+	return store, nil
+}
+
+// SaveContexts saves JSON-LD context documents into store, the underlying storage of the DocumentLoader.
+func SaveContexts(store storage.Store, docs []ContextDocument) error {
 	var ops []storage.Operation
 
 	for _, doc := range docs {
@@ -80,7 +136,7 @@ func save(store storage.Store, docs []ContextDocument) error {
 			return fmt.Errorf("marshal remote document: %w", err)
 		}
 
-		ops = append(ops, storage.Operation{Key: doc.URL, Value: b})
+		ops = append(ops, storage.Operation{Key: doc.URL, Value: b, Tags: []storage.Tag{{Name: ContextRecordTag}}})
 	}
 
 	// TODO: Support new/updated contexts on fresh storage and on the one with existing data (migrations).
@@ -93,7 +149,14 @@ func save(store storage.Store, docs []ContextDocument) error {
 
 // LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from remote URL.
 // If document is not found in the storage and remote DocumentLoader is not specified, ErrContextNotFound is returned.
+// The context documents loaded from their remote URL are loaded again once expired, the expired document being
+// returned if loading fails.
 func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	// the cached credential schemas sharing the underlying storage are not context documents.
+	if strings.HasPrefix(u, schemaKeyPrefix) {
+		return nil, ErrContextNotFound
+	}
+
 	b, err := l.store.Get(u)
 	if err != nil {
 		if !errors.Is(err, storage.ErrDataNotFound) {
@@ -107,13 +170,32 @@ func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
 		return l.loadDocumentFromURL(u)
 	}
 
-	var rd ld.RemoteDocument
+	var rec contextRecord
 
-	if err := json.Unmarshal(b, &rd); err != nil {
+	if err := json.Unmarshal(b, &rec); err != nil {
 		return nil, fmt.Errorf("unmarshal context document: %w", err)
 	}
 
-	return &rd, nil
+	if rec.ExpiresAt != nil && time.Now().After(*rec.ExpiresAt) && l.remoteDocumentLoader != nil {
+		rd, err := l.loadDocumentFromURL(u)
+		if err == nil {
+			return rd, nil
+		}
+
+		logger.Warnf("using expired context document %s: %s", u, err)
+	}
+
+	return &rec.RemoteDocument, nil
+}
+
+// RefreshContext loads the JSON-LD context document of u from its remote URL again and saves it into the underlying
+// storage. The stored context document is kept if loading fails.
+func (l *DocumentLoader) RefreshContext(u string) (*ld.RemoteDocument, error) {
+	if l.remoteDocumentLoader == nil {
+		return nil, ErrRemoteLoadingDisabled
+	}
+
+	return l.loadDocumentFromURL(u)
 }
 
 func (l *DocumentLoader) loadDocumentFromURL(u string) (*ld.RemoteDocument, error) {
@@ -122,20 +204,99 @@ func (l *DocumentLoader) loadDocumentFromURL(u string) (*ld.RemoteDocument, erro
 		return nil, fmt.Errorf("load remote context document: %w", err)
 	}
 
-	b, err := json.Marshal(rd)
+	if err = l.saveRemoteDocument(u, rd, ContextRecordTag); err != nil {
+		return nil, err
+	}
+
+	return rd, nil
+}
+
+// saveRemoteDocument saves the document loaded from the remote URL u under key into the underlying storage, with
+// the tag of its kind, until it expires.
+func (l *DocumentLoader) saveRemoteDocument(key string, rd *ld.RemoteDocument, tag string) error {
+	now := time.Now().UTC()
+
+	rec := contextRecord{
+		RemoteDocument: *rd,
+		FetchedAt:      &now,
+	}
+
+	if l.remoteContextTTL > 0 {
+		expiresAt := now.Add(l.remoteContextTTL)
+		rec.ExpiresAt = &expiresAt
+	}
+
+	b, err := json.Marshal(rec)
 	if err != nil {
-		return nil, fmt.Errorf("marshal remote document: %w", err)
+		return fmt.Errorf("marshal remote document: %w", err)
 	}
 
-	if err := l.store.Put(u, b); err != nil {
-		return nil, fmt.Errorf("save remote document: %w", err)
+	if err := l.store.Put(key, b, storage.Tag{Name: tag}); err != nil {
+		return fmt.Errorf("save remote document: %w", err)
 	}
 
-	return rd, nil
+	return nil
+}
+
+// ListContexts lists the JSON-LD context documents of store, the underlying storage of the DocumentLoader.
+// The preloaded context documents saved untagged by previous versions are tagged when the DocumentLoader opens the
+// store, saving them again. The store cannot list the other untagged records: the context documents loaded from
+// their remote URL by previous versions are listed once refreshed with RefreshContext.
+func ListContexts(store storage.Store) ([]StoredContext, error) {
+	itr, err := store.Query(ContextRecordTag)
+	if err != nil {
+		return nil, fmt.Errorf("query contexts: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close contexts iterator: %s", errClose)
+		}
+	}()
+
+	var contexts []StoredContext
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("next context: %w", err)
+	}
+
+	for more {
+		key, err := itr.Key()
+		if err != nil {
+			return nil, fmt.Errorf("get context key: %w", err)
+		}
+
+		value, err := itr.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get context value: %w", err)
+		}
+
+		var rec contextRecord
+
+		if err = json.Unmarshal(value, &rec); err != nil {
+			return nil, fmt.Errorf("unmarshal context document: %w", err)
+		}
+
+		contexts = append(contexts, StoredContext{
+			URL:         key,
+			DocumentURL: rec.DocumentURL,
+			FetchedAt:   rec.FetchedAt,
+			ExpiresAt:   rec.ExpiresAt,
+		})
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("next context: %w", err)
+		}
+	}
+
+	return contexts, nil
 }
 
 type documentLoaderOpts struct {
 	remoteDocumentLoader ld.DocumentLoader
+	remoteContextTTL     time.Duration
 	extraContexts        []ContextDocument
 }
 
@@ -163,3 +324,11 @@ func WithRemoteDocumentLoader(loader ld.DocumentLoader) DocumentLoaderOpts {
 		opts.remoteDocumentLoader = loader
 	}
 }
+
+// WithRemoteContextTTL sets how long the context documents fetched from their remote URL are kept before being
+// fetched again. By default, they are kept until refreshed with DocumentLoader.RefreshContext().
+func WithRemoteContextTTL(ttl time.Duration) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.remoteContextTTL = ttl
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -28,7 +30,18 @@ func TestNewDocumentLoader(t *testing.T) {
 
 		require.NotNil(t, loader)
 		require.NoError(t, err)
-		require.Equal(t, 16, len(storageProvider.Store.Store))
+		require.Equal(t, 18, len(storageProvider.Store.Store))
+	})
+
+	t.Run("Configure context DB store", func(t *testing.T) {
+		storageProvider := mem.NewProvider()
+
+		_, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		config, err := storageProvider.GetStoreConfig(jsonld.ContextsDBName)
+		require.NoError(t, err)
+		require.Contains(t, config.TagNames, jsonld.ContextRecordTag)
 	})
 
 	t.Run("Fail to open context DB store", func(t *testing.T) {
@@ -173,6 +186,186 @@ func TestLoadDocument(t *testing.T) {
 	})
 }
 
+func TestRemoteContextTTL(t *testing.T) {
+	t.Run("Fetch expired remote context document again", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader), jsonld.WithRemoteContextTTL(time.Nanosecond))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond)
+
+		rd, err := loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+		require.Equal(t, 2, remoteLoader.loads)
+	})
+
+	t.Run("Use expired remote context document if loading fails", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader), jsonld.WithRemoteContextTTL(time.Nanosecond))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond)
+
+		remoteLoader.ErrLoadDocument = errors.New("load document error")
+
+		rd, err := loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+		require.Equal(t, 2, remoteLoader.loads)
+	})
+
+	t.Run("Keep remote context document until expired", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader), jsonld.WithRemoteContextTTL(time.Hour))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err = loader.LoadDocument("https://example.com/context.jsonld")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, remoteLoader.loads)
+	})
+}
+
+func TestRefreshContext(t *testing.T) {
+	t.Run("Refresh remote context document", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+
+		rd, err := loader.RefreshContext("https://example.com/context.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+		require.Equal(t, 2, remoteLoader.loads)
+	})
+
+	t.Run("Keep stored context document if loading fails", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+
+		remoteLoader.ErrLoadDocument = errors.New("load document error")
+
+		_, err = loader.RefreshContext("https://example.com/context.jsonld")
+		require.Contains(t, err.Error(), "load document error")
+
+		rd, err := loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+	})
+
+	t.Run("Remote loading disabled", func(t *testing.T) {
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = loader.RefreshContext("https://example.com/context.jsonld")
+		require.ErrorIs(t, err, jsonld.ErrRemoteLoadingDisabled)
+	})
+}
+
+func TestListContexts(t *testing.T) {
+	t.Run("List preloaded and remote context documents", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider,
+			jsonld.WithRemoteDocumentLoader(&mockDocumentLoader{}), jsonld.WithRemoteContextTTL(time.Hour))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://example.com/context.jsonld")
+		require.NoError(t, err)
+
+		contexts, err := jsonld.ListContexts(storageProvider.Store)
+		require.NoError(t, err)
+		require.Len(t, contexts, 19)
+
+		var remote []jsonld.StoredContext
+
+		for _, c := range contexts {
+			if c.FetchedAt != nil {
+				remote = append(remote, c)
+			}
+		}
+
+		require.Len(t, remote, 1)
+		require.Equal(t, "https://example.com/context.jsonld", remote[0].URL)
+		require.Equal(t, "https://example.com/context.jsonld", remote[0].DocumentURL)
+		require.WithinDuration(t, remote[0].FetchedAt.Add(time.Hour), *remote[0].ExpiresAt, time.Second)
+	})
+
+	t.Run("List context documents saved untagged by previous versions", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		// the preloaded and remote context documents were saved untagged
+		require.NoError(t, storageProvider.Store.Put("https://www.w3.org/2018/credentials/v1",
+			[]byte(`{"documentUrl":"https://www.w3.org/2018/credentials/v1","document":{"@context":{}}}`)))
+		require.NoError(t, storageProvider.Store.Put("https://example.com/untagged.jsonld",
+			[]byte(`{"documentUrl":"https://example.com/untagged.jsonld","document":{"@context":{}}}`)))
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider,
+			jsonld.WithRemoteDocumentLoader(&mockDocumentLoader{}))
+		require.NoError(t, err)
+
+		contexts, err := jsonld.ListContexts(storageProvider.Store)
+		require.NoError(t, err)
+		require.Len(t, contexts, 18)
+
+		rd, err := loader.LoadDocument("https://example.com/untagged.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd.Document)
+
+		_, err = loader.RefreshContext("https://example.com/untagged.jsonld")
+		require.NoError(t, err)
+
+		contexts, err = jsonld.ListContexts(storageProvider.Store)
+		require.NoError(t, err)
+		require.Len(t, contexts, 19)
+	})
+
+	t.Run("Fail to query context documents", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+		storageProvider.Store.ErrQuery = errors.New("query error")
+
+		_, err := jsonld.ListContexts(storageProvider.Store)
+		require.EqualError(t, err, "query contexts: query error")
+	})
+
+	t.Run("Fail to iterate over context documents", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		_, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		storageProvider.Store.ErrNext = errors.New("next error")
+
+		_, err = jsonld.ListContexts(storageProvider.Store)
+		require.EqualError(t, err, "next context: next error")
+	})
+}
+
 const sampleJSONLDContext = `
 {
   "@context": {
@@ -186,9 +379,12 @@ const sampleJSONLDContext = `
 
 type mockDocumentLoader struct {
 	ErrLoadDocument error
+	loads           int
 }
 
 func (m *mockDocumentLoader) LoadDocument(string) (*ld.RemoteDocument, error) {
+	m.loads++
+
 	if m.ErrLoadDocument != nil {
 		return nil, m.ErrLoadDocument
 	}
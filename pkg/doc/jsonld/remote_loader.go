@@ -0,0 +1,190 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+const (
+	maxRedirects = 10
+	// defaultMaxContextSize is the default maximum size in bytes of the fetched context documents.
+	defaultMaxContextSize = 1 << 20
+)
+
+var (
+	// ErrDomainNotAllowed is returned when loading a context document from a domain which is not allowed.
+	ErrDomainNotAllowed = errors.New("context domain not allowed")
+	// ErrContextHashMismatch is returned when the SHA-256 hash of a context document differs from its pinned hash.
+	ErrContextHashMismatch = errors.New("context hash mismatch")
+	// ErrContextTooLarge is returned when a context document exceeds the maximum size.
+	ErrContextTooLarge = errors.New("context document too large")
+)
+
+// RemoteDocumentLoader is an ld.DocumentLoader fetching JSON-LD context documents from their remote URLs under a
+// policy: only the allowed domains are fetched from, and the documents with a pinned hash are checked against it.
+//
+// Use it as the remote loader of the DocumentLoader with the WithRemoteDocumentLoader() option.
+type RemoteDocumentLoader struct {
+	httpClient     *http.Client
+	allowedDomains []string
+	pinnedHashes   map[string]string
+	maxContextSize int64
+}
+
+// NewRemoteDocumentLoader returns a new RemoteDocumentLoader fetching context documents with httpClient.
+//
+// By default, context documents are fetched from any domain. Use WithAllowedDomains() to restrict the domains and
+// WithPinnedContexts() to check the integrity of known context documents. The context documents larger than 1 MiB
+// are rejected, use WithMaxContextSize() to change the limit.
+func NewRemoteDocumentLoader(httpClient *http.Client, opts ...RemoteDocumentLoaderOpts) *RemoteDocumentLoader {
+	l := &RemoteDocumentLoader{
+		pinnedHashes:   map[string]string{},
+		maxContextSize: defaultMaxContextSize,
+	}
+
+	for i := range opts {
+		opts[i](l)
+	}
+
+	// the redirections are fetched under the same policy
+	client := *httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return l.checkURL(req.URL)
+	}
+
+	l.httpClient = &client
+
+	return l
+}
+
+// LoadDocument fetches the JSON-LD context document of u, if the policy allows it.
+func (l *RemoteDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	parsedURL, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("parse context URL: %w", err)
+	}
+
+	if err = l.checkURL(parsedURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/ld+json, application/json;q=0.9")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch context document: %w", err)
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Warnf("failed to close context document response body: %s", errClose)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch context document: unexpected status %d", resp.StatusCode)
+	}
+
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, l.maxContextSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read context document: %w", err)
+	}
+
+	if int64(len(content)) > l.maxContextSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrContextTooLarge, u, l.maxContextSize)
+	}
+
+	if pinned, ok := l.pinnedHashes[u]; ok {
+		hash := sha256.Sum256(content)
+
+		if !strings.EqualFold(pinned, hex.EncodeToString(hash[:])) {
+			return nil, fmt.Errorf("%w: %s", ErrContextHashMismatch, u)
+		}
+	}
+
+	doc, err := ld.DocumentFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("document from reader: %w", err)
+	}
+
+	return &ld.RemoteDocument{
+		DocumentURL: resp.Request.URL.String(),
+		Document:    doc,
+	}, nil
+}
+
+func (l *RemoteDocumentLoader) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported context URL scheme: %s", u.Scheme)
+	}
+
+	if len(l.allowedDomains) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	for _, domain := range l.allowedDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrDomainNotAllowed, host)
+}
+
+// RemoteDocumentLoaderOpts configures RemoteDocumentLoader during creation.
+type RemoteDocumentLoaderOpts func(l *RemoteDocumentLoader)
+
+// WithAllowedDomains restricts the domains context documents are fetched from. A domain allows its subdomains too:
+// "w3id.org" allows "w3id.org" and "www.w3id.org".
+func WithAllowedDomains(domains ...string) RemoteDocumentLoaderOpts {
+	return func(l *RemoteDocumentLoader) {
+		for _, domain := range domains {
+			l.allowedDomains = append(l.allowedDomains, strings.ToLower(strings.TrimPrefix(domain, ".")))
+		}
+	}
+}
+
+// WithPinnedContexts pins the hex-encoded SHA-256 hashes of context documents, by context URL. A pinned context
+// document is rejected with ErrContextHashMismatch if its content has another hash.
+func WithPinnedContexts(hashes map[string]string) RemoteDocumentLoaderOpts {
+	return func(l *RemoteDocumentLoader) {
+		for u, hash := range hashes {
+			l.pinnedHashes[u] = hash
+		}
+	}
+}
+
+// WithMaxContextSize sets the maximum size in bytes of the fetched context documents, the larger ones are rejected
+// with ErrContextTooLarge.
+func WithMaxContextSize(size int64) RemoteDocumentLoaderOpts {
+	return func(l *RemoteDocumentLoader) {
+		l.maxContextSize = size
+	}
+}
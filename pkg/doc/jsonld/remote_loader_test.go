@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+)
+
+func TestRemoteDocumentLoader(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/context.jsonld", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/ld+json")
+		fmt.Fprint(w, sampleJSONLDContext)
+	})
+	mux.HandleFunc("/moved.jsonld", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/context.jsonld", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/away.jsonld", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://evil.example.com/context.jsonld", http.StatusFound)
+	})
+	mux.HandleFunc("/invalid.jsonld", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "not JSON")
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	contextURL := server.URL + "/context.jsonld"
+
+	hash := sha256.Sum256([]byte(sampleJSONLDContext))
+	contextHash := hex.EncodeToString(hash[:])
+
+	t.Run("Load context document", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, contextURL, rd.DocumentURL)
+		require.NotNil(t, rd.Document)
+	})
+
+	t.Run("Load context document from an allowed domain", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient, jsonld.WithAllowedDomains("127.0.0.1"))
+
+		_, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+	})
+
+	t.Run("Load context document from a domain not allowed", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient, jsonld.WithAllowedDomains("w3id.org"))
+
+		for _, u := range []string{contextURL, "https://w3id.org.example.com/context.jsonld"} {
+			_, err := loader.LoadDocument(u)
+			require.ErrorIs(t, err, jsonld.ErrDomainNotAllowed)
+		}
+	})
+
+	t.Run("Allowed domains allow their subdomains", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(&http.Client{Transport: rewriteTransport(server.URL)},
+			jsonld.WithAllowedDomains(".W3ID.org"))
+
+		for _, u := range []string{"https://w3id.org/context.jsonld", "https://www.w3id.org/context.jsonld"} {
+			_, err := loader.LoadDocument(u)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Follow redirections to allowed domains only", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient, jsonld.WithAllowedDomains("127.0.0.1"))
+
+		rd, err := loader.LoadDocument(server.URL + "/moved.jsonld")
+		require.NoError(t, err)
+		require.Equal(t, contextURL, rd.DocumentURL)
+
+		_, err = loader.LoadDocument(server.URL + "/away.jsonld")
+		require.ErrorIs(t, err, jsonld.ErrDomainNotAllowed)
+	})
+
+	t.Run("Check pinned hashes", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient, jsonld.WithPinnedContexts(map[string]string{
+			contextURL:                     contextHash,
+			server.URL + "/moved.jsonld":   contextHash,
+			server.URL + "/invalid.jsonld": contextHash,
+		}))
+
+		_, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(server.URL + "/moved.jsonld")
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(server.URL + "/invalid.jsonld")
+		require.ErrorIs(t, err, jsonld.ErrContextHashMismatch)
+	})
+
+	t.Run("Unsupported scheme", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient)
+
+		_, err := loader.LoadDocument("file:///etc/context.jsonld")
+		require.EqualError(t, err, "unsupported context URL scheme: file")
+	})
+
+	t.Run("Unexpected status", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient)
+
+		_, err := loader.LoadDocument(server.URL + "/missing.jsonld")
+		require.EqualError(t, err, "fetch context document: unexpected status 404")
+	})
+
+	t.Run("Context document too large", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient, jsonld.WithMaxContextSize(10))
+
+		_, err := loader.LoadDocument(contextURL)
+		require.ErrorIs(t, err, jsonld.ErrContextTooLarge)
+
+		loader = jsonld.NewRemoteDocumentLoader(http.DefaultClient,
+			jsonld.WithMaxContextSize(int64(len(sampleJSONLDContext))))
+
+		_, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid context document", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient)
+
+		_, err := loader.LoadDocument(server.URL + "/invalid.jsonld")
+		require.Contains(t, err.Error(), "document from reader")
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		loader := jsonld.NewRemoteDocumentLoader(http.DefaultClient)
+
+		_, err := loader.LoadDocument("http://[::1")
+		require.Contains(t, err.Error(), "parse context URL")
+	})
+}
+
+// rewriteTransport sends all the requests to the server at serverURL, as if it served any domain.
+type rewriteTransport string
+
+func (s rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = "http"
+	rewritten.URL.Host = string(s)[len("http://"):]
+
+	return http.DefaultTransport.RoundTrip(rewritten)
+}
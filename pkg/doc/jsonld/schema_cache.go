@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// schemaKeyPrefix prefixes the keys of the cached credential schemas, so that they are never loaded as context
+	// documents.
+	schemaKeyPrefix = "credentialschema:"
+	// schemaRecordTag is the tag of the cached credential schemas in the underlying storage.
+	schemaRecordTag = "credentialSchema"
+)
+
+// SchemaCache is a cache of credential schemas sharing the underlying storage of a DocumentLoader. It implements
+// verifiable.SchemaCache.
+//
+// The cached schemas are kept apart from the context documents: they are neither loaded nor listed as contexts, and
+// they expire after the remote context TTL of the DocumentLoader.
+type SchemaCache struct {
+	loader *DocumentLoader
+}
+
+// NewSchemaCache returns a new SchemaCache saving the credential schemas into the underlying storage of loader.
+func NewSchemaCache(loader *DocumentLoader) *SchemaCache {
+	return &SchemaCache{loader: loader}
+}
+
+// Put saves the credential schema v downloaded from the URL k.
+func (c *SchemaCache) Put(k string, v []byte) {
+	var doc interface{}
+
+	if err := json.Unmarshal(v, &doc); err != nil {
+		// not JSON, like a credential schema secured as a JWT
+		doc = string(v)
+	}
+
+	err := c.loader.saveRemoteDocument(schemaKeyPrefix+k, &ld.RemoteDocument{DocumentURL: k, Document: doc},
+		schemaRecordTag)
+	if err != nil {
+		logger.Warnf("failed to cache credential schema %s: %s", k, err)
+	}
+}
+
+// Get returns the credential schema downloaded from the URL k, unless it is missing or expired.
+func (c *SchemaCache) Get(k string) ([]byte, bool) {
+	b, err := c.loader.store.Get(schemaKeyPrefix + k)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Warnf("failed to get cached credential schema %s: %s", k, err)
+		}
+
+		return nil, false
+	}
+
+	var rec contextRecord
+
+	if err = json.Unmarshal(b, &rec); err != nil {
+		logger.Warnf("failed to unmarshal cached credential schema %s: %s", k, err)
+
+		return nil, false
+	}
+
+	if rec.ExpiresAt != nil && time.Now().After(*rec.ExpiresAt) {
+		return nil, false
+	}
+
+	if s, ok := rec.Document.(string); ok {
+		return []byte(s), true
+	}
+
+	schema, err := json.Marshal(rec.Document)
+	if err != nil {
+		logger.Warnf("failed to marshal cached credential schema %s: %s", k, err)
+
+		return nil, false
+	}
+
+	return schema, true
+}
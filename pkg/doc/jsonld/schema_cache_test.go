@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestSchemaCache(t *testing.T) {
+	const schemaURL = "https://example.com/schemas/email.json"
+
+	schema := []byte(`{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object"}`)
+
+	t.Run("Put and get credential schemas", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		cache := jsonld.NewSchemaCache(loader)
+
+		_, ok := cache.Get(schemaURL)
+		require.False(t, ok)
+
+		cache.Put(schemaURL, schema)
+
+		cached, ok := cache.Get(schemaURL)
+		require.True(t, ok)
+		require.JSONEq(t, string(schema), string(cached))
+
+		cache.Put(schemaURL+".jwt", []byte("eyJhbGciOiJFZERTQSJ9.e30.c2ln"))
+
+		cached, ok = cache.Get(schemaURL + ".jwt")
+		require.True(t, ok)
+		require.Equal(t, "eyJhbGciOiJFZERTQSJ9.e30.c2ln", string(cached))
+
+		// the cached schemas are neither listed nor loaded as context documents
+		contexts, err := jsonld.ListContexts(storageProvider.Store)
+		require.NoError(t, err)
+
+		for _, c := range contexts {
+			require.NotContains(t, c.URL, schemaURL)
+		}
+
+		_, err = loader.LoadDocument(schemaURL)
+		require.ErrorIs(t, err, jsonld.ErrContextNotFound)
+
+		_, err = loader.LoadDocument("credentialschema:" + schemaURL)
+		require.ErrorIs(t, err, jsonld.ErrContextNotFound)
+	})
+
+	t.Run("Cached credential schemas expire", func(t *testing.T) {
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteContextTTL(time.Nanosecond))
+		require.NoError(t, err)
+
+		cache := jsonld.NewSchemaCache(loader)
+		cache.Put(schemaURL, schema)
+
+		time.Sleep(time.Millisecond)
+
+		_, ok := cache.Get(schemaURL)
+		require.False(t, ok)
+	})
+
+	t.Run("Fail to get cached credential schema", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		cache := jsonld.NewSchemaCache(loader)
+		cache.Put(schemaURL, schema)
+
+		storageProvider.Store.ErrGet = errors.New("get error")
+
+		_, ok := cache.Get(schemaURL)
+		require.False(t, ok)
+	})
+}
@@ -257,7 +257,7 @@ func checkHeaders(headers map[string]interface{}) error {
 	}
 
 	typ, ok := headers[jose.HeaderType]
-	if ok && typ != TypeJWT {
+	if ok && !isJWTType(typ) {
 		return errors.New("typ is not JWT")
 	}
 
@@ -269,6 +269,19 @@ func checkHeaders(headers map[string]interface{}) error {
 	return nil
 }
 
+// isJWTType tells whether the typ header is JWT or an explicit JWT type (e.g. "vc+jwt"),
+// see https://tools.ietf.org/html/rfc8725#section-3.11.
+func isJWTType(typ interface{}) bool {
+	t, ok := typ.(string)
+	if !ok {
+		return false
+	}
+
+	t = strings.TrimPrefix(strings.ToLower(t), "application/")
+
+	return t == strings.ToLower(TypeJWT) || strings.HasSuffix(t, "+jwt")
+}
+
 func toMap(i interface{}) (map[string]interface{}, error) {
 	if reflect.ValueOf(i).Kind() == reflect.Map {
 		return i.(map[string]interface{}), nil
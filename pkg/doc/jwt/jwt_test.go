@@ -179,6 +179,14 @@ func TestParse(t *testing.T) {
 	r.Contains(err.Error(), "typ is not JWT")
 	r.Nil(token)
 
+	// explicit JWT type
+	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "vc+jwt"}
+	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
+	r.NoError(err)
+	token, err = Parse(jws, WithSignatureVerifier(verifier))
+	r.NoError(err)
+	r.Equal("vc+jwt", token.LookupStringHeader("typ"))
+
 	// content type is not empty (equals to JWT)
 	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "JWT", "cty": "JWT"}
 	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
@@ -176,6 +176,83 @@ func (p *Processor) Compact(input, context map[string]interface{},
 	return ld.NewJsonLdProcessor().Compact(input, context, ldOptions)
 }
 
+// Expand expands given json ld object.
+func (p *Processor) Expand(input map[string]interface{}, opts ...ProcessorOpts) ([]interface{}, error) {
+	procOptions := prepareOpts(opts)
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.DocumentLoader = procOptions.documentLoader
+
+	return ld.NewJsonLdProcessor().Expand(input, ldOptions)
+}
+
+// ToRDF returns the N-Quads of given json ld object, either compacted or expanded, without canonicalizing them.
+func (p *Processor) ToRDF(input interface{}, opts ...ProcessorOpts) (string, error) {
+	procOptions := prepareOpts(opts)
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.Format = format
+	ldOptions.ProduceGeneralizedRdf = true
+	ldOptions.DocumentLoader = procOptions.documentLoader
+
+	view, err := ld.NewJsonLdProcessor().ToRDF(input, ldOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert JSON-LD document to RDF: %w", err)
+	}
+
+	result, ok := view.(string)
+	if !ok {
+		return "", fmt.Errorf("failed to convert JSON-LD document to RDF, invalid view")
+	}
+
+	return result, nil
+}
+
+// CanonicalizeNQuads canonicalizes the RDF dataset given as N-Quads. It returns the sorted canonical N-Quads
+// terminated by a new line, and the canonical identifiers issued to the blank nodes of the input, mapped without
+// the "_:" prefix.
+func (p *Processor) CanonicalizeNQuads(nquads string) ([]string, map[string]string, error) {
+	dataset, err := ld.ParseNQuads(nquads)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse N-Quads: %w", err)
+	}
+
+	type blankNodeLabel struct {
+		node  *ld.BlankNode
+		input string
+	}
+
+	var labels []blankNodeLabel
+
+	for _, quads := range dataset.Graphs {
+		for _, quad := range quads {
+			for _, node := range []ld.Node{quad.Subject, quad.Object} {
+				if bn, ok := node.(*ld.BlankNode); ok {
+					labels = append(labels, blankNodeLabel{node: bn, input: bn.Attribute})
+				}
+			}
+		}
+	}
+
+	// normalization relabels the blank nodes of the dataset in place.
+	view, err := ld.NewNormalisationAlgorithm(p.algorithm).Main(dataset, &ld.JsonLdOptions{Format: format})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to canonicalize N-Quads: %w", err)
+	}
+
+	canonicalIDs := make(map[string]string, len(labels))
+
+	for _, l := range labels {
+		canonicalIDs[strings.TrimPrefix(l.input, "_:")] = strings.TrimPrefix(l.node.Attribute, "_:")
+	}
+
+	lines := strings.SplitAfter(view.(string), "\n")
+
+	return lines[:len(lines)-1], canonicalIDs, nil
+}
+
 // Frame makes a frame from the inputDoc using frameDoc.
 func (p *Processor) Frame(inputDoc map[string]interface{}, frameDoc map[string]interface{},
 	opts ...ProcessorOpts) (map[string]interface{}, error) {
@@ -57,6 +57,25 @@ func CreateVerifyData(suite signatureSuite, jsonldDoc map[string]interface{}, pr
 // CreateVerifyHash returns data that is used to generate or verify a digital signature
 // Algorithm steps are described here https://w3c-dvcg.github.io/ld-signatures/#create-verify-hash-algorithm
 func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	proofOptionsDigest, err := CreateProofConfigHash(suite, jsonldDoc, proofOptions, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	docDigest := suite.GetDigest(canonicalDoc)
+
+	return append(proofOptionsDigest, docDigest...), nil
+}
+
+// CreateProofConfigHash returns the digest of the canonical proof options, the proof configuration of
+// a Data Integrity proof. It's the first part of the data created by CreateVerifyHash.
+func CreateProofConfigHash(suite signatureSuite, jsonldDoc, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
 	// in  order to generate canonical form we need context
 	// if context is not passed, use document's context
@@ -71,22 +90,16 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 		return nil, err
 	}
 
-	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
-
-	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	docDigest := suite.GetDigest(canonicalDoc)
-
-	return append(proofOptionsDigest, docDigest...), nil
+	return suite.GetDigest(canonicalProofOptions), nil
 }
 
 func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	// Data Integrity proof configuration excludes only the proof value, its created is optional
+	isDataIntegrityProof := proofOptions[jsonldType] == DataIntegrityProof
+
 	value, ok := proofOptions[jsonldCreated]
-	if !ok || value == nil {
+	if (!ok || value == nil) && !isDataIntegrityProof {
 		return nil, errors.New("created is missing")
 	}
 
@@ -94,7 +107,9 @@ func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]
 	proofOptionsCopy := make(map[string]interface{}, len(proofOptions))
 
 	for key, value := range proofOptions {
-		if excludedKeyFromString(key) == 0 {
+		ek := excludedKeyFromString(key)
+
+		if ek == 0 || (isDataIntegrityProof && ek != proofValue) {
 			proofOptionsCopy[key] = value
 		}
 	}
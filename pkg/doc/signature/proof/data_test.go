@@ -77,6 +77,30 @@ func TestPrepareCanonicalProofOptions(t *testing.T) {
 	require.NotNil(t, err)
 	require.Nil(t, canonicalProofOptions)
 	require.Contains(t, err.Error(), "created is missing")
+
+	t.Run("data integrity proof configuration keeps nonce", func(t *testing.T) {
+		suite := &mockSignatureSuite{}
+
+		diProofOptions := map[string]interface{}{
+			"@context":    []interface{}{"https://w3id.org/security/data-integrity/v1"},
+			"type":        "DataIntegrityProof",
+			"cryptosuite": "eddsa-rdfc-2022",
+			"created":     "2018-03-15T00:00:00Z",
+			"nonce":       "nonce",
+			"proofValue":  "z123",
+		}
+
+		withNonce, err := prepareCanonicalProofOptions(suite, diProofOptions, jsonldtest.WithDocumentLoader(t))
+		require.NoError(t, err)
+		require.Contains(t, string(withNonce), "nonce")
+		require.NotContains(t, string(withNonce), "z123")
+
+		delete(diProofOptions, jsonldCreated)
+
+		withoutCreated, err := prepareCanonicalProofOptions(suite, diProofOptions, jsonldtest.WithDocumentLoader(t))
+		require.NoError(t, err)
+		require.NotContains(t, string(withoutCreated), "created")
+	})
 }
 
 func TestCreateVerifyData(t *testing.T) {
@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/multiformats/go-multibase"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 )
 
@@ -36,8 +38,17 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for cryptosuite.
+	jsonldCryptosuite = "cryptosuite"
 )
 
+// DataIntegrityProof is the type of W3C Data Integrity proofs (https://www.w3.org/TR/vc-data-integrity/).
+// The signature suite of such a proof is selected by its cryptosuite and the proof value is multibase encoded.
+const DataIntegrityProof = "DataIntegrityProof"
+
+// bbs2023Cryptosuite is the only cryptosuite supported which doesn't encode its proof values as base58-btc.
+const bbs2023Cryptosuite = "bbs-2023"
+
 // Proof is cryptographic proof of the integrity of the DID Document.
 type Proof struct {
 	Type                    string
@@ -53,13 +64,15 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// Cryptosuite is the cryptographic suite of a DataIntegrityProof.
+	Cryptosuite string
 }
 
 // NewProof creates new proof.
 func NewProof(emap map[string]interface{}) (*Proof, error) {
-	created := stringEntry(emap[jsonldCreated])
+	proofType := stringEntry(emap[jsonldType])
 
-	timeValue, err := util.ParseTimeWithTrailingZeroMsec(created)
+	timeValue, err := decodeCreated(stringEntry(emap[jsonldCreated]), proofType)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +84,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	)
 
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), proofType)
 		if err != nil {
 			return nil, err
 		}
@@ -86,7 +99,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		return nil, errors.New("signature is not defined")
 	}
 
-	nonce, err := decodeBase64(stringEntry(emap[jsonldNonce]))
+	nonce, err := decodeNonce(stringEntry(emap[jsonldNonce]), proofType)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +110,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	}
 
 	return &Proof{
-		Type:                    stringEntry(emap[jsonldType]),
+		Type:                    proofType,
 		Created:                 timeValue,
 		Creator:                 stringEntry(emap[jsonldCreator]),
 		VerificationMethod:      stringEntry(emap[jsonldVerificationMethod]),
@@ -109,9 +122,43 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 	}, nil
 }
 
+// decodeCreated decodes the created time of the proof, it's optional for Data Integrity proofs.
+func decodeCreated(s, proofType string) (*util.TimeWithTrailingZeroMsec, error) {
+	if s == "" && proofType == DataIntegrityProof {
+		return nil, nil
+	}
+
+	return util.ParseTimeWithTrailingZeroMsec(s)
+}
+
+// decodeProofValue decodes the multibase proof value of a Data Integrity proof
+// or the base64 proof value of a Linked Data Proof.
+func decodeProofValue(s, proofType string) ([]byte, error) {
+	if proofType != DataIntegrityProof {
+		return decodeBase64(s)
+	}
+
+	_, value, err := multibase.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase proof value: %w", err)
+	}
+
+	return value, nil
+}
+
+// decodeNonce returns the nonce of a Data Integrity proof as is, it's base64 encoded in Linked Data Proofs.
+func decodeNonce(s, proofType string) ([]byte, error) {
+	if proofType == DataIntegrityProof {
+		return []byte(s), nil
+	}
+
+	return decodeBase64(s)
+}
+
 func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error) {
 	var capabilityChain []interface{}
 
@@ -170,7 +217,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	}
 
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = p.encodeProofValue()
 	}
 
 	if len(p.JWS) > 0 {
@@ -182,7 +229,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	}
 
 	if len(p.Nonce) > 0 {
-		emap[jsonldNonce] = base64.RawURLEncoding.EncodeToString(p.Nonce)
+		emap[jsonldNonce] = p.encodeNonce()
 	}
 
 	if p.ProofPurpose != "" {
@@ -197,9 +244,48 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	return emap
 }
 
+func (p *Proof) encodeProofValue() string {
+	if p.Type == DataIntegrityProof {
+		var encoding multibase.Encoding = multibase.Base58BTC
+		if p.Cryptosuite == bbs2023Cryptosuite {
+			// the base and derived proof values of bbs-2023 are base64url encoded.
+			encoding = multibase.Base64url
+		}
+
+		// base58-btc and base64url encodings are always supported by multibase.
+		value, _ := multibase.Encode(encoding, p.ProofValue) //nolint:errcheck
+
+		return value
+	}
+
+	return base64.RawURLEncoding.EncodeToString(p.ProofValue)
+}
+
+func (p *Proof) encodeNonce() string {
+	if p.Type == DataIntegrityProof {
+		return string(p.Nonce)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(p.Nonce)
+}
+
+// SuiteType returns the type a signature suite of the proof is selected by.
+// It's the cryptosuite of a Data Integrity proof and the proof type otherwise.
+func (p *Proof) SuiteType() string {
+	if p.Type == DataIntegrityProof {
+		return p.Cryptosuite
+	}
+
+	return p.Type
+}
+
 // PublicKeyID provides ID of public key to be used to independently verify the proof.
 // "verificationMethod" field is checked first. If not empty, its value is returned.
 // Otherwise, "creator" field is returned if not empty. Otherwise, error is returned.
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
@@ -124,6 +125,58 @@ func TestProof(t *testing.T) {
 	})
 }
 
+func TestDataIntegrityProof(t *testing.T) {
+	proofValueBytes, err := base64.RawURLEncoding.DecodeString(proofValueBase64)
+	require.NoError(t, err)
+
+	p, err := NewProof(map[string]interface{}{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-rdfc-2022",
+		"verificationMethod": "did:example:123456#key1",
+		"created":            "2018-03-15T00:00:00Z",
+		"nonce":              "abc",
+		"proofValue":         "z" + base58.Encode(proofValueBytes),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, DataIntegrityProof, p.Type)
+	require.Equal(t, "eddsa-rdfc-2022", p.Cryptosuite)
+	require.Equal(t, "eddsa-rdfc-2022", p.SuiteType())
+	require.Equal(t, []byte("abc"), p.Nonce)
+	require.Equal(t, proofValueBytes, p.ProofValue)
+
+	proofMap := p.JSONLdObject()
+	require.Equal(t, "z"+base58.Encode(proofValueBytes), proofMap["proofValue"])
+	require.Equal(t, "eddsa-rdfc-2022", proofMap["cryptosuite"])
+	require.Equal(t, "abc", proofMap["nonce"])
+
+	t.Run("legacy proof suite type", func(t *testing.T) {
+		require.Equal(t, "Ed25519Signature2018", (&Proof{Type: "Ed25519Signature2018"}).SuiteType())
+	})
+
+	t.Run("created is optional", func(t *testing.T) {
+		p, err := NewProof(map[string]interface{}{
+			"type":        "DataIntegrityProof",
+			"cryptosuite": "eddsa-rdfc-2022",
+			"proofValue":  "z" + base58.Encode(proofValueBytes),
+		})
+		require.NoError(t, err)
+		require.Nil(t, p.Created)
+		require.NotContains(t, p.JSONLdObject(), "created")
+	})
+
+	t.Run("invalid multibase proof value", func(t *testing.T) {
+		_, err := NewProof(map[string]interface{}{
+			"type":        "DataIntegrityProof",
+			"cryptosuite": "eddsa-rdfc-2022",
+			"created":     "2018-03-15T00:00:00Z",
+			"proofValue":  proofValueBase64,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode multibase proof value")
+	})
+}
+
 func TestInvalidProofValue(t *testing.T) {
 	// invalid proof value
 	p, err := NewProof(map[string]interface{}{
@@ -37,6 +37,12 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// proofValueCreator is implemented by the cryptosuites creating the proof value from the document and
+// the proof configuration themselves, like bbs-2023 whose proof value carries more than a signature.
+type proofValueCreator interface {
+	CreateProofValue(doc map[string]interface{}, p *proof.Proof, opts ...jsonld.ProcessorOpts) ([]byte, error)
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -54,6 +60,7 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	Cryptosuite             string                        // required for DataIntegrityProof
 }
 
 // New returns new instance of document verifier.
@@ -90,11 +97,6 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	suite, err := signer.getSignatureSuite(context.SignatureType)
-	if err != nil {
-		return err
-	}
-
 	created := context.Created
 	if created == nil {
 		now := time.Now()
@@ -112,6 +114,12 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Challenge:               context.Challenge,
 		ProofPurpose:            context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		Cryptosuite:             context.Cryptosuite,
+	}
+
+	suite, err := signer.getSignatureSuite(p.SuiteType())
+	if err != nil {
+		return err
 	}
 
 	// TODO support custom proof purpose
@@ -124,6 +132,15 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		p.JWS = proof.CreateDetachedJWTHeader(p) + ".."
 	}
 
+	if creator, ok := suite.(proofValueCreator); ok {
+		p.ProofValue, err = creator.CreateProofValue(jsonLdObject, p, append(opts, jsonld.WithValidateRDF())...)
+		if err != nil {
+			return err
+		}
+
+		return proof.AddProof(jsonLdObject, p)
+	}
+
 	message, err := proof.CreateVerifyData(suite, jsonLdObject, p, append(opts, jsonld.WithValidateRDF())...)
 	if err != nil {
 		return err
@@ -165,5 +182,9 @@ func isValidContext(context *Context) error {
 		return errors.New("signature type is missing")
 	}
 
+	if context.SignatureType == proof.DataIntegrityProof && context.Cryptosuite == "" {
+		return errors.New("cryptosuite is missing")
+	}
+
 	return nil
 }
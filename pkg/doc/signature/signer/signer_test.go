@@ -123,6 +123,13 @@ func TestDocumentSigner_isValidContext(t *testing.T) {
 	require.NotNil(t, err)
 	require.Nil(t, signedDoc)
 	require.Contains(t, err.Error(), "signature type is missing")
+
+	context = getSignatureContext()
+	context.SignatureType = "DataIntegrityProof"
+	signedDoc, err = s.Sign(context, []byte(validDoc), jsonldtest.WithDocumentLoader(t))
+	require.NotNil(t, err)
+	require.Nil(t, signedDoc)
+	require.Contains(t, err.Error(), "cryptosuite is missing")
 }
 
 func getSignatureContext() *Context {
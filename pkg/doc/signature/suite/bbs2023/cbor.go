@@ -0,0 +1,269 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// The components of the bbs-2023 proof values are serialized as a CBOR (RFC 8949) array of byte strings,
+// text strings, unsigned integers and maps of unsigned integers. Only those data items are supported below.
+
+const (
+	cborUint  byte = 0
+	cborBytes byte = 2
+	cborText  byte = 3
+	cborArray byte = 4
+	cborMap   byte = 5
+
+	cborUint8Info  = 24
+	cborUint16Info = 25
+	cborUint32Info = 26
+	cborUint64Info = 27
+	cborMajorShift = 5
+	cborInfoMask   = 0x1f
+)
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+type cborEncoder struct {
+	buf []byte
+}
+
+func (e *cborEncoder) head(major byte, n uint64) {
+	m := major << cborMajorShift
+
+	var info byte
+
+	switch {
+	case n < cborUint8Info:
+		e.buf = append(e.buf, m|byte(n))
+
+		return
+	case n <= 0xff:
+		info = cborUint8Info
+	case n <= 0xffff:
+		info = cborUint16Info
+	case n <= 0xffffffff:
+		info = cborUint32Info
+	default:
+		info = cborUint64Info
+	}
+
+	size := 1 << (info - cborUint8Info)
+
+	e.buf = append(e.buf, m|info)
+
+	for i := size - 1; i >= 0; i-- {
+		e.buf = append(e.buf, byte(n>>(8*i))) //nolint:gomnd
+	}
+}
+
+func (e *cborEncoder) bytes(b []byte) {
+	e.head(cborBytes, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *cborEncoder) text(s string) {
+	e.head(cborText, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *cborEncoder) array(n int) {
+	e.head(cborArray, uint64(n))
+}
+
+func (e *cborEncoder) texts(values []string) {
+	e.array(len(values))
+
+	for _, v := range values {
+		e.text(v)
+	}
+}
+
+func (e *cborEncoder) uints(values []int) {
+	e.array(len(values))
+
+	for _, v := range values {
+		e.head(cborUint, uint64(v))
+	}
+}
+
+// uintMap encodes the map with its keys sorted to keep the encoding deterministic.
+func (e *cborEncoder) uintMap(m map[int]int) {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Ints(keys)
+
+	e.head(cborMap, uint64(len(m)))
+
+	for _, k := range keys {
+		e.head(cborUint, uint64(k))
+		e.head(cborUint, uint64(m[k]))
+	}
+}
+
+type cborDecoder struct {
+	data []byte
+}
+
+func (d *cborDecoder) head(expected byte) (uint64, error) {
+	if len(d.data) == 0 {
+		return 0, errCBORTruncated
+	}
+
+	major, info := d.data[0]>>cborMajorShift, d.data[0]&cborInfoMask
+	if major != expected {
+		return 0, fmt.Errorf("cbor: unexpected major type %d, expected %d", major, expected)
+	}
+
+	d.data = d.data[1:]
+
+	if info > cborUint64Info {
+		return 0, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+
+	if info < cborUint8Info {
+		return uint64(info), nil
+	}
+
+	size := 1 << (info - cborUint8Info)
+
+	if len(d.data) < size {
+		return 0, errCBORTruncated
+	}
+
+	var n uint64
+	for _, b := range d.data[:size] {
+		n = n<<8 | uint64(b) //nolint:gomnd
+	}
+
+	d.data = d.data[size:]
+
+	return n, nil
+}
+
+// length decodes the head of a data item holding a length or a count bounded by the remaining data.
+func (d *cborDecoder) length(major byte) (int, error) {
+	n, err := d.head(major)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > uint64(len(d.data)) {
+		return 0, errCBORTruncated
+	}
+
+	return int(n), nil
+}
+
+func (d *cborDecoder) uint() (int, error) {
+	n, err := d.head(cborUint)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > uint64(int(^uint(0)>>1)) {
+		return 0, errors.New("cbor: unsigned integer overflow")
+	}
+
+	return int(n), nil
+}
+
+func (d *cborDecoder) bytes() ([]byte, error) {
+	n, err := d.length(cborBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	b := d.data[:n]
+	d.data = d.data[n:]
+
+	return b, nil
+}
+
+func (d *cborDecoder) text() (string, error) {
+	n, err := d.length(cborText)
+	if err != nil {
+		return "", err
+	}
+
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+
+	return s, nil
+}
+
+func (d *cborDecoder) array() (int, error) {
+	return d.length(cborArray)
+}
+
+func (d *cborDecoder) texts() ([]string, error) {
+	n, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, n)
+
+	for i := range values {
+		values[i], err = d.text()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (d *cborDecoder) uints() ([]int, error) {
+	n, err := d.array()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]int, n)
+
+	for i := range values {
+		values[i], err = d.uint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (d *cborDecoder) uintMap() (map[int]int, error) {
+	n, err := d.length(cborMap)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[int]int, n)
+
+	for i := 0; i < n; i++ {
+		k, err := d.uint()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := d.uint()
+		if err != nil {
+			return nil, err
+		}
+
+		m[k] = v
+	}
+
+	return m, nil
+}
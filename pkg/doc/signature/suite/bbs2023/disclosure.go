@@ -0,0 +1,487 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// The functions below implement the selective disclosure primitives of the Data Integrity
+// BBS Cryptosuites specification (https://www.w3.org/TR/vc-di-bbs/#selective-disclosure-functions).
+
+const skolemPrefix = "urn:custom-scheme:"
+
+//nolint:gochecknoglobals
+var (
+	skolemIRI  = regexp.MustCompile(`<` + regexp.QuoteMeta(skolemPrefix) + `([^>]+)>`)
+	blankLabel = regexp.MustCompile(`_:([^\s]+)`)
+)
+
+// labelMapFactory creates the blank node labels replacing the labels issued by the canonicalization.
+// It maps the input blank node labels to the new ones.
+type labelMapFactory func(canonicalIDs map[string]string) map[string]string
+
+// hmacLabels creates the labels of the base proofs: the HMAC of the canonical labels, in sorted order,
+// so that the labels don't reveal anything about the non-disclosed statements.
+func hmacLabels(hmacKey []byte) labelMapFactory {
+	return func(canonicalIDs map[string]string) map[string]string {
+		hmacIDs := make(map[string]string, len(canonicalIDs))
+		sortedIDs := make([]string, 0, len(canonicalIDs))
+
+		for input, c14nLabel := range canonicalIDs {
+			mac := hmac.New(sha256.New, hmacKey)
+			mac.Write([]byte(c14nLabel)) //nolint:errcheck
+
+			hmacIDs[input] = "u" + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+			sortedIDs = append(sortedIDs, hmacIDs[input])
+		}
+
+		sort.Strings(sortedIDs)
+
+		positions := make(map[string]int, len(sortedIDs))
+		for i, id := range sortedIDs {
+			positions[id] = i
+		}
+
+		labels := make(map[string]string, len(hmacIDs))
+		for input, id := range hmacIDs {
+			labels[input] = bbsLabelPrefix + strconv.Itoa(positions[id])
+		}
+
+		return labels
+	}
+}
+
+// mappedLabels creates the labels of the derived proofs from the canonical labels of the reveal document.
+func mappedLabels(labelMap map[string]string) labelMapFactory {
+	return func(canonicalIDs map[string]string) map[string]string {
+		labels := make(map[string]string, len(canonicalIDs))
+
+		for input, c14nLabel := range canonicalIDs {
+			labels[input] = labelMap[c14nLabel]
+		}
+
+		return labels
+	}
+}
+
+// group holds the indexes, in the canonical N-Quads of a document, of the N-Quads selected by a group of
+// JSON pointers and of the other ones.
+type group struct {
+	matching    map[int]string
+	nonMatching map[int]string
+}
+
+// groupedNQuads holds the canonical N-Quads of a document, relabeled by the label map, grouped by JSON pointers.
+type groupedNQuads struct {
+	groups   map[string]*group
+	labelMap map[string]string
+	nquads   []string
+}
+
+func (s *Suite) canonicalizeAndGroup(doc map[string]interface{}, labelFactory labelMapFactory,
+	groupDefinitions map[string][]string, opts ...jsonld.ProcessorOpts) (*groupedNQuads, error) {
+	expanded, compacted, err := s.skolemize(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	deskolemized, err := s.toDeskolemizedNQuads(expanded, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	nquads, labelMap, err := s.labelReplacementCanonicalize(strings.Join(deskolemized, ""), labelFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &groupedNQuads{
+		groups:   make(map[string]*group, len(groupDefinitions)),
+		labelMap: labelMap,
+		nquads:   nquads,
+	}
+
+	for name, pointers := range groupDefinitions {
+		selected, err := s.selectCanonicalNQuads(pointers, labelMap, compacted, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("select %s statements: %w", name, err)
+		}
+
+		g := &group{matching: map[int]string{}, nonMatching: map[int]string{}}
+
+		for i, nq := range nquads {
+			if selected[nq] {
+				g.matching[i] = nq
+			} else {
+				g.nonMatching[i] = nq
+			}
+		}
+
+		result.groups[name] = g
+	}
+
+	return result, nil
+}
+
+func (s *Suite) selectCanonicalNQuads(pointers []string, labelMap map[string]string,
+	doc map[string]interface{}, opts ...jsonld.ProcessorOpts) (map[string]bool, error) {
+	selected := map[string]bool{}
+
+	if len(pointers) == 0 {
+		return selected, nil
+	}
+
+	selection, err := selectJSONLD(pointers, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	deskolemized, err := s.toDeskolemizedNQuads(selection, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nq := range relabelBlankNodes(deskolemized, labelMap) {
+		selected[nq] = true
+	}
+
+	return selected, nil
+}
+
+// labelReplacementCanonicalize canonicalizes the N-Quads and replaces the canonical blank node labels with the
+// labels created by the factory. It returns the sorted relabeled N-Quads and the labels of the input blank nodes.
+func (s *Suite) labelReplacementCanonicalize(nquads string,
+	labelFactory labelMapFactory) ([]string, map[string]string, error) {
+	canonical, canonicalIDs, err := s.jsonldProcessor.CanonicalizeNQuads(nquads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labelMap := labelFactory(canonicalIDs)
+
+	c14nToLabel := make(map[string]string, len(canonicalIDs))
+	for input, c14nLabel := range canonicalIDs {
+		c14nToLabel[c14nLabel] = labelMap[input]
+	}
+
+	relabeled := relabelBlankNodes(canonical, c14nToLabel)
+	sort.Strings(relabeled)
+
+	return relabeled, labelMap, nil
+}
+
+// labelReplacementCanonicalizeJSONLD canonicalizes the document with its blank nodes relabeled by the factory.
+func (s *Suite) labelReplacementCanonicalizeJSONLD(doc map[string]interface{}, labelFactory labelMapFactory,
+	opts ...jsonld.ProcessorOpts) ([]string, error) {
+	nquads, err := s.jsonldProcessor.ToRDF(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	relabeled, _, err := s.labelReplacementCanonicalize(nquads, labelFactory)
+
+	return relabeled, err
+}
+
+func relabelBlankNodes(nquads []string, labelMap map[string]string) []string {
+	relabeled := make([]string, len(nquads))
+
+	for i, nq := range nquads {
+		relabeled[i] = blankLabel.ReplaceAllStringFunc(nq, func(label string) string {
+			if newLabel, ok := labelMap[label[2:]]; ok {
+				return "_:" + newLabel
+			}
+
+			return label
+		})
+	}
+
+	return relabeled
+}
+
+// skolemize replaces the blank nodes of the document with IRIs, so that they are kept when selecting parts of the
+// document. It returns the skolemized document expanded and compacted with the document context.
+func (s *Suite) skolemize(doc map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]interface{}, map[string]interface{}, error) {
+	expanded, err := s.jsonldProcessor.Expand(doc, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("expand document: %w", err)
+	}
+
+	count := 0
+	skolemized := skolemizeExpanded(expanded, strings.ReplaceAll(uuid.New().String(), "-", ""), &count)
+
+	compacted, err := s.jsonldProcessor.Compact(map[string]interface{}{"@graph": skolemized},
+		map[string]interface{}{"@context": doc["@context"]}, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compact skolemized document: %w", err)
+	}
+
+	return skolemized, compacted, nil
+}
+
+func skolemizeExpanded(expanded []interface{}, random string, count *int) []interface{} {
+	skolemized := make([]interface{}, len(expanded))
+
+	for i, element := range expanded {
+		node, ok := element.(map[string]interface{})
+		if !ok || node["@value"] != nil {
+			skolemized[i] = element
+
+			continue
+		}
+
+		skolemizedNode := make(map[string]interface{}, len(node)+1)
+
+		for property, value := range node {
+			if values, isArray := value.([]interface{}); isArray {
+				skolemizedNode[property] = skolemizeExpanded(values, random, count)
+			} else {
+				skolemizedNode[property] = skolemizeExpanded([]interface{}{value}, random, count)[0]
+			}
+		}
+
+		if _, isList := node["@list"]; !isList {
+			id, _ := skolemizedNode["@id"].(string) //nolint:errcheck
+
+			switch {
+			case id == "":
+				skolemizedNode["@id"] = skolemPrefix + random + "_" + strconv.Itoa(*count)
+				*count++
+			case strings.HasPrefix(id, "_:"):
+				skolemizedNode["@id"] = skolemPrefix + id[2:]
+			}
+		}
+
+		skolemized[i] = skolemizedNode
+	}
+
+	return skolemized
+}
+
+// toDeskolemizedNQuads returns the N-Quads of the document with the skolem IRIs turned back into blank nodes.
+func (s *Suite) toDeskolemizedNQuads(doc interface{}, opts ...jsonld.ProcessorOpts) ([]string, error) {
+	nquads, err := s.jsonldProcessor.ToRDF(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(nquads, "\n")
+	lines = lines[:len(lines)-1]
+
+	for i, line := range lines {
+		lines[i] = skolemIRI.ReplaceAllString(line, "_:$1")
+	}
+
+	return lines, nil
+}
+
+// sparseArray is an array of the selection of which only some elements are selected.
+type sparseArray map[int]interface{}
+
+// selectJSONLD selects the parts of the document identified by the JSON pointers (RFC 6901). The selection keeps
+// the context of the document and the id and type of the selected objects.
+func selectJSONLD(pointers []string, doc map[string]interface{}) (map[string]interface{}, error) {
+	selection := initialSelection(doc)
+	selection["@context"] = deepCopy(doc["@context"])
+
+	for _, pointer := range pointers {
+		paths, err := parsePointer(pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = selectPaths(paths, doc, selection); err != nil {
+			return nil, fmt.Errorf("JSON pointer %s: %w", pointer, err)
+		}
+	}
+
+	return compactSelection(selection).(map[string]interface{}), nil
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer: %s", pointer)
+	}
+
+	paths := strings.Split(pointer[1:], "/")
+
+	for i, p := range paths {
+		paths[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+
+	return paths, nil
+}
+
+func initialSelection(source map[string]interface{}) map[string]interface{} {
+	selection := map[string]interface{}{}
+
+	if id, ok := source["id"].(string); ok && !strings.HasPrefix(id, "_:") {
+		selection["id"] = id
+	}
+
+	if t, ok := source["type"]; ok {
+		selection["type"] = t
+	}
+
+	return selection
+}
+
+func selectPaths(paths []string, doc map[string]interface{}, selection map[string]interface{}) error {
+	var (
+		value         interface{} = doc
+		selectedValue interface{} = selection
+		parentValue   interface{}
+	)
+
+	for _, path := range paths {
+		parentValue = selectedValue
+
+		next, err := child(value, path)
+		if err != nil {
+			return err
+		}
+
+		value = next
+
+		if selectedValue = selectedChild(parentValue, path); selectedValue != nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case []interface{}:
+			selectedValue = sparseArray{}
+		case map[string]interface{}:
+			selectedValue = initialSelection(v)
+		default:
+			selectedValue = map[string]interface{}{}
+		}
+
+		setSelectedChild(parentValue, path, selectedValue)
+	}
+
+	if v, ok := value.(map[string]interface{}); ok {
+		if s, isMap := selectedValue.(map[string]interface{}); isMap {
+			for k, e := range v {
+				s[k] = deepCopy(e)
+			}
+
+			return nil
+		}
+	}
+
+	setSelectedChild(parentValue, paths[len(paths)-1], deepCopy(value))
+
+	return nil
+}
+
+func child(value interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if c, ok := v[path]; ok {
+			return c, nil
+		}
+	case []interface{}:
+		if i, err := strconv.Atoi(path); err == nil && i >= 0 && i < len(v) {
+			return v[i], nil
+		}
+	}
+
+	return nil, errors.New("no value matches the pointer")
+}
+
+func selectedChild(selected interface{}, path string) interface{} {
+	switch s := selected.(type) {
+	case map[string]interface{}:
+		return s[path]
+	case sparseArray:
+		i, _ := strconv.Atoi(path) //nolint:errcheck
+
+		return s[i]
+	case []interface{}:
+		i, _ := strconv.Atoi(path) //nolint:errcheck
+
+		return s[i]
+	}
+
+	return nil
+}
+
+func setSelectedChild(selected interface{}, path string, value interface{}) {
+	switch s := selected.(type) {
+	case map[string]interface{}:
+		s[path] = value
+	case sparseArray:
+		i, _ := strconv.Atoi(path) //nolint:errcheck
+		s[i] = value
+	case []interface{}:
+		i, _ := strconv.Atoi(path) //nolint:errcheck
+		s[i] = value
+	}
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = deepCopy(e)
+		}
+
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = deepCopy(e)
+		}
+
+		return a
+	}
+
+	return value
+}
+
+// compactSelection turns the sparse arrays of the selection into arrays keeping the order of the elements.
+func compactSelection(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = compactSelection(e)
+		}
+
+		return v
+	case sparseArray:
+		indexes := make([]int, 0, len(v))
+		for i := range v {
+			indexes = append(indexes, i)
+		}
+
+		sort.Ints(indexes)
+
+		values := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			values[i] = compactSelection(v[index])
+		}
+
+		return values
+	}
+
+	return value
+}
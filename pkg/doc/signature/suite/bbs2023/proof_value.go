@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	baseProofComponents    = 5
+	derivedProofComponents = 5
+
+	c14nLabelPrefix = "c14n"
+	bbsLabelPrefix  = "b"
+)
+
+//nolint:gochecknoglobals
+var (
+	// baseProofHeader and derivedProofHeader are the bytes prefixing the CBOR-encoded components of the proof values.
+	baseProofHeader    = []byte{0xd9, 0x5d, 0x02}
+	derivedProofHeader = []byte{0xd9, 0x5d, 0x03}
+)
+
+// baseProof holds the components of the proof value of a base proof created by the issuer.
+type baseProof struct {
+	signature         []byte
+	header            []byte
+	publicKey         []byte
+	hmacKey           []byte
+	mandatoryPointers []string
+}
+
+// derivedProof holds the components of the proof value of a proof derived by the holder.
+type derivedProof struct {
+	proof []byte
+	// labelMap maps the canonical blank node labels of the reveal document to the labels of the base proof.
+	labelMap           map[string]string
+	mandatoryIndexes   []int
+	selectiveIndexes   []int
+	presentationHeader []byte
+}
+
+func isBaseProof(proofValue []byte) bool {
+	return bytes.HasPrefix(proofValue, baseProofHeader)
+}
+
+func isDerivedProof(proofValue []byte) bool {
+	return bytes.HasPrefix(proofValue, derivedProofHeader)
+}
+
+func (p *baseProof) serialize() []byte {
+	e := &cborEncoder{buf: append([]byte{}, baseProofHeader...)}
+
+	e.array(baseProofComponents)
+	e.bytes(p.signature)
+	e.bytes(p.header)
+	e.bytes(p.publicKey)
+	e.bytes(p.hmacKey)
+	e.texts(p.mandatoryPointers)
+
+	return e.buf
+}
+
+func parseBaseProof(proofValue []byte) (*baseProof, error) {
+	if !isBaseProof(proofValue) {
+		return nil, errors.New("proof value is not a bbs-2023 base proof")
+	}
+
+	d := &cborDecoder{data: proofValue[len(baseProofHeader):]}
+
+	if err := checkComponents(d, baseProofComponents); err != nil {
+		return nil, fmt.Errorf("parse base proof: %w", err)
+	}
+
+	p := &baseProof{}
+
+	var err error
+
+	for _, b := range []*[]byte{&p.signature, &p.header, &p.publicKey, &p.hmacKey} {
+		if *b, err = d.bytes(); err != nil {
+			return nil, fmt.Errorf("parse base proof: %w", err)
+		}
+	}
+
+	if p.mandatoryPointers, err = d.texts(); err != nil {
+		return nil, fmt.Errorf("parse base proof: %w", err)
+	}
+
+	return p, nil
+}
+
+func (p *derivedProof) serialize() ([]byte, error) {
+	labelMap, err := compressLabelMap(p.labelMap)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &cborEncoder{buf: append([]byte{}, derivedProofHeader...)}
+
+	e.array(derivedProofComponents)
+	e.bytes(p.proof)
+	e.uintMap(labelMap)
+	e.uints(p.mandatoryIndexes)
+	e.uints(p.selectiveIndexes)
+	e.bytes(p.presentationHeader)
+
+	return e.buf, nil
+}
+
+func parseDerivedProof(proofValue []byte) (*derivedProof, error) {
+	if !isDerivedProof(proofValue) {
+		return nil, errors.New("proof value is not a bbs-2023 derived proof")
+	}
+
+	d := &cborDecoder{data: proofValue[len(derivedProofHeader):]}
+
+	if err := checkComponents(d, derivedProofComponents); err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	p := &derivedProof{}
+
+	var err error
+
+	if p.proof, err = d.bytes(); err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	labelMap, err := d.uintMap()
+	if err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	p.labelMap = decompressLabelMap(labelMap)
+
+	if p.mandatoryIndexes, err = d.uints(); err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	if p.selectiveIndexes, err = d.uints(); err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	if p.presentationHeader, err = d.bytes(); err != nil {
+		return nil, fmt.Errorf("parse derived proof: %w", err)
+	}
+
+	return p, nil
+}
+
+func checkComponents(d *cborDecoder, expected int) error {
+	n, err := d.array()
+	if err != nil {
+		return err
+	}
+
+	if n != expected {
+		return fmt.Errorf("expected %d components, got %d", expected, n)
+	}
+
+	return nil
+}
+
+// compressLabelMap turns the "c14nN" to "bM" labels into the N to M integers serialized in derived proofs.
+func compressLabelMap(labelMap map[string]string) (map[int]int, error) {
+	compressed := make(map[int]int, len(labelMap))
+
+	for k, v := range labelMap {
+		key, err := strconv.Atoi(strings.TrimPrefix(k, c14nLabelPrefix))
+		if err != nil || !strings.HasPrefix(k, c14nLabelPrefix) {
+			return nil, fmt.Errorf("invalid canonical blank node label: %s", k)
+		}
+
+		value, err := strconv.Atoi(strings.TrimPrefix(v, bbsLabelPrefix))
+		if err != nil || !strings.HasPrefix(v, bbsLabelPrefix) {
+			return nil, fmt.Errorf("invalid blank node label: %s", v)
+		}
+
+		compressed[key] = value
+	}
+
+	return compressed, nil
+}
+
+func decompressLabelMap(compressed map[int]int) map[string]string {
+	labelMap := make(map[string]string, len(compressed))
+
+	for k, v := range compressed {
+		labelMap[c14nLabelPrefix+strconv.Itoa(k)] = bbsLabelPrefix + strconv.Itoa(v)
+	}
+
+	return labelMap
+}
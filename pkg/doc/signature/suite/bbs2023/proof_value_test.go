@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofValue(t *testing.T) {
+	t.Run("base proof", func(t *testing.T) {
+		base := &baseProof{
+			signature:         bytes.Repeat([]byte{1}, 80),
+			header:            bytes.Repeat([]byte{2}, 64),
+			publicKey:         bytes.Repeat([]byte{3}, 96),
+			hmacKey:           bytes.Repeat([]byte{4}, 32),
+			mandatoryPointers: []string{"/issuer", "/credentialSubject/name"},
+		}
+
+		value := base.serialize()
+		require.Equal(t, []byte{0xd9, 0x5d, 0x02}, value[:3])
+
+		parsed, err := parseBaseProof(value)
+		require.NoError(t, err)
+		require.Equal(t, base, parsed)
+
+		_, err = parseBaseProof(value[:len(value)-1])
+		require.EqualError(t, err, "parse base proof: cbor: unexpected end of data")
+
+		_, err = parseDerivedProof(value)
+		require.EqualError(t, err, "proof value is not a bbs-2023 derived proof")
+	})
+
+	t.Run("derived proof", func(t *testing.T) {
+		derived := &derivedProof{
+			proof:              bytes.Repeat([]byte{1}, 336),
+			labelMap:           map[string]string{"c14n0": "b2", "c14n1": "b0", "c14n2": "b1"},
+			mandatoryIndexes:   []int{0, 1, 30},
+			selectiveIndexes:   []int{2, 300},
+			presentationHeader: []byte("presentation header"),
+		}
+
+		value, err := derived.serialize()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xd9, 0x5d, 0x03}, value[:3])
+
+		parsed, err := parseDerivedProof(value)
+		require.NoError(t, err)
+		require.Equal(t, derived, parsed)
+
+		_, err = parseBaseProof(value)
+		require.EqualError(t, err, "proof value is not a bbs-2023 base proof")
+
+		derived.labelMap = map[string]string{"_:c14n0": "b0"}
+
+		_, err = derived.serialize()
+		require.EqualError(t, err, "invalid canonical blank node label: _:c14n0")
+	})
+}
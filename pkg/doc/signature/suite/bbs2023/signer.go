@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+// PrivateKeySigner signs base proofs with a BLS12-381-SHA-256 private key.
+type PrivateKeySigner struct {
+	privKey []byte
+	pubKey  []byte
+}
+
+// NewPrivateKeySigner creates a new PrivateKeySigner from the private key in compressed form.
+func NewPrivateKeySigner(privKeyBytes []byte) (*PrivateKeySigner, error) {
+	privKey, err := bbs12381g2pub.UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	pubKey, err := privKey.PublicKey().Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key: %w", err)
+	}
+
+	return &PrivateKeySigner{privKey: privKeyBytes, pubKey: pubKey}, nil
+}
+
+// SignWithHeader signs the messages and binds the signature to the header.
+func (s *PrivateKeySigner) SignWithHeader(header []byte, messages [][]byte) ([]byte, error) {
+	return bbs12381g2pub.NewBLS12381SHA256().SignWithHeader(header, messages, s.privKey)
+}
+
+// PublicKey returns the public key of the signer in compressed form.
+func (s *PrivateKeySigner) PublicKey() ([]byte, error) {
+	return s.pubKey, nil
+}
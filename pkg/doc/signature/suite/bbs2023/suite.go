@@ -0,0 +1,423 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbs2023 implements the bbs-2023 cryptosuite of the Data Integrity BBS Cryptosuites specification
+// (https://www.w3.org/TR/vc-di-bbs/) used with DataIntegrityProof proofs.
+// The issuer creates a base proof signing the statements of the document with the BBS signature scheme
+// (BLS12-381-SHA-256 ciphersuite), the holder derives from it a proof disclosing only the selected statements.
+// It uses the RDF Dataset Canonicalization Algorithm to transform the document into statements and
+// SHA-256 [RFC6234] as the message digest algorithm.
+package bbs2023
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	// Cryptosuite is the bbs-2023 cryptosuite identifier.
+	Cryptosuite   = "bbs-2023"
+	rdfDataSetAlg = "URDNA2015"
+	hmacKeySize   = 32
+
+	mandatoryGroup = "mandatory"
+	selectiveGroup = "selective"
+	combinedGroup  = "combined"
+
+	jsonldProof      = "proof"
+	jsonldProofValue = "proofValue"
+)
+
+var errUseProofValue = errors.New("bbs-2023 proof values are not a signature of the verify data")
+
+// Signer creates the BBS signatures of base proofs.
+type Signer interface {
+	// SignWithHeader signs the messages and binds the signature to the header.
+	SignWithHeader(header []byte, messages [][]byte) ([]byte, error)
+
+	// PublicKey returns the BLS12-381 G2 public key of the signer in compressed form.
+	PublicKey() ([]byte, error)
+}
+
+// Suite implements bbs-2023 cryptosuite.
+type Suite struct {
+	signer            Signer
+	mandatoryPointers []string
+	jsonldProcessor   *jsonld.Processor
+}
+
+// Opt is the bbs-2023 Suite option.
+type Opt func(s *Suite)
+
+// WithSigner defines the signer of the base proofs.
+func WithSigner(signer Signer) Opt {
+	return func(s *Suite) {
+		s.signer = signer
+	}
+}
+
+// WithMandatoryPointers defines the JSON pointers of the statements the base proofs make mandatory to disclose.
+func WithMandatoryPointers(pointers ...string) Opt {
+	return func(s *Suite) {
+		s.mandatoryPointers = pointers
+	}
+}
+
+// New an instance of bbs-2023 cryptosuite.
+func New(opts ...Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// bbs-2023 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only bbs-2023 cryptosuite.
+func (s *Suite) Accept(t string) bool {
+	return t == Cryptosuite
+}
+
+// CompactProof indicates weather to compact the proof doc before canonization.
+func (s *Suite) CompactProof() bool {
+	return false
+}
+
+// Sign is not supported, the proof values are created by CreateProofValue.
+func (s *Suite) Sign([]byte) ([]byte, error) {
+	return nil, errUseProofValue
+}
+
+// Verify is not supported, the proof values are verified by VerifyProofValue.
+func (s *Suite) Verify(*verifier.PublicKey, []byte, []byte) error {
+	return errUseProofValue
+}
+
+// CreateProofValue creates the base proof value signing the document, without proof, and the proof configuration.
+func (s *Suite) CreateProofValue(doc map[string]interface{}, p *proof.Proof,
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	if s.signer == nil {
+		return nil, suite.ErrSignerNotDefined
+	}
+
+	proofHash, err := s.proofHash(doc, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey := make([]byte, hmacKeySize)
+
+	if _, err = rand.Read(hmacKey); err != nil {
+		return nil, fmt.Errorf("generate HMAC key: %w", err)
+	}
+
+	grouped, err := s.canonicalizeAndGroup(proof.GetCopyWithoutProof(doc), hmacLabels(hmacKey),
+		map[string][]string{mandatoryGroup: s.mandatoryPointers}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory := grouped.groups[mandatoryGroup]
+	header := append(proofHash, hashNQuads(mandatory.matching)...)
+
+	signature, err := s.signer.SignWithHeader(header, messages(mandatory.nonMatching))
+	if err != nil {
+		return nil, fmt.Errorf("sign base proof: %w", err)
+	}
+
+	publicKey, err := s.signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return (&baseProof{
+		signature:         signature,
+		header:            header,
+		publicKey:         publicKey,
+		hmacKey:           hmacKey,
+		mandatoryPointers: s.mandatoryPointers,
+	}).serialize(), nil
+}
+
+// VerifyProofValue verifies the base or derived proof value against the document and the proof configuration.
+func (s *Suite) VerifyProofValue(pubKey *verifier.PublicKey, doc map[string]interface{}, p *proof.Proof,
+	opts ...jsonld.ProcessorOpts) error {
+	proofHash, err := s.proofHash(doc, p, opts...)
+	if err != nil {
+		return err
+	}
+
+	docWithoutProof := proof.GetCopyWithoutProof(doc)
+
+	switch {
+	case isBaseProof(p.ProofValue):
+		return s.verifyBaseProof(pubKey, docWithoutProof, proofHash, p.ProofValue, opts...)
+	case isDerivedProof(p.ProofValue):
+		return s.verifyDerivedProof(pubKey, docWithoutProof, proofHash, p.ProofValue, opts...)
+	default:
+		return errors.New("proof value is not a bbs-2023 proof")
+	}
+}
+
+func (s *Suite) verifyBaseProof(pubKey *verifier.PublicKey, doc map[string]interface{}, proofHash,
+	proofValue []byte, opts ...jsonld.ProcessorOpts) error {
+	base, err := parseBaseProof(proofValue)
+	if err != nil {
+		return err
+	}
+
+	grouped, err := s.canonicalizeAndGroup(doc, hmacLabels(base.hmacKey),
+		map[string][]string{mandatoryGroup: base.mandatoryPointers}, opts...)
+	if err != nil {
+		return err
+	}
+
+	mandatory := grouped.groups[mandatoryGroup]
+	header := append(proofHash, hashNQuads(mandatory.matching)...)
+
+	err = bbs12381g2pub.NewBLS12381SHA256().VerifyWithHeader(header, messages(mandatory.nonMatching),
+		base.signature, pubKey.Value)
+	if err != nil {
+		return fmt.Errorf("verify base proof: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Suite) verifyDerivedProof(pubKey *verifier.PublicKey, doc map[string]interface{}, proofHash,
+	proofValue []byte, opts ...jsonld.ProcessorOpts) error {
+	derived, err := parseDerivedProof(proofValue)
+	if err != nil {
+		return err
+	}
+
+	nquads, err := s.labelReplacementCanonicalizeJSONLD(doc, mappedLabels(derived.labelMap), opts...)
+	if err != nil {
+		return err
+	}
+
+	mandatory := make(map[int]string, len(derived.mandatoryIndexes))
+
+	var nonMandatory [][]byte
+
+	for i, nq := range nquads {
+		if containsIndex(derived.mandatoryIndexes, i) {
+			mandatory[i] = nq
+		} else {
+			nonMandatory = append(nonMandatory, []byte(nq))
+		}
+	}
+
+	if len(mandatory) != len(derived.mandatoryIndexes) {
+		return errors.New("verify derived proof: mandatory indexes do not match the document")
+	}
+
+	undisclosed, err := bbs12381g2pub.ProofUndisclosedCount(derived.proof)
+	if err != nil {
+		return fmt.Errorf("verify derived proof: %w", err)
+	}
+
+	header := append(proofHash, hashNQuads(mandatory)...)
+
+	err = bbs12381g2pub.NewBLS12381SHA256().VerifyProofWithHeader(header, derived.presentationHeader, nonMandatory,
+		derived.proof, pubKey.Value, len(nonMandatory)+undisclosed, derived.selectiveIndexes)
+	if err != nil {
+		return fmt.Errorf("verify derived proof: %w", err)
+	}
+
+	return nil
+}
+
+// Derive creates the document disclosing the statements selected by the JSON pointers, along with the mandatory
+// ones, with a derived proof of the base proof of doc. The presentation header is bound to the derived proof.
+func (s *Suite) Derive(doc map[string]interface{}, selectivePointers []string, presentationHeader []byte,
+	opts ...jsonld.ProcessorOpts) (map[string]interface{}, error) {
+	p, err := getBaseProof(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := parseBaseProof(p.ProofValue)
+	if err != nil {
+		return nil, err
+	}
+
+	docWithoutProof := proof.GetCopyWithoutProof(doc)
+	combinedPointers := append(append([]string{}, base.mandatoryPointers...), selectivePointers...)
+
+	grouped, err := s.canonicalizeAndGroup(docWithoutProof, hmacLabels(base.hmacKey), map[string][]string{
+		mandatoryGroup: base.mandatoryPointers,
+		selectiveGroup: selectivePointers,
+		combinedGroup:  combinedPointers,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory, selective, combined := grouped.groups[mandatoryGroup], grouped.groups[selectiveGroup],
+		grouped.groups[combinedGroup]
+
+	// the indexes of the mandatory statements among the disclosed ones,
+	// and of the selected statements among the signed ones, the non-mandatory statements.
+	mandatoryIndexes := relativeIndexes(sortedIndexes(combined.matching), mandatory.matching)
+	selectiveIndexes := relativeIndexes(sortedIndexes(mandatory.nonMatching), selective.matching)
+
+	bbsProof, err := bbs12381g2pub.NewBLS12381SHA256().DeriveProofWithHeader(base.header, presentationHeader,
+		messages(mandatory.nonMatching), base.signature, base.publicKey, selectiveIndexes)
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: %w", err)
+	}
+
+	labelMap, err := s.verifierLabelMap(combined.matching)
+	if err != nil {
+		return nil, err
+	}
+
+	revealDoc, err := selectJSONLD(combinedPointers, docWithoutProof)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ProofValue, err = (&derivedProof{
+		proof:              bbsProof,
+		labelMap:           labelMap,
+		mandatoryIndexes:   mandatoryIndexes,
+		selectiveIndexes:   selectiveIndexes,
+		presentationHeader: presentationHeader,
+	}).serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	revealDoc[jsonldProof] = p.JSONLdObject()
+
+	return revealDoc, nil
+}
+
+// verifierLabelMap maps the canonical labels of the reveal document to the labels of the base proof. The
+// canonical labels don't depend on the labels of the input, so the disclosed statements are canonicalized as is.
+func (s *Suite) verifierLabelMap(disclosed map[int]string) (map[string]string, error) {
+	_, canonicalIDs, err := s.jsonldProcessor.CanonicalizeNQuads(strings.Join(messagesOf(disclosed), ""))
+	if err != nil {
+		return nil, err
+	}
+
+	verifierLabels := make(map[string]string, len(canonicalIDs))
+	for label, c14nLabel := range canonicalIDs {
+		verifierLabels[c14nLabel] = label
+	}
+
+	return verifierLabels, nil
+}
+
+func (s *Suite) proofHash(doc map[string]interface{}, p *proof.Proof, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	proofConfig := p.JSONLdObject()
+	delete(proofConfig, jsonldProofValue)
+
+	return proof.CreateProofConfigHash(s, doc, proofConfig, opts...)
+}
+
+func getBaseProof(doc map[string]interface{}) (*proof.Proof, error) {
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range proofs {
+		if p.SuiteType() == Cryptosuite && isBaseProof(p.ProofValue) {
+			return p, nil
+		}
+	}
+
+	return nil, errors.New("no bbs-2023 base proof present")
+}
+
+func hashNQuads(nquads map[int]string) []byte {
+	digest := sha256.Sum256(bytes.Join(messages(nquads), nil))
+
+	return digest[:]
+}
+
+// messages returns the N-Quads in the order of their indexes.
+func messages(nquads map[int]string) [][]byte {
+	ordered := messagesOf(nquads)
+	msgs := make([][]byte, len(ordered))
+
+	for i, nq := range ordered {
+		msgs[i] = []byte(nq)
+	}
+
+	return msgs
+}
+
+func messagesOf(nquads map[int]string) []string {
+	indexes := sortedIndexes(nquads)
+	ordered := make([]string, len(indexes))
+
+	for i, index := range indexes {
+		ordered[i] = nquads[index]
+	}
+
+	return ordered
+}
+
+func sortedIndexes(nquads map[int]string) []int {
+	indexes := make([]int, 0, len(nquads))
+	for i := range nquads {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes
+}
+
+// relativeIndexes returns the positions, within indexes, of the indexes of the N-Quads.
+func relativeIndexes(indexes []int, nquads map[int]string) []int {
+	var relative []int
+
+	for i, index := range indexes {
+		if _, ok := nquads[index]; ok {
+			relative = append(relative, i)
+		}
+	}
+
+	return relative
+}
+
+func containsIndex(indexes []int, index int) bool {
+	for _, i := range indexes {
+		if i == index {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs2023_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbs2023"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+)
+
+//nolint:lll
+const (
+	// key pair of the BLS12-381-SHA-256 fixtures of the IETF BBS Signatures draft.
+	ietfSecretKeyHex = "60e55110f76883a13d030b2f6bd11883422d5abde717569fc0731f51237169fc"
+	ietfPublicKeyHex = "a820f230f6ae38503b86c70dc50b61c58a77e45c39ab25c0652bbaa8fa136f2851bd4781c9dcde39fc9d1d52c9e60268061e7d7632171d91aa8d460acee0e96f1e7c4cfb12d3ff9ab5d5dc91c277db75c845d649ef3c4f63aebc364cd55ded0c"
+
+	// credential of the bbs-2023 examples of the Data Integrity BBS Cryptosuites specification.
+	windsurfCredential = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2",
+    {"@vocab": "https://windsurf.grotto-networking.com/selective#"}
+  ],
+  "type": ["VerifiableCredential"],
+  "issuer": "https://vc.example/windsurf/racecommittee",
+  "credentialSubject": {
+    "sailNumber": "Earth101",
+    "sails": [
+      {"size": 5.5, "sailName": "Kihei", "year": 2023},
+      {"size": 6.1, "sailName": "Lahaina", "year": 2023},
+      {"size": 7.0, "sailName": "Lahaina", "year": 2020},
+      {"size": 7.8, "sailName": "Lahaina", "year": 2023}
+    ],
+    "boards": [
+      {"boardName": "CompFoil170", "brand": "Wailea", "year": 2022},
+      {"boardName": "Kanaha Custom", "brand": "Wailea", "year": 2019}
+    ]
+  }
+}`
+)
+
+//nolint:gochecknoglobals
+var (
+	mandatoryPointers = []string{
+		"/issuer", "/credentialSubject/sailNumber", "/credentialSubject/sails/1",
+		"/credentialSubject/boards/0/year", "/credentialSubject/sails/2",
+	}
+	selectivePointers = []string{"/credentialSubject/boards/0", "/credentialSubject/boards/1"}
+)
+
+func TestSuite_BaseAndDerivedProofs(t *testing.T) {
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	signer, err := bbs2023.NewPrivateKeySigner(decodeHex(t, ietfSecretKeyHex))
+	require.NoError(t, err)
+
+	pubKey := decodeHex(t, ietfPublicKeyHex)
+	pubKeyFetcher := verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, "Bls12381G2Key2020"))
+
+	vc, err := verifiable.ParseCredential([]byte(windsurfCredential), verifiable.WithJSONLDDocumentLoader(loader),
+		verifiable.WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "DataIntegrityProof",
+		Cryptosuite:             bbs2023.Cryptosuite,
+		Suite:                   bbs2023.New(bbs2023.WithSigner(signer), bbs2023.WithMandatoryPointers(mandatoryPointers...)),
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		VerificationMethod:      "did:example:issuer#bbs-key",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, "DataIntegrityProof", vc.Proofs[0]["type"])
+	require.Equal(t, "bbs-2023", vc.Proofs[0]["cryptosuite"])
+	requireProofValueHeader(t, vc.Proofs[0], 0x02)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	t.Run("verify base proof", func(t *testing.T) {
+		_, err := verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader), pubKeyFetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("verify base proof of modified credential", func(t *testing.T) {
+		modified := strings.Replace(string(vcBytes), "Kanaha Custom", "Kanaha", 1)
+
+		_, err := verifiable.ParseCredential([]byte(modified), verifiable.WithJSONLDDocumentLoader(loader),
+			pubKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify base proof")
+	})
+
+	presentationHeader := []byte("presentation header")
+
+	derived, err := vc.GenerateBBS2023SelectiveDisclosure(selectivePointers, presentationHeader,
+		verifiable.WithJSONLDDocumentLoader(loader))
+	require.NoError(t, err)
+
+	require.Len(t, derived.Proofs, 1)
+	requireProofValueHeader(t, derived.Proofs[0], 0x03)
+
+	derivedBytes, err := json.Marshal(derived)
+	require.NoError(t, err)
+
+	t.Run("derived credential discloses the mandatory and selected statements only", func(t *testing.T) {
+		var subject map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(derivedBytes, &struct {
+			Subject *map[string]interface{} `json:"credentialSubject"`
+		}{Subject: &subject}))
+
+		require.Equal(t, "Earth101", subject["sailNumber"])
+		require.Len(t, subject["sails"], 2)
+		require.Len(t, subject["boards"], 2)
+		require.Contains(t, string(derivedBytes), "Kanaha Custom")
+		require.NotContains(t, string(derivedBytes), "Kihei")
+	})
+
+	t.Run("verify derived proof", func(t *testing.T) {
+		_, err := verifiable.ParseCredential(derivedBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			pubKeyFetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("verify derived proof with another key", func(t *testing.T) {
+		otherPubKey, _, err := bbs12381g2pub.GenerateKeyPairBLS12381SHA256(make([]byte, 32), nil)
+		require.NoError(t, err)
+
+		otherPubKeyBytes, err := otherPubKey.Marshal()
+		require.NoError(t, err)
+
+		_, err = verifiable.ParseCredential(derivedBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(otherPubKeyBytes, "Bls12381G2Key2020")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify derived proof")
+	})
+
+	t.Run("verify derived proof of modified credential", func(t *testing.T) {
+		modified := strings.Replace(string(derivedBytes), "Kanaha Custom", "Kanaha", 1)
+
+		_, err := verifiable.ParseCredential([]byte(modified), verifiable.WithJSONLDDocumentLoader(loader),
+			pubKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify derived proof")
+	})
+
+	t.Run("derive with invalid pointer", func(t *testing.T) {
+		_, err := vc.GenerateBBS2023SelectiveDisclosure([]string{"/credentialSubject/kites"}, presentationHeader,
+			verifiable.WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no value matches the pointer")
+	})
+
+	t.Run("derive from derived credential", func(t *testing.T) {
+		_, err := derived.GenerateBBS2023SelectiveDisclosure(selectivePointers, presentationHeader,
+			verifiable.WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no bbs-2023 base proof present")
+	})
+}
+
+func TestSuite_SignerNotDefined(t *testing.T) {
+	_, err := bbs2023.New().CreateProofValue(map[string]interface{}{}, nil)
+	require.EqualError(t, err, "signer is not defined")
+}
+
+func requireProofValueHeader(t *testing.T, p verifiable.Proof, header byte) {
+	t.Helper()
+
+	proofValue, ok := p["proofValue"].(string)
+	require.True(t, ok)
+
+	encoding, value, err := multibase.Decode(proofValue)
+	require.NoError(t, err)
+	require.Equal(t, multibase.Encoding(multibase.Base64url), encoding)
+	require.Equal(t, []byte{0xd9, 0x5d, header}, value[:3])
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
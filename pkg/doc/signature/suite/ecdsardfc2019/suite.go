@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsardfc2019 implements the ecdsa-rdfc-2019 cryptosuite of the Data Integrity ECDSA Cryptosuites
+// specification (https://www.w3.org/TR/vc-di-ecdsa/) used with DataIntegrityProof proofs.
+// It uses the RDF Dataset Canonicalization Algorithm to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and ECDSA P-256 as the signature algorithm,
+// or SHA-384 and ECDSA P-384 respectively.
+package ecdsardfc2019
+
+import (
+	"crypto"
+	_ "crypto/sha256" // register SHA-256
+	_ "crypto/sha512" // register SHA-384
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements ecdsa-rdfc-2019 cryptosuite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	hash            crypto.Hash
+}
+
+const (
+	// Cryptosuite is the ecdsa-rdfc-2019 cryptosuite identifier.
+	Cryptosuite   = "ecdsa-rdfc-2019"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of ecdsa-rdfc-2019 cryptosuite for P-256 keys.
+func New(opts ...suite.Opt) *Suite {
+	return newSuite(crypto.SHA256, opts...)
+}
+
+// NewP384 an instance of ecdsa-rdfc-2019 cryptosuite for P-384 keys.
+func NewP384(opts ...suite.Opt) *Suite {
+	return newSuite(crypto.SHA384, opts...)
+}
+
+func newSuite(hash crypto.Hash, opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg), hash: hash}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// ecdsa-rdfc-2019 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	h := s.hash.New()
+	h.Write(doc) //nolint:errcheck,gosec // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
+
+// Accept will accept only ecdsa-rdfc-2019 cryptosuite.
+func (s *Suite) Accept(t string) bool {
+	return t == Cryptosuite
+}
@@ -0,0 +1,260 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsardfc2019_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+)
+
+func TestSuite_DataIntegrityProof(t *testing.T) {
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		curve elliptic.Curve
+		hash  crypto.Hash
+		suite func(opts ...suite.Opt) *ecdsardfc2019.Suite
+	}{
+		{
+			name:  "P-256",
+			curve: elliptic.P256(),
+			hash:  crypto.SHA256,
+			suite: ecdsardfc2019.New,
+		},
+		{
+			name:  "P-384",
+			curve: elliptic.P384(),
+			hash:  crypto.SHA384,
+			suite: ecdsardfc2019.NewP384,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			privKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+			require.NoError(t, err)
+
+			vc := newCredential()
+
+			err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+				SignatureType:           "DataIntegrityProof",
+				Cryptosuite:             ecdsardfc2019.Cryptosuite,
+				Suite:                   tc.suite(suite.WithSigner(&ecdsaSigner{privKey: privKey, hash: tc.hash})),
+				SignatureRepresentation: verifiable.SignatureProofValue,
+				VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+			}, jsonld.WithDocumentLoader(loader))
+			require.NoError(t, err)
+
+			require.Len(t, vc.Proofs, 1)
+			require.Equal(t, "ecdsa-rdfc-2019", vc.Proofs[0]["cryptosuite"])
+
+			vcBytes, err := json.Marshal(vc)
+			require.NoError(t, err)
+
+			t.Run("uncompressed public key", func(t *testing.T) {
+				pubKeyBytes := elliptic.Marshal(tc.curve, privKey.X, privKey.Y) //nolint:staticcheck
+
+				_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+					verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, "Multikey")))
+				require.NoError(t, err)
+			})
+
+			t.Run("compressed public key", func(t *testing.T) {
+				pubKeyBytes := elliptic.MarshalCompressed(tc.curve, privKey.X, privKey.Y)
+
+				_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+					verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, "Multikey")))
+				require.NoError(t, err)
+			})
+
+			t.Run("another public key", func(t *testing.T) {
+				otherKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+				require.NoError(t, err)
+
+				pubKeyBytes := elliptic.MarshalCompressed(tc.curve, otherKey.X, otherKey.Y)
+
+				_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+					verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, "Multikey")))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "ecdsa: invalid signature")
+			})
+		})
+	}
+}
+
+// Test vector of the ecdsa-rdfc-2019 cryptosuite with a P-256 key
+// (https://www.w3.org/TR/vc-di-ecdsa/#representation-ecdsa-rdfc-2019-with-curve-p-256).
+const (
+	vectorSecretKeyMultibase = "z42twTcNeSYcnqg1FLuSFs2bsGH3ZqbRHFmvS9XMsYhjxvHN"
+	vectorPublicKeyMultibase = "zDnaepBuvsQ8cpsWrVKw8fbpGpvPeNSjVPTWoq6cRqaYzBKVP"
+	vectorVerificationMethod = "did:key:" + vectorPublicKeyMultibase + "#" + vectorPublicKeyMultibase
+	vectorCreated            = "2023-02-24T23:36:38Z"
+	vectorProofConfigHash    = "3a8a522f689025727fb9d1f0fa99a618da023e8494ac74f51015d009d35abc2e"
+	vectorDocumentHash       = "517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+	vectorCredential         = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2",
+    "https://www.w3.org/ns/credentials/examples/v2"
+  ],
+  "id": "urn:uuid:58172aac-d8ba-11ed-83dd-0b3aef56cc33",
+  "type": ["VerifiableCredential", "AlumniCredential"],
+  "name": "Alumni Credential",
+  "description": "A minimum viable example of an Alumni Credential.",
+  "issuer": "https://vc.example/issuers/5678",
+  "validFrom": "2023-01-01T00:00:00Z",
+  "credentialSubject": {
+    "id": "did:example:abcdefgh",
+    "alumniOf": "The School of Examples"
+  }
+}`
+)
+
+func TestSuite_TestVector(t *testing.T) {
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	pubKeyBytes := decodeMultikey(t, vectorPublicKeyMultibase)
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubKeyBytes)
+	require.NotNil(t, x)
+
+	privKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(decodeMultikey(t, vectorSecretKeyMultibase)),
+	}
+
+	t.Run("hashes of the proof configuration and document", func(t *testing.T) {
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(vectorCredential), &doc))
+
+		verifyData, err := proof.CreateVerifyHash(ecdsardfc2019.New(), doc, map[string]interface{}{
+			"type":               "DataIntegrityProof",
+			"cryptosuite":        "ecdsa-rdfc-2019",
+			"created":            vectorCreated,
+			"verificationMethod": vectorVerificationMethod,
+			"proofPurpose":       "assertionMethod",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+		require.Equal(t, vectorProofConfigHash+vectorDocumentHash, hex.EncodeToString(verifyData))
+	})
+
+	t.Run("add and verify proof", func(t *testing.T) {
+		vc, err := verifiable.ParseCredential([]byte(vectorCredential), verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		created, err := time.Parse(time.RFC3339, vectorCreated)
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			Cryptosuite:             ecdsardfc2019.Cryptosuite,
+			Suite:                   ecdsardfc2019.New(suite.WithSigner(&ecdsaSigner{privKey: privKey, hash: crypto.SHA256})),
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Created:                 &created,
+			VerificationMethod:      vectorVerificationMethod,
+			Purpose:                 "assertionMethod",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKeyBytes, "Multikey")))
+		require.NoError(t, err)
+	})
+}
+
+func TestSuite_GetDigest(t *testing.T) {
+	digest256 := sha256.Sum256([]byte("test doc"))
+	require.Equal(t, digest256[:], ecdsardfc2019.New().GetDigest([]byte("test doc")))
+
+	digest384 := sha512.Sum384([]byte("test doc"))
+	require.Equal(t, digest384[:], ecdsardfc2019.NewP384().GetDigest([]byte("test doc")))
+}
+
+func TestSuite_Accept(t *testing.T) {
+	s := ecdsardfc2019.New()
+
+	require.True(t, s.Accept("ecdsa-rdfc-2019"))
+	require.False(t, s.Accept("eddsa-rdfc-2022"))
+	require.False(t, s.Accept("DataIntegrityProof"))
+}
+
+func newCredential() *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://w3id.org/security/data-integrity/v1",
+		},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+	}
+}
+
+// ecdsaSigner creates IEEE P1363 ECDSA signatures.
+type ecdsaSigner struct {
+	privKey *ecdsa.PrivateKey
+	hash    crypto.Hash
+}
+
+func (s *ecdsaSigner) Sign(data []byte) ([]byte, error) {
+	h := s.hash.New()
+	h.Write(data) //nolint:errcheck,gosec
+
+	r, ss, err := ecdsa.Sign(rand.Reader, s.privKey, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	keySize := (s.privKey.Curve.Params().BitSize + 7) / 8 //nolint:gomnd
+
+	sig := make([]byte, 2*keySize)
+	r.FillBytes(sig[:keySize])
+	ss.FillBytes(sig[keySize:])
+
+	return sig, nil
+}
+
+// decodeMultikey returns the raw key of a multibase encoded Multikey, prefixed with a two bytes multicodec header.
+func decodeMultikey(t *testing.T, key string) []byte {
+	t.Helper()
+
+	_, value, err := multibase.Decode(key)
+	require.NoError(t, err)
+
+	return value[2:]
+}
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eddsardfc2022 implements the eddsa-rdfc-2022 cryptosuite of the Data Integrity EdDSA Cryptosuites
+// specification (https://www.w3.org/TR/vc-di-eddsa/) used with DataIntegrityProof proofs.
+// It uses the RDF Dataset Canonicalization Algorithm to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and
+// Ed25519 [ED25519] as the signature algorithm.
+package eddsardfc2022
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements eddsa-rdfc-2022 cryptosuite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// Cryptosuite is the eddsa-rdfc-2022 cryptosuite identifier.
+	Cryptosuite   = "eddsa-rdfc-2022"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of eddsa-rdfc-2022 cryptosuite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// eddsa-rdfc-2022 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only eddsa-rdfc-2022 cryptosuite.
+func (s *Suite) Accept(t string) bool {
+	return t == Cryptosuite
+}
@@ -0,0 +1,225 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsardfc2022_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+)
+
+func TestSuite_DataIntegrityProof(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	vc := &verifiable.Credential{
+		Context: []string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://w3id.org/security/data-integrity/v1",
+		},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+	}
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "DataIntegrityProof",
+		Cryptosuite:             eddsardfc2022.Cryptosuite,
+		Suite:                   eddsardfc2022.New(suite.WithSigner(&ed25519Signer{privKey: privKey})),
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, "DataIntegrityProof", vc.Proofs[0]["type"])
+	require.Equal(t, "eddsa-rdfc-2022", vc.Proofs[0]["cryptosuite"])
+	require.True(t, strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	t.Run("verify proof", func(t *testing.T) {
+		_, err := verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, "Ed25519VerificationKey2020")))
+		require.NoError(t, err)
+	})
+
+	t.Run("verify proof with another key", func(t *testing.T) {
+		otherPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(otherPubKey, "Ed25519VerificationKey2020")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+	})
+
+	t.Run("verify modified credential", func(t *testing.T) {
+		modified := strings.Replace(string(vcBytes), "http://example.edu/credentials/1872",
+			"http://example.edu/credentials/1873", 1)
+
+		_, err := verifiable.ParseCredential([]byte(modified), verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, "Ed25519VerificationKey2020")))
+		require.Error(t, err)
+	})
+
+	t.Run("cryptosuite is missing", func(t *testing.T) {
+		err := vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			Suite:                   eddsardfc2022.New(suite.WithSigner(&ed25519Signer{privKey: privKey})),
+			SignatureRepresentation: verifiable.SignatureProofValue,
+		}, jsonld.WithDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cryptosuite is missing")
+	})
+}
+
+// Test vector of the eddsa-rdfc-2022 cryptosuite (https://www.w3.org/TR/vc-di-eddsa/#representation-eddsa-rdfc-2022).
+const (
+	vectorSecretKeyMultibase = "z3u2en7t5LR2WtQH5PfFqMqwVHBeXouLzo6haApm8XHqvjxq"
+	vectorPublicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+	vectorVerificationMethod = "did:key:" + vectorPublicKeyMultibase + "#" + vectorPublicKeyMultibase
+	vectorCreated            = "2023-02-24T23:36:38Z"
+	vectorProofConfigHash    = "bea7b7acfbad0126b135104024a5f1733e705108f42d59668b05c0c50004c6b0"
+	vectorDocumentHash       = "517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+	vectorProofValue         = "z2YwC8z3ap7yx1nZYCg4L3j3ApHsF8kgPdSb5xoS1VR7vPG3F561B52hYnQF9iseabecm3ijx4K1FBTQsCZahKZme"
+	vectorCredential         = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2",
+    "https://www.w3.org/ns/credentials/examples/v2"
+  ],
+  "id": "urn:uuid:58172aac-d8ba-11ed-83dd-0b3aef56cc33",
+  "type": ["VerifiableCredential", "AlumniCredential"],
+  "name": "Alumni Credential",
+  "description": "A minimum viable example of an Alumni Credential.",
+  "issuer": "https://vc.example/issuers/5678",
+  "validFrom": "2023-01-01T00:00:00Z",
+  "credentialSubject": {
+    "id": "did:example:abcdefgh",
+    "alumniOf": "The School of Examples"
+  }
+}`
+)
+
+func TestSuite_TestVector(t *testing.T) {
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	privKey := ed25519.NewKeyFromSeed(decodeMultikey(t, vectorSecretKeyMultibase))
+	pubKey := ed25519.PublicKey(decodeMultikey(t, vectorPublicKeyMultibase))
+	require.Equal(t, pubKey, privKey.Public())
+
+	t.Run("hashes of the proof configuration and document", func(t *testing.T) {
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(vectorCredential), &doc))
+
+		verifyData, err := proof.CreateVerifyHash(eddsardfc2022.New(), doc, map[string]interface{}{
+			"type":               "DataIntegrityProof",
+			"cryptosuite":        "eddsa-rdfc-2022",
+			"created":            vectorCreated,
+			"verificationMethod": vectorVerificationMethod,
+			"proofPurpose":       "assertionMethod",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+		require.Equal(t, vectorProofConfigHash+vectorDocumentHash, hex.EncodeToString(verifyData))
+	})
+
+	t.Run("add proof", func(t *testing.T) {
+		vc, err := verifiable.ParseCredential([]byte(vectorCredential), verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		created, err := time.Parse(time.RFC3339, vectorCreated)
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			Cryptosuite:             eddsardfc2022.Cryptosuite,
+			Suite:                   eddsardfc2022.New(suite.WithSigner(&ed25519Signer{privKey: privKey})),
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Created:                 &created,
+			VerificationMethod:      vectorVerificationMethod,
+			Purpose:                 "assertionMethod",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, vectorProofValue, vc.Proofs[0]["proofValue"])
+	})
+
+	t.Run("verify proof", func(t *testing.T) {
+		securedCredential := strings.TrimSuffix(vectorCredential, "}") + `,
+  "proof": {
+    "type": "DataIntegrityProof",
+    "cryptosuite": "eddsa-rdfc-2022",
+    "created": "` + vectorCreated + `",
+    "verificationMethod": "` + vectorVerificationMethod + `",
+    "proofPurpose": "assertionMethod",
+    "proofValue": "` + vectorProofValue + `"
+  }
+}`
+
+		_, err := verifiable.ParseCredential([]byte(securedCredential), verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, "Ed25519VerificationKey2020")))
+		require.NoError(t, err)
+	})
+}
+
+func TestSuite_GetDigest(t *testing.T) {
+	digest := sha256.Sum256([]byte("test doc"))
+
+	require.Equal(t, digest[:], eddsardfc2022.New().GetDigest([]byte("test doc")))
+}
+
+func TestSuite_Accept(t *testing.T) {
+	s := eddsardfc2022.New()
+
+	require.True(t, s.Accept("eddsa-rdfc-2022"))
+	require.False(t, s.Accept("DataIntegrityProof"))
+	require.False(t, s.Accept("Ed25519Signature2018"))
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+// decodeMultikey returns the raw key of a multibase encoded Multikey, prefixed with a two bytes multicodec header.
+func decodeMultikey(t *testing.T, key string) []byte {
+	t.Helper()
+
+	_, value, err := multibase.Decode(key)
+	require.NoError(t, err)
+
+	return value[2:]
+}
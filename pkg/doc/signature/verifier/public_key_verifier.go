@@ -284,6 +284,11 @@ func (sv *ECDSASignatureVerifier) createJWK(pubKeyBytes []byte) (*jose.JWK, erro
 	curve := sv.ec.curve
 
 	x, y := elliptic.Unmarshal(curve, pubKeyBytes)
+	if x == nil {
+		// public keys of Multikey verification methods are compressed
+		x, y = elliptic.UnmarshalCompressed(curve, pubKeyBytes)
+	}
+
 	if x == nil {
 		return nil, errors.New("invalid public key")
 	}
@@ -34,6 +34,13 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// proofValueVerifier is implemented by the cryptosuites verifying the proof value against the document and
+// the proof configuration themselves, like bbs-2023 whose proof value carries more than a signature.
+type proofValueVerifier interface {
+	VerifyProofValue(pubKey *PublicKey, doc map[string]interface{}, p *proof.Proof,
+		opts ...jsonld.ProcessorOpts) error
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,11 +103,20 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p.SuiteType())
 		if err != nil {
 			return err
 		}
 
+		if v, ok := suite.(proofValueVerifier); ok {
+			err = v.VerifyProofValue(publicKey, jsonLdObject, p, opts...)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
 		if err != nil {
 			return err
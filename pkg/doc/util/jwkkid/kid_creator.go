@@ -53,7 +53,7 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return ed25519KID, nil
-	case kms.BLS12381G2Type: // BBS+ as JWK thumbprint.
+	case kms.BLS12381G2Type, kms.BLS12381G2SHA256Type: // BBS+ as JWK thumbprint.
 		bbsKID, err := createBLS12381G2KID(keyBytes)
 		if err != nil {
 			return "", fmt.Errorf("createKID: %w", err)
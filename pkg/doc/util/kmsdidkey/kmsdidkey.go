@@ -22,6 +22,7 @@ var keyTypeCodecs = map[kms.KeyType]uint64{
 	// signing keys
 	kms.ED25519:                fingerprint.ED25519PubKeyMultiCodec,
 	kms.BLS12381G2Type:         fingerprint.BLS12381g2PubKeyMultiCodec,
+	kms.BLS12381G2SHA256Type:   fingerprint.BLS12381g2PubKeyMultiCodec,
 	kms.ECDSAP256TypeIEEEP1363: fingerprint.P256PubKeyMultiCodec,
 	kms.ECDSAP256TypeDER:       fingerprint.P256PubKeyMultiCodec,
 	kms.ECDSAP384TypeIEEEP1363: fingerprint.P384PubKeyMultiCodec,
@@ -222,18 +222,32 @@ const DefaultSchema = `{
 }
 `
 
-// https://www.w3.org/TR/vc-data-model/#data-schemas
-const jsonSchema2018Type = "JsonSchemaValidator2018"
+const (
+	// https://www.w3.org/TR/vc-data-model/#data-schemas
+	jsonSchema2018Type = "JsonSchemaValidator2018"
+
+	// https://www.w3.org/TR/vc-json-schema/#jsonschema
+	jsonSchemaType = "JsonSchema"
+
+	// https://www.w3.org/TR/vc-json-schema/#jsonschemacredential
+	jsonSchemaCredentialType = "JsonSchemaCredential"
+)
 
 const (
 	// https://www.w3.org/TR/vc-data-model/#base-context
 	baseContext = "https://www.w3.org/2018/credentials/v1"
 
+	// https://www.w3.org/TR/vc-data-model-2.0/#base-context
+	baseContextV2 = "https://www.w3.org/ns/credentials/v2"
+
 	// https://www.w3.org/TR/vc-data-model/#types
 	vcType = "VerifiableCredential"
 
 	// https://www.w3.org/TR/vc-data-model/#presentations-0
 	vpType = "VerifiablePresentation"
+
+	// https://www.w3.org/TR/vc-data-model-2.0/#enveloped-verifiable-credentials
+	envelopedVCType = "EnvelopedVerifiableCredential"
 )
 
 // vcModelValidationMode defines constraint put on context and type of VC.
@@ -318,7 +332,8 @@ func (b *CredentialSchemaLoaderBuilder) SetSchemaDownloadClient(client *http.Cli
 	return b
 }
 
-// SetCache defines SchemaCache.
+// SetCache defines SchemaCache. Use jsonld.NewSchemaCache() to share the underlying storage of the JSON-LD
+// document loader, where the cached schemas are kept apart from the context documents.
 func (b *CredentialSchemaLoaderBuilder) SetCache(cache SchemaCache) *CredentialSchemaLoaderBuilder {
 	b.loader.cache = cache
 	return b
@@ -492,18 +507,30 @@ func (s *Subject) UnmarshalJSON(bytes []byte) error {
 }
 
 // Credential Verifiable Credential definition.
+// Both the Verifiable Credentials Data Model 1.1 and 2.0 are supported, the version being defined by the first
+// context (https://www.w3.org/2018/credentials/v1 or https://www.w3.org/ns/credentials/v2).
 type Credential struct {
 	Context       []string
 	CustomContext []interface{}
 	ID            string
 	Types         []string
 	// Subject can be a string, map, slice of maps, struct (Subject or any custom), slice of structs.
-	Subject        interface{}
-	Issuer         Issuer
-	Issued         *util.TimeWithTrailingZeroMsec
-	Expired        *util.TimeWithTrailingZeroMsec
-	Proofs         []Proof
+	Subject interface{}
+	Issuer  Issuer
+	// Issued and Expired are the issuanceDate and expirationDate of Verifiable Credentials Data Model 1.1.
+	Issued  *util.TimeWithTrailingZeroMsec
+	Expired *util.TimeWithTrailingZeroMsec
+	// ValidFrom and ValidUntil are the validFrom and validUntil of Verifiable Credentials Data Model 2.0.
+	ValidFrom  *util.TimeWithTrailingZeroMsec
+	ValidUntil *util.TimeWithTrailingZeroMsec
+	// Name and Description are plain string values, language-tagged values are kept in CustomFields.
+	Name        string
+	Description string
+	Proofs      []Proof
+	// Status is the first credential status. Statuses has all of them when several are defined,
+	// it takes precedence over Status on serialization when not empty.
 	Status         *TypedID
+	Statuses       []TypedID
 	Schemas        []TypedID
 	Evidence       Evidence
 	TermsOfUse     []TypedID
@@ -520,8 +547,12 @@ type rawCredential struct {
 	Subject        json.RawMessage                `json:"credentialSubject,omitempty"`
 	Issued         *util.TimeWithTrailingZeroMsec `json:"issuanceDate,omitempty"`
 	Expired        *util.TimeWithTrailingZeroMsec `json:"expirationDate,omitempty"`
+	ValidFrom      *util.TimeWithTrailingZeroMsec `json:"validFrom,omitempty"`
+	ValidUntil     *util.TimeWithTrailingZeroMsec `json:"validUntil,omitempty"`
+	Name           interface{}                    `json:"name,omitempty"`
+	Description    interface{}                    `json:"description,omitempty"`
 	Proof          json.RawMessage                `json:"proof,omitempty"`
-	Status         *TypedID                       `json:"credentialStatus,omitempty"`
+	Status         json.RawMessage                `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage                `json:"issuer,omitempty"`
 	Schema         interface{}                    `json:"credentialSchema,omitempty"`
 	Evidence       Evidence                       `json:"evidence,omitempty"`
@@ -639,6 +670,7 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 		}
 
 		opts.allowedCustomContexts[baseContext] = true
+		opts.allowedCustomContexts[baseContextV2] = true
 
 		opts.allowedCustomTypes = make(map[string]bool)
 		for _, context := range customTypes {
@@ -787,6 +819,10 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 	// Apply options.
 	vcOpts := getCredentialOpts(opts)
 
+	return parseCredential(vcData, vcOpts)
+}
+
+func parseCredential(vcData []byte, vcOpts *credentialOpts) (*Credential, error) {
 	// Decode credential (e.g. from JWT).
 	vcDataDecoded, err := decodeRaw(vcData, vcOpts)
 	if err != nil {
@@ -849,7 +885,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 		return errors.New("violated type constraint: not base only type defined")
 	}
 
-	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
+	if len(vc.Context) > 1 || (vc.Context[0] != baseContext && vc.Context[0] != baseContextV2) {
 		return errors.New("violated @context constraint: not base only @context defined")
 	}
 
@@ -963,6 +999,25 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		return nil, fmt.Errorf("fill credential subject from raw: %w", err)
 	}
 
+	statuses, err := parseTypedID(raw.Status)
+	if err != nil {
+		return nil, fmt.Errorf("fill credential status from raw: %w", err)
+	}
+
+	var status *TypedID
+
+	if len(statuses) > 0 {
+		status = &statuses[0]
+	}
+
+	// Statuses is only set when there are several of them.
+	if len(statuses) == 1 {
+		statuses = nil
+	}
+
+	name := languageValueFromRaw(raw, "name", raw.Name)
+	description := languageValueFromRaw(raw, "description", raw.Description)
+
 	return &Credential{
 		Context:        context,
 		CustomContext:  customContext,
@@ -972,8 +1027,13 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Issuer:         issuer,
 		Issued:         raw.Issued,
 		Expired:        raw.Expired,
+		ValidFrom:      raw.ValidFrom,
+		ValidUntil:     raw.ValidUntil,
+		Name:           name,
+		Description:    description,
 		Proofs:         proofs,
-		Status:         raw.Status,
+		Status:         status,
+		Statuses:       statuses,
 		Schemas:        schemas,
 		Evidence:       raw.Evidence,
 		TermsOfUse:     termsOfUse,
@@ -1029,6 +1089,15 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 		return checkEmbeddedProof(vcDecodedBytes, getEmbeddedProofCheckOpts(vcOpts))
 	}
 
+	vcJWT, enveloped, err := envelopedCredentialFromJSON(vcData)
+	if err != nil {
+		return nil, fmt.Errorf("enveloped credential decoding: %w", err)
+	}
+
+	if enveloped {
+		return decodeRaw([]byte(vcJWT), vcOpts)
+	}
+
 	// Embedded proof.
 	return checkEmbeddedProof(vcData, getEmbeddedProofCheckOpts(vcOpts))
 }
@@ -1157,7 +1226,7 @@ func (vc *Credential) validateJSONSchema(data []byte, opts *credentialOpts) erro
 func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *credentialOpts) error {
 	// Validate that the Verifiable Credential conforms to the serialization of the Verifiable Credential data model
 	// (https://w3c.github.io/vc-data-model/#example-1-a-simple-example-of-a-verifiable-credential)
-	schemaLoader, err := getSchemaLoader(schemas, opts)
+	schemaLoader, err := getSchemaLoader(data, schemas, opts)
 	if err != nil {
 		return err
 	}
@@ -1177,27 +1246,44 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 	return nil
 }
 
-func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
+func getSchemaLoader(data []byte, schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
+	defaultLoader := defaultSchemaLoader
+	if isV2Document(data) {
+		defaultLoader = defaultSchemaV2Loader
+	}
+
 	if opts.disabledCustomSchema {
-		return defaultSchemaLoader(), nil
+		return defaultLoader(), nil
 	}
 
 	for _, schema := range schemas {
 		switch schema.Type {
-		case jsonSchema2018Type:
+		case jsonSchema2018Type, jsonSchemaType:
 			customSchemaData, err := getJSONSchema(schema.ID, opts)
 			if err != nil {
 				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
 			}
 
-			return gojsonschema.NewBytesLoader(customSchemaData), nil
+			return newJSONSchemaLoader(customSchemaData)
+		case jsonSchemaCredentialType:
+			schemaCredentialData, err := getJSONSchema(schema.ID, opts)
+			if err != nil {
+				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
+			}
+
+			customSchemaData, err := jsonSchemaFromCredential(schemaCredentialData, opts)
+			if err != nil {
+				return nil, fmt.Errorf("custom credential schema from %s: %w", schema.ID, err)
+			}
+
+			return newJSONSchemaLoader(customSchemaData)
 		default:
 			logger.Warnf("unsupported credential schema: %s. Using default schema for validation", schema.Type)
 		}
 	}
 
 	// If no custom schema is chosen, use default one
-	return defaultSchemaLoader(), nil
+	return defaultLoader(), nil
 }
 
 func defaultSchemaLoader() gojsonschema.JSONLoader {
@@ -1353,13 +1439,18 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		return nil, err
 	}
 
+	status, err := vc.statusToRaw()
+	if err != nil {
+		return nil, err
+	}
+
 	r := &rawCredential{
 		Context:        contextToRaw(vc.Context, vc.CustomContext),
 		ID:             vc.ID,
 		Type:           typesToRaw(vc.Types),
 		Subject:        subject,
 		Proof:          proof,
-		Status:         vc.Status,
+		Status:         status,
 		Issuer:         issuer,
 		Schema:         schema,
 		Evidence:       vc.Evidence,
@@ -1367,12 +1458,35 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		TermsOfUse:     rawTermsOfUse,
 		Issued:         vc.Issued,
 		Expired:        vc.Expired,
+		ValidFrom:      vc.ValidFrom,
+		ValidUntil:     vc.ValidUntil,
 		CustomFields:   vc.CustomFields,
 	}
 
+	// Keep language-tagged values of CustomFields when no plain string is defined.
+	if vc.Name != "" {
+		r.Name = vc.Name
+	}
+
+	if vc.Description != "" {
+		r.Description = vc.Description
+	}
+
 	return r, nil
 }
 
+func (vc *Credential) statusToRaw() (json.RawMessage, error) {
+	if len(vc.Statuses) > 0 {
+		return typedIDsToRaw(vc.Statuses)
+	}
+
+	if vc.Status == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(vc.Status)
+}
+
 func typesToRaw(types []string) interface{} {
 	if len(types) == 1 {
 		// as string
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbs2023"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
 )
 
@@ -51,3 +52,35 @@ func (vc *Credential) GenerateBBSSelectiveDisclosure(revealDoc map[string]interf
 
 	return ParseCredential(vcWithSelectiveDisclosureBytes, opts...)
 }
+
+// GenerateBBS2023SelectiveDisclosure derives from the bbs-2023 base proof of the credential a credential disclosing
+// only the statements selected by the JSON pointers, along with the statements the issuer made mandatory.
+// The presentation header is bound to the derived proof.
+func (vc *Credential) GenerateBBS2023SelectiveDisclosure(selectivePointers []string, presentationHeader []byte,
+	opts ...CredentialOpt) (*Credential, error) {
+	if len(vc.Proofs) == 0 {
+		return nil, errors.New("expected at least one proof present")
+	}
+
+	vcOpts := getCredentialOpts(opts)
+	jsonldProcessorOpts := mapJSONLDProcessorOpts(&vcOpts.jsonldCredentialOpts)
+
+	vcDoc, err := toMap(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedDoc, err := bbs2023.New().Derive(vcDoc, selectivePointers, presentationHeader, jsonldProcessorOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("create VC selective disclosure: %w", err)
+	}
+
+	derivedBytes, err := json.Marshal(derivedDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, WithDisabledProofCheck())
+
+	return ParseCredential(derivedBytes, opts...)
+}
@@ -7,7 +7,12 @@ package verifiable
 
 // MarshalJWS serializes JWT into signed form (JWS).
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jcc, signatureAlg, signer, keyID)
+	claimsSet, err := jcc.claimsSet()
+	if err != nil {
+		return "", err
+	}
+
+	return marshalJWS(jcc.joseHeaders(), claimsSet, signatureAlg, signer, keyID)
 }
 
 func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) (*JWTCredClaims, error) {
@@ -13,6 +13,7 @@ import (
 
 	josejwt "github.com/square/go-jose/v3/jwt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 )
 
@@ -20,19 +21,119 @@ const (
 	vcIssuanceDateField   = "issuanceDate"
 	vcIDField             = "id"
 	vcExpirationDateField = "expirationDate"
+	vcValidFromField      = "validFrom"
+	vcValidUntilField     = "validUntil"
+	vcContextField        = "@context"
 	vcIssuerField         = "issuer"
 	vcIssuerIDField       = "id"
+
+	// jwtTypeVC is the "typ" header of a Verifiable Credentials Data Model 2.0 credential secured as JWT.
+	jwtTypeVC = "vc+jwt"
 )
 
 // JWTCredClaims is JWT Claims extension by Verifiable Credential (with custom "vc" claim).
+// A Verifiable Credentials Data Model 2.0 credential is secured as the JWT claims set itself, with no "vc" claim.
 type JWTCredClaims struct {
 	*jwt.Claims
 
 	VC map[string]interface{} `json:"vc,omitempty"`
+
+	// securedAsClaimsSet is true if the credential was decoded from the JWT claims set.
+	securedAsClaimsSet bool
+}
+
+// registeredJWTClaims are the JWT claims which are not part of a credential secured as JWT claims set.
+var registeredJWTClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti"} //nolint:gochecknoglobals
+
+// UnmarshalJSON defines custom unmarshalling of JWTCredClaims from JSON.
+// A Verifiable Credentials Data Model 2.0 credential may be secured as the JWT claims set itself (with no "vc"
+// claim), in that case the claims set except the registered JWT claims is taken as the credential.
+func (jcc *JWTCredClaims) UnmarshalJSON(data []byte) error {
+	type Alias JWTCredClaims
+
+	alias := (*Alias)(jcc)
+
+	if err := json.Unmarshal(data, alias); err != nil {
+		return err
+	}
+
+	if jcc.Claims == nil {
+		jcc.Claims = &jwt.Claims{}
+	}
+
+	if jcc.VC != nil {
+		return nil
+	}
+
+	var claimsSet map[string]interface{}
+
+	if err := json.Unmarshal(data, &claimsSet); err != nil {
+		return err
+	}
+
+	if _, ok := claimsSet[vcContextField]; !ok {
+		return nil
+	}
+
+	for _, claim := range registeredJWTClaims {
+		delete(claimsSet, claim)
+	}
+
+	jcc.VC = claimsSet
+	jcc.securedAsClaimsSet = true
+
+	return nil
+}
+
+// claimsSet returns the JWT claims set to be secured. A Verifiable Credentials Data Model 2.0 credential
+// is the claims set itself together with the registered JWT claims.
+func (jcc *JWTCredClaims) claimsSet() (interface{}, error) {
+	if !jcc.isV2() {
+		return jcc, nil
+	}
+
+	claimsBytes, err := json.Marshal(jcc.Claims)
+	if err != nil {
+		return nil, fmt.Errorf("marshal JWT claims: %w", err)
+	}
+
+	var claimsSet map[string]interface{}
+
+	if err = json.Unmarshal(claimsBytes, &claimsSet); err != nil {
+		return nil, fmt.Errorf("unmarshal JWT claims: %w", err)
+	}
+
+	if claimsSet == nil {
+		claimsSet = make(map[string]interface{}, len(jcc.VC))
+	}
+
+	for k, v := range jcc.VC {
+		claimsSet[k] = v
+	}
+
+	return claimsSet, nil
+}
+
+// isV2 tells whether the claims hold a Verifiable Credentials Data Model 2.0 credential.
+func (jcc *JWTCredClaims) isV2() bool {
+	context, _, err := decodeContext(jcc.VC[vcContextField])
+
+	return err == nil && isV2Context(context)
+}
+
+// joseHeaders returns the JOSE headers of the JWT, a Verifiable Credentials Data Model 2.0 credential
+// is explicitly typed.
+func (jcc *JWTCredClaims) joseHeaders() jose.Headers {
+	if !jcc.isV2() {
+		return nil
+	}
+
+	return jose.Headers{jose.HeaderType: jwtTypeVC}
 }
 
 // newJWTCredClaims creates JWT Claims of VC with an option to minimize certain fields of VC
-// which is put into "vc" claim.
+// which is put into "vc" claim. A Verifiable Credentials Data Model 2.0 credential is never minimized
+// as it is secured as the JWT claims set.
 func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 	subjectID, err := SubjectID(vc.Subject)
 	if err != nil {
@@ -41,24 +142,35 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 
 	// currently jwt encoding supports only single subject (by the spec)
 	jwtClaims := &jwt.Claims{
-		Issuer:    vc.Issuer.ID,                           // iss
-		NotBefore: josejwt.NewNumericDate(vc.Issued.Time), // nbf
-		ID:        vc.ID,                                  // jti
-		Subject:   subjectID,                              // sub
-		IssuedAt:  josejwt.NewNumericDate(vc.Issued.Time), // iat (not in spec, follow the interop project approach)
+		Issuer:  vc.Issuer.ID, // iss
+		ID:      vc.ID,        // jti
+		Subject: subjectID,    // sub
+	}
+
+	// validFrom and validUntil of Verifiable Credentials Data Model 2.0 take place of issuanceDate and expirationDate.
+	v2 := isV2Context(vc.Context)
+
+	issued, expired := vc.Issued, vc.Expired
+	if v2 {
+		issued, expired = vc.ValidFrom, vc.ValidUntil
 	}
-	if vc.Expired != nil {
-		jwtClaims.Expiry = josejwt.NewNumericDate(vc.Expired.Time) // exp
+
+	if issued != nil {
+		jwtClaims.NotBefore = josejwt.NewNumericDate(issued.Time) // nbf
+		jwtClaims.IssuedAt = josejwt.NewNumericDate(issued.Time)  // iat (not in spec, follow the interop project approach)
+	}
+
+	if expired != nil {
+		jwtClaims.Expiry = josejwt.NewNumericDate(expired.Time) // exp
 	}
 
 	var raw *rawCredential
 
-	if minimizeVC {
+	if minimizeVC && !v2 {
 		vcCopy := *vc
-		vcCopy.Expired = nil
 		vcCopy.Issuer.ID = ""
-		vcCopy.Issued = nil
 		vcCopy.ID = ""
+		vcCopy.Issued, vcCopy.Expired = nil, nil
 
 		raw, err = vcCopy.raw()
 	} else {
@@ -105,6 +217,11 @@ func decodeCredJWT(rawJWT string, unmarshaller JWTCredClaimsUnmarshaller) ([]byt
 }
 
 func (jcc *JWTCredClaims) refineFromJWTClaims() {
+	// the claims set is the credential itself, the registered JWT claims are not part of it.
+	if jcc.securedAsClaimsSet {
+		return
+	}
+
 	vcMap := jcc.VC
 	claims := jcc.Claims
 
@@ -112,15 +229,22 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 		refineVCIssuerFromJWTClaims(vcMap, iss)
 	}
 
+	if jti := claims.ID; jti != "" {
+		vcMap[vcIDField] = jti
+	}
+
+	context, _, err := decodeContext(vcMap[vcContextField])
+	if err == nil && isV2Context(context) {
+		refineVCValidityFromJWTClaims(vcMap, claims)
+
+		return
+	}
+
 	if nbf := claims.NotBefore; nbf != nil {
 		nbfTime := nbf.Time().UTC()
 		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
 	}
 
-	if jti := claims.ID; jti != "" {
-		vcMap[vcIDField] = jti
-	}
-
 	if iat := claims.IssuedAt; iat != nil {
 		iatTime := iat.Time().UTC()
 		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
@@ -132,6 +256,18 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	}
 }
 
+// refineVCValidityFromJWTClaims sets validFrom and validUntil of Verifiable Credentials Data Model 2.0 credential
+// from "nbf" and "exp" claims.
+func refineVCValidityFromJWTClaims(vcMap map[string]interface{}, claims *jwt.Claims) {
+	if nbf := claims.NotBefore; nbf != nil {
+		vcMap[vcValidFromField] = nbf.Time().UTC().Format(time.RFC3339)
+	}
+
+	if exp := claims.Expiry; exp != nil {
+		vcMap[vcValidUntilField] = exp.Time().UTC().Format(time.RFC3339)
+	}
+}
+
 func refineVCIssuerFromJWTClaims(vcMap map[string]interface{}, iss string) {
 	// Issuer of Verifiable Credential could be either string (id) or struct (with "id" field).
 	if _, exists := vcMap[vcIssuerField]; !exists {
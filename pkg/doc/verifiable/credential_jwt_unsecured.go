@@ -11,7 +11,12 @@ import (
 
 // MarshalUnsecuredJWT serialized JWT into unsecured JWT.
 func (jcc *JWTCredClaims) MarshalUnsecuredJWT() (string, error) {
-	return marshalUnsecuredJWT(nil, jcc)
+	claimsSet, err := jcc.claimsSet()
+	if err != nil {
+		return "", err
+	}
+
+	return marshalUnsecuredJWT(jcc.joseHeaders(), claimsSet)
 }
 
 func unmarshalUnsecuredJWTClaims(rawJWT string) (*JWTCredClaims, error) {
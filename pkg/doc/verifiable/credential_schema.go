@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	draft07SchemaURL = "http://json-schema.org/draft-07/schema#"
+
+	jsonSchemaSubjectField = "jsonSchema"
+)
+
+// draft2020Schemas are the JSON Schema dialects validated as their draft 7 equivalent.
+var draft2020Schemas = map[string]bool{ //nolint:gochecknoglobals
+	"https://json-schema.org/draft/2020-12/schema":  true,
+	"https://json-schema.org/draft/2020-12/schema#": true,
+	"https://json-schema.org/draft/2019-09/schema":  true,
+	"https://json-schema.org/draft/2019-09/schema#": true,
+}
+
+// unsupportedSchemaKeywords are the JSON Schema 2019-09 and 2020-12 keywords with no draft 7 equivalent.
+var unsupportedSchemaKeywords = []string{ //nolint:gochecknoglobals
+	"$anchor", "$dynamicRef", "$dynamicAnchor", "$recursiveRef", "$recursiveAnchor", "minContains", "maxContains",
+}
+
+// schemaApplicators are the keywords whose evaluated properties and items are taken into account by
+// unevaluatedProperties and unevaluatedItems.
+var schemaApplicators = []string{ //nolint:gochecknoglobals
+	"$ref", "allOf", "anyOf", "oneOf", "if", "then", "else", "dependentSchemas",
+}
+
+// newJSONSchemaLoader returns the loader of a custom credential schema. The schemas of the 2019-09 and 2020-12
+// drafts are converted to draft 7, the latest draft supported by gojsonschema.
+func newJSONSchemaLoader(schemaData []byte) (gojsonschema.JSONLoader, error) {
+	var schema map[string]interface{}
+
+	if err := json.Unmarshal(schemaData, &schema); err != nil {
+		return nil, fmt.Errorf("unmarshal custom credential schema: %w", err)
+	}
+
+	dialect, ok := schema["$schema"].(string)
+	if !ok || !draft2020Schemas[dialect] {
+		return gojsonschema.NewBytesLoader(schemaData), nil
+	}
+
+	converted, err := toDraft07Schema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s credential schema: %w", dialect, err)
+	}
+
+	converted["$schema"] = draft07SchemaURL
+
+	return gojsonschema.NewGoLoader(converted), nil
+}
+
+// jsonSchemaFromCredential returns the JSON schema of a JsonSchemaCredential, after checking it like the
+// credential it is the schema of.
+// https://www.w3.org/TR/vc-json-schema/#jsonschemacredential
+func jsonSchemaFromCredential(vcData []byte, opts *credentialOpts) ([]byte, error) {
+	// the schema credential is checked against the default schema, not against its own credential schema
+	schemaOpts := *opts
+	schemaOpts.disabledCustomSchema = true
+
+	vc, err := parseCredential(vcData, &schemaOpts)
+	if err != nil {
+		return nil, fmt.Errorf("parse schema credential: %w", err)
+	}
+
+	if !opts.disabledProofCheck && len(vc.Proofs) == 0 && !jwt.IsJWS(string(vcData)) {
+		return nil, errors.New("schema credential is not secured")
+	}
+
+	if !containsString(vc.Types, jsonSchemaCredentialType) {
+		return nil, fmt.Errorf("schema credential is not of %s type", jsonSchemaCredentialType)
+	}
+
+	subjects, ok := vc.Subject.([]Subject)
+	if !ok || len(subjects) != 1 {
+		return nil, errors.New("schema credential must have a single subject")
+	}
+
+	schema, ok := subjects[0].CustomFields[jsonSchemaSubjectField].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema credential subject has no %s object", jsonSchemaSubjectField)
+	}
+
+	return json.Marshal(schema)
+}
+
+// toDraft07Schema converts a JSON schema of the 2019-09 or 2020-12 drafts to its draft 7 equivalent.
+func toDraft07Schema(schema map[string]interface{}) (map[string]interface{}, error) { //nolint:gocyclo
+	for _, keyword := range unsupportedSchemaKeywords {
+		if _, ok := schema[keyword]; ok {
+			return nil, fmt.Errorf("unsupported keyword %s", keyword)
+		}
+	}
+
+	converted := make(map[string]interface{}, len(schema))
+
+	for k, v := range schema {
+		c, err := convertSchemaKeyword(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+
+		converted[k] = c
+	}
+
+	// prefixItems and items replace the array form of items and additionalItems
+	if prefixItems, ok := converted["prefixItems"]; ok {
+		if items, ok := converted["items"]; ok {
+			converted["additionalItems"] = items
+		}
+
+		converted["items"] = prefixItems
+		delete(converted, "prefixItems")
+	}
+
+	// dependentRequired and dependentSchemas replace dependencies
+	for _, keyword := range []string{"dependentRequired", "dependentSchemas"} {
+		dependent, ok := converted[keyword].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		dependencies, ok := converted["dependencies"].(map[string]interface{})
+		if !ok {
+			dependencies = map[string]interface{}{}
+		}
+
+		for k, v := range dependent {
+			dependencies[k] = v
+		}
+
+		converted["dependencies"] = dependencies
+		delete(converted, keyword)
+	}
+
+	if err := convertUnevaluated(converted); err != nil {
+		return nil, err
+	}
+
+	// the keywords next to $ref are ignored in draft 7
+	if ref, ok := converted["$ref"]; ok && len(converted) > 1 {
+		allOf, _ := converted["allOf"].([]interface{}) //nolint:errcheck
+
+		converted["allOf"] = append(allOf, map[string]interface{}{"$ref": ref})
+		delete(converted, "$ref")
+	}
+
+	return converted, nil
+}
+
+// convertUnevaluated converts unevaluatedProperties and unevaluatedItems to additionalProperties and
+// additionalItems, which are equivalent when there are no applicators.
+func convertUnevaluated(schema map[string]interface{}) error {
+	for _, keyword := range []string{"unevaluatedProperties", "unevaluatedItems"} {
+		unevaluated, ok := schema[keyword]
+		if !ok {
+			continue
+		}
+
+		delete(schema, keyword)
+
+		if b, ok := unevaluated.(bool); ok && b {
+			continue
+		}
+
+		for _, applicator := range schemaApplicators {
+			if _, ok := schema[applicator]; ok {
+				return fmt.Errorf("unsupported keyword %s along with %s", keyword, applicator)
+			}
+		}
+
+		if keyword == "unevaluatedProperties" {
+			if _, ok := schema["additionalProperties"]; !ok {
+				schema["additionalProperties"] = unevaluated
+			}
+
+			continue
+		}
+
+		switch schema["items"].(type) {
+		case nil:
+			schema["items"] = unevaluated
+		case []interface{}:
+			if _, ok := schema["additionalItems"]; !ok {
+				schema["additionalItems"] = unevaluated
+			}
+		default:
+			// all the items are evaluated by items
+		}
+	}
+
+	return nil
+}
+
+func convertSchemaKeyword(keyword string, value interface{}) (interface{}, error) {
+	switch keyword {
+	case "additionalProperties", "additionalItems", "items", "contains", "propertyNames", "not", "if", "then",
+		"else", "unevaluatedProperties", "unevaluatedItems", "contentSchema":
+		return convertSubSchema(value)
+	case "allOf", "anyOf", "oneOf", "prefixItems":
+		return convertSubSchemas(value)
+	case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+		return convertSubSchemaMap(value)
+	case "dependencies":
+		return convertDependencies(value)
+	default:
+		return value, nil
+	}
+}
+
+func convertSubSchema(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return toDraft07Schema(v)
+	case []interface{}:
+		return convertSubSchemas(v)
+	default:
+		return value, nil
+	}
+}
+
+func convertSubSchemas(value interface{}) (interface{}, error) {
+	schemas, ok := value.([]interface{})
+	if !ok {
+		return value, nil
+	}
+
+	converted := make([]interface{}, len(schemas))
+
+	for i, schema := range schemas {
+		c, err := convertSubSchema(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		converted[i] = c
+	}
+
+	return converted, nil
+}
+
+func convertSubSchemaMap(value interface{}) (interface{}, error) {
+	schemas, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+
+	converted := make(map[string]interface{}, len(schemas))
+
+	for k, schema := range schemas {
+		c, err := convertSubSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+
+		converted[k] = c
+	}
+
+	return converted, nil
+}
+
+func convertDependencies(value interface{}) (interface{}, error) {
+	dependencies, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+
+	converted := make(map[string]interface{}, len(dependencies))
+
+	for k, dependency := range dependencies {
+		// an array of property names is kept as is
+		if _, ok := dependency.([]interface{}); ok {
+			converted[k] = dependency
+
+			continue
+		}
+
+		c, err := convertSubSchema(dependency)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+
+		converted[k] = c
+	}
+
+	return converted, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestNewJSONSchemaLoader(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		valid   []string
+		invalid []string
+	}{
+		{
+			name: "prefixItems and items",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "array",
+				"prefixItems": [{"type": "string"}, {"type": "number"}],
+				"items": false
+			}`,
+			valid:   []string{`["a", 1]`, `["a"]`},
+			invalid: []string{`[1, "a"]`, `["a", 1, 2]`},
+		},
+		{
+			name: "$defs and $ref with other keywords",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"$defs": {"email": {"type": "string", "pattern": "@"}},
+				"type": "object",
+				"properties": {
+					"email": {"$ref": "#/$defs/email", "maxLength": 10}
+				}
+			}`,
+			valid:   []string{`{"email": "a@b.c"}`},
+			invalid: []string{`{"email": "abc"}`, `{"email": "a@verylongdomain.com"}`},
+		},
+		{
+			name: "dependentRequired and dependentSchemas",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"dependentRequired": {"creditCard": ["billingAddress"]},
+				"dependentSchemas": {"name": {"required": ["surname"]}}
+			}`,
+			valid:   []string{`{"creditCard": 1, "billingAddress": "a"}`, `{"name": "a", "surname": "b"}`},
+			invalid: []string{`{"creditCard": 1}`, `{"name": "a"}`},
+		},
+		{
+			name: "unevaluatedProperties and unevaluatedItems without applicators",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"properties": {
+					"name": {"type": "string"},
+					"tags": {"prefixItems": [{"type": "string"}], "unevaluatedItems": false}
+				},
+				"unevaluatedProperties": false
+			}`,
+			valid:   []string{`{"name": "a", "tags": ["a"]}`},
+			invalid: []string{`{"name": "a", "age": 1}`, `{"tags": ["a", "b"]}`},
+		},
+		{
+			name: "draft 7 schema",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"items": [{"type": "string"}],
+				"additionalItems": false
+			}`,
+			valid:   []string{`["a"]`},
+			invalid: []string{`["a", "b"]`},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			loader, err := newJSONSchemaLoader([]byte(tc.schema))
+			require.NoError(t, err)
+
+			schema, err := gojsonschema.NewSchema(loader)
+			require.NoError(t, err)
+
+			for _, doc := range tc.valid {
+				result, err := schema.Validate(gojsonschema.NewStringLoader(doc))
+				require.NoError(t, err)
+				require.True(t, result.Valid(), doc)
+			}
+
+			for _, doc := range tc.invalid {
+				result, err := schema.Validate(gojsonschema.NewStringLoader(doc))
+				require.NoError(t, err)
+				require.False(t, result.Valid(), doc)
+			}
+		})
+	}
+
+	t.Run("Unsupported keywords", func(t *testing.T) {
+		_, err := newJSONSchemaLoader([]byte(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"properties": {"tree": {"$dynamicRef": "#node"}}
+		}`))
+		require.EqualError(t, err,
+			"convert https://json-schema.org/draft/2020-12/schema credential schema: properties: tree: "+
+				"unsupported keyword $dynamicRef")
+
+		_, err = newJSONSchemaLoader([]byte(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"allOf": [{"properties": {"name": {"type": "string"}}}],
+			"unevaluatedProperties": false
+		}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported keyword unevaluatedProperties along with allOf")
+	})
+
+	t.Run("Invalid schema", func(t *testing.T) {
+		_, err := newJSONSchemaLoader([]byte("not JSON"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal custom credential schema")
+	})
+}
+
+func TestCustomCredentialJsonSchema(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, err := res.Write([]byte(referenceNumberSchema))
+		require.NoError(t, err)
+	}))
+
+	defer testServer.Close()
+
+	t.Run("Applies custom 2020-12 JSON Schema", func(t *testing.T) {
+		vcBytes := credentialWithSchema(t, TypedID{ID: testServer.URL, Type: "JsonSchema"}, nil)
+
+		_, err := parseTestCredential(t, vcBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "referenceNumber is required")
+
+		vcBytes = credentialWithSchema(t, TypedID{ID: testServer.URL, Type: "JsonSchema"}, 83294847)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, "JsonSchema", vc.Schemas[0].Type)
+	})
+}
+
+func TestCustomCredentialJsonSchemaCredential(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(referenceNumberSchema), &schema))
+
+	schemaVC := &Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "https://example.com/schemas/reference-number",
+		Types:   []string{"VerifiableCredential", jsonSchemaCredentialType},
+		Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+		Subject: map[string]interface{}{
+			"id":         "https://example.com/schemas/reference-number.json",
+			"type":       "JsonSchema",
+			"jsonSchema": schema,
+		},
+	}
+
+	schemaVCBytes, err := json.Marshal(schemaVC)
+	require.NoError(t, err)
+
+	served := map[string][]byte{
+		"/secured":   createEdDSAJWS(t, schemaVCBytes, signer, false),
+		"/unsecured": schemaVCBytes,
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, err := res.Write(served[req.URL.Path])
+		require.NoError(t, err)
+	}))
+
+	defer testServer.Close()
+
+	publicKeyFetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("Applies JSON Schema of a secured schema credential", func(t *testing.T) {
+		schemaID := TypedID{ID: testServer.URL + "/secured", Type: jsonSchemaCredentialType}
+
+		_, err := parseTestCredential(t, credentialWithSchema(t, schemaID, nil), publicKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "referenceNumber is required")
+
+		_, err = parseTestCredential(t, credentialWithSchema(t, schemaID, 83294847), publicKeyFetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejects an unsecured schema credential", func(t *testing.T) {
+		schemaID := TypedID{ID: testServer.URL + "/unsecured", Type: jsonSchemaCredentialType}
+
+		_, err := parseTestCredential(t, credentialWithSchema(t, schemaID, 83294847), publicKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema credential is not secured")
+
+		// unless the proofs are not checked
+		_, err = parseTestCredential(t, credentialWithSchema(t, schemaID, 83294847), WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejects a schema credential signed with another key", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		schemaID := TypedID{ID: testServer.URL + "/secured", Type: jsonSchemaCredentialType}
+
+		_, err = parseTestCredential(t, credentialWithSchema(t, schemaID, 83294847),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse schema credential")
+	})
+
+	t.Run("Rejects a credential of another type", func(t *testing.T) {
+		_, err := jsonSchemaFromCredential([]byte(validCredential), &credentialOpts{
+			disabledProofCheck:   true,
+			jsonldCredentialOpts: jsonldCredentialOpts{jsonldDocumentLoader: createTestDocumentLoader(t)},
+		})
+		require.EqualError(t, err, "schema credential is not of JsonSchemaCredential type")
+	})
+}
+
+const referenceNumberSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$defs": {
+		"referenceNumber": {"type": "integer"}
+	},
+	"type": "object",
+	"properties": {
+		"referenceNumber": {"$ref": "#/$defs/referenceNumber"}
+	},
+	"required": ["referenceNumber"]
+}`
+
+func credentialWithSchema(t *testing.T, schema TypedID, referenceNumber interface{}) []byte {
+	t.Helper()
+
+	raw := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
+
+	raw["credentialSchema"] = schema
+
+	if referenceNumber != nil {
+		raw["referenceNumber"] = referenceNumber
+	}
+
+	vcBytes, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	return vcBytes
+}
@@ -537,7 +537,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		var raw rawCredential
 
 		require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
-		raw.Status = &TypedID{Type: "CredentialStatusList2017"}
+		raw.Status = json.RawMessage(`{"type":"CredentialStatusList2017"}`)
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
 		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
@@ -549,7 +549,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		var raw rawCredential
 
 		require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
-		raw.Status = &TypedID{ID: "https://example.edu/status/24"}
+		raw.Status = json.RawMessage(`{"id":"https://example.edu/status/24"}`)
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
 		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
@@ -561,7 +561,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		var raw rawCredential
 
 		require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
-		raw.Status = &TypedID{ID: "invalid URL", Type: "CredentialStatusList2017"}
+		raw.Status = json.RawMessage(`{"id":"invalid URL","type":"CredentialStatusList2017"}`)
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
 		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
@@ -831,6 +831,7 @@ func TestWithBaseContextExtendedValidation(t *testing.T) {
 
 	require.Equal(t, map[string]bool{
 		"https://www.w3.org/2018/credentials/v1":          true,
+		"https://www.w3.org/ns/credentials/v2":            true,
 		"https://www.w3.org/2018/credentials/examples/v1": true,
 	},
 		opts.allowedCustomContexts)
@@ -0,0 +1,352 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DefaultSchemaV2 describes default schema of the Verifiable Credentials Data Model 2.0 credentials.
+const DefaultSchemaV2 = `{
+  "required": [
+    "@context",
+    "type",
+    "credentialSubject",
+    "issuer"
+  ],
+  "properties": {
+    "@context": {
+      "oneOf": [
+        {
+          "type": "string",
+          "const": "https://www.w3.org/ns/credentials/v2"
+        },
+        {
+          "type": "array",
+          "items": [
+            {
+              "type": "string",
+              "const": "https://www.w3.org/ns/credentials/v2"
+            }
+          ],
+          "uniqueItems": true,
+          "additionalItems": {
+            "oneOf": [
+              {
+                "type": "object"
+              },
+              {
+                "type": "string"
+              }
+            ]
+          }
+        }
+      ]
+    },
+    "id": {
+      "type": "string",
+      "format": "uri"
+    },
+    "type": {
+      "oneOf": [
+        {
+          "type": "array",
+          "minItems": 1,
+          "contains": {
+            "type": "string",
+            "pattern": "^VerifiableCredential$"
+          }
+        },
+        {
+          "type": "string",
+          "pattern": "^VerifiableCredential$"
+        }
+      ]
+    },
+    "name": {
+      "$ref": "#/definitions/languageValue"
+    },
+    "description": {
+      "$ref": "#/definitions/languageValue"
+    },
+    "credentialSubject": {
+      "anyOf": [
+        {
+          "type": "array"
+        },
+        {
+          "type": "object"
+        },
+        {
+          "type": "string"
+        }
+      ]
+    },
+    "issuer": {
+      "anyOf": [
+        {
+          "type": "string",
+          "format": "uri"
+        },
+        {
+          "type": "object",
+          "required": [
+            "id"
+          ],
+          "properties": {
+            "id": {
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      ]
+    },
+    "validFrom": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "validUntil": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "proof": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/proof"
+        },
+        {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/proof"
+          }
+        },
+        {
+          "type": "null"
+        }
+      ]
+    },
+    "credentialStatus": {
+      "$ref": "#/definitions/typedObjects"
+    },
+    "credentialSchema": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/typedID"
+        },
+        {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/typedID"
+          }
+        }
+      ]
+    },
+    "evidence": {
+      "$ref": "#/definitions/typedObjects"
+    },
+    "refreshService": {
+      "$ref": "#/definitions/typedObjects"
+    },
+    "termsOfUse": {
+      "$ref": "#/definitions/typedObjects"
+    }
+  },
+  "definitions": {
+    "typedObject": {
+      "type": "object",
+      "required": [
+        "type"
+      ],
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uri"
+        },
+        "type": {
+          "anyOf": [
+            {
+              "type": "string"
+            },
+            {
+              "type": "array",
+              "items": {
+                "type": "string"
+              }
+            }
+          ]
+        }
+      }
+    },
+    "typedObjects": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/typedObject"
+        },
+        {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/typedObject"
+          }
+        }
+      ]
+    },
+    "typedID": {
+      "allOf": [
+        {
+          "$ref": "#/definitions/typedObject"
+        },
+        {
+          "required": [
+            "id"
+          ]
+        }
+      ]
+    },
+    "languageValue": {
+      "anyOf": [
+        {
+          "type": "string"
+        },
+        {
+          "type": "object",
+          "required": [
+            "@value"
+          ]
+        },
+        {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": [
+              "@value"
+            ]
+          }
+        }
+      ]
+    },
+    "proof": {
+      "type": "object",
+      "required": [
+        "type"
+      ],
+      "properties": {
+        "type": {
+          "type": "string"
+        }
+      }
+    }
+  }
+}
+`
+
+// isV2Context tells whether the contexts are the ones of a Verifiable Credentials Data Model 2.0 document,
+// the first context being the base context of the data model.
+func isV2Context(context []string) bool {
+	return len(context) > 0 && context[0] == baseContextV2
+}
+
+// isV2Document tells whether the JSON document is a Verifiable Credentials Data Model 2.0 one.
+func isV2Document(data []byte) bool {
+	var doc struct {
+		Context interface{} `json:"@context"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+
+	context, _, err := decodeContext(doc.Context)
+	if err != nil {
+		return false
+	}
+
+	return isV2Context(context)
+}
+
+func defaultSchemaV2Loader() gojsonschema.JSONLoader {
+	return gojsonschema.NewStringLoader(DefaultSchemaV2)
+}
+
+// languageValueFromRaw returns the value of a name or description field of rawCredential when it is a plain string.
+// Other values (language-tagged ones) are kept in custom fields.
+func languageValueFromRaw(raw *rawCredential, field string, value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	if value != nil {
+		if raw.CustomFields == nil {
+			raw.CustomFields = make(CustomFields)
+		}
+
+		raw.CustomFields[field] = value
+	}
+
+	return ""
+}
+
+// NewEnvelopedCredential returns an EnvelopedVerifiableCredential enclosing the credential secured as a JWT,
+// to be added to a Verifiable Credentials Data Model 2.0 presentation.
+func NewEnvelopedCredential(vcJWT string) map[string]interface{} {
+	return map[string]interface{}{
+		"@context": baseContextV2,
+		"id":       "data:application/" + jwtTypeVC + "," + vcJWT,
+		"type":     envelopedVCType,
+	}
+}
+
+// envelopedCredentialFromJSON returns the secured credential enclosed in the EnvelopedVerifiableCredential JSON.
+// It returns false if the JSON is not an EnvelopedVerifiableCredential.
+func envelopedCredentialFromJSON(data []byte) (string, bool, error) {
+	var cred map[string]interface{}
+
+	if err := json.Unmarshal(data, &cred); err != nil {
+		return "", false, nil //nolint:nilerr
+	}
+
+	return envelopedCredential(cred)
+}
+
+// envelopedCredential returns the secured credential enclosed in the data URL of an EnvelopedVerifiableCredential.
+// It returns false if the credential is not an EnvelopedVerifiableCredential.
+func envelopedCredential(cred map[string]interface{}) (string, bool, error) {
+	types, err := decodeType(cred["type"])
+	if err != nil || !containsString(types, envelopedVCType) {
+		return "", false, nil //nolint:nilerr
+	}
+
+	id, ok := cred["id"].(string)
+	if !ok || !strings.HasPrefix(id, "data:") {
+		return "", true, errors.New("enveloped credential id is not a data URL")
+	}
+
+	// data:[<media type>][;base64],<data>
+	sep := strings.Index(id, ",")
+	if sep < 0 {
+		return "", true, errors.New("enveloped credential id is not a data URL")
+	}
+
+	mediaType, data := id[len("data:"):sep], id[sep+1:]
+
+	if strings.HasSuffix(mediaType, ";base64") {
+		return "", true, fmt.Errorf("unsupported enveloped credential encoding: %s", mediaType)
+	}
+
+	data, err = url.PathUnescape(data)
+	if err != nil {
+		return "", true, fmt.Errorf("unescape enveloped credential: %w", err)
+	}
+
+	return data, true, nil
+}
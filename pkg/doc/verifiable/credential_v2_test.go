@@ -0,0 +1,342 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const validCredentialV2 = `
+{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2"
+  ],
+  "id": "http://university.example/credentials/3732",
+  "type": [
+    "VerifiableCredential",
+    "ExampleDegreeCredential"
+  ],
+  "name": "Example Degree",
+  "description": "Degree awarded by the Example University.",
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "validFrom": "2010-01-01T19:23:24Z",
+  "validUntil": "2030-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "ExampleBachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  },
+  "credentialStatus": [
+    {
+      "id": "https://university.example/credentials/status/3#94567",
+      "type": "BitstringStatusListEntry",
+      "statusPurpose": "revocation",
+      "statusListIndex": "94567",
+      "statusListCredential": "https://university.example/credentials/status/3"
+    },
+    {
+      "id": "https://university.example/credentials/status/4#23452",
+      "type": "BitstringStatusListEntry",
+      "statusPurpose": "suspension",
+      "statusListIndex": "23452",
+      "statusListCredential": "https://university.example/credentials/status/4"
+    }
+  ]
+}
+`
+
+func TestParseCredentialV2(t *testing.T) {
+	t.Run("Parse Verifiable Credentials Data Model 2.0 credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredentialV2), WithStrictValidation())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{baseContextV2}, vc.Context)
+		require.Equal(t, "Example Degree", vc.Name)
+		require.Equal(t, "Degree awarded by the Example University.", vc.Description)
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.ValidFrom.Time)
+		require.Equal(t, time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC), vc.ValidUntil.Time)
+		require.Nil(t, vc.Issued)
+		require.Nil(t, vc.Expired)
+		require.Empty(t, vc.CustomFields)
+
+		require.Len(t, vc.Statuses, 2)
+		require.Equal(t, &vc.Statuses[0], vc.Status)
+		require.Equal(t, "suspension", vc.Statuses[1].CustomFields["statusPurpose"])
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, validCredentialV2, string(vcBytes))
+	})
+
+	t.Run("Parse credential with a single credential status", func(t *testing.T) {
+		vcMap := credentialV2Map(t)
+		vcMap["credentialStatus"] = vcMap["credentialStatus"].([]interface{})[0]
+
+		vc, err := parseTestCredential(t, toJSON(t, vcMap))
+		require.NoError(t, err)
+		require.Equal(t, "https://university.example/credentials/status/3#94567", vc.Status.ID)
+		require.Empty(t, vc.Statuses)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, string(toJSON(t, vcMap)), string(vcBytes))
+	})
+
+	t.Run("Keep language-tagged name and description", func(t *testing.T) {
+		vcMap := credentialV2Map(t)
+		vcMap["name"] = map[string]interface{}{"@value": "Exemple de diplôme", "@language": "fr"}
+		vcMap["description"] = []interface{}{
+			map[string]interface{}{"@value": "Example Degree", "@language": "en"},
+			map[string]interface{}{"@value": "Exemple de diplôme", "@language": "fr"},
+		}
+
+		vc, err := parseTestCredential(t, toJSON(t, vcMap))
+		require.NoError(t, err)
+		require.Empty(t, vc.Name)
+		require.Empty(t, vc.Description)
+		require.Equal(t, vcMap["name"], vc.CustomFields["name"])
+		require.Equal(t, vcMap["description"], vc.CustomFields["description"])
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, string(toJSON(t, vcMap)), string(vcBytes))
+	})
+
+	t.Run("Validate against default schema of data model 2.0", func(t *testing.T) {
+		vcMap := credentialV2Map(t)
+		delete(vcMap, "issuer")
+
+		_, err := parseTestCredential(t, toJSON(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issuer is required")
+
+		vcMap = credentialV2Map(t)
+		vcMap["name"] = 42
+
+		_, err = parseTestCredential(t, toJSON(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "name: Must validate at least one schema (anyOf)")
+
+		vcMap = credentialV2Map(t)
+		vcMap["credentialStatus"] = []interface{}{map[string]interface{}{"id": "https://example.edu/status/24"}}
+
+		_, err = parseTestCredential(t, toJSON(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type is required")
+	})
+
+	t.Run("Base context validation accepts data model 2.0 base context", func(t *testing.T) {
+		vcMap := credentialV2Map(t)
+		vcMap["type"] = "VerifiableCredential"
+		delete(vcMap, "credentialStatus")
+
+		_, err := parseTestCredential(t, toJSON(t, vcMap), WithBaseContextValidation())
+		require.NoError(t, err)
+	})
+}
+
+func TestCredentialV2JWT(t *testing.T) {
+	t.Run("Map validFrom and validUntil to JWT claims", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredentialV2))
+		require.NoError(t, err)
+
+		for _, minimize := range []bool{false, true} {
+			claims, err := vc.JWTClaims(minimize)
+			require.NoError(t, err)
+			require.Equal(t, vc.ValidFrom.Unix(), claims.NotBefore.Time().Unix())
+			require.Equal(t, vc.ValidUntil.Unix(), claims.Expiry.Time().Unix())
+
+			vcJWT, err := claims.MarshalUnsecuredJWT()
+			require.NoError(t, err)
+
+			vcFromJWT, err := parseTestCredential(t, []byte(vcJWT))
+			require.NoError(t, err)
+			require.Equal(t, vc.ValidFrom.Unix(), vcFromJWT.ValidFrom.Unix())
+			require.Equal(t, vc.ValidUntil.Unix(), vcFromJWT.ValidUntil.Unix())
+			require.Nil(t, vcFromJWT.Issued)
+			require.Nil(t, vcFromJWT.Expired)
+			require.Equal(t, vc.ID, vcFromJWT.ID)
+			require.Equal(t, vc.Issuer.ID, vcFromJWT.Issuer.ID)
+		}
+	})
+
+	t.Run("Secure credential as JWT claims set", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredentialV2))
+		require.NoError(t, err)
+
+		claims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vcJWS, err := claims.MarshalJWS(EdDSA, signer, "any")
+		require.NoError(t, err)
+
+		vcJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		for _, token := range []string{vcJWS, vcJWT} {
+			parts := strings.Split(token, ".")
+			require.Len(t, parts, 3)
+
+			var headers, claimsSet map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(decodeBase64URL(t, parts[0]), &headers))
+			require.Equal(t, "vc+jwt", headers["typ"])
+
+			require.NoError(t, json.Unmarshal(decodeBase64URL(t, parts[1]), &claimsSet))
+			require.NotContains(t, claimsSet, "vc")
+			require.Equal(t, vc.ID, claimsSet["id"])
+			require.Equal(t, vc.Issuer.ID, claimsSet["issuer"])
+			require.Equal(t, "2010-01-01T19:23:24Z", claimsSet["validFrom"])
+			require.Equal(t, "Example Degree", claimsSet["name"])
+		}
+
+		vcFromJWT, err := ParseCredential([]byte(vcJWS), WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWT.ID)
+		require.Equal(t, vc.Name, vcFromJWT.Name)
+		require.Equal(t, vc.ValidFrom, vcFromJWT.ValidFrom)
+		require.Equal(t, vc.Statuses, vcFromJWT.Statuses)
+	})
+
+	t.Run("Parse credential secured as JWT claims set", func(t *testing.T) {
+		claimsSet := credentialV2Map(t)
+		claimsSet["iat"] = 1262373804
+		claimsSet["iss"] = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+		vcJWT, err := marshalUnsecuredJWT(jose.Headers{"typ": "JWT"}, claimsSet)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(vcJWT))
+		require.NoError(t, err)
+		require.Equal(t, "http://university.example/credentials/3732", vc.ID)
+		require.Equal(t, "Example Degree", vc.Name)
+		require.Len(t, vc.Statuses, 2)
+		require.NotContains(t, vc.CustomFields, "iat")
+		require.NotContains(t, vc.CustomFields, "iss")
+	})
+
+	t.Run("Credential without validity period", func(t *testing.T) {
+		vcMap := credentialV2Map(t)
+		delete(vcMap, "validFrom")
+		delete(vcMap, "validUntil")
+
+		vc, err := parseTestCredential(t, toJSON(t, vcMap))
+		require.NoError(t, err)
+
+		claims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+		require.Nil(t, claims.NotBefore)
+		require.Nil(t, claims.Expiry)
+	})
+}
+
+func TestEnvelopedCredential(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredentialV2))
+	require.NoError(t, err)
+
+	claims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := claims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	t.Run("Parse enveloped credential", func(t *testing.T) {
+		vcEnveloped, err := parseTestCredential(t, toJSON(t, NewEnvelopedCredential(vcJWT)))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcEnveloped.ID)
+		require.Equal(t, vc.Statuses, vcEnveloped.Statuses)
+	})
+
+	t.Run("Presentation with enveloped credential", func(t *testing.T) {
+		vp, err := NewPresentation(WithEnvelopedCredentials(vcJWT))
+		require.NoError(t, err)
+
+		vp.Context = []string{baseContextV2}
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+		require.Contains(t, string(vpBytes), "data:application/vc+jwt,"+vcJWT)
+
+		vp, err = newTestPresentation(t, vpBytes, WithPresStrictValidation())
+		require.NoError(t, err)
+
+		creds := vp.Credentials()
+		require.Len(t, creds, 1)
+
+		vcFromVP, err := parseTestCredential(t, creds[0].([]byte))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromVP.ID)
+	})
+
+	t.Run("Not a JWT", func(t *testing.T) {
+		_, err := NewPresentation(WithEnvelopedCredentials("not a JWT"))
+		require.EqualError(t, err, "credential is not base64url encoded JWT")
+	})
+
+	t.Run("Invalid data URL", func(t *testing.T) {
+		for _, id := range []string{"https://example.com/credential", "data:application/vc+jwt"} {
+			_, err := parseTestCredential(t, toJSON(t, map[string]interface{}{
+				"@context": baseContextV2,
+				"id":       id,
+				"type":     envelopedVCType,
+			}))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "enveloped credential id is not a data URL")
+		}
+
+		_, err := parseTestCredential(t, toJSON(t, map[string]interface{}{
+			"@context": baseContextV2,
+			"id":       "data:application/vc+jwt;base64,ZXlK",
+			"type":     envelopedVCType,
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported enveloped credential encoding")
+	})
+}
+
+func credentialV2Map(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	var vcMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(validCredentialV2), &vcMap))
+
+	return vcMap
+}
+
+func toJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return b
+}
+
+func decodeBase64URL(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
@@ -12,11 +12,15 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbs2023"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
@@ -27,8 +31,11 @@ const (
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+
+	p384SignatureSize = 96
 )
 
+// getProofType returns the type of the proof, the cryptosuite is returned for Data Integrity proofs.
 func getProofType(proofMap map[string]interface{}) (string, error) {
 	proofType, ok := proofMap["type"]
 	if !ok {
@@ -40,6 +47,8 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
 		bbsBlsSignature2020, bbsBlsSignatureProof2020:
 		return proofTypeStr, nil
+	case proof.DataIntegrityProof:
+		return getCryptosuite(proofMap)
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
 	}
@@ -101,6 +110,17 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 	return docBytes, nil
 }
 
+func getCryptosuite(proofMap map[string]interface{}) (string, error) {
+	cryptosuite := safeStringValue(proofMap["cryptosuite"])
+
+	switch cryptosuite {
+	case eddsardfc2022.Cryptosuite, ecdsardfc2019.Cryptosuite, bbs2023.Cryptosuite:
+		return cryptosuite, nil
+	default:
+		return "", fmt.Errorf("unsupported cryptosuite: %s", cryptosuite)
+	}
+}
+
 func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]verifier.SignatureSuite, error) {
 	ldpSuites := opts.ldpSuites
 
@@ -132,6 +152,13 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 
 				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
 					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			case eddsardfc2022.Cryptosuite:
+				ldpSuites = append(ldpSuites, eddsardfc2022.New(
+					suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier())))
+			case ecdsardfc2019.Cryptosuite:
+				ldpSuites = append(ldpSuites, getECDSARDFC2019Suite(proofs[i]))
+			case bbs2023.Cryptosuite:
+				ldpSuites = append(ldpSuites, bbs2023.New())
 			}
 		}
 	}
@@ -139,6 +166,17 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+// getECDSARDFC2019Suite returns ecdsa-rdfc-2019 cryptosuite for the curve the proof was created with.
+// The curve is told by the size of the signature as P-256 and P-384 keys use different digest algorithms.
+func getECDSARDFC2019Suite(proofMap map[string]interface{}) verifier.SignatureSuite {
+	p, err := proof.NewProof(proofMap)
+	if err == nil && len(p.ProofValue) == p384SignatureSize {
+		return ecdsardfc2019.NewP384(suite.WithVerifier(ecdsardfc2019.NewP384PublicKeyVerifier()))
+	}
+
+	return ecdsardfc2019.New(suite.WithVerifier(ecdsardfc2019.NewPublicKeyVerifier()))
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
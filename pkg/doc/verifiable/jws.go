@@ -49,17 +49,20 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 }
 
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
+func marshalJWS(headers jose.Headers, jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer,
+	keyID string) (string, error) {
 	algName, err := signatureAlg.name()
 	if err != nil {
 		return "", err
 	}
 
-	headers := map[string]interface{}{
-		jose.HeaderKeyID: keyID,
+	protectedHeaders := jose.Headers{jose.HeaderKeyID: keyID}
+
+	for k, v := range headers {
+		protectedHeaders[k] = v
 	}
 
-	token, err := jwt.NewSigned(jwtClaims, headers, getJWTSigner(signer, algName))
+	token, err := jwt.NewSigned(jwtClaims, protectedHeaders, getJWTSigner(signer, algName))
 	if err != nil {
 		return "", err
 	}
@@ -64,6 +64,8 @@ type LinkedDataProofContext struct {
 	Purpose                 string                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// Cryptosuite selects the Suite of a DataIntegrityProof signature type (e.g. "eddsa-rdfc-2022").
+	Cryptosuite string
 }
 
 func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
@@ -140,5 +142,6 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		Cryptosuite:             context.Cryptosuite,
 	}
 }
@@ -0,0 +1,392 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+// Rules of the verification policy checked by PolicyEngine.
+const (
+	// PolicyRuleProof checks the proofs of the credential.
+	PolicyRuleProof = "proof"
+	// PolicyRuleProofType checks that the credential is secured by a proof of the required types.
+	PolicyRuleProofType = "proofType"
+	// PolicyRuleIssuer checks that the issuer is trusted for the types of the credential and that the credential is
+	// secured by keys of the issuer.
+	PolicyRuleIssuer = "issuer"
+	// PolicyRuleCredentialType checks that the credential is of one of the accepted types.
+	PolicyRuleCredentialType = "credentialType"
+	// PolicyRuleContext checks that the credential has the required contexts.
+	PolicyRuleContext = "context"
+	// PolicyRuleValidityPeriod checks that the credential is neither expired nor not yet valid.
+	PolicyRuleValidityPeriod = "validityPeriod"
+	// PolicyRuleStatus checks the status (e.g. revocation) of the credential.
+	PolicyRuleStatus = "status"
+
+	// JWTProofType is the proof type of the credentials secured as JWS in verification policies.
+	JWTProofType = "JWT"
+)
+
+// VerificationPolicy is a declarative policy of credential verification, defined in JSON or YAML.
+type VerificationPolicy struct {
+	// TrustedIssuers accepted. Any issuer is accepted if none is defined.
+	TrustedIssuers []TrustedIssuer `json:"trustedIssuers,omitempty"`
+	// CredentialTypes accepted, the credential must be of one of them. Any type is accepted if none is defined.
+	CredentialTypes []string `json:"credentialTypes,omitempty"`
+	// RequiredContexts the credential must have all of.
+	RequiredContexts []string `json:"requiredContexts,omitempty"`
+	// ProofTypes accepted, the credential must have a proof of one of them (JWTProofType for JWS).
+	// Unsecured credentials are accepted if none is defined.
+	ProofTypes []string `json:"proofTypes,omitempty"`
+	// CheckValidityPeriod rejects expired and not yet valid credentials.
+	CheckValidityPeriod bool `json:"checkValidityPeriod,omitempty"`
+	// ClockSkew tolerated when checking the validity period.
+	ClockSkew Duration `json:"clockSkew,omitempty"`
+	// CheckStatus rejects credentials of which the status check fails, it requires a CredentialStatusChecker.
+	CheckStatus bool `json:"checkStatus,omitempty"`
+}
+
+// TrustedIssuer of a VerificationPolicy.
+type TrustedIssuer struct {
+	// ID of the issuer, typically a DID.
+	ID string `json:"id"`
+	// CredentialTypes the issuer is trusted for. The issuer is trusted for any type if none is defined.
+	CredentialTypes []string `json:"credentialTypes,omitempty"`
+}
+
+// Duration is a time.Duration defined in JSON and YAML as a string, e.g. "5m".
+type Duration time.Duration
+
+// MarshalJSON marshals Duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON unmarshals Duration from a string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration is not a string: %w", err)
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// ParseVerificationPolicy parses the verification policy from JSON or YAML.
+func ParseVerificationPolicy(data []byte) (*VerificationPolicy, error) {
+	// JSON being YAML, the policy is decoded as YAML then defined by its JSON representation.
+	var doc interface{}
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode verification policy: %w", err)
+	}
+
+	policyJSON, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode verification policy: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(policyJSON))
+	decoder.DisallowUnknownFields()
+
+	policy := &VerificationPolicy{}
+
+	if err = decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("decode verification policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// CredentialStatusChecker checks the status (e.g. revocation) of the credential.
+type CredentialStatusChecker func(vc *Credential, status *TypedID) error
+
+// PolicyCheck is the result of the check of a rule of the verification policy.
+type PolicyCheck struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// VerificationReport is the result of the verification of a credential against a verification policy.
+type VerificationReport struct {
+	// Verified is true if all the checks passed.
+	Verified bool          `json:"verified"`
+	Checks   []PolicyCheck `json:"checks"`
+}
+
+func (r *VerificationReport) add(rule string, err error) {
+	check := PolicyCheck{Rule: rule, Passed: err == nil}
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	r.Checks = append(r.Checks, check)
+	r.Verified = r.Verified && check.Passed
+}
+
+// PolicyEngineOpt is the PolicyEngine option.
+type PolicyEngineOpt func(e *PolicyEngine)
+
+// WithStatusChecker sets the checker of the credential status required by the CheckStatus policy rule.
+func WithStatusChecker(checker CredentialStatusChecker) PolicyEngineOpt {
+	return func(e *PolicyEngine) {
+		e.statusChecker = checker
+	}
+}
+
+// PolicyEngine verifies credentials against a verification policy.
+type PolicyEngine struct {
+	policy        *VerificationPolicy
+	statusChecker CredentialStatusChecker
+	now           func() time.Time
+}
+
+// NewPolicyEngine returns a new PolicyEngine of the verification policy.
+func NewPolicyEngine(policy *VerificationPolicy, opts ...PolicyEngineOpt) *PolicyEngine {
+	e := &PolicyEngine{
+		policy: policy,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Verify verifies the credential in JSON or JWS form against the verification policy.
+// The credential is parsed using the options (e.g. public key fetcher, JSON-LD document loader), the proof check
+// being one of the checks of the report. An error is returned if the credential cannot be parsed at all.
+func (e *PolicyEngine) Verify(vcData []byte, opts ...CredentialOpt) (*VerificationReport, error) {
+	vc, err := ParseCredential(vcData, append(opts, WithDisabledProofCheck())...)
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	report := &VerificationReport{Verified: true}
+
+	_, err = ParseCredential(vcData, opts...)
+	report.add(PolicyRuleProof, err)
+
+	if len(e.policy.ProofTypes) > 0 {
+		report.add(PolicyRuleProofType, e.checkProofType(vc, jwt.IsJWS(string(vcData))))
+	}
+
+	if len(e.policy.TrustedIssuers) > 0 {
+		report.add(PolicyRuleIssuer, e.checkIssuer(vc, string(vcData)))
+	}
+
+	if len(e.policy.CredentialTypes) > 0 {
+		report.add(PolicyRuleCredentialType, e.checkCredentialType(vc))
+	}
+
+	if len(e.policy.RequiredContexts) > 0 {
+		report.add(PolicyRuleContext, e.checkContext(vc))
+	}
+
+	if e.policy.CheckValidityPeriod {
+		report.add(PolicyRuleValidityPeriod, e.checkValidityPeriod(vc))
+	}
+
+	if e.policy.CheckStatus {
+		report.add(PolicyRuleStatus, e.checkStatus(vc))
+	}
+
+	return report, nil
+}
+
+func (e *PolicyEngine) checkProofType(vc *Credential, jws bool) error {
+	var proofTypes []string
+
+	if jws {
+		proofTypes = append(proofTypes, JWTProofType)
+	}
+
+	for _, proof := range vc.Proofs {
+		if proofType, ok := proof["type"].(string); ok {
+			proofTypes = append(proofTypes, proofType)
+		}
+	}
+
+	for _, proofType := range proofTypes {
+		if containsString(e.policy.ProofTypes, proofType) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no proof of accepted types %s", strings.Join(e.policy.ProofTypes, ", "))
+}
+
+func (e *PolicyEngine) checkIssuer(vc *Credential, vcData string) error {
+	if err := e.checkTrustedIssuer(vc); err != nil {
+		return err
+	}
+
+	return checkSecuredByIssuer(vc, vcData)
+}
+
+func (e *PolicyEngine) checkTrustedIssuer(vc *Credential) error {
+	for _, issuer := range e.policy.TrustedIssuers {
+		if issuer.ID != vc.Issuer.ID {
+			continue
+		}
+
+		if len(issuer.CredentialTypes) == 0 {
+			return nil
+		}
+
+		for _, t := range vc.Types {
+			if t != vcType && containsString(issuer.CredentialTypes, t) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("issuer %s is not trusted for credential types %s",
+			vc.Issuer.ID, strings.Join(vc.Types, ", "))
+	}
+
+	return fmt.Errorf("issuer %s is not trusted", vc.Issuer.ID)
+}
+
+// checkSecuredByIssuer checks that the credential is secured by keys of its issuer only, the proof check verifying
+// the proofs against the keys they reference whoever these belong to. The key of a JWS is resolved from the
+// issuer claim, which must be the issuer of the credential, so only an absolute key ID of another DID is rejected.
+func checkSecuredByIssuer(vc *Credential, vcData string) error {
+	if jwt.IsJWS(vcData) {
+		token, err := jwt.Parse(vcData, jwt.WithSignatureVerifier(&noVerifier{}))
+		if err != nil {
+			return fmt.Errorf("parse JWS: %w", err)
+		}
+
+		if iss, _ := token.Payload["iss"].(string); iss != vc.Issuer.ID { // nolint:errcheck
+			return fmt.Errorf("JWS issuer %s is not the issuer %s", iss, vc.Issuer.ID)
+		}
+
+		if kid, _ := token.Headers.KeyID(); strings.HasPrefix(kid, "did:") && didOfURL(kid) != vc.Issuer.ID {
+			return fmt.Errorf("JWS key %s is not a key of the issuer %s", kid, vc.Issuer.ID)
+		}
+
+		return nil
+	}
+
+	if len(vc.Proofs) == 0 {
+		return fmt.Errorf("credential is not secured by the issuer %s", vc.Issuer.ID)
+	}
+
+	for _, proof := range vc.Proofs {
+		vm, _ := proof["verificationMethod"].(string) // nolint:errcheck
+
+		if didOfURL(vm) != vc.Issuer.ID {
+			return fmt.Errorf("proof verification method %q is not a key of the issuer %s", vm, vc.Issuer.ID)
+		}
+	}
+
+	return nil
+}
+
+// didOfURL returns the DID of the DID URL.
+func didOfURL(didURL string) string {
+	if i := strings.IndexAny(didURL, "#?/"); i >= 0 && strings.HasPrefix(didURL, "did:") {
+		return didURL[:i]
+	}
+
+	return didURL
+}
+
+func (e *PolicyEngine) checkCredentialType(vc *Credential) error {
+	for _, t := range vc.Types {
+		if containsString(e.policy.CredentialTypes, t) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("credential types %s are not accepted", strings.Join(vc.Types, ", "))
+}
+
+func (e *PolicyEngine) checkContext(vc *Credential) error {
+	var missing []string
+
+	for _, context := range e.policy.RequiredContexts {
+		if !containsString(vc.Context, context) {
+			missing = append(missing, context)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required contexts %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func (e *PolicyEngine) checkValidityPeriod(vc *Credential) error {
+	now := e.now()
+	skew := time.Duration(e.policy.ClockSkew)
+
+	validFrom := firstTime(vc.ValidFrom, vc.Issued)
+	if validFrom != nil && validFrom.After(now.Add(skew)) {
+		return fmt.Errorf("credential is not valid before %s", validFrom.Format(time.RFC3339))
+	}
+
+	validUntil := firstTime(vc.ValidUntil, vc.Expired)
+	if validUntil != nil && validUntil.Before(now.Add(-skew)) {
+		return fmt.Errorf("credential expired at %s", validUntil.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (e *PolicyEngine) checkStatus(vc *Credential) error {
+	if e.statusChecker == nil {
+		return errors.New("no credential status checker")
+	}
+
+	statuses := vc.Statuses
+	if len(statuses) == 0 && vc.Status != nil {
+		statuses = []TypedID{*vc.Status}
+	}
+
+	for i := range statuses {
+		if err := e.statusChecker(vc, &statuses[i]); err != nil {
+			return fmt.Errorf("credential status %s: %w", statuses[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// firstTime returns the first of the times which is defined.
+func firstTime(times ...*util.TimeWithTrailingZeroMsec) *time.Time {
+	for _, t := range times {
+		if t != nil {
+			return &t.Time
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const verificationPolicyYAML = `
+trustedIssuers:
+  - id: did:example:76e12ec712ebc6f1c221ebfeb1f
+    credentialTypes:
+      - ExampleDegreeCredential
+credentialTypes:
+  - ExampleDegreeCredential
+requiredContexts:
+  - https://www.w3.org/ns/credentials/v2
+checkValidityPeriod: true
+clockSkew: 5m
+checkStatus: true
+`
+
+func TestParseVerificationPolicy(t *testing.T) {
+	t.Run("Parse YAML policy", func(t *testing.T) {
+		policy, err := ParseVerificationPolicy([]byte(verificationPolicyYAML))
+		require.NoError(t, err)
+		require.Equal(t, &VerificationPolicy{
+			TrustedIssuers: []TrustedIssuer{{
+				ID:              "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				CredentialTypes: []string{"ExampleDegreeCredential"},
+			}},
+			CredentialTypes:     []string{"ExampleDegreeCredential"},
+			RequiredContexts:    []string{"https://www.w3.org/ns/credentials/v2"},
+			CheckValidityPeriod: true,
+			ClockSkew:           Duration(5 * time.Minute),
+			CheckStatus:         true,
+		}, policy)
+	})
+
+	t.Run("Parse JSON policy", func(t *testing.T) {
+		policy, err := ParseVerificationPolicy([]byte(`{"proofTypes":["Ed25519Signature2018"],"clockSkew":"30s"}`))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Ed25519Signature2018"}, policy.ProofTypes)
+		require.Equal(t, Duration(30*time.Second), policy.ClockSkew)
+
+		policyJSON, err := toJSONString(policy)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"proofTypes":["Ed25519Signature2018"],"clockSkew":"30s"}`, policyJSON)
+	})
+
+	t.Run("Invalid policies", func(t *testing.T) {
+		for policy, errMsg := range map[string]string{
+			"trustedIssuer: did:example:123": `unknown field "trustedIssuer"`,
+			"clockSkew: 5 minutes":           `time: unknown unit`,
+			"clockSkew: 5":                   `duration is not a string`,
+			"proofTypes: [":                  `decode verification policy`,
+		} {
+			_, err := ParseVerificationPolicy([]byte(policy))
+			require.Error(t, err, policy)
+			require.Contains(t, err.Error(), errMsg, policy)
+		}
+	})
+}
+
+func TestPolicyEngine_Verify(t *testing.T) {
+	policy, err := ParseVerificationPolicy([]byte(verificationPolicyYAML))
+	require.NoError(t, err)
+
+	validStatus := func(*Credential, *TypedID) error { return nil }
+
+	verify := func(t *testing.T, engine *PolicyEngine, vcData string, opts ...CredentialOpt) *VerificationReport {
+		t.Helper()
+
+		engine.now = func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+		report, err := engine.Verify([]byte(vcData),
+			append([]CredentialOpt{WithJSONLDDocumentLoader(createTestDocumentLoader(t))}, opts...)...)
+		require.NoError(t, err)
+
+		return report
+	}
+
+	t.Run("All checks pass", func(t *testing.T) {
+		vcJWS, publicKeyFetcher := issuerSignedJWS(t, "#key1")
+
+		report := verify(t, NewPolicyEngine(policy, WithStatusChecker(validStatus)), vcJWS,
+			WithPublicKeyFetcher(publicKeyFetcher))
+		require.True(t, report.Verified)
+		require.Equal(t, []PolicyCheck{
+			{Rule: PolicyRuleProof, Passed: true},
+			{Rule: PolicyRuleIssuer, Passed: true},
+			{Rule: PolicyRuleCredentialType, Passed: true},
+			{Rule: PolicyRuleContext, Passed: true},
+			{Rule: PolicyRuleValidityPeriod, Passed: true},
+			{Rule: PolicyRuleStatus, Passed: true},
+		}, report.Checks)
+	})
+
+	t.Run("Checks fail", func(t *testing.T) {
+		report := verify(t, NewPolicyEngine(policy), validCredential)
+		require.False(t, report.Verified)
+		require.Equal(t, []PolicyCheck{
+			{Rule: PolicyRuleProof, Passed: true},
+			{Rule: PolicyRuleIssuer, Passed: false,
+				Error: "issuer did:example:76e12ec712ebc6f1c221ebfeb1f is not trusted for credential types " +
+					"VerifiableCredential"},
+			{Rule: PolicyRuleCredentialType, Passed: false,
+				Error: "credential types VerifiableCredential are not accepted"},
+			{Rule: PolicyRuleContext, Passed: false,
+				Error: "missing required contexts https://www.w3.org/ns/credentials/v2"},
+			{Rule: PolicyRuleValidityPeriod, Passed: false, Error: "credential expired at 2020-01-01T19:23:24Z"},
+			{Rule: PolicyRuleStatus, Passed: false, Error: "no credential status checker"},
+		}, report.Checks)
+	})
+
+	t.Run("Issuer not trusted", func(t *testing.T) {
+		report := verify(t, NewPolicyEngine(&VerificationPolicy{
+			TrustedIssuers: []TrustedIssuer{{ID: "did:example:123"}},
+		}), validCredentialV2)
+		require.False(t, report.Verified)
+		require.Equal(t, "issuer did:example:76e12ec712ebc6f1c221ebfeb1f is not trusted", report.Checks[1].Error)
+	})
+
+	t.Run("Credential secured by a key of another DID", func(t *testing.T) {
+		engine := NewPolicyEngine(&VerificationPolicy{
+			TrustedIssuers: []TrustedIssuer{{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}},
+		})
+
+		vc, publicKeyFetcher := createVCWithLinkedDataProof(t)
+
+		vcData, err := toJSONString(vc)
+		require.NoError(t, err)
+
+		report := verify(t, engine, vcData, WithPublicKeyFetcher(publicKeyFetcher))
+		require.False(t, report.Verified)
+		require.Equal(t, []PolicyCheck{
+			{Rule: PolicyRuleProof, Passed: true},
+			{Rule: PolicyRuleIssuer, Passed: false,
+				Error: `proof verification method "did:123#any" is not a key of the issuer ` +
+					"did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		}, report.Checks)
+
+		vcJWS, publicKeyFetcher := issuerSignedJWS(t, "did:example:attacker#key1")
+
+		report = verify(t, engine, vcJWS, WithPublicKeyFetcher(publicKeyFetcher))
+		require.False(t, report.Verified)
+		require.True(t, report.Checks[0].Passed)
+		require.Equal(t, "JWS key did:example:attacker#key1 is not a key of the issuer "+
+			"did:example:76e12ec712ebc6f1c221ebfeb1f", report.Checks[1].Error)
+
+		vcJWS, publicKeyFetcher = issuerSignedJWS(t, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+
+		report = verify(t, engine, vcJWS, WithPublicKeyFetcher(publicKeyFetcher))
+		require.True(t, report.Verified)
+
+		report = verify(t, engine, validCredentialV2)
+		require.False(t, report.Verified)
+		require.Equal(t, "credential is not secured by the issuer did:example:76e12ec712ebc6f1c221ebfeb1f",
+			report.Checks[1].Error)
+	})
+
+	t.Run("Credential not yet valid", func(t *testing.T) {
+		engine := NewPolicyEngine(&VerificationPolicy{CheckValidityPeriod: true})
+
+		report := verify(t, engine, strings.Replace(validCredentialV2, "2010-01-01T19:23:24Z", "2021-01-01T00:01:00Z", 1))
+		require.False(t, report.Verified)
+		require.Equal(t, "credential is not valid before 2021-01-01T00:01:00Z", report.Checks[1].Error)
+
+		engine.policy.ClockSkew = Duration(time.Minute)
+
+		report = verify(t, engine, strings.Replace(validCredentialV2, "2010-01-01T19:23:24Z", "2021-01-01T00:01:00Z", 1))
+		require.True(t, report.Verified)
+	})
+
+	t.Run("Credential status check fails", func(t *testing.T) {
+		report := verify(t, NewPolicyEngine(&VerificationPolicy{CheckStatus: true},
+			WithStatusChecker(func(_ *Credential, status *TypedID) error {
+				if status.CustomFields["statusPurpose"] == "suspension" {
+					return errors.New("suspended")
+				}
+
+				return nil
+			})), validCredentialV2)
+		require.False(t, report.Verified)
+		require.Equal(t, "credential status https://university.example/credentials/status/4#23452: suspended",
+			report.Checks[1].Error)
+	})
+
+	t.Run("Check proofs", func(t *testing.T) {
+		vc, publicKeyFetcher := createVCWithLinkedDataProof(t)
+
+		vcData, err := toJSONString(vc)
+		require.NoError(t, err)
+
+		engine := NewPolicyEngine(&VerificationPolicy{ProofTypes: []string{"Ed25519Signature2018"}})
+
+		report := verify(t, engine, vcData, WithPublicKeyFetcher(publicKeyFetcher))
+		require.True(t, report.Verified)
+		require.Equal(t, []PolicyCheck{
+			{Rule: PolicyRuleProof, Passed: true},
+			{Rule: PolicyRuleProofType, Passed: true},
+		}, report.Checks)
+
+		report = verify(t, engine, strings.Replace(vcData, "credentials/1872", "credentials/1873", 1),
+			WithPublicKeyFetcher(publicKeyFetcher))
+		require.False(t, report.Verified)
+		require.False(t, report.Checks[0].Passed)
+		require.Contains(t, report.Checks[0].Error, "check embedded proof")
+
+		report = verify(t, engine, validCredential)
+		require.False(t, report.Verified)
+		require.Equal(t, "no proof of accepted types Ed25519Signature2018", report.Checks[1].Error)
+	})
+
+	t.Run("JWT proof type", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredentialV2))
+		require.NoError(t, err)
+
+		claims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := claims.MarshalJWS(EdDSA, &noopSigner{}, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+		require.NoError(t, err)
+
+		report := verify(t, NewPolicyEngine(&VerificationPolicy{ProofTypes: []string{JWTProofType}}), vcJWS,
+			WithPublicKeyFetcher(func(string, string) (*verifier.PublicKey, error) {
+				return nil, errors.New("no public key")
+			}))
+		require.False(t, report.Verified)
+		require.Contains(t, report.Checks[0].Error, "no public key")
+		require.Equal(t, PolicyCheck{Rule: PolicyRuleProofType, Passed: true}, report.Checks[1])
+	})
+
+	t.Run("Invalid credential", func(t *testing.T) {
+		_, err := NewPolicyEngine(policy).Verify([]byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse credential")
+	})
+}
+
+// issuerSignedJWS returns validCredentialV2 secured as JWS with a new key of the given key ID.
+func issuerSignedJWS(t *testing.T, keyID string) (string, PublicKeyFetcher) {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, []byte(validCredentialV2))
+	require.NoError(t, err)
+
+	claims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	vcJWS, err := claims.MarshalJWS(EdDSA, signer, keyID)
+	require.NoError(t, err)
+
+	return vcJWS, SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)
+}
+
+func toJSONString(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+
+	return string(b), err
+}
+
+type noopSigner struct{}
+
+func (noopSigner) Sign([]byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
@@ -29,14 +29,20 @@ const basePresentationSchema = `
       "oneOf": [
         {
           "type": "string",
-          "const": "https://www.w3.org/2018/credentials/v1"
+          "enum": [
+            "https://www.w3.org/2018/credentials/v1",
+            "https://www.w3.org/ns/credentials/v2"
+          ]
         },
         {
           "type": "array",
           "items": [
             {
               "type": "string",
-              "const": "https://www.w3.org/2018/credentials/v1"
+              "enum": [
+                "https://www.w3.org/2018/credentials/v1",
+                "https://www.w3.org/ns/credentials/v2"
+              ]
             }
           ],
           "uniqueItems": true,
@@ -220,6 +226,23 @@ func WithJWTCredentials(cs ...string) CreatePresentationOpt {
 	}
 }
 
+// WithEnvelopedCredentials sets the provided base64url encoded JWT credentials into the presentation
+// as Verifiable Credentials Data Model 2.0 EnvelopedVerifiableCredential.
+// The presentation is expected to have the https://www.w3.org/ns/credentials/v2 base context.
+func WithEnvelopedCredentials(cs ...string) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, c := range cs {
+			if !jose.IsCompactJWS(c) {
+				return errors.New("credential is not base64url encoded JWT")
+			}
+
+			p.credentials = append(p.credentials, NewEnvelopedCredential(c))
+		}
+
+		return nil
+	}
+}
+
 // MarshalJSON converts Verifiable Presentation to JSON bytes.
 func (vp *Presentation) MarshalJSON() ([]byte, error) {
 	raw, err := vp.raw()
@@ -463,6 +486,7 @@ func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Present
 // 2) the same as 1) but as array - e.g. zero ore more JWS
 // 3) struct (should be map[string]interface{}) representing credential data model
 // 4) the same as 3) but as array - i.e. zero or more credentials structs.
+// Verifiable Credentials Data Model 2.0 EnvelopedVerifiableCredential structs are decoded as 1).
 func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface{}, error) {
 	// Accept the case when VP does not have any VCs.
 	if rawCred == nil {
@@ -470,6 +494,17 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 	}
 
 	marshalSingleCredFn := func(cred interface{}) (interface{}, error) {
+		if mCred, ok := cred.(map[string]interface{}); ok {
+			sCred, enveloped, err := envelopedCredential(mCred)
+			if err != nil {
+				return nil, fmt.Errorf("decode enveloped credential of presentation: %w", err)
+			}
+
+			if enveloped {
+				cred = sCred
+			}
+		}
+
 		// Check the case when VC is defined in string format (e.g. JWT).
 		// Decode credential and keep result of decoding.
 		if sCred, ok := cred.(string); ok {
@@ -7,7 +7,7 @@ package verifiable
 
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
 func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jpc, signatureAlg, signer, keyID)
+	return marshalJWS(nil, jpc, signatureAlg, signer, keyID)
 }
 
 func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) (*JWTPresClaims, error) {
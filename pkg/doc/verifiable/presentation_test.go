@@ -274,7 +274,7 @@ func TestValidateVP_Context(t *testing.T) {
 		require.NoError(t, err)
 		vp, err := newTestPresentation(t, bytes)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "does not match: \"https://www.w3.org/2018/credentials/v1\"")
+		require.Contains(t, err.Error(), "must be one of the following: \"https://www.w3.org/2018/credentials/v1\"")
 		require.Nil(t, vp)
 	})
 
@@ -297,7 +297,7 @@ func TestValidateVP_Context(t *testing.T) {
 		require.NoError(t, err)
 		vp, err := newTestPresentation(t, bytes)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "does not match: \"https://www.w3.org/2018/credentials/v1\"")
+		require.Contains(t, err.Error(), "must be one of the following: \"https://www.w3.org/2018/credentials/v1\"")
 		require.Nil(t, vp)
 	})
 }
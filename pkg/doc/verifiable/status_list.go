@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+)
+
+// Types of the credential statuses checked against status lists.
+const (
+	// BitstringStatusListEntryType is the status type of the Bitstring Status List specification.
+	BitstringStatusListEntryType = "BitstringStatusListEntry"
+	// StatusList2021EntryType is the status type of the Status List 2021 specification.
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	defaultStatusListTimeout = 10 * time.Second
+	// maxStatusListCredentialSize bounds the status list credentials fetched.
+	maxStatusListCredentialSize = 1 << 20
+	// maxStatusListSize bounds the decompressed status lists, 16 times the size of the largest list recommended.
+	maxStatusListSize = 16 << 20
+)
+
+// StatusListCredentialFetcher fetches and parses the status list credential of the given URL.
+type StatusListCredentialFetcher func(url string) (*Credential, error)
+
+// NewHTTPStatusListFetcher returns a StatusListCredentialFetcher downloading the status list credentials with
+// client, a client with a 10 seconds timeout if nil, and parsing them with opts. The proofs of the status list
+// credentials are checked unless disabled by opts.
+func NewHTTPStatusListFetcher(client *http.Client, opts ...CredentialOpt) StatusListCredentialFetcher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultStatusListTimeout}
+	}
+
+	return func(url string) (*Credential, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("fetch status list credential: %w", err)
+		}
+
+		defer func() {
+			if e := resp.Body.Close(); e != nil {
+				logger.Errorf("closing response body failed [%v]", e)
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("status list credential endpoint HTTP failure [%v]", resp.StatusCode)
+		}
+
+		vcData, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxStatusListCredentialSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("status list credential: read response body: %w", err)
+		}
+
+		if len(vcData) > maxStatusListCredentialSize {
+			return nil, errors.New("status list credential too large")
+		}
+
+		return ParseCredential(vcData, opts...)
+	}
+}
+
+// NewStatusListChecker returns a CredentialStatusChecker of the BitstringStatusListEntry and StatusList2021Entry
+// statuses, fetching the status list credentials with fetch. A status fails the check if its bit is set in the
+// status list, or if the status list credential is not issued by the issuer of the credential.
+func NewStatusListChecker(fetch StatusListCredentialFetcher) CredentialStatusChecker {
+	return func(vc *Credential, status *TypedID) error {
+		if status.Type != BitstringStatusListEntryType && status.Type != StatusList2021EntryType {
+			return fmt.Errorf("unsupported credential status type %s", status.Type)
+		}
+
+		index, err := statusListIndex(status.CustomFields["statusListIndex"])
+		if err != nil {
+			return err
+		}
+
+		listURL, ok := status.CustomFields["statusListCredential"].(string)
+		if !ok || listURL == "" {
+			return errors.New("statusListCredential is not defined")
+		}
+
+		purpose, _ := status.CustomFields["statusPurpose"].(string) // nolint:errcheck
+
+		listVC, err := fetch(listURL)
+		if err != nil {
+			return err
+		}
+
+		if listVC.Issuer.ID != vc.Issuer.ID {
+			return fmt.Errorf("status list credential is issued by %s, not by the issuer %s",
+				listVC.Issuer.ID, vc.Issuer.ID)
+		}
+
+		set, err := statusListBit(listVC, status.Type, purpose, index)
+		if err != nil {
+			return err
+		}
+
+		if set {
+			return fmt.Errorf("status %s is set", purpose)
+		}
+
+		return nil
+	}
+}
+
+// statusListIndex reads the status list index, defined as a string by the specifications or as a number.
+func statusListIndex(value interface{}) (int, error) {
+	var (
+		index int
+		err   error
+	)
+
+	switch v := value.(type) {
+	case string:
+		index, err = strconv.Atoi(v)
+	case float64:
+		index = int(v)
+		if float64(index) != v {
+			err = errors.New("not an integer")
+		}
+	default:
+		err = errors.New("not defined")
+	}
+
+	if err == nil && index < 0 {
+		err = errors.New("negative")
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid statusListIndex: %w", err)
+	}
+
+	return index, nil
+}
+
+type statusListSubject struct {
+	StatusPurpose string `json:"statusPurpose,omitempty"`
+	EncodedList   string `json:"encodedList"`
+}
+
+// statusListBit returns the bit of the status list credential at index, the first index being the most
+// significant bit of the first byte.
+func statusListBit(listVC *Credential, statusType, purpose string, index int) (bool, error) {
+	subjectBytes, err := subjectToBytes(listVC.Subject)
+	if err != nil {
+		return false, fmt.Errorf("status list credential subject: %w", err)
+	}
+
+	subject := &statusListSubject{}
+
+	if err = json.Unmarshal(subjectBytes, subject); err != nil {
+		return false, fmt.Errorf("status list credential subject: %w", err)
+	}
+
+	if subject.StatusPurpose != "" && subject.StatusPurpose != purpose {
+		return false, fmt.Errorf("status list purpose %s does not match the status purpose %s",
+			subject.StatusPurpose, purpose)
+	}
+
+	list, err := decodeStatusList(subject.EncodedList, statusType)
+	if err != nil {
+		return false, err
+	}
+
+	if index/8 >= len(list) {
+		return false, fmt.Errorf("statusListIndex %d is out of the status list", index)
+	}
+
+	return list[index/8]&(1<<(7-uint(index%8))) != 0, nil
+}
+
+// decodeStatusList decodes the GZIP compressed status list, which is multibase encoded by the Bitstring Status
+// List specification and base64url encoded without multibase prefix by the Status List 2021 specification.
+func decodeStatusList(encodedList, statusType string) ([]byte, error) {
+	var (
+		compressed []byte
+		err        error
+	)
+
+	if statusType == BitstringStatusListEntryType {
+		_, compressed, err = multibase.Decode(encodedList)
+	} else {
+		compressed, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedList, "="))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("decode status list: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	list, err := ioutil.ReadAll(io.LimitReader(reader, maxStatusListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	if len(list) > maxStatusListSize {
+		return nil, errors.New("status list too large")
+	}
+
+	return list, nil
+}
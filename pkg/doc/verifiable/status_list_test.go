@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusListChecker(t *testing.T) {
+	lists := map[string]string{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		list, ok := lists[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, err := w.Write([]byte(list))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	vc, err := parseTestCredential(t, []byte(strings.ReplaceAll(validCredentialV2,
+		"https://university.example", srv.URL)))
+	require.NoError(t, err)
+
+	checker := NewStatusListChecker(NewHTTPStatusListFetcher(srv.Client(),
+		WithJSONLDDocumentLoader(createTestDocumentLoader(t)), WithDisabledProofCheck()))
+
+	lists["/credentials/status/3"] = statusListCredential(t, "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"revocation", "u"+encodeStatusList(t, 94566, 94568))
+	lists["/credentials/status/4"] = statusListCredential(t, "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"suspension", "u"+encodeStatusList(t, 23452))
+
+	t.Run("status not set", func(t *testing.T) {
+		require.NoError(t, checker(vc, &vc.Statuses[0]))
+	})
+
+	t.Run("status set", func(t *testing.T) {
+		require.EqualError(t, checker(vc, &vc.Statuses[1]), "status suspension is set")
+
+		report, err := NewPolicyEngine(&VerificationPolicy{CheckStatus: true}, WithStatusChecker(checker)).
+			Verify([]byte(strings.ReplaceAll(validCredentialV2, "https://university.example", srv.URL)),
+				WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.False(t, report.Verified)
+		require.Equal(t, fmt.Sprintf("credential status %s/credentials/status/4#23452: status suspension is set",
+			srv.URL), report.Checks[1].Error)
+	})
+
+	t.Run("status list 2021", func(t *testing.T) {
+		lists["/2021"] = statusListCredential(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", "revocation",
+			encodeStatusList(t, 7))
+
+		status := &TypedID{Type: StatusList2021EntryType, CustomFields: CustomFields{
+			"statusPurpose":        "revocation",
+			"statusListIndex":      "7",
+			"statusListCredential": srv.URL + "/2021",
+		}}
+		require.EqualError(t, checker(vc, status), "status revocation is set")
+
+		status.CustomFields["statusListIndex"] = float64(6)
+		require.NoError(t, checker(vc, status))
+	})
+
+	t.Run("status list of another issuer", func(t *testing.T) {
+		lists["/other"] = statusListCredential(t, "did:example:other", "revocation", "u"+encodeStatusList(t))
+
+		status := &TypedID{Type: BitstringStatusListEntryType, CustomFields: CustomFields{
+			"statusPurpose":        "revocation",
+			"statusListIndex":      "0",
+			"statusListCredential": srv.URL + "/other",
+		}}
+		require.EqualError(t, checker(vc, status), "status list credential is issued by did:example:other, "+
+			"not by the issuer did:example:76e12ec712ebc6f1c221ebfeb1f")
+	})
+
+	t.Run("invalid statuses", func(t *testing.T) {
+		for errMsg, status := range map[string]*TypedID{
+			"unsupported credential status type CredentialStatusList2017": {Type: "CredentialStatusList2017"},
+			"invalid statusListIndex: not defined":                        {Type: BitstringStatusListEntryType},
+			"invalid statusListIndex: negative": {Type: BitstringStatusListEntryType,
+				CustomFields: CustomFields{"statusListIndex": "-1"}},
+			"statusListCredential is not defined": {Type: BitstringStatusListEntryType,
+				CustomFields: CustomFields{"statusListIndex": "1"}},
+			"status list credential endpoint HTTP failure [404]": {Type: BitstringStatusListEntryType,
+				CustomFields: CustomFields{"statusListIndex": "1", "statusListCredential": srv.URL + "/none"}},
+			"status list purpose revocation does not match the status purpose suspension": {
+				Type: BitstringStatusListEntryType, CustomFields: CustomFields{"statusPurpose": "suspension",
+					"statusListIndex": "1", "statusListCredential": srv.URL + "/credentials/status/3"}},
+			"statusListIndex 200000 is out of the status list": {Type: BitstringStatusListEntryType,
+				CustomFields: CustomFields{"statusPurpose": "revocation", "statusListIndex": "200000",
+					"statusListCredential": srv.URL + "/credentials/status/3"}},
+		} {
+			err := checker(vc, status)
+			require.Error(t, err, errMsg)
+			require.Contains(t, err.Error(), errMsg)
+		}
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		fetchErr := errors.New("fetch error")
+
+		err := NewStatusListChecker(func(string) (*Credential, error) { return nil, fetchErr })(vc, &vc.Statuses[0])
+		require.ErrorIs(t, err, fetchErr)
+	})
+}
+
+// statusListCredential returns a status list credential of the Bitstring Status List specification.
+func statusListCredential(t *testing.T, issuer, purpose, encodedList string) string {
+	t.Helper()
+
+	return fmt.Sprintf(`{
+  "@context": ["https://www.w3.org/ns/credentials/v2"],
+  "id": "https://university.example/credentials/status/3",
+  "type": ["VerifiableCredential", "BitstringStatusListCredential"],
+  "issuer": %q,
+  "validFrom": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "https://university.example/credentials/status/3#list",
+    "type": "BitstringStatusList",
+    "statusPurpose": %q,
+    "encodedList": %q
+  }
+}`, issuer, purpose, encodedList)
+}
+
+// encodeStatusList returns the base64url encoded GZIP compressed status list of 131072 entries with the given
+// indexes set.
+func encodeStatusList(t *testing.T, set ...int) string {
+	t.Helper()
+
+	list := make([]byte, 16384)
+
+	for _, i := range set {
+		list[i/8] |= 1 << (7 - uint(i%8))
+	}
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write(list)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
@@ -12,8 +12,26 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 )
 
-// ErrNotFound is returned when a DID resolver does not find the DID.
-var ErrNotFound = errors.New("DID not found")
+var (
+	// ErrNotFound is returned when a DID resolver does not find the DID.
+	ErrNotFound = errors.New("DID not found")
+	// ErrInvalidDID is returned when the DID does not conform to the DID syntax.
+	ErrInvalidDID = errors.New("invalid DID")
+	// ErrInvalidDIDURL is returned when the DID URL does not conform to the DID URL syntax.
+	ErrInvalidDIDURL = errors.New("invalid DID URL")
+	// ErrMethodNotSupported is returned when no VDR accepts the DID method.
+	ErrMethodNotSupported = errors.New("DID method not supported")
+	// ErrDeactivated is returned when the DID has been deactivated.
+	ErrDeactivated = errors.New("DID deactivated")
+)
+
+const (
+	// VersionIDOpt is the did method option for resolving a specific version of a DID document.
+	VersionIDOpt = "versionId"
+	// VersionTimeOpt is the did method option for resolving the DID document version valid at the given time.
+	// The value is either a time.Time or an RFC3339 formatted string.
+	VersionTimeOpt = "versionTime"
+)
 
 // DIDCommServiceType default DID Communication service endpoint type.
 const DIDCommServiceType = "did-communication"
@@ -21,6 +39,7 @@ const DIDCommServiceType = "did-communication"
 // Registry vdr registry.
 type Registry interface {
 	Resolve(did string, opts ...DIDMethodOption) (*did.DocResolution, error)
+	Dereference(didURL string, opts ...DIDMethodOption) (*did.DereferencingResult, error)
 	Create(method string, did *did.Doc, opts ...DIDMethodOption) (*did.DocResolution, error)
 	Update(did *did.Doc, opts ...DIDMethodOption) error
 	Deactivate(did string, opts ...DIDMethodOption) error
@@ -52,3 +71,41 @@ func WithOption(name string, value interface{}) DIDMethodOption {
 		didMethodOpts.Values[name] = value
 	}
 }
+
+// ResolutionErrorCode returns the didResolutionMetadata error code for the given resolution error,
+// or an empty string if the error has no standard code.
+func ResolutionErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return did.ResolutionErrorNotFound
+	case errors.Is(err, ErrInvalidDID):
+		return did.ResolutionErrorInvalidDID
+	case errors.Is(err, ErrInvalidDIDURL):
+		return did.ResolutionErrorInvalidDIDURL
+	case errors.Is(err, ErrMethodNotSupported):
+		return did.ResolutionErrorMethodNotSupported
+	case errors.Is(err, ErrDeactivated):
+		return did.ResolutionErrorDeactivated
+	default:
+		return ""
+	}
+}
+
+// ResolutionError returns the resolution error matching the given didResolutionMetadata error code,
+// or nil if the code is unknown.
+func ResolutionError(code string) error {
+	switch code {
+	case did.ResolutionErrorNotFound:
+		return ErrNotFound
+	case did.ResolutionErrorInvalidDID:
+		return ErrInvalidDID
+	case did.ResolutionErrorInvalidDIDURL:
+		return ErrInvalidDIDURL
+	case did.ResolutionErrorMethodNotSupported:
+		return ErrMethodNotSupported
+	case did.ResolutionErrorDeactivated:
+		return ErrDeactivated
+	default:
+		return nil
+	}
+}
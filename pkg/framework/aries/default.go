@@ -18,7 +18,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
@@ -27,6 +30,7 @@ import (
 	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -71,8 +75,9 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 	// - OutOfBand depends on DIDExchange
 	// - Introduce depends on OutOfBand
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
-		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+		newMessagePickupSvc(frameworkOpts.messagePickupOpts...), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
+		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newTrustPingSvc(),
+		newDiscoverFeaturesSvc(), newBasicMessageSvc(), newActionMenuSvc())
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -135,14 +140,43 @@ func newRouteSvc() api.ProtocolSvcCreator {
 	}
 }
 
-func newMessagePickupSvc() api.ProtocolSvcCreator {
+func newMessagePickupSvc(opts ...messagepickup.Opt) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		tp, ok := prv.(transport.Provider)
 		if !ok {
 			return nil, errors.New("failed to cast transport provider")
 		}
 
-		return messagepickup.New(prv, tp)
+		return messagepickup.New(prv, tp, opts...)
+	}
+}
+
+func newTrustPingSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return trustping.New(prv)
+	}
+}
+
+func newDiscoverFeaturesSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		registry, ok := prv.(discoverfeatures.Registry)
+		if !ok {
+			return nil, errors.New("failed to cast service registry")
+		}
+
+		return discoverfeatures.New(prv, registry)
+	}
+}
+
+func newBasicMessageSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return basicmessage.New(prv)
+	}
+}
+
+func newActionMenuSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return actionmenu.New(prv)
 	}
 }
 
@@ -15,9 +15,10 @@ import (
 )
 
 // WithInboundHTTPAddr return new default http inbound transport.
-func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string) aries.Option {
+func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string,
+	httpOpts ...http.InboundHTTPOpt) aries.Option {
 	return func(opts *aries.Aries) error {
-		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile)
+		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile, httpOpts...)
 		if err != nil {
 			return fmt.Errorf("http inbound transport initialization failed : %w", err)
 		}
@@ -27,9 +28,9 @@ func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string) a
 }
 
 // WithInboundWSAddr return new default ws inbound transport.
-func WithInboundWSAddr(internalAddr, externalAddr, certFile, keyFile string) aries.Option {
+func WithInboundWSAddr(internalAddr, externalAddr, certFile, keyFile string, wsOpts ...ws.Opt) aries.Option {
 	return func(opts *aries.Aries) error {
-		inbound, err := ws.NewInbound(internalAddr, externalAddr, certFile, keyFile)
+		inbound, err := ws.NewInbound(internalAddr, externalAddr, certFile, keyFile, wsOpts...)
 		if err != nil {
 			return fmt.Errorf("ws inbound transport initialization failed : %w", err)
 		}
@@ -7,39 +7,52 @@ SPDX-License-Identifier: Apache-2.0
 package aries
 
 import (
+	gocontext "context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	cryptoinstrumented "github.com/hyperledger/aries-framework-go/pkg/crypto/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	kmsinstrumented "github.com/hyperledger/aries-framework-go/pkg/kms/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/pkh"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
-	defaultEndpoint     = "didcomm:transport/queue"
-	defaultMasterKeyURI = "local-lock://default/master/key/"
+	defaultEndpoint        = "didcomm:transport/queue"
+	defaultMasterKeyURI    = "local-lock://default/master/key/"
+	defaultShutdownTimeout = 10 * time.Second
 )
 
+var logger = log.New("aries-framework/framework")
+
 // Aries provides access to the context being managed by the framework. The context can be used to create aries clients.
 type Aries struct {
 	storeProvider              storage.Provider
@@ -66,10 +79,19 @@ type Aries struct {
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
 	jsonldDocumentLoader       ld.DocumentLoader
+	trustRegistry              trustregistry.Provider
 	transportReturnRoute       string
 	id                         string
 	keyType                    kms.KeyType
 	keyAgreementType           kms.KeyType
+	inboundTracker             shutdown.Tracker
+	shutdownTimeout            time.Duration
+	messagePickupOpts          []messagepickup.Opt
+}
+
+// gracefulInbound is implemented by the inbound transports which can stop within a deadline.
+type gracefulInbound interface {
+	Shutdown(ctx gocontext.Context) error
 }
 
 // Option configures the framework.
@@ -78,7 +100,7 @@ type Option func(opts *Aries) error
 // New initializes the Aries framework based on the set of options provided. This function returns a framework
 // which can be used to manage Aries clients by getting the framework context.
 func New(opts ...Option) (*Aries, error) {
-	frameworkOpts := &Aries{}
+	frameworkOpts := &Aries{shutdownTimeout: defaultShutdownTimeout}
 
 	// generate framework configs from options
 	for _, option := range opts {
@@ -114,6 +136,9 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, e
 	}
 
+	frameworkOpts.kms = kmsinstrumented.New(frameworkOpts.kms)
+	frameworkOpts.crypto = cryptoinstrumented.New(frameworkOpts.crypto)
+
 	// Create vdr
 	if e := createVDR(frameworkOpts); e != nil {
 		return nil, e
@@ -295,6 +320,15 @@ func WithJSONLDDocumentLoader(loader ld.DocumentLoader) Option {
 	}
 }
 
+// WithTrustRegistry injects a trust registry authorizing issuers and verifiers. The present-proof and
+// issue-credential action events then carry the trust decision about the verifier or the issuers.
+func WithTrustRegistry(registry trustregistry.Provider) Option {
+	return func(opts *Aries) error {
+		opts.trustRegistry = registry
+		return nil
+	}
+}
+
 // WithKeyType injects a default signing key type.
 func WithKeyType(keyType kms.KeyType) Option {
 	return func(opts *Aries) error {
@@ -311,6 +345,29 @@ func WithKeyAgreementType(keyAgreementType kms.KeyType) Option {
 	}
 }
 
+// WithShutdownTimeout sets how long Close waits for the inbound messages being handled, the protocol services and
+// the outbound messages being sent to be drained, 10 seconds by default.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(opts *Aries) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid shutdown timeout : %s", timeout)
+		}
+
+		opts.shutdownTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithMessagePickupQueue configures the queues of the messages the mediator keeps for the recipients it couldn't
+// deliver to, like their limits, the message TTL and the push notifier of the offline clients.
+func WithMessagePickupQueue(queueOpts ...messagepickup.Opt) Option {
+	return func(opts *Aries) error {
+		opts.messagePickupOpts = append(opts.messagePickupOpts, queueOpts...)
+		return nil
+	}
+}
+
 // Context provides a handle to the framework context.
 func (a *Aries) Context() (*context.Provider, error) {
 	return context.New(
@@ -334,8 +391,10 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithVerifiableStore(a.verifiableStore),
 		context.WithDIDConnectionStore(a.didConnectionStore),
 		context.WithJSONLDDocumentLoader(a.jsonldDocumentLoader),
+		context.WithTrustRegistry(a.trustRegistry),
 		context.WithKeyType(a.keyType),
 		context.WithKeyAgreementType(a.keyAgreementType),
+		context.WithInboundTracker(&a.inboundTracker),
 	)
 }
 
@@ -345,8 +404,83 @@ func (a *Aries) Messenger() service.Messenger {
 	return a.messenger
 }
 
-// Close frees resources being maintained by the framework.
+// Close gracefully shuts the framework down, waiting for its in-flight work up to the shutdown timeout, and frees
+// the resources being maintained by the framework.
 func (a *Aries) Close() error {
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	return a.Shutdown(ctx)
+}
+
+// Shutdown gracefully shuts the framework down. The inbound transports stop accepting messages, then the inbound
+// messages being handled, the protocol services and the outbound messages being sent are drained until ctx is done,
+// before the stores are closed. The in-flight work not completed when ctx is done is abandoned, and reported in the
+// returned error once the stores are closed.
+func (a *Aries) Shutdown(ctx gocontext.Context) error {
+	for _, inbound := range a.inboundTransports {
+		if err := stopInbound(ctx, inbound); err != nil {
+			return fmt.Errorf("inbound transport close failed: %w", err)
+		}
+	}
+
+	drainErr := a.drain(ctx)
+
+	if err := a.closeStores(); err != nil {
+		return err
+	}
+
+	if err := a.closeVDR(); err != nil {
+		return err
+	}
+
+	return drainErr
+}
+
+// drain waits for the inbound messages being handled, then for the protocol services, the dependent services
+// first, and finally for the outbound messages being sent.
+func (a *Aries) drain(ctx gocontext.Context) error {
+	var drainErr error
+
+	if err := a.inboundTracker.Drain(ctx); err != nil {
+		drainErr = fmt.Errorf("drain inbound messages: %w", err)
+		logger.Warnf("shutdown: %s", drainErr)
+	}
+
+	for i := len(a.services) - 1; i >= 0; i-- {
+		if d, ok := a.services[i].(dispatcher.Drainer); ok {
+			if err := d.Drain(ctx); err != nil {
+				logger.Warnf("shutdown: %s", err)
+
+				if drainErr == nil {
+					drainErr = err
+				}
+			}
+		}
+	}
+
+	if d, ok := a.outboundDispatcher.(dispatcher.Drainer); ok {
+		if err := d.Drain(ctx); err != nil {
+			logger.Warnf("shutdown: %s", err)
+
+			if drainErr == nil {
+				drainErr = err
+			}
+		}
+	}
+
+	return drainErr
+}
+
+func stopInbound(ctx gocontext.Context, inbound transport.InboundTransport) error {
+	if g, ok := inbound.(gracefulInbound); ok {
+		return g.Shutdown(ctx)
+	}
+
+	return inbound.Stop()
+}
+
+func (a *Aries) closeStores() error {
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -361,13 +495,7 @@ func (a *Aries) Close() error {
 		}
 	}
 
-	for _, inbound := range a.inboundTransports {
-		if err := inbound.Stop(); err != nil {
-			return fmt.Errorf("inbound transport close failed: %w", err)
-		}
-	}
-
-	return a.closeVDR()
+	return nil
 }
 
 func (a *Aries) closeVDR() error {
@@ -425,7 +553,7 @@ func createVDR(frameworkOpts *Aries) error {
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(jwk.New()), vdr.WithVDR(pkh.New()))
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -508,6 +636,7 @@ func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 
 func startTransports(frameworkOpts *Aries) error {
 	ctx, err := context.New(
+		context.WithKMS(frameworkOpts.kms),
 		context.WithCrypto(frameworkOpts.crypto),
 		context.WithPackager(frameworkOpts.packager),
 		context.WithProtocolServices(frameworkOpts.services...),
@@ -515,6 +644,7 @@ func startTransports(frameworkOpts *Aries) error {
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithMessengerHandler(frameworkOpts.messenger),
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
+		context.WithInboundTracker(&frameworkOpts.inboundTracker),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
@@ -553,6 +683,7 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithJSONLDDocumentLoader(frameworkOpts.jsonldDocumentLoader),
+		context.WithTrustRegistry(frameworkOpts.trustRegistry),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
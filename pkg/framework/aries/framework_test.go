@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package aries
 
 import (
+	gocontext "context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -18,16 +19,21 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
@@ -37,6 +43,7 @@ import (
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	kmsinstrumented "github.com/hyperledger/aries-framework-go/pkg/kms/instrumented"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
@@ -51,7 +58,9 @@ import (
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	trustregistryfile "github.com/hyperledger/aries-framework-go/pkg/trustregistry/file"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 //nolint:lll
@@ -190,6 +199,25 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - default did:jwk and did:pkh vdr", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		didJWK := "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9" //nolint:lll
+
+		resolvedDoc, err := aries.vdrRegistry.Resolve(didJWK)
+		require.NoError(t, err)
+		require.Equal(t, didJWK, resolvedDoc.DIDDocument.ID)
+
+		didPKH := "did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a"
+
+		resolvedDoc, err = aries.vdrRegistry.Resolve(didPKH)
+		require.NoError(t, err)
+		require.Equal(t, didPKH, resolvedDoc.DIDDocument.ID)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with default protocol", func(t *testing.T) {
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
 		require.NoError(t, err)
@@ -204,6 +232,32 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test protocol svc - discloses the default protocols", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+		require.NoError(t, err)
+
+		var pids []string
+
+		for _, disclosure := range svc.(*discoverfeatures.Service).Disclose("*") {
+			pids = append(pids, disclosure.ID)
+		}
+
+		require.Contains(t, pids, "https://didcomm.org/didexchange/1.0")
+		require.Contains(t, pids, "https://didcomm.org/present-proof/2.0")
+		require.Contains(t, pids, "https://didcomm.org/trust-ping/2.0")
+		require.Contains(t, pids, "https://didcomm.org/discover-features/2.0")
+		require.Contains(t, pids, "https://didcomm.org/basicmessage/2.0")
+		require.Contains(t, pids, "https://didcomm.org/action-menu/1.0")
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with user provided protocol", func(t *testing.T) {
 		newMockSvc := func(prv api.Provider) (dispatcher.ProtocolService, error) {
 			return &mockdidexchange.MockDIDExchangeSvc{
@@ -256,6 +310,79 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "error creating the protocol")
 	})
 
+	t.Run("test graceful shutdown", func(t *testing.T) {
+		var events []string
+
+		inbound := &gracefulInboundTransport{events: &events}
+		svc := &drainingSvc{
+			MockDIDExchangeSvc: mockdidexchange.MockDIDExchangeSvc{ProtocolName: "mockProtocolSvc"},
+			events:             &events,
+		}
+
+		aries, err := New(
+			WithStoreProvider(&closingStoreProvider{Provider: mem.NewProvider(), events: &events}),
+			WithProtocols(func(api.Provider) (dispatcher.ProtocolService, error) { return svc, nil }),
+			WithInboundTransport(inbound),
+			WithShutdownTimeout(time.Second))
+		require.NoError(t, err)
+
+		require.NoError(t, aries.Close())
+		require.Equal(t, []string{"inbound shutdown", "service drained", "store closed"}, events)
+
+		// the inbound messages are refused once drained
+		err = inbound.prov.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{"@type":"type"}`)})
+		require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+	})
+
+	t.Run("test graceful shutdown - drain error", func(t *testing.T) {
+		var events []string
+
+		svc := &drainingSvc{
+			MockDIDExchangeSvc: mockdidexchange.MockDIDExchangeSvc{ProtocolName: "mockProtocolSvc"},
+			events:             &events,
+			drainErr:           gocontext.DeadlineExceeded,
+		}
+
+		aries, err := New(
+			WithStoreProvider(&closingStoreProvider{Provider: mem.NewProvider(), events: &events}),
+			WithProtocols(func(api.Provider) (dispatcher.ProtocolService, error) { return svc, nil }),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		// the stores are closed even though the service wasn't drained in time
+		err = aries.Close()
+		require.ErrorIs(t, err, gocontext.DeadlineExceeded)
+		require.Equal(t, []string{"service drained", "store closed"}, events)
+	})
+
+	t.Run("test message pickup queue options", func(t *testing.T) {
+		aries, err := New(WithStoreProvider(mem.NewProvider()), WithMessagePickupQueue(
+			messagepickup.WithMaxMessages(1), messagepickup.WithEvictionPolicy(messagepickup.RejectNew),
+		))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(messagepickup.MessagePickup)
+		require.NoError(t, err)
+
+		pickup, ok := svc.(*messagepickup.Service)
+		require.True(t, ok)
+
+		require.NoError(t, pickup.AddMessage(&model.Envelope{CipherText: "first"}, "their-did"))
+		require.ErrorIs(t, pickup.AddMessage(&model.Envelope{CipherText: "second"}, "their-did"),
+			messagepickup.ErrQueueFull)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test invalid shutdown timeout", func(t *testing.T) {
+		_, err := New(WithShutdownTimeout(0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid shutdown timeout")
+	})
+
 	t.Run("test Inbound transport - with options", func(t *testing.T) {
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
 		require.NoError(t, err)
@@ -515,7 +642,8 @@ func TestFramework(t *testing.T) {
 		}), WithStoreProvider(storage.NewMockStoreProvider()))
 		require.NoError(t, err)
 		require.NotEmpty(t, a)
-		require.Equal(t, customKMS, a.kms)
+		require.IsType(t, &kmsinstrumented.KeyManager{}, a.kms)
+		require.Equal(t, customKMS, a.kms.(*kmsinstrumented.KeyManager).Unwrap())
 
 		err = a.Close()
 		require.NoError(t, err)
@@ -623,6 +751,18 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, loader, aries.jsonldDocumentLoader)
 	})
 
+	t.Run("test trust registry option", func(t *testing.T) {
+		registry := &trustregistryfile.Registry{}
+
+		aries, err := New(WithTrustRegistry(registry))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, registry, ctx.TrustRegistry())
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test JSON-LD document loader creation error", func(t *testing.T) {
 		_, err := New(
 			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: "jsonldContexts"}),
@@ -727,3 +867,44 @@ func (m *mockInboundTransport) Stop() error {
 func (m *mockInboundTransport) Endpoint() string {
 	return ""
 }
+
+type gracefulInboundTransport struct {
+	mockInboundTransport
+	prov   transport.Provider
+	events *[]string
+}
+
+func (m *gracefulInboundTransport) Start(prov transport.Provider) error {
+	m.prov = prov
+
+	return nil
+}
+
+func (m *gracefulInboundTransport) Shutdown(gocontext.Context) error {
+	*m.events = append(*m.events, "inbound shutdown")
+
+	return nil
+}
+
+type drainingSvc struct {
+	mockdidexchange.MockDIDExchangeSvc
+	events   *[]string
+	drainErr error
+}
+
+func (m *drainingSvc) Drain(gocontext.Context) error {
+	*m.events = append(*m.events, "service drained")
+
+	return m.drainErr
+}
+
+type closingStoreProvider struct {
+	spi.Provider
+	events *[]string
+}
+
+func (p *closingStoreProvider) Close() error {
+	*p.events = append(*p.events, "store closed")
+
+	return p.Provider.Close()
+}
@@ -7,12 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -25,9 +31,21 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// unroutedService is the service label of the inbound messages no service accepted.
+const unroutedService = "none"
+
+//nolint:gochecknoglobals
+var (
+	logger = log.New("aries-framework/framework/context")
+
+	inboundDispatchDuration = metrics.NewHistogram("aries_dispatcher_inbound_duration_seconds",
+		"Time spent by the protocol and message services to handle an inbound message.", nil, "service", "status")
+)
+
 // package context creates a framework Provider context to add optional (non default) framework services and provides
 // simple accessor methods to those same services.
 
@@ -52,10 +70,12 @@ type Provider struct {
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
 	jsonldDocumentLoader       ld.DocumentLoader
+	trustRegistry              trustregistry.Provider
 	transportReturnRoute       string
 	frameworkID                string
 	keyType                    kms.KeyType
 	keyAgreementType           kms.KeyType
+	inboundTracker             *shutdown.Tracker
 }
 
 type inboundHandler struct {
@@ -107,6 +127,20 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return nil, api.ErrSvcNotFound
 }
 
+// ProtocolServices returns the loaded protocol services.
+func (p *Provider) ProtocolServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+// MessageServices returns the registered message services.
+func (p *Provider) MessageServices() []dispatcher.MessageService {
+	if p.msgSvcProvider == nil {
+		return nil
+	}
+
+	return p.msgSvcProvider.Services()
+}
+
 // KMS returns a Key Management Service.
 func (p *Provider) KMS() kms.KeyManager {
 	return p.kms
@@ -170,56 +204,97 @@ func (p *Provider) tryToHandle(
 // InboundMessageHandler return an inbound message handler.
 func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 	return func(envelope *transport.Envelope) error {
+		if p.inboundTracker != nil {
+			done, err := p.inboundTracker.Add()
+			if err != nil {
+				return fmt.Errorf("inbound message handler: %w", err)
+			}
+
+			defer done()
+		}
+
 		msg, err := service.ParseDIDCommMsgMap(envelope.Message)
 		if err != nil {
 			return err
 		}
 
-		// find the service which accepts the message type
-		for _, svc := range p.services {
-			if svc.Accept(msg.Type()) {
-				var myDID, theirDID string
-
-				switch svc.Name() {
-				// perf: DID exchange doesn't require myDID and theirDID
-				case didexchange.DIDExchange:
-				default:
-					myDID, theirDID, err = p.getDIDs(envelope)
-					if err != nil {
-						return fmt.Errorf("inbound message handler: %w", err)
-					}
-				}
-
-				_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		start := time.Now()
+		_, span := tracing.Start(context.Background(), "dispatcher.inbound",
+			tracing.Attr("didcomm.type", msg.Type()))
 
-				return err
-			}
+		if thid, e := msg.ThreadID(); e == nil {
+			span.SetThreadID(thid)
 		}
 
-		// in case of no services are registered for given message type,
-		// find generic inbound services registered for given message header
-		for _, svc := range p.msgSvcProvider.Services() {
-			h := struct {
-				Purpose []string `json:"~purpose"`
-			}{}
-			err = msg.Decode(&h)
+		svcName, err := p.handleInbound(msg, envelope)
 
-			if err != nil {
-				return err
-			}
+		span.SetAttributes(tracing.Attr("service", svcName))
+		span.RecordError(err)
+		span.End()
 
-			if svc.Accept(msg.Type(), h.Purpose) {
-				myDID, theirDID, err := p.getDIDs(envelope)
+		elapsed := time.Since(start)
+		inboundDispatchDuration.Observe(elapsed.Seconds(), svcName, metrics.Status(err))
+
+		msgLogger := logger.With(append(service.LogFields(msg), "service", svcName)...)
+		if err != nil {
+			msgLogger.Debugf("failed to handle inbound message in %s: %s", elapsed, err)
+		} else {
+			msgLogger.Debugf("handled inbound message in %s", elapsed)
+		}
+
+		return err
+	}
+}
+
+// handleInbound routes msg to the service accepting it and returns the name of this service.
+func (p *Provider) handleInbound(msg service.DIDCommMsgMap, envelope *transport.Envelope) (string, error) {
+	// find the service which accepts the message type
+	for _, svc := range p.services {
+		if svc.Accept(msg.Type()) {
+			var (
+				myDID, theirDID string
+				err             error
+			)
+
+			switch svc.Name() {
+			// perf: DID exchange doesn't require myDID and theirDID
+			case didexchange.DIDExchange:
+			default:
+				myDID, theirDID, err = p.getDIDs(envelope)
 				if err != nil {
-					return fmt.Errorf("inbound message handler: %w", err)
+					return svc.Name(), fmt.Errorf("inbound message handler: %w", err)
 				}
-
-				return p.tryToHandle(svc, msg, service.NewDIDCommContext(myDID, theirDID, nil))
 			}
+
+			_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+
+			return svc.Name(), err
 		}
+	}
+
+	// in case of no services are registered for given message type,
+	// find generic inbound services registered for given message header
+	for _, svc := range p.msgSvcProvider.Services() {
+		h := struct {
+			Purpose []string `json:"~purpose"`
+		}{}
 
-		return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
+		err := msg.Decode(&h)
+		if err != nil {
+			return unroutedService, err
+		}
+
+		if svc.Accept(msg.Type(), h.Purpose) {
+			myDID, theirDID, err := p.getDIDs(envelope)
+			if err != nil {
+				return svc.Name(), fmt.Errorf("inbound message handler: %w", err)
+			}
+
+			return svc.Name(), p.tryToHandle(svc, msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		}
 	}
+
+	return unroutedService, fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
 }
 
 func (p *Provider) getDIDs(envelope *transport.Envelope) (string, string, error) {
@@ -288,6 +363,11 @@ func (p *Provider) JSONLDDocumentLoader() ld.DocumentLoader {
 	return p.jsonldDocumentLoader
 }
 
+// TrustRegistry returns the trust registry authorizing issuers and verifiers, nil if none is configured.
+func (p *Provider) TrustRegistry() trustregistry.Provider {
+	return p.trustRegistry
+}
+
 // KeyType returns the default Key type (signing/authentication).
 func (p *Provider) KeyType() kms.KeyType {
 	return p.keyType
@@ -467,12 +547,21 @@ func WithJSONLDDocumentLoader(loader ld.DocumentLoader) ProviderOption {
 	}
 }
 
+// WithTrustRegistry injects a trust registry authorizing issuers and verifiers into the context.
+func WithTrustRegistry(registry trustregistry.Provider) ProviderOption {
+	return func(opts *Provider) error {
+		opts.trustRegistry = registry
+		return nil
+	}
+}
+
 // WithKeyType injects a keyType for authentication (signing) into the context.
 func WithKeyType(keyType kms.KeyType) ProviderOption {
 	return func(opts *Provider) error {
 		switch keyType {
 		case kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
-			kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER, kms.BLS12381G2Type:
+			kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER, kms.BLS12381G2Type,
+			kms.BLS12381G2SHA256Type:
 			opts.keyType = keyType
 			return nil
 		default:
@@ -493,3 +582,11 @@ func WithKeyAgreementType(keyAgreementType kms.KeyType) ProviderOption {
 		}
 	}
 }
+
+// WithInboundTracker injects the tracker of the inbound messages being handled, which the framework drains on close.
+func WithInboundTracker(tracker *shutdown.Tracker) ProviderOption {
+	return func(opts *Provider) error {
+		opts.inboundTracker = tracker
+		return nil
+	}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/shutdown"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -37,6 +39,7 @@ import (
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
+	trustregistryfile "github.com/hyperledger/aries-framework-go/pkg/trustregistry/file"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -53,6 +56,7 @@ func TestNewProvider(t *testing.T) {
 		prov, err := New(WithOutboundDispatcher(&mockdispatcher.MockOutbound{}))
 		require.NoError(t, err)
 		require.NoError(t, prov.OutboundDispatcher().Send(nil, "", nil))
+		require.Empty(t, prov.MessageServices())
 	})
 
 	t.Run("test error return from options", func(t *testing.T) {
@@ -76,6 +80,8 @@ func TestNewProvider(t *testing.T) {
 
 		_, err = prov.Service("mockProtocolSvc1")
 		require.Error(t, err)
+
+		require.Len(t, prov.ProtocolServices(), 1)
 	})
 
 	t.Run("test new with DID connection store", func(t *testing.T) {
@@ -443,6 +449,7 @@ func TestNewProvider(t *testing.T) {
 			},
 		})
 		require.NoError(t, err)
+		require.Len(t, prov.MessageServices(), 1)
 
 		inboundHandler := prov.InboundMessageHandler()
 
@@ -461,6 +468,26 @@ func TestNewProvider(t *testing.T) {
 		}
 	})
 
+	t.Run("test inbound message handler refuses the messages once draining", func(t *testing.T) {
+		tracker := &shutdown.Tracker{}
+
+		prov, err := New(WithInboundTracker(tracker),
+			WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()))
+		require.NoError(t, err)
+
+		inboundHandler := prov.InboundMessageHandler()
+
+		// the message fails to be routed, but is tracked
+		err = inboundHandler(&transport.Envelope{Message: []byte(`{"@type": "unknown"}`)})
+		require.Error(t, err)
+		require.Zero(t, tracker.InFlight())
+
+		require.NoError(t, tracker.Drain(context.Background()))
+
+		err = inboundHandler(&transport.Envelope{Message: []byte(`{"@type": "unknown"}`)})
+		require.ErrorIs(t, err, shutdown.ErrShuttingDown)
+	})
+
 	t.Run("test new with crypto, KMS, packer and packager services", func(t *testing.T) {
 		prov, err := New(
 			WithKMS(&mockkms.KeyManager{CreateKeyID: "123"}),
@@ -588,6 +615,18 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, loader, prov.JSONLDDocumentLoader())
 	})
 
+	t.Run("test new with trust registry", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Nil(t, prov.TrustRegistry())
+
+		registry := &trustregistryfile.Registry{}
+
+		prov, err = New(WithTrustRegistry(registry))
+		require.NoError(t, err)
+		require.Equal(t, registry, prov.TrustRegistry())
+	})
+
 	t.Run("test new with bad (fake) option", func(t *testing.T) {
 		prov, err := New(func(opts *Provider) error {
 			return fmt.Errorf("bad option")
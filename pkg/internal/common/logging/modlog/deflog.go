@@ -78,6 +78,12 @@ func (l *DefLog) Errorf(format string, args ...interface{}) {
 	l.logf(log.ERROR, format, args...)
 }
 
+// Logw logs msg at level with the fields in keysAndValues appended as ` key=value` text.
+// Unlike Panicf and Fatalf, Logw returns for the CRITICAL level.
+func (l *DefLog) Logw(level log.Level, msg string, keysAndValues ...interface{}) {
+	l.logf(level, "%s", msg+FormatFields(keysAndValues))
+}
+
 // SetOutput sets the output destination for the logger.
 func (l *DefLog) SetOutput(output io.Writer) {
 	l.logger.SetOutput(output)
@@ -105,7 +111,7 @@ func (l *DefLog) getCallerInfo(level log.Level) string {
 	const (
 		// search MAXCALLERS caller frames for the real caller,
 		// MAXCALLERS defines maximum number of caller frames needed to be recorded to find the actual caller frame
-		MAXCALLERS = 6
+		MAXCALLERS = 8
 		// skip SKIPCALLERS frames when determining the real caller
 		// SKIPCALLERS is the number of stack frames to skip before recording caller frames,
 		// this is mainly used to filter logger library functions in caller frames
@@ -122,22 +128,17 @@ func (l *DefLog) getCallerInfo(level log.Level) string {
 	}
 
 	frames := runtime.CallersFrames(fpcs[:n])
-	loggerFrameFound := false
 
 	for f, more := frames.Next(); more; f, more = frames.Next() {
 		_, fnName := filepath.Split(f.Function)
 
-		if f.Func == nil || f.Function == "" {
+		// f.Func is nil for inlined frames, which still have the function name
+		if f.Function == "" {
 			fnName = NOTFOUND // not a function or unknown
 		}
 
-		if loggerFrameFound {
-			return fmt.Sprintf(callerInfoFormatter, fnName)
-		}
-
+		// skip the frames of log.Log, whose methods call each other when logging with fields
 		if strings.HasPrefix(fnName, DEFAULTLOGPREFIX) {
-			loggerFrameFound = true
-
 			continue
 		}
 
@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package modlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BadKey is the key of a field value which has no key, or whose key is not a string.
+const BadKey = "!BADKEY"
+
+// Fields returns the key/value pairs of keysAndValues, which alternates keys and values. A value missing its key
+// is returned with the BadKey key.
+func Fields(keysAndValues []interface{}) ([]string, []interface{}) {
+	keys := make([]string, 0, (len(keysAndValues)+1)/2) //nolint:gomnd
+	values := make([]interface{}, 0, cap(keys))
+
+	for i := 0; i < len(keysAndValues); i++ {
+		key, ok := keysAndValues[i].(string)
+		if !ok || i == len(keysAndValues)-1 {
+			keys = append(keys, BadKey)
+			values = append(values, keysAndValues[i])
+
+			continue
+		}
+
+		keys = append(keys, key)
+		values = append(values, keysAndValues[i+1])
+		i++
+	}
+
+	return keys, values
+}
+
+// FormatFields formats keysAndValues as the ` key=value` suffix of a text log line.
+func FormatFields(keysAndValues []interface{}) string {
+	if len(keysAndValues) == 0 {
+		return ""
+	}
+
+	keys, values := Fields(keysAndValues)
+
+	var b strings.Builder
+
+	for i, key := range keys {
+		value := fmt.Sprint(values[i])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+
+		b.WriteString(" ")
+		b.WriteString(key)
+		b.WriteString("=")
+		b.WriteString(value)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package modlog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/common/logging/metadata"
+	"github.com/hyperledger/aries-framework-go/spi/log"
+)
+
+func TestFields(t *testing.T) {
+	t.Run("pairs", func(t *testing.T) {
+		keys, values := Fields([]interface{}{"thid", "123", "count", 2})
+		require.Equal(t, []string{"thid", "count"}, keys)
+		require.Equal(t, []interface{}{"123", 2}, values)
+	})
+
+	t.Run("value without key", func(t *testing.T) {
+		keys, values := Fields([]interface{}{"thid", "123", "dangling"})
+		require.Equal(t, []string{"thid", BadKey}, keys)
+		require.Equal(t, []interface{}{"123", "dangling"}, values)
+
+		keys, values = Fields([]interface{}{1, "thid", "123"})
+		require.Equal(t, []string{BadKey, "thid"}, keys)
+		require.Equal(t, []interface{}{1, "123"}, values)
+	})
+
+	t.Run("format", func(t *testing.T) {
+		require.Empty(t, FormatFields(nil))
+		require.Equal(t, ` thid=123 err="not found" empty=""`,
+			FormatFields([]interface{}{"thid", "123", "err", errors.New("not found"), "empty", ""}))
+	})
+}
+
+func TestLogw(t *testing.T) {
+	const module = "sample-module-fields"
+
+	t.Run("default logger", func(t *testing.T) {
+		var out bytes.Buffer
+
+		defLog := NewDefLog(module)
+		defLog.SetOutput(&out)
+
+		metadata.SetLevel(module, log.INFO)
+
+		logger := NewModLog(defLog, module)
+
+		logger.Logw(log.DEBUG, "hidden", "thid", "123")
+		require.Empty(t, out.String())
+
+		logger.Logw(log.INFO, "message sent", "thid", "123")
+		require.Contains(t, out.String(), "INFO message sent thid=123")
+
+		out.Reset()
+
+		logger.Logw(log.CRITICAL, "critical", "thid", "123")
+		require.Contains(t, out.String(), "CRITICAL critical thid=123")
+	})
+
+	t.Run("logger without fields support", func(t *testing.T) {
+		defer buf.Reset()
+
+		metadata.SetLevel(module, log.DEBUG)
+
+		logger := NewModLog(GetSampleCustomLogger(module), module)
+
+		for _, level := range []log.Level{log.DEBUG, log.INFO, log.WARNING, log.ERROR, log.CRITICAL} {
+			logger.Logw(level, "message", "thid", "123")
+			require.Contains(t, buf.String(), customOutput)
+			buf.Reset()
+		}
+	})
+}
@@ -16,6 +16,11 @@ func NewModLog(logger log.Logger, module string) *ModLog {
 	return &ModLog{logger: logger, module: module}
 }
 
+// fieldLogger is implemented by the loggers writing structured logs, see spi/log.FieldLogger.
+type fieldLogger interface {
+	Logw(level log.Level, msg string, keysAndValues ...interface{})
+}
+
 // ModLog is a moduled wrapper for any underlying 'log.Logger' implementation.
 // Since this is a moduled wrapper each module can have different logging levels (default is INFO).
 type ModLog struct {
@@ -68,3 +73,30 @@ func (m *ModLog) Errorf(format string, args ...interface{}) {
 
 	m.logger.Errorf(format, args...)
 }
+
+// Logw calls the Logw function of the underlying logger if the level is enabled. Loggers not writing structured
+// logs get the fields appended to msg as text.
+func (m *ModLog) Logw(level log.Level, msg string, keysAndValues ...interface{}) {
+	if level != log.CRITICAL && !metadata.IsEnabledFor(m.module, level) {
+		return
+	}
+
+	if l, ok := m.logger.(fieldLogger); ok {
+		l.Logw(level, msg, keysAndValues...)
+
+		return
+	}
+
+	msg += FormatFields(keysAndValues)
+
+	switch level {
+	case log.DEBUG:
+		m.logger.Debugf("%s", msg)
+	case log.INFO:
+		m.logger.Infof("%s", msg)
+	case log.WARNING:
+		m.logger.Warnf("%s", msg)
+	default:
+		m.logger.Errorf("%s", msg)
+	}
+}
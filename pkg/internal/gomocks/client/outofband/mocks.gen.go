@@ -117,6 +117,20 @@ func (mr *MockOobServiceMockRecorder) AcceptInvitation(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOobService)(nil).AcceptInvitation), arg0, arg1)
 }
 
+// AcceptInvitationV2 mocks base method.
+func (m *MockOobService) AcceptInvitationV2(arg0 *outofband.InvitationV2, arg1 outofband.Options) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationV2", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitationV2 indicates an expected call of AcceptInvitationV2.
+func (mr *MockOobServiceMockRecorder) AcceptInvitationV2(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationV2", reflect.TypeOf((*MockOobService)(nil).AcceptInvitationV2), arg0, arg1)
+}
+
 // ActionContinue mocks base method.
 func (m *MockOobService) ActionContinue(arg0 string, arg1 outofband.Options) error {
 	m.ctrl.T.Helper()
@@ -88,6 +88,26 @@ func (mr *MockRegistryMockRecorder) Deactivate(arg0 interface{}, arg1 ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockRegistry)(nil).Deactivate), varargs...)
 }
 
+// Dereference mocks base method.
+func (m *MockRegistry) Dereference(arg0 string, arg1 ...vdr.DIDMethodOption) (*did.DereferencingResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Dereference", varargs...)
+	ret0, _ := ret[0].(*did.DereferencingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dereference indicates an expected call of Dereference.
+func (mr *MockRegistryMockRecorder) Dereference(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dereference", reflect.TypeOf((*MockRegistry)(nil).Dereference), varargs...)
+}
+
 // Resolve mocks base method.
 func (m *MockRegistry) Resolve(arg0 string, arg1 ...vdr.DIDMethodOption) (*did.DocResolution, error) {
 	m.ctrl.T.Helper()
@@ -26,6 +26,8 @@ var (
 	odrl []byte
 	//go:embed contexts/third_party/w3.org/credentials-examples_v1.jsonld
 	credentialExamples []byte
+	//go:embed contexts/third_party/w3.org/credentials-examples_v2.jsonld
+	credentialExamplesV2 []byte
 	//go:embed contexts/third_party/trustbloc.github.io/trustbloc-examples_v1.jsonld
 	vcExamples []byte
 )
@@ -44,6 +46,10 @@ var testContexts = []jsonld.ContextDocument{ //nolint:gochecknoglobals // embedd
 		URL:     "https://www.w3.org/2018/credentials/examples/v1",
 		Content: credentialExamples,
 	},
+	{
+		URL:     "https://www.w3.org/ns/credentials/examples/v2",
+		Content: credentialExamplesV2,
+	},
 	{
 		URL:     "https://trustbloc.github.io/context/vc/examples-v1.jsonld",
 		Content: vcExamples,
@@ -120,6 +120,8 @@ const (
 	X25519ECDHKW = "X25519ECDHKW"
 	// BLS12381G2 BBS+ key type value.
 	BLS12381G2 = "BLS12381G2"
+	// BLS12381G2SHA256 BBS key type value for the BLS12-381-SHA-256 ciphersuite of the IETF BBS draft.
+	BLS12381G2SHA256 = "BLS12381G2SHA256"
 )
 
 // KeyType represents a key type supported by the KMS.
@@ -168,6 +170,8 @@ const (
 	X25519ECDHKWType = KeyType(X25519ECDHKW)
 	// BLS12381G2Type BBS+ key type value.
 	BLS12381G2Type = KeyType(BLS12381G2)
+	// BLS12381G2SHA256Type BBS key type value for the BLS12-381-SHA-256 ciphersuite of the IETF BBS draft.
+	BLS12381G2SHA256Type = KeyType(BLS12381G2SHA256)
 )
 
 // CryptoBox is a libsodium crypto service used by legacy authcrypt packer.
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package instrumented provides a kms.KeyManager decorator recording the duration and outcome of each operation in
+// the metrics and the traces.
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//nolint:gochecknoglobals
+var operationDuration = metrics.NewHistogram("aries_kms_operation_duration_seconds",
+	"Time spent by the KMS operations.", nil, "operation", "status")
+
+// KeyManager decorates a kms.KeyManager with metrics and traces.
+type KeyManager struct {
+	km kms.KeyManager
+}
+
+// New returns km decorated with metrics and traces.
+func New(km kms.KeyManager) *KeyManager {
+	return &KeyManager{km: km}
+}
+
+// Unwrap returns the decorated kms.KeyManager.
+func (k *KeyManager) Unwrap() kms.KeyManager {
+	return k.km
+}
+
+// Create calls Create of the decorated KMS.
+func (k *KeyManager) Create(kt kms.KeyType) (string, interface{}, error) {
+	done := observe("Create")
+
+	kid, kh, err := k.km.Create(kt)
+
+	done(err)
+
+	return kid, kh, err
+}
+
+// Get calls Get of the decorated KMS.
+func (k *KeyManager) Get(keyID string) (interface{}, error) {
+	done := observe("Get")
+
+	kh, err := k.km.Get(keyID)
+
+	done(err)
+
+	return kh, err
+}
+
+// Rotate calls Rotate of the decorated KMS.
+func (k *KeyManager) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	done := observe("Rotate")
+
+	kid, kh, err := k.km.Rotate(kt, keyID)
+
+	done(err)
+
+	return kid, kh, err
+}
+
+// ExportPubKeyBytes calls ExportPubKeyBytes of the decorated KMS.
+func (k *KeyManager) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	done := observe("ExportPubKeyBytes")
+
+	pubKey, err := k.km.ExportPubKeyBytes(keyID)
+
+	done(err)
+
+	return pubKey, err
+}
+
+// CreateAndExportPubKeyBytes calls CreateAndExportPubKeyBytes of the decorated KMS.
+func (k *KeyManager) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	done := observe("CreateAndExportPubKeyBytes")
+
+	kid, pubKey, err := k.km.CreateAndExportPubKeyBytes(kt)
+
+	done(err)
+
+	return kid, pubKey, err
+}
+
+// PubKeyBytesToHandle calls PubKeyBytesToHandle of the decorated KMS.
+func (k *KeyManager) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	done := observe("PubKeyBytesToHandle")
+
+	kh, err := k.km.PubKeyBytesToHandle(pubKey, kt)
+
+	done(err)
+
+	return kh, err
+}
+
+// ImportPrivateKey calls ImportPrivateKey of the decorated KMS.
+func (k *KeyManager) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	done := observe("ImportPrivateKey")
+
+	kid, kh, err := k.km.ImportPrivateKey(privKey, kt, opts...)
+
+	done(err)
+
+	return kid, kh, err
+}
+
+func observe(operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(context.Background(), "kms."+operation)
+
+	return func(err error) {
+		span.RecordError(err)
+		span.End()
+
+		operationDuration.Observe(time.Since(start).Seconds(), operation, metrics.Status(err))
+	}
+}
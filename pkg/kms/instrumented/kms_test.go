@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package instrumented_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/instrumented"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+)
+
+func TestKeyManager(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	mock := &mockkms.KeyManager{
+		CreateKeyID:            "created",
+		GetKeyErr:              errFailed,
+		RotateKeyID:            "rotated",
+		ExportPubKeyBytesValue: []byte("public key"),
+		CrAndExportPubKeyID:    "created and exported",
+		CrAndExportPubKeyValue: []byte("created public key"),
+		ImportPrivateKeyID:     "imported",
+	}
+
+	km := instrumented.New(mock)
+	require.Equal(t, mock, km.Unwrap())
+
+	kid, _, err := km.Create(kms.ED25519Type)
+	require.NoError(t, err)
+	require.Equal(t, "created", kid)
+
+	_, err = km.Get("kid")
+	require.ErrorIs(t, err, errFailed)
+
+	kid, _, err = km.Rotate(kms.ED25519Type, "kid")
+	require.NoError(t, err)
+	require.Equal(t, "rotated", kid)
+
+	pubKey, err := km.ExportPubKeyBytes("kid")
+	require.NoError(t, err)
+	require.Equal(t, []byte("public key"), pubKey)
+
+	kid, pubKey, err = km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+	require.Equal(t, "created and exported", kid)
+	require.Equal(t, []byte("created public key"), pubKey)
+
+	_, err = km.PubKeyBytesToHandle(nil, kms.ED25519Type)
+	require.NoError(t, err)
+
+	kid, _, err = km.ImportPrivateKey(nil, kms.ED25519Type)
+	require.NoError(t, err)
+	require.Equal(t, "imported", kid)
+
+	var buf bytes.Buffer
+
+	_, err = metrics.Default().WriteTo(&buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `aries_kms_operation_duration_seconds_count{operation="Get",status="error"} 1`)
+	require.Contains(t, buf.String(),
+		`aries_kms_operation_duration_seconds_count{operation="ImportPrivateKey",status="success"} 1`)
+}
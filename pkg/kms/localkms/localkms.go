@@ -216,6 +216,8 @@ func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 		return ecdh.X25519ECDHKWKeyTemplate(), nil
 	case kms.BLS12381G2Type:
 		return bbs.BLS12381G2KeyTemplate(), nil
+	case kms.BLS12381G2SHA256Type:
+		return bbs.BLS12381G2SHA256KeyTemplate(), nil
 	default:
 		return nil, fmt.Errorf("getKeyTemplate: key type '%s' unrecognized", keyType)
 	}
@@ -266,6 +266,7 @@ func TestLocalKMS_Success(t *testing.T) {
 		kms.NISTP521ECDHKWType,
 		kms.X25519ECDHKWType,
 		kms.BLS12381G2Type,
+		kms.BLS12381G2SHA256Type,
 	}
 
 	for _, v := range keyTemplates {
@@ -314,7 +315,8 @@ func TestLocalKMS_Success(t *testing.T) {
 		require.Equal(t, len(newKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 		require.Equal(t, len(readKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 
-		if strings.Contains(string(v), "ECDSA") || v == kms.ED25519Type || v == kms.BLS12381G2Type {
+		if strings.Contains(string(v), "ECDSA") || v == kms.ED25519Type || v == kms.BLS12381G2Type ||
+			v == kms.BLS12381G2SHA256Type {
 			pubKeyBytes, e := kmsService.ExportPubKeyBytes(keyID)
 			require.Errorf(t, e, "KeyID has been rotated. An error must be returned")
 			require.Empty(t, pubKeyBytes)
@@ -140,7 +140,7 @@ func (l *LocalKMS) importBBSKey(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyTyp
 		return "", nil, fmt.Errorf("import private BBS+ key failed: private key is nil")
 	}
 
-	if kt != kms.BLS12381G2Type {
+	if kt != kms.BLS12381G2Type && kt != kms.BLS12381G2SHA256Type {
 		return "", nil, fmt.Errorf("import private BBS+ key failed: invalid key type")
 	}
 
@@ -233,12 +233,20 @@ func newProtoBBSPrivateKey(privateKey *bbs12381g2pub.PrivateKey, kt kms.KeyType)
 }
 
 func buidBBSParams(kt kms.KeyType) *bbspb.BBSParams {
-	if kt == kms.BLS12381G2Type {
+	switch kt {
+	case kms.BLS12381G2Type:
 		return &bbspb.BBSParams{
 			HashType: commonpb.HashType_SHA256,
 			Curve:    bbspb.BBSCurveType_BLS12_381,
 			Group:    bbspb.GroupField_G2,
 		}
+	case kms.BLS12381G2SHA256Type:
+		return &bbspb.BBSParams{
+			HashType:    commonpb.HashType_SHA256,
+			Curve:       bbspb.BBSCurveType_BLS12_381,
+			Group:       bbspb.GroupField_G2,
+			Ciphersuite: bbspb.BBSCiphersuite_BLS12_381_SHA_256,
+		}
 	}
 
 	return nil
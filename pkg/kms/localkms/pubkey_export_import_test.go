@@ -77,6 +77,12 @@ func TestPubKeyExportAndRead(t *testing.T) {
 			keyTemplate: bbs.BLS12381G2KeyTemplate(),
 			doSign:      true,
 		},
+		{
+			tcName:      "export then read BBS BLS12381G2SHA256 public key",
+			keyType:     kms.BLS12381G2SHA256Type,
+			keyTemplate: bbs.BLS12381G2SHA256KeyTemplate(),
+			doSign:      true,
+		},
 	}
 
 	for _, tc := range flagTests {
@@ -89,7 +95,7 @@ func TestPubKeyExportAndRead(t *testing.T) {
 			require.NotEmpty(t, kh)
 
 			if tt.doSign {
-				if tt.keyType == kms.BLS12381G2Type {
+				if tt.keyType == kms.BLS12381G2Type || tt.keyType == kms.BLS12381G2SHA256Type {
 					msg1 := []byte("Lorem ipsum dolor sit amet,")
 					msg2 := []byte("consectetur adipiscing elit.")
 					msg := [][]byte{msg1, msg2}
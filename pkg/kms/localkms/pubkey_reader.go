@@ -153,7 +153,7 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType) ([]byte, stri
 		if err != nil {
 			return nil, "", err
 		}
-	case kms.BLS12381G2Type:
+	case kms.BLS12381G2Type, kms.BLS12381G2SHA256Type:
 		tURL = bbsVerifierKeyTypeURL
 		pubKeyProto := new(bbspb.BBSPublicKey)
 		pubKeyProto.Version = 0
@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+// MockActionMenuSvc mock action menu service.
+type MockActionMenuSvc struct {
+	service.Action
+	service.Message
+	PublishMenuErr    error
+	MyMenuValue       *actionmenu.Menu
+	MyMenuErr         error
+	TheirMenuValue    *actionmenu.Menu
+	TheirMenuErr      error
+	RequestMenuErr    error
+	PerformFunc       func(connectionID, name string, params map[string]string) error
+	ActionsValue      []actionmenu.Action
+	ActionsErr        error
+	ActionContinueErr error
+	ActionStopErr     error
+}
+
+// PublishMenu performs PublishMenu.
+func (m *MockActionMenuSvc) PublishMenu(_ string, menu *actionmenu.Menu) (*actionmenu.Menu, error) {
+	if m.PublishMenuErr != nil {
+		return nil, m.PublishMenuErr
+	}
+
+	return menu, nil
+}
+
+// MyMenu returns MyMenuValue.
+func (m *MockActionMenuSvc) MyMenu(string) (*actionmenu.Menu, error) {
+	return m.MyMenuValue, m.MyMenuErr
+}
+
+// TheirMenu returns TheirMenuValue.
+func (m *MockActionMenuSvc) TheirMenu(string) (*actionmenu.Menu, error) {
+	return m.TheirMenuValue, m.TheirMenuErr
+}
+
+// RequestMenu performs RequestMenu.
+func (m *MockActionMenuSvc) RequestMenu(string) error {
+	return m.RequestMenuErr
+}
+
+// Perform performs Perform.
+func (m *MockActionMenuSvc) Perform(connectionID, name string, params map[string]string) error {
+	if m.PerformFunc != nil {
+		return m.PerformFunc(connectionID, name, params)
+	}
+
+	return nil
+}
+
+// Actions returns ActionsValue.
+func (m *MockActionMenuSvc) Actions() ([]actionmenu.Action, error) {
+	return m.ActionsValue, m.ActionsErr
+}
+
+// ActionContinue performs ActionContinue.
+func (m *MockActionMenuSvc) ActionContinue(string, actionmenu.Opt) error {
+	return m.ActionContinueErr
+}
+
+// ActionStop performs ActionStop.
+func (m *MockActionMenuSvc) ActionStop(string, error) error {
+	return m.ActionStopErr
+}
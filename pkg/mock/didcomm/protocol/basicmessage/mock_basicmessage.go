@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package basicmessage
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/basicmessage"
+)
+
+// MockBasicMessageSvc mock basic message service.
+type MockBasicMessageSvc struct {
+	SendErr      error
+	SendFunc     func(connectionID, content string, opts ...basicmessage.SendOpt) (*basicmessage.Record, error)
+	HistoryErr   error
+	HistoryFunc  func(connectionID string, opts ...basicmessage.HistoryOpt) (*basicmessage.History, error)
+	MarkReadErr  error
+	MarkReadFunc func(connectionID string, msgIDs ...string) (int, error)
+}
+
+// Send performs Send.
+func (m *MockBasicMessageSvc) Send(connectionID, content string,
+	opts ...basicmessage.SendOpt) (*basicmessage.Record, error) {
+	if m.SendErr != nil {
+		return nil, m.SendErr
+	}
+
+	if m.SendFunc != nil {
+		return m.SendFunc(connectionID, content, opts...)
+	}
+
+	return &basicmessage.Record{ConnectionID: connectionID, Content: content}, nil
+}
+
+// History performs History.
+func (m *MockBasicMessageSvc) History(connectionID string,
+	opts ...basicmessage.HistoryOpt) (*basicmessage.History, error) {
+	if m.HistoryErr != nil {
+		return nil, m.HistoryErr
+	}
+
+	if m.HistoryFunc != nil {
+		return m.HistoryFunc(connectionID, opts...)
+	}
+
+	return &basicmessage.History{Messages: []*basicmessage.Record{}}, nil
+}
+
+// MarkRead performs MarkRead.
+func (m *MockBasicMessageSvc) MarkRead(connectionID string, msgIDs ...string) (int, error) {
+	if m.MarkReadErr != nil {
+		return 0, m.MarkReadErr
+	}
+
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(connectionID, msgIDs...)
+	}
+
+	return len(msgIDs), nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// MockDiscoverFeaturesSvc mock discover features service.
+type MockDiscoverFeaturesSvc struct {
+	Disclosures []discoverfeatures.Disclosure
+	QueryErr    error
+	QueryFunc   func(connectionID string, matches []string,
+		opts ...discoverfeatures.QueryOpt) ([]discoverfeatures.Disclosure, error)
+}
+
+// Disclose returns the disclosures of the mock.
+func (m *MockDiscoverFeaturesSvc) Disclose(...string) []discoverfeatures.Disclosure {
+	return m.Disclosures
+}
+
+// Query performs Query.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID string, matches []string,
+	opts ...discoverfeatures.QueryOpt) ([]discoverfeatures.Disclosure, error) {
+	if m.QueryErr != nil {
+		return nil, m.QueryErr
+	}
+
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, matches, opts...)
+	}
+
+	return m.Disclosures, nil
+}
@@ -30,6 +30,9 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	RemoveKeyErr       error
+	Keylist            *mediator.Keylist
+	KeylistQueryErr    error
 }
 
 // HandleInbound msg.
@@ -117,3 +120,17 @@ func (m *MockMediatorSvc) GetConnections() ([]string, error) {
 
 	return m.Connections, nil
 }
+
+// RemoveKey removes agents recKey from the router.
+func (m *MockMediatorSvc) RemoveKey(connID, recKey string) error {
+	return m.RemoveKeyErr
+}
+
+// KeylistQuery returns the agent keys registered with the router.
+func (m *MockMediatorSvc) KeylistQuery(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+	if m.KeylistQueryErr != nil {
+		return nil, m.KeylistQueryErr
+	}
+
+	return m.Keylist, nil
+}
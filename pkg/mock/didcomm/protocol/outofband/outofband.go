@@ -13,6 +13,7 @@ import (
 // MockOobService is a mock of OobService interface.
 type MockOobService struct {
 	AcceptInvitationHandle      func(*outofband.Invitation, outofband.Options) (string, error)
+	AcceptInvitationV2Handle    func(*outofband.InvitationV2, outofband.Options) error
 	ActionContinueHandle        func(string, outofband.Options) error
 	ActionStopHandle            func(string, error) error
 	ActionsHandle               func() ([]outofband.Action, error)
@@ -32,6 +33,15 @@ func (m *MockOobService) AcceptInvitation(arg0 *outofband.Invitation, arg1 outof
 	return "", nil
 }
 
+// AcceptInvitationV2 mock implementation.
+func (m *MockOobService) AcceptInvitationV2(arg0 *outofband.InvitationV2, arg1 outofband.Options) error {
+	if m.AcceptInvitationV2Handle != nil {
+		return m.AcceptInvitationV2Handle(arg0, arg1)
+	}
+
+	return nil
+}
+
 // ActionContinue mock implementation.
 func (m *MockOobService) ActionContinue(arg0 string, arg1 outofband.Options) error {
 	if m.ActionContinueHandle != nil {
@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trust ping service.
+type MockTrustPingSvc struct {
+	PingErr  error
+	PingFunc func(connectionID string, opts ...trustping.PingOpt) (*trustping.Result, error)
+}
+
+// Ping performs Ping.
+func (m *MockTrustPingSvc) Ping(connectionID string, opts ...trustping.PingOpt) (*trustping.Result, error) {
+	if m.PingErr != nil {
+		return nil, m.PingErr
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, opts...)
+	}
+
+	return &trustping.Result{}, nil
+}
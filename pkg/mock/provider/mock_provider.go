@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
@@ -35,6 +36,7 @@ type Provider struct {
 	JSONLDDocumentLoaderValue         ld.DocumentLoader
 	KeyTypeValue                      kms.KeyType
 	KeyAgreementTypeValue             kms.KeyType
+	OutboundTransportsValue           []transport.OutboundTransport
 }
 
 // Service return service.
@@ -119,3 +121,8 @@ func (p *Provider) KeyType() kms.KeyType {
 func (p *Provider) KeyAgreementType() kms.KeyType {
 	return p.KeyAgreementTypeValue
 }
+
+// OutboundTransports returns the outbound transports.
+func (p *Provider) OutboundTransports() []transport.OutboundTransport {
+	return p.OutboundTransportsValue
+}
@@ -18,14 +18,16 @@ import (
 // MockVDRegistry mock implementation of vdr
 // to be used only for unit tests.
 type MockVDRegistry struct {
-	CreateErr      error
-	CreateValue    *did.Doc
-	CreateFunc     func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
-	UpdateFunc     func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
-	DeactivateFunc func(did string, opts ...vdrapi.DIDMethodOption) error
-	ResolveErr     error
-	ResolveValue   *did.Doc
-	ResolveFunc    func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	CreateErr       error
+	CreateValue     *did.Doc
+	CreateFunc      func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	UpdateFunc      func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
+	DeactivateFunc  func(did string, opts ...vdrapi.DIDMethodOption) error
+	ResolveErr      error
+	ResolveValue    *did.Doc
+	ResolveFunc     func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	DereferenceErr  error
+	DereferenceFunc func(didURL string, opts ...vdrapi.DIDMethodOption) (*did.DereferencingResult, error)
 }
 
 // Create mock implementation of create DID.
@@ -64,6 +66,25 @@ func (m *MockVDRegistry) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (
 	return &did.DocResolution{DIDDocument: m.ResolveValue}, nil
 }
 
+// Dereference mock implementation of dereference DID URL.
+func (m *MockVDRegistry) Dereference(didURL string,
+	opts ...vdrapi.DIDMethodOption) (*did.DereferencingResult, error) {
+	if m.DereferenceFunc != nil {
+		return m.DereferenceFunc(didURL, opts...)
+	}
+
+	if m.DereferenceErr != nil {
+		return nil, m.DereferenceErr
+	}
+
+	docResolution, err := m.Resolve(didURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DereferencingResult{Content: docResolution.DIDDocument}, nil
+}
+
 // Update did.
 func (m *MockVDRegistry) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
 	if m.UpdateFunc != nil {
@@ -10,6 +10,7 @@
 package connection
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +31,18 @@ const (
 	didConnMapKeyPrefix = "didconn"
 	keySeparator        = "_"
 	stateIDEmptyErr     = "stateID can't be empty"
+	metadataTagPrefix   = "connmeta_"
+	tagTagPrefix        = "conntag_"
+)
+
+const (
+	// StatusActive is the lifecycle status of the connections in use, the connection records having no status being
+	// active.
+	StatusActive = "active"
+	// StatusInactive is the lifecycle status of the connections temporarily out of use.
+	StatusInactive = "inactive"
+	// StatusArchived is the lifecycle status of the connections no longer in use, kept for the records.
+	StatusArchived = "archived"
 )
 
 var logger = log.New("aries-framework/store/connection")
@@ -59,6 +72,15 @@ type Record struct {
 	Implicit          bool
 	Namespace         string
 	MediaTypeProfiles []string
+	// Metadata, Tags and Status are managed by the connection owner, the metadata and tags being queryable.
+	Metadata map[string]string `json:",omitempty"`
+	Tags     []string          `json:",omitempty"`
+	Status   string            `json:",omitempty"`
+}
+
+// IsActive checks whether the connection lifecycle status is active.
+func (r *Record) IsActive() bool {
+	return r.Status == "" || r.Status == StatusActive
 }
 
 // NewLookup returns new connection lookup instance.
@@ -117,8 +139,28 @@ func (c *Lookup) GetConnectionRecord(connectionID string) (*Record, error) {
 // for given query criteria.
 func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 query criteria to be added as part of issue
-	searchKey := getConnectionKeyPrefix()("")
+	return c.queryConnectionRecords(getConnectionKeyPrefix()(""))
+}
+
+// QueryConnectionRecordsByMetadata returns the connection records whose metadata has the given value for key.
+func (c *Lookup) QueryConnectionRecordsByMetadata(key, value string) ([]*Record, error) {
+	if key == "" {
+		return nil, errors.New("metadata key can't be empty")
+	}
+
+	return c.queryConnectionRecords(fmt.Sprintf("%s:%s", metadataTagName(key), encodeTagPart(value)))
+}
 
+// QueryConnectionRecordsByTag returns the connection records having the given tag.
+func (c *Lookup) QueryConnectionRecordsByTag(tag string) ([]*Record, error) {
+	if tag == "" {
+		return nil, errors.New("tag can't be empty")
+	}
+
+	return c.queryConnectionRecords(tagTagName(tag))
+}
+
+func (c *Lookup) queryConnectionRecords(searchKey string) ([]*Record, error) {
 	persistentStoreRecords, persistentStoreKeys, err := c.getDataFromPersistentStore(searchKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get data from persistent store: %w", err)
@@ -327,6 +369,23 @@ func getDIDConnMapKeyPrefix() KeyPrefix {
 	}
 }
 
+// metadataTagName returns the storage tag name of the connection metadata key, the tag value being the encoded
+// metadata value.
+func metadataTagName(key string) string {
+	return metadataTagPrefix + encodeTagPart(key)
+}
+
+// tagTagName returns the storage tag name of the connection tag.
+func tagTagName(tag string) string {
+	return tagTagPrefix + encodeTagPart(tag)
+}
+
+// encodeTagPart encodes the tag names and values set by the connection owner, which may contain the ':' separator
+// of the query expressions.
+func encodeTagPart(part string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(part))
+}
+
 // CreateNamespaceKey creates key prefix for namespace related data.
 func CreateNamespaceKey(prefix, thID string) (string, error) {
 	key, err := computeHash([]byte(thID))
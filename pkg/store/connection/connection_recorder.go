@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -30,6 +31,9 @@ const (
 	errMsgInvalidKey = "invalid key"
 )
 
+// updateLock serializes the updates of connection records, the recorders of a provider sharing its stores.
+var updateLock sync.Mutex // nolint:gochecknoglobals
+
 // NewRecorder returns new connection recorder.
 // Recorder is read-write connection store which provides
 // write features on top query features from Lookup.
@@ -60,10 +64,7 @@ func (c *Recorder) SaveInvitation(id string, invitation interface{}) error {
 // SaveConnectionRecord saves given connection records in underlying store.
 func (c *Recorder) SaveConnectionRecord(record *Record) error {
 	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-		record, c.protocolStateStore, storage.Tag{
-			Name:  getConnectionKeyPrefix()(""),
-			Value: getConnectionKeyPrefix()(record.ConnectionID),
-		}); err != nil {
+		record, c.protocolStateStore, recordTags(record)...); err != nil {
 		return fmt.Errorf("save connection record in protocol state store: %w", err)
 	}
 
@@ -80,10 +81,7 @@ func (c *Recorder) SaveConnectionRecord(record *Record) error {
 
 	if record.State == StateNameCompleted {
 		if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-			record, c.store, storage.Tag{
-				Name:  getConnectionKeyPrefix()(""),
-				Value: getConnectionKeyPrefix()(record.ConnectionID),
-			}); err != nil {
+			record, c.store, recordTags(record)...); err != nil {
 			return fmt.Errorf("save connection record in permanent store: %w", err)
 		}
 
@@ -181,6 +179,129 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 	return nil
 }
 
+// UpdateMetadata merges metadata into the metadata of the connection record, the keys with an empty value being
+// removed.
+func (c *Recorder) UpdateMetadata(connectionID string, metadata map[string]string) error {
+	return c.updateRecord(connectionID, func(record *Record) error {
+		for key, value := range metadata {
+			if key == "" {
+				return errors.New("metadata key can't be empty")
+			}
+
+			if value == "" {
+				delete(record.Metadata, key)
+
+				continue
+			}
+
+			if record.Metadata == nil {
+				record.Metadata = make(map[string]string)
+			}
+
+			record.Metadata[key] = value
+		}
+
+		return nil
+	})
+}
+
+// SetTags replaces the tags of the connection record.
+func (c *Recorder) SetTags(connectionID string, tags ...string) error {
+	return c.updateRecord(connectionID, func(record *Record) error {
+		record.Tags = nil
+
+		seen := make(map[string]bool)
+
+		for _, tag := range tags {
+			if tag == "" {
+				return errors.New("tag can't be empty")
+			}
+
+			if !seen[tag] {
+				seen[tag] = true
+
+				record.Tags = append(record.Tags, tag)
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetStatus sets the lifecycle status of the connection record.
+func (c *Recorder) SetStatus(connectionID, status string) error {
+	switch status {
+	case StatusActive, StatusInactive, StatusArchived:
+	default:
+		return fmt.Errorf("unsupported connection status: %s", status)
+	}
+
+	return c.updateRecord(connectionID, func(record *Record) error {
+		record.Status = status
+
+		return nil
+	})
+}
+
+// RemoveExchangeData removes the data kept by the DID exchange service for the connection once removed: its event
+// data and its namespaced thread ID mapping. The DID key mappings of the DID connection store are not removed, the
+// DIDs possibly being used by other connections.
+func (c *Recorder) RemoveExchangeData(record *Record) error {
+	if err := c.protocolStateStore.Delete(getEventDataKeyPrefix()(record.ConnectionID)); err != nil {
+		return fmt.Errorf("unable to delete connection event data: connectionid=%s err=%w", record.ConnectionID, err)
+	}
+
+	if record.ThreadID == "" || record.Namespace == "" {
+		return nil
+	}
+
+	key, err := computeHash([]byte(record.ThreadID))
+	if err != nil {
+		return fmt.Errorf("compute hash: %w", err)
+	}
+
+	if err = c.protocolStateStore.Delete(getNamespaceKeyPrefix(record.Namespace)(key)); err != nil {
+		return fmt.Errorf("unable to delete namespaced thread ID mapping: connectionid=%s err=%w",
+			record.ConnectionID, err)
+	}
+
+	return nil
+}
+
+func (c *Recorder) updateRecord(connectionID string, update func(record *Record) error) error {
+	updateLock.Lock()
+	defer updateLock.Unlock()
+
+	record, err := c.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("unable to get connection record: connectionid=%s err=%w", connectionID, err)
+	}
+
+	if err = update(record); err != nil {
+		return fmt.Errorf("update connection record: connectionid=%s err=%w", connectionID, err)
+	}
+
+	return c.SaveConnectionRecord(record)
+}
+
+// recordTags returns the storage tags of the connection record, its metadata and tags being queryable.
+func recordTags(record *Record) []storage.Tag {
+	tags := []storage.Tag{{
+		Name:  getConnectionKeyPrefix()(""),
+		Value: getConnectionKeyPrefix()(record.ConnectionID),
+	}}
+
+	for key, value := range record.Metadata {
+		tags = append(tags, storage.Tag{Name: metadataTagName(key), Value: encodeTagPart(value)})
+	}
+
+	for _, tag := range record.Tags {
+		tags = append(tags, storage.Tag{Name: tagTagName(tag)})
+	}
+
+	return tags
+}
+
 func marshalAndSave(k string, v interface{}, store storage.Store, tags ...storage.Tag) error {
 	bytes, err := json.Marshal(v)
 	if err != nil {
@@ -11,6 +11,7 @@ package connection
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/google/uuid"
@@ -796,3 +797,148 @@ type mockInvitation struct {
 	Type            string            `json:"@type,omitempty"`
 	Thread          *decorator.Thread `json:"~thread,omitempty"`
 }
+
+func TestConnectionRecorder_MetadataTagsAndStatus(t *testing.T) {
+	recorder, err := NewRecorder(&mockProvider{})
+	require.NoError(t, err)
+
+	completed := &Record{
+		ThreadID:     threadIDValue,
+		ConnectionID: uuid.New().String(),
+		State:        StateNameCompleted,
+		Namespace:    TheirNSPrefix,
+		MyDID:        "did:mydid:123",
+		TheirDID:     "did:theirdid:123",
+	}
+	require.NoError(t, recorder.SaveConnectionRecord(completed))
+
+	invited := &Record{
+		ThreadID:     threadIDValue,
+		ConnectionID: uuid.New().String(),
+		State:        stateNameInvited,
+		Namespace:    MyNSPrefix,
+	}
+	require.NoError(t, recorder.SaveConnectionRecord(invited))
+
+	t.Run("metadata", func(t *testing.T) {
+		require.NoError(t, recorder.UpdateMetadata(completed.ConnectionID,
+			map[string]string{"crm:customer": "crm:123", "region": "eu"}))
+		require.NoError(t, recorder.UpdateMetadata(invited.ConnectionID, map[string]string{"crm:customer": "crm:456"}))
+
+		records, err := recorder.QueryConnectionRecordsByMetadata("crm:customer", "crm:123")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, completed.ConnectionID, records[0].ConnectionID)
+		require.Equal(t, map[string]string{"crm:customer": "crm:123", "region": "eu"}, records[0].Metadata)
+
+		records, err = recorder.QueryConnectionRecordsByMetadata("crm:customer", "crm:456")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, invited.ConnectionID, records[0].ConnectionID)
+
+		// an empty value removes the key
+		require.NoError(t, recorder.UpdateMetadata(completed.ConnectionID, map[string]string{"region": ""}))
+
+		records, err = recorder.QueryConnectionRecordsByMetadata("region", "eu")
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		record, err := recorder.GetConnectionRecord(completed.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"crm:customer": "crm:123"}, record.Metadata)
+
+		err = recorder.UpdateMetadata(completed.ConnectionID, map[string]string{"": "value"})
+		require.EqualError(t, err, fmt.Sprintf("update connection record: connectionid=%s err=metadata key can't be empty",
+			completed.ConnectionID))
+
+		_, err = recorder.QueryConnectionRecordsByMetadata("", "value")
+		require.Error(t, err)
+	})
+
+	t.Run("concurrent metadata updates", func(t *testing.T) {
+		const updates = 20
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < updates; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				require.NoError(t, recorder.UpdateMetadata(invited.ConnectionID,
+					map[string]string{fmt.Sprintf("key-%d", i): "value"}))
+			}(i)
+		}
+
+		wg.Wait()
+
+		record, err := recorder.GetConnectionRecord(invited.ConnectionID)
+		require.NoError(t, err)
+		require.Len(t, record.Metadata, updates+1)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		require.NoError(t, recorder.SetTags(completed.ConnectionID, "vip", "partner", "vip"))
+		require.NoError(t, recorder.SetTags(invited.ConnectionID, "partner"))
+
+		records, err := recorder.QueryConnectionRecordsByTag("partner")
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		records, err = recorder.QueryConnectionRecordsByTag("vip")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, []string{"vip", "partner"}, records[0].Tags)
+
+		require.NoError(t, recorder.SetTags(completed.ConnectionID))
+
+		records, err = recorder.QueryConnectionRecordsByTag("vip")
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		require.Error(t, recorder.SetTags(completed.ConnectionID, ""))
+
+		_, err = recorder.QueryConnectionRecordsByTag("")
+		require.Error(t, err)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		record, err := recorder.GetConnectionRecord(completed.ConnectionID)
+		require.NoError(t, err)
+		require.True(t, record.IsActive())
+
+		require.NoError(t, recorder.SetStatus(completed.ConnectionID, StatusArchived))
+
+		record, err = recorder.GetConnectionRecord(completed.ConnectionID)
+		require.NoError(t, err)
+		require.False(t, record.IsActive())
+		require.Equal(t, StatusArchived, record.Status)
+
+		err = recorder.SetStatus(completed.ConnectionID, "deleted")
+		require.EqualError(t, err, "unsupported connection status: deleted")
+
+		err = recorder.SetStatus("unknown", StatusInactive)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("remove protocol state", func(t *testing.T) {
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(invited))
+		require.NoError(t, recorder.SaveEvent(invited.ConnectionID, []byte("event")))
+
+		nsKey, err := CreateNamespaceKey(MyNSPrefix, invited.ThreadID)
+		require.NoError(t, err)
+
+		_, err = recorder.protocolStateStore.Get(nsKey)
+		require.NoError(t, err)
+
+		require.NoError(t, recorder.RemoveConnection(invited.ConnectionID))
+		require.NoError(t, recorder.RemoveExchangeData(invited))
+
+		_, err = recorder.GetEvent(invited.ConnectionID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+		_, err = recorder.protocolStateStore.Get(nsKey)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+}
@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prefix
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// namespaceTag tags all the records written through a ProviderPrefixWrapper, so that they can be found to be
+	// purged. It is hidden from the users of the wrapper.
+	namespaceTag = "prefixNamespace"
+	// storeNamesStore is the store of the names of the stores opened through a ProviderPrefixWrapper.
+	storeNamesStore = "prefixstorenames"
+)
+
+// NewPrefixProviderWrapper creates a new ProviderPrefixWrapper of provider.
+func NewPrefixProviderWrapper(provider storage.Provider, prefix string) (*ProviderPrefixWrapper, error) {
+	if prefix == "" {
+		return nil, errors.New("newPrefixProviderWrapper: prefix is empty")
+	}
+
+	return &ProviderPrefixWrapper{provider: provider, prefix: prefix}, nil
+}
+
+// ProviderPrefixWrapper is a wrapper provider that prepends prefix to the store names, isolating the stores opened
+// through it from the ones opened through other prefixes, like the stores of the tenants sharing a database.
+//
+// The data of the prefix can be deleted with Purge, the names of the stores opened through the wrapper being kept
+// in a store of the prefix.
+type ProviderPrefixWrapper struct {
+	provider storage.Provider
+	prefix   string
+	stores   []*namespacedStore
+	lock     sync.RWMutex
+}
+
+// OpenStore opens the store name of the namespace.
+func (p *ProviderPrefixWrapper) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(p.prefix + name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, s := range p.stores {
+		if s.Store == store {
+			return s, nil
+		}
+	}
+
+	if err = p.saveStoreName(name); err != nil {
+		return nil, err
+	}
+
+	s := &namespacedStore{Store: store}
+	p.stores = append(p.stores, s)
+
+	return s, nil
+}
+
+// SetStoreConfig sets the configuration of the store name of the namespace.
+func (p *ProviderPrefixWrapper) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	config.TagNames = append(append([]string{}, config.TagNames...), namespaceTag)
+
+	return p.provider.SetStoreConfig(p.prefix+name, config)
+}
+
+// GetStoreConfig returns the configuration of the store name of the namespace.
+func (p *ProviderPrefixWrapper) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	config, err := p.provider.GetStoreConfig(p.prefix + name)
+	if err != nil {
+		return config, err
+	}
+
+	var tagNames []string
+
+	for _, tagName := range config.TagNames {
+		if tagName != namespaceTag {
+			tagNames = append(tagNames, tagName)
+		}
+	}
+
+	config.TagNames = tagNames
+
+	return config, nil
+}
+
+// GetOpenStores returns the stores opened through the wrapper.
+func (p *ProviderPrefixWrapper) GetOpenStores() []storage.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	stores := make([]storage.Store, len(p.stores))
+	for i, s := range p.stores {
+		stores[i] = s
+	}
+
+	return stores
+}
+
+// Close closes the stores opened through the wrapper, the wrapped provider being left open.
+func (p *ProviderPrefixWrapper) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, store := range p.stores {
+		if err := store.Close(); err != nil {
+			return fmt.Errorf("close store: %w", err)
+		}
+	}
+
+	p.stores = nil
+
+	return nil
+}
+
+// Purge deletes the data of the prefix, i.e. the records of all the stores ever opened through the wrapper,
+// once their users are done with them (e.g. after the agent of a deleted tenant is closed).
+func (p *ProviderPrefixWrapper) Purge() error {
+	names, err := p.provider.OpenStore(p.prefix + storeNamesStore)
+	if err != nil {
+		return fmt.Errorf("open store names: %w", err)
+	}
+
+	storeNames, err := keys(names, namespaceTag)
+	if err != nil {
+		return fmt.Errorf("read store names: %w", err)
+	}
+
+	for _, name := range storeNames {
+		store, err := p.provider.OpenStore(p.prefix + name)
+		if err != nil {
+			return fmt.Errorf("open store %s: %w", name, err)
+		}
+
+		if err = deleteAll(store); err != nil {
+			return fmt.Errorf("purge store %s: %w", name, err)
+		}
+	}
+
+	if err = deleteAll(names); err != nil {
+		return fmt.Errorf("purge store names: %w", err)
+	}
+
+	return nil
+}
+
+func (p *ProviderPrefixWrapper) saveStoreName(name string) error {
+	names, err := p.provider.OpenStore(p.prefix + storeNamesStore)
+	if err != nil {
+		return fmt.Errorf("open store names: %w", err)
+	}
+
+	if err = names.Put(name, []byte(name), storage.Tag{Name: namespaceTag}); err != nil {
+		return fmt.Errorf("save store name: %w", err)
+	}
+
+	return nil
+}
+
+// deleteAll deletes the records of store written through the wrapper.
+func deleteAll(store storage.Store) error {
+	storeKeys, err := keys(store, namespaceTag)
+	if err != nil {
+		return err
+	}
+
+	if len(storeKeys) == 0 {
+		return nil
+	}
+
+	ops := make([]storage.Operation, len(storeKeys))
+	for i, k := range storeKeys {
+		ops[i] = storage.Operation{Key: k}
+	}
+
+	return store.Batch(ops)
+}
+
+func keys(store storage.Store, tagName string) ([]string, error) {
+	iter, err := store.Query(tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = iter.Close() // nolint: errcheck
+	}()
+
+	var storeKeys []string
+
+	more, err := iter.Next()
+	for ; err == nil && more; more, err = iter.Next() {
+		var k string
+
+		if k, err = iter.Key(); err != nil {
+			break
+		}
+
+		storeKeys = append(storeKeys, k)
+	}
+
+	return storeKeys, err
+}
+
+// namespacedStore tags the records written to a store of the prefix with the namespace tag.
+type namespacedStore struct {
+	storage.Store
+}
+
+func (s *namespacedStore) Put(k string, v []byte, tags ...storage.Tag) error {
+	return s.Store.Put(k, v, withNamespaceTag(tags)...)
+}
+
+func (s *namespacedStore) GetTags(k string) ([]storage.Tag, error) {
+	tags, err := s.Store.GetTags(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutNamespaceTag(tags), nil
+}
+
+func (s *namespacedStore) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	iter, err := s.Store.Query(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespacedIterator{Iterator: iter}, nil
+}
+
+func (s *namespacedStore) Batch(operations []storage.Operation) error {
+	ops := make([]storage.Operation, len(operations))
+
+	for i, op := range operations {
+		ops[i] = op
+
+		if op.Value != nil {
+			ops[i].Tags = withNamespaceTag(op.Tags)
+		}
+	}
+
+	return s.Store.Batch(ops)
+}
+
+type namespacedIterator struct {
+	storage.Iterator
+}
+
+func (i *namespacedIterator) Tags() ([]storage.Tag, error) {
+	tags, err := i.Iterator.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutNamespaceTag(tags), nil
+}
+
+func withNamespaceTag(tags []storage.Tag) []storage.Tag {
+	return append(append([]storage.Tag{}, tags...), storage.Tag{Name: namespaceTag})
+}
+
+func withoutNamespaceTag(tags []storage.Tag) []storage.Tag {
+	var filtered []storage.Tag
+
+	for _, tag := range tags {
+		if tag.Name != namespaceTag {
+			filtered = append(filtered, tag)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,214 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prefix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestProviderPrefixWrapper(t *testing.T) {
+	_, err := NewPrefixProviderWrapper(nil, "")
+	require.EqualError(t, err, "newPrefixProviderWrapper: prefix is empty")
+
+	t.Run("isolates the stores of the prefixes", func(t *testing.T) {
+		prov := mem.NewProvider()
+
+		tenant1, err := NewPrefixProviderWrapper(prov, "tenant1_")
+		require.NoError(t, err)
+
+		tenant2, err := NewPrefixProviderWrapper(prov, "tenant2_")
+		require.NoError(t, err)
+
+		store1, err := tenant1.OpenStore("store")
+		require.NoError(t, err)
+		require.NoError(t, store1.Put("key", []byte("value")))
+
+		store2, err := tenant2.OpenStore("store")
+		require.NoError(t, err)
+
+		_, err = store2.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		raw, err := prov.OpenStore("tenant1_store")
+		require.NoError(t, err)
+
+		value, err := raw.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	t.Run("sets and gets the store configurations of the prefix", func(t *testing.T) {
+		prov := mem.NewProvider()
+
+		wrapper, err := NewPrefixProviderWrapper(prov, "tenant_")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store")
+		require.NoError(t, err)
+
+		config := storage.StoreConfiguration{TagNames: []string{"tag"}}
+		require.NoError(t, wrapper.SetStoreConfig("store", config))
+
+		got, err := wrapper.GetStoreConfig("store")
+		require.NoError(t, err)
+		require.Equal(t, config, got)
+
+		got, err = prov.GetStoreConfig("tenant_store")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tag", namespaceTag}, got.TagNames)
+
+		_, err = wrapper.GetStoreConfig("unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("closes the stores of the prefix only", func(t *testing.T) {
+		prov := mem.NewProvider()
+
+		wrapper, err := NewPrefixProviderWrapper(prov, "tenant_")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store1")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store1")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store2")
+		require.NoError(t, err)
+
+		_, err = prov.OpenStore("other")
+		require.NoError(t, err)
+
+		require.Len(t, wrapper.GetOpenStores(), 2)
+		require.Len(t, prov.GetOpenStores(), 4)
+
+		require.NoError(t, wrapper.Close())
+		require.Empty(t, wrapper.GetOpenStores())
+		require.Len(t, prov.GetOpenStores(), 2)
+	})
+
+	t.Run("hides the namespace tag", func(t *testing.T) {
+		wrapper, err := NewPrefixProviderWrapper(mem.NewProvider(), "tenant_")
+		require.NoError(t, err)
+
+		store, err := wrapper.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key1", []byte("value"), storage.Tag{Name: "tag", Value: "1"}))
+		require.NoError(t, store.Batch([]storage.Operation{{Key: "key2", Value: []byte("value")}}))
+
+		tags, err := store.GetTags("key1")
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "tag", Value: "1"}}, tags)
+
+		iter, err := store.Query("tag")
+		require.NoError(t, err)
+
+		more, err := iter.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		tags, err = iter.Tags()
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "tag", Value: "1"}}, tags)
+		require.NoError(t, iter.Close())
+
+		tags, err = store.GetTags("key2")
+		require.NoError(t, err)
+		require.Empty(t, tags)
+	})
+
+	t.Run("purges the data of the prefix", func(t *testing.T) {
+		prov := mem.NewProvider()
+
+		wrapper, err := NewPrefixProviderWrapper(prov, "tenant1_")
+		require.NoError(t, err)
+
+		other, err := NewPrefixProviderWrapper(prov, "tenant2_")
+		require.NoError(t, err)
+
+		for _, w := range []*ProviderPrefixWrapper{wrapper, other} {
+			for _, name := range []string{"store1", "store2"} {
+				store, openErr := w.OpenStore(name)
+				require.NoError(t, openErr)
+				require.NoError(t, store.Put("key", []byte("value"), storage.Tag{Name: "tag"}))
+				require.NoError(t, store.Batch([]storage.Operation{{Key: "batched", Value: []byte("value")}}))
+			}
+		}
+
+		require.NoError(t, wrapper.Close())
+		require.NoError(t, wrapper.Purge())
+
+		for _, name := range []string{"store1", "store2"} {
+			store, openErr := prov.OpenStore("tenant1_" + name)
+			require.NoError(t, openErr)
+
+			for _, key := range []string{"key", "batched"} {
+				_, err = store.Get(key)
+				require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+			}
+
+			store, openErr = prov.OpenStore("tenant2_" + name)
+			require.NoError(t, openErr)
+
+			_, err = store.Get("key")
+			require.NoError(t, err)
+		}
+
+		// purging an empty prefix is a no-op
+		require.NoError(t, wrapper.Purge())
+	})
+
+	t.Run("purge errors", func(t *testing.T) {
+		wrapper, err := NewPrefixProviderWrapper(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}, "tenant_")
+		require.NoError(t, err)
+
+		require.EqualError(t, wrapper.Purge(), "open store names: open error")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		wrapper, err := NewPrefixProviderWrapper(&mockstorage.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("open error"),
+		}, "tenant_")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store")
+		require.EqualError(t, err, "open error")
+
+		wrapper, err = NewPrefixProviderWrapper(&mockstorage.MockStoreProvider{
+			Store: &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrPut: errors.New("put error")},
+		}, "tenant_")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store")
+		require.EqualError(t, err, "save store name: put error")
+	})
+
+	t.Run("close store error", func(t *testing.T) {
+		wrapper, err := NewPrefixProviderWrapper(&mockstorage.MockStoreProvider{
+			Store: &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}, ErrClose: errors.New("close error")},
+		}, "tenant_")
+		require.NoError(t, err)
+
+		_, err = wrapper.OpenStore("store")
+		require.NoError(t, err)
+
+		require.EqualError(t, wrapper.Close(), "close store: close error")
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
+)
+
+// Entity is an issuer or a verifier of the trust registry file.
+type Entity struct {
+	// DID of the entity.
+	DID string `json:"did" yaml:"did"`
+	// CredentialTypes the entity is authorized to issue or to request. Any type is authorized if none is defined.
+	CredentialTypes []string `json:"credentialTypes,omitempty" yaml:"credentialTypes,omitempty"`
+}
+
+// Registry is a trust registry defined by a JSON or YAML file, e.g.
+//
+//	issuers:
+//	  - did: did:example:76e12ec712ebc6f1c221ebfeb1f
+//	    credentialTypes:
+//	      - UniversityDegreeCredential
+//	verifiers:
+//	  - did: did:example:ebfeb1f712ebc6f1c276e12ec21
+type Registry struct {
+	Issuers   []Entity `json:"issuers,omitempty" yaml:"issuers,omitempty"`
+	Verifiers []Entity `json:"verifiers,omitempty" yaml:"verifiers,omitempty"`
+}
+
+// New returns the trust registry defined by the JSON or YAML file at path.
+func New(path string) (*Registry, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read trust registry file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses the trust registry from JSON or YAML.
+func Parse(data []byte) (*Registry, error) {
+	// JSON being YAML, the registry is decoded as YAML.
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	r := &Registry{}
+
+	// an empty registry has no document to decode.
+	if err := decoder.Decode(r); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode trust registry: %w", err)
+	}
+
+	return r, nil
+}
+
+// IsIssuerAuthorized returns true if the issuer DID is authorized to issue credentials of the type.
+func (r *Registry) IsIssuerAuthorized(did, credentialType string) (bool, error) {
+	return isAuthorized(r.Issuers, did, credentialType), nil
+}
+
+// IsVerifierAuthorized returns true if the verifier DID is authorized to request all the credential types of the
+// presentation definition.
+func (r *Registry) IsVerifierAuthorized(did string, pd *presexch.PresentationDefinition) (bool, error) {
+	for _, t := range trustregistry.RequestedCredentialTypes(pd) {
+		if !isAuthorized(r.Verifiers, did, t) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isAuthorized returns true if the entity is authorized for the credential type.
+func isAuthorized(entities []Entity, did, credentialType string) bool {
+	for _, e := range entities {
+		if e.DID != did {
+			continue
+		}
+
+		if len(e.CredentialTypes) == 0 {
+			return true
+		}
+
+		for _, t := range e.CredentialTypes {
+			if t == credentialType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+const registryYAML = `
+issuers:
+  - did: did:example:university
+    credentialTypes:
+      - UniversityDegreeCredential
+  - did: did:example:government
+verifiers:
+  - did: did:example:employer
+    credentialTypes:
+      - UniversityDegreeCredential
+  - did: did:example:bank
+`
+
+func TestNew(t *testing.T) {
+	t.Run("Read YAML file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "registry.yaml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(registryYAML), 0o600))
+
+		registry, err := New(path)
+		require.NoError(t, err)
+		require.Equal(t, &Registry{
+			Issuers: []Entity{
+				{DID: "did:example:university", CredentialTypes: []string{"UniversityDegreeCredential"}},
+				{DID: "did:example:government"},
+			},
+			Verifiers: []Entity{
+				{DID: "did:example:employer", CredentialTypes: []string{"UniversityDegreeCredential"}},
+				{DID: "did:example:bank"},
+			},
+		}, registry)
+	})
+
+	t.Run("Read JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "registry.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"issuers":[{"did":"did:example:university"}]}`), 0o600))
+
+		registry, err := New(path)
+		require.NoError(t, err)
+		require.Equal(t, &Registry{Issuers: []Entity{{DID: "did:example:university"}}}, registry)
+	})
+
+	t.Run("Empty registry", func(t *testing.T) {
+		registry, err := Parse(nil)
+		require.NoError(t, err)
+		require.Equal(t, &Registry{}, registry)
+
+		authorized, err := registry.IsIssuerAuthorized("did:example:university", "UniversityDegreeCredential")
+		require.NoError(t, err)
+		require.False(t, authorized)
+	})
+
+	t.Run("File not found", func(t *testing.T) {
+		_, err := New(filepath.Join(t.TempDir(), "registry.yaml"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read trust registry file")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Invalid registries", func(t *testing.T) {
+		for registry, errMsg := range map[string]string{
+			"issuer: did:example:university": "field issuer not found",
+			"issuers: [":                     "did not find expected node content",
+			"issuers: did:example:123":       "cannot unmarshal",
+		} {
+			_, err := Parse([]byte(registry))
+			require.Error(t, err, registry)
+			require.Contains(t, err.Error(), "decode trust registry", registry)
+			require.Contains(t, err.Error(), errMsg, registry)
+		}
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := Parse([]byte(registryYAML))
+	require.NoError(t, err)
+
+	t.Run("Issuers", func(t *testing.T) {
+		for _, tc := range []struct {
+			did            string
+			credentialType string
+			authorized     bool
+		}{
+			{did: "did:example:university", credentialType: "UniversityDegreeCredential", authorized: true},
+			{did: "did:example:university", credentialType: "PassportCredential"},
+			{did: "did:example:government", credentialType: "PassportCredential", authorized: true},
+			{did: "did:example:unknown", credentialType: "UniversityDegreeCredential"},
+		} {
+			authorized, err := registry.IsIssuerAuthorized(tc.did, tc.credentialType)
+			require.NoError(t, err)
+			require.Equal(t, tc.authorized, authorized, tc)
+		}
+	})
+
+	t.Run("Verifiers", func(t *testing.T) {
+		degree := presentationDefinition("https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential")
+		degreeAndPassport := presentationDefinition(
+			"https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential",
+			"https://example.com/credentials/v1#PassportCredential")
+		// requests a passport through a constraints field filter on the type instead of a schema.
+		passportFilter := &presexch.PresentationDefinition{ID: "pd", InputDescriptors: []*presexch.InputDescriptor{{
+			ID: "passport",
+			Constraints: &presexch.Constraints{Fields: []*presexch.Field{{
+				Path:   []string{"$.type"},
+				Filter: &presexch.Filter{Const: "PassportCredential"},
+			}}},
+		}}}
+
+		for _, tc := range []struct {
+			did        string
+			pd         *presexch.PresentationDefinition
+			authorized bool
+		}{
+			{did: "did:example:employer", pd: degree, authorized: true},
+			{did: "did:example:employer", pd: degreeAndPassport},
+			{did: "did:example:employer", pd: passportFilter},
+			{did: "did:example:employer"},
+			{did: "did:example:bank", pd: passportFilter, authorized: true},
+			{did: "did:example:bank", authorized: true},
+			{did: "did:example:bank", pd: degreeAndPassport, authorized: true},
+			{did: "did:example:unknown"},
+		} {
+			authorized, err := registry.IsVerifierAuthorized(tc.did, tc.pd)
+			require.NoError(t, err)
+			require.Equal(t, tc.authorized, authorized, tc)
+		}
+	})
+}
+
+func presentationDefinition(schemaURIs ...string) *presexch.PresentationDefinition {
+	pd := &presexch.PresentationDefinition{ID: "pd"}
+
+	for _, uri := range schemaURIs {
+		pd.InputDescriptors = append(pd.InputDescriptors, &presexch.InputDescriptor{
+			ID:     uri,
+			Schema: []*presexch.Schema{{URI: uri}},
+		})
+	}
+
+	return pd
+}
@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
+)
+
+const (
+	// ActionIssue is the action queried for the issuers.
+	ActionIssue = "issue"
+	// ActionVerify is the action queried for the verifiers.
+	ActionVerify = "verify"
+
+	authorizationPath = "/authorization"
+	contentTypeJSON   = "application/json"
+
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize limits the size in bytes of the trust registry responses read.
+	maxResponseSize = 64 * 1024
+)
+
+var logger = log.New("aries-framework/trustregistry/httpbinding")
+
+// AuthorizationQuery is the query of the authorization of an entity, sent to the trust registry.
+type AuthorizationQuery struct {
+	// EntityID is the DID of the issuer or the verifier.
+	EntityID string `json:"entity_id"`
+	// AuthorityID is the ecosystem governance authority, if the trust registry serves several of them.
+	AuthorityID string `json:"authority_id,omitempty"`
+	// Action is ActionIssue or ActionVerify.
+	Action string `json:"action"`
+	// Resource is the credential type.
+	Resource string `json:"resource,omitempty"`
+}
+
+// AuthorizationResponse is the response of the trust registry to an AuthorizationQuery.
+type AuthorizationResponse struct {
+	Authorized bool `json:"authorized"`
+}
+
+// Registry is a trust registry queried over HTTP(s), in the style of the Trust over IP Trust Registry Query
+// Protocol and TRAIN trust lists: the authorization of an entity for an action on a resource is queried by
+// POSTing an AuthorizationQuery to <endpoint>/authorization. An unknown entity (HTTP 404) is not authorized.
+type Registry struct {
+	endpointURL string
+	authorityID string
+	authToken   string
+	client      *http.Client
+	cacheTTL    time.Duration
+	cacheMutex  sync.Mutex
+	cache       map[AuthorizationQuery]cachedAuthorization
+}
+
+type cachedAuthorization struct {
+	authorized bool
+	expires    time.Time
+}
+
+// Option configures the HTTP trust registry.
+type Option func(opts *Registry)
+
+// WithTimeout option is for definition of HTTP(s) timeout value of the trust registry queries, 10 seconds by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Registry) {
+		opts.client.Timeout = timeout
+	}
+}
+
+// WithTLSConfig option is for definition of secured HTTP transport using a tls.Config instance.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(opts *Registry) {
+		opts.client.Transport = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	}
+}
+
+// WithCacheTTL caches the answers of the trust registry for the duration, so that the protocol messages of the
+// same issuers and verifiers do not each wait on a query. Answers are not cached by default.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(opts *Registry) {
+		opts.cacheTTL = ttl
+	}
+}
+
+// WithAuthorityID sets the ecosystem governance authority of the queries.
+func WithAuthorityID(authorityID string) Option {
+	return func(opts *Registry) {
+		opts.authorityID = authorityID
+	}
+}
+
+// WithAuthToken adds the bearer token to the queries.
+func WithAuthToken(authToken string) Option {
+	return func(opts *Registry) {
+		opts.authToken = "Bearer " + authToken
+	}
+}
+
+// New returns the trust registry served at the endpoint URL.
+func New(endpointURL string, opts ...Option) (*Registry, error) {
+	r := &Registry{
+		client: &http.Client{Timeout: defaultTimeout},
+		cache:  make(map[AuthorizationQuery]cachedAuthorization),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if _, err := url.ParseRequestURI(endpointURL); err != nil {
+		return nil, fmt.Errorf("base URL invalid: %w", err)
+	}
+
+	r.endpointURL = strings.TrimSuffix(endpointURL, "/")
+
+	return r, nil
+}
+
+// IsIssuerAuthorized returns true if the issuer DID is authorized to issue credentials of the type.
+func (r *Registry) IsIssuerAuthorized(did, credentialType string) (bool, error) {
+	return r.query(did, ActionIssue, credentialType)
+}
+
+// IsVerifierAuthorized returns true if the verifier DID is authorized to request all the credential types of the
+// presentation definition. Presentation definitions not restricting the requested types are queried for the
+// trustregistry.AnyCredentialType resource.
+func (r *Registry) IsVerifierAuthorized(did string, pd *presexch.PresentationDefinition) (bool, error) {
+	for _, t := range trustregistry.RequestedCredentialTypes(pd) {
+		authorized, err := r.query(did, ActionVerify, t)
+		if err != nil || !authorized {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (r *Registry) query(did, action, resource string) (bool, error) {
+	query := AuthorizationQuery{
+		EntityID:    did,
+		AuthorityID: r.authorityID,
+		Action:      action,
+		Resource:    resource,
+	}
+
+	if authorized, ok := r.cached(query); ok {
+		return authorized, nil
+	}
+
+	authorized, err := r.send(&query)
+	if err != nil {
+		return false, err
+	}
+
+	if r.cacheTTL > 0 {
+		r.cacheMutex.Lock()
+		r.cache[query] = cachedAuthorization{authorized: authorized, expires: time.Now().Add(r.cacheTTL)}
+		r.cacheMutex.Unlock()
+	}
+
+	return authorized, nil
+}
+
+func (r *Registry) cached(query AuthorizationQuery) (bool, bool) {
+	r.cacheMutex.Lock()
+	defer r.cacheMutex.Unlock()
+
+	c, ok := r.cache[query]
+	if !ok {
+		return false, false
+	}
+
+	if time.Now().After(c.expires) {
+		delete(r.cache, query)
+
+		return false, false
+	}
+
+	return c.authorized, true
+}
+
+func (r *Registry) send(query *AuthorizationQuery) (bool, error) {
+	reqBytes, err := json.Marshal(query)
+	if err != nil {
+		return false, fmt.Errorf("marshal authorization query: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpointURL+authorizationPath, bytes.NewReader(reqBytes))
+	if err != nil {
+		return false, fmt.Errorf("new authorization query request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	if r.authToken != "" {
+		req.Header.Set("Authorization", r.authToken)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("query trust registry: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	respBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return false, fmt.Errorf("read trust registry response: %w", err)
+	}
+
+	if len(respBytes) > maxResponseSize {
+		return false, fmt.Errorf("read trust registry response: exceeds %d bytes", maxResponseSize)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("query trust registry: status code %d: %s", resp.StatusCode, respBytes)
+	}
+
+	var authorization AuthorizationResponse
+
+	if err = json.Unmarshal(respBytes, &authorization); err != nil {
+		return false, fmt.Errorf("unmarshal trust registry response: %w", err)
+	}
+
+	return authorization.Authorized, nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
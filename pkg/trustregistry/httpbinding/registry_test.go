@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpbinding
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/trustregistry"
+)
+
+const (
+	university = "did:example:university"
+	employer   = "did:example:employer"
+	authority  = "did:example:authority"
+	authToken  = "token"
+)
+
+// trustRegistry is a local stand-in of a trust registry serving authorization queries.
+func trustRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	authorizations := map[string]map[string]bool{
+		university: {ActionIssue + "/UniversityDegreeCredential": true, ActionIssue + "/PassportCredential": false},
+		employer: {
+			ActionVerify + "/UniversityDegreeCredential":         true,
+			ActionVerify + "/" + trustregistry.AnyCredentialType: false,
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != authorizationPath {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+authToken {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var query AuthorizationQuery
+
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil || query.AuthorityID != authority {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		entity, ok := authorizations[query.EntityID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypeJSON)
+		require.NoError(t, json.NewEncoder(w).Encode(&AuthorizationResponse{
+			Authorized: entity[query.Action+"/"+query.Resource],
+		}))
+	}))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Options", func(t *testing.T) {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		registry, err := New("https://registry.example.com/", WithTimeout(time.Second), WithTLSConfig(tlsConfig),
+			WithAuthorityID(authority), WithAuthToken(authToken))
+		require.NoError(t, err)
+		require.Equal(t, "https://registry.example.com", registry.endpointURL)
+		require.Equal(t, authority, registry.authorityID)
+		require.Equal(t, "Bearer "+authToken, registry.authToken)
+		require.Equal(t, time.Second, registry.client.Timeout)
+		require.Equal(t, tlsConfig, registry.client.Transport.(*http.Transport).TLSClientConfig)
+	})
+
+	t.Run("Default timeout", func(t *testing.T) {
+		registry, err := New("https://registry.example.com")
+		require.NoError(t, err)
+		require.Equal(t, defaultTimeout, registry.client.Timeout)
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		_, err := New("registry")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "base URL invalid")
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	server := trustRegistry(t)
+	defer server.Close()
+
+	registry, err := New(server.URL, WithAuthorityID(authority), WithAuthToken(authToken))
+	require.NoError(t, err)
+
+	t.Run("Issuers", func(t *testing.T) {
+		for _, tc := range []struct {
+			did            string
+			credentialType string
+			authorized     bool
+		}{
+			{did: university, credentialType: "UniversityDegreeCredential", authorized: true},
+			{did: university, credentialType: "PassportCredential"},
+			{did: university, credentialType: "DriverLicenseCredential"},
+			{did: "did:example:unknown", credentialType: "UniversityDegreeCredential"},
+		} {
+			authorized, err := registry.IsIssuerAuthorized(tc.did, tc.credentialType)
+			require.NoError(t, err)
+			require.Equal(t, tc.authorized, authorized, tc)
+		}
+	})
+
+	t.Run("Verifiers", func(t *testing.T) {
+		degree := &presexch.InputDescriptor{Schema: []*presexch.Schema{
+			{URI: "https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"},
+		}}
+		passport := &presexch.InputDescriptor{Schema: []*presexch.Schema{
+			{URI: "https://example.com/credentials/v1#PassportCredential"},
+		}}
+
+		for _, tc := range []struct {
+			did        string
+			pd         *presexch.PresentationDefinition
+			authorized bool
+		}{
+			{did: employer, pd: &presexch.PresentationDefinition{InputDescriptors: []*presexch.InputDescriptor{degree}},
+				authorized: true},
+			{did: employer, pd: &presexch.PresentationDefinition{
+				InputDescriptors: []*presexch.InputDescriptor{degree, passport},
+			}},
+			{did: employer},
+			{did: university},
+		} {
+			authorized, err := registry.IsVerifierAuthorized(tc.did, tc.pd)
+			require.NoError(t, err)
+			require.Equal(t, tc.authorized, authorized, tc)
+		}
+	})
+
+	t.Run("Query errors", func(t *testing.T) {
+		unauthorized, err := New(server.URL, WithAuthorityID(authority))
+		require.NoError(t, err)
+
+		_, err = unauthorized.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+		require.EqualError(t, err, "query trust registry: status code 401: ")
+
+		_, err = unauthorized.IsVerifierAuthorized(employer, nil)
+		require.EqualError(t, err, "query trust registry: status code 401: ")
+
+		invalidResponse := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, writeErr := w.Write([]byte("{"))
+			require.NoError(t, writeErr)
+		}))
+		defer invalidResponse.Close()
+
+		registry, err := New(invalidResponse.URL)
+		require.NoError(t, err)
+
+		_, err = registry.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal trust registry response")
+
+		largeResponse := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, writeErr := w.Write(make([]byte, maxResponseSize+1))
+			require.NoError(t, writeErr)
+		}))
+		defer largeResponse.Close()
+
+		registry, err = New(largeResponse.URL)
+		require.NoError(t, err)
+
+		_, err = registry.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+		require.EqualError(t, err, "read trust registry response: exceeds 65536 bytes")
+
+		registry, err = New("http://127.0.0.1:1")
+		require.NoError(t, err)
+
+		_, err = registry.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query trust registry")
+	})
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	queries := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		queries++
+
+		require.NoError(t, json.NewEncoder(w).Encode(&AuthorizationResponse{Authorized: true}))
+	}))
+	defer server.Close()
+
+	registry, err := New(server.URL, WithCacheTTL(time.Hour))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		authorized, err := registry.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+		require.NoError(t, err)
+		require.True(t, authorized)
+	}
+
+	require.Equal(t, 1, queries)
+
+	// expire the cached answer.
+	for query := range registry.cache {
+		registry.cache[query] = cachedAuthorization{authorized: false, expires: time.Now().Add(-time.Second)}
+	}
+
+	authorized, err := registry.IsIssuerAuthorized(university, "UniversityDegreeCredential")
+	require.NoError(t, err)
+	require.True(t, authorized)
+	require.Equal(t, 2, queries)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+// Roles of the entities looked up in the trust registry.
+const (
+	// RoleIssuer is the role of the entities issuing credentials.
+	RoleIssuer = "issuer"
+	// RoleVerifier is the role of the entities requesting presentations.
+	RoleVerifier = "verifier"
+)
+
+// AnyCredentialType is the credential type looked up for credentials having no other type than the base
+// VerifiableCredential type, and for presentation definitions not restricting the requested credential types.
+const AnyCredentialType = "VerifiableCredential"
+
+// typePaths are the JSONPaths of the credential types in the constraints fields of presentation definitions.
+var typePaths = map[string]bool{ // nolint:gochecknoglobals
+	"$.type": true, "$['type']": true, "$.vc.type": true, "$.vc['type']": true,
+}
+
+// Provider looks up whether issuers and verifiers are authorized by a trust registry.
+type Provider interface {
+	// IsIssuerAuthorized returns true if the issuer DID is authorized to issue credentials of the type.
+	IsIssuerAuthorized(did, credentialType string) (bool, error)
+	// IsVerifierAuthorized returns true if the verifier DID is authorized to request the credentials of the
+	// presentation definition. The presentation definition may be nil if the request does not define one.
+	IsVerifierAuthorized(did string, pd *presexch.PresentationDefinition) (bool, error)
+}
+
+// Check is the result of a lookup in the trust registry.
+type Check struct {
+	Role            string   `json:"role"`
+	DID             string   `json:"did,omitempty"`
+	CredentialTypes []string `json:"credentialTypes,omitempty"`
+	Authorized      bool     `json:"authorized"`
+	// Undetermined is true if the entity to look up could not be determined, the Error telling why.
+	Undetermined bool   `json:"undetermined,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Decision is the trust decision about the issuers or the verifier of a protocol message. It is made on the DIDs
+// claimed by the message, which are not verified by the decision.
+type Decision struct {
+	// Authorized is true if all the checks passed.
+	Authorized bool    `json:"authorized"`
+	Checks     []Check `json:"checks"`
+}
+
+// NewDecision returns a new Decision without checks.
+func NewDecision() *Decision {
+	return &Decision{Authorized: true}
+}
+
+// CheckIssuer checks that the issuer is authorized to issue credentials of all the types. The base
+// VerifiableCredential type is only checked if it is the only type of the credential.
+func (d *Decision) CheckIssuer(p Provider, did string, credentialTypes []string) {
+	types := issuedTypes(credentialTypes)
+	check := Check{Role: RoleIssuer, DID: did, CredentialTypes: types, Authorized: true}
+
+	for _, t := range types {
+		authorized, err := p.IsIssuerAuthorized(did, t)
+		if err != nil {
+			check.Authorized, check.Error = false, err.Error()
+
+			break
+		}
+
+		if !authorized {
+			check.Authorized = false
+
+			break
+		}
+	}
+
+	d.add(check)
+}
+
+// CheckVerifier checks that the verifier is authorized to request the credentials of the presentation definition.
+func (d *Decision) CheckVerifier(p Provider, did string, pd *presexch.PresentationDefinition) {
+	check := Check{Role: RoleVerifier, DID: did, CredentialTypes: RequestedCredentialTypes(pd)}
+
+	authorized, err := p.IsVerifierAuthorized(did, pd)
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	check.Authorized = err == nil && authorized
+
+	d.add(check)
+}
+
+// AddUndetermined adds the check of an entity with the role that could not be determined for the reason. The check
+// is not authorized.
+func (d *Decision) AddUndetermined(role, reason string) {
+	d.add(Check{Role: role, Undetermined: true, Error: reason})
+}
+
+func (d *Decision) add(check Check) {
+	d.Checks = append(d.Checks, check)
+	d.Authorized = d.Authorized && check.Authorized
+}
+
+// RequestedCredentialTypes returns the credential types requested by the presentation definition, i.e. the
+// fragments of the schema URIs of its input descriptors (e.g. UniversityDegreeCredential for
+// https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential), or the schema URIs having none,
+// and the const or enum values of the filters of its constraints fields on the credential type.
+// AnyCredentialType is returned for filters on the type not listing the types, and if the presentation definition
+// does not restrict the requested types.
+func RequestedCredentialTypes(pd *presexch.PresentationDefinition) []string {
+	var types []string
+
+	if pd != nil {
+		for _, descriptor := range pd.InputDescriptors {
+			if descriptor == nil {
+				continue
+			}
+
+			types = addSchemaTypes(types, descriptor.Schema)
+
+			if descriptor.Constraints != nil {
+				types = addFilterTypes(types, descriptor.Constraints.Fields)
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return []string{AnyCredentialType}
+	}
+
+	return types
+}
+
+func addSchemaTypes(types []string, schemas []*presexch.Schema) []string {
+	for _, schema := range schemas {
+		if schema == nil || schema.URI == "" {
+			continue
+		}
+
+		t := schema.URI
+		if i := strings.LastIndex(t, "#"); i >= 0 {
+			t = t[i+1:]
+		}
+
+		if !contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
+
+func addFilterTypes(types []string, fields []*presexch.Field) []string {
+	for _, field := range fields {
+		if field == nil || field.Filter == nil || !isTypeField(field) {
+			continue
+		}
+
+		var filterTypes []string
+
+		for _, v := range append([]presexch.StrOrInt{field.Filter.Const}, field.Filter.Enum...) {
+			if t, ok := v.(string); ok && t != "" {
+				filterTypes = append(filterTypes, t)
+			}
+		}
+
+		// a filter on the type not listing the types, e.g. a pattern, may match any credential type.
+		if len(filterTypes) == 0 {
+			filterTypes = []string{AnyCredentialType}
+		}
+
+		for _, t := range filterTypes {
+			if !contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+
+	return types
+}
+
+func isTypeField(field *presexch.Field) bool {
+	for _, p := range field.Path {
+		if typePaths[p] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func issuedTypes(credentialTypes []string) []string {
+	var types []string
+
+	for _, t := range credentialTypes {
+		if t != AnyCredentialType && !contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	if len(types) == 0 {
+		return []string{AnyCredentialType}
+	}
+
+	return types
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
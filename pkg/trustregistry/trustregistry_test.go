@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustregistry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+func TestRequestedCredentialTypes(t *testing.T) {
+	t.Run("Types of schema URIs", func(t *testing.T) {
+		require.Equal(t, []string{"UniversityDegreeCredential", "https://example.com/schemas/driver-license.json"},
+			RequestedCredentialTypes(&presexch.PresentationDefinition{
+				InputDescriptors: []*presexch.InputDescriptor{
+					{Schema: []*presexch.Schema{
+						{URI: "https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"},
+						{URI: ""},
+						nil,
+					}},
+					nil,
+					{Schema: []*presexch.Schema{
+						{URI: "https://www.w3.org/2018/credentials/v1#UniversityDegreeCredential"},
+						{URI: "https://example.com/schemas/driver-license.json"},
+					}},
+				},
+			}))
+	})
+
+	t.Run("Types of constraints fields filters", func(t *testing.T) {
+		passport := "PassportCredential"
+
+		require.Equal(t, []string{"UniversityDegreeCredential", passport, "DriverLicenseCredential", AnyCredentialType},
+			RequestedCredentialTypes(&presexch.PresentationDefinition{
+				InputDescriptors: []*presexch.InputDescriptor{
+					{
+						Schema: []*presexch.Schema{
+							{URI: "https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"},
+						},
+						Constraints: &presexch.Constraints{Fields: []*presexch.Field{
+							{Path: []string{"$.type"}, Filter: &presexch.Filter{Const: passport}},
+							{Path: []string{"$.credentialSubject.type"}, Filter: &presexch.Filter{Const: "Person"}},
+							{Path: []string{"$.type"}},
+							nil,
+						}},
+					},
+					{Constraints: &presexch.Constraints{Fields: []*presexch.Field{
+						{Path: []string{"$.vc.type", "$.type"}, Filter: &presexch.Filter{
+							Enum: []presexch.StrOrInt{passport, "DriverLicenseCredential"},
+						}},
+						{Path: []string{"$['type']"}, Filter: &presexch.Filter{Pattern: "Credential$"}},
+					}}},
+				},
+			}))
+	})
+
+	t.Run("Types not restricted", func(t *testing.T) {
+		require.Equal(t, []string{AnyCredentialType}, RequestedCredentialTypes(nil))
+		require.Equal(t, []string{AnyCredentialType}, RequestedCredentialTypes(&presexch.PresentationDefinition{
+			InputDescriptors: []*presexch.InputDescriptor{{ID: "any"}},
+		}))
+	})
+}
+
+func TestDecision(t *testing.T) {
+	registry := &mockRegistry{
+		issuers:   map[string][]string{"did:example:issuer": {"UniversityDegreeCredential", AnyCredentialType}},
+		verifiers: map[string]bool{"did:example:verifier": true},
+	}
+
+	pd := &presexch.PresentationDefinition{
+		InputDescriptors: []*presexch.InputDescriptor{{
+			Schema: []*presexch.Schema{{URI: "https://www.w3.org/2018/credentials/examples/v1#UniversityDegreeCredential"}},
+		}},
+	}
+
+	t.Run("Authorized", func(t *testing.T) {
+		decision := NewDecision()
+		decision.CheckIssuer(registry, "did:example:issuer", []string{AnyCredentialType, "UniversityDegreeCredential"})
+		decision.CheckIssuer(registry, "did:example:issuer", []string{AnyCredentialType})
+		decision.CheckVerifier(registry, "did:example:verifier", pd)
+
+		require.Equal(t, &Decision{
+			Authorized: true,
+			Checks: []Check{
+				{
+					Role: RoleIssuer, DID: "did:example:issuer",
+					CredentialTypes: []string{"UniversityDegreeCredential"}, Authorized: true,
+				},
+				{Role: RoleIssuer, DID: "did:example:issuer", CredentialTypes: []string{AnyCredentialType}, Authorized: true},
+				{
+					Role: RoleVerifier, DID: "did:example:verifier",
+					CredentialTypes: []string{"UniversityDegreeCredential"}, Authorized: true,
+				},
+			},
+		}, decision)
+	})
+
+	t.Run("Not authorized", func(t *testing.T) {
+		decision := NewDecision()
+		decision.CheckIssuer(registry, "did:example:issuer",
+			[]string{AnyCredentialType, "UniversityDegreeCredential", "PassportCredential"})
+		decision.CheckVerifier(registry, "did:example:issuer", pd)
+
+		require.False(t, decision.Authorized)
+		require.False(t, decision.Checks[0].Authorized)
+		require.Empty(t, decision.Checks[0].Error)
+		require.False(t, decision.Checks[1].Authorized)
+	})
+
+	t.Run("Lookup errors", func(t *testing.T) {
+		registry := &mockRegistry{err: errors.New("registry unavailable")}
+
+		decision := NewDecision()
+		decision.CheckIssuer(registry, "did:example:issuer", []string{"UniversityDegreeCredential"})
+		decision.CheckVerifier(registry, "did:example:verifier", nil)
+
+		require.False(t, decision.Authorized)
+
+		for _, check := range decision.Checks {
+			require.False(t, check.Authorized)
+			require.Equal(t, "registry unavailable", check.Error)
+		}
+	})
+
+	t.Run("Undetermined", func(t *testing.T) {
+		decision := NewDecision()
+		decision.CheckIssuer(registry, "did:example:issuer", []string{"UniversityDegreeCredential"})
+		decision.AddUndetermined(RoleIssuer, "unsupported credential format")
+
+		require.Equal(t, &Decision{
+			Checks: []Check{
+				{
+					Role: RoleIssuer, DID: "did:example:issuer",
+					CredentialTypes: []string{"UniversityDegreeCredential"}, Authorized: true,
+				},
+				{Role: RoleIssuer, Undetermined: true, Error: "unsupported credential format"},
+			},
+		}, decision)
+	})
+}
+
+type mockRegistry struct {
+	issuers   map[string][]string
+	verifiers map[string]bool
+	err       error
+}
+
+func (m *mockRegistry) IsIssuerAuthorized(did, credentialType string) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+
+	for _, t := range m.issuers[did] {
+		if t == credentialType {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *mockRegistry) IsVerifierAuthorized(did string, _ *presexch.PresentationDefinition) (bool, error) {
+	return m.verifiers[did], m.err
+}
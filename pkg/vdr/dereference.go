@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	didLDJSONContentType = "application/did+ld+json"
+	uriListContentType   = "text/uri-list"
+
+	serviceQuery     = "service"
+	relativeRefQuery = "relativeRef"
+)
+
+// Dereference dereferences a DID URL (https://w3c-ccg.github.io/did-resolution/#dereferencing).
+//
+// Supported DID URLs select the DID document itself, a verification method or service by fragment,
+// or a service endpoint by the service and relativeRef query parameters. The versionId and versionTime
+// query parameters are used to resolve a specific version of the DID document.
+func (r *Registry) Dereference(didURL string,
+	opts ...vdrapi.DIDMethodOption) (*diddoc.DereferencingResult, error) {
+	parsed, err := diddoc.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse did url: %s: %w", err, vdrapi.ErrInvalidDIDURL)
+	}
+
+	docResolution, err := r.Resolve(parsed.DID.String(), append(versionOpts(parsed), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if docResolution == nil || docResolution.DIDDocument == nil {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	result := &diddoc.DereferencingResult{
+		ContentMetadata:       docResolution.DocumentMetadata,
+		DereferencingMetadata: &diddoc.ResolutionMetadata{ContentType: didLDJSONContentType},
+	}
+
+	doc := docResolution.DIDDocument
+
+	switch {
+	case parsed.Query(serviceQuery) != "":
+		endpoint, e := dereferenceService(doc, parsed)
+		if e != nil {
+			return nil, e
+		}
+
+		result.Content = endpoint
+		result.DereferencingMetadata.ContentType = uriListContentType
+	case parsed.Path != "":
+		return nil, fmt.Errorf("dereferencing DID URL path %s is not supported: %w", parsed.Path,
+			vdrapi.ErrNotFound)
+	case parsed.Fragment != "":
+		content, found := dereferenceFragment(doc, parsed.Fragment)
+		if !found {
+			return nil, fmt.Errorf("fragment %s not found in DID document %s: %w", parsed.Fragment, doc.ID,
+				vdrapi.ErrNotFound)
+		}
+
+		result.Content = content
+	default:
+		result.Content = doc
+	}
+
+	return result, nil
+}
+
+// dereferenceService selects a service endpoint and applies the relativeRef and fragment of the DID URL to it.
+func dereferenceService(doc *diddoc.Doc, didURL *diddoc.DIDURL) (string, error) {
+	name := didURL.Query(serviceQuery)
+
+	svc, found := lookupService(doc, name)
+	if !found {
+		return "", fmt.Errorf("service %s not found in DID document %s: %w", name, doc.ID, vdrapi.ErrNotFound)
+	}
+
+	endpoint, err := url.Parse(svc.ServiceEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse service endpoint %s: %w", svc.ServiceEndpoint, err)
+	}
+
+	if relativeRef := didURL.Query(relativeRefQuery); relativeRef != "" {
+		ref, e := url.Parse(relativeRef)
+		if e != nil {
+			return "", fmt.Errorf("parse relativeRef %s: %s: %w", relativeRef, e, vdrapi.ErrInvalidDIDURL)
+		}
+
+		if !strings.HasSuffix(endpoint.Path, "/") && strings.HasPrefix(ref.Path, "/") {
+			// relativeRef extends the endpoint path rather than replacing it.
+			ref.Path = endpoint.Path + ref.Path
+		}
+
+		endpoint = endpoint.ResolveReference(ref)
+	}
+
+	if didURL.Fragment != "" {
+		endpoint.Fragment = didURL.Fragment
+	}
+
+	return endpoint.String(), nil
+}
+
+// dereferenceFragment looks up the verification method or service identified by the fragment.
+func dereferenceFragment(doc *diddoc.Doc, fragment string) (interface{}, bool) {
+	for i := range doc.VerificationMethod {
+		if matchesFragment(doc.ID, doc.VerificationMethod[i].ID, fragment) {
+			return &doc.VerificationMethod[i], true
+		}
+	}
+
+	relationships := [][]diddoc.Verification{
+		doc.Authentication, doc.AssertionMethod, doc.CapabilityDelegation, doc.CapabilityInvocation, doc.KeyAgreement,
+	}
+
+	for _, verifications := range relationships {
+		for i := range verifications {
+			if verifications[i].Embedded && matchesFragment(doc.ID, verifications[i].VerificationMethod.ID, fragment) {
+				return &verifications[i].VerificationMethod, true
+			}
+		}
+	}
+
+	if svc, found := lookupService(doc, fragment); found {
+		return svc, true
+	}
+
+	return nil, false
+}
+
+func lookupService(doc *diddoc.Doc, fragment string) (*diddoc.Service, bool) {
+	for i := range doc.Service {
+		if matchesFragment(doc.ID, doc.Service[i].ID, fragment) {
+			return &doc.Service[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// matchesFragment checks whether the absolute or relative id refers to the given fragment of the DID.
+func matchesFragment(did, id, fragment string) bool {
+	return id == did+"#"+fragment || id == "#"+fragment
+}
+
+func versionOpts(didURL *diddoc.DIDURL) []vdrapi.DIDMethodOption {
+	var opts []vdrapi.DIDMethodOption
+
+	if versionID := didURL.Query(vdrapi.VersionIDOpt); versionID != "" {
+		opts = append(opts, vdrapi.WithOption(vdrapi.VersionIDOpt, versionID))
+	}
+
+	if versionTime := didURL.Query(vdrapi.VersionTimeOpt); versionTime != "" {
+		opts = append(opts, vdrapi.WithOption(vdrapi.VersionTimeOpt, versionTime))
+	}
+
+	return opts
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const testDID = "did:example:123456"
+
+func TestRegistry_Dereference(t *testing.T) {
+	doc := &did.Doc{
+		ID: testDID,
+		VerificationMethod: []did.VerificationMethod{
+			{ID: testDID + "#key-1", Type: "Ed25519VerificationKey2018", Controller: testDID, Value: []byte("key")},
+		},
+		KeyAgreement: []did.Verification{{
+			VerificationMethod: did.VerificationMethod{
+				ID: testDID + "#key-2", Type: "X25519KeyAgreementKey2019", Controller: testDID, Value: []byte("key"),
+			},
+			Relationship: did.KeyAgreement,
+			Embedded:     true,
+		}},
+		Service: []did.Service{
+			{ID: testDID + "#agent", Type: "did-communication", ServiceEndpoint: "https://agent.example.com"},
+			{ID: "#files", Type: "LinkedDomains", ServiceEndpoint: "https://example.com/files"},
+		},
+	}
+
+	var versionID string
+
+	registry := New(WithVDR(&mockvdr.MockVDR{
+		AcceptValue: true,
+		ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+			for _, opt := range opts {
+				opt(didOpts)
+			}
+
+			versionID, _ = didOpts.Values[vdrapi.VersionIDOpt].(string) // nolint: errcheck
+
+			if didID != testDID {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			return &did.DocResolution{DIDDocument: doc, DocumentMetadata: &did.DocumentMetadata{VersionID: "3"}}, nil
+		},
+	}))
+
+	t.Run("dereference DID document", func(t *testing.T) {
+		result, err := registry.Dereference(testDID + "?versionId=3")
+		require.NoError(t, err)
+		require.Equal(t, doc, result.Content)
+		require.Equal(t, "3", versionID)
+		require.Equal(t, "3", result.ContentMetadata.VersionID)
+		require.Equal(t, didLDJSONContentType, result.DereferencingMetadata.ContentType)
+	})
+
+	t.Run("dereference verification method", func(t *testing.T) {
+		result, err := registry.Dereference(testDID + "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, &doc.VerificationMethod[0], result.Content)
+
+		result, err = registry.Dereference(testDID + "#key-2")
+		require.NoError(t, err)
+		require.Equal(t, &doc.KeyAgreement[0].VerificationMethod, result.Content)
+	})
+
+	t.Run("dereference service", func(t *testing.T) {
+		result, err := registry.Dereference(testDID + "#agent")
+		require.NoError(t, err)
+		require.Equal(t, &doc.Service[0], result.Content)
+	})
+
+	t.Run("dereference service endpoint", func(t *testing.T) {
+		result, err := registry.Dereference(testDID + "?service=files&relativeRef=%2Fresume.pdf")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/files/resume.pdf", result.Content)
+		require.Equal(t, uriListContentType, result.DereferencingMetadata.ContentType)
+
+		result, err = registry.Dereference(testDID + "?service=agent#frag")
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com#frag", result.Content)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := registry.Dereference(testDID + "#key-3")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		_, err = registry.Dereference(testDID + "?service=unknown")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		_, err = registry.Dereference(testDID + "/path")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		_, err = registry.Dereference("did:example:654321")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("invalid DID URL", func(t *testing.T) {
+		_, err := registry.Dereference("example:123#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDIDURL))
+		require.Equal(t, did.ResolutionErrorInvalidDIDURL, vdrapi.ResolutionErrorCode(err))
+	})
+
+	t.Run("method not supported", func(t *testing.T) {
+		_, err := New().Dereference(testDID + "#key-1")
+		require.True(t, errors.Is(err, vdrapi.ErrMethodNotSupported))
+		require.Equal(t, did.ResolutionErrorMethodNotSupported, vdrapi.ResolutionErrorCode(err))
+	})
+}
@@ -9,19 +9,34 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
-	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 const (
-	didLDJson = "application/did+ld+json"
+	didLDJson         = "application/did+ld+json"
+	didJSON           = "application/did+json"
+	ldJSON            = "application/ld+json"
+	jsonContentType   = "application/json"
+	versionIDParam    = "versionId"
+	versionTimeParam  = "versionTime"
+	resolutionProfile = "https://w3id.org/did-resolution"
 )
 
+// supportedContentTypes are the response media types understood by the DID resolution client.
+var supportedContentTypes = map[string]bool{ //nolint:gochecknoglobals
+	didLDJson:       true,
+	didJSON:         true,
+	ldJSON:          true,
+	jsonContentType: true,
+}
+
 // resolveDID makes DID resolution via HTTP.
 func (v *VDR) resolveDID(uri string) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodGet, uri, nil)
@@ -29,7 +44,9 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 		return nil, fmt.Errorf("HTTP create get request failed: %w", err)
 	}
 
-	req.Header.Add("Accept", didLDJson)
+	for _, accept := range v.acceptContentTypes {
+		req.Header.Add("Accept", accept)
+	}
 
 	if v.resolveAuthToken != "" {
 		req.Header.Add("Authorization", v.resolveAuthToken)
@@ -49,18 +66,49 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 		return nil, fmt.Errorf("reading response body failed: %w", err)
 	}
 
-	if resp.StatusCode == http.StatusOK && strings.Contains(resp.Header.Get("Content-type"), didLDJson) {
-		return gotBody, nil
-	} else if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("DID does not exist for request: %s", uri)
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-type"))
+	if err != nil {
+		mediaType = ""
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if supportedContentTypes[mediaType] {
+			return gotBody, nil
+		}
+	case http.StatusGone:
+		// deactivated DIDs may still return the resolution result with the DID document.
+		if supportedContentTypes[mediaType] {
+			if _, e := did.ParseDocumentResolution(gotBody); e == nil {
+				return gotBody, nil
+			}
+		}
+
+		return nil, fmt.Errorf("DID deactivated for request: %s: %w", uri, vdrapi.ErrDeactivated)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("DID does not exist for request: %s: %w", uri, vdrapi.ErrNotFound)
+	case http.StatusBadRequest, http.StatusNotImplemented:
+		if resolutionErr := resolutionError(gotBody); resolutionErr != nil {
+			return nil, fmt.Errorf("DID resolution failed for request: %s: %w", uri, resolutionErr)
+		}
 	}
 
 	return nil, fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
 		resp.StatusCode, resp.Header.Get("Content-type"), gotBody)
 }
 
+// resolutionError returns the error matching the didResolutionMetadata error code of the response body.
+func resolutionError(body []byte) error {
+	resolutionMeta, err := did.ParseResolutionMetadata(body)
+	if err != nil {
+		return nil
+	}
+
+	return vdrapi.ResolutionError(resolutionMeta.Error)
+}
+
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
-func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	reqURL, err := url.ParseRequestURI(v.endpointURL)
 	if err != nil {
 		return nil, fmt.Errorf("url parse request uri failed: %w", err)
@@ -68,6 +116,13 @@ func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolutio
 
 	reqURL.Path = path.Join(reqURL.Path, didID)
 
+	query, err := versionQuery(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL.RawQuery = query.Encode()
+
 	data, err := v.resolveDID(reqURL.String())
 	if err != nil {
 		return nil, err
@@ -83,7 +138,7 @@ func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolutio
 			return nil, err
 		}
 
-		logger.Warnf("parse document resolution failed %w", err)
+		logger.Warnf("parse document resolution failed %s", err)
 	} else {
 		return documentResolution, nil
 	}
@@ -97,3 +152,34 @@ func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolutio
 
 	return &did.DocResolution{DIDDocument: didDoc}, nil
 }
+
+// versionQuery builds the versionId and versionTime resolution parameters from the did method options.
+func versionQuery(opts ...vdrapi.DIDMethodOption) (url.Values, error) {
+	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(didMethodOpts)
+	}
+
+	query := url.Values{}
+
+	if versionID, ok := didMethodOpts.Values[vdrapi.VersionIDOpt].(string); ok && versionID != "" {
+		query.Set(versionIDParam, versionID)
+	}
+
+	switch versionTime := didMethodOpts.Values[vdrapi.VersionTimeOpt].(type) {
+	case nil:
+	case time.Time:
+		query.Set(versionTimeParam, versionTime.UTC().Format(time.RFC3339))
+	case string:
+		if _, err := time.Parse(time.RFC3339, versionTime); err != nil {
+			return nil, fmt.Errorf("invalid versionTime %s: %w", versionTime, err)
+		}
+
+		query.Set(versionTimeParam, versionTime)
+	default:
+		return nil, fmt.Errorf("invalid versionTime type %T", versionTime)
+	}
+
+	return query, nil
+}
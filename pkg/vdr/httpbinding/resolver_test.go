@@ -211,6 +211,106 @@ func TestRead_DIDDocNotFound(t *testing.T) {
 	require.Contains(t, err.Error(), "DID does not exist")
 }
 
+func TestRead_VersionParameters(t *testing.T) {
+	versionTime := time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/did:example:334455", req.URL.Path)
+		require.Equal(t, "2", req.URL.Query().Get("versionId"))
+		require.Equal(t, "2021-05-10T17:00:00Z", req.URL.Query().Get("versionTime"))
+		require.Equal(t, []string{didJSON, didLDJson}, req.Header.Values("Accept"))
+
+		res.Header().Add("Content-type", "application/did+json; charset=utf-8")
+		_, err := res.Write([]byte(didResolutionData))
+		require.NoError(t, err)
+	}))
+
+	defer func() { testServer.Close() }()
+
+	resolver, err := New(testServer.URL, WithAcceptContentTypes(didJSON, didLDJson))
+	require.NoError(t, err)
+
+	gotDocument, err := resolver.Read("did:example:334455",
+		vdrapi.WithOption(vdrapi.VersionIDOpt, "2"), vdrapi.WithOption(vdrapi.VersionTimeOpt, versionTime))
+	require.NoError(t, err)
+	require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", gotDocument.DIDDocument.ID)
+
+	_, err = resolver.Read("did:example:334455",
+		vdrapi.WithOption(vdrapi.VersionIDOpt, "2"), vdrapi.WithOption(vdrapi.VersionTimeOpt, "2021-05-10T17:00:00Z"))
+	require.NoError(t, err)
+
+	_, err = resolver.Read("did:example:334455", vdrapi.WithOption(vdrapi.VersionTimeOpt, "yesterday"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid versionTime")
+
+	_, err = resolver.Read("did:example:334455", vdrapi.WithOption(vdrapi.VersionTimeOpt, 1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid versionTime type")
+}
+
+func TestRead_ResolutionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		err    error
+	}{
+		{name: "not found", status: http.StatusNotFound, err: vdrapi.ErrNotFound},
+		{
+			name: "invalid did", status: http.StatusBadRequest, err: vdrapi.ErrInvalidDID,
+			body: `{"didResolutionMetadata":{"error":"invalidDid"}}`,
+		},
+		{
+			name: "method not supported", status: http.StatusNotImplemented, err: vdrapi.ErrMethodNotSupported,
+			body: `{"didResolutionMetadata":{"error":"methodNotSupported"}}`,
+		},
+		{
+			name: "deactivated", status: http.StatusGone, err: vdrapi.ErrDeactivated,
+			body: `{"didResolutionMetadata":{"error":"deactivated"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				res.Header().Add("Content-type", "application/did+ld+json")
+				res.WriteHeader(tc.status)
+				_, err := res.Write([]byte(tc.body))
+				require.NoError(t, err)
+			}))
+
+			defer func() { testServer.Close() }()
+
+			resolver, err := New(testServer.URL)
+			require.NoError(t, err)
+
+			_, err = resolver.Read("did:example:334455")
+			require.Error(t, err)
+			require.True(t, errors.Is(err, tc.err))
+		})
+	}
+
+	t.Run("deactivated with document", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Add("Content-type", "application/did+ld+json")
+			res.WriteHeader(http.StatusGone)
+			_, err := res.Write([]byte(`{"didDocument":` + doc + `,"didDocumentMetadata":{"deactivated":true}}`))
+			require.NoError(t, err)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		docResolution, err := resolver.Read("did:example:334455")
+		require.NoError(t, err)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+	})
+}
+
 func TestRead_UnsupportedStatus(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		res.WriteHeader(http.StatusForbidden)
@@ -23,10 +23,11 @@ var logger = log.New("aries-framework/vdr/httpbinding")
 
 // VDR via HTTP(s) endpoint.
 type VDR struct {
-	endpointURL      string
-	client           *http.Client
-	accept           Accept
-	resolveAuthToken string
+	endpointURL        string
+	client             *http.Client
+	accept             Accept
+	resolveAuthToken   string
+	acceptContentTypes []string
 }
 
 // Accept is method to accept did method.
@@ -34,7 +35,11 @@ type Accept func(method string) bool
 
 // New creates new DID Resolver.
 func New(endpointURL string, opts ...Option) (*VDR, error) {
-	v := &VDR{client: &http.Client{}, accept: func(method string) bool { return true }}
+	v := &VDR{
+		client:             &http.Client{},
+		accept:             func(method string) bool { return true },
+		acceptContentTypes: []string{didLDJson},
+	}
 
 	for _, opt := range opts {
 		opt(v)
@@ -102,6 +107,15 @@ func WithAccept(accept Accept) Option {
 	}
 }
 
+// WithAcceptContentTypes sets the media types sent in the Accept header of resolution requests,
+// e.g. application/did+ld+json, application/did+json or
+// application/ld+json;profile="https://w3id.org/did-resolution". Defaults to application/did+ld+json.
+func WithAcceptContentTypes(contentTypes ...string) Option {
+	return func(opts *VDR) {
+		opts.acceptContentTypes = contentTypes
+	}
+}
+
 // WithResolveAuthToken add auth token for resolve.
 func WithResolveAuthToken(authToken string) Option {
 	return func(opts *VDR) {
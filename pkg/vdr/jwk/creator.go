@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	schemaResV1                = "https://w3id.org/did-resolution/v1"
+	jsonWebKey2020Context      = "https://w3id.org/security/suites/jws-2020/v1"
+	jsonWebKey2020             = "JsonWebKey2020"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+
+	// vmFragment is the fragment of the single verification method of a did:jwk document.
+	vmFragment = "#0"
+)
+
+// Create creates a did:jwk DID document.
+// didDoc must contain a verification method which is either a JsonWebKey2020 with a JWK or holds the raw public
+// key bytes of a kms key. The kms key type is taken from the KeyType option or derived from the verification
+// method type.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	createDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(createDIDOpts)
+	}
+
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	jwk, err := publicKeyJWK(&didDoc.VerificationMethod[0], createDIDOpts.Values[KeyType])
+	if err != nil {
+		return nil, err
+	}
+
+	jwkBytes, err := json.Marshal(jwk)
+	if err != nil {
+		return nil, fmt.Errorf("marshal jwk: %w", err)
+	}
+
+	// resolve the new DID so that the created document is identical to the resolved one.
+	return v.Read(fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes)))
+}
+
+// publicKeyJWK returns the public JWK of the verification method.
+func publicKeyJWK(vm *did.VerificationMethod, keyTypeOpt interface{}) (*jose.JWK, error) {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		return publicJWK(jwk)
+	}
+
+	var keyType kms.KeyType
+
+	switch kt := keyTypeOpt.(type) {
+	case kms.KeyType:
+		keyType = kt
+	case string:
+		keyType = kms.KeyType(kt)
+	case nil:
+		switch vm.Type {
+		case ed25519VerificationKey2018:
+			keyType = kms.ED25519Type
+		case x25519KeyAgreementKey2019:
+			keyType = kms.X25519ECDHKWType
+		case bls12381G2Key2020:
+			keyType = kms.BLS12381G2Type
+		default:
+			return nil, fmt.Errorf("not supported public key type: %s", vm.Type)
+		}
+	default:
+		return nil, fmt.Errorf("keyType option is not a kms.KeyType")
+	}
+
+	jwk, err := jose.PubKeyBytesToJWK(vm.Value, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create jwk from public key: %w", err)
+	}
+
+	return jwk, nil
+}
+
+// publicJWK strips the private key material from the JWK.
+func publicJWK(jwk *jose.JWK) (*jose.JWK, error) {
+	var pubKey interface{}
+
+	switch key := jwk.Key.(type) {
+	case *ecdsa.PrivateKey:
+		pubKey = &key.PublicKey
+	case ed25519.PrivateKey:
+		pubKey = key.Public()
+	default:
+		return jwk, nil
+	}
+
+	pub, err := jose.JWKFromKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("create public jwk: %w", err)
+	}
+
+	pub.KeyID = jwk.KeyID
+	pub.Use = jwk.Use
+
+	return pub, nil
+}
+
+// createDoc creates the did:jwk document with a single JsonWebKey2020 verification method. Depending on the "use"
+// of the JWK, the verification method is used for signing, key agreement or both.
+func createDoc(didJWK string, jwk *jose.JWK) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+vmFragment, jsonWebKey2020, didJWK, jwk)
+	if err != nil {
+		return nil, fmt.Errorf("create verification method: %w", err)
+	}
+
+	doc := &did.Doc{
+		Context:            []string{did.ContextV1, jsonWebKey2020Context},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	if jwk.Use != "enc" && jwk.Crv != "X25519" {
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+	}
+
+	if jwk.Use != "sig" && jwk.Crv != "Ed25519" {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
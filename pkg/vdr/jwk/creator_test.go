@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("create from JWK", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		// a private JWK must only contribute its public key to the did.
+		jwk, err := jose.JWKFromKey(privKey)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key1", jsonWebKey2020, "", jwk)
+		require.NoError(t, err)
+
+		v := New()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "P-256", doc.VerificationMethod[0].JSONWebKey().Crv)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		resolvedBytes, err := resolved.DIDDocument.JSONBytes()
+		require.NoError(t, err)
+		require.Equal(t, docBytes, resolvedBytes)
+		require.NotContains(t, string(docBytes), `"d":`)
+	})
+
+	t.Run("create from kms key", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		v := New()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", ed25519VerificationKey2018, "", pubKey),
+		}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Empty(t, doc.KeyAgreement)
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		docResolution, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "", "", elliptic.Marshal(ecKey.Curve, ecKey.X, ecKey.Y)),
+		}}, vdrapi.WithOption(KeyType, kms.ECDSAP384TypeIEEEP1363))
+		require.NoError(t, err)
+		require.Equal(t, "P-384", docResolution.DIDDocument.VerificationMethod[0].JSONWebKey().Crv)
+	})
+
+	t.Run("create failure", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Create(&did.Doc{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method is empty")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "unknown", "", []byte("key")),
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported public key type: unknown")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "", "", []byte("key")),
+		}}, vdrapi.WithOption(KeyType, 1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "keyType option is not a kms.KeyType")
+	})
+}
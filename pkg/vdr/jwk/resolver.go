@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Read expands did:jwk value to a DID document.
+func (v *VDR) Read(didJWK string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID document: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s: %w", parsed.Method, vdrapi.ErrInvalidDID)
+	}
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	var fields map[string]interface{}
+
+	if err = json.Unmarshal(jwkBytes, &fields); err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	if _, ok := fields["d"]; ok {
+		return nil, fmt.Errorf("jwk vdr Read: did:jwk must not contain a private key: %w", vdrapi.ErrInvalidDID)
+	}
+
+	jwk := &jose.JWK{}
+
+	if err = jwk.UnmarshalJSON(jwkBytes); err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	doc, err := createDoc(didJWK, jwk)
+	if err != nil {
+		return nil, fmt.Errorf("creating did document from jwk failed: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
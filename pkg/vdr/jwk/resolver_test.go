@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	didP256   = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9" //nolint:lll
+	didX25519 = "did:jwk:eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ1c2UiOiJlbmMiLCJ4IjoiM3A3YmZYdDl3YlRUVzJIQzdPUTFOei1EUThoYmVHZE5yZngtRkctSUswOCJ9"                                                 //nolint:lll
+)
+
+func TestRead(t *testing.T) {
+	t.Run("resolve P-256 did:jwk", func(t *testing.T) {
+		v := New()
+
+		docResolution, err := v.Read(didP256)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didP256, doc.ID)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didP256+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, didP256, doc.VerificationMethod[0].Controller)
+		require.Equal(t, "P-256", doc.VerificationMethod[0].JSONWebKey().Crv)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+	})
+
+	t.Run("resolve encryption only did:jwk", func(t *testing.T) {
+		v := New()
+
+		docResolution, err := v.Read(didX25519)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Empty(t, doc.Authentication)
+		require.Empty(t, doc.AssertionMethod)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, didX25519+"#0", doc.KeyAgreement[0].VerificationMethod.ID)
+	})
+
+	t.Run("invalid did:jwk", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Read("did:key:abc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:jwk method: key")
+
+		_, err = v.Read("did:jwk:!!")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte("not json")))
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"OKP","crv":"Ed25519",`+
+			`"x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "must not contain a private key")
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	schemaResV1                     = "https://w3id.org/did-resolution/v1"
+	ed25519VerificationKey2018      = "Ed25519VerificationKey2018"
+	ecdsaSecp256k1VerificationKey19 = "EcdsaSecp256k1VerificationKey2019"
+
+	// defaultEIP155Network is Ethereum mainnet.
+	defaultEIP155Network = EIP155Namespace + ":1"
+	// defaultSolanaNetwork is Solana mainnet.
+	defaultSolanaNetwork = SolanaNamespace + ":4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ"
+)
+
+// Create creates a did:pkh DID document for the blockchain account of a key.
+// didDoc must contain a verification method which is either a JsonWebKey2020 with a JWK or holds the raw public
+// key bytes of a kms key, in which case the kms key type is taken from the KeyType option or derived from the
+// verification method type. secp256k1 keys create eip155 (Ethereum) accounts and Ed25519 keys create solana
+// accounts, on the blockchain of the NetworkOpt option or on mainnet by default.
+//
+// The created document is the resolved document of the new DID, which contains the JWK only if it can be derived
+// from the account (solana). eip155 accounts are verified by public key recovery.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	createDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(createDIDOpts)
+	}
+
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	jwk, err := publicKeyJWK(&didDoc.VerificationMethod[0], createDIDOpts.Values[KeyType])
+	if err != nil {
+		return nil, err
+	}
+
+	network, _ := createDIDOpts.Values[NetworkOpt].(string) // nolint: errcheck
+
+	accountID, err := createAccountID(jwk, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Read(fmt.Sprintf("did:%s:%s", DIDMethod, accountID))
+}
+
+// createAccountID creates the CAIP-10 account id of the key on the network.
+func createAccountID(jwk *jose.JWK, network string) (string, error) {
+	var (
+		namespace, address string
+	)
+
+	switch key := jwk.Key.(type) {
+	case ed25519.PublicKey:
+		namespace, address = SolanaNamespace, base58.Encode(key)
+
+		if network == "" {
+			network = defaultSolanaNetwork
+		}
+	case *ecdsa.PublicKey:
+		if key.Curve != btcec.S256() {
+			return "", fmt.Errorf("not supported curve %s", key.Curve.Params().Name)
+		}
+
+		namespace, address = EIP155Namespace, ethereumAddress(key)
+
+		if network == "" {
+			network = defaultEIP155Network
+		}
+	default:
+		return "", fmt.Errorf("not supported key type %T", jwk.Key)
+	}
+
+	if !strings.HasPrefix(network, namespace+":") {
+		return "", fmt.Errorf("network %s does not match the %s key of the account", network, namespace)
+	}
+
+	return network + ":" + address, nil
+}
+
+// ethereumAddress returns the EIP-55 mixed-case checksum encoded address of the secp256k1 public key.
+func ethereumAddress(key *ecdsa.PublicKey) string {
+	pubKey := (*btcec.PublicKey)(key).SerializeUncompressed()
+
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(pubKey[1:]) // nolint: errcheck
+
+	return "0x" + checksumAddress(hex.EncodeToString(h.Sum(nil)[12:]))
+}
+
+// checksumAddress applies the EIP-55 checksum to the lower case hex address.
+func checksumAddress(address string) string {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write([]byte(address)) // nolint: errcheck
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	checksummed := []byte(address)
+
+	for i, c := range checksummed {
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	return string(checksummed)
+}
+
+// publicKeyJWK returns the public JWK of the verification method.
+func publicKeyJWK(vm *did.VerificationMethod, keyTypeOpt interface{}) (*jose.JWK, error) {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		switch key := jwk.Key.(type) {
+		case *ecdsa.PrivateKey:
+			return jose.JWKFromKey(&key.PublicKey)
+		case ed25519.PrivateKey:
+			return jose.JWKFromKey(key.Public())
+		}
+
+		return jwk, nil
+	}
+
+	var keyType kms.KeyType
+
+	switch kt := keyTypeOpt.(type) {
+	case kms.KeyType:
+		keyType = kt
+	case string:
+		keyType = kms.KeyType(kt)
+	case nil:
+		switch vm.Type {
+		case ed25519VerificationKey2018:
+			keyType = kms.ED25519Type
+		case ecdsaSecp256k1VerificationKey19:
+			keyType = kms.ECDSASecp256k1TypeIEEEP1363
+		default:
+			return nil, fmt.Errorf("not supported public key type: %s", vm.Type)
+		}
+	default:
+		return nil, fmt.Errorf("keyType option is not a kms.KeyType")
+	}
+
+	jwk, err := jose.PubKeyBytesToJWK(vm.Value, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create jwk from public key: %w", err)
+	}
+
+	return jwk, nil
+}
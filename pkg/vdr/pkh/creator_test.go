@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("create eip155 account from JWK", func(t *testing.T) {
+		// the address of the secp256k1 private key 1 is a well known test vector.
+		privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), big.NewInt(1).Bytes())
+
+		jwk, err := jose.JWKFromKey(privKey.PubKey().ToECDSA())
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key1", jsonWebKey2020, "", jwk)
+		require.NoError(t, err)
+
+		v := New()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+		require.Equal(t, "did:pkh:eip155:1:0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", docResolution.DIDDocument.ID)
+
+		docResolution, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}},
+			vdrapi.WithOption(NetworkOpt, "eip155:137"))
+		require.NoError(t, err)
+		require.Equal(t, "did:pkh:eip155:137:0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			docResolution.DIDDocument.ID)
+	})
+
+	t.Run("create eip155 account from kms key", func(t *testing.T) {
+		privKey, err := btcec.NewPrivateKey(btcec.S256())
+		require.NoError(t, err)
+
+		v := New()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "", "", privKey.PubKey().SerializeCompressed()),
+		}}, vdrapi.WithOption(KeyType, kms.ECDSASecp256k1TypeIEEEP1363))
+		require.NoError(t, err)
+		require.Equal(t, ecdsaSecp256k1RecoveryMethod2020, docResolution.DIDDocument.VerificationMethod[0].Type)
+	})
+
+	t.Run("create solana account", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		v := New()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", ed25519VerificationKey2018, "", pubKey),
+		}})
+		require.NoError(t, err)
+		require.Equal(t, "did:pkh:"+defaultSolanaNetwork+":"+base58.Encode(pubKey), docResolution.DIDDocument.ID)
+		require.Equal(t, []byte(pubKey), docResolution.DIDDocument.VerificationMethod[0].Value)
+	})
+
+	t.Run("create failure", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Create(&did.Doc{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method is empty")
+
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", ed25519VerificationKey2018, "", pubKey),
+		}}, vdrapi.WithOption(NetworkOpt, defaultEIP155Network))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the solana key of the account")
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("", "", "", elliptic.Marshal(ecKey.Curve, ecKey.X, ecKey.Y)),
+		}}, vdrapi.WithOption(KeyType, kms.ECDSAP256TypeIEEEP1363))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported curve P-256")
+	})
+}
+
+func TestChecksumAddress(t *testing.T) {
+	require.Equal(t, "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		checksumAddress("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	require.Equal(t, "fB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		checksumAddress("fb6916095ca1df60bb79ce92ce3ea74c37c5d359"))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	jsonWebKey2020                   = "JsonWebKey2020"
+	jsonWebKey2020Context            = "https://w3id.org/security/suites/jws-2020/v1"
+	ecdsaSecp256k1RecoveryMethod2020 = "EcdsaSecp256k1RecoveryMethod2020"
+	secp256k1RecoveryContext         = "https://w3id.org/security/suites/secp256k1recovery-2020/v2"
+	blockchainAccountIDContext       = "https://w3id.org/security/v3-unstable"
+
+	blockchainAccountIDFragment = "#blockchainAccountId"
+	controllerFragment          = "#controller"
+
+	accountIDParts = 3
+)
+
+var (
+	// CAIP-10 account id, https://github.com/ChainAgnostic/CAIPs/blob/master/CAIPs/caip-10.md.
+	accountIDRegexp = regexp.MustCompile(`^[-a-z0-9]{3,8}:[-_a-zA-Z0-9]{1,32}:[-.%a-zA-Z0-9]{1,128}$`) //nolint:gochecknoglobals
+	eip155Address   = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)                                        //nolint:gochecknoglobals
+)
+
+// Read expands did:pkh value to a DID document.
+func (v *VDR) Read(didPKH string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didPKH)
+	if err != nil {
+		return nil, fmt.Errorf("pkh vdr Read: failed to parse DID document: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("pkh vdr Read: invalid did:pkh method: %s: %w", parsed.Method, vdrapi.ErrInvalidDID)
+	}
+
+	accountID := parsed.MethodSpecificID
+	if !accountIDRegexp.MatchString(accountID) {
+		return nil, fmt.Errorf("pkh vdr Read: invalid did:pkh account id: %s: %w", accountID, vdrapi.ErrInvalidDID)
+	}
+
+	parts := strings.SplitN(accountID, ":", accountIDParts)
+
+	var doc *did.Doc
+
+	switch parts[0] {
+	case EIP155Namespace:
+		doc, err = createEIP155Doc(didPKH, accountID, parts[2])
+	case SolanaNamespace:
+		doc, err = createSolanaDoc(didPKH, accountID, parts[2])
+	default:
+		return nil, fmt.Errorf("pkh vdr Read: not supported blockchain namespace %s: %w", parts[0],
+			vdrapi.ErrMethodNotSupported)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("pkh vdr Read: %s: %w", err, vdrapi.ErrInvalidDID)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// createEIP155Doc creates the document of an Ethereum account. As the address is a hash of the public key,
+// the verification method identifies the account and the public key is recovered from signatures.
+func createEIP155Doc(didPKH, accountID, address string) (*did.Doc, error) {
+	if !eip155Address.MatchString(address) {
+		return nil, fmt.Errorf("invalid eip155 address %s", address)
+	}
+
+	vm := &did.VerificationMethod{
+		ID:                  didPKH + blockchainAccountIDFragment,
+		Type:                ecdsaSecp256k1RecoveryMethod2020,
+		Controller:          didPKH,
+		BlockchainAccountID: accountID,
+	}
+
+	return createDoc(didPKH, []string{did.ContextV1, secp256k1RecoveryContext, blockchainAccountIDContext}, vm), nil
+}
+
+// createSolanaDoc creates the document of a Solana account, whose address is the base58 encoded Ed25519 public key.
+func createSolanaDoc(didPKH, accountID, address string) (*did.Doc, error) {
+	pubKey := base58.Decode(address)
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid solana address %s", address)
+	}
+
+	jwk, err := jose.JWKFromKey(ed25519.PublicKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := did.NewVerificationMethodFromJWK(didPKH+controllerFragment, jsonWebKey2020, didPKH, jwk)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.BlockchainAccountID = accountID
+
+	return createDoc(didPKH, []string{did.ContextV1, jsonWebKey2020Context, blockchainAccountIDContext}, vm), nil
+}
+
+func createDoc(didPKH string, context []string, vm *did.VerificationMethod) *did.Doc {
+	return &did.Doc{
+		Context:            context,
+		ID:                 didPKH,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	didEIP155 = "did:pkh:eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a"
+	didSolana = "did:pkh:solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:CKg5d12Jhpej1JqtmxLJgaFqqeYjxgPqToJ4LBdvG9Ev"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("resolve eip155 account", func(t *testing.T) {
+		v := New()
+
+		docResolution, err := v.Read(didEIP155)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didEIP155, doc.ID)
+		require.Equal(t, didEIP155+"#blockchainAccountId", doc.VerificationMethod[0].ID)
+		require.Equal(t, ecdsaSecp256k1RecoveryMethod2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, "eip155:1:0xb9c5714089478a327f09197987f16f9e5d936e8a",
+			doc.VerificationMethod[0].BlockchainAccountID)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := did.ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, doc.VerificationMethod[0].BlockchainAccountID, parsed.VerificationMethod[0].BlockchainAccountID)
+	})
+
+	t.Run("resolve solana account", func(t *testing.T) {
+		v := New()
+
+		docResolution, err := v.Read(didSolana)
+		require.NoError(t, err)
+
+		vm := docResolution.DIDDocument.VerificationMethod[0]
+		require.Equal(t, didSolana+"#controller", vm.ID)
+		require.Equal(t, jsonWebKey2020, vm.Type)
+		require.Equal(t, "Ed25519", vm.JSONWebKey().Crv)
+		require.Len(t, vm.Value, 32)
+	})
+
+	t.Run("invalid did:pkh", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Read("did:key:abc")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+
+		_, err = v.Read("did:pkh:eip155:0xb9c5714089478a327f09197987f16f9e5d936e8a")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Contains(t, err.Error(), "invalid did:pkh account id")
+
+		_, err = v.Read("did:pkh:eip155:1:0xb9c571")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Contains(t, err.Error(), "invalid eip155 address")
+
+		_, err = v.Read("did:pkh:solana:4sGjMW1sUnHzSxGspuhpqLDx6wiyjNtZ:abc")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Contains(t, err.Error(), "invalid solana address")
+
+		_, err = v.Read("did:pkh:bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6")
+		require.True(t, errors.Is(err, vdrapi.ErrMethodNotSupported))
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"fmt"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// DIDMethod did method.
+	DIDMethod = "pkh"
+	// NetworkOpt option of the CAIP-2 blockchain id (e.g. "eip155:1") to create the did:pkh account on.
+	NetworkOpt = "network"
+	// KeyType option of kms.KeyType to create a did:pkh from the raw public key bytes (Value) of a kms key.
+	KeyType = "keyType"
+
+	// EIP155Namespace is the CAIP-2 namespace of Ethereum (EVM) chains, for secp256k1 keys.
+	EIP155Namespace = "eip155"
+	// SolanaNamespace is the CAIP-2 namespace of Solana chains, for Ed25519 keys.
+	SolanaNamespace = "solana"
+)
+
+// VDR implements did:pkh method support (https://github.com/w3c-ccg/did-pkh/blob/main/did-pkh-method-draft.md).
+type VDR struct{}
+
+// New returns new instance of VDR that works with did:pkh method.
+func New() *VDR {
+	return &VDR{}
+}
+
+// Accept accepts did:pkh method.
+func (v *VDR) Accept(method string) bool {
+	return method == DIDMethod
+}
+
+// Close frees resources being maintained by VDR.
+func (v *VDR) Close() error {
+	return nil
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
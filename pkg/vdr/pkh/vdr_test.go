@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVDR(t *testing.T) {
+	v := New()
+	require.True(t, v.Accept(DIDMethod))
+	require.False(t, v.Accept("key"))
+	require.NoError(t, v.Close())
+	require.EqualError(t, v.Update(nil), "not supported")
+	require.EqualError(t, v.Deactivate("did:pkh:eip155:1:0x0"), "not supported")
+}
@@ -38,8 +38,19 @@ func New(opts ...Option) *Registry {
 	return baseVDR
 }
 
-// Resolve did document.
+// Resolve did document. The DID may carry versionId and versionTime query parameters, which are passed on
+// to the did method as vdrapi.VersionIDOpt and vdrapi.VersionTimeOpt options.
 func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	if strings.Contains(did, "?") {
+		didURL, err := diddoc.ParseDIDURL(did)
+		if err != nil {
+			return nil, fmt.Errorf("parse did url: %s: %w", err, vdrapi.ErrInvalidDID)
+		}
+
+		did = didURL.DID.String()
+		opts = append(versionOpts(didURL), opts...)
+	}
+
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
 		return nil, err
@@ -61,6 +72,10 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
+	if didDocResolution != nil && didDocResolution.ResolutionMetadata == nil {
+		didDocResolution.ResolutionMetadata = &diddoc.ResolutionMetadata{ContentType: didLDJSONContentType}
+	}
+
 	return didDocResolution, nil
 }
 
@@ -150,7 +165,7 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("did method %s not supported for vdr", method)
+	return nil, fmt.Errorf("did method %s not supported for vdr: %w", method, vdrapi.ErrMethodNotSupported)
 }
 
 // WithVDR adds did method implementation for store.
@@ -183,7 +198,7 @@ func GetDidMethod(didID string) (string, error) {
 
 	didParts := strings.Split(didID, ":")
 	if len(didParts) < numPartsDID {
-		return "", fmt.Errorf("wrong format did input: %s", didID)
+		return "", fmt.Errorf("wrong format did input: %s: %w", didID, vdrapi.ErrInvalidDID)
 	}
 
 	return didParts[1], nil
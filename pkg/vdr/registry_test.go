@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -108,6 +109,41 @@ func TestRegistry_Resolve(t *testing.T) {
 		_, err := registry.Resolve("1:id:123")
 		require.NoError(t, err)
 	})
+
+	t.Run("test resolution error codes", func(t *testing.T) {
+		_, err := New().Resolve("id")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+		require.Equal(t, did.ResolutionErrorInvalidDID, vdrapi.ResolutionErrorCode(err))
+
+		_, err = New().Resolve("did:id:123")
+		require.True(t, errors.Is(err, vdrapi.ErrMethodNotSupported))
+		require.Equal(t, did.ResolutionErrorMethodNotSupported, vdrapi.ResolutionErrorCode(err))
+	})
+
+	t.Run("test version query", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+				for _, opt := range opts {
+					opt(didOpts)
+				}
+
+				require.Equal(t, "did:example:123", didID)
+				require.Equal(t, "2", didOpts.Values[vdrapi.VersionIDOpt])
+				require.Equal(t, "2021-05-10T17:00:00Z", didOpts.Values[vdrapi.VersionTimeOpt])
+
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}))
+
+		docResolution, err := registry.Resolve("did:example:123?versionId=2&versionTime=2021-05-10T17:00:00Z")
+		require.NoError(t, err)
+		require.Equal(t, "application/did+ld+json", docResolution.ResolutionMetadata.ContentType)
+
+		_, err = registry.Resolve("example:123?versionId=2")
+		require.True(t, errors.Is(err, vdrapi.ErrInvalidDID))
+	})
 }
 
 func TestRegistry_Update(t *testing.T) {
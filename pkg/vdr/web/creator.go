@@ -8,12 +8,150 @@ package web
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
-// Create creates a did:web diddoc (unsupported at the moment).
+const (
+	// DomainOpt is the create option for the domain (optionally including a port) hosting the did:web document.
+	DomainOpt = "domain"
+	// PathOpt is the create option for the optional path of the did:web document, e.g. "user/alice".
+	PathOpt = "path"
+
+	schemaResV1 = "https://w3id.org/did-resolution/v1"
+)
+
+// Create creates a did:web diddoc.
+// The did is taken from didDoc.ID or built from the DomainOpt and PathOpt options. The didDoc must contain at least
+// one verification method, typically built from a kms public key, which is used for authentication, assertion,
+// capability invocation and capability delegation. Key agreement methods and services of didDoc are kept.
+// When the VDR has a document directory, the ready-to-host did.json is written to it.
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
+	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(didOpts)
+	}
+
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("error building did:web did doc --> verification method is empty")
+	}
+
+	didID, err := didWebID(didDoc, didOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error building did:web did doc --> %w", err)
+	}
+
+	doc := buildDoc(didID, didDoc)
+
+	if v.documentDir != "" {
+		file, e := documentFile(v.documentDir, didID)
+		if e != nil {
+			return nil, fmt.Errorf("error building did:web did doc --> %w", e)
+		}
+
+		if fileExists(file) || fileExists(file+deactivatedSuffix) {
+			return nil, fmt.Errorf("error building did:web did doc --> document for %s already exists", didID)
+		}
+
+		if e = writeDocument(file, doc); e != nil {
+			return nil, e
+		}
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+func didWebID(didDoc *did.Doc, didOpts *vdrapi.DIDMethodOpts) (string, error) {
+	if didDoc.ID != "" {
+		if !strings.HasPrefix(didDoc.ID, didWebPrefix) {
+			return "", fmt.Errorf("invalid did:web did %s", didDoc.ID)
+		}
+
+		if _, _, err := parseDIDWeb(didDoc.ID); err != nil {
+			return "", err
+		}
+
+		return didDoc.ID, nil
+	}
+
+	domain, ok := didOpts.Values[DomainOpt].(string)
+	if !ok || domain == "" {
+		return "", fmt.Errorf("either did doc id or %s option is required", DomainOpt)
+	}
+
+	var path []string
+
+	if p, ok := didOpts.Values[PathOpt].(string); ok {
+		path = append(path, p)
+	}
+
+	return createDIDWeb(domain, path...), nil
+}
+
+func buildDoc(didID string, didDoc *did.Doc) *did.Doc {
+	t := time.Now()
+
+	vms := make([]did.VerificationMethod, len(didDoc.VerificationMethod))
+
+	for i := range didDoc.VerificationMethod {
+		vms[i] = didDoc.VerificationMethod[i]
+		vms[i].ID = absoluteID(didID, vms[i].ID, fmt.Sprintf("key-%d", i+1))
+		vms[i].Controller = didID
+	}
+
+	var authentication, assertion, invocation, delegation, keyAgreement []did.Verification
+
+	for i := range vms {
+		authentication = append(authentication, *did.NewReferencedVerification(&vms[i], did.Authentication))
+		assertion = append(assertion, *did.NewReferencedVerification(&vms[i], did.AssertionMethod))
+		invocation = append(invocation, *did.NewReferencedVerification(&vms[i], did.CapabilityInvocation))
+		delegation = append(delegation, *did.NewReferencedVerification(&vms[i], did.CapabilityDelegation))
+	}
+
+	for i := range didDoc.KeyAgreement {
+		ka := didDoc.KeyAgreement[i].VerificationMethod
+		ka.ID = absoluteID(didID, ka.ID, fmt.Sprintf("key-agreement-%d", i+1))
+		ka.Controller = didID
+
+		keyAgreement = append(keyAgreement, *did.NewEmbeddedVerification(&ka, did.KeyAgreement))
+	}
+
+	services := make([]did.Service, len(didDoc.Service))
+
+	for i := range didDoc.Service {
+		services[i] = didDoc.Service[i]
+		services[i].ID = absoluteID(didID, services[i].ID, fmt.Sprintf("service-%d", i+1))
+	}
+
+	return &did.Doc{
+		Context:              []string{did.ContextV1},
+		ID:                   didID,
+		VerificationMethod:   vms,
+		Authentication:       authentication,
+		AssertionMethod:      assertion,
+		CapabilityInvocation: invocation,
+		CapabilityDelegation: delegation,
+		KeyAgreement:         keyAgreement,
+		Service:              services,
+		Created:              &t,
+		Updated:              &t,
+	}
+}
+
+// absoluteID returns the id as a DID URL of the given did, using the default fragment for empty ids.
+func absoluteID(didID, id, defaultFragment string) string {
+	switch {
+	case id == "":
+		return didID + "#" + defaultFragment
+	case strings.HasPrefix(id, didID+"#"):
+		return id
+	case strings.Contains(id, "#"):
+		return didID + id[strings.Index(id, "#"):]
+	default:
+		return didID + "#" + id
+	}
 }
@@ -7,17 +7,139 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestCreateDID(t *testing.T) {
 	t.Run("test create did failure", func(t *testing.T) {
 		v := New()
-		d, err := v.Create(nil, nil)
+		d, err := v.Create(nil)
+		require.Nil(t, d)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method is empty")
+
+		d, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*newVM(t)}})
+		require.Nil(t, d)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "domain option is required")
+
+		d, err = v.Create(&did.Doc{ID: "did:key:abc", VerificationMethod: []did.VerificationMethod{*newVM(t)}})
 		require.Nil(t, d)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "build not supported in http binding vdr")
+		require.Contains(t, err.Error(), "invalid did:web did")
 	})
+
+	t.Run("test create did from domain and path", func(t *testing.T) {
+		v := New()
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{*newVM(t)},
+			KeyAgreement: []did.Verification{{
+				VerificationMethod: *did.NewVerificationMethodFromBytes("", "X25519KeyAgreementKey2019", "", []byte("ka")),
+				Embedded:           true,
+			}},
+			Service: []did.Service{{Type: "did-communication", ServiceEndpoint: "https://agent.example.com"}},
+		}, vdrapi.WithOption(DomainOpt, "example.com:8443"), vdrapi.WithOption(PathOpt, "/user/alice"))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:web:example.com%3A8443:user:alice", doc.ID)
+		require.Equal(t, doc.ID+"#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, doc.ID, doc.VerificationMethod[0].Controller)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Equal(t, doc.ID+"#key-agreement-1", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#service-1", doc.Service[0].ID)
+	})
+
+	t.Run("test create did writes document", func(t *testing.T) {
+		dir := tempDir(t)
+
+		v := New(WithDocumentDir(dir))
+
+		vm := newVM(t)
+		vm.ID = "#owner"
+
+		docResolution, err := v.Create(&did.Doc{
+			ID:                 "did:web:example.com",
+			VerificationMethod: []did.VerificationMethod{*vm},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com#owner", docResolution.DIDDocument.VerificationMethod[0].ID)
+
+		written, err := readDocument(filepath.Join(dir, "example.com", ".well-known", "did.json"))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com", written.ID)
+		require.Equal(t, vm.Value, written.VerificationMethod[0].Value)
+
+		_, err = v.Create(&did.Doc{
+			ID:                 "did:web:example.com",
+			VerificationMethod: []did.VerificationMethod{*vm},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already exists")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}},
+			vdrapi.WithOption(DomainOpt, "example.com"), vdrapi.WithOption(PathOpt, "user/bob"))
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(dir, "example.com", "user", "bob", "did.json"))
+
+		_, err = v.Create(&did.Doc{
+			ID:                 "did:web:other.example.com",
+			VerificationMethod: []did.VerificationMethod{*vm},
+		})
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(dir, "other.example.com", ".well-known", "did.json"))
+	})
+}
+
+func TestDocumentFile(t *testing.T) {
+	file, err := documentFile("/srv/web", "did:web:example.com:..:..:etc")
+	require.NoError(t, err)
+	require.Equal(t, filepath.FromSlash("/srv/web/example.com/etc/did.json"), file)
+
+	file, err = documentFile("/srv/web", "did:web:Example.com%3A8443:alice")
+	require.NoError(t, err)
+	require.Equal(t, filepath.FromSlash("/srv/web/example.com:8443/alice/did.json"), file)
+
+	_, err = hostFile("/srv/web", "..", "/.well-known/did.json")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid did:web host")
+
+	_, err = documentFile("", "did:web:example.com")
+	require.ErrorIs(t, err, errNoDocumentDir)
+
+	_, err = documentFile("/srv/web", "did:web")
+	require.Error(t, err)
+}
+
+func newVM(t *testing.T) *did.VerificationMethod {
+	t.Helper()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return did.NewVerificationMethodFromBytes("", "Ed25519VerificationKey2018", "", pubKey)
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "didweb")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	return dir
 }
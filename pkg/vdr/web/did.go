@@ -17,6 +17,7 @@ import (
 const (
 	defaultPath  = "/.well-known/did.json"
 	documentPath = "/did.json"
+	didWebPrefix = "did:" + namespace + ":"
 )
 
 // parseDIDWeb consumes a did:web identifier and returns the URL location of the did Doc.
@@ -46,3 +47,34 @@ func parseDIDWeb(id string) (string, string, error) {
 
 	return address, host, nil
 }
+
+// createDIDWeb builds a did:web identifier for the given domain (which may include a port) and optional path.
+func createDIDWeb(domain string, path ...string) string {
+	components := []string{url.QueryEscape(domain)}
+
+	for _, p := range path {
+		for _, c := range strings.Split(strings.Trim(p, "/"), "/") {
+			if c != "" {
+				components = append(components, url.QueryEscape(c))
+			}
+		}
+	}
+
+	return didWebPrefix + strings.Join(components, ":")
+}
+
+// documentFilePath returns the host of the did:web did and the slash separated location of its document relative
+// to the web root of that host, e.g. ".well-known/did.json" or "user/alice/did.json".
+func documentFilePath(id string) (string, string, error) {
+	address, _, err := parseDIDWeb(id)
+	if err != nil {
+		return "", "", err
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing did:web document address: %w", err)
+	}
+
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
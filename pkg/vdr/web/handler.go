@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const didJSONContentType = "application/did+json"
+
+// NewDocumentHandler returns an http.Handler hosting the did:web documents written to the given document directory
+// (see WithDocumentDir). It serves GET requests for /.well-known/did.json and /<path>/did.json from the documents of
+// the requested host. Documents marked as deactivated are served with status 410 (Gone).
+func NewDocumentHandler(documentDir string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if !IsDocumentPath(r.URL.Path) {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		file, err := hostFile(documentDir, r.Host, r.URL.Path)
+		if err != nil {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		status := http.StatusOK
+
+		docBytes, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			docBytes, err = ioutil.ReadFile(filepath.Clean(file + deactivatedSuffix))
+			if err != nil {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			status = http.StatusGone
+		}
+
+		w.Header().Set("Content-Type", didJSONContentType)
+		w.WriteHeader(status)
+
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		if _, err = w.Write(docBytes); err != nil {
+			logger.Errorf("failed to write did:web document: %v", err)
+		}
+	})
+}
+
+// IsDocumentPath checks whether the URL path is the location of a did:web document.
+func IsDocumentPath(urlPath string) bool {
+	return strings.HasSuffix(urlPath, documentPath)
+}
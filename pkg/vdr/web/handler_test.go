@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	urlapi "net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+func TestDocumentHandler(t *testing.T) {
+	dir := tempDir(t)
+
+	s := httptest.NewTLSServer(NewDocumentHandler(dir))
+	defer s.Close()
+
+	domain := strings.TrimPrefix(s.URL, "https://")
+	v := New(WithDocumentDir(dir))
+
+	t.Run("host created document", func(t *testing.T) {
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*newVM(t)}},
+			vdrapi.WithOption(DomainOpt, domain), vdrapi.WithOption(PathOpt, "alice"))
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("did:web:%s:alice", urlapi.QueryEscape(domain)), docResolution.DIDDocument.ID)
+
+		resolved, err := v.Read(docResolution.DIDDocument.ID, vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.ID, resolved.DIDDocument.ID)
+		require.False(t, resolved.DocumentMetadata.Deactivated)
+
+		require.NoError(t, v.Deactivate(docResolution.DIDDocument.ID, vdrapi.WithOption(MarkDeactivatedOpt, true)))
+
+		resolved, err = v.Read(docResolution.DIDDocument.ID, vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.NoError(t, err)
+		require.True(t, resolved.DocumentMetadata.Deactivated)
+	})
+
+	t.Run("document not found", func(t *testing.T) {
+		_, err := v.Read(fmt.Sprintf("did:web:%s:bob", urlapi.QueryEscape(domain)),
+			vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		resp, err := s.Client().Get(s.URL + "/alice/other.json")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("document of another host not served", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*newVM(t)}},
+			vdrapi.WithOption(DomainOpt, "other.example.com"), vdrapi.WithOption(PathOpt, "carol"))
+		require.NoError(t, err)
+
+		resp, err := s.Client().Get(s.URL + "/carol/did.json")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp, err := s.Client().Post(s.URL+"/.well-known/did.json", "application/json", nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
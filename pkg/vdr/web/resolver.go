@@ -55,7 +55,11 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 
 	defer closeResponseBody(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusGone:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("http server returned status code [%d]: %w", resp.StatusCode, vdrapi.ErrNotFound)
+	default:
 		return nil, fmt.Errorf("http server returned status code [%d]", resp.StatusCode)
 	}
 
@@ -66,10 +70,18 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 
 	doc, err := did.ParseDocument(body)
 	if err != nil {
+		if resp.StatusCode == http.StatusGone {
+			return nil, fmt.Errorf("error resolving did:web did --> %w", vdrapi.ErrDeactivated)
+		}
+
 		return nil, fmt.Errorf("error resolving did:web did --> error parsing did doc --> %w", err)
 	}
 
-	return &did.DocResolution{DIDDocument: doc}, nil
+	// documents marked as deactivated are served with 410 (Gone).
+	return &did.DocResolution{
+		DIDDocument:      doc,
+		DocumentMetadata: &did.DocumentMetadata{Deactivated: resp.StatusCode == http.StatusGone},
+	}, nil
 }
 
 func closeResponseBody(respBody io.Closer) {
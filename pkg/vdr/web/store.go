@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+const (
+	// deactivatedSuffix is appended to the file name of documents marked as deactivated.
+	deactivatedSuffix = ".deactivated"
+
+	documentFileMode = 0o644
+	documentDirMode  = 0o755
+)
+
+// errNoDocumentDir is returned by operations which need a document directory when none is configured.
+var errNoDocumentDir = errors.New("did:web document directory not configured")
+
+// documentFile returns the file in the document directory that hosts the document of the given did:web did.
+// Documents are laid out per host, so that the dids of different domains cannot overwrite each other's documents.
+func documentFile(documentDir, id string) (string, error) {
+	if documentDir == "" {
+		return "", errNoDocumentDir
+	}
+
+	host, p, err := documentFilePath(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hostFile(documentDir, host, p)
+}
+
+// hostFile returns the file in the document directory that hosts the given URL path of the host.
+func hostFile(documentDir, host, urlPath string) (string, error) {
+	host = strings.ToLower(host)
+
+	if host == "" || host == "." || host == ".." || strings.ContainsAny(host, `/\`) {
+		return "", fmt.Errorf("invalid did:web host %q", host)
+	}
+
+	// clean the path as rooted so that it cannot escape the directory of the host.
+	return filepath.Join(documentDir, host, filepath.FromSlash(path.Clean("/"+urlPath))), nil
+}
+
+// writeDocument writes the did document to the file atomically, so that a concurrent reader (such as the
+// document handler) always sees either the previous or the new version of the document.
+func writeDocument(file string, doc *did.Doc) error {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("marshal did:web document: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(file), documentDirMode); err != nil {
+		return fmt.Errorf("create did:web document directory: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temporary did:web document: %w", err)
+	}
+
+	defer func() {
+		// no-op once the temporary file has been renamed.
+		_ = os.Remove(tmp.Name()) // nolint: errcheck
+	}()
+
+	if _, err = tmp.Write(docBytes); err != nil {
+		_ = tmp.Close() // nolint: errcheck
+
+		return fmt.Errorf("write did:web document: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close() // nolint: errcheck
+
+		return fmt.Errorf("sync did:web document: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close did:web document: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), documentFileMode); err != nil {
+		return fmt.Errorf("set did:web document permissions: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("replace did:web document: %w", err)
+	}
+
+	return nil
+}
+
+// readDocument reads the did document from the file.
+func readDocument(file string) (*did.Doc, error) {
+	docBytes, err := ioutil.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return did.ParseDocument(docBytes)
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+
+	return err == nil
+}
@@ -8,6 +8,8 @@ package web
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -15,14 +17,38 @@ import (
 
 const (
 	namespace = "web"
+
+	// MarkDeactivatedOpt is the Deactivate option which, when true, keeps the document marked as deactivated
+	// instead of removing it. Hosted marked documents are served with HTTP status 410 (Gone).
+	MarkDeactivatedOpt = "markDeactivated"
 )
 
 // VDR implements the VDR interface.
-type VDR struct{}
+type VDR struct {
+	documentDir string
+}
+
+// Option configures the did:web vdr.
+type Option func(opts *VDR)
 
 // New creates a new VDR struct.
-func New() *VDR {
-	return &VDR{}
+func New(opts ...Option) *VDR {
+	v := &VDR{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// WithDocumentDir sets the directory to which created did:web documents are written, ready to be hosted.
+// Documents are written to <dir>/<host>/.well-known/did.json or, for did:web dids with a path, to
+// <dir>/<host>/<path>/did.json, where <host> includes the port if the did has one.
+func WithDocumentDir(dir string) Option {
+	return func(opts *VDR) {
+		opts.documentDir = dir
+	}
 }
 
 // Accept method of the VDR interface.
@@ -30,14 +56,57 @@ func (v *VDR) Accept(method string) bool {
 	return method == namespace
 }
 
-// Update did doc.
+// Update did doc by atomically rewriting the hosted document.
 func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if didDoc == nil {
+		return fmt.Errorf("update did:web document: did doc is nil")
+	}
+
+	file, err := documentFile(v.documentDir, didDoc.ID)
+	if err != nil {
+		return fmt.Errorf("update did:web document: %w", err)
+	}
+
+	if !fileExists(file) {
+		return fmt.Errorf("update did:web document %s: %w", didDoc.ID, vdrapi.ErrNotFound)
+	}
+
+	updated := time.Now()
+	didDoc.Updated = &updated
+
+	return writeDocument(file, didDoc)
 }
 
-// Deactivate did doc.
+// Deactivate did doc by removing the hosted document or, with the MarkDeactivatedOpt option, by marking it
+// as deactivated.
 func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(didOpts)
+	}
+
+	file, err := documentFile(v.documentDir, did)
+	if err != nil {
+		return fmt.Errorf("deactivate did:web document: %w", err)
+	}
+
+	if !fileExists(file) {
+		return fmt.Errorf("deactivate did:web document %s: %w", did, vdrapi.ErrNotFound)
+	}
+
+	mark, ok := didOpts.Values[MarkDeactivatedOpt].(bool)
+	if ok && mark {
+		err = os.Rename(file, file+deactivatedSuffix)
+	} else {
+		err = os.Remove(file)
+	}
+
+	if err != nil {
+		return fmt.Errorf("deactivate did:web document %s: %w", did, err)
+	}
+
+	return nil
 }
 
 // Close method of the VDR interface.
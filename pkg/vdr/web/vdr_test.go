@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestVDRMethods(t *testing.T) {
@@ -23,19 +28,84 @@ func TestVDRMethods(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	t.Run("test update", func(t *testing.T) {
+	t.Run("test update without document dir", func(t *testing.T) {
 		v := New()
+		err := v.Update(&did.Doc{ID: "did:web:example.com"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document directory not configured")
+	})
+
+	t.Run("test update nil did doc", func(t *testing.T) {
+		v := New(WithDocumentDir(tempDir(t)))
 		err := v.Update(nil)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.Contains(t, err.Error(), "did doc is nil")
+	})
+
+	t.Run("test update", func(t *testing.T) {
+		dir := tempDir(t)
+		v := New(WithDocumentDir(dir))
+
+		err := v.Update(&did.Doc{ID: "did:web:example.com"})
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+
+		docResolution, err := v.Create(&did.Doc{
+			ID:                 "did:web:example.com",
+			VerificationMethod: []did.VerificationMethod{*newVM(t)},
+		})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		doc.Service = []did.Service{{
+			ID: doc.ID + "#agent", Type: "did-communication", ServiceEndpoint: "https://agent.example.com",
+		}}
+
+		require.NoError(t, v.Update(doc))
+
+		written, err := readDocument(filepath.Join(dir, "example.com", ".well-known", "did.json"))
+		require.NoError(t, err)
+		require.Len(t, written.Service, 1)
+		require.Equal(t, "https://agent.example.com", written.Service[0].ServiceEndpoint)
 	})
 }
 
 func TestDeactivate(t *testing.T) {
-	t.Run("test deactivate", func(t *testing.T) {
+	t.Run("test deactivate without document dir", func(t *testing.T) {
 		v := New()
-		err := v.Deactivate("")
+		err := v.Deactivate("did:web:example.com")
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.Contains(t, err.Error(), "document directory not configured")
+	})
+
+	t.Run("test deactivate removes document", func(t *testing.T) {
+		dir := tempDir(t)
+		v := New(WithDocumentDir(dir))
+
+		_, err := v.Create(&did.Doc{
+			ID:                 "did:web:example.com:alice",
+			VerificationMethod: []did.VerificationMethod{*newVM(t)},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, v.Deactivate("did:web:example.com:alice"))
+		require.NoFileExists(t, filepath.Join(dir, "example.com", "alice", "did.json"))
+
+		err = v.Deactivate("did:web:example.com:alice")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("test deactivate marks document", func(t *testing.T) {
+		dir := tempDir(t)
+		v := New(WithDocumentDir(dir))
+
+		_, err := v.Create(&did.Doc{
+			ID:                 "did:web:example.com:alice",
+			VerificationMethod: []did.VerificationMethod{*newVM(t)},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, v.Deactivate("did:web:example.com:alice", vdrapi.WithOption(MarkDeactivatedOpt, true)))
+		require.NoFileExists(t, filepath.Join(dir, "example.com", "alice", "did.json"))
+		require.FileExists(t, filepath.Join(dir, "example.com", "alice", "did.json"+deactivatedSuffix))
 	})
 }
@@ -50,6 +50,9 @@ type ProofOptions struct {
 	// ProofRepresentation is type of proof data expected, (Refer verifiable.SignatureProofValue)
 	// Optional, by default proof will be represented as 'verifiable.SignatureProofValue'.
 	ProofRepresentation *verifiable.SignatureRepresentation `json:"proofRepresentation,omitempty"`
+	// Cryptosuite is cryptographic suite of 'DataIntegrityProof' proof type ('eddsa-rdfc-2022' or 'ecdsa-rdfc-2019').
+	// Required for 'DataIntegrityProof' proof type, ignored otherwise.
+	Cryptosuite string `json:"cryptosuite,omitempty"`
 }
 
 // DeriveOptions model containing options for deriving a credential.
@@ -21,7 +21,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -37,12 +39,15 @@ const (
 	JSONWebSignature2020 = "JsonWebSignature2020"
 	// BbsBlsSignature2020 BBS signature suite.
 	BbsBlsSignature2020 = "BbsBlsSignature2020"
+	// DataIntegrityProof data integrity proof, signature suite is selected by ProofOptions.Cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
 )
 
 // miscellaneous constants.
 const (
-	bbsContext     = "https://w3id.org/security/bbs/v1"
-	emptyRawLength = 4
+	bbsContext           = "https://w3id.org/security/bbs/v1"
+	dataIntegrityContext = "https://w3id.org/security/data-integrity/v1"
+	emptyRawLength       = 4
 )
 
 // proof options.
@@ -687,6 +692,8 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 
 	var signatureSuite signer.SignatureSuite
 
+	signatureRepresentation := *opts.ProofRepresentation
+
 	switch opts.ProofType {
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
@@ -696,14 +703,25 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 		addContext(p, bbsContext)
 
 		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
+	case DataIntegrityProof:
+		addContext(p, dataIntegrityContext)
+
+		// data integrity proofs are always represented by multibase encoded proof value.
+		signatureRepresentation = verifiable.SignatureProofValue
+
+		signatureSuite, err = getDataIntegritySuite(opts.Cryptosuite, s)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported signature type '%s'", opts.ProofType)
 	}
 
 	signingCtx := &verifiable.LinkedDataProofContext{
 		VerificationMethod:      opts.VerificationMethod,
-		SignatureRepresentation: *opts.ProofRepresentation,
+		SignatureRepresentation: signatureRepresentation,
 		SignatureType:           opts.ProofType,
+		Cryptosuite:             opts.Cryptosuite,
 		Suite:                   signatureSuite,
 		Created:                 opts.Created,
 		Domain:                  opts.Domain,
@@ -719,6 +737,17 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 	return nil
 }
 
+func getDataIntegritySuite(cryptosuite string, s *kmsSigner) (signer.SignatureSuite, error) {
+	switch cryptosuite {
+	case eddsardfc2022.Cryptosuite:
+		return eddsardfc2022.New(suite.WithSigner(s)), nil
+	case ecdsardfc2019.Cryptosuite:
+		return ecdsardfc2019.New(suite.WithSigner(s)), nil
+	default:
+		return nil, fmt.Errorf("unsupported cryptosuite '%s'", cryptosuite)
+	}
+}
+
 func (c *Wallet) validateProofOption(authToken string, opts *ProofOptions, method did.VerificationRelationship) error {
 	if opts == nil || opts.Controller == "" {
 		return errors.New("invalid proof option, 'controller' is required")
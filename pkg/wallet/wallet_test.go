@@ -1235,6 +1235,39 @@ func TestWallet_Issue(t *testing.T) {
 		require.Len(t, result.Proofs, 1)
 	})
 
+	t.Run("Test VC wallet issue DataIntegrityProof using controller - success", func(t *testing.T) {
+		walletInstance, err := New(user, mockctx)
+		require.NotEmpty(t, walletInstance)
+		require.NoError(t, err)
+
+		// unlock wallet
+		authToken, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+		require.NotEmpty(t, authToken)
+
+		defer walletInstance.Close()
+
+		// import keys manually
+		kmgr, err := keyManager().getKeyManger(authToken)
+		require.NoError(t, err)
+		edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+		// nolint: errcheck, gosec
+		kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
+
+		// sign with just controller
+		result, err := walletInstance.Issue(authToken, []byte(sampleUDCVC), &ProofOptions{
+			Controller:  didKey,
+			ProofType:   DataIntegrityProof,
+			Cryptosuite: "eddsa-rdfc-2022",
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, result)
+		require.Len(t, result.Proofs, 1)
+		require.Equal(t, DataIntegrityProof, result.Proofs[0]["type"])
+		require.Equal(t, "eddsa-rdfc-2022", result.Proofs[0]["cryptosuite"])
+		require.Contains(t, result.Context, "https://w3id.org/security/data-integrity/v1")
+	})
+
 	t.Run("Test VC wallet issue using verification method - success", func(t *testing.T) {
 		walletInstance, err := New(user, mockctx)
 		require.NotEmpty(t, walletInstance)
@@ -1470,6 +1503,15 @@ func TestWallet_Issue(t *testing.T) {
 		require.Empty(t, result)
 		require.Contains(t, err.Error(), " unsupported signature type 'invalid'")
 
+		// invalid cryptosuite
+		result, err = walletInstance.Issue(authToken, []byte(sampleUDCVC), &ProofOptions{
+			Controller:  didKey,
+			ProofType:   DataIntegrityProof,
+			Cryptosuite: "invalid",
+		})
+		require.Empty(t, result)
+		require.Contains(t, err.Error(), "unsupported cryptosuite 'invalid'")
+
 		// wrong key type
 		result, err = walletInstance.Issue(authToken, []byte(sampleUDCVC), &ProofOptions{
 			Controller: didKey,
@@ -28,6 +28,16 @@ type Logger interface {
 	Debugf(msg string, args ...interface{})
 }
 
+// FieldLogger is a Logger which also writes structured logs. The loggers returned by a custom LoggerProvider may
+// implement it to receive the key/value fields of the structured logs instead of having them appended to the
+// message text.
+type FieldLogger interface {
+	Logger
+	// Logw writes msg at level with the fields given as alternating keys and values in keysAndValues.
+	// Unlike Panicf and Fatalf, Logw must return for the CRITICAL level.
+	Logw(level Level, msg string, keysAndValues ...interface{})
+}
+
 // LoggerProvider is a factory for moduled loggers.
 type LoggerProvider interface {
 	GetLogger(module string) Logger